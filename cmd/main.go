@@ -2,26 +2,97 @@ package main
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"log/slog"
+	nethttp "net/http"
 	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	paseto "harajuku/backend/internal/adapter/auth"
 	"harajuku/backend/internal/adapter/communication/email"
+	"harajuku/backend/internal/adapter/communication/fcm"
+	"harajuku/backend/internal/adapter/communication/inbox"
+	"harajuku/backend/internal/adapter/communication/sendgrid"
+	"harajuku/backend/internal/adapter/communication/ses"
+	"harajuku/backend/internal/adapter/communication/smtp"
+	"harajuku/backend/internal/adapter/communication/twilio"
+	"harajuku/backend/internal/adapter/communication/webhook"
+	"harajuku/backend/internal/adapter/communication/whatsapp"
 	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/adapter/errorreport/sentry"
+	"harajuku/backend/internal/adapter/event"
+	graphqlhandler "harajuku/backend/internal/adapter/handler/graphql"
+	grpchandler "harajuku/backend/internal/adapter/handler/grpc"
 	"harajuku/backend/internal/adapter/handler/http"
 	"harajuku/backend/internal/adapter/logger"
+	"harajuku/backend/internal/adapter/payment"
+	"harajuku/backend/internal/adapter/scanner/clamav"
 	"harajuku/backend/internal/adapter/storage/awsS3"
+	"harajuku/backend/internal/adapter/storage/cache"
+	"harajuku/backend/internal/adapter/storage/cloudfront"
+	"harajuku/backend/internal/adapter/storage/gcs"
+	"harajuku/backend/internal/adapter/storage/local"
+	"harajuku/backend/internal/adapter/storage/memory"
+	"harajuku/backend/internal/adapter/storage/minio"
 	"harajuku/backend/internal/adapter/storage/postgres"
 	"harajuku/backend/internal/adapter/storage/postgres/repository"
 	"harajuku/backend/internal/adapter/storage/redis"
+	"harajuku/backend/internal/adapter/telemetry"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
 	"harajuku/backend/internal/core/service"
+	"harajuku/backend/internal/core/util"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
+	_ "harajuku/backend/docs"
 )
 
+const usage = `usage: harajuku [command]
+
+commands:
+  serve [--demo]    start the HTTP server (default), --demo keeps the cache, files and emails in memory
+  worker            run the background jobs, emails and scheduled tasks without the HTTP server
+  migrate up        apply all pending migrations
+  migrate down N    roll back the last N migrations
+  migrate version   print the current schema version
+  seed              fill the database with sample data for local development`
+
+// @title						Harajuku API
+// @version					1.0
+// @description				Quotes, appointments and payments of the Harajuku salon
+// @BasePath					/
+// @securityDefinitions.apikey	BearerAuth
+// @in							header
+// @name						Authorization
+// @description				Type "Bearer" followed by a space and the access token
 func main() {
+	// Command, the server doesn't migrate the database so schema changes can be rolled out on their own
+	command := "serve"
+	args := os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "--") {
+		command, args = args[0], args[1:]
+	}
+
+	if command != "serve" && command != "worker" && command != "migrate" && command != "seed" {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	// The demo runs the API without any cloud account, only postgres and redis are needed. The environment
+	// takes precedence over the .env file, so the providers can't be overridden by it
+	demo := command == "serve" && slices.Contains(args, "--demo")
+	if demo {
+		os.Setenv("CACHE_PROVIDER", "memory")
+		os.Setenv("STORAGE_PROVIDER", "memory")
+		os.Setenv("EMAIL_PROVIDER", "memory")
+	}
+
 	// Load environment variables
 	config, err := config.New()
 	if err != nil {
@@ -32,7 +103,18 @@ func main() {
 	// Set logger
 	logger.Set(config.App)
 
-	slog.Info("Starting the application", "app", config.App.Name, "env", config.App.Env)
+	slog.Info("Starting the application", "app", config.App.Name, "env", config.App.Env, "demo", demo)
+
+	// Images are read into memory up to the upload limit
+	util.MaxImageSize = config.HTTP.MaxUploadSize
+
+	// Currency
+	defaultCurrency := domain.Currency(config.App.DefaultCurrency)
+	_, err = domain.ParseExchangeRates(defaultCurrency, config.App.ExchangeRates)
+	if err != nil {
+		slog.Error("Error parsing currency settings", "error", err)
+		os.Exit(1)
+	}
 
 	// Init database
 	ctx := context.Background()
@@ -45,14 +127,77 @@ func main() {
 
 	slog.Info("Successfully connected to the database", "db", config.DB.Connection)
 
-	// Migrate database
-	err = db.Migrate()
+	// Migrate database and exit
+	if command == "migrate" {
+		err = runMigrate(db, args)
+		if err != nil {
+			slog.Error("Error migrating database", "error", err)
+			db.Close()
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Seed database and exit, sample files are always written to the local storage
+	if command == "seed" {
+		err = runSeed(ctx, db, config, defaultCurrency)
+		if err != nil {
+			slog.Error("Error seeding database", "error", err)
+			db.Close()
+			os.Exit(1)
+		}
+		return
+	}
+
+	// SIGINT and SIGTERM stop the background workers and drain the HTTP server, the clients
+	// keep ctx so their connections outlive the signal until they are closed
+	runCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var workers sync.WaitGroup
+	runWorker := func(run func(context.Context)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run(runCtx)
+		}()
+	}
+
+	// The queued jobs, emails and scheduled tasks are run by the worker command, and by the server unless
+	// they are left to dedicated workers
+	runBackground := func(run func(context.Context)) {
+		if command == "worker" || config.Jobs.RunInServer {
+			runWorker(run)
+		}
+	}
+
+	// Tracing, spans are exported to the OTLP collector when one is configured
+	shutdownTracing, err := telemetry.New(ctx, config.Telemetry)
 	if err != nil {
-		slog.Error("Error migrating database", "error", err)
+		slog.Error("Error initializing tracing", "error", err)
 		os.Exit(1)
 	}
+	defer shutdownTracing(context.Background())
+
+	if config.Telemetry.Endpoint != "" {
+		slog.Info("Exporting traces", "endpoint", config.Telemetry.Endpoint, "sample_ratio", config.Telemetry.SampleRatio)
+	}
 
-	slog.Info("Successfully migrated the database")
+	// Error reporting, unexpected errors and panics are sent to Sentry when a DSN is configured
+	reporter, err := sentry.New(config.Sentry)
+	if err != nil {
+		slog.Error("Error initializing error reporting", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		reporter.Flush(flushCtx)
+	}()
+
+	if config.Sentry.DSN != "" {
+		slog.Info("Reporting errors to Sentry", "environment", config.Sentry.Environment)
+	}
 
 	// Init token service
 	token, err := paseto.New(config.Token)
@@ -61,15 +206,48 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Init cache service
-	cache, err := redis.New(ctx, config.Redis)
+	// Init cache service, local development and small deployments can skip redis
+	var cacheProvider port.CacheRepository
+	switch config.Redis.Provider {
+	case "redis":
+		cacheProvider, err = redis.New(ctx, config.Redis)
+		if err != nil {
+			slog.Error("Error initializing cache connection", "error", err)
+			os.Exit(1)
+		}
+	case "memory":
+		cacheProvider = cache.NewMemory(config.Redis.MemorySize)
+	case "none":
+		cacheProvider = cache.NewNoop()
+	default:
+		slog.Error("Unknown cache provider", "provider", config.Redis.Provider)
+		os.Exit(1)
+	}
+
+	// Cached values expire after the TTL configured for their entity, and a cache
+	// outage only loses the cache instead of failing the requests
+	cacheDefaultTTL, err := time.ParseDuration(config.Redis.DefaultTTL)
 	if err != nil {
-		slog.Error("Error initializing cache connection", "error", err)
+		slog.Error("Invalid cache default TTL", "error", err)
 		os.Exit(1)
 	}
-	defer cache.Close()
 
-	slog.Info("Successfully connected to the cache server")
+	cacheTTLs, err := cache.ParseTTLs(config.Redis.TTLs)
+	if err != nil {
+		slog.Error("Invalid cache TTLs", "error", err)
+		os.Exit(1)
+	}
+
+	// Keys are namespaced so environments sharing a redis server don't mix their values
+	cacheNamespace := fmt.Sprintf("%s:%s:v%s:", config.App.Name, config.App.Env, config.Redis.Version)
+	cacheProvider = cache.NewNamespace(cacheProvider, cacheNamespace)
+
+	// Hits, misses, sets and invalidations per key prefix are published on /v1/metrics
+	cacheMetrics := cache.NewMetrics(cacheProvider, expvar.NewMap("cache"))
+	cacheRepo := cache.NewResilient(cache.NewPolicy(cacheMetrics, cacheDefaultTTL, cacheTTLs))
+	defer cacheRepo.Close()
+
+	slog.Info("Using cache provider", "provider", config.Redis.Provider)
 
 	// // Init token service
 	// token, err := paseto.New(config.Token)
@@ -79,79 +257,517 @@ func main() {
 	// }
 
 	// Dependency injection
+	// Audit trail of the sensitive changes, recorded by the services that make them
+	auditRepo := repository.NewAuditRepository(db)
+	auditService := service.NewAuditService(auditRepo)
+	auditHandler := http.NewAuditHandler(auditService)
+
+	// Salon
+	salonService := service.NewSalonService(repository.NewSalonRepository(db), cacheRepo)
+	salonHandler := http.NewSalonHandler(salonService)
+
 	// User
 	userRepo := repository.NewUserRepository(db)
-	userService := service.NewUserService(userRepo, cache)
+	userService := service.NewUserService(userRepo, cacheRepo, auditRepo)
 	userHandler := http.NewUserHandler(userService)
 
 	// Auth
 	authService := service.NewAuthService(userRepo, token)
 	authHandler := http.NewAuthHandler(authService)
 
-	// S3
+	// Background jobs are queued in redis and run by the workers with retries
+	jobQueueRepo, err := redis.NewJobQueue(ctx, config.Redis)
+	if err != nil {
+		slog.Error("Error initializing the job queue", "error", err)
+		os.Exit(1)
+	}
+	defer jobQueueRepo.Close()
+
+	jobRetryDelay, err := time.ParseDuration(config.Jobs.RetryDelay)
+	if err != nil {
+		slog.Error("Invalid job retry delay", "error", err)
+		os.Exit(1)
+	}
+
+	jobPollInterval, err := time.ParseDuration(config.Jobs.PollInterval)
+	if err != nil {
+		slog.Error("Invalid job poll interval", "error", err)
+		os.Exit(1)
+	}
+
+	jobs := service.NewJobQueueService(jobQueueRepo, reporter, config.Jobs.MaxAttempts, jobRetryDelay, jobPollInterval, config.Jobs.Concurrency)
+	jobQueueHandler := http.NewJobQueueHandler(jobs)
+
+	// File storage
+	var fileStorage port.FileRepository
 
-	sess := session.Must(session.NewSession(&aws.Config{
-		Region: aws.String(config.AwsS3.Region),
-	}))
+	switch config.Storage.Provider {
+	case "local":
+		fileStorage, err = local.NewLocal(config.Storage.LocalDir)
+		if err != nil {
+			slog.Error("Error initializing the local file storage", "error", err)
+			os.Exit(1)
+		}
+	case "s3":
+		client, err := awsS3.NewClient(ctx, config.AwsS3)
+		if err != nil {
+			slog.Error("Error initializing the S3 client", "error", err)
+			os.Exit(1)
+		}
 
-	s3 := awsS3.NewAwsS3(sess, config.AwsS3.Bucket)
+		fileStorage = awsS3.NewAwsS3(client, config.AwsS3.Bucket, config.AwsS3.PartSize)
+	case "minio":
+		fileStorage, err = minio.New(ctx, config.Minio)
+		if err != nil {
+			slog.Error("Error initializing the MinIO file storage", "error", err)
+			os.Exit(1)
+		}
+	case "gcs":
+		fileStorage, err = gcs.New(ctx, config.GCS)
+		if err != nil {
+			slog.Error("Error initializing the Google Cloud Storage file storage", "error", err)
+			os.Exit(1)
+		}
+	case "memory":
+		fileStorage = memory.NewFileRepository()
+	default:
+		slog.Error("Unknown storage provider", "provider", config.Storage.Provider)
+		os.Exit(1)
+	}
+
+	// Dependencies the readiness probe checks, the file storage before it gets wrapped
+	readinessChecks := map[string]port.HealthChecker{"postgres": db}
+	if checker, ok := fileStorage.(port.HealthChecker); ok {
+		readinessChecks["storage"] = checker
+	}
+
+	// The orphan file cleanup lists the storage as is, when its provider can list files
+	fileLister, _ := fileStorage.(port.FileLister)
+
+	fileStorage = telemetry.NewTracedFileRepository(fileStorage, config.Storage.Provider)
+	slog.Info("Using file storage", "provider", config.Storage.Provider)
+
+	// Thumbnails are written by the workers to the storage as is, they are neither scanned nor thumbnailed again
+	thumbnailStorage := fileStorage
+
+	// Antivirus scanning of uploads
+	if config.ClamAV.Addr != "" {
+		scanner, err := clamav.New(config.ClamAV)
+		if err != nil {
+			slog.Error("Error initializing the ClamAV scanner", "error", err)
+			os.Exit(1)
+		}
+
+		fileStorage = service.NewScannedFileService(fileStorage, scanner)
+		slog.Info("Scanning uploads with ClamAV", "addr", config.ClamAV.Addr)
+	}
+
+	// Images get a thumbnail generated in the background
+	fileStorage = service.NewThumbnailService(fileStorage, thumbnailStorage, jobs)
+
+	// Signed file URLs, files are returned by key when no distribution is configured
+	var urlSigner port.FileURLSigner
+	if config.CloudFront.Domain != "" {
+		urlSigner, err = cloudfront.New(config.CloudFront)
+		if err != nil {
+			slog.Error("Error initializing the CloudFront URL signer", "error", err)
+			os.Exit(1)
+		}
+
+		slog.Info("Signing file URLs with CloudFront", "domain", config.CloudFront.Domain)
+	}
 
 	// Email
 
-	email, err := email.New(ctx, config.Email)
+	emailTemplates, err := email.NewTemplateRenderer()
+	if err != nil {
+		slog.Error("Error parsing the email templates", "error", err)
+		os.Exit(1)
+	}
+
+	var emailSender port.EmailRepository
+
+	switch config.Email.Provider {
+	case "mailtrap":
+		emailSender, err = email.New(ctx, config.Email)
+	case "smtp":
+		emailSender, err = smtp.New(config.SMTP)
+	case "ses":
+		emailSender, err = ses.New(ctx, config.Email)
+	case "sendgrid":
+		emailSender, err = sendgrid.New(config.Email)
+	case "memory":
+		emailSender = inbox.New()
+	default:
+		slog.Error("Unknown email provider", "provider", config.Email.Provider)
+		os.Exit(1)
+	}
 
 	if err != nil {
 		slog.Error("Error initializing the email service", "error", err)
 		os.Exit(1)
 	}
 
+	emailSender = telemetry.NewTracedEmailRepository(emailSender, config.Email.Provider)
+	slog.Info("Using email provider", "provider", config.Email.Provider)
+
+	// Emails are queued in redis and delivered by a background worker with retries
+	emailQueueRepo, err := redis.NewEmailQueue(ctx, config.Redis)
+	if err != nil {
+		slog.Error("Error initializing the email queue", "error", err)
+		os.Exit(1)
+	}
+	defer emailQueueRepo.Close()
+
+	if checker, ok := emailQueueRepo.(port.HealthChecker); ok {
+		readinessChecks["redis"] = checker
+	}
+
+	emailRetryDelay, err := time.ParseDuration(config.Email.QueueRetryDelay)
+	if err != nil {
+		slog.Error("Invalid email queue retry delay", "error", err)
+		os.Exit(1)
+	}
+
+	emailPollInterval, err := time.ParseDuration(config.Email.QueuePollInterval)
+	if err != nil {
+		slog.Error("Invalid email queue poll interval", "error", err)
+		os.Exit(1)
+	}
+
+	// Every delivery attempt is recorded in postgres for admins to inspect
+	emailLogRepo := repository.NewEmailLogRepository(db)
+	email := service.NewEmailQueueService(emailQueueRepo, emailSender, emailLogRepo, config.Email.QueueMaxAttempts, emailRetryDelay, emailPollInterval)
+	emailQueueHandler := http.NewEmailQueueHandler(email)
+	runBackground(email.Run)
+
+	// Text messages, disabled when no Twilio account is configured
+	var sms port.MessageSender
+	if config.Twilio.AccountSID != "" {
+		sms, err = twilio.New(config.Twilio)
+		if err != nil {
+			slog.Error("Error initializing the SMS sender", "error", err)
+			os.Exit(1)
+		}
+
+		slog.Info("Sending text messages with Twilio")
+	}
+
+	// WhatsApp messages, disabled when no access token is configured
+	var whatsApp port.MessageSender
+	if config.WhatsApp.AccessToken != "" {
+		whatsApp, err = whatsapp.New(config.WhatsApp)
+		if err != nil {
+			slog.Error("Error initializing the WhatsApp sender", "error", err)
+			os.Exit(1)
+		}
+
+		slog.Info("Sending WhatsApp messages", "phone_number_id", config.WhatsApp.PhoneNumberID)
+	}
+
+	// Push notifications, disabled when no Firebase project is configured
+	var push port.MessageSender
+	if config.FCM.ProjectID != "" {
+		push, err = fcm.New(ctx, config.FCM)
+		if err != nil {
+			slog.Error("Error initializing the FCM sender", "error", err)
+			os.Exit(1)
+		}
+
+		slog.Info("Sending push notifications", "project_id", config.FCM.ProjectID)
+	}
+
+	// ServiceCategory
+	serviceCategoryRepo := repository.NewServiceCategoryRepository(db)
+	serviceCategoryService := service.NewServiceCategoryService(serviceCategoryRepo, cacheRepo)
+	serviceCategoryHandler := http.NewServiceCategoryHandler(serviceCategoryService)
+
 	// TypeOfService
 	typeOfServiceRepo := repository.NewTypeOfServiceRepository(db)
-	typeOfServiceService := service.NewTypeOfServiceService(typeOfServiceRepo, cache)
-	typeOfServiceHandler := http.NewTypeOfServiceHandler(typeOfServiceService)
+	typeOfServiceImageRepo := repository.NewTypeOfServiceImageRepository(db)
+	serviceOfferingRepo := repository.NewServiceOfferingRepository(db)
+	staffRepo := repository.NewStaffRepository(db)
+	typeOfServiceService := service.NewTypeOfServiceService(typeOfServiceRepo, typeOfServiceImageRepo, serviceOfferingRepo, staffRepo, userRepo, fileStorage, cacheRepo, defaultCurrency, auditRepo)
+	typeOfServiceHandler := http.NewTypeOfServiceHandler(typeOfServiceService, urlSigner)
+
+	// Staff
+	staffService := service.NewStaffService(staffRepo, userRepo, fileStorage, cacheRepo)
+	staffHandler := http.NewStaffHandler(staffService, urlSigner)
+
+	// Promotion
+	promotionRepo := repository.NewPromotionRepository(db)
+	promotionService := service.NewPromotionService(promotionRepo, cacheRepo)
+	promotionHandler := http.NewPromotionHandler(promotionService)
+
+	// Coupon
+	couponRepo := repository.NewCouponRepository(db)
+	couponService := service.NewCouponService(couponRepo, cacheRepo)
+	couponHandler := http.NewCouponHandler(couponService)
+
+	// Domain events, recorded in the event store when they happen
+	events := event.New()
+	eventStoreRepo := repository.NewEventStoreRepository(db)
+	eventStoreService := service.NewEventStoreService(eventStoreRepo)
+	eventStoreHandler := http.NewEventStoreHandler(eventStoreService)
+
+	// Devices push notifications are sent to
+	deviceRepo := repository.NewDeviceRepository(db)
+	deviceService := service.NewDeviceService(deviceRepo)
+	deviceHandler := http.NewDeviceHandler(deviceService)
+
+	// Audit log
+	auditLogRepo := repository.NewAuditLogRepository(db)
+	auditLogService := service.NewAuditLogService(auditLogRepo)
+	auditLogHandler := http.NewAuditLogHandler(auditLogService)
+
+	// Notification
+	notificationRepo := repository.NewNotificationRepository(db)
+	notificationService := service.NewNotificationService(notificationRepo, deviceRepo, userRepo, email, sms, whatsApp, push, emailTemplates, jobs)
+	notificationHandler := http.NewNotificationHandler(notificationService, events)
 
 	// Quote
 	quoteRepo := repository.NewQuoteRepository(db)
 	quoteImageRepo := repository.NewQuoteImageRepository(db)
-	quoteService := service.NewQuoteService(quoteRepo, s3, userRepo, email, quoteImageRepo, typeOfServiceRepo, *db, cache)
-	quoteHandler := http.NewQuoteHandler(quoteService)
+	quoteService := service.NewQuoteService(quoteRepo, fileStorage, userRepo, quoteImageRepo, typeOfServiceRepo, promotionRepo, couponRepo, *db, cacheRepo)
+	quoteHandler := http.NewQuoteHandler(quoteService, urlSigner)
 
 	// AvailabilitySlot
 	availabilitySlotRepo := repository.NewAvailabilitySlotRepository(db)
-	availabilitySlotService := service.NewAvailabilitySlotService(availabilitySlotRepo, cache)
+	appointmentRepo := repository.NewAppointmentRepository(db)
+	availabilitySlotService := service.NewAvailabilitySlotService(availabilitySlotRepo, appointmentRepo, staffRepo, cacheRepo)
 	availabilitySlotHandler := http.NewAvailabilitySlotHandler(availabilitySlotService, userService)
 
 	// Appointment
-	appointmentRepo := repository.NewAppointmentRepository(db)
-	appointmentService := service.NewAppointmentService(appointmentRepo, quoteRepo, availabilitySlotRepo, cache)
+	appointmentService := service.NewAppointmentService(appointmentRepo, quoteRepo, availabilitySlotRepo, serviceOfferingRepo, userRepo, notificationService, events, eventStoreRepo, cacheRepo)
 	appointmentHandler := http.NewAppointmentHandler(appointmentService, userService)
 
+	reminderLead, err := time.ParseDuration(config.App.ReminderLead)
+	if err != nil {
+		slog.Error("Invalid appointment reminder lead", "error", err)
+		os.Exit(1)
+	}
+
+	appointmentReminderService := service.NewAppointmentReminderService(appointmentRepo, availabilitySlotRepo, userRepo, notificationService, reminderLead)
+
 	// PaymentProof
 	paymentProofRepo := repository.NewPaymentProofRepository(db)
 	paymentProofService := service.NewPaymentProofService(
 		paymentProofRepo, // port.PaymentProofRepository
-		s3,               // port.FileRepository
+		fileStorage,      // port.FileRepository
 		quoteRepo,        // port.QuoteRepository
-		email,            // port.EmailRepository
+		userRepo,         // port.UserRepository
 		*db,              // postgres.DB
-		cache,            // port.CacheRepository
+		cacheRepo,        // port.CacheRepository
 	)
-	paymentProofHandler := http.NewPaymentProofHandler(paymentProofService)
+	paymentProofHandler := http.NewPaymentProofHandler(paymentProofService, urlSigner)
 
 	// QuoteImage
 	quoteImageService := service.NewQuoteImageService(
 		quoteImageRepo, // port.QuoteImageRepository
-		s3,             // port.FileRepository
+		fileStorage,    // port.FileRepository
 		quoteRepo,      // port.QuoteRepository
 		*db,            // postgres.DB
-		cache,          // port.CacheRepository
+		cacheRepo,      // port.CacheRepository
 	)
-	quoteImageHandler := http.NewQuoteImageHandler(quoteImageService)
+	quoteImageHandler := http.NewQuoteImageHandler(quoteImageService, urlSigner)
+
+	// PaymentWebhook
+	paymentVerifier := payment.New(config.PaymentWebhook)
+	paymentEventRepo := repository.NewPaymentEventRepository(db)
+	paymentWebhookService := service.NewPaymentWebhookService(paymentEventRepo, paymentVerifier, quoteRepo, quoteService)
+	paymentWebhookHandler := http.NewPaymentWebhookHandler(paymentWebhookService)
+
+	// Webhooks, the published events are posted to the subscribed endpoints with retries
+	webhookRetryDelay, err := time.ParseDuration(config.App.WebhookRetryDelay)
+	if err != nil {
+		slog.Error("Invalid webhook retry delay", "error", err)
+		os.Exit(1)
+	}
+
+	webhookRepo := repository.NewWebhookRepository(db)
+	webhookService := service.NewWebhookService(webhookRepo, webhook.New(), events, config.App.WebhookMaxAttempts, webhookRetryDelay)
+	webhookHandler := http.NewWebhookHandler(webhookService)
+	// The events are published in process, so the server delivers them whoever runs the jobs
+	runWorker(webhookService.Run)
+
+	// Outbox, the notifications and events written along with quote and payment proof changes are relayed
+	// from postgres once committed
+	outboxPollInterval, err := time.ParseDuration(config.Outbox.PollInterval)
+	if err != nil {
+		slog.Error("Invalid outbox poll interval", "error", err)
+		os.Exit(1)
+	}
+
+	outboxRetryDelay, err := time.ParseDuration(config.Outbox.RetryDelay)
+	if err != nil {
+		slog.Error("Invalid outbox retry delay", "error", err)
+		os.Exit(1)
+	}
+
+	outboxService := service.NewOutboxService(repository.NewOutboxRepository(db), jobs, events, outboxRetryDelay, outboxPollInterval)
+	// The events reach the admin dashboards connected to the server, so the server relays them
+	if command == "serve" {
+		runWorker(outboxService.Run)
+	}
+
+	// Reports are built by the workers and emailed to the admin who asked for them
+	reportService := service.NewReportService(quoteRepo, appointmentRepo, repository.NewReportRepository(db), userRepo, email, emailTemplates, jobs)
+	reportHandler := http.NewReportHandler(reportService)
+
+	// Registered last so every service added its job handlers before the first job is taken
+	runBackground(jobs.Run)
+
+	// Scheduled tasks, every run is locked in redis so it happens on a single server or worker
+	schedulerLocks, err := redis.NewSchedulerLockRepository(ctx, config.Redis, fmt.Sprintf("%s:%s:", config.App.Name, config.App.Env))
+	if err != nil {
+		slog.Error("Error initializing the scheduler locks", "error", err)
+		os.Exit(1)
+	}
+	defer schedulerLocks.Close()
+
+	quoteMaxAge, err := time.ParseDuration(config.Schedule.QuoteMaxAge)
+	if err != nil {
+		slog.Error("Invalid quote max age", "error", err)
+		os.Exit(1)
+	}
+
+	orphanFileGrace, err := time.ParseDuration(config.Schedule.OrphanFileGrace)
+	if err != nil {
+		slog.Error("Invalid orphan file grace period", "error", err)
+		os.Exit(1)
+	}
+
+	quoteExpiration, err := domain.ParseSchedule(config.Schedule.QuoteExpiration)
+	if err != nil {
+		slog.Error("Invalid quote expiration schedule", "error", err)
+		os.Exit(1)
+	}
+	appointmentReminders, err := domain.ParseSchedule(config.Schedule.AppointmentReminders)
+	if err != nil {
+		slog.Error("Invalid appointment reminders schedule", "error", err)
+		os.Exit(1)
+	}
+	orphanFileCleanup, err := domain.ParseSchedule(config.Schedule.OrphanFileCleanup)
+	if err != nil {
+		slog.Error("Invalid orphan file cleanup schedule", "error", err)
+		os.Exit(1)
+	}
+	retentionCleanup, err := domain.ParseSchedule(config.Schedule.RetentionCleanup)
+	if err != nil {
+		slog.Error("Invalid retention cleanup schedule", "error", err)
+		os.Exit(1)
+	}
+	if fileLister == nil && orphanFileCleanup != nil {
+		slog.Warn("The file storage can't list its files, orphan files are not cleaned up", "provider", config.Storage.Provider)
+	}
+
+	scheduler := service.NewSchedulerService(schedulerLocks, reporter)
+	scheduler.Schedule("quote-expiration", quoteExpiration, func(ctx context.Context) error {
+		_, err := quoteService.ExpireQuotes(ctx, time.Now().Add(-quoteMaxAge))
+		return err
+	})
+	scheduler.Schedule("appointment-reminders", appointmentReminders, appointmentReminderService.RemindDue)
+	if fileLister != nil {
+		// Orphans are deleted from the storage as is, their thumbnails are deleted along with them
+		fileCleanupService := service.NewFileCleanupService(fileLister, thumbnailStorage, repository.NewFileReferenceRepository(db), orphanFileGrace)
+		scheduler.Schedule("orphan-file-cleanup", orphanFileCleanup, func(ctx context.Context) error {
+			_, err := fileCleanupService.CleanOrphans(ctx)
+			return err
+		})
+	}
+	// An empty retention keeps the records forever
+	var emailLogRetention, notificationRetention time.Duration
+	if config.Schedule.EmailLogRetention != "" {
+		emailLogRetention, err = time.ParseDuration(config.Schedule.EmailLogRetention)
+		if err != nil {
+			slog.Error("Invalid email log retention", "error", err)
+			os.Exit(1)
+		}
+	}
+	if config.Schedule.NotificationRetention != "" {
+		notificationRetention, err = time.ParseDuration(config.Schedule.NotificationRetention)
+		if err != nil {
+			slog.Error("Invalid notification retention", "error", err)
+			os.Exit(1)
+		}
+	}
+	retentionService := service.NewRetentionService(repository.NewEmailLogRepository(db), repository.NewNotificationRepository(db), emailLogRetention, notificationRetention)
+	scheduler.Schedule("retention-cleanup", retentionCleanup, retentionService.Purge)
+	runBackground(scheduler.Run)
+
+	// Health
+	readinessTimeout, err := time.ParseDuration(config.HTTP.ReadinessTimeout)
+	if err != nil {
+		slog.Error("Invalid readiness timeout", "error", err)
+		os.Exit(1)
+	}
+
+	healthService := service.NewHealthService(db, readinessChecks, readinessTimeout)
+	healthHandler := http.NewHealthHandler(healthService)
+
+	// Rate limits are token buckets in redis shared by every server
+	rateLimiter, err := redis.NewRateLimiter(ctx, config.Redis, fmt.Sprintf("%s:%s:", config.App.Name, config.App.Env))
+	if err != nil {
+		slog.Error("Error initializing the rate limiter", "error", err)
+		os.Exit(1)
+	}
+	defer rateLimiter.Close()
+
+	var rateLimits http.RateLimits
+	rateLimits.Auth, err = domain.ParseRateLimit(config.RateLimit.Auth)
+	if err != nil {
+		slog.Error("Invalid auth rate limit", "error", err)
+		os.Exit(1)
+	}
+	rateLimits.Upload, err = domain.ParseRateLimit(config.RateLimit.Upload)
+	if err != nil {
+		slog.Error("Invalid upload rate limit", "error", err)
+		os.Exit(1)
+	}
+
+	// Daily quotas are counted in redis, admins can change the configured ones
+	quotaRepo, err := redis.NewQuotaRepository(ctx, config.Redis, fmt.Sprintf("%s:%s:", config.App.Name, config.App.Env))
+	if err != nil {
+		slog.Error("Error initializing the quotas", "error", err)
+		os.Exit(1)
+	}
+	defer quotaRepo.Close()
+
+	quotaService := service.NewQuotaService(quotaRepo, []domain.Quota{
+		{Role: domain.Client, Action: domain.QuotaQuoteCreation, Limit: config.Quota.ClientQuoteCreation},
+		{Role: domain.Client, Action: domain.QuotaFileUpload, Limit: config.Quota.ClientFileUpload},
+		{Role: domain.Admin, Action: domain.QuotaQuoteCreation, Limit: config.Quota.AdminQuoteCreation},
+		{Role: domain.Admin, Action: domain.QuotaFileUpload, Limit: config.Quota.AdminFileUpload},
+	})
+	quotaHandler := http.NewQuotaHandler(quotaService)
+
+	// Log level, admins change it at runtime while diagnosing incidents
+	logLevelService := service.NewLogLevelService(logger.Level())
+	logLevelHandler := http.NewLogLevelHandler(logLevelService)
+
+	// GraphQL gateway of the dashboard, over the same services as the REST API
+	var graphQLServer nethttp.Handler
+	if config.HTTP.GraphQL {
+		graphQLServer = graphqlhandler.NewHandler(quoteService, appointmentService, availabilitySlotService, typeOfServiceService, urlSigner)
+	}
+	graphQLHandler := http.NewGraphQLHandler(graphQLServer)
+
+	// The worker runs until it is signalled, letting the jobs in progress finish
+	if command == "worker" {
+		slog.Info("Running the background workers", "concurrency", config.Jobs.Concurrency)
+		<-runCtx.Done()
+		workers.Wait()
+		slog.Info("Stopped the workers")
+		return
+	}
 
 	// Init router
 	router, err := http.NewRouter(
 		config.HTTP,
 		token,
+		rateLimiter,
+		rateLimits,
+		reporter,
 		*userHandler,
 		*authHandler,
 		*quoteHandler,
@@ -160,18 +776,128 @@ func main() {
 		*appointmentHandler,
 		*paymentProofHandler,
 		*quoteImageHandler,
+		*paymentWebhookHandler,
+		*serviceCategoryHandler,
+		*promotionHandler,
+		*emailQueueHandler,
+		*notificationHandler,
+		*webhookHandler,
+		*deviceHandler,
+		*healthHandler,
+		*auditLogHandler,
+		*quotaHandler,
+		*logLevelHandler,
+		*jobQueueHandler,
+		*reportHandler,
+		*eventStoreHandler,
+		*auditHandler,
+		*salonHandler,
+		*staffHandler,
+		*couponHandler,
+		*graphQLHandler,
 	)
 
 	if err != nil {
 		slog.Error("Error initializing router", "error", err)
 		os.Exit(1)
 	}
+
+	shutdownTimeout, err := time.ParseDuration(config.HTTP.ShutdownTimeout)
+	if err != nil {
+		slog.Error("Invalid shutdown timeout", "error", err)
+		os.Exit(1)
+	}
+
+	// The gRPC API shares the core services with the HTTP API, for internal services
+	if config.GRPC.Port != "" {
+		grpcServer := grpchandler.NewServer(token, quoteService, appointmentService, availabilitySlotService, urlSigner, reporter)
+		grpcAddr := fmt.Sprintf("%s:%s", config.HTTP.URL, config.GRPC.Port)
+		slog.Info("Starting the gRPC server", "listen_address", grpcAddr)
+		runWorker(func(ctx context.Context) {
+			if err := grpcServer.Serve(ctx, grpcAddr, shutdownTimeout); err != nil {
+				slog.Error("Error starting the gRPC server", "error", err)
+				stop()
+			}
+		})
+	}
+
 	// Start server
 	listenAddr := fmt.Sprintf("%s:%s", config.HTTP.URL, config.HTTP.Port)
 	slog.Info("Starting the HTTP server", "listen_address", listenAddr)
-	err = router.Serve(listenAddr)
+	err = router.Serve(runCtx, listenAddr, shutdownTimeout)
 	if err != nil {
 		slog.Error("Error starting the HTTP server", "error", err)
 		os.Exit(1)
 	}
+
+	// Let the workers finish what they were doing before the deferred calls close redis and postgres
+	stop()
+	workers.Wait()
+	slog.Info("Stopped the application")
+}
+
+// runMigrate runs the migrate subcommand given by args
+func runMigrate(db *postgres.DB, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing migrate command\n%s", usage)
+	}
+
+	switch args[0] {
+	case "up":
+		err := db.Migrate()
+		if err != nil {
+			return err
+		}
+		slog.Info("Successfully migrated the database")
+	case "down":
+		if len(args) < 2 {
+			return fmt.Errorf("missing number of migrations to roll back\n%s", usage)
+		}
+		steps, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid number of migrations to roll back: %w", err)
+		}
+		err = db.MigrateDown(steps)
+		if err != nil {
+			return err
+		}
+		slog.Info("Successfully rolled back the database", "steps", steps)
+	case "version":
+		version, dirty, err := db.MigrationVersion()
+		if err != nil {
+			return err
+		}
+		slog.Info("Current database version", "version", version, "dirty", dirty)
+	default:
+		return fmt.Errorf("unknown migrate command %q\n%s", args[0], usage)
+	}
+
+	return nil
+}
+
+// runSeed fills the database with the sample data of the seed service, production databases are never seeded
+func runSeed(ctx context.Context, db *postgres.DB, config *config.Container, currency domain.Currency) error {
+	if config.App.Env == "production" {
+		return fmt.Errorf("refusing to seed a production database")
+	}
+
+	fileStorage, err := local.NewLocal(config.Storage.LocalDir)
+	if err != nil {
+		return err
+	}
+
+	seedService := service.NewSeedService(
+		repository.NewUserRepository(db),
+		repository.NewServiceCategoryRepository(db),
+		repository.NewTypeOfServiceRepository(db),
+		repository.NewServiceOfferingRepository(db),
+		repository.NewAvailabilitySlotRepository(db),
+		repository.NewQuoteRepository(db),
+		repository.NewAppointmentRepository(db),
+		repository.NewPaymentProofRepository(db),
+		fileStorage,
+		currency,
+	)
+
+	return seedService.Seed(ctx)
 }
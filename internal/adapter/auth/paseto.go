@@ -1,6 +1,7 @@
 package paseto
 
 import (
+	"fmt"
 	"time"
 
 	"aidanwoods.dev/go-paseto"
@@ -29,8 +30,17 @@ func New(config *config.Token) (port.TokenService, error) {
 		return nil, domain.ErrTokenDuration
 	}
 
-	token := paseto.NewToken()
+	// Without a configured key every start signs with a new one, signing out the users and
+	// making the tokens of one server unreadable by the others
 	key := paseto.NewV4SymmetricKey()
+	if config.SymmetricKey != "" {
+		key, err = paseto.V4SymmetricKeyFromHex(config.SymmetricKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid token symmetric key: %w", err)
+		}
+	}
+
+	token := paseto.NewToken()
 	parser := paseto.NewParser()
 
 	return &PasetoToken{
@@ -49,9 +59,11 @@ func (pt *PasetoToken) CreateToken(user *domain.User) (string, error) {
 	}
 
 	payload := &domain.TokenPayload{
-		ID:     id,
-		UserID: user.ID,
-		Role:   user.Role,
+		ID:       id,
+		UserID:   user.ID,
+		Role:     user.Role,
+		Language: user.PreferredLanguage.OrDefault(),
+		SalonID:  user.Salon(),
 	}
 
 	err = pt.token.Set("payload", payload)
@@ -88,5 +100,10 @@ func (pt *PasetoToken) VerifyToken(token string) (*domain.TokenPayload, error) {
 		return nil, domain.ErrInvalidToken
 	}
 
+	// Tokens issued before there were salons are of the default one
+	if payload.SalonID == uuid.Nil {
+		payload.SalonID = domain.DefaultSalonID
+	}
+
 	return payload, nil
 }
@@ -3,12 +3,15 @@ package email
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
 	"harajuku/backend/internal/core/port"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -23,6 +26,13 @@ type mailtrapRecipient struct {
 	Email string `json:"email"`
 }
 
+type mailtrapAttachment struct {
+	Content     string `json:"content"`
+	Filename    string `json:"filename"`
+	Type        string `json:"type,omitempty"`
+	Disposition string `json:"disposition"`
+}
+
 type mailtrapPayload struct {
 	From struct {
 		Email string `json:"email"`
@@ -32,6 +42,11 @@ type mailtrapPayload struct {
 	Subject string              `json:"subject"`
 	Text    string              `json:"text,omitempty"`
 	HTML    string              `json:"html,omitempty"`
+	Attachments []mailtrapAttachment `json:"attachments,omitempty"`
+}
+
+type mailtrapResponse struct {
+	MessageIDs []string `json:"message_ids"`
 }
 
 func New(ctx context.Context, config *config.Email) (port.EmailRepository, error) {
@@ -49,15 +64,15 @@ func New(ctx context.Context, config *config.Email) (port.EmailRepository, error
 	}, nil
 }
 
-func (em *EmailManager) SendEmail(ctx context.Context, to []string, subject string, textContent string, htmlContent string) error {
+func (em *EmailManager) SendEmail(ctx context.Context, to []string, subject string, textContent string, htmlContent string, attachments ...domain.EmailAttachment) (string, error) {
 	// Validate at least one recipient
 	if len(to) == 0 {
-		return errors.New("at least one recipient is required")
+		return "", errors.New("at least one recipient is required")
 	}
 
 	// Validate at least one content type is provided
 	if textContent == "" && htmlContent == "" {
-		return errors.New("either text or HTML content must be provided")
+		return "", errors.New("either text or HTML content must be provided")
 	}
 
 	// Prepare payload
@@ -81,9 +96,19 @@ func (em *EmailManager) SendEmail(ctx context.Context, to []string, subject stri
 		payload.HTML = htmlContent
 	}
 
+	// Mailtrap expects the content of attachments base64 encoded
+	for _, attachment := range attachments {
+		payload.Attachments = append(payload.Attachments, mailtrapAttachment{
+			Content:     base64.StdEncoding.EncodeToString(attachment.Content),
+			Filename:    attachment.FileName,
+			Type:        attachment.ContentType,
+			Disposition: "attachment",
+		})
+	}
+
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal email payload: %w", err)
+		return "", fmt.Errorf("failed to marshal email payload: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(
@@ -93,7 +118,7 @@ func (em *EmailManager) SendEmail(ctx context.Context, to []string, subject stri
 		bytes.NewBuffer(jsonPayload),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", em.APIToken))
@@ -102,14 +127,20 @@ func (em *EmailManager) SendEmail(ctx context.Context, to []string, subject stri
 
 	res, err := em.Client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send email: %w", err)
+		return "", fmt.Errorf("failed to send email: %w", err)
 	}
 	defer res.Body.Close()
 
 
 	if res.StatusCode >= 400 {
-		return fmt.Errorf("email sending failed with status: %d", res.StatusCode)
+		return "", fmt.Errorf("email sending failed with status: %d", res.StatusCode)
+	}
+
+	// Mailtrap assigns one message ID per recipient
+	var body mailtrapResponse
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode email response: %w", err)
 	}
 
-	return nil
+	return strings.Join(body.MessageIDs, ","), nil
 }
@@ -2,9 +2,13 @@ package email
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -44,3 +48,27 @@ func TestNew(t *testing.T) {
 	})
 }
 
+func TestSendEmailAttachments(t *testing.T) {
+	var payload mailtrapPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success":true,"message_ids":["mailtrap-message-id"]}`))
+	}))
+	defer server.Close()
+
+	repo, err := New(context.Background(), &config.Email{Url: server.URL, ApiToken: "test-token", FromEmail: "test@example.com"})
+	require.NoError(t, err)
+
+	id, err := repo.SendEmail(context.Background(), []string{"ana@example.com"}, "Recibo", "Hola Ana", "",
+		domain.EmailAttachment{FileName: "recibo.pdf", Content: []byte("%PDF-1.7"), ContentType: "application/pdf"})
+	require.NoError(t, err)
+	assert.Equal(t, "mailtrap-message-id", id)
+
+	assert.Equal(t, []mailtrapAttachment{{
+		Content:     "JVBERi0xLjc=",
+		Filename:    "recibo.pdf",
+		Type:        "application/pdf",
+		Disposition: "attachment",
+	}}, payload.Attachments)
+}
@@ -0,0 +1,239 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"reflect"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+)
+
+//go:embed templates
+var templateFiles embed.FS
+
+// registry maps every email template to the type of the data it is rendered with
+var registry = map[domain.EmailTemplate]reflect.Type{
+	domain.EmailQuoteCreated:         reflect.TypeOf(domain.QuoteCreatedEmail{}),
+	domain.EmailQuoteRequiresProof:   reflect.TypeOf(domain.QuoteStateEmail{}),
+	domain.EmailQuoteStateChanged:    reflect.TypeOf(domain.QuoteStateEmail{}),
+	domain.EmailAppointmentConfirmed: reflect.TypeOf(domain.AppointmentConfirmedEmail{}),
+	domain.EmailAppointmentReminder:  reflect.TypeOf(domain.AppointmentReminderEmail{}),
+	domain.EmailPaymentProofUploaded: reflect.TypeOf(domain.PaymentProofUploadedEmail{}),
+	domain.EmailReportReady:          reflect.TypeOf(domain.ReportReadyEmail{}),
+}
+
+// languages are the languages with a message catalog in templates/locales
+var languages = []domain.Language{domain.LanguageSpanish, domain.LanguageEnglish}
+
+// catalog maps message keys to their text in one language, texts may hold fmt verbs for the arguments given to t
+type catalog map[string]string
+
+// emailTemplate holds the parsed parts of a template, the subject and the optional
+// short message for phone channels are defined in the text part
+type emailTemplate struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+/**
+ * TemplateRenderer implements port.EmailTemplateRenderer interface
+ * and renders the templates embedded at build time in the language of the recipient
+ */
+type TemplateRenderer struct {
+	templates map[domain.EmailTemplate]emailTemplate
+	catalogs  map[domain.Language]catalog
+}
+
+// NewTemplateRenderer parses every registered template and message catalog, and renders each template
+// in every language once so a missing message fails on startup rather than when an event is sent
+func NewTemplateRenderer() (port.EmailTemplateRenderer, error) {
+	catalogs := make(map[domain.Language]catalog, len(languages))
+	for _, lang := range languages {
+		data, err := templateFiles.ReadFile(fmt.Sprintf("templates/locales/%s.json", lang))
+		if err != nil {
+			return nil, err
+		}
+
+		var c catalog
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("failed to parse %s message catalog: %w", lang, err)
+		}
+		catalogs[lang] = c
+	}
+
+	// The real functions are bound to a language on every render
+	placeholders := funcs(nil, domain.DefaultLanguage)
+
+	templates := make(map[domain.EmailTemplate]emailTemplate, len(registry))
+	for name := range registry {
+		html, err := htmltemplate.New("layout.html").Funcs(placeholders).
+			ParseFS(templateFiles, "templates/layout.html", fmt.Sprintf("templates/%s.html", name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s html template: %w", name, err)
+		}
+
+		text, err := texttemplate.New(fmt.Sprintf("%s.txt", name)).Funcs(placeholders).
+			ParseFS(templateFiles, fmt.Sprintf("templates/%s.txt", name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s text template: %w", name, err)
+		}
+
+		if text.Lookup("subject") == nil {
+			return nil, fmt.Errorf("%s text template does not define a subject", name)
+		}
+
+		templates[name] = emailTemplate{html, text}
+	}
+
+	r := &TemplateRenderer{templates, catalogs}
+
+	for name, dataType := range registry {
+		for _, lang := range languages {
+			data := reflect.Zero(dataType).Interface()
+			if _, err := r.Render(name, lang, data); err != nil {
+				return nil, err
+			}
+			if _, err := r.RenderMessage(name, lang, data); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// Render renders the subject, HTML and text parts of template with data in lang, Spanish when lang is not supported
+func (r *TemplateRenderer) Render(template domain.EmailTemplate, lang domain.Language, data any) (*domain.EmailMessage, error) {
+	t, err := r.lookup(template, data)
+	if err != nil {
+		return nil, err
+	}
+
+	lang = lang.OrDefault()
+	localized := funcs(r.catalogs[lang], lang)
+
+	html, err := t.html.Clone()
+	if err != nil {
+		return nil, err
+	}
+	html.Funcs(localized)
+
+	text, err := t.text.Clone()
+	if err != nil {
+		return nil, err
+	}
+	text.Funcs(localized)
+
+	var subject, textBody, htmlBody bytes.Buffer
+
+	if err := text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := text.Execute(&textBody, data); err != nil {
+		return nil, err
+	}
+	if err := html.Execute(&htmlBody, data); err != nil {
+		return nil, err
+	}
+
+	return &domain.EmailMessage{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(textBody.String()),
+		HTML:    htmlBody.String(),
+	}, nil
+}
+
+// RenderMessage renders the "message" block of the text part of template with data in lang, and the
+// "message_params" block, one line per placeholder, as the parameters of the provider template named
+// like the event in snake case. Templates without a message block are not sent to phones and render nil
+func (r *TemplateRenderer) RenderMessage(template domain.EmailTemplate, lang domain.Language, data any) (*domain.Message, error) {
+	t, err := r.lookup(template, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.text.Lookup("message") == nil {
+		return nil, nil
+	}
+
+	lang = lang.OrDefault()
+
+	text, err := t.text.Clone()
+	if err != nil {
+		return nil, err
+	}
+	text.Funcs(funcs(r.catalogs[lang], lang))
+
+	var body, params bytes.Buffer
+	if err := text.ExecuteTemplate(&body, "message", data); err != nil {
+		return nil, err
+	}
+	if text.Lookup("message_params") != nil {
+		if err := text.ExecuteTemplate(&params, "message_params", data); err != nil {
+			return nil, err
+		}
+	}
+
+	var parameters []string
+	for _, line := range strings.Split(strings.TrimSpace(params.String()), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			parameters = append(parameters, line)
+		}
+	}
+
+	return &domain.Message{
+		Body: strings.TrimSpace(body.String()),
+		Template: &domain.MessageTemplate{
+			Name:       strings.ReplaceAll(string(template), "-", "_"),
+			Language:   lang,
+			Parameters: parameters,
+		},
+	}, nil
+}
+
+// lookup returns the parsed template, checking data has the type registered for it
+func (r *TemplateRenderer) lookup(template domain.EmailTemplate, data any) (emailTemplate, error) {
+	t, ok := r.templates[template]
+	if !ok {
+		return emailTemplate{}, fmt.Errorf("unknown email template %q", template)
+	}
+
+	if dataType := reflect.TypeOf(data); dataType != registry[template] {
+		return emailTemplate{}, fmt.Errorf("email template %q expects %s, got %v", template, registry[template], dataType)
+	}
+
+	return t, nil
+}
+
+// funcs returns the helpers available to both the HTML and text templates, translating with c
+func funcs(c catalog, lang domain.Language) map[string]any {
+	t := func(key string, args ...any) (string, error) {
+		message, ok := c[key]
+		if !ok {
+			return "", fmt.Errorf("message %q is missing from the %s catalog", key, lang)
+		}
+		if len(args) == 0 {
+			return message, nil
+		}
+		return fmt.Sprintf(message, args...), nil
+	}
+
+	return map[string]any{
+		"t":    t,
+		"lang": func() string { return string(lang) },
+		"datetime": func(at time.Time) (string, error) {
+			layout, err := t("datetime_layout")
+			if err != nil {
+				return "", err
+			}
+			return at.Format(layout), nil
+		},
+	}
+}
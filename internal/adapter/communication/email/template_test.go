@@ -0,0 +1,127 @@
+package email
+
+import (
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateRenderer(t *testing.T) {
+	renderer, err := NewTemplateRenderer()
+	require.NoError(t, err)
+
+	t.Run("every registered template renders", func(t *testing.T) {
+		data := map[domain.EmailTemplate]any{
+			domain.EmailQuoteCreated:       domain.QuoteCreatedEmail{QuoteID: uuid.New(), Description: "Balayage", ClientName: "Ana López"},
+			domain.EmailQuoteRequiresProof: domain.QuoteStateEmail{QuoteID: uuid.New(), ClientName: "Ana López", State: domain.QuoteRequiresProof},
+			domain.EmailQuoteStateChanged:  domain.QuoteStateEmail{QuoteID: uuid.New(), ClientName: "Ana López", State: domain.QuoteApproved},
+			domain.EmailAppointmentConfirmed: domain.AppointmentConfirmedEmail{
+				AppointmentID: uuid.New(),
+				QuoteID:       uuid.New(),
+				ClientName:    "Ana López",
+				StartTime:     time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC),
+				EndTime:       time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC),
+			},
+			domain.EmailAppointmentReminder: domain.AppointmentReminderEmail{
+				AppointmentID: uuid.New(),
+				QuoteID:       uuid.New(),
+				ClientName:    "Ana López",
+				StartTime:     time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC),
+				EndTime:       time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC),
+			},
+			domain.EmailPaymentProofUploaded: domain.PaymentProofUploadedEmail{PaymentProofID: uuid.New(), QuoteID: uuid.New(), ClientName: "Ana López"},
+			domain.EmailReportReady:          domain.ReportReadyEmail{AdminName: "Sofía Ruiz", Kind: domain.ReportQuotes, Rows: 42},
+		}
+		require.Len(t, data, len(registry))
+
+		for name, d := range data {
+			for _, lang := range languages {
+				message, err := renderer.Render(name, lang, d)
+				require.NoError(t, err, name)
+				assert.NotEmpty(t, message.Subject, name)
+				assert.Contains(t, message.HTML, `<html lang="`+string(lang)+`">`, name)
+				assert.NotEmpty(t, message.Text, name)
+			}
+		}
+	})
+
+	t.Run("html part escapes the data", func(t *testing.T) {
+		message, err := renderer.Render(domain.EmailQuoteCreated, domain.LanguageSpanish, domain.QuoteCreatedEmail{Description: "<script>alert(1)</script>"})
+		require.NoError(t, err)
+		assert.NotContains(t, message.HTML, "<script>")
+		assert.Contains(t, message.Text, "<script>")
+	})
+
+	t.Run("text part follows the state", func(t *testing.T) {
+		message, err := renderer.Render(domain.EmailQuoteStateChanged, domain.LanguageSpanish, domain.QuoteStateEmail{State: domain.QuoteRejected})
+		require.NoError(t, err)
+		assert.Contains(t, message.Text, "rechazada")
+		assert.Equal(t, "Respuesta a su cotización", message.Subject)
+	})
+
+	t.Run("text part follows the report kind", func(t *testing.T) {
+		message, err := renderer.Render(domain.EmailReportReady, domain.LanguageEnglish, domain.ReportReadyEmail{AdminName: "Sofía", Kind: domain.ReportAppointments, Rows: 3})
+		require.NoError(t, err)
+		assert.Equal(t, "Your report is ready", message.Subject)
+		assert.Equal(t, "Dear Sofía,\n\nThe appointments report you requested is attached as a CSV file.\n\tRows: 3", message.Text)
+	})
+
+	t.Run("english", func(t *testing.T) {
+		message, err := renderer.Render(domain.EmailQuoteStateChanged, domain.LanguageEnglish, domain.QuoteStateEmail{ClientName: "Ana", State: domain.QuoteApproved})
+		require.NoError(t, err)
+		assert.Equal(t, "Update on your quote", message.Subject)
+		assert.Equal(t, "Dear Ana,\n\nYour quote has been approved.", message.Text)
+	})
+
+	t.Run("unsupported language falls back to spanish", func(t *testing.T) {
+		message, err := renderer.Render(domain.EmailAppointmentConfirmed, "fr", domain.AppointmentConfirmedEmail{
+			StartTime: time.Date(2025, 3, 14, 17, 30, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Su cita ha sido confirmada", message.Subject)
+		assert.Contains(t, message.Text, "14/03/2025 17:30")
+	})
+
+	t.Run("short message for phones", func(t *testing.T) {
+		message, err := renderer.RenderMessage(domain.EmailAppointmentReminder, domain.LanguageEnglish, domain.AppointmentReminderEmail{
+			ClientName: "Ana",
+			StartTime:  time.Date(2025, 3, 14, 17, 30, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Harajuku: this is a reminder of your appointment on 03/14/2025 5:30 PM.", message.Body)
+		assert.Equal(t, &domain.MessageTemplate{
+			Name:       "appointment_reminder",
+			Language:   domain.LanguageEnglish,
+			Parameters: []string{"Ana", "03/14/2025 5:30 PM"},
+		}, message.Template)
+
+		message, err = renderer.RenderMessage(domain.EmailQuoteCreated, domain.LanguageSpanish, domain.QuoteCreatedEmail{})
+		require.NoError(t, err)
+		assert.Nil(t, message)
+	})
+
+	t.Run("catalogs have the same messages", func(t *testing.T) {
+		r := renderer.(*TemplateRenderer)
+		for _, lang := range languages {
+			for key := range r.catalogs[domain.DefaultLanguage] {
+				assert.Contains(t, r.catalogs[lang], key, lang)
+			}
+			assert.Len(t, r.catalogs[lang], len(r.catalogs[domain.DefaultLanguage]), lang)
+		}
+	})
+
+	t.Run("wrong data type", func(t *testing.T) {
+		_, err := renderer.Render(domain.EmailQuoteCreated, domain.LanguageSpanish, domain.QuoteStateEmail{})
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		_, err := renderer.Render("unknown", domain.LanguageSpanish, nil)
+		assert.Error(t, err)
+	})
+}
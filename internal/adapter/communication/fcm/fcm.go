@@ -0,0 +1,152 @@
+package fcm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// apiURL is the base of the Firebase Cloud Messaging HTTP v1 API
+const apiURL = "https://fcm.googleapis.com/v1"
+
+// messagingScope is the OAuth scope the service account needs to send messages
+const messagingScope = "https://www.googleapis.com/auth/firebase.messaging"
+
+// notificationTitle is the title push notifications are shown with, the same name emails are sent from
+const notificationTitle = "Harajuku"
+
+/**
+ * FCM implements port.MessageSender interface
+ * and sends push notifications through Firebase Cloud Messaging
+ */
+type FCM struct {
+	url    string
+	client *http.Client
+}
+
+type notification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+type message struct {
+	Token        string            `json:"token"`
+	Notification notification      `json:"notification"`
+	Data         map[string]string `json:"data,omitempty"`
+}
+
+type sendPayload struct {
+	Message message `json:"message"`
+}
+
+// errorResponse is the body FCM answers failed requests with
+type errorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+		Status  string `json:"status"`
+		Details []struct {
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// New creates a new FCM sender for the Firebase project of the configuration, authenticated with the
+// service account in the credentials file or, when none is given, the application default credentials
+func New(ctx context.Context, config *config.FCM) (port.MessageSender, error) {
+	if config == nil || config.ProjectID == "" {
+		return nil, errors.New("invalid fcm configuration")
+	}
+
+	var creds *google.Credentials
+	var err error
+	if config.CredentialsFile != "" {
+		data, readErr := os.ReadFile(config.CredentialsFile)
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read fcm credentials: %w", readErr)
+		}
+		creds, err = google.CredentialsFromJSON(ctx, data, messagingScope)
+	} else {
+		creds, err = google.FindDefaultCredentials(ctx, messagingScope)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fcm credentials: %w", err)
+	}
+
+	client := oauth2.NewClient(ctx, creds.TokenSource)
+	client.Timeout = 30 * time.Second
+
+	return &FCM{
+		url:    fmt.Sprintf("%s/projects/%s/messages:send", apiURL, config.ProjectID),
+		client: client,
+	}, nil
+}
+
+// SendMessage sends the message body as a push notification to the device token in To, returning
+// domain.ErrDeviceUnregistered when the app was uninstalled or the token expired
+func (f *FCM) SendMessage(ctx context.Context, msg *domain.Message) error {
+	if msg.To == "" || msg.Body == "" {
+		return errors.New("a device token and a body are required")
+	}
+
+	jsonPayload, err := json.Marshal(sendPayload{
+		Message: message{
+			Token:        msg.To,
+			Notification: notification{Title: notificationTitle, Body: msg.Body},
+			Data:         msg.Data,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal message payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.url, bytes.NewReader(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := f.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		return mapError(res)
+	}
+
+	return nil
+}
+
+// mapError tells apart tokens FCM no longer knows, which should be forgotten, from the other messages it
+// will keep refusing, marked as domain.ErrMessageRejected; quota and server errors are left as they are
+func mapError(res *http.Response) error {
+	var body errorResponse
+	_ = json.NewDecoder(res.Body).Decode(&body)
+
+	for _, detail := range body.Error.Details {
+		if detail.ErrorCode == "UNREGISTERED" {
+			return fmt.Errorf("%w: %s", domain.ErrDeviceUnregistered, body.Error.Message)
+		}
+	}
+
+	switch {
+	case res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500:
+		return fmt.Errorf("message sending failed with status: %d %s", res.StatusCode, body.Error.Message)
+	default:
+		return fmt.Errorf("%w: status %d: %s: %s", domain.ErrMessageRejected, res.StatusCode, body.Error.Status, body.Error.Message)
+	}
+}
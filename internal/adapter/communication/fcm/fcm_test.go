@@ -0,0 +1,72 @@
+package fcm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFCM(t *testing.T, handler http.HandlerFunc) *FCM {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return &FCM{url: server.URL, client: server.Client()}
+}
+
+func TestSendMessage(t *testing.T) {
+	var payload sendPayload
+	f := newTestFCM(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		w.WriteHeader(http.StatusOK)
+	})
+
+	err := f.SendMessage(context.Background(), &domain.Message{
+		To:   "fcm-device-token",
+		Body: "Harajuku: su cita del 14/03/2025 17:30 está confirmada.",
+		Data: map[string]string{"type": "appointment-confirmed"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "fcm-device-token", payload.Message.Token)
+	assert.Equal(t, notification{Title: "Harajuku", Body: "Harajuku: su cita del 14/03/2025 17:30 está confirmada."}, payload.Message.Notification)
+	assert.Equal(t, map[string]string{"type": "appointment-confirmed"}, payload.Message.Data)
+}
+
+func TestSendMessageErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{"unregistered token", http.StatusNotFound, `{"error":{"status":"NOT_FOUND","message":"Requested entity was not found.","details":[{"errorCode":"UNREGISTERED"}]}}`, domain.ErrDeviceUnregistered},
+		{"invalid message", http.StatusBadRequest, `{"error":{"status":"INVALID_ARGUMENT","message":"Invalid value","details":[{"errorCode":"INVALID_ARGUMENT"}]}}`, domain.ErrMessageRejected},
+		{"quota exceeded", http.StatusTooManyRequests, `{"error":{"status":"RESOURCE_EXHAUSTED","message":"Quota exceeded"}}`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newTestFCM(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			err := f.SendMessage(context.Background(), &domain.Message{To: "fcm-device-token", Body: "Hola"})
+			require.Error(t, err)
+			if tt.want != nil {
+				assert.True(t, errors.Is(err, tt.want))
+			} else {
+				assert.False(t, errors.Is(err, domain.ErrMessageRejected))
+				assert.False(t, errors.Is(err, domain.ErrDeviceUnregistered))
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package inbox
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// Email is an email kept by the inbox instead of being delivered
+type Email struct {
+	ID          string
+	To          []string
+	Subject     string
+	Text        string
+	HTML        string
+	Attachments []domain.EmailAttachment
+}
+
+/**
+ * Inbox implements port.EmailRepository interface
+ * and keeps the emails in memory instead of sending them, for the demo mode and the tests
+ */
+type Inbox struct {
+	mu     sync.Mutex
+	emails []Email
+}
+
+// New creates an empty inbox
+func New() *Inbox {
+	return &Inbox{}
+}
+
+// SendEmail keeps the email and logs its recipients and subject, validating it as the providers do
+func (i *Inbox) SendEmail(ctx context.Context, to []string, subject string, textContent string, htmlContent string, attachments ...domain.EmailAttachment) (string, error) {
+	if len(to) == 0 {
+		return "", errors.New("at least one recipient is required")
+	}
+	if textContent == "" && htmlContent == "" {
+		return "", errors.New("either text or HTML content must be provided")
+	}
+
+	email := Email{
+		ID:          uuid.NewString(),
+		To:          append([]string(nil), to...),
+		Subject:     subject,
+		Text:        textContent,
+		HTML:        htmlContent,
+		Attachments: attachments,
+	}
+
+	i.mu.Lock()
+	i.emails = append(i.emails, email)
+	i.mu.Unlock()
+
+	slog.InfoContext(ctx, "Email kept in the inbox", "message_id", email.ID, "to", to, "subject", subject)
+
+	return email.ID, nil
+}
+
+// Emails returns the emails sent so far, oldest first
+func (i *Inbox) Emails() []Email {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return append([]Email(nil), i.emails...)
+}
+
+// EmailsTo returns the emails sent so far to recipient, oldest first
+func (i *Inbox) EmailsTo(recipient string) []Email {
+	var emails []Email
+	for _, email := range i.Emails() {
+		for _, to := range email.To {
+			if to == recipient {
+				emails = append(emails, email)
+				break
+			}
+		}
+	}
+
+	return emails
+}
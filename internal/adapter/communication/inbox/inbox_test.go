@@ -0,0 +1,39 @@
+package inbox
+
+import (
+	"context"
+	"testing"
+
+	"harajuku/backend/internal/core/port"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ port.EmailRepository = (*Inbox)(nil)
+
+func TestInboxKeepsTheEmails(t *testing.T) {
+	ctx := context.Background()
+	inbox := New()
+
+	id, err := inbox.SendEmail(ctx, []string{"ana@harajuku.mx", "admin@harajuku.mx"}, "Tu cotización fue aprobada", "Hola Ana", "")
+	require.NoError(t, err)
+	_, err = inbox.SendEmail(ctx, []string{"admin@harajuku.mx"}, "Nueva cotización", "", "<p>Nueva cotización</p>")
+	require.NoError(t, err)
+
+	emails := inbox.EmailsTo("ana@harajuku.mx")
+	require.Len(t, emails, 1)
+	assert.Equal(t, id, emails[0].ID)
+	assert.Equal(t, "Tu cotización fue aprobada", emails[0].Subject)
+	assert.Len(t, inbox.EmailsTo("admin@harajuku.mx"), 2)
+}
+
+func TestInboxValidatesTheEmails(t *testing.T) {
+	inbox := New()
+
+	_, err := inbox.SendEmail(context.Background(), nil, "Nueva cotización", "Hola", "")
+	assert.Error(t, err)
+	_, err = inbox.SendEmail(context.Background(), []string{"ana@harajuku.mx"}, "Nueva cotización", "", "")
+	assert.Error(t, err)
+	assert.Empty(t, inbox.Emails())
+}
@@ -0,0 +1,180 @@
+package sendgrid
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+)
+
+// sendURL is the SendGrid v3 mail send endpoint
+const sendURL = "https://api.sendgrid.com/v3/mail/send"
+
+/**
+ * SendGrid implements port.EmailRepository interface
+ * and sends emails through the SendGrid v3 API
+ */
+type SendGrid struct {
+	url     string
+	apiKey  string
+	from    string
+	sandbox bool
+	client  *http.Client
+}
+
+type address struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type content struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type attachment struct {
+	Content     string `json:"content"`
+	Type        string `json:"type,omitempty"`
+	Filename    string `json:"filename"`
+	Disposition string `json:"disposition"`
+}
+
+type personalization struct {
+	To []address `json:"to"`
+}
+
+type sendPayload struct {
+	Personalizations []personalization `json:"personalizations"`
+	From             address           `json:"from"`
+	Subject          string            `json:"subject"`
+	Content          []content         `json:"content"`
+	Attachments      []attachment      `json:"attachments,omitempty"`
+	MailSettings     struct {
+		SandboxMode struct {
+			Enable bool `json:"enable"`
+		} `json:"sandbox_mode"`
+	} `json:"mail_settings"`
+}
+
+// errorResponse is the body SendGrid answers rejected requests with
+type errorResponse struct {
+	Errors []struct {
+		Message string `json:"message"`
+		Field   string `json:"field"`
+	} `json:"errors"`
+}
+
+// New creates a new SendGrid email sender, in sandbox mode SendGrid validates emails without delivering them
+func New(config *config.Email) (port.EmailRepository, error) {
+	if config == nil || config.SendGridAPIKey == "" || config.FromEmail == "" {
+		return nil, errors.New("invalid email configuration")
+	}
+
+	return &SendGrid{
+		url:     sendURL,
+		apiKey:  config.SendGridAPIKey,
+		from:    config.FromEmail,
+		sandbox: config.Sandbox,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// SendEmail sends the email with a plain text and an HTML alternative, whichever are provided, and the attachments
+func (sg *SendGrid) SendEmail(ctx context.Context, to []string, subject string, textContent string, htmlContent string, attachments ...domain.EmailAttachment) (string, error) {
+	// Validate at least one recipient
+	if len(to) == 0 {
+		return "", errors.New("at least one recipient is required")
+	}
+
+	// Validate at least one content type is provided
+	if textContent == "" && htmlContent == "" {
+		return "", errors.New("either text or HTML content must be provided")
+	}
+
+	payload := sendPayload{
+		From:    address{Email: sg.from, Name: "Harajuku"},
+		Subject: subject,
+	}
+	recipients := personalization{}
+	for _, recipient := range to {
+		recipients.To = append(recipients.To, address{Email: recipient})
+	}
+	payload.Personalizations = []personalization{recipients}
+
+	// SendGrid requires text/plain to come before text/html
+	if textContent != "" {
+		payload.Content = append(payload.Content, content{"text/plain", textContent})
+	}
+	if htmlContent != "" {
+		payload.Content = append(payload.Content, content{"text/html", htmlContent})
+	}
+	for _, a := range attachments {
+		payload.Attachments = append(payload.Attachments, attachment{
+			Content:     base64.StdEncoding.EncodeToString(a.Content),
+			Type:        a.ContentType,
+			Filename:    a.FileName,
+			Disposition: "attachment",
+		})
+	}
+	payload.MailSettings.SandboxMode.Enable = sg.sandbox
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal email payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sg.url, bytes.NewReader(jsonPayload))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+sg.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := sg.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send email: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		return "", mapError(res)
+	}
+
+	return res.Header.Get("X-Message-Id"), nil
+}
+
+// mapError marks the responses SendGrid will keep returning for the same email as domain.ErrEmailRejected,
+// rate limiting and server errors are left as they are so the email is retried
+func mapError(res *http.Response) error {
+	var body errorResponse
+	_ = json.NewDecoder(res.Body).Decode(&body)
+
+	messages := make([]string, 0, len(body.Errors))
+	for _, e := range body.Errors {
+		if e.Field != "" {
+			messages = append(messages, e.Field+": "+e.Message)
+		} else {
+			messages = append(messages, e.Message)
+		}
+	}
+	detail := strings.Join(messages, "; ")
+
+	switch {
+	case res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500:
+		return fmt.Errorf("email sending failed with status: %d %s", res.StatusCode, detail)
+	default:
+		return fmt.Errorf("%w: status %d: %s", domain.ErrEmailRejected, res.StatusCode, detail)
+	}
+}
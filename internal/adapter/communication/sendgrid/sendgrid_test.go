@@ -0,0 +1,91 @@
+package sendgrid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSendGrid(t *testing.T, handler http.HandlerFunc) *SendGrid {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	repo, err := New(&config.Email{SendGridAPIKey: "SG.test", FromEmail: "no-reply@harajuku.mx", Sandbox: true})
+	require.NoError(t, err)
+
+	sg := repo.(*SendGrid)
+	sg.url = server.URL
+	return sg
+}
+
+func TestSendEmail(t *testing.T) {
+	var payload sendPayload
+	sg := newTestSendGrid(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer SG.test", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		w.Header().Set("X-Message-Id", "sg-message-id")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	id, err := sg.SendEmail(context.Background(), []string{"ana@example.com"}, "Hola", "Hola Ana", "<p>Hola Ana</p>")
+	require.NoError(t, err)
+	assert.Equal(t, "sg-message-id", id)
+
+	assert.True(t, payload.MailSettings.SandboxMode.Enable)
+	assert.Equal(t, []address{{Email: "ana@example.com"}}, payload.Personalizations[0].To)
+	assert.Equal(t, []content{{"text/plain", "Hola Ana"}, {"text/html", "<p>Hola Ana</p>"}}, payload.Content)
+}
+
+func TestSendEmailAttachments(t *testing.T) {
+	var payload sendPayload
+	sg := newTestSendGrid(t, func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		w.WriteHeader(http.StatusOK)
+	})
+
+	_, err := sg.SendEmail(context.Background(), []string{"ana@example.com"}, "Recibo", "Hola Ana", "",
+		domain.EmailAttachment{FileName: "recibo.pdf", Content: []byte("%PDF-1.7"), ContentType: "application/pdf"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []attachment{{
+		Content:     "JVBERi0xLjc=",
+		Type:        "application/pdf",
+		Filename:    "recibo.pdf",
+		Disposition: "attachment",
+	}}, payload.Attachments)
+}
+
+func TestSendEmailErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		rejected bool
+	}{
+		{"invalid recipient is rejected", http.StatusBadRequest, true},
+		{"revoked api key is rejected", http.StatusForbidden, true},
+		{"rate limit is retried", http.StatusTooManyRequests, false},
+		{"server error is retried", http.StatusServiceUnavailable, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := newTestSendGrid(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"errors":[{"message":"Does not contain a valid address.","field":"personalizations.0.to.0.email"}]}`))
+			})
+
+			_, err := sg.SendEmail(context.Background(), []string{"not-an-email"}, "Hola", "Hola", "")
+			require.Error(t, err)
+			assert.Equal(t, tt.rejected, errors.Is(err, domain.ErrEmailRejected))
+		})
+	}
+}
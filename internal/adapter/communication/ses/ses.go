@@ -0,0 +1,151 @@
+package ses
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+	"github.com/aws/smithy-go"
+)
+
+// simulatorAddress is the SES mailbox simulator address sandboxed emails are redirected to,
+// SES accepts and reports them as delivered without sending them anywhere
+const simulatorAddress = "success@simulator.amazonses.com"
+
+// fromName is the display name emails are sent with, the same one used with the Mailtrap API
+const fromName = "Harajuku"
+
+// Client is the subset of the SES API the adapter uses
+type Client interface {
+	SendEmail(ctx context.Context, params *sesv2.SendEmailInput, optFns ...func(*sesv2.Options)) (*sesv2.SendEmailOutput, error)
+}
+
+/**
+ * SES implements port.EmailRepository interface
+ * and sends emails through Amazon Simple Email Service
+ */
+type SES struct {
+	client  Client
+	from    string
+	sandbox bool
+}
+
+// New creates a new SES email sender, credentials are taken from the default AWS chain
+func New(ctx context.Context, config *config.Email) (port.EmailRepository, error) {
+	if config == nil || config.FromEmail == "" {
+		return nil, errors.New("invalid email configuration")
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(config.SESRegion))
+	if err != nil {
+		return nil, err
+	}
+
+	client := sesv2.NewFromConfig(awsCfg)
+
+	// Accounts still in the SES sandbox can only send to verified addresses
+	account, err := client.GetAccount(ctx, &sesv2.GetAccountInput{})
+	if err != nil {
+		slog.Warn("Could not check the SES account status", "error", err)
+	} else if !account.ProductionAccessEnabled {
+		slog.Warn("The SES account is in the sandbox, only verified addresses will receive emails", "region", config.SESRegion)
+	}
+
+	return NewSES(client, config.FromEmail, config.Sandbox), nil
+}
+
+// NewSES creates a new SES email sender from an existing client,
+// in sandbox mode every email goes to the SES mailbox simulator instead of its recipients
+func NewSES(client Client, from string, sandbox bool) *SES {
+	return &SES{
+		client,
+		from,
+		sandbox,
+	}
+}
+
+// SendEmail sends the email with a plain text and an HTML alternative, whichever are provided, and the attachments
+func (s *SES) SendEmail(ctx context.Context, to []string, subject string, textContent string, htmlContent string, attachments ...domain.EmailAttachment) (string, error) {
+	// Validate at least one recipient
+	if len(to) == 0 {
+		return "", errors.New("at least one recipient is required")
+	}
+
+	// Validate at least one content type is provided
+	if textContent == "" && htmlContent == "" {
+		return "", errors.New("either text or HTML content must be provided")
+	}
+
+	recipients := to
+	if s.sandbox {
+		slog.Info("Email redirected to the SES mailbox simulator", "to", to, "subject", subject)
+		recipients = []string{simulatorAddress}
+	}
+
+	body := &types.Body{}
+	if textContent != "" {
+		body.Text = &types.Content{Data: aws.String(textContent), Charset: aws.String("UTF-8")}
+	}
+	if htmlContent != "" {
+		body.Html = &types.Content{Data: aws.String(htmlContent), Charset: aws.String("UTF-8")}
+	}
+
+	files := make([]types.Attachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		files = append(files, types.Attachment{
+			FileName:           aws.String(attachment.FileName),
+			RawContent:         attachment.Content,
+			ContentType:        aws.String(attachment.ContentType),
+			ContentDisposition: types.AttachmentContentDispositionAttachment,
+		})
+	}
+
+	out, err := s.client.SendEmail(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: aws.String((&mail.Address{Name: fromName, Address: s.from}).String()),
+		Destination:      &types.Destination{ToAddresses: recipients},
+		Content: &types.EmailContent{
+			Simple: &types.Message{
+				Subject:     &types.Content{Data: aws.String(subject), Charset: aws.String("UTF-8")},
+				Body:        body,
+				Attachments: files,
+			},
+		},
+	})
+	if err != nil {
+		return "", mapError(err)
+	}
+
+	return aws.ToString(out.MessageId), nil
+}
+
+// mapError marks the errors SES will keep returning for the same email as domain.ErrEmailRejected,
+// throttling and service errors are left as they are so the email is retried
+func mapError(err error) error {
+	var (
+		rejected         *types.MessageRejected
+		unverifiedDomain *types.MailFromDomainNotVerifiedException
+		suspended        *types.AccountSuspendedException
+		paused           *types.SendingPausedException
+		badRequest       *types.BadRequestException
+		notFound         *types.NotFoundException
+	)
+
+	if errors.As(err, &rejected) || errors.As(err, &unverifiedDomain) || errors.As(err, &suspended) ||
+		errors.As(err, &paused) || errors.As(err, &badRequest) || errors.As(err, &notFound) {
+		var apiErr smithy.APIError
+		errors.As(err, &apiErr)
+		return fmt.Errorf("%w: %s: %s", domain.ErrEmailRejected, apiErr.ErrorCode(), apiErr.ErrorMessage())
+	}
+
+	return fmt.Errorf("failed to send email: %w", err)
+}
@@ -0,0 +1,74 @@
+package ses
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient records the last email sent and fails with err when set
+type fakeClient struct {
+	input *sesv2.SendEmailInput
+	err   error
+}
+
+func (c *fakeClient) SendEmail(ctx context.Context, params *sesv2.SendEmailInput, optFns ...func(*sesv2.Options)) (*sesv2.SendEmailOutput, error) {
+	c.input = params
+	return &sesv2.SendEmailOutput{MessageId: aws.String("ses-message-id")}, c.err
+}
+
+func TestSendEmail(t *testing.T) {
+	client := &fakeClient{}
+	s := NewSES(client, "no-reply@harajuku.mx", false)
+
+	id, err := s.SendEmail(context.Background(), []string{"ana@example.com"}, "Hola", "Hola Ana", "<p>Hola Ana</p>")
+	require.NoError(t, err)
+	assert.Equal(t, "ses-message-id", id)
+
+	assert.Equal(t, []string{"ana@example.com"}, client.input.Destination.ToAddresses)
+	assert.Equal(t, `"Harajuku" <no-reply@harajuku.mx>`, *client.input.FromEmailAddress)
+	assert.Equal(t, "Hola Ana", *client.input.Content.Simple.Body.Text.Data)
+	assert.Equal(t, "<p>Hola Ana</p>", *client.input.Content.Simple.Body.Html.Data)
+}
+
+func TestSendEmailSandbox(t *testing.T) {
+	client := &fakeClient{}
+	s := NewSES(client, "no-reply@harajuku.mx", true)
+
+	_, err := s.SendEmail(context.Background(), []string{"ana@example.com"}, "Hola", "Hola Ana", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{simulatorAddress}, client.input.Destination.ToAddresses)
+	assert.Nil(t, client.input.Content.Simple.Body.Html)
+}
+
+func TestSendEmailErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		rejected bool
+	}{
+		{"unverified recipient is rejected", &types.MessageRejected{Message: aws.String("Email address is not verified.")}, true},
+		{"paused sending is rejected", &types.SendingPausedException{Message: aws.String("Sending is paused for this account.")}, true},
+		{"throttling is retried", &types.TooManyRequestsException{Message: aws.String("Maximum sending rate exceeded.")}, false},
+		{"network error is retried", errors.New("connection reset by peer"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSES(&fakeClient{err: tt.err}, "no-reply@harajuku.mx", false)
+
+			_, err := s.SendEmail(context.Background(), []string{"ana@example.com"}, "Hola", "Hola", "")
+			require.Error(t, err)
+			assert.Equal(t, tt.rejected, errors.Is(err, domain.ErrEmailRejected))
+		})
+	}
+}
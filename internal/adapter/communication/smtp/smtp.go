@@ -0,0 +1,326 @@
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+)
+
+// fromName is the display name emails are sent with, the same one used with the Mailtrap API
+const fromName = "Harajuku"
+
+/**
+ * SMTP implements port.EmailRepository interface
+ * and sends emails through a plain SMTP server
+ */
+type SMTP struct {
+	host    string
+	addr    string
+	auth    smtp.Auth
+	tls     string
+	from    string
+	timeout time.Duration
+}
+
+// New creates a new SMTP email sender
+func New(config *config.SMTP) (port.EmailRepository, error) {
+	if config == nil || config.Host == "" || config.FromEmail == "" {
+		return nil, errors.New("invalid smtp configuration")
+	}
+
+	switch config.TLS {
+	case "starttls", "tls", "none":
+	default:
+		return nil, fmt.Errorf("invalid smtp tls mode %q", config.TLS)
+	}
+
+	var auth smtp.Auth
+	if config.Username != "" {
+		auth = smtp.PlainAuth("", config.Username, config.Password, config.Host)
+	}
+
+	return &SMTP{
+		host:    config.Host,
+		addr:    net.JoinHostPort(config.Host, config.Port),
+		auth:    auth,
+		tls:     config.TLS,
+		from:    config.FromEmail,
+		timeout: 30 * time.Second,
+	}, nil
+}
+
+// SendEmail sends the email with a plain text and an HTML alternative, whichever are provided, and the attachments
+func (s *SMTP) SendEmail(ctx context.Context, to []string, subject string, textContent string, htmlContent string, attachments ...domain.EmailAttachment) (string, error) {
+	// Validate at least one recipient
+	if len(to) == 0 {
+		return "", errors.New("at least one recipient is required")
+	}
+
+	// Validate at least one content type is provided
+	if textContent == "" && htmlContent == "" {
+		return "", errors.New("either text or HTML content must be provided")
+	}
+
+	id := messageID(s.from)
+	message, err := buildMessage(s.from, to, subject, textContent, htmlContent, attachments, id, time.Now())
+	if err != nil {
+		return "", fmt.Errorf("failed to build email: %w", err)
+	}
+
+	client, err := s.dial(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to smtp server: %w", err)
+	}
+	defer client.Close()
+
+	if s.tls == "starttls" {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return "", fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+
+	if s.auth != nil {
+		if err := client.Auth(s.auth); err != nil {
+			return "", fmt.Errorf("smtp authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.from); err != nil {
+		return "", fmt.Errorf("failed to send email: %w", err)
+	}
+	for _, recipient := range to {
+		if err := client.Rcpt(recipient); err != nil {
+			return "", fmt.Errorf("recipient %s rejected: %w", recipient, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return "", fmt.Errorf("failed to send email: %w", err)
+	}
+	if _, err := w.Write(message); err != nil {
+		return "", fmt.Errorf("failed to send email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("failed to send email: %w", err)
+	}
+
+	if err := client.Quit(); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// dial connects to the server, the connection deadline follows ctx so a stalled server does not block the caller
+func (s *SMTP) dial(ctx context.Context) (*smtp.Client, error) {
+	deadline := time.Now().Add(s.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	dialer := &net.Dialer{Deadline: deadline}
+
+	var conn net.Conn
+	var err error
+	if s.tls == "tls" {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: s.host}}).DialContext(ctx, "tcp", s.addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", s.addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// buildMessage writes a MIME email, multipart/alternative when it has both a text and an HTML part,
+// wrapped in multipart/mixed along with the attachments when there are any
+func buildMessage(from string, to []string, subject, textContent, htmlContent string, attachments []domain.EmailAttachment, id string, date time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+
+	recipients := make([]string, len(to))
+	for i, recipient := range to {
+		recipients[i] = (&mail.Address{Address: recipient}).String()
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("From", (&mail.Address{Name: fromName, Address: from}).String())
+	header.Set("To", strings.Join(recipients, ", "))
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", subject))
+	header.Set("Date", date.Format(time.RFC1123Z))
+	header.Set("Message-ID", id)
+	header.Set("MIME-Version", "1.0")
+
+	bodyHeader, body, err := buildBody(textContent, htmlContent)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(attachments) == 0 {
+		for key, values := range bodyHeader {
+			header[key] = values
+		}
+		writeHeader(&buf, header)
+		buf.Write(body)
+		return buf.Bytes(), nil
+	}
+
+	parts := multipart.NewWriter(&buf)
+	header.Set("Content-Type", "multipart/mixed; boundary="+parts.Boundary())
+	writeHeader(&buf, header)
+
+	w, err := parts.CreatePart(bodyHeader)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(body); err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range attachments {
+		contentType := attachment.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(contentType, map[string]string{"name": attachment.FileName})},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeBase64(w, attachment.Content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// buildBody encodes the text and HTML content, returning the headers that describe it
+func buildBody(textContent, htmlContent string) (textproto.MIMEHeader, []byte, error) {
+	var buf bytes.Buffer
+
+	if textContent == "" || htmlContent == "" {
+		contentType, content := "text/plain; charset=utf-8", textContent
+		if textContent == "" {
+			contentType, content = "text/html; charset=utf-8", htmlContent
+		}
+		if err := writeQuotedPrintable(&buf, content); err != nil {
+			return nil, nil, err
+		}
+		return textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		}, buf.Bytes(), nil
+	}
+
+	parts := multipart.NewWriter(&buf)
+
+	// The preferred alternative goes last
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", textContent},
+		{"text/html; charset=utf-8", htmlContent},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := writeQuotedPrintable(w, part.content); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := parts.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	return textproto.MIMEHeader{
+		"Content-Type": {"multipart/alternative; boundary=" + parts.Boundary()},
+	}, buf.Bytes(), nil
+}
+
+// writeHeader writes the header fields in a stable order followed by the blank line that ends them
+func writeHeader(buf *bytes.Buffer, header textproto.MIMEHeader) {
+	for _, key := range []string{"From", "To", "Subject", "Date", "Message-ID", "MIME-Version", "Content-Type", "Content-Transfer-Encoding"} {
+		if value := header.Get(key); value != "" {
+			fmt.Fprintf(buf, "%s: %s\r\n", key, value)
+		}
+	}
+	buf.WriteString("\r\n")
+}
+
+// writeBase64 encodes content in lines of 76 characters, the longest MIME allows
+func writeBase64(w io.Writer, content []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(content)
+	for len(encoded) > 76 {
+		if _, err := io.WriteString(w, encoded[:76]+"\r\n"); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err := io.WriteString(w, encoded+"\r\n")
+	return err
+}
+
+// writeQuotedPrintable encodes content so long lines and non-ASCII text survive any SMTP relay
+func writeQuotedPrintable(w io.Writer, content string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// messageID generates a unique Message-ID in the domain of the sender
+func messageID(from string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(from, "@"); at >= 0 {
+		domain = from[at+1:]
+	}
+
+	id := make([]byte, 16)
+	rand.Read(id)
+
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(id), domain)
+}
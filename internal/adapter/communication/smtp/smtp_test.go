@@ -0,0 +1,171 @@
+package smtp
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildMessage(t *testing.T) {
+	raw, err := buildMessage("no-reply@harajuku.mx", []string{"ana@example.com"}, "Respuesta a su cotización", "Hola Ana", "<p>Hola Ana</p>", nil, "<test@harajuku.mx>", time.Now())
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Respuesta a su cotización", subject)
+	assert.Equal(t, `"Harajuku" <no-reply@harajuku.mx>`, msg.Header.Get("From"))
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	var contents []string
+	for {
+		part, err := parts.NextRawPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		body, err := io.ReadAll(quotedprintable.NewReader(part))
+		require.NoError(t, err)
+		contents = append(contents, part.Header.Get("Content-Type")+": "+string(body))
+	}
+
+	assert.Equal(t, []string{
+		"text/plain; charset=utf-8: Hola Ana",
+		"text/html; charset=utf-8: <p>Hola Ana</p>",
+	}, contents)
+}
+
+func TestBuildMessageWithAttachments(t *testing.T) {
+	attachment := domain.EmailAttachment{FileName: "cotización.pdf", Content: []byte("%PDF-1.7"), ContentType: "application/pdf"}
+	raw, err := buildMessage("no-reply@harajuku.mx", []string{"ana@example.com"}, "Cotización", "Hola Ana", "<p>Hola Ana</p>", []domain.EmailAttachment{attachment}, "<test@harajuku.mx>", time.Now())
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	require.NoError(t, err)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/mixed", mediaType)
+
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+
+	body, err := parts.NextRawPart()
+	require.NoError(t, err)
+	mediaType, _, err = mime.ParseMediaType(body.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	file, err := parts.NextRawPart()
+	require.NoError(t, err)
+	assert.Equal(t, "cotización.pdf", file.FileName())
+	content, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, file))
+	require.NoError(t, err)
+	assert.Equal(t, attachment.Content, content)
+
+	_, err = parts.NextRawPart()
+	assert.Equal(t, io.EOF, err)
+}
+
+// fakeServer accepts one SMTP session and returns the recipients and data it received
+func fakeServer(t *testing.T) (string, <-chan []string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan []string, 1)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+		var lines []string
+
+		reply("220 fake ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+
+			switch {
+			case strings.HasPrefix(line, "EHLO"):
+				reply("250 fake")
+			case strings.HasPrefix(line, "MAIL FROM"), strings.HasPrefix(line, "RCPT TO"):
+				lines = append(lines, line)
+				reply("250 OK")
+			case line == "DATA":
+				reply("354 go ahead")
+				for {
+					data, err := r.ReadString('\n')
+					if err != nil || data == ".\r\n" {
+						break
+					}
+				}
+				reply("250 queued")
+			case line == "QUIT":
+				reply("221 bye")
+				received <- lines
+				return
+			default:
+				reply("502 unknown")
+			}
+		}
+	}()
+
+	return listener.Addr().String(), received
+}
+
+func TestSendEmail(t *testing.T) {
+	addr, received := fakeServer(t)
+	host, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+
+	sender, err := New(&config.SMTP{Host: host, Port: port, TLS: "none", FromEmail: "no-reply@harajuku.mx"})
+	require.NoError(t, err)
+
+	id, err := sender.SendEmail(context.Background(), []string{"ana@example.com", "luis@example.com"}, "Hola", "Hola", "")
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(id, "@harajuku.mx>"))
+
+	assert.Equal(t, []string{
+		"MAIL FROM:<no-reply@harajuku.mx>",
+		"RCPT TO:<ana@example.com>",
+		"RCPT TO:<luis@example.com>",
+	}, <-received)
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	_, err := New(&config.SMTP{Host: "smtp.example.com", Port: "587", TLS: "ssl", FromEmail: "no-reply@harajuku.mx"})
+	assert.Error(t, err)
+
+	_, err = New(&config.SMTP{Port: "587", TLS: "none", FromEmail: "no-reply@harajuku.mx"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,111 @@
+package twilio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+)
+
+// apiURL is the base of the Twilio REST API
+const apiURL = "https://api.twilio.com/2010-04-01"
+
+/**
+ * Twilio implements port.MessageSender interface
+ * and sends text messages through the Twilio Programmable Messaging API
+ */
+type Twilio struct {
+	url                 string
+	accountSID          string
+	authToken           string
+	from                string
+	messagingServiceSID string
+	client              *http.Client
+}
+
+// errorResponse is the body Twilio answers failed requests with
+type errorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// New creates a new Twilio SMS sender, messages are sent from the messaging service when one is configured
+// and from the configured number otherwise
+func New(config *config.Twilio) (port.MessageSender, error) {
+	if config == nil || config.AccountSID == "" || config.AuthToken == "" {
+		return nil, errors.New("invalid twilio configuration")
+	}
+	if config.FromNumber == "" && config.MessagingServiceSID == "" {
+		return nil, errors.New("twilio requires a from number or a messaging service")
+	}
+
+	return &Twilio{
+		url:                 apiURL,
+		accountSID:          config.AccountSID,
+		authToken:           config.AuthToken,
+		from:                config.FromNumber,
+		messagingServiceSID: config.MessagingServiceSID,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// SendMessage sends the message as an SMS
+func (t *Twilio) SendMessage(ctx context.Context, message *domain.Message) error {
+	if message.To == "" || message.Body == "" {
+		return errors.New("a recipient and a body are required")
+	}
+
+	form := url.Values{}
+	form.Set("To", message.To)
+	form.Set("Body", message.Body)
+	if t.messagingServiceSID != "" {
+		form.Set("MessagingServiceSid", t.messagingServiceSID)
+	} else {
+		form.Set("From", t.from)
+	}
+
+	endpoint := fmt.Sprintf("%s/Accounts/%s/Messages.json", t.url, t.accountSID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.SetBasicAuth(t.accountSID, t.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		return mapError(res)
+	}
+
+	return nil
+}
+
+// mapError marks the responses Twilio will keep returning for the same message as domain.ErrMessageRejected,
+// rate limiting and server errors are left as they are
+func mapError(res *http.Response) error {
+	var body errorResponse
+	_ = json.NewDecoder(res.Body).Decode(&body)
+
+	switch {
+	case res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500:
+		return fmt.Errorf("message sending failed with status: %d %s", res.StatusCode, body.Message)
+	default:
+		return fmt.Errorf("%w: status %d: code %d: %s", domain.ErrMessageRejected, res.StatusCode, body.Code, body.Message)
+	}
+}
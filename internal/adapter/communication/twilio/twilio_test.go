@@ -0,0 +1,89 @@
+package twilio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTwilio(t *testing.T, cfg *config.Twilio, handler http.HandlerFunc) *Twilio {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	sender, err := New(cfg)
+	require.NoError(t, err)
+
+	tw := sender.(*Twilio)
+	tw.url = server.URL
+	return tw
+}
+
+func TestSendMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *config.Twilio
+		wantFrom string
+		wantSID  string
+	}{
+		{"from number", &config.Twilio{AccountSID: "AC123", AuthToken: "secret", FromNumber: "+15005550006"}, "+15005550006", ""},
+		{"messaging service", &config.Twilio{AccountSID: "AC123", AuthToken: "secret", FromNumber: "+15005550006", MessagingServiceSID: "MG123"}, "", "MG123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tw := newTestTwilio(t, tt.config, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/Accounts/AC123/Messages.json", r.URL.Path)
+
+				user, password, ok := r.BasicAuth()
+				assert.True(t, ok)
+				assert.Equal(t, "AC123", user)
+				assert.Equal(t, "secret", password)
+
+				require.NoError(t, r.ParseForm())
+				assert.Equal(t, "+5215512345678", r.PostForm.Get("To"))
+				assert.Equal(t, "Harajuku: su cita ha sido confirmada.", r.PostForm.Get("Body"))
+				assert.Equal(t, tt.wantFrom, r.PostForm.Get("From"))
+				assert.Equal(t, tt.wantSID, r.PostForm.Get("MessagingServiceSid"))
+
+				w.WriteHeader(http.StatusCreated)
+			})
+
+			err := tw.SendMessage(context.Background(), &domain.Message{To: "+5215512345678", Body: "Harajuku: su cita ha sido confirmada."})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSendMessageErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		rejected bool
+	}{
+		{"invalid number is rejected", http.StatusBadRequest, true},
+		{"wrong credentials are rejected", http.StatusUnauthorized, true},
+		{"rate limit is not rejected", http.StatusTooManyRequests, false},
+		{"server error is not rejected", http.StatusServiceUnavailable, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tw := newTestTwilio(t, &config.Twilio{AccountSID: "AC123", AuthToken: "secret", FromNumber: "+15005550006"}, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"code":21211,"message":"The 'To' number is not a valid phone number."}`))
+			})
+
+			err := tw.SendMessage(context.Background(), &domain.Message{To: "+1", Body: "Hola"})
+			require.Error(t, err)
+			assert.Equal(t, tt.rejected, errors.Is(err, domain.ErrMessageRejected))
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+)
+
+// Headers sent with every delivery, subscribers verify the signature with the secret of their webhook
+const (
+	eventHeader     = "X-Harajuku-Event"
+	deliveryHeader  = "X-Harajuku-Delivery"
+	signatureHeader = "X-Harajuku-Signature"
+)
+
+/**
+ * Sender implements port.WebhookSender interface
+ * and posts events to webhook URLs signed with HMAC-SHA256
+ */
+type Sender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// body is the JSON document an event is posted as
+type body struct {
+	ID         string           `json:"id"`
+	Type       domain.EventType `json:"type"`
+	OccurredAt time.Time        `json:"occurredAt"`
+	Data       any              `json:"data"`
+}
+
+// New creates a new webhook sender
+func New() *Sender {
+	return &Sender{
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		now: time.Now,
+	}
+}
+
+// Send posts the event to the webhook URL, any response other than 2xx is a failure.
+// The signature header is "t=<unix timestamp>,v1=<hex HMAC-SHA256 of "<t>.<body>">", the same scheme Stripe uses
+func (s *Sender) Send(ctx context.Context, webhook *domain.Webhook, event domain.Event) error {
+	payload, err := json.Marshal(body{
+		ID:         event.ID.String(),
+		Type:       event.Type,
+		OccurredAt: event.OccurredAt,
+		Data:       event.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Harajuku-Webhooks/1.0")
+	req.Header.Set(eventHeader, string(event.Type))
+	req.Header.Set(deliveryHeader, event.ID.String())
+	req.Header.Set(signatureHeader, "t="+timestamp+",v1="+Sign(webhook.Secret, timestamp, payload))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 4096))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook answered with status: %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign computes the signature of a payload sent at timestamp
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
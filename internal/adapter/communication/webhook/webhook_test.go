@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSend(t *testing.T) {
+	var header http.Header
+	var payload []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		payload, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := New()
+	sender.now = func() time.Time { return time.Unix(1700000000, 0) }
+
+	quoteID := uuid.New()
+	event := domain.NewEvent(domain.EventQuoteCreated, domain.QuoteCreatedEvent{QuoteID: quoteID, Description: "Uñas acrílicas"})
+	webhook := &domain.Webhook{URL: server.URL, Secret: "whsec_test"}
+
+	err := sender.Send(context.Background(), webhook, event)
+	require.NoError(t, err)
+
+	assert.Equal(t, "quote.created", header.Get("X-Harajuku-Event"))
+	assert.Equal(t, event.ID.String(), header.Get("X-Harajuku-Delivery"))
+	assert.Equal(t, "t=1700000000,v1="+Sign("whsec_test", "1700000000", payload), header.Get("X-Harajuku-Signature"))
+
+	var body struct {
+		Type string                   `json:"type"`
+		Data domain.QuoteCreatedEvent `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(payload, &body))
+	assert.Equal(t, "quote.created", body.Type)
+	assert.Equal(t, quoteID, body.Data.QuoteID)
+}
+
+func TestSendRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := New().Send(context.Background(), &domain.Webhook{URL: server.URL, Secret: "whsec_test"}, domain.NewEvent(domain.EventPaymentReviewed, domain.PaymentReviewedEvent{}))
+	assert.Error(t, err)
+}
@@ -0,0 +1,157 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+)
+
+// graphURL is the base of the Meta Graph API the WhatsApp Cloud API is served from
+const graphURL = "https://graph.facebook.com"
+
+/**
+ * WhatsApp implements port.MessageSender interface
+ * and sends messages through the WhatsApp Business Cloud API
+ */
+type WhatsApp struct {
+	url           string
+	accessToken   string
+	phoneNumberID string
+	client        *http.Client
+}
+
+type textBody struct {
+	Body string `json:"body"`
+}
+
+type templateParameter struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type templateComponent struct {
+	Type       string              `json:"type"`
+	Parameters []templateParameter `json:"parameters"`
+}
+
+type templateBody struct {
+	Name     string `json:"name"`
+	Language struct {
+		Code string `json:"code"`
+	} `json:"language"`
+	Components []templateComponent `json:"components,omitempty"`
+}
+
+type sendPayload struct {
+	MessagingProduct string        `json:"messaging_product"`
+	To               string        `json:"to"`
+	Type             string        `json:"type"`
+	Text             *textBody     `json:"text,omitempty"`
+	Template         *templateBody `json:"template,omitempty"`
+}
+
+// errorResponse is the body the Graph API answers failed requests with
+type errorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+		Code    int    `json:"code"`
+	} `json:"error"`
+}
+
+// New creates a new WhatsApp sender for the business phone number of the configuration
+func New(config *config.WhatsApp) (port.MessageSender, error) {
+	if config == nil || config.AccessToken == "" || config.PhoneNumberID == "" {
+		return nil, errors.New("invalid whatsapp configuration")
+	}
+
+	return &WhatsApp{
+		url:           fmt.Sprintf("%s/%s", graphURL, config.APIVersion),
+		accessToken:   config.AccessToken,
+		phoneNumberID: config.PhoneNumberID,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// SendMessage sends the message's template, which WhatsApp requires to start a conversation,
+// or its body as free text when it has none
+func (w *WhatsApp) SendMessage(ctx context.Context, message *domain.Message) error {
+	if message.To == "" || (message.Body == "" && message.Template == nil) {
+		return errors.New("a recipient and a body or template are required")
+	}
+
+	payload := sendPayload{
+		MessagingProduct: "whatsapp",
+		// The Cloud API takes the number without the leading plus sign
+		To: strings.TrimPrefix(message.To, "+"),
+	}
+
+	if message.Template != nil {
+		template := &templateBody{Name: message.Template.Name}
+		template.Language.Code = string(message.Template.Language.OrDefault())
+
+		if len(message.Template.Parameters) > 0 {
+			body := templateComponent{Type: "body"}
+			for _, parameter := range message.Template.Parameters {
+				body.Parameters = append(body.Parameters, templateParameter{Type: "text", Text: parameter})
+			}
+			template.Components = []templateComponent{body}
+		}
+
+		payload.Type = "template"
+		payload.Template = template
+	} else {
+		payload.Type = "text"
+		payload.Text = &textBody{Body: message.Body}
+	}
+
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message payload: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/messages", w.url, w.phoneNumberID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+w.accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		return mapError(res)
+	}
+
+	return nil
+}
+
+// mapError marks the responses the Cloud API will keep returning for the same message as domain.ErrMessageRejected,
+// e.g. an unknown template or a number without WhatsApp, rate limiting and server errors are left as they are
+func mapError(res *http.Response) error {
+	var body errorResponse
+	_ = json.NewDecoder(res.Body).Decode(&body)
+
+	switch {
+	case res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500:
+		return fmt.Errorf("message sending failed with status: %d %s", res.StatusCode, body.Error.Message)
+	default:
+		return fmt.Errorf("%w: status %d: code %d: %s", domain.ErrMessageRejected, res.StatusCode, body.Error.Code, body.Error.Message)
+	}
+}
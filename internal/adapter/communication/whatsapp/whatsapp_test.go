@@ -0,0 +1,104 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWhatsApp(t *testing.T, handler http.HandlerFunc) *WhatsApp {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	sender, err := New(&config.WhatsApp{AccessToken: "EAAG", PhoneNumberID: "1065", APIVersion: "v21.0"})
+	require.NoError(t, err)
+
+	w := sender.(*WhatsApp)
+	w.url = server.URL
+	return w
+}
+
+func TestSendMessage(t *testing.T) {
+	t.Run("template", func(t *testing.T) {
+		var payload sendPayload
+		w := newTestWhatsApp(t, func(rw http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/1065/messages", r.URL.Path)
+			assert.Equal(t, "Bearer EAAG", r.Header.Get("Authorization"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			rw.WriteHeader(http.StatusOK)
+		})
+
+		err := w.SendMessage(context.Background(), &domain.Message{
+			To:   "+5215512345678",
+			Body: "Harajuku: le recordamos su cita del 14/03/2025 17:30.",
+			Template: &domain.MessageTemplate{
+				Name:       "appointment_reminder",
+				Language:   domain.LanguageSpanish,
+				Parameters: []string{"Ana", "14/03/2025 17:30"},
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "whatsapp", payload.MessagingProduct)
+		assert.Equal(t, "5215512345678", payload.To)
+		assert.Equal(t, "template", payload.Type)
+		assert.Nil(t, payload.Text)
+		require.NotNil(t, payload.Template)
+		assert.Equal(t, "appointment_reminder", payload.Template.Name)
+		assert.Equal(t, "es", payload.Template.Language.Code)
+		assert.Equal(t, []templateComponent{{
+			Type:       "body",
+			Parameters: []templateParameter{{"text", "Ana"}, {"text", "14/03/2025 17:30"}},
+		}}, payload.Template.Components)
+	})
+
+	t.Run("free text", func(t *testing.T) {
+		var payload sendPayload
+		w := newTestWhatsApp(t, func(rw http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			rw.WriteHeader(http.StatusOK)
+		})
+
+		err := w.SendMessage(context.Background(), &domain.Message{To: "+5215512345678", Body: "Hola"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "text", payload.Type)
+		assert.Equal(t, &textBody{Body: "Hola"}, payload.Text)
+		assert.Nil(t, payload.Template)
+	})
+}
+
+func TestSendMessageErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		rejected bool
+	}{
+		{"unknown template is rejected", http.StatusBadRequest, true},
+		{"expired token is rejected", http.StatusUnauthorized, true},
+		{"rate limit is not rejected", http.StatusTooManyRequests, false},
+		{"server error is not rejected", http.StatusInternalServerError, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newTestWhatsApp(t, func(rw http.ResponseWriter, r *http.Request) {
+				rw.WriteHeader(tt.status)
+				rw.Write([]byte(`{"error":{"message":"(#132001) Template name does not exist in the translation","code":132001}}`))
+			})
+
+			err := w.SendMessage(context.Background(), &domain.Message{To: "+5215512345678", Body: "Hola"})
+			require.Error(t, err)
+			assert.Equal(t, tt.rejected, errors.Is(err, domain.ErrMessageRejected))
+		})
+	}
+}
@@ -1,7 +1,11 @@
 package config
 
 import (
+	"errors"
+	"io/fs"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -16,20 +20,69 @@ type (
 		HTTP  *HTTP
     Email *Email
     AwsS3 *AwsS3
+		PaymentWebhook *PaymentWebhook
+		Storage *Storage
+		Minio   *Minio
+		GCS     *GCS
+		ClamAV  *ClamAV
+		CloudFront *CloudFront
+		SMTP    *SMTP
+		Twilio  *Twilio
+		WhatsApp *WhatsApp
+		FCM      *FCM
+		RateLimit *RateLimit
+		Telemetry *Telemetry
+		GRPC      *GRPC
+		Quota     *Quota
+		Secrets   *Secrets
+		Jobs      *Jobs
+		Schedule  *Schedule
+		Outbox    *Outbox
+		Sentry    *Sentry
 	}
 	// App contains all the environment variables for the application
 	App struct {
 		Name string
 		Env  string
+		// DefaultCurrency is used for prices created without an explicit currency
+		DefaultCurrency string
+		// ExchangeRates converts other currencies into the default one, e.g. "USD:17.05,EUR:18.40"
+		ExchangeRates string
+		// ReminderLead is how long before an appointment its client is reminded of it
+		ReminderLead string
+		// WebhookMaxAttempts is how many times an event is posted to a webhook before it is given up on
+		WebhookMaxAttempts int
+		// WebhookRetryDelay is the wait before the first retry of a webhook, doubled after every failed attempt
+		WebhookRetryDelay string
 	}
 	// Token contains all the environment variables for the token service
 	Token struct {
 		Duration string
+		// SymmetricKey is the hex encoded 32 byte key tokens are encrypted with, a random one is made on every start when it is empty
+		SymmetricKey string
 	}
 	// Redis contains all the environment variables for the cache service
 	Redis struct {
+		// Provider is "redis", "memory" or "none", it only selects the cache since the email queue always uses redis
+		Provider string
+		// MemorySize is how many values the memory provider keeps before evicting the least recently used
+		MemorySize int
+		// Mode is "standalone", "sentinel" or "cluster"
+		Mode string
+		// Addr is the redis address, or the sentinel or cluster node addresses separated by commas
 		Addr     string
 		Password string
+		// MasterName is the name of the master monitored by the sentinels
+		MasterName       string
+		SentinelPassword string
+		TLS              bool
+		// DefaultTTL is how long cached values are kept when their entity has no TTL of its own
+		DefaultTTL string
+		// TTLs are the TTLs per entity, written as "quote:10m,quotes:2m"
+		TTLs string
+		// Version namespaces the cache keys with the app name and environment, bumping it
+		// drops every value cached with the previous shape of the entities
+		Version string
 	}
 	// Database contains all the environment variables for the database
 	DB struct {
@@ -39,48 +92,354 @@ type (
 		User       string
 		Password   string
 		Name       string
+		// ReplicaURLs are the URLs of the read replicas separated by commas, list endpoints read from them
+		ReplicaURLs string
+		// Pool settings applied to the primary and the replicas, zero values keep the pgxpool defaults
+		MaxConns          int
+		MinConns          int
+		MaxConnLifetime   string
+		HealthCheckPeriod string
+		// QueryTimeout bounds every query and SlowQueryThreshold logs the queries taking longer, empty disables them
+		QueryTimeout       string
+		SlowQueryThreshold string
+		// QueryLogSampleRatio is the fraction of the queries logged at the debug level, between 0 and 1
+		QueryLogSampleRatio float64
+		// QueryLogArgs logs the values of the query args instead of their types, it can't be enabled in production
+		QueryLogArgs bool
 	}
 	// HTTP contains all the environment variables for the http server
 	HTTP struct {
 		Env            string
 		URL            string
 		Port           string
+		// AllowedOrigins are the origins, separated by commas, browsers may call the API from. An origin can
+		// start with a wildcard subdomain, e.g. https://*.harajuku.mx. HTTP_ALLOWED_ORIGINS_<APP_ENV>, e.g.
+		// HTTP_ALLOWED_ORIGINS_STAGING, replaces HTTP_ALLOWED_ORIGINS in that environment
 		AllowedOrigins string
+		// CORSDevMode allows any localhost origin on top of AllowedOrigins, it can't be enabled in production
+		CORSDevMode bool
+		// HealthToken lets load balancers and other internal callers check the health endpoints without
+		// signing in, sent in the X-Health-Token header. Empty restricts them to admins
+		HealthToken string
+		// TrustedProxies are the proxies, separated by commas, whose X-Forwarded-For header gives the client IP
+		// the rate limits count by. Empty trusts every proxy, which lets clients pick their own IP
+		TrustedProxies string
+		// ReadinessTimeout is how long each dependency gets to answer the readiness probe
+		ReadinessTimeout string
+		// ShutdownTimeout is how long the requests in flight get to finish when the server is stopped
+		ShutdownTimeout string
+		// SlowRequestThreshold logs the requests taking longer, empty disables the log
+		SlowRequestThreshold string
+		// CompressionLevel is the gzip level of the responses from 1 (fastest) to 9 (smallest), 0 disables it
+		CompressionLevel int
+		// CompressionMinSize is the size in bytes from which responses are compressed
+		CompressionMinSize int
+		// MaxBodySize is the maximum size in bytes of the request bodies, such as JSON
+		MaxBodySize int64
+		// MaxUploadSize is the maximum size in bytes of the image and document uploads
+		MaxUploadSize int64
+		// MaxMediaUploadSize is the maximum size in bytes of the uploads that may be videos, like the quote media
+		MaxMediaUploadSize int64
+		// TLSCertFile and TLSKeyFile are the PEM files of the certificate the server terminates TLS with,
+		// both empty serves plain HTTP
+		TLSCertFile string
+		TLSKeyFile  string
+		// AutocertDomains are the domains, separated by commas, to get certificates for from Let's Encrypt
+		// instead of the files. They are stored in AutocertCacheDir, and AutocertEmail is told about problems
+		AutocertDomains  string
+		AutocertCacheDir string
+		AutocertEmail    string
+		// H2C serves HTTP/2 without TLS, for load balancers that speak it to the server. Over TLS HTTP/2 is always on
+		H2C bool
+		// GraphQL serves the GraphQL gateway of the dashboard at /graphql
+		GraphQL bool
 	}
 
   Email struct {
+    // Provider is "mailtrap", "smtp", "ses", "sendgrid" or "memory", which keeps the emails in memory
+    Provider string
     Url string
     ApiToken string
     FromEmail string
+    SESRegion string
+    SendGridAPIKey string
+    // Sandbox makes SES and SendGrid accept emails without delivering them
+    Sandbox bool
+    // QueueMaxAttempts is how many times a queued email is tried before it is dead-lettered
+    QueueMaxAttempts int
+    // QueueRetryDelay is the wait before the first retry, doubled on every following one
+    QueueRetryDelay string
+    QueuePollInterval string
   }
 
   AwsS3 struct {
     Bucket string
     Region string
+    // Endpoint overrides the AWS endpoint, e.g. for an S3-compatible store
+    Endpoint string
+    // AccessKey and SecretKey are optional, the default credential chain is used without them
+    AccessKey string
+    SecretKey string
+    UsePathStyle bool
+    // MaxAttempts is how many times a request is tried before giving up
+    MaxAttempts int
+    // PartSize is the size in bytes of each part of a multipart upload, and so the memory an upload takes
+    PartSize int64
   }
+
+	// PaymentWebhook contains the signing secrets shared with each payment provider
+	PaymentWebhook struct {
+		StripeSecret      string
+		MercadoPagoSecret string
+		BankSecret        string
+		// MercadoPagoAccessToken fetches the notified payments from the MercadoPago API, as its
+		// notifications only sign the payment ID
+		MercadoPagoAccessToken string
+		// MercadoPagoAPIURL is the base URL of the MercadoPago API
+		MercadoPagoAPIURL string
+	}
+	// Storage selects where uploaded files are kept
+	Storage struct {
+		// Provider is "s3", "minio", "gcs", "local" or "memory", which loses the files on restart
+		Provider string
+		// LocalDir is the directory used by the local provider
+		LocalDir string
+	}
+	// Minio contains the connection to an S3-compatible object store such as MinIO
+	Minio struct {
+		Endpoint  string
+		Region    string
+		Bucket    string
+		AccessKey string
+		SecretKey string
+		UseSSL    bool
+	}
+	// GCS contains the Google Cloud Storage bucket, credentials fall back to the application default ones when no file is given
+	GCS struct {
+		Bucket          string
+		CredentialsFile string
+	}
+	// ClamAV contains the clamd daemon uploads are scanned with, scanning is disabled when no address is given
+	ClamAV struct {
+		Addr    string
+		Timeout string
+	}
+	// CloudFront contains the distribution file URLs are signed for, files are returned by key when no domain is given
+	CloudFront struct {
+		Domain         string
+		KeyPairID      string
+		PrivateKeyFile string
+		TTL            string
+	}
+	// SMTP contains the mail server emails are sent through when the email provider is "smtp"
+	SMTP struct {
+		Host     string
+		Port     string
+		Username string
+		Password string
+		// TLS is "starttls", "tls" for implicit TLS (usually port 465) or "none"
+		TLS       string
+		FromEmail string
+	}
+	// Twilio contains the account text messages are sent through, they are disabled when no account is given
+	Twilio struct {
+		AccountSID string
+		AuthToken  string
+		// FromNumber is the E.164 number messages are sent from when no messaging service is given
+		FromNumber          string
+		MessagingServiceSID string
+	}
+	// WhatsApp contains the WhatsApp Business number messages are sent from, they are disabled when no token is given.
+	// Messages are sent as templates named like the event in snake case, approved in every supported language
+	WhatsApp struct {
+		AccessToken   string
+		PhoneNumberID string
+		APIVersion    string
+	}
+	// FCM contains the Firebase project push notifications are sent through, they are disabled when no project is given.
+	// Without a credentials file the application default credentials are used
+	FCM struct {
+		ProjectID       string
+		CredentialsFile string
+	}
+	// RateLimit contains the rate limits of the route groups, written as "10/1m" for a bucket of
+	// 10 requests refilled over a minute. An empty limit leaves the group unlimited
+	RateLimit struct {
+		// Auth applies to login and registration
+		Auth string
+		// Upload applies to the routes receiving files
+		Upload string
+	}
+	// Quota contains the daily quotas of the roles, how many times a day each of their users may create
+	// a quote or upload a file. Admins can change them at runtime, 0 is no limit
+	Quota struct {
+		ClientQuoteCreation int
+		ClientFileUpload    int
+		AdminQuoteCreation  int
+		AdminFileUpload     int
+	}
+	// Secrets contains the secrets manager the variables written as "secret:<name>#<field>" are read from on
+	// startup, so passwords and keys don't have to be kept in the environment files of the servers
+	Secrets struct {
+		// Provider is "aws" for AWS Secrets Manager or "vault" for a HashiCorp Vault KV version 2 engine
+		Provider string
+		// AWSRegion is the region of the secrets, it defaults to the S3 region
+		AWSRegion      string
+		VaultAddr      string
+		VaultToken     string
+		VaultMount     string
+		VaultNamespace string
+	}
+	// Jobs contains the settings of the background job queue, run by the worker command and, unless
+	// RunInServer is false, by the server too
+	Jobs struct {
+		// MaxAttempts is how many times a job is run before it is dead-lettered
+		MaxAttempts int
+		// RetryDelay is the wait before the first retry, doubled on every following one
+		RetryDelay   string
+		PollInterval string
+		// Concurrency is how many jobs each process runs at a time
+		Concurrency int
+		RunInServer bool
+	}
+	// Outbox contains how the messages written along with the changes they belong to are relayed
+	Outbox struct {
+		PollInterval string
+		// RetryDelay is the wait before a message that failed is relayed again, doubled on every following attempt
+		RetryDelay string
+	}
+	// Schedule contains when the periodic tasks run, written as cron expressions ("0 * * * *"), macros
+	// like "@daily" or "@every 5m". A task set to "off" is left empty and never runs
+	Schedule struct {
+		QuoteExpiration      string
+		AppointmentReminders string
+		OrphanFileCleanup    string
+		RetentionCleanup     string
+		// QuoteMaxAge is how long an open quote waits for the client before it expires
+		QuoteMaxAge string
+		// OrphanFileGrace is how old an unreferenced file must be before it is deleted, so uploads whose
+		// row is not written yet are kept
+		OrphanFileGrace string
+		// EmailLogRetention and NotificationRetention are how long email logs and notifications are kept,
+		// "off" leaves them empty and keeps them forever
+		EmailLogRetention     string
+		NotificationRetention string
+	}
+	// GRPC contains the port of the gRPC API internal services call, the server is disabled when no port is given
+	GRPC struct {
+		Port string
+	}
+	// Telemetry contains the OTLP collector traces are exported to, tracing is disabled when no endpoint is given
+	Telemetry struct {
+		// Endpoint is the base URL of the OTLP/HTTP collector, e.g. http://localhost:4318
+		Endpoint string
+		// Headers are sent with every export, written as "key=value,key2=value2"
+		Headers     string
+		ServiceName string
+		// SampleRatio is the fraction of traces started here that are kept, between 0 and 1
+		SampleRatio float64
+	}
+	// Sentry contains the project unexpected errors and panics are reported to, reporting is disabled when no DSN is given
+	Sentry struct {
+		// DSN is the client key of the project, e.g. https://key@o0.ingest.sentry.io/0
+		DSN string
+		// Environment tags the reports, APP_ENV by default
+		Environment string
+		// Release tags the reports, the VCS revision the binary was built from by default
+		Release string
+	}
 )
 
 // New creates a new container instance
 func New() (*Container, error) {
 	if os.Getenv("APP_ENV") != "production" {
+		// The .env file is optional when the settings come from a config file
 		err := godotenv.Load()
-		if err != nil {
+		if err != nil && !(errors.Is(err, fs.ErrNotExist) && os.Getenv("CONFIG_FILE") != "") {
 			return nil, err
 		}
 	}
 
+	// The environment and the .env file take precedence over the config file and its profile
+	if err := loadFile(); err != nil {
+		return nil, err
+	}
+
+	secretsManager := &Secrets{
+		Provider:       os.Getenv("SECRETS_PROVIDER"),
+		AWSRegion:      os.Getenv("SECRETS_AWS_REGION"),
+		VaultAddr:      os.Getenv("VAULT_ADDR"),
+		VaultToken:     os.Getenv("VAULT_TOKEN"),
+		VaultMount:     os.Getenv("VAULT_MOUNT"),
+		VaultNamespace: os.Getenv("VAULT_NAMESPACE"),
+	}
+	if secretsManager.AWSRegion == "" {
+		secretsManager.AWSRegion = os.Getenv("AWS_S3_REGION")
+	}
+	if secretsManager.VaultMount == "" {
+		secretsManager.VaultMount = "secret"
+	}
+
+	// Secrets are resolved first, the variables referring to them are then read like the others
+	v := &validation{}
+	resolveSecrets(secretsManager, v)
+
 	app := &App{
-		Name: os.Getenv("APP_NAME"),
-		Env:  os.Getenv("APP_ENV"),
+		Name:             os.Getenv("APP_NAME"),
+		Env:              os.Getenv("APP_ENV"),
+		DefaultCurrency:  os.Getenv("APP_DEFAULT_CURRENCY"),
+		ExchangeRates:    os.Getenv("APP_EXCHANGE_RATES"),
+		ReminderLead:     os.Getenv("APP_REMINDER_LEAD"),
+		WebhookRetryDelay: os.Getenv("APP_WEBHOOK_RETRY_DELAY"),
+	}
+
+	if app.DefaultCurrency == "" {
+		app.DefaultCurrency = "MXN"
+	}
+	if app.ReminderLead == "" {
+		app.ReminderLead = "24h"
+	}
+	app.WebhookMaxAttempts, _ = strconv.Atoi(os.Getenv("APP_WEBHOOK_MAX_ATTEMPTS"))
+	if app.WebhookMaxAttempts <= 0 {
+		app.WebhookMaxAttempts = 5
+	}
+	if app.WebhookRetryDelay == "" {
+		app.WebhookRetryDelay = "30s"
 	}
 
 	token := &Token{
-		Duration: os.Getenv("TOKEN_DURATION"),
+		Duration:     os.Getenv("TOKEN_DURATION"),
+		SymmetricKey: os.Getenv("TOKEN_SYMMETRIC_KEY"),
 	}
 
 	redis := &Redis{
+		Provider:   os.Getenv("CACHE_PROVIDER"),
+		Mode:     os.Getenv("REDIS_MODE"),
 		Addr:     os.Getenv("REDIS_ADDR"),
 		Password: os.Getenv("REDIS_PASSWORD"),
+		MasterName:       os.Getenv("REDIS_MASTER_NAME"),
+		SentinelPassword: os.Getenv("REDIS_SENTINEL_PASSWORD"),
+		TLS:              os.Getenv("REDIS_TLS") == "true",
+		DefaultTTL: os.Getenv("REDIS_CACHE_DEFAULT_TTL"),
+		TTLs:       os.Getenv("REDIS_CACHE_TTLS"),
+		Version:    os.Getenv("REDIS_CACHE_VERSION"),
+	}
+
+	if redis.Mode == "" {
+		redis.Mode = "standalone"
+	}
+	if redis.Provider == "" {
+		redis.Provider = "redis"
+	}
+	redis.MemorySize, _ = strconv.Atoi(os.Getenv("CACHE_MEMORY_SIZE"))
+	if redis.MemorySize <= 0 {
+		redis.MemorySize = 10000
+	}
+	if redis.Version == "" {
+		redis.Version = "1"
+	}
+	if redis.DefaultTTL == "" {
+		redis.DefaultTTL = "1h"
 	}
 
 	db := &DB{
@@ -90,27 +449,342 @@ func New() (*Container, error) {
 		User:       os.Getenv("DB_USER"),
 		Password:   os.Getenv("DB_PASSWORD"),
 		Name:       os.Getenv("DB_NAME"),
+		ReplicaURLs: os.Getenv("DB_REPLICA_URLS"),
+		MaxConnLifetime:   os.Getenv("DB_MAX_CONN_LIFETIME"),
+		HealthCheckPeriod: os.Getenv("DB_HEALTH_CHECK_PERIOD"),
+		QueryTimeout:       os.Getenv("DB_QUERY_TIMEOUT"),
+		SlowQueryThreshold: os.Getenv("DB_SLOW_QUERY_THRESHOLD"),
+		QueryLogArgs:       os.Getenv("DB_QUERY_LOG_ARGS") == "true",
+	}
+	db.MaxConns, _ = strconv.Atoi(os.Getenv("DB_MAX_CONNS"))
+	db.MinConns, _ = strconv.Atoi(os.Getenv("DB_MIN_CONNS"))
+	queryLogSampleRatio, err := strconv.ParseFloat(os.Getenv("DB_QUERY_LOG_SAMPLE_RATIO"), 64)
+	if err != nil || queryLogSampleRatio < 0 || queryLogSampleRatio > 1 {
+		queryLogSampleRatio = 1
 	}
+	db.QueryLogSampleRatio = queryLogSampleRatio
 
 	http := &HTTP{
 		Env:            os.Getenv("APP_ENV"),
 		URL:            os.Getenv("HTTP_URL"),
 		Port:           os.Getenv("HTTP_PORT"),
 		AllowedOrigins: os.Getenv("HTTP_ALLOWED_ORIGINS"),
+		CORSDevMode:    os.Getenv("HTTP_CORS_DEV_MODE") == "true",
+		HealthToken:    os.Getenv("HTTP_HEALTH_TOKEN"),
+		TrustedProxies: os.Getenv("HTTP_TRUSTED_PROXIES"),
+		ReadinessTimeout: os.Getenv("HTTP_READINESS_TIMEOUT"),
+	}
+	if origins := os.Getenv("HTTP_ALLOWED_ORIGINS_" + strings.ToUpper(http.Env)); origins != "" {
+		http.AllowedOrigins = origins
+	}
+	if http.ReadinessTimeout == "" {
+		http.ReadinessTimeout = "2s"
+	}
+	http.ShutdownTimeout = os.Getenv("HTTP_SHUTDOWN_TIMEOUT")
+	if http.ShutdownTimeout == "" {
+		http.ShutdownTimeout = "15s"
+	}
+	http.SlowRequestThreshold = os.Getenv("HTTP_SLOW_REQUEST_THRESHOLD")
+	http.CompressionLevel = 6
+	if level := os.Getenv("HTTP_COMPRESSION_LEVEL"); level != "" {
+		http.CompressionLevel, _ = strconv.Atoi(level)
 	}
+	http.CompressionMinSize, _ = strconv.Atoi(os.Getenv("HTTP_COMPRESSION_MIN_SIZE"))
+	if http.CompressionMinSize == 0 {
+		http.CompressionMinSize = 1024
+	}
+	http.MaxBodySize, _ = strconv.ParseInt(os.Getenv("HTTP_MAX_BODY_SIZE"), 10, 64)
+	if http.MaxBodySize == 0 {
+		http.MaxBodySize = 1 << 20
+	}
+	http.MaxUploadSize, _ = strconv.ParseInt(os.Getenv("HTTP_MAX_UPLOAD_SIZE"), 10, 64)
+	if http.MaxUploadSize == 0 {
+		http.MaxUploadSize = 10 << 20
+	}
+	http.MaxMediaUploadSize, _ = strconv.ParseInt(os.Getenv("HTTP_MAX_MEDIA_UPLOAD_SIZE"), 10, 64)
+	if http.MaxMediaUploadSize == 0 {
+		http.MaxMediaUploadSize = 500 << 20
+	}
+	http.TLSCertFile = os.Getenv("HTTP_TLS_CERT_FILE")
+	http.TLSKeyFile = os.Getenv("HTTP_TLS_KEY_FILE")
+	http.AutocertDomains = os.Getenv("HTTP_AUTOCERT_DOMAINS")
+	http.AutocertCacheDir = os.Getenv("HTTP_AUTOCERT_CACHE_DIR")
+	if http.AutocertCacheDir == "" {
+		http.AutocertCacheDir = "certs"
+	}
+	http.AutocertEmail = os.Getenv("HTTP_AUTOCERT_EMAIL")
+	http.H2C = os.Getenv("HTTP_H2C") == "true"
+	http.GraphQL = os.Getenv("HTTP_GRAPHQL") == "true"
 
 	email := &Email{
+		Provider:       os.Getenv("EMAIL_PROVIDER"),
 		Url:            os.Getenv("EMAIL_URL"),
 		ApiToken:       os.Getenv("EMAIL_API_TOKEN"),
 		FromEmail:      os.Getenv("FROM_EMAIL"),
+		SESRegion:      os.Getenv("EMAIL_SES_REGION"),
+		SendGridAPIKey: os.Getenv("SENDGRID_API_KEY"),
+		Sandbox:        os.Getenv("EMAIL_SANDBOX") == "true",
+		QueueRetryDelay:   os.Getenv("EMAIL_QUEUE_RETRY_DELAY"),
+		QueuePollInterval: os.Getenv("EMAIL_QUEUE_POLL_INTERVAL"),
 	}
+
+  if email.Provider == "" {
+    email.Provider = "mailtrap"
+  }
+  if email.SESRegion == "" {
+    email.SESRegion = os.Getenv("AWS_S3_REGION")
+  }
+  email.QueueMaxAttempts, _ = strconv.Atoi(os.Getenv("EMAIL_QUEUE_MAX_ATTEMPTS"))
+  if email.QueueMaxAttempts <= 0 {
+    email.QueueMaxAttempts = 5
+  }
+  if email.QueueRetryDelay == "" {
+    email.QueueRetryDelay = "30s"
+  }
+  if email.QueuePollInterval == "" {
+    email.QueuePollInterval = "1s"
+  }
   
   awsS3 := &AwsS3{
     Bucket: os.Getenv("AWS_S3_BUCKET_NAME"),
     Region: os.Getenv("AWS_S3_REGION"),
+    Endpoint: os.Getenv("AWS_S3_ENDPOINT"),
+    AccessKey: os.Getenv("AWS_S3_ACCESS_KEY"),
+    SecretKey: os.Getenv("AWS_S3_SECRET_KEY"),
+    UsePathStyle: os.Getenv("AWS_S3_USE_PATH_STYLE") == "true",
+  }
+
+  awsS3.MaxAttempts, _ = strconv.Atoi(os.Getenv("AWS_S3_MAX_ATTEMPTS"))
+  if awsS3.MaxAttempts <= 0 {
+    awsS3.MaxAttempts = 3
   }
 
-	return &Container{
+  partSizeMB, _ := strconv.ParseInt(os.Getenv("AWS_S3_PART_SIZE_MB"), 10, 64)
+  if partSizeMB <= 0 {
+    partSizeMB = 8
+  }
+  awsS3.PartSize = partSizeMB << 20
+
+	paymentWebhook := &PaymentWebhook{
+		StripeSecret:           os.Getenv("PAYMENT_WEBHOOK_STRIPE_SECRET"),
+		MercadoPagoSecret:      os.Getenv("PAYMENT_WEBHOOK_MERCADOPAGO_SECRET"),
+		BankSecret:             os.Getenv("PAYMENT_WEBHOOK_BANK_SECRET"),
+		MercadoPagoAccessToken: os.Getenv("PAYMENT_WEBHOOK_MERCADOPAGO_ACCESS_TOKEN"),
+		MercadoPagoAPIURL:      os.Getenv("PAYMENT_WEBHOOK_MERCADOPAGO_API_URL"),
+	}
+	if paymentWebhook.MercadoPagoAPIURL == "" {
+		paymentWebhook.MercadoPagoAPIURL = "https://api.mercadopago.com"
+	}
+
+	storage := &Storage{
+		Provider: os.Getenv("STORAGE_PROVIDER"),
+		LocalDir: os.Getenv("STORAGE_LOCAL_DIR"),
+	}
+
+	if storage.Provider == "" {
+		storage.Provider = "s3"
+	}
+
+	if storage.LocalDir == "" {
+		storage.LocalDir = "uploads"
+	}
+
+	minio := &Minio{
+		Endpoint:  os.Getenv("MINIO_ENDPOINT"),
+		Region:    os.Getenv("MINIO_REGION"),
+		Bucket:    os.Getenv("MINIO_BUCKET_NAME"),
+		AccessKey: os.Getenv("MINIO_ACCESS_KEY"),
+		SecretKey: os.Getenv("MINIO_SECRET_KEY"),
+		UseSSL:    os.Getenv("MINIO_USE_SSL") == "true",
+	}
+
+	if minio.Region == "" {
+		minio.Region = "us-east-1"
+	}
+
+	gcs := &GCS{
+		Bucket:          os.Getenv("GCS_BUCKET_NAME"),
+		CredentialsFile: os.Getenv("GCS_CREDENTIALS_FILE"),
+	}
+
+	clamAV := &ClamAV{
+		Addr:    os.Getenv("CLAMAV_ADDR"),
+		Timeout: os.Getenv("CLAMAV_TIMEOUT"),
+	}
+
+	if clamAV.Timeout == "" {
+		clamAV.Timeout = "30s"
+	}
+
+	cloudFront := &CloudFront{
+		Domain:         os.Getenv("CLOUDFRONT_DOMAIN"),
+		KeyPairID:      os.Getenv("CLOUDFRONT_KEY_PAIR_ID"),
+		PrivateKeyFile: os.Getenv("CLOUDFRONT_PRIVATE_KEY_FILE"),
+		TTL:            os.Getenv("CLOUDFRONT_URL_TTL"),
+	}
+
+	if cloudFront.TTL == "" {
+		cloudFront.TTL = "15m"
+	}
+
+	smtp := &SMTP{
+		Host:      os.Getenv("SMTP_HOST"),
+		Port:      os.Getenv("SMTP_PORT"),
+		Username:  os.Getenv("SMTP_USERNAME"),
+		Password:  os.Getenv("SMTP_PASSWORD"),
+		TLS:       os.Getenv("SMTP_TLS"),
+		FromEmail: os.Getenv("FROM_EMAIL"),
+	}
+
+	if smtp.Port == "" {
+		smtp.Port = "587"
+	}
+	if smtp.TLS == "" {
+		smtp.TLS = "starttls"
+	}
+
+	twilio := &Twilio{
+		AccountSID:          os.Getenv("TWILIO_ACCOUNT_SID"),
+		AuthToken:           os.Getenv("TWILIO_AUTH_TOKEN"),
+		FromNumber:          os.Getenv("TWILIO_FROM_NUMBER"),
+		MessagingServiceSID: os.Getenv("TWILIO_MESSAGING_SERVICE_SID"),
+	}
+
+	whatsApp := &WhatsApp{
+		AccessToken:   os.Getenv("WHATSAPP_ACCESS_TOKEN"),
+		PhoneNumberID: os.Getenv("WHATSAPP_PHONE_NUMBER_ID"),
+		APIVersion:    os.Getenv("WHATSAPP_API_VERSION"),
+	}
+
+	if whatsApp.APIVersion == "" {
+		whatsApp.APIVersion = "v21.0"
+	}
+
+	fcm := &FCM{
+		ProjectID:       os.Getenv("FCM_PROJECT_ID"),
+		CredentialsFile: os.Getenv("FCM_CREDENTIALS_FILE"),
+	}
+
+	rateLimit := &RateLimit{
+		Auth:   os.Getenv("RATE_LIMIT_AUTH"),
+		Upload: os.Getenv("RATE_LIMIT_UPLOAD"),
+	}
+
+	telemetry := &Telemetry{
+		Endpoint:    os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
+		Headers:     os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"),
+		ServiceName: os.Getenv("OTEL_SERVICE_NAME"),
+	}
+	if telemetry.ServiceName == "" {
+		telemetry.ServiceName = app.Name
+	}
+	sampleRatio, err := strconv.ParseFloat(os.Getenv("OTEL_TRACES_SAMPLE_RATIO"), 64)
+	if err != nil || sampleRatio < 0 || sampleRatio > 1 {
+		sampleRatio = 1
+	}
+	telemetry.SampleRatio = sampleRatio
+
+	grpc := &GRPC{
+		Port: os.Getenv("GRPC_PORT"),
+	}
+
+	quota := &Quota{}
+	quota.ClientQuoteCreation, _ = strconv.Atoi(os.Getenv("QUOTA_CLIENT_QUOTE_CREATION"))
+	quota.ClientFileUpload, _ = strconv.Atoi(os.Getenv("QUOTA_CLIENT_FILE_UPLOAD"))
+	quota.AdminQuoteCreation, _ = strconv.Atoi(os.Getenv("QUOTA_ADMIN_QUOTE_CREATION"))
+	quota.AdminFileUpload, _ = strconv.Atoi(os.Getenv("QUOTA_ADMIN_FILE_UPLOAD"))
+
+	jobs := &Jobs{
+		RetryDelay:   os.Getenv("JOB_RETRY_DELAY"),
+		PollInterval: os.Getenv("JOB_POLL_INTERVAL"),
+		RunInServer:  os.Getenv("JOB_RUN_IN_SERVER") != "false",
+	}
+	jobs.MaxAttempts, _ = strconv.Atoi(os.Getenv("JOB_MAX_ATTEMPTS"))
+	if jobs.MaxAttempts <= 0 {
+		jobs.MaxAttempts = 5
+	}
+	if jobs.RetryDelay == "" {
+		jobs.RetryDelay = "30s"
+	}
+	if jobs.PollInterval == "" {
+		jobs.PollInterval = "1s"
+	}
+	jobs.Concurrency, _ = strconv.Atoi(os.Getenv("JOB_CONCURRENCY"))
+	if jobs.Concurrency <= 0 {
+		jobs.Concurrency = 2
+	}
+
+	schedule := &Schedule{
+		QuoteExpiration:      os.Getenv("SCHEDULE_QUOTE_EXPIRATION"),
+		AppointmentReminders: os.Getenv("SCHEDULE_APPOINTMENT_REMINDERS"),
+		OrphanFileCleanup:    os.Getenv("SCHEDULE_ORPHAN_FILE_CLEANUP"),
+		QuoteMaxAge:          os.Getenv("SCHEDULE_QUOTE_MAX_AGE"),
+		OrphanFileGrace:      os.Getenv("SCHEDULE_ORPHAN_FILE_GRACE"),
+		RetentionCleanup:     os.Getenv("SCHEDULE_RETENTION_CLEANUP"),
+
+		EmailLogRetention:     os.Getenv("SCHEDULE_EMAIL_LOG_RETENTION"),
+		NotificationRetention: os.Getenv("SCHEDULE_NOTIFICATION_RETENTION"),
+	}
+	if schedule.QuoteExpiration == "" {
+		schedule.QuoteExpiration = "0 * * * *"
+	}
+	// APP_REMINDER_INTERVAL is what set how often reminders were looked for before the scheduler
+	if schedule.AppointmentReminders == "" {
+		schedule.AppointmentReminders = "*/5 * * * *"
+		if interval := os.Getenv("APP_REMINDER_INTERVAL"); interval != "" {
+			schedule.AppointmentReminders = "@every " + interval
+		}
+	}
+	if schedule.OrphanFileCleanup == "" {
+		schedule.OrphanFileCleanup = "30 3 * * *"
+	}
+	if schedule.RetentionCleanup == "" {
+		schedule.RetentionCleanup = "0 4 * * *"
+	}
+	for _, task := range []*string{&schedule.QuoteExpiration, &schedule.AppointmentReminders, &schedule.OrphanFileCleanup, &schedule.RetentionCleanup} {
+		if *task == "off" {
+			*task = ""
+		}
+	}
+	if schedule.QuoteMaxAge == "" {
+		schedule.QuoteMaxAge = "720h"
+	}
+	if schedule.OrphanFileGrace == "" {
+		schedule.OrphanFileGrace = "24h"
+	}
+	if schedule.EmailLogRetention == "" {
+		schedule.EmailLogRetention = "2160h"
+	}
+	if schedule.NotificationRetention == "" {
+		schedule.NotificationRetention = "2160h"
+	}
+	for _, retention := range []*string{&schedule.EmailLogRetention, &schedule.NotificationRetention} {
+		if *retention == "off" {
+			*retention = ""
+		}
+	}
+
+	outbox := &Outbox{
+		PollInterval: os.Getenv("OUTBOX_POLL_INTERVAL"),
+		RetryDelay:   os.Getenv("OUTBOX_RETRY_DELAY"),
+	}
+	if outbox.PollInterval == "" {
+		outbox.PollInterval = "1s"
+	}
+	if outbox.RetryDelay == "" {
+		outbox.RetryDelay = "10s"
+	}
+
+	sentry := &Sentry{
+		DSN:         os.Getenv("SENTRY_DSN"),
+		Environment: os.Getenv("SENTRY_ENVIRONMENT"),
+		Release:     os.Getenv("SENTRY_RELEASE"),
+	}
+	if sentry.Environment == "" {
+		sentry.Environment = app.Env
+	}
+
+	container := &Container{
 		app,
 		token,
 		redis,
@@ -118,5 +792,31 @@ func New() (*Container, error) {
 		http,
     email,
     awsS3,
-	}, nil
+		paymentWebhook,
+		storage,
+		minio,
+		gcs,
+		clamAV,
+		cloudFront,
+		smtp,
+		twilio,
+		whatsApp,
+		fcm,
+		rateLimit,
+		telemetry,
+		grpc,
+		quota,
+		secretsManager,
+		jobs,
+		schedule,
+		outbox,
+		sentry,
+	}
+
+	// Every problem is reported on startup at once, instead of the first one failing later in a subsystem
+	if err := v.validate(container); err != nil {
+		return nil, err
+	}
+
+	return container, nil
 }
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// loadFile sets the variables of the config file named by CONFIG_FILE that aren't set in the environment, which
+// includes the .env file. The profile of APP_ENV, a file next to it named like config.production.yaml for
+// config.yaml, is laid over it when it exists. Keys are the variable names, or nested like http.port for HTTP_PORT
+func loadFile() error {
+	path := os.Getenv("CONFIG_FILE")
+	if path == "" {
+		return nil
+	}
+
+	vars, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	env := os.Getenv("APP_ENV")
+	if env == "" {
+		env = vars["APP_ENV"]
+	}
+	if env != "" {
+		profile, err := readConfigFile(profilePath(path, env))
+		switch {
+		case err == nil:
+			for name, value := range profile {
+				vars[name] = value
+			}
+		case !errors.Is(err, fs.ErrNotExist):
+			return err
+		}
+	}
+
+	for name, value := range vars {
+		if _, ok := os.LookupEnv(name); !ok {
+			os.Setenv(name, value)
+		}
+	}
+
+	return nil
+}
+
+// profilePath returns the path of the profile of env for the config file at path
+func profilePath(path, env string) string {
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s.%s%s", strings.TrimSuffix(path, ext), env, ext)
+}
+
+// readConfigFile reads a YAML or JSON config file into the variables it sets
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var values map[string]any
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".json":
+		// Numbers are kept as written, 1048576 would otherwise turn into 1.048576e+06
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		err = decoder.Decode(&values)
+	default:
+		return nil, fmt.Errorf("config file %s must be .yaml, .yml or .json", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	vars := map[string]string{}
+	flattenConfig(vars, "", values)
+	return vars, nil
+}
+
+// flattenConfig sets the variable of every value of values, named by the keys leading to it joined by
+// underscores, like HTTP_PORT for http.port. Lists are joined by commas like in the environment
+func flattenConfig(vars map[string]string, prefix string, values map[string]any) {
+	for key, value := range values {
+		name := strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+
+		if nested, ok := value.(map[string]any); ok {
+			flattenConfig(vars, name, nested)
+			continue
+		}
+		vars[name] = configValue(value)
+	}
+}
+
+// configValue writes a value of the config file the way it would be written in the environment
+func configValue(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case []any:
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			items = append(items, configValue(item))
+		}
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unsetenv unsets names for the test and restores them after it
+func unsetenv(t *testing.T, names ...string) {
+	for _, name := range names {
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+}
+
+// writeFile writes a file in dir and returns its path
+func writeFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadFileLayersProfileAndEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.yaml", `
+app:
+  name: harajuku
+  env: staging
+http:
+  port: 8080
+  allowed-origins:
+    - https://harajuku.mx
+    - https://*.harajuku.mx
+  max_body_size: 1048576
+DB_HOST: db.internal
+REDIS_TLS: false
+`)
+	writeFile(t, dir, "config.staging.yaml", `
+http:
+  port: 9090
+DB_HOST: staging-db.internal
+`)
+
+	unsetenv(t, "APP_ENV", "APP_NAME", "HTTP_PORT", "HTTP_ALLOWED_ORIGINS", "HTTP_MAX_BODY_SIZE", "REDIS_TLS")
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("DB_HOST", "127.0.0.1")
+
+	require.NoError(t, loadFile())
+
+	assert.Equal(t, "harajuku", os.Getenv("APP_NAME"))
+	assert.Equal(t, "staging", os.Getenv("APP_ENV"))
+	assert.Equal(t, "9090", os.Getenv("HTTP_PORT"))
+	assert.Equal(t, "https://harajuku.mx,https://*.harajuku.mx", os.Getenv("HTTP_ALLOWED_ORIGINS"))
+	assert.Equal(t, "1048576", os.Getenv("HTTP_MAX_BODY_SIZE"))
+	assert.Equal(t, "false", os.Getenv("REDIS_TLS"))
+	// The environment wins over the file and its profile
+	assert.Equal(t, "127.0.0.1", os.Getenv("DB_HOST"))
+}
+
+func TestLoadFileJSON(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.json", `{"http": {"max_body_size": 1048576}, "OTEL_TRACES_SAMPLE_RATIO": 0.25}`)
+
+	unsetenv(t, "APP_ENV", "HTTP_MAX_BODY_SIZE", "OTEL_TRACES_SAMPLE_RATIO")
+	t.Setenv("CONFIG_FILE", path)
+
+	require.NoError(t, loadFile())
+
+	assert.Equal(t, "1048576", os.Getenv("HTTP_MAX_BODY_SIZE"))
+	assert.Equal(t, "0.25", os.Getenv("OTEL_TRACES_SAMPLE_RATIO"))
+}
+
+func TestLoadFileInvalid(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := map[string]string{
+		"missing":   filepath.Join(dir, "missing.yaml"),
+		"extension": writeFile(t, dir, "config.toml", "port = 8080"),
+		"syntax":    writeFile(t, dir, "broken.yaml", "http: [port"),
+	}
+
+	for name, path := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("CONFIG_FILE", path)
+			assert.Error(t, loadFile())
+		})
+	}
+}
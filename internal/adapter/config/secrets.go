@@ -0,0 +1,68 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"harajuku/backend/internal/adapter/secrets"
+)
+
+// secretPrefix marks the variables whose value refers to a secret, e.g. DB_PASSWORD="secret:harajuku/production#db_password"
+const secretPrefix = "secret:"
+
+// secretsTimeout bounds how long the secrets manager gets to answer on startup
+const secretsTimeout = 30 * time.Second
+
+// resolveSecrets replaces the value of the variables referring to a secret with the secret, so New reads them
+// like any other variable. The secrets that can't be resolved are recorded as problems of v
+func resolveSecrets(config *Secrets, v *validation) {
+	var names []string
+	for _, env := range os.Environ() {
+		name, value, _ := strings.Cut(env, "=")
+		if strings.HasPrefix(value, secretPrefix) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	sort.Strings(names)
+
+	ctx, cancel := context.WithTimeout(context.Background(), secretsTimeout)
+	defer cancel()
+
+	provider, err := newSecretsProvider(ctx, config)
+	if err != nil {
+		v.addf("%s can't be read from the secrets manager: %v", strings.Join(names, ", "), err)
+		return
+	}
+
+	resolver := secrets.NewResolver(provider)
+	for _, name := range names {
+		value, err := resolver.Resolve(ctx, strings.TrimPrefix(os.Getenv(name), secretPrefix))
+		if err != nil {
+			v.addf("%s: %v", name, err)
+			continue
+		}
+		os.Setenv(name, value)
+	}
+}
+
+// newSecretsProvider returns the secrets manager selected by the config
+func newSecretsProvider(ctx context.Context, config *Secrets) (secrets.Provider, error) {
+	switch config.Provider {
+	case "aws":
+		return secrets.NewAWSSecretsManager(ctx, config.AWSRegion)
+	case "vault":
+		return secrets.NewVault(config.VaultAddr, config.VaultToken, config.VaultMount, config.VaultNamespace)
+	case "":
+		return nil, errors.New("SECRETS_PROVIDER is not set")
+	default:
+		return nil, fmt.Errorf("unknown secrets provider %q", config.Provider)
+	}
+}
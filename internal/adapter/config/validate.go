@@ -0,0 +1,313 @@
+package config
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValidationError holds every problem found in the environment variables, so they can all be fixed at once
+// instead of one per restart
+type ValidationError struct {
+	Problems []string
+}
+
+// Error lists the problems separated by semicolons
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid configuration: %s", strings.Join(e.Problems, "; "))
+}
+
+// validation collects the problems of the variables it checks, empty values pass every format check
+// so optional variables are only checked when they are set
+type validation struct {
+	problems []string
+}
+
+// addf records a problem
+func (v *validation) addf(format string, args ...any) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+// required records a problem when value is empty
+func (v *validation) required(name, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.addf("%s is required", name)
+	}
+}
+
+// requiredWhen records a problem when value is empty and the subsystem described by reason is enabled
+func (v *validation) requiredWhen(name, value, reason string) {
+	if strings.TrimSpace(value) == "" {
+		v.addf("%s is required when %s", name, reason)
+	}
+}
+
+// oneOf records a problem when value is none of options
+func (v *validation) oneOf(name, value string, options ...string) {
+	for _, option := range options {
+		if value == option {
+			return
+		}
+	}
+	v.addf("%s must be one of %s, got %q", name, strings.Join(options, ", "), value)
+}
+
+// port records a problem when value isn't a TCP port
+func (v *validation) port(name, value string) {
+	if value == "" {
+		return
+	}
+	if port, err := strconv.Atoi(value); err != nil || port < 1 || port > 65535 {
+		v.addf("%s must be a port between 1 and 65535, got %q", name, value)
+	}
+}
+
+// httpURL records a problem when value isn't an absolute http or https URL
+func (v *validation) httpURL(name, value string) {
+	if value == "" {
+		return
+	}
+	if u, err := url.Parse(value); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.addf("%s must be an http or https URL, got %q", name, value)
+	}
+}
+
+// duration records a problem when value isn't a positive duration like 30s or 1h
+func (v *validation) duration(name, value string) {
+	if value == "" {
+		return
+	}
+	if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+		v.addf("%s must be a positive duration like 30s or 5m, got %q", name, value)
+	}
+}
+
+// integer records a problem when the variable name is set to something other than an integer between min and max.
+// Integers are read again from the environment since New falls back to their defaults when they don't parse
+func (v *validation) integer(name string, min, max int) {
+	value := os.Getenv(name)
+	if value == "" {
+		return
+	}
+
+	n, err := strconv.Atoi(value)
+	switch {
+	case err != nil:
+		v.addf("%s must be an integer, got %q", name, value)
+	case n < min || n > max:
+		if max == math.MaxInt {
+			v.addf("%s must be at least %d, got %d", name, min, n)
+		} else {
+			v.addf("%s must be between %d and %d, got %d", name, min, max, n)
+		}
+	}
+}
+
+// err returns the problems found as a ValidationError, or nil when there are none
+func (v *validation) err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
+
+// validate checks the variables every server needs and the ones of the subsystems that are enabled,
+// like the selected storage and email providers, and returns the problems found along with the ones
+// recorded before, like the secrets that couldn't be resolved
+func (v *validation) validate(c *Container) error {
+	v.required("APP_NAME", c.App.Name)
+	v.required("APP_ENV", c.App.Env)
+	v.duration("APP_REMINDER_LEAD", c.App.ReminderLead)
+	v.duration("APP_WEBHOOK_RETRY_DELAY", c.App.WebhookRetryDelay)
+	v.integer("APP_WEBHOOK_MAX_ATTEMPTS", 1, math.MaxInt)
+
+	v.required("TOKEN_DURATION", c.Token.Duration)
+	v.duration("TOKEN_DURATION", c.Token.Duration)
+	if key, err := hex.DecodeString(c.Token.SymmetricKey); err != nil || (c.Token.SymmetricKey != "" && len(key) != 32) {
+		v.addf("TOKEN_SYMMETRIC_KEY must be 32 bytes written in hex, like the output of openssl rand -hex 32")
+	}
+
+	v.required("DB_CONNECTION", c.DB.Connection)
+	v.required("DB_HOST", c.DB.Host)
+	v.required("DB_PORT", c.DB.Port)
+	v.port("DB_PORT", c.DB.Port)
+	v.required("DB_USER", c.DB.User)
+	v.required("DB_NAME", c.DB.Name)
+	v.integer("DB_MAX_CONNS", 0, math.MaxInt32)
+	v.integer("DB_MIN_CONNS", 0, math.MaxInt32)
+	if c.DB.MaxConns > 0 && c.DB.MinConns > c.DB.MaxConns {
+		v.addf("DB_MIN_CONNS can't be more than DB_MAX_CONNS, got %d and %d", c.DB.MinConns, c.DB.MaxConns)
+	}
+	v.duration("DB_MAX_CONN_LIFETIME", c.DB.MaxConnLifetime)
+	v.duration("DB_HEALTH_CHECK_PERIOD", c.DB.HealthCheckPeriod)
+	v.duration("DB_QUERY_TIMEOUT", c.DB.QueryTimeout)
+	v.duration("DB_SLOW_QUERY_THRESHOLD", c.DB.SlowQueryThreshold)
+	if ratio := os.Getenv("DB_QUERY_LOG_SAMPLE_RATIO"); ratio != "" {
+		if r, err := strconv.ParseFloat(ratio, 64); err != nil || r < 0 || r > 1 {
+			v.addf("DB_QUERY_LOG_SAMPLE_RATIO must be a number between 0 and 1, got %q", ratio)
+		}
+	}
+	if c.DB.QueryLogArgs && c.App.Env == "production" {
+		v.addf("DB_QUERY_LOG_ARGS can't be enabled in production")
+	}
+
+	// Redis is always needed, the email queue, the rate limits and the quotas live there whatever the cache provider
+	v.required("REDIS_ADDR", c.Redis.Addr)
+	v.oneOf("REDIS_MODE", c.Redis.Mode, "standalone", "sentinel", "cluster")
+	if c.Redis.Mode == "sentinel" {
+		v.requiredWhen("REDIS_MASTER_NAME", c.Redis.MasterName, "REDIS_MODE is sentinel")
+	}
+	v.oneOf("CACHE_PROVIDER", c.Redis.Provider, "redis", "memory", "none")
+	v.integer("CACHE_MEMORY_SIZE", 1, math.MaxInt)
+	v.duration("REDIS_CACHE_DEFAULT_TTL", c.Redis.DefaultTTL)
+
+	v.required("HTTP_PORT", c.HTTP.Port)
+	v.port("HTTP_PORT", c.HTTP.Port)
+	v.duration("HTTP_READINESS_TIMEOUT", c.HTTP.ReadinessTimeout)
+	v.duration("HTTP_SHUTDOWN_TIMEOUT", c.HTTP.ShutdownTimeout)
+	v.duration("HTTP_SLOW_REQUEST_THRESHOLD", c.HTTP.SlowRequestThreshold)
+	v.integer("HTTP_COMPRESSION_LEVEL", 0, 9)
+	v.integer("HTTP_COMPRESSION_MIN_SIZE", 0, math.MaxInt)
+	v.integer("HTTP_MAX_BODY_SIZE", 1, math.MaxInt)
+	v.integer("HTTP_MAX_UPLOAD_SIZE", 1, math.MaxInt)
+	v.integer("HTTP_MAX_MEDIA_UPLOAD_SIZE", 1, math.MaxInt)
+	if (c.HTTP.TLSCertFile == "") != (c.HTTP.TLSKeyFile == "") {
+		v.addf("HTTP_TLS_CERT_FILE and HTTP_TLS_KEY_FILE must be set together")
+	}
+	v.port("GRPC_PORT", c.GRPC.Port)
+
+	v.required("FROM_EMAIL", c.Email.FromEmail)
+	v.oneOf("EMAIL_PROVIDER", c.Email.Provider, "mailtrap", "smtp", "ses", "sendgrid", "memory")
+	switch c.Email.Provider {
+	case "mailtrap":
+		v.requiredWhen("EMAIL_URL", c.Email.Url, "EMAIL_PROVIDER is mailtrap")
+		v.httpURL("EMAIL_URL", c.Email.Url)
+		v.requiredWhen("EMAIL_API_TOKEN", c.Email.ApiToken, "EMAIL_PROVIDER is mailtrap")
+	case "smtp":
+		v.requiredWhen("SMTP_HOST", c.SMTP.Host, "EMAIL_PROVIDER is smtp")
+		v.port("SMTP_PORT", c.SMTP.Port)
+		v.oneOf("SMTP_TLS", c.SMTP.TLS, "starttls", "tls", "none")
+	case "ses":
+		v.requiredWhen("EMAIL_SES_REGION", c.Email.SESRegion, "EMAIL_PROVIDER is ses")
+	case "sendgrid":
+		v.requiredWhen("SENDGRID_API_KEY", c.Email.SendGridAPIKey, "EMAIL_PROVIDER is sendgrid")
+	case "memory":
+		if c.App.Env == "production" {
+			v.addf("EMAIL_PROVIDER memory doesn't deliver the emails, it can't be used in production")
+		}
+	}
+	v.integer("EMAIL_QUEUE_MAX_ATTEMPTS", 1, math.MaxInt)
+	v.duration("EMAIL_QUEUE_RETRY_DELAY", c.Email.QueueRetryDelay)
+	v.duration("EMAIL_QUEUE_POLL_INTERVAL", c.Email.QueuePollInterval)
+
+	v.oneOf("STORAGE_PROVIDER", c.Storage.Provider, "s3", "minio", "gcs", "local", "memory")
+	switch c.Storage.Provider {
+	case "s3":
+		v.requiredWhen("AWS_S3_BUCKET_NAME", c.AwsS3.Bucket, "STORAGE_PROVIDER is s3")
+		v.requiredWhen("AWS_S3_REGION", c.AwsS3.Region, "STORAGE_PROVIDER is s3")
+		v.httpURL("AWS_S3_ENDPOINT", c.AwsS3.Endpoint)
+		if (c.AwsS3.AccessKey == "") != (c.AwsS3.SecretKey == "") {
+			v.addf("AWS_S3_ACCESS_KEY and AWS_S3_SECRET_KEY must be set together")
+		}
+		v.integer("AWS_S3_MAX_ATTEMPTS", 1, math.MaxInt)
+		// S3 rejects multipart parts under 5 MB
+		v.integer("AWS_S3_PART_SIZE_MB", 5, math.MaxInt)
+	case "minio":
+		v.requiredWhen("MINIO_ENDPOINT", c.Minio.Endpoint, "STORAGE_PROVIDER is minio")
+		v.requiredWhen("MINIO_BUCKET_NAME", c.Minio.Bucket, "STORAGE_PROVIDER is minio")
+		if strings.Contains(c.Minio.Endpoint, "://") {
+			v.addf("MINIO_ENDPOINT must be a host and port without a scheme, MINIO_USE_SSL picks it, got %q", c.Minio.Endpoint)
+		}
+	case "gcs":
+		v.requiredWhen("GCS_BUCKET_NAME", c.GCS.Bucket, "STORAGE_PROVIDER is gcs")
+	case "local":
+		v.requiredWhen("STORAGE_LOCAL_DIR", c.Storage.LocalDir, "STORAGE_PROVIDER is local")
+	case "memory":
+		if c.App.Env == "production" {
+			v.addf("STORAGE_PROVIDER memory loses the files on restart, it can't be used in production")
+		}
+	}
+
+	if c.ClamAV.Addr != "" {
+		v.duration("CLAMAV_TIMEOUT", c.ClamAV.Timeout)
+	}
+
+	if c.CloudFront.Domain != "" {
+		v.requiredWhen("CLOUDFRONT_KEY_PAIR_ID", c.CloudFront.KeyPairID, "CLOUDFRONT_DOMAIN is set")
+		v.requiredWhen("CLOUDFRONT_PRIVATE_KEY_FILE", c.CloudFront.PrivateKeyFile, "CLOUDFRONT_DOMAIN is set")
+		v.duration("CLOUDFRONT_URL_TTL", c.CloudFront.TTL)
+	}
+
+	if c.PaymentWebhook != nil && c.PaymentWebhook.MercadoPagoSecret != "" {
+		v.requiredWhen("PAYMENT_WEBHOOK_MERCADOPAGO_ACCESS_TOKEN", c.PaymentWebhook.MercadoPagoAccessToken, "PAYMENT_WEBHOOK_MERCADOPAGO_SECRET is set")
+		v.httpURL("PAYMENT_WEBHOOK_MERCADOPAGO_API_URL", c.PaymentWebhook.MercadoPagoAPIURL)
+	}
+
+	if c.Twilio.AccountSID != "" {
+		v.requiredWhen("TWILIO_AUTH_TOKEN", c.Twilio.AuthToken, "TWILIO_ACCOUNT_SID is set")
+		if c.Twilio.FromNumber == "" && c.Twilio.MessagingServiceSID == "" {
+			v.addf("TWILIO_FROM_NUMBER or TWILIO_MESSAGING_SERVICE_SID is required when TWILIO_ACCOUNT_SID is set")
+		}
+	}
+
+	if c.WhatsApp.AccessToken != "" {
+		v.requiredWhen("WHATSAPP_PHONE_NUMBER_ID", c.WhatsApp.PhoneNumberID, "WHATSAPP_ACCESS_TOKEN is set")
+	}
+
+	v.httpURL("OTEL_EXPORTER_OTLP_ENDPOINT", c.Telemetry.Endpoint)
+	if ratio := os.Getenv("OTEL_TRACES_SAMPLE_RATIO"); ratio != "" {
+		if r, err := strconv.ParseFloat(ratio, 64); err != nil || r < 0 || r > 1 {
+			v.addf("OTEL_TRACES_SAMPLE_RATIO must be a number between 0 and 1, got %q", ratio)
+		}
+	}
+
+	if c.Sentry.DSN != "" {
+		if u, err := url.Parse(c.Sentry.DSN); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			u.User.Username() == "" || strings.Trim(u.Path, "/") == "" {
+			v.addf("SENTRY_DSN must be a DSN like https://key@o0.ingest.sentry.io/0, got %q", c.Sentry.DSN)
+		}
+	}
+
+	if c.Secrets != nil && c.Secrets.Provider != "" {
+		v.oneOf("SECRETS_PROVIDER", c.Secrets.Provider, "aws", "vault")
+		if c.Secrets.Provider == "vault" {
+			v.requiredWhen("VAULT_ADDR", c.Secrets.VaultAddr, "SECRETS_PROVIDER is vault")
+			v.httpURL("VAULT_ADDR", c.Secrets.VaultAddr)
+			v.requiredWhen("VAULT_TOKEN", c.Secrets.VaultToken, "SECRETS_PROVIDER is vault")
+		}
+	}
+
+	v.integer("QUOTA_CLIENT_QUOTE_CREATION", 0, math.MaxInt)
+	v.integer("QUOTA_CLIENT_FILE_UPLOAD", 0, math.MaxInt)
+	v.integer("QUOTA_ADMIN_QUOTE_CREATION", 0, math.MaxInt)
+	v.integer("QUOTA_ADMIN_FILE_UPLOAD", 0, math.MaxInt)
+
+	if c.Jobs != nil {
+		v.integer("JOB_MAX_ATTEMPTS", 1, math.MaxInt)
+		v.duration("JOB_RETRY_DELAY", c.Jobs.RetryDelay)
+		v.duration("JOB_POLL_INTERVAL", c.Jobs.PollInterval)
+		v.integer("JOB_CONCURRENCY", 1, 64)
+		if value := os.Getenv("JOB_RUN_IN_SERVER"); value != "" {
+			v.oneOf("JOB_RUN_IN_SERVER", value, "true", "false")
+		}
+	}
+
+	if c.Outbox != nil {
+		v.duration("OUTBOX_POLL_INTERVAL", c.Outbox.PollInterval)
+		v.duration("OUTBOX_RETRY_DELAY", c.Outbox.RetryDelay)
+	}
+
+	if c.Schedule != nil {
+		v.duration("SCHEDULE_QUOTE_MAX_AGE", c.Schedule.QuoteMaxAge)
+		v.duration("SCHEDULE_ORPHAN_FILE_GRACE", c.Schedule.OrphanFileGrace)
+		v.duration("SCHEDULE_EMAIL_LOG_RETENTION", c.Schedule.EmailLogRetention)
+		v.duration("SCHEDULE_NOTIFICATION_RETENTION", c.Schedule.NotificationRetention)
+	}
+
+	return v.err()
+}
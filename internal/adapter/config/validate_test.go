@@ -0,0 +1,155 @@
+package config
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validContainer returns a container with every required variable set, on local storage and SMTP
+func validContainer() *Container {
+	return &Container{
+		App:        &App{Name: "harajuku", Env: "development", ReminderLead: "24h", WebhookRetryDelay: "30s"},
+		Token:      &Token{Duration: "15m"},
+		Redis:      &Redis{Provider: "redis", Mode: "standalone", Addr: "localhost:6379", DefaultTTL: "1h"},
+		DB:         &DB{Connection: "postgres", Host: "127.0.0.1", Port: "5432", User: "postgres", Name: "harajuku"},
+		HTTP:       &HTTP{Port: "8080", ReadinessTimeout: "2s", ShutdownTimeout: "15s"},
+		Email:      &Email{Provider: "smtp", FromEmail: "hola@harajuku.mx", QueueRetryDelay: "30s", QueuePollInterval: "1s"},
+		AwsS3:      &AwsS3{},
+		Storage:    &Storage{Provider: "local", LocalDir: "uploads"},
+		Minio:      &Minio{},
+		GCS:        &GCS{},
+		ClamAV:     &ClamAV{},
+		CloudFront: &CloudFront{},
+		SMTP:       &SMTP{Host: "smtp.harajuku.mx", Port: "587", TLS: "starttls"},
+		Twilio:     &Twilio{},
+		WhatsApp:   &WhatsApp{},
+		Telemetry:  &Telemetry{},
+		GRPC:       &GRPC{},
+		Jobs:       &Jobs{MaxAttempts: 5, RetryDelay: "30s", PollInterval: "1s", Concurrency: 2, RunInServer: true},
+		Schedule:   &Schedule{QuoteExpiration: "0 * * * *", QuoteMaxAge: "720h", OrphanFileGrace: "24h", EmailLogRetention: "2160h"},
+		Outbox:     &Outbox{PollInterval: "1s", RetryDelay: "10s"},
+		Sentry:     &Sentry{},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, (&validation{}).validate(validContainer()))
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	t.Setenv("HTTP_COMPRESSION_LEVEL", "11")
+	t.Setenv("QUOTA_CLIENT_FILE_UPLOAD", "many")
+
+	c := validContainer()
+	c.DB.Host = ""
+	c.HTTP.Port = "80800"
+	c.Token.Duration = "15"
+	c.Storage.Provider = "s3"
+	c.CloudFront.Domain = "https://d111111abcdef8.cloudfront.net"
+
+	err := (&validation{}).validate(c)
+
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []string{
+		`TOKEN_DURATION must be a positive duration like 30s or 5m, got "15"`,
+		"DB_HOST is required",
+		`HTTP_PORT must be a port between 1 and 65535, got "80800"`,
+		"HTTP_COMPRESSION_LEVEL must be between 0 and 9, got 11",
+		"AWS_S3_BUCKET_NAME is required when STORAGE_PROVIDER is s3",
+		"AWS_S3_REGION is required when STORAGE_PROVIDER is s3",
+		"CLOUDFRONT_KEY_PAIR_ID is required when CLOUDFRONT_DOMAIN is set",
+		"CLOUDFRONT_PRIVATE_KEY_FILE is required when CLOUDFRONT_DOMAIN is set",
+		`QUOTA_CLIENT_FILE_UPLOAD must be an integer, got "many"`,
+	}, validationErr.Problems)
+}
+
+func TestValidateProviders(t *testing.T) {
+	tests := map[string]struct {
+		change  func(c *Container)
+		problem string
+	}{
+		"unknown email provider": {
+			change:  func(c *Container) { c.Email.Provider = "postmark" },
+			problem: `EMAIL_PROVIDER must be one of mailtrap, smtp, ses, sendgrid, memory, got "postmark"`,
+		},
+		"mailtrap URL": {
+			change: func(c *Container) {
+				c.Email.Provider, c.Email.Url, c.Email.ApiToken = "mailtrap", "send.api.mailtrap.io", "token"
+			},
+			problem: `EMAIL_URL must be an http or https URL, got "send.api.mailtrap.io"`,
+		},
+		"sentinel master": {
+			change:  func(c *Container) { c.Redis.Mode = "sentinel" },
+			problem: "REDIS_MASTER_NAME is required when REDIS_MODE is sentinel",
+		},
+		"minio scheme": {
+			change: func(c *Container) {
+				c.Storage.Provider, c.Minio.Endpoint, c.Minio.Bucket = "minio", "http://localhost:9000", "harajuku"
+			},
+			problem: `MINIO_ENDPOINT must be a host and port without a scheme, MINIO_USE_SSL picks it, got "http://localhost:9000"`,
+		},
+		"mercadopago access token": {
+			change:  func(c *Container) { c.PaymentWebhook = &PaymentWebhook{MercadoPagoSecret: "mp_secret"} },
+			problem: "PAYMENT_WEBHOOK_MERCADOPAGO_ACCESS_TOKEN is required when PAYMENT_WEBHOOK_MERCADOPAGO_SECRET is set",
+		},
+		"twilio sender": {
+			change:  func(c *Container) { c.Twilio.AccountSID, c.Twilio.AuthToken = "AC123", "secret" },
+			problem: "TWILIO_FROM_NUMBER or TWILIO_MESSAGING_SERVICE_SID is required when TWILIO_ACCOUNT_SID is set",
+		},
+		"query args in production": {
+			change:  func(c *Container) { c.App.Env, c.DB.QueryLogArgs = "production", true },
+			problem: "DB_QUERY_LOG_ARGS can't be enabled in production",
+		},
+		"memory storage in production": {
+			change:  func(c *Container) { c.App.Env, c.Storage.Provider = "production", "memory" },
+			problem: "STORAGE_PROVIDER memory loses the files on restart, it can't be used in production",
+		},
+		"sentry project": {
+			change:  func(c *Container) { c.Sentry.DSN = "https://key@o0.ingest.sentry.io" },
+			problem: `SENTRY_DSN must be a DSN like https://key@o0.ingest.sentry.io/0, got "https://key@o0.ingest.sentry.io"`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := validContainer()
+			tt.change(c)
+
+			var validationErr *ValidationError
+			require.True(t, errors.As((&validation{}).validate(c), &validationErr))
+			assert.Equal(t, []string{tt.problem}, validationErr.Problems)
+		})
+	}
+}
+
+func TestResolveSecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"data":{"db_password":"s3cr3t"}}}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("DB_PASSWORD", "secret:harajuku/production#db_password")
+	t.Setenv("EMAIL_API_TOKEN", "secret:harajuku/production#email_api_token")
+
+	v := &validation{}
+	resolveSecrets(&Secrets{Provider: "vault", VaultAddr: server.URL, VaultToken: "root", VaultMount: "secret"}, v)
+
+	assert.Equal(t, "s3cr3t", os.Getenv("DB_PASSWORD"))
+	assert.Equal(t, []string{"EMAIL_API_TOKEN: secret harajuku/production has no field email_api_token"}, v.problems)
+}
+
+func TestResolveSecretsWithoutProvider(t *testing.T) {
+	t.Setenv("DB_PASSWORD", "secret:harajuku/production#db_password")
+
+	v := &validation{}
+	resolveSecrets(&Secrets{}, v)
+
+	assert.Equal(t, []string{"DB_PASSWORD can't be read from the secrets manager: SECRETS_PROVIDER is not set"}, v.problems)
+}
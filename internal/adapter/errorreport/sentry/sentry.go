@@ -0,0 +1,364 @@
+package sentry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// queueSize is how many reports wait to be sent, the reports beyond it are dropped so a burst of
+	// errors never blocks the requests reporting them
+	queueSize = 100
+	// maxFrames is the deepest stack reported
+	maxFrames = 64
+	// clientName identifies the reports of this service to Sentry
+	clientName = "harajuku-backend/1.0"
+)
+
+/**
+ * Sentry implements port.ErrorReporter interface
+ * and sends the reports to a Sentry project as envelopes, one at a time in the background
+ */
+type Sentry struct {
+	client      *http.Client
+	url         string
+	auth        string
+	environment string
+	release     string
+	serverName  string
+
+	queue   chan *event
+	pending sync.WaitGroup
+}
+
+// New creates a new reporter sending to the project of cfg.DSN, without a DSN the reports are discarded
+func New(cfg *config.Sentry) (port.ErrorReporter, error) {
+	if cfg.DSN == "" {
+		return noop{}, nil
+	}
+
+	envelopeURL, key, err := parseDSN(cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	release := cfg.Release
+	if release == "" {
+		release = buildRevision()
+	}
+	serverName, _ := os.Hostname()
+
+	s := &Sentry{
+		client:      &http.Client{Timeout: 10 * time.Second},
+		url:         envelopeURL,
+		auth:        fmt.Sprintf("Sentry sentry_version=7, sentry_key=%s, sentry_client=%s", key, clientName),
+		environment: cfg.Environment,
+		release:     release,
+		serverName:  serverName,
+		queue:       make(chan *event, queueSize),
+	}
+	go s.send()
+
+	return s, nil
+}
+
+// parseDSN returns the envelope endpoint and the public key of a DSN like https://key@o0.ingest.sentry.io/0
+func parseDSN(dsn string) (string, string, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid sentry DSN: %w", err)
+	}
+
+	key := u.User.Username()
+	path, project, _ := cutLast(strings.TrimSuffix(u.Path, "/"), "/")
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || key == "" || project == "" {
+		return "", "", errors.New("invalid sentry DSN, expected https://key@host/project")
+	}
+
+	return fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, path, project), key, nil
+}
+
+// buildRevision returns the VCS revision the binary was built from, empty when it isn't known
+func buildRevision() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			return setting.Value
+		}
+	}
+
+	return ""
+}
+
+// Report sends err with the stack of its caller
+func (s *Sentry) Report(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+
+	s.enqueue(s.newEvent(ctx, "error", exception{
+		Type:       fmt.Sprintf("%T", err),
+		Value:      err.Error(),
+		Stacktrace: newStacktrace(3),
+	}))
+}
+
+// ReportPanic sends recovered with the stack of the panic, which is still on the stack of the deferred function calling it
+func (s *Sentry) ReportPanic(ctx context.Context, recovered any) {
+	s.enqueue(s.newEvent(ctx, "fatal", exception{
+		Type:       "panic",
+		Value:      fmt.Sprint(recovered),
+		Stacktrace: newStacktrace(3),
+	}))
+}
+
+// Flush waits until the queued reports are sent or ctx is done
+func (s *Sentry) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// newEvent returns an event of exc tagged with the environment, the release and the log attributes of ctx.
+// The route names the transaction and the user ID the user, as Sentry groups and searches them
+func (s *Sentry) newEvent(ctx context.Context, level string, exc exception) *event {
+	e := &event{
+		EventID:     strings.ReplaceAll(uuid.NewString(), "-", ""),
+		Timestamp:   time.Now().UTC(),
+		Level:       level,
+		Platform:    "go",
+		ServerName:  s.serverName,
+		Environment: s.environment,
+		Release:     s.release,
+		Tags:        map[string]string{},
+		Exception:   exceptions{Values: []exception{exc}},
+	}
+
+	for _, attr := range util.LogAttrs(ctx) {
+		value := attr.Value.String()
+		e.Tags[attr.Key] = value
+
+		switch attr.Key {
+		case "route":
+			e.Transaction = value
+		case "user_id":
+			e.User = &user{ID: value}
+		}
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		e.Tags["trace_id"] = span.TraceID().String()
+	}
+
+	return e
+}
+
+// enqueue queues e to be sent, or drops it when the queue is full
+func (s *Sentry) enqueue(e *event) {
+	s.pending.Add(1)
+	select {
+	case s.queue <- e:
+	default:
+		s.pending.Done()
+		slog.Warn("Error report queue full, dropping the report", "event_id", e.EventID)
+	}
+}
+
+// send sends the queued events one at a time, a failed report is logged and not retried
+func (s *Sentry) send() {
+	for e := range s.queue {
+		if err := s.post(e); err != nil {
+			slog.Warn("Error report failed", "event_id", e.EventID, "error", err)
+		}
+		s.pending.Done()
+	}
+}
+
+// post sends e in an envelope, a header line followed by the item header and the event
+func (s *Sentry) post(e *event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	enc.Encode(map[string]any{"event_id": e.EventID, "sent_at": time.Now().UTC()})
+	enc.Encode(map[string]any{"type": "event", "length": len(payload)})
+	body.Write(payload)
+	body.WriteByte('\n')
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.client.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", s.auth)
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("sentry rejected the report with status %d: %s", res.StatusCode, msg)
+	}
+
+	return nil
+}
+
+// newStacktrace returns the stack of the caller skip frames up, with the outermost call first as Sentry expects
+func newStacktrace(skip int) *stacktrace {
+	pcs := make([]uintptr, maxFrames)
+	n := runtime.Callers(skip, pcs)
+	if n == 0 {
+		return nil
+	}
+
+	var frames []frame
+	callers := runtime.CallersFrames(pcs[:n])
+	for {
+		f, more := callers.Next()
+		module, function := splitFunction(f.Function)
+		frames = append(frames, frame{
+			Function: function,
+			Module:   module,
+			AbsPath:  f.File,
+			Lineno:   f.Line,
+			// The frames of the runtime and the dependencies are collapsed by Sentry
+			InApp: f.Function != "" && !strings.HasPrefix(f.Function, "runtime.") && !strings.Contains(f.File, "/pkg/mod/"),
+		})
+		if !more {
+			break
+		}
+	}
+
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+
+	return &stacktrace{Frames: frames}
+}
+
+// splitFunction splits a function name like harajuku/backend/internal/core/service.(*JobQueueService).run
+// into its package and the rest
+func splitFunction(name string) (string, string) {
+	dir, last, found := cutLast(name, "/")
+	if !found {
+		dir, last = "", name
+	}
+
+	pkg, function, found := strings.Cut(last, ".")
+	if !found {
+		return "", name
+	}
+	if dir != "" {
+		pkg = dir + "/" + pkg
+	}
+
+	return pkg, function
+}
+
+// cutLast slices s around the last instance of sep, like strings.Cut does around the first
+func cutLast(s, sep string) (string, string, bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+/**
+ * noop implements port.ErrorReporter interface
+ * and discards the reports, for when no DSN is configured
+ */
+type noop struct{}
+
+// Report discards err
+func (noop) Report(ctx context.Context, err error) {}
+
+// ReportPanic discards recovered
+func (noop) ReportPanic(ctx context.Context, recovered any) {}
+
+// Flush has nothing to wait for
+func (noop) Flush(ctx context.Context) error {
+	return nil
+}
+
+// The types below are the JSON encoding of a Sentry event, with the fields the reports use
+
+type event struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   time.Time         `json:"timestamp"`
+	Level       string            `json:"level"`
+	Platform    string            `json:"platform"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	Transaction string            `json:"transaction,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	User        *user             `json:"user,omitempty"`
+	Exception   exceptions        `json:"exception"`
+}
+
+type user struct {
+	ID string `json:"id"`
+}
+
+type exceptions struct {
+	Values []exception `json:"values"`
+}
+
+type exception struct {
+	Type       string      `json:"type"`
+	Value      string      `json:"value"`
+	Stacktrace *stacktrace `json:"stacktrace,omitempty"`
+}
+
+type stacktrace struct {
+	Frames []frame `json:"frames"`
+}
+
+type frame struct {
+	Function string `json:"function"`
+	Module   string `json:"module,omitempty"`
+	AbsPath  string `json:"abs_path,omitempty"`
+	Lineno   int    `json:"lineno,omitempty"`
+	InApp    bool   `json:"in_app"`
+}
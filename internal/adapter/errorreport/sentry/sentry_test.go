@@ -0,0 +1,143 @@
+package sentry
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDSN(t *testing.T) {
+	envelopeURL, key, err := parseDSN("https://abc123@o42.ingest.sentry.io/7")
+	require.NoError(t, err)
+	assert.Equal(t, "https://o42.ingest.sentry.io/api/7/envelope/", envelopeURL)
+	assert.Equal(t, "abc123", key)
+
+	envelopeURL, _, err = parseDSN("http://abc123@sentry.internal:9000/errors/7")
+	require.NoError(t, err)
+	assert.Equal(t, "http://sentry.internal:9000/errors/api/7/envelope/", envelopeURL)
+
+	for _, dsn := range []string{"https://o42.ingest.sentry.io/7", "https://abc123@o42.ingest.sentry.io", "ftp://abc123@host/7"} {
+		_, _, err := parseDSN(dsn)
+		assert.Error(t, err, dsn)
+	}
+}
+
+func TestNewWithoutDSN(t *testing.T) {
+	reporter, err := New(&config.Sentry{})
+	require.NoError(t, err)
+
+	reporter.Report(context.Background(), errors.New("boom"))
+	assert.NoError(t, reporter.Flush(context.Background()))
+}
+
+// receivedEvent starts a Sentry project, reports through report and returns the event it received
+func receivedEvent(t *testing.T, report func(reporter *Sentry)) *event {
+	t.Helper()
+
+	events := make(chan *event, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/7/envelope/", r.URL.Path)
+		assert.Contains(t, r.Header.Get("X-Sentry-Auth"), "sentry_key=abc123")
+
+		// The envelope header and the item header come before the event
+		scanner := bufio.NewScanner(r.Body)
+		scanner.Buffer(nil, 1<<20)
+		for range 3 {
+			require.True(t, scanner.Scan())
+		}
+
+		var e event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		events <- &e
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "://", "://abc123@", 1) + "/7"
+	reporter, err := New(&config.Sentry{DSN: dsn, Environment: "production", Release: "v1.2.3"})
+	require.NoError(t, err)
+
+	report(reporter.(*Sentry))
+	require.NoError(t, reporter.Flush(context.Background()))
+
+	select {
+	case e := <-events:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+		return nil
+	}
+}
+
+func TestReport(t *testing.T) {
+	ctx := util.WithLogAttrs(context.Background(),
+		slog.String("request_id", "req-1"),
+		slog.String("route", "POST /v1/quotes"),
+		slog.String("user_id", "user-1"),
+	)
+
+	e := receivedEvent(t, func(reporter *Sentry) {
+		reporter.Report(ctx, errors.New("transaction failed"))
+	})
+
+	assert.Equal(t, "error", e.Level)
+	assert.Equal(t, "production", e.Environment)
+	assert.Equal(t, "v1.2.3", e.Release)
+	assert.Equal(t, "POST /v1/quotes", e.Transaction)
+	assert.Equal(t, "req-1", e.Tags["request_id"])
+	assert.Equal(t, &user{ID: "user-1"}, e.User)
+	require.Len(t, e.Exception.Values, 1)
+	assert.Equal(t, "transaction failed", e.Exception.Values[0].Value)
+
+	// The innermost frame is the caller of Report
+	frames := e.Exception.Values[0].Stacktrace.Frames
+	require.NotEmpty(t, frames)
+	assert.Equal(t, "TestReport.func1", frames[len(frames)-1].Function)
+	assert.True(t, frames[len(frames)-1].InApp)
+}
+
+func TestReportPanic(t *testing.T) {
+	e := receivedEvent(t, func(reporter *Sentry) {
+		defer func() {
+			reporter.ReportPanic(context.Background(), recover())
+		}()
+		panicking()
+	})
+
+	assert.Equal(t, "fatal", e.Level)
+	assert.Equal(t, "panic", e.Exception.Values[0].Type)
+	assert.Equal(t, "nil map", e.Exception.Values[0].Value)
+
+	var functions []string
+	for _, f := range e.Exception.Values[0].Stacktrace.Frames {
+		functions = append(functions, f.Function)
+	}
+	assert.Contains(t, functions, "panicking")
+}
+
+// panicking panics, the report of the panic must include it
+func panicking() {
+	panic("nil map")
+}
+
+func TestSplitFunction(t *testing.T) {
+	module, function := splitFunction("harajuku/backend/internal/core/service.(*JobQueueService).run")
+	assert.Equal(t, "harajuku/backend/internal/core/service", module)
+	assert.Equal(t, "(*JobQueueService).run", function)
+
+	module, function = splitFunction("main.main")
+	assert.Equal(t, "main", module)
+	assert.Equal(t, "main", function)
+}
@@ -0,0 +1,78 @@
+package event
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"harajuku/backend/internal/core/domain"
+)
+
+// subscriberBuffer is how many events a subscriber can fall behind before new ones are dropped for it
+const subscriberBuffer = 32
+
+/**
+ * Dispatcher implements port.EventDispatcher interface
+ * and fans the events published in this process out to its subscribers
+ */
+type Dispatcher struct {
+	mu          sync.RWMutex
+	subscribers map[*subscriber]struct{}
+}
+
+// subscriber is a channel and the event types it listens to
+type subscriber struct {
+	ch    chan domain.Event
+	types []domain.EventType
+}
+
+// New creates a new in-process event dispatcher
+func New() *Dispatcher {
+	return &Dispatcher{
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Publish delivers the event to every subscriber of its type, a subscriber whose buffer
+// is full misses the event so a slow consumer never blocks the publishing request
+func (d *Dispatcher) Publish(ctx context.Context, event domain.Event) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for sub := range d.subscribers {
+		if len(sub.types) > 0 && !slices.Contains(sub.types, event.Type) {
+			continue
+		}
+
+		select {
+		case sub.ch <- event:
+		default:
+			slog.WarnContext(ctx, "Dropped event for slow subscriber", "type", event.Type, "id", event.ID)
+		}
+	}
+}
+
+// Subscribe registers a subscriber for the given event types, or every type when none is given
+func (d *Dispatcher) Subscribe(types ...domain.EventType) (<-chan domain.Event, func()) {
+	sub := &subscriber{
+		ch:    make(chan domain.Event, subscriberBuffer),
+		types: types,
+	}
+
+	d.mu.Lock()
+	d.subscribers[sub] = struct{}{}
+	d.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			d.mu.Lock()
+			delete(d.subscribers, sub)
+			d.mu.Unlock()
+			close(sub.ch)
+		})
+	}
+
+	return sub.ch, unsubscribe
+}
@@ -0,0 +1,48 @@
+package event
+
+import (
+	"context"
+	"testing"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishFiltersByType(t *testing.T) {
+	d := New()
+	ctx := context.Background()
+
+	quotes, unsubscribeQuotes := d.Subscribe(domain.EventQuoteCreated)
+	defer unsubscribeQuotes()
+	all, unsubscribeAll := d.Subscribe()
+	defer unsubscribeAll()
+
+	d.Publish(ctx, domain.NewEvent(domain.EventPaymentProofUploaded, nil))
+	d.Publish(ctx, domain.NewEvent(domain.EventQuoteCreated, nil))
+
+	require.Len(t, quotes, 1)
+	assert.Equal(t, domain.EventQuoteCreated, (<-quotes).Type)
+
+	require.Len(t, all, 2)
+	assert.Equal(t, domain.EventPaymentProofUploaded, (<-all).Type)
+	assert.Equal(t, domain.EventQuoteCreated, (<-all).Type)
+}
+
+func TestPublishDropsForFullSubscriber(t *testing.T) {
+	d := New()
+	ctx := context.Background()
+
+	events, unsubscribe := d.Subscribe()
+
+	for range subscriberBuffer + 5 {
+		d.Publish(ctx, domain.NewEvent(domain.EventQuoteCreated, nil))
+	}
+	assert.Len(t, events, subscriberBuffer)
+
+	unsubscribe()
+	unsubscribe()
+	d.Publish(ctx, domain.NewEvent(domain.EventQuoteCreated, nil))
+	assert.Len(t, events, subscriberBuffer)
+}
@@ -0,0 +1,36 @@
+package graphql
+
+import (
+	"errors"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// errorCodeMap is a map of defined errors and the codes set in the extensions of the GraphQL errors,
+// the same stable codes the HTTP API answers with
+var errorCodeMap = map[error]string{
+	domain.ErrInternal:      "internal_error",
+	domain.ErrDataNotFound:  "not_found",
+	domain.ErrUnauthorized:  "unauthorized",
+	domain.ErrForbidden:     "forbidden",
+	domain.ErrInvalidCursor: "invalid_cursor",
+}
+
+// toError converts an error of the core services into a GraphQL error, unknown errors are reported
+// as internal without leaking their message
+func toError(err error) error {
+	for target, code := range errorCodeMap {
+		if errors.Is(err, target) {
+			return &gqlerror.Error{Message: target.Error(), Extensions: map[string]interface{}{"code": code}}
+		}
+	}
+
+	return &gqlerror.Error{Message: domain.ErrInternal.Error(), Extensions: map[string]interface{}{"code": errorCodeMap[domain.ErrInternal]}}
+}
+
+// invalidArgument returns the error of an argument failing validation
+func invalidArgument(message string) error {
+	return &gqlerror.Error{Message: message, Extensions: map[string]interface{}{"code": "validation_failed"}}
+}
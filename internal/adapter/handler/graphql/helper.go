@@ -0,0 +1,87 @@
+package graphql
+
+import (
+	"context"
+	"log/slog"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// minLimit and maxLimit bound the page sizes, every item of a page can resolve its own nested fields
+	minLimit = 5
+	maxLimit = 100
+	// maxQuoteAppointments is how many appointments of a quote are looked at for its current one, the
+	// cancelled ones are still listed
+	maxQuoteAppointments = 20
+)
+
+// canSee reports whether the user of ctx can see the quotes and appointments of a client, admins see all of them
+func canSee(ctx context.Context, clientID uuid.UUID) bool {
+	actor := util.Actor(ctx)
+	return actor.Role == domain.Admin || actor.UserID == clientID
+}
+
+// validateLimit checks the size of a page
+func validateLimit(limit int) error {
+	if limit < minLimit || limit > maxLimit {
+		return invalidArgument("limit must be between 5 and 100")
+	}
+	return nil
+}
+
+// parseCursor decodes the cursor of a page, nil when the listing starts from the beginning
+func parseCursor(token *string) (*domain.Cursor, error) {
+	if token == nil || *token == "" {
+		return nil, nil
+	}
+
+	cursor, err := domain.DecodeCursor(*token)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return cursor, nil
+}
+
+// newPageInfo returns the total and the cursor of the next page, which is nil on the last page
+func newPageInfo(total uint64, next *domain.Cursor) *PageInfo {
+	info := &PageInfo{Total: int(total)}
+	if next != nil {
+		cursor := next.Encode()
+		info.NextCursor = &cursor
+	}
+	return info
+}
+
+// latestAppointment returns the last appointment booked for a quote that wasn't cancelled, or the last
+// cancelled one when there is no other. The appointments are ordered by the start of their slot
+func latestAppointment(appointments []domain.Appointment) *domain.Appointment {
+	if len(appointments) == 0 {
+		return nil
+	}
+
+	for i := len(appointments) - 1; i >= 0; i-- {
+		if appointments[i].Status != domain.Cancelled {
+			return &appointments[i]
+		}
+	}
+	return &appointments[len(appointments)-1]
+}
+
+// fileURL returns a signed URL for the file at path, or the path itself when signing is disabled or fails
+func (r *Resolver) fileURL(ctx context.Context, path string) string {
+	if r.signer == nil || path == "" {
+		return path
+	}
+
+	signed, err := r.signer.SignURL(path)
+	if err != nil {
+		slog.ErrorContext(ctx, "File URL signing failed", "path", path, "error", err)
+		return path
+	}
+
+	return signed
+}
@@ -0,0 +1,50 @@
+package graphql
+
+//go:generate go run github.com/99designs/gqlgen generate
+
+import (
+	"net/http"
+
+	"harajuku/backend/internal/core/port"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+)
+
+// maxComplexity bounds how many fields a query resolves, nested quotes and appointments resolve each other
+const maxComplexity = 500
+
+// Resolver resolves the queries of the gateway with the same core services as the HTTP API
+type Resolver struct {
+	quote         port.QuoteService
+	appointment   port.AppointmentService
+	slot          port.AvailabilitySlotService
+	typeOfService port.TypeOfServiceService
+	signer        port.FileURLSigner
+}
+
+// NewHandler creates the handler of the GraphQL gateway, signer can be nil to return the image paths
+// unsigned. The requests it serves must have gone through the authentication of the HTTP router
+func NewHandler(
+	quote port.QuoteService,
+	appointment port.AppointmentService,
+	slot port.AvailabilitySlotService,
+	typeOfService port.TypeOfServiceService,
+	signer port.FileURLSigner,
+) http.Handler {
+	resolver := &Resolver{
+		quote:         quote,
+		appointment:   appointment,
+		slot:          slot,
+		typeOfService: typeOfService,
+		signer:        signer,
+	}
+
+	server := handler.New(NewExecutableSchema(Config{Resolvers: resolver}))
+	server.AddTransport(transport.POST{})
+	server.Use(extension.Introspection{})
+	server.Use(extension.FixedComplexityLimit(maxComplexity))
+
+	return server
+}
@@ -0,0 +1,256 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQuotes is a port.QuoteService serving its quotes and images, and keeping the filter of the last listing
+type fakeQuotes struct {
+	port.QuoteService
+	quotes map[uuid.UUID]domain.Quote
+	images map[uuid.UUID][]domain.QuoteImage
+	filter port.QuoteFilter
+}
+
+func (f *fakeQuotes) GetQuote(ctx context.Context, id uuid.UUID) (*domain.Quote, []domain.QuoteImage, error) {
+	quote, ok := f.quotes[id]
+	if !ok {
+		return nil, nil, domain.ErrDataNotFound
+	}
+	return &quote, f.images[id], nil
+}
+
+func (f *fakeQuotes) ListQuotes(ctx context.Context, filter port.QuoteFilter) ([]domain.Quote, uint64, *domain.Cursor, error) {
+	f.filter = filter
+	var quotes []domain.Quote
+	for _, quote := range f.quotes {
+		if filter.ClientID == nil || quote.ClientID == *filter.ClientID {
+			quotes = append(quotes, quote)
+		}
+	}
+	return quotes, uint64(len(quotes)), nil, nil
+}
+
+// fakeAppointments is a port.AppointmentService serving its appointments
+type fakeAppointments struct {
+	port.AppointmentService
+	appointments []domain.Appointment
+}
+
+func (f *fakeAppointments) GetAppointment(ctx context.Context, id uuid.UUID) (*domain.Appointment, error) {
+	for _, appointment := range f.appointments {
+		if appointment.ID == id {
+			return &appointment, nil
+		}
+	}
+	return nil, domain.ErrDataNotFound
+}
+
+func (f *fakeAppointments) ListAppointments(ctx context.Context, filter port.AppointmentFilter) ([]domain.Appointment, uint64, *domain.Cursor, error) {
+	var appointments []domain.Appointment
+	for _, appointment := range f.appointments {
+		if filter.QuoteID != nil && appointment.QuoteID != *filter.QuoteID {
+			continue
+		}
+		if filter.CustomerID != nil && appointment.UserID != *filter.CustomerID {
+			continue
+		}
+		appointments = append(appointments, appointment)
+	}
+	return appointments, uint64(len(appointments)), nil, nil
+}
+
+// fakeSlots is a port.AvailabilitySlotService serving its slots
+type fakeSlots struct {
+	port.AvailabilitySlotService
+	slots map[uuid.UUID]domain.AvailabilitySlot
+}
+
+func (f *fakeSlots) GetAvailabilitySlot(ctx context.Context, id uuid.UUID) (*domain.AvailabilitySlot, error) {
+	slot, ok := f.slots[id]
+	if !ok {
+		return nil, domain.ErrDataNotFound
+	}
+	return &slot, nil
+}
+
+// fakeTypesOfService is a port.TypeOfServiceService serving its types of service
+type fakeTypesOfService struct {
+	port.TypeOfServiceService
+	types map[uuid.UUID]domain.TypeOfService
+}
+
+func (f *fakeTypesOfService) GetTypeOfService(ctx context.Context, id uuid.UUID) (*domain.TypeOfService, error) {
+	t, ok := f.types[id]
+	if !ok {
+		return nil, domain.ErrDataNotFound
+	}
+	return &t, nil
+}
+
+// prefixSigner is a port.FileURLSigner signing the paths under a CDN domain
+type prefixSigner struct{}
+
+func (prefixSigner) SignURL(path string) (string, error) {
+	return "https://cdn.harajuku.mx/" + path + "?signature=abc", nil
+}
+
+// graphQLResponse is the body of a GraphQL response
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+// query runs a query on the gateway as the user of payload
+func query(t *testing.T, handler http.Handler, payload *domain.TokenPayload, q string, variables map[string]any) graphQLResponse {
+	body, err := json.Marshal(map[string]any{"query": q, "variables": variables})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(util.WithActor(req.Context(), payload))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var rsp graphQLResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rsp))
+	return rsp
+}
+
+func TestQuoteResolution(t *testing.T) {
+	client := &domain.TokenPayload{ID: uuid.New(), UserID: uuid.New(), Role: domain.Client}
+	other := &domain.TokenPayload{ID: uuid.New(), UserID: uuid.New(), Role: domain.Client}
+	admin := &domain.TokenPayload{ID: uuid.New(), UserID: uuid.New(), Role: domain.Admin}
+
+	typeOfService := domain.TypeOfService{ID: uuid.New(), Name: "Balayage", Price: 1500, Currency: domain.CurrencyMXN, DurationMinutes: 180}
+	quote := domain.Quote{ID: uuid.New(), TypeOfServiceID: typeOfService.ID, ClientID: client.UserID, State: domain.QuoteApproved, Price: 1500, Discount: 150, Currency: domain.CurrencyMXN, Time: time.Now()}
+	slot := domain.AvailabilitySlot{ID: uuid.New(), AdminID: admin.UserID, StartTime: time.Now().Add(24 * time.Hour), EndTime: time.Now().Add(27 * time.Hour), IsBooked: true}
+	cancelled := domain.Appointment{ID: uuid.New(), UserID: client.UserID, SlotID: slot.ID, QuoteID: quote.ID, Status: domain.Cancelled}
+	booked := domain.Appointment{ID: uuid.New(), UserID: client.UserID, SlotID: slot.ID, QuoteID: quote.ID, Status: domain.Booked}
+
+	quotes := &fakeQuotes{
+		quotes: map[uuid.UUID]domain.Quote{quote.ID: quote},
+		images: map[uuid.UUID][]domain.QuoteImage{quote.ID: {{ID: uuid.New(), QuoteID: quote.ID, URL: "quotes/referencia.jpg", FileName: "referencia.jpg", Size: 2048, ContentType: "image/jpeg"}}},
+	}
+	handler := NewHandler(
+		quotes,
+		&fakeAppointments{appointments: []domain.Appointment{booked, cancelled}},
+		&fakeSlots{slots: map[uuid.UUID]domain.AvailabilitySlot{slot.ID: slot}},
+		&fakeTypesOfService{types: map[uuid.UUID]domain.TypeOfService{typeOfService.ID: typeOfService}},
+		prefixSigner{},
+	)
+
+	const quoteQuery = `query($id: UUID!) {
+		quote(id: $id) {
+			id state discount total currency
+			typeOfService { name durationMinutes }
+			images { url fileName }
+			appointment { id status slot { adminId } quote { id } }
+		}
+	}`
+
+	rsp := query(t, handler, client, quoteQuery, map[string]any{"id": quote.ID})
+	require.Empty(t, rsp.Errors)
+
+	var data struct {
+		Quote struct {
+			ID            uuid.UUID
+			State         string
+			Discount      float64
+			Total         float64
+			Currency      string
+			TypeOfService struct {
+				Name            string
+				DurationMinutes int
+			}
+			Images []struct {
+				URL      string
+				FileName string
+			}
+			Appointment struct {
+				ID     uuid.UUID
+				Status string
+				Slot   struct{ AdminID uuid.UUID }
+				Quote  struct{ ID uuid.UUID }
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(rsp.Data, &data))
+	assert.Equal(t, quote.ID, data.Quote.ID)
+	assert.Equal(t, "approved", data.Quote.State)
+	assert.Equal(t, 150.0, data.Quote.Discount)
+	assert.Equal(t, 1350.0, data.Quote.Total)
+	assert.Equal(t, "MXN", data.Quote.Currency)
+	assert.Equal(t, "Balayage", data.Quote.TypeOfService.Name)
+	assert.Equal(t, 180, data.Quote.TypeOfService.DurationMinutes)
+	require.Len(t, data.Quote.Images, 1)
+	assert.Equal(t, "https://cdn.harajuku.mx/quotes/referencia.jpg?signature=abc", data.Quote.Images[0].URL)
+	// The cancelled appointment is passed over for the booked one
+	assert.Equal(t, booked.ID, data.Quote.Appointment.ID)
+	assert.Equal(t, "booked", data.Quote.Appointment.Status)
+	assert.Equal(t, admin.UserID, data.Quote.Appointment.Slot.AdminID)
+	assert.Equal(t, quote.ID, data.Quote.Appointment.Quote.ID)
+
+	// Other clients can't see the quote, admins can
+	rsp = query(t, handler, other, quoteQuery, map[string]any{"id": quote.ID})
+	require.Len(t, rsp.Errors, 1)
+	assert.Equal(t, "forbidden", rsp.Errors[0].Extensions["code"])
+
+	rsp = query(t, handler, admin, quoteQuery, map[string]any{"id": quote.ID})
+	assert.Empty(t, rsp.Errors)
+
+	rsp = query(t, handler, admin, quoteQuery, map[string]any{"id": uuid.New()})
+	require.Len(t, rsp.Errors, 1)
+	assert.Equal(t, "not_found", rsp.Errors[0].Extensions["code"])
+}
+
+func TestQuotesListedForTheirClient(t *testing.T) {
+	client := &domain.TokenPayload{ID: uuid.New(), UserID: uuid.New(), Role: domain.Client}
+	admin := &domain.TokenPayload{ID: uuid.New(), UserID: uuid.New(), Role: domain.Admin}
+
+	quotes := &fakeQuotes{quotes: map[uuid.UUID]domain.Quote{}}
+	handler := NewHandler(quotes, &fakeAppointments{}, &fakeSlots{}, &fakeTypesOfService{}, nil)
+
+	const quotesQuery = `query($clientId: UUID, $limit: Int!) {
+		quotes(clientId: $clientId, limit: $limit) { quotes { id } pageInfo { total nextCursor } }
+	}`
+
+	rsp := query(t, handler, client, quotesQuery, map[string]any{"limit": 10})
+	require.Empty(t, rsp.Errors)
+	require.NotNil(t, quotes.filter.ClientID)
+	assert.Equal(t, client.UserID, *quotes.filter.ClientID)
+	assert.Equal(t, uint64(10), quotes.filter.Limit)
+
+	// Clients can't ask for the quotes of someone else
+	rsp = query(t, handler, client, quotesQuery, map[string]any{"clientId": uuid.New(), "limit": 10})
+	require.Len(t, rsp.Errors, 1)
+	assert.Equal(t, "unauthorized", rsp.Errors[0].Extensions["code"])
+
+	someone := uuid.New()
+	rsp = query(t, handler, admin, quotesQuery, map[string]any{"clientId": someone, "limit": 10})
+	require.Empty(t, rsp.Errors)
+	assert.Equal(t, someone, *quotes.filter.ClientID)
+
+	rsp = query(t, handler, admin, quotesQuery, map[string]any{"limit": 1000})
+	require.Len(t, rsp.Errors, 1)
+	assert.Equal(t, "validation_failed", rsp.Errors[0].Extensions["code"])
+}
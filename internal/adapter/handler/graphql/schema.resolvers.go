@@ -0,0 +1,244 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.95
+
+import (
+	"context"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Slot is the resolver for the slot field.
+func (r *appointmentResolver) Slot(ctx context.Context, obj *domain.Appointment) (*domain.AvailabilitySlot, error) {
+	slot, err := r.slot.GetAvailabilitySlot(ctx, obj.SlotID)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return slot, nil
+}
+
+// Quote is the resolver for the quote field.
+func (r *appointmentResolver) Quote(ctx context.Context, obj *domain.Appointment) (*domain.Quote, error) {
+	quote, _, err := r.quote.GetQuote(ctx, obj.QuoteID)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return quote, nil
+}
+
+// Quote is the resolver for the quote field.
+func (r *queryResolver) Quote(ctx context.Context, id uuid.UUID) (*domain.Quote, error) {
+	quote, _, err := r.quote.GetQuote(ctx, id)
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	if !canSee(ctx, quote.ClientID) {
+		return nil, toError(domain.ErrForbidden)
+	}
+
+	return quote, nil
+}
+
+// Quotes is the resolver for the quotes field.
+func (r *queryResolver) Quotes(ctx context.Context, state *domain.QuoteState, clientID *uuid.UUID, typeOfServiceID *uuid.UUID, limit int, cursor *string) (*QuotePage, error) {
+	actor := util.Actor(ctx)
+	if actor.Role == domain.Client && clientID != nil {
+		return nil, toError(domain.ErrUnauthorized)
+	}
+	if actor.Role == domain.Client {
+		clientID = &actor.UserID
+	}
+
+	if err := validateLimit(limit); err != nil {
+		return nil, err
+	}
+
+	after, err := parseCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	quotes, total, next, err := r.quote.ListQuotes(ctx, port.QuoteFilter{
+		TypeOfServiceID: typeOfServiceID,
+		ClientID:        clientID,
+		ByState:         state,
+		Limit:           uint64(limit),
+		After:           after,
+	})
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	return &QuotePage{Quotes: quotes, PageInfo: newPageInfo(total, next)}, nil
+}
+
+// Appointment is the resolver for the appointment field.
+func (r *queryResolver) Appointment(ctx context.Context, id uuid.UUID) (*domain.Appointment, error) {
+	appointment, err := r.appointment.GetAppointment(ctx, id)
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	if !canSee(ctx, appointment.UserID) {
+		return nil, toError(domain.ErrForbidden)
+	}
+
+	return appointment, nil
+}
+
+// Appointments is the resolver for the appointments field.
+func (r *queryResolver) Appointments(ctx context.Context, status *domain.AppointmentStatus, clientID *uuid.UUID, quoteID *uuid.UUID, limit int, cursor *string) (*AppointmentPage, error) {
+	actor := util.Actor(ctx)
+	if actor.Role != domain.Admin {
+		clientID = &actor.UserID
+	}
+
+	if err := validateLimit(limit); err != nil {
+		return nil, err
+	}
+
+	after, err := parseCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	appointments, total, next, err := r.appointment.ListAppointments(ctx, port.AppointmentFilter{
+		CustomerID: clientID,
+		QuoteID:    quoteID,
+		ByState:    status,
+		Limit:      uint64(limit),
+		After:      after,
+	})
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	return &AppointmentPage{Appointments: appointments, PageInfo: newPageInfo(total, next)}, nil
+}
+
+// Slots is the resolver for the slots field.
+func (r *queryResolver) Slots(ctx context.Context, state *port.SlotState, startDate *time.Time, endDate *time.Time, limit int, cursor *string) (*AvailabilitySlotPage, error) {
+	if err := validateLimit(limit); err != nil {
+		return nil, err
+	}
+
+	after, err := parseCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	slots, total, next, err := r.slot.ListAvailabilitySlots(ctx, port.AvailabilitySlotFilter{
+		StartDate: startDate,
+		EndDate:   endDate,
+		ByState:   state,
+		Limit:     uint64(limit),
+		After:     after,
+	})
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	return &AvailabilitySlotPage{Slots: slots, PageInfo: newPageInfo(total, next)}, nil
+}
+
+// TypeOfService is the resolver for the typeOfService field.
+func (r *queryResolver) TypeOfService(ctx context.Context, id uuid.UUID) (*domain.TypeOfService, error) {
+	typeOfService, err := r.typeOfService.GetTypeOfService(ctx, id)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return typeOfService, nil
+}
+
+// TypesOfService is the resolver for the typesOfService field.
+func (r *queryResolver) TypesOfService(ctx context.Context, name *string, limit int, skip int) (*TypeOfServicePage, error) {
+	if err := validateLimit(limit); err != nil {
+		return nil, err
+	}
+	if skip < 0 {
+		return nil, invalidArgument("skip must not be negative")
+	}
+
+	filter := port.TypeOfServiceFilter{Skip: uint64(skip), Limit: uint64(limit)}
+	if name != nil {
+		filter.Name = *name
+	}
+
+	typesOfService, total, err := r.typeOfService.ListTypeOfServices(ctx, filter)
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	return &TypeOfServicePage{TypesOfService: typesOfService, PageInfo: newPageInfo(total, nil)}, nil
+}
+
+// TypeOfService is the resolver for the typeOfService field.
+func (r *quoteResolver) TypeOfService(ctx context.Context, obj *domain.Quote) (*domain.TypeOfService, error) {
+	// Deleted types of service still resolve for the quotes made before
+	typeOfService, err := r.typeOfService.GetTypeOfService(ctx, obj.TypeOfServiceID)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return typeOfService, nil
+}
+
+// Images is the resolver for the images field.
+func (r *quoteResolver) Images(ctx context.Context, obj *domain.Quote) ([]domain.QuoteImage, error) {
+	_, images, err := r.quote.GetQuote(ctx, obj.ID)
+	if err != nil {
+		return nil, toError(err)
+	}
+	return images, nil
+}
+
+// Appointment is the resolver for the appointment field.
+func (r *quoteResolver) Appointment(ctx context.Context, obj *domain.Quote) (*domain.Appointment, error) {
+	appointments, _, _, err := r.appointment.ListAppointments(ctx, port.AppointmentFilter{QuoteID: &obj.ID, Limit: maxQuoteAppointments})
+	if err != nil {
+		return nil, toError(err)
+	}
+
+	return latestAppointment(appointments), nil
+}
+
+// URL is the resolver for the url field.
+func (r *quoteImageResolver) URL(ctx context.Context, obj *domain.QuoteImage) (string, error) {
+	return r.fileURL(ctx, obj.URL), nil
+}
+
+// Appointment returns AppointmentResolver implementation.
+func (r *Resolver) Appointment() AppointmentResolver { return &appointmentResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// Quote returns QuoteResolver implementation.
+func (r *Resolver) Quote() QuoteResolver { return &quoteResolver{r} }
+
+// QuoteImage returns QuoteImageResolver implementation.
+func (r *Resolver) QuoteImage() QuoteImageResolver { return &quoteImageResolver{r} }
+
+type (
+	appointmentResolver struct{ *Resolver }
+	queryResolver       struct{ *Resolver }
+	quoteResolver       struct{ *Resolver }
+	quoteImageResolver  struct{ *Resolver }
+)
+
+// !!! WARNING !!!
+// The code below was going to be deleted when updating resolvers. It has been copied here so you have
+// one last chance to move it out of harms way if you want. There are two reasons this happens:
+//  - When renaming or deleting a resolver the old code will be put in here. You can safely delete
+//    it when you're done.
+//  - You have helper methods in this file. Move them out to keep these resolver files clean.
+/*
+	func (r *Resolver) TypeOfService() TypeOfServiceResolver { return &typeOfServiceResolver{r} }
+*/
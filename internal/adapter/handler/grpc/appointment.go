@@ -0,0 +1,122 @@
+package grpc
+
+import (
+	"context"
+
+	"harajuku/backend/internal/adapter/handler/grpc/pb"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+)
+
+// appointmentServer implements pb.AppointmentServiceServer on top of the appointment service
+type appointmentServer struct {
+	pb.UnimplementedAppointmentServiceServer
+	svc port.AppointmentService
+}
+
+// newAppointmentServer creates a new appointment server
+func newAppointmentServer(svc port.AppointmentService) *appointmentServer {
+	return &appointmentServer{svc: svc}
+}
+
+// GetAppointment returns an appointment, clients can only get their own appointments
+func (s *appointmentServer) GetAppointment(ctx context.Context, req *pb.GetAppointmentRequest) (*pb.Appointment, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	appointment, err := s.svc.GetAppointment(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	payload := getAuthPayload(ctx)
+	if payload.Role != domain.Admin && appointment.UserID != payload.UserID {
+		return nil, toStatus(domain.ErrForbidden)
+	}
+
+	return newAppointment(appointment), nil
+}
+
+// ListAppointments returns a page of appointments, clients only list their own
+func (s *appointmentServer) ListAppointments(ctx context.Context, req *pb.ListAppointmentsRequest) (*pb.ListAppointmentsResponse, error) {
+	if req.GetLimit() < 5 {
+		return nil, invalidArgument("limit must be at least 5")
+	}
+
+	customerID, err := parseOptionalID("customer_id", req.GetCustomerId())
+	if err != nil {
+		return nil, err
+	}
+
+	payload := getAuthPayload(ctx)
+	if payload.Role != domain.Admin {
+		customerID = &payload.UserID
+	}
+
+	quoteID, err := parseOptionalID("quote_id", req.GetQuoteId())
+	if err != nil {
+		return nil, err
+	}
+
+	var status *domain.AppointmentStatus
+	if req.GetStatus() != "" {
+		s := domain.AppointmentStatus(req.GetStatus())
+		if s != domain.Booked && s != domain.Cancelled && s != domain.Pending && s != domain.Completed {
+			return nil, invalidArgument("invalid status value, must be 'booked', 'pending', 'cancelled' or 'completed'")
+		}
+		status = &s
+	}
+
+	after, err := parseCursor(req.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+
+	appointments, total, next, err := s.svc.ListAppointments(ctx, port.AppointmentFilter{
+		CustomerID: customerID,
+		QuoteID:    quoteID,
+		ByState:    status,
+		Skip:       req.GetSkip(),
+		Limit:      req.GetLimit(),
+		After:      after,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	rsp := &pb.ListAppointmentsResponse{Total: total, NextCursor: encodeCursor(next)}
+	for i := range appointments {
+		rsp.Appointments = append(rsp.Appointments, newAppointment(&appointments[i]))
+	}
+
+	return rsp, nil
+}
+
+// CancelAppointment cancels an appointment and frees its slot, the service checks the caller owns it
+func (s *appointmentServer) CancelAppointment(ctx context.Context, req *pb.CancelAppointmentRequest) (*pb.Appointment, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	appointment, err := s.svc.CancelAppointment(ctx, id, getAuthPayload(ctx))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return newAppointment(appointment), nil
+}
+
+// newAppointment converts an appointment into its protobuf message
+func newAppointment(appointment *domain.Appointment) *pb.Appointment {
+	return &pb.Appointment{
+		Id:      appointment.ID.String(),
+		UserId:  appointment.UserID.String(),
+		SlotId:  appointment.SlotID.String(),
+		QuoteId: appointment.QuoteID.String(),
+		Status:  string(appointment.Status),
+		Version: int32(appointment.Version),
+	}
+}
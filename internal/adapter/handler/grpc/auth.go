@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"harajuku/backend/internal/adapter/handler/grpc/pb"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// authorizationMetadataKey is the key of the metadata the bearer token is sent in
+	authorizationMetadataKey = "authorization"
+	// authorizationType is the accepted authorization type
+	authorizationType = "bearer"
+)
+
+// authorizationPayloadKey is the key for the authorization payload in the context
+type authorizationPayloadKey struct{}
+
+// adminMethods are the methods only admins can call, like the routes behind the admin middleware
+var adminMethods = map[string]bool{
+	pb.QuoteService_ChangeQuoteState_FullMethodName:                  true,
+	pb.AvailabilitySlotService_CreateAvailabilitySlot_FullMethodName: true,
+}
+
+// authInterceptor is an interceptor to check if the caller is authenticated, mirroring the auth
+// middleware of the HTTP API, and if it is an admin for the admin methods
+func authInterceptor(token port.TokenService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		payload, err := verifyAuthorization(ctx, token)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+
+		if adminMethods[info.FullMethod] && payload.Role != domain.Admin {
+			return nil, toStatus(domain.ErrForbidden)
+		}
+
+		ctx = util.WithSalon(util.WithActor(ctx, payload), payload.SalonID)
+		ctx = util.WithLogAttrs(ctx, slog.String("user_id", payload.UserID.String()), slog.String("salon_id", payload.SalonID.String()))
+		return handler(context.WithValue(ctx, authorizationPayloadKey{}, payload), req)
+	}
+}
+
+// verifyAuthorization verifies the bearer token of the authorization metadata and returns its payload
+func verifyAuthorization(ctx context.Context, token port.TokenService) (*domain.TokenPayload, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationMetadataKey)
+	if len(values) == 0 || len(values[0]) == 0 {
+		return nil, domain.ErrEmptyAuthorizationHeader
+	}
+
+	fields := strings.Fields(values[0])
+	if len(fields) != 2 {
+		return nil, domain.ErrInvalidAuthorizationHeader
+	}
+
+	if strings.ToLower(fields[0]) != authorizationType {
+		return nil, domain.ErrInvalidAuthorizationType
+	}
+
+	return token.VerifyToken(fields[1])
+}
+
+// getAuthPayload returns the payload the auth interceptor stored in the context
+func getAuthPayload(ctx context.Context) *domain.TokenPayload {
+	payload, _ := ctx.Value(authorizationPayloadKey{}).(*domain.TokenPayload)
+	return payload
+}
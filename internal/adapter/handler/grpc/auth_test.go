@@ -0,0 +1,88 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"harajuku/backend/internal/adapter/handler/grpc/pb"
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeTokenService accepts the tokens it knows the payloads of
+type fakeTokenService struct {
+	payloads map[string]*domain.TokenPayload
+}
+
+func (f fakeTokenService) CreateToken(user *domain.User) (string, error) {
+	return "", nil
+}
+
+func (f fakeTokenService) VerifyToken(token string) (*domain.TokenPayload, error) {
+	if payload, ok := f.payloads[token]; ok {
+		return payload, nil
+	}
+	return nil, domain.ErrInvalidToken
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	admin := &domain.TokenPayload{ID: uuid.New(), UserID: uuid.New(), Role: domain.Admin}
+	client := &domain.TokenPayload{ID: uuid.New(), UserID: uuid.New(), Role: domain.Client}
+	interceptor := authInterceptor(fakeTokenService{map[string]*domain.TokenPayload{
+		"admin-token":  admin,
+		"client-token": client,
+	}})
+
+	tests := []struct {
+		name          string
+		authorization []string
+		method        string
+		code          codes.Code
+		payload       *domain.TokenPayload
+	}{
+		{"missing metadata", nil, pb.QuoteService_GetQuote_FullMethodName, codes.Unauthenticated, nil},
+		{"malformed header", []string{"bearer"}, pb.QuoteService_GetQuote_FullMethodName, codes.Unauthenticated, nil},
+		{"wrong type", []string{"basic client-token"}, pb.QuoteService_GetQuote_FullMethodName, codes.Unauthenticated, nil},
+		{"unknown token", []string{"bearer nope"}, pb.QuoteService_GetQuote_FullMethodName, codes.Unauthenticated, nil},
+		{"client", []string{"Bearer client-token"}, pb.QuoteService_GetQuote_FullMethodName, codes.OK, client},
+		{"client on admin method", []string{"bearer client-token"}, pb.QuoteService_ChangeQuoteState_FullMethodName, codes.PermissionDenied, nil},
+		{"admin on admin method", []string{"bearer admin-token"}, pb.AvailabilitySlotService_CreateAvailabilitySlot_FullMethodName, codes.OK, admin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.authorization != nil {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(authorizationMetadataKey, tt.authorization[0]))
+			}
+
+			var got *domain.TokenPayload
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				got = getAuthPayload(ctx)
+				return nil, nil
+			}
+
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			require.Equal(t, tt.code, status.Code(err))
+			assert.Equal(t, tt.payload, got)
+		})
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	assert.Equal(t, codes.NotFound, status.Code(toStatus(domain.ErrDataNotFound)))
+	assert.Equal(t, codes.PermissionDenied, status.Code(toStatus(domain.ErrForbidden)))
+	assert.Equal(t, codes.InvalidArgument, status.Code(toStatus(domain.ErrInvalidCursor)))
+
+	// Unknown errors don't leak their message
+	err := toStatus(assert.AnError)
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, domain.ErrInternal.Error(), status.Convert(err).Message())
+}
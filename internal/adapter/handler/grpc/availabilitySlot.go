@@ -0,0 +1,121 @@
+package grpc
+
+import (
+	"context"
+
+	"harajuku/backend/internal/adapter/handler/grpc/pb"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// availabilitySlotServer implements pb.AvailabilitySlotServiceServer on top of the availability slot service
+type availabilitySlotServer struct {
+	pb.UnimplementedAvailabilitySlotServiceServer
+	svc port.AvailabilitySlotService
+}
+
+// newAvailabilitySlotServer creates a new availability slot server
+func newAvailabilitySlotServer(svc port.AvailabilitySlotService) *availabilitySlotServer {
+	return &availabilitySlotServer{svc: svc}
+}
+
+// GetAvailabilitySlot returns a slot
+func (s *availabilitySlotServer) GetAvailabilitySlot(ctx context.Context, req *pb.GetAvailabilitySlotRequest) (*pb.AvailabilitySlot, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	slot, err := s.svc.GetAvailabilitySlot(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return newAvailabilitySlot(slot), nil
+}
+
+// ListAvailabilitySlots returns a page of slots
+func (s *availabilitySlotServer) ListAvailabilitySlots(ctx context.Context, req *pb.ListAvailabilitySlotsRequest) (*pb.ListAvailabilitySlotsResponse, error) {
+	if req.GetLimit() < 5 {
+		return nil, invalidArgument("limit must be at least 5")
+	}
+
+	filter := port.AvailabilitySlotFilter{
+		Skip:  req.GetSkip(),
+		Limit: req.GetLimit(),
+	}
+
+	if req.GetStartDate() != nil {
+		t := req.GetStartDate().AsTime()
+		filter.StartDate = &t
+	}
+	if req.GetEndDate() != nil {
+		t := req.GetEndDate().AsTime()
+		filter.EndDate = &t
+	}
+
+	if req.GetState() != "" {
+		state := port.SlotState(req.GetState())
+		if state != port.SlotStateFree && state != port.SlotStateBooked {
+			return nil, invalidArgument("invalid state value, must be 'free' or 'booked'")
+		}
+		filter.ByState = &state
+	}
+
+	after, err := parseCursor(req.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+	filter.After = after
+
+	slots, total, next, err := s.svc.ListAvailabilitySlots(ctx, filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	rsp := &pb.ListAvailabilitySlotsResponse{Total: total, NextCursor: encodeCursor(next)}
+	for i := range slots {
+		rsp.Slots = append(rsp.Slots, newAvailabilitySlot(&slots[i]))
+	}
+
+	return rsp, nil
+}
+
+// CreateAvailabilitySlot opens a slot of the calling admin, the auth interceptor lets only admins through
+func (s *availabilitySlotServer) CreateAvailabilitySlot(ctx context.Context, req *pb.CreateAvailabilitySlotRequest) (*pb.AvailabilitySlot, error) {
+	if req.GetStartTime() == nil || req.GetEndTime() == nil {
+		return nil, invalidArgument("start_time and end_time are required")
+	}
+
+	start, end := req.GetStartTime().AsTime(), req.GetEndTime().AsTime()
+	if !end.After(start) {
+		return nil, invalidArgument("end_time must be after start_time")
+	}
+
+	created, err := s.svc.CreateAvailabilitySlot(ctx, &domain.AvailabilitySlot{
+		ID:        uuid.New(),
+		AdminID:   getAuthPayload(ctx).UserID,
+		StartTime: start,
+		EndTime:   end,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return newAvailabilitySlot(created), nil
+}
+
+// newAvailabilitySlot converts a slot into its protobuf message
+func newAvailabilitySlot(slot *domain.AvailabilitySlot) *pb.AvailabilitySlot {
+	return &pb.AvailabilitySlot{
+		Id:        slot.ID.String(),
+		AdminId:   slot.AdminID.String(),
+		StartTime: timestamppb.New(slot.StartTime),
+		EndTime:   timestamppb.New(slot.EndTime),
+		IsBooked:  slot.IsBooked,
+		Version:   int32(slot.Version),
+	}
+}
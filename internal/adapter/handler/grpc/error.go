@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"errors"
+
+	"harajuku/backend/internal/core/domain"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorCodeMap is a map of defined errors and their gRPC codes, the counterpart of the HTTP status codes
+var errorCodeMap = map[error]codes.Code{
+	domain.ErrInternal:                   codes.Internal,
+	domain.ErrDataNotFound:               codes.NotFound,
+	domain.ErrConflictingData:            codes.AlreadyExists,
+	domain.ErrUnauthorized:               codes.Unauthenticated,
+	domain.ErrEmptyAuthorizationHeader:   codes.Unauthenticated,
+	domain.ErrInvalidAuthorizationHeader: codes.Unauthenticated,
+	domain.ErrInvalidAuthorizationType:   codes.Unauthenticated,
+	domain.ErrInvalidToken:               codes.Unauthenticated,
+	domain.ErrExpiredToken:               codes.Unauthenticated,
+	domain.ErrForbidden:                  codes.PermissionDenied,
+	domain.ErrNoUpdatedData:              codes.InvalidArgument,
+	domain.ErrServiceNotOffered:          codes.FailedPrecondition,
+	domain.ErrForbidenAppointment:        codes.FailedPrecondition,
+	domain.ErrStaffInactive:              codes.FailedPrecondition,
+	domain.ErrInvalidCoupon:              codes.InvalidArgument,
+	domain.ErrCouponUnavailable:          codes.FailedPrecondition,
+	domain.ErrCouponNotApplicable:        codes.FailedPrecondition,
+	domain.ErrInvalidCursor:              codes.InvalidArgument,
+	domain.ErrDatabaseUnavailable:        codes.Unavailable,
+	domain.ErrNotReady:                   codes.Unavailable,
+	domain.ErrTooManyRequests:            codes.ResourceExhausted,
+	domain.ErrQuotaExceeded:              codes.ResourceExhausted,
+}
+
+// toStatus converts an error of the core services into a gRPC status error, unknown errors
+// are reported as internal without leaking their message
+func toStatus(err error) error {
+	for target, code := range errorCodeMap {
+		if errors.Is(err, target) {
+			return status.Error(code, target.Error())
+		}
+	}
+
+	return status.Error(codes.Internal, domain.ErrInternal.Error())
+}
+
+// invalidArgument returns the status error of a request failing validation
+func invalidArgument(format string, args ...interface{}) error {
+	return status.Errorf(codes.InvalidArgument, format, args...)
+}
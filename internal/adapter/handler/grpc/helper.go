@@ -0,0 +1,50 @@
+package grpc
+
+import (
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// parseID parses the id of a request, field names it in the error
+func parseID(field, id string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, invalidArgument("invalid %s: %v", field, err)
+	}
+	return parsed, nil
+}
+
+// parseOptionalID parses an id filter, nil when it wasn't sent
+func parseOptionalID(field, id string) (*uuid.UUID, error) {
+	if id == "" {
+		return nil, nil
+	}
+
+	parsed, err := parseID(field, id)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// parseCursor decodes the cursor of a page, nil when the listing starts from the beginning
+func parseCursor(token string) (*domain.Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	cursor, err := domain.DecodeCursor(token)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return cursor, nil
+}
+
+// encodeCursor encodes the cursor of the next page, empty on the last page
+func encodeCursor(next *domain.Cursor) string {
+	if next == nil {
+		return ""
+	}
+	return next.Encode()
+}
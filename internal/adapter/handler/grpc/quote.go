@@ -0,0 +1,167 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+
+	"harajuku/backend/internal/adapter/handler/grpc/pb"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// quoteServer implements pb.QuoteServiceServer on top of the quote service
+type quoteServer struct {
+	pb.UnimplementedQuoteServiceServer
+	svc    port.QuoteService
+	signer port.FileURLSigner
+}
+
+// newQuoteServer creates a new quote server, signer can be nil to return the image paths unsigned
+func newQuoteServer(svc port.QuoteService, signer port.FileURLSigner) *quoteServer {
+	return &quoteServer{svc: svc, signer: signer}
+}
+
+// GetQuote returns a quote with its images, clients can only get their own quotes
+func (s *quoteServer) GetQuote(ctx context.Context, req *pb.GetQuoteRequest) (*pb.GetQuoteResponse, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	quote, images, err := s.svc.GetQuote(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	payload := getAuthPayload(ctx)
+	if payload.Role != domain.Admin && quote.ClientID != payload.UserID {
+		return nil, toStatus(domain.ErrForbidden)
+	}
+
+	rsp := &pb.GetQuoteResponse{Quote: newQuote(quote)}
+	for i := range images {
+		rsp.Images = append(rsp.Images, s.newQuoteImage(&images[i]))
+	}
+
+	return rsp, nil
+}
+
+// ListQuotes returns a page of quotes, clients only list their own
+func (s *quoteServer) ListQuotes(ctx context.Context, req *pb.ListQuotesRequest) (*pb.ListQuotesResponse, error) {
+	payload := getAuthPayload(ctx)
+	if payload.Role == domain.Client && req.GetClientId() != "" {
+		return nil, toStatus(domain.ErrUnauthorized)
+	}
+
+	if req.GetLimit() < 5 {
+		return nil, invalidArgument("limit must be at least 5")
+	}
+
+	clientID, err := parseOptionalID("client_id", req.GetClientId())
+	if err != nil {
+		return nil, err
+	}
+	if payload.Role == domain.Client {
+		clientID = &payload.UserID
+	}
+
+	typeOfServiceID, err := parseOptionalID("type_of_service_id", req.GetTypeOfServiceId())
+	if err != nil {
+		return nil, err
+	}
+
+	var state *domain.QuoteState
+	if req.GetState() != "" {
+		s := domain.QuoteState(req.GetState())
+		if !s.IsValidState() {
+			return nil, invalidArgument("invalid state value")
+		}
+		state = &s
+	}
+
+	after, err := parseCursor(req.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+
+	quotes, total, next, err := s.svc.ListQuotes(ctx, port.QuoteFilter{
+		TypeOfServiceID: typeOfServiceID,
+		ClientID:        clientID,
+		ByState:         state,
+		Skip:            req.GetSkip(),
+		Limit:           req.GetLimit(),
+		After:           after,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	rsp := &pb.ListQuotesResponse{Total: total, NextCursor: encodeCursor(next)}
+	for i := range quotes {
+		rsp.Quotes = append(rsp.Quotes, newQuote(&quotes[i]))
+	}
+
+	return rsp, nil
+}
+
+// ChangeQuoteState moves a quote through its states, the auth interceptor lets only admins through
+func (s *quoteServer) ChangeQuoteState(ctx context.Context, req *pb.ChangeQuoteStateRequest) (*pb.Quote, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	state := domain.QuoteState(req.GetState())
+	if state != domain.QuoteApproved && state != domain.QuoteRequiresProof && state != domain.QuoteRejected && state != domain.QuotePendingPayment {
+		return nil, invalidArgument("invalid state value it has to be either 'approved', 'rejected', 'requires_proof' or 'pending_payment'")
+	}
+
+	quote, err := s.svc.ChangeQuoteState(ctx, id, state)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return newQuote(quote), nil
+}
+
+// newQuote converts a quote into its protobuf message
+func newQuote(quote *domain.Quote) *pb.Quote {
+	return &pb.Quote{
+		Id:              quote.ID.String(),
+		TypeOfServiceId: quote.TypeOfServiceID.String(),
+		ClientId:        quote.ClientID.String(),
+		Description:     quote.Description,
+		State:           quote.State.String(),
+		Price:           quote.Price,
+		Currency:        string(quote.Currency),
+		Discount:        quote.TotalDiscount(),
+		Total:           quote.Total(),
+		Time:            timestamppb.New(quote.Time),
+		Version:         int32(quote.Version),
+	}
+}
+
+// newQuoteImage converts a quote image into its protobuf message, with its URL signed like the HTTP API does
+func (s *quoteServer) newQuoteImage(image *domain.QuoteImage) *pb.QuoteImage {
+	url := image.URL
+	if s.signer != nil && url != "" {
+		signed, err := s.signer.SignURL(url)
+		if err != nil {
+			slog.Error("File URL signing failed", "path", url, "error", err)
+		} else {
+			url = signed
+		}
+	}
+
+	return &pb.QuoteImage{
+		Id:          image.ID.String(),
+		QuoteId:     image.QuoteID.String(),
+		Url:         url,
+		FileName:    image.FileName,
+		Size:        image.Size,
+		ContentType: image.ContentType,
+		Checksum:    image.Checksum,
+	}
+}
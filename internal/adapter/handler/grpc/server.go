@@ -0,0 +1,122 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"time"
+
+	"harajuku/backend/internal/adapter/handler/grpc/pb"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server is a wrapper for the gRPC server, it exposes the same core services as the HTTP API
+// to internal services that prefer typed contracts
+type Server struct {
+	*grpc.Server
+}
+
+// NewServer creates a new gRPC server and registers the quote, appointment and availability slot services
+func NewServer(
+	token port.TokenService,
+	quote port.QuoteService,
+	appointment port.AppointmentService,
+	slot port.AvailabilitySlotService,
+	signer port.FileURLSigner,
+	reporter port.ErrorReporter,
+) *Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			loggingInterceptor(),
+			errorReportInterceptor(reporter),
+			authInterceptor(token),
+		),
+	)
+
+	pb.RegisterQuoteServiceServer(server, newQuoteServer(quote, signer))
+	pb.RegisterAppointmentServiceServer(server, newAppointmentServer(appointment))
+	pb.RegisterAvailabilitySlotServiceServer(server, newAvailabilitySlotServer(slot))
+
+	return &Server{server}
+}
+
+// Serve starts the gRPC server and stops it once ctx is cancelled, giving the calls in flight up
+// to shutdownTimeout to finish before closing their connections
+func (s *Server) Serve(ctx context.Context, listenAddr string, shutdownTimeout time.Duration) error {
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return err
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- s.Server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("Shutting down the gRPC server", "timeout", shutdownTimeout)
+
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		slog.Warn("Calls still running after the shutdown timeout, closing their connections")
+		s.Stop()
+	}
+
+	return nil
+}
+
+// errorReportInterceptor is an interceptor to report the internal errors of the calls, and to recover
+// their panics, which would stop the whole server, reporting them and failing the call with an internal error
+func errorReportInterceptor(reporter port.ErrorReporter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (rsp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				reporter.ReportPanic(ctx, r)
+				rsp, err = nil, status.Error(codes.Internal, domain.ErrInternal.Error())
+			}
+		}()
+
+		rsp, err = handler(ctx, req)
+		if status.Code(err) == codes.Internal {
+			reporter.Report(ctx, err)
+		}
+
+		return rsp, err
+	}
+}
+
+// loggingInterceptor is an interceptor to log every call with its duration, like the request logs of the HTTP API
+func loggingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		// The logs of the services carry the method the way they carry the route of HTTP requests
+		rsp, err := handler(util.WithLogAttrs(ctx, slog.String("route", info.FullMethod)), req)
+
+		attrs := []any{"method", info.FullMethod, "latency", time.Since(start)}
+		if err != nil {
+			slog.WarnContext(ctx, "gRPC call failed", append(attrs, "error", err)...)
+		} else {
+			slog.InfoContext(ctx, "gRPC call", attrs...)
+		}
+
+		return rsp, err
+	}
+}
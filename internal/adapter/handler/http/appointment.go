@@ -1,10 +1,8 @@
 package http
 
 import (
-	"fmt"
 	"harajuku/backend/internal/core/domain"
 	"harajuku/backend/internal/core/port"
-	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -32,6 +30,8 @@ type appointmentResponse struct {
 	SlotID 		uuid.UUID    								`json:"slotId"`
 	QuoteID   uuid.UUID    								`json:"quoteId"`
 	Status  	domain.AppointmentStatus    `json:"status"`
+	DeletedAt	*time.Time									`json:"deletedAt,omitempty"`
+	Version		int													`json:"version"`
 }
 
 func newAppointmentResponse(appointment *domain.Appointment) *appointmentResponse {
@@ -41,9 +41,28 @@ func newAppointmentResponse(appointment *domain.Appointment) *appointmentRespons
 		SlotID: 		appointment.SlotID,
 		QuoteID:   	appointment.QuoteID,
 		Status:  		appointment.Status,
+		DeletedAt:	appointment.DeletedAt,
+		Version:		appointment.Version,
 	}
 }
 
+// CreateAppointment godoc
+//
+// @Summary        Book an appointment
+// @Description    Book an availability slot for a quote of the authenticated client, the appointment is booked right away when the quote only awaits its payment proof and pending otherwise
+// @Tags           Appointments
+// @Accept         json
+// @Produce        json
+// @Security       BearerAuth
+// @Param          appointment body    createAppointmentRequest true   "Appointment Data"
+// @Success        200    {object}  appointmentResponse  "Appointment created"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        403    {object}  errorResponse  "Quote not approved yet"
+// @Failure        404    {object}  errorResponse  "Slot or quote not found"
+// @Failure        409    {object}  errorResponse  "Slot already booked"
+// @Failure        422    {object}  errorResponse  "Service not offered by the slot's admin"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/appointments [post]
 func (h *AppointmentHandler) CreateAppointment(ctx *gin.Context) {
 	auth := getAuthPayload(ctx, authorizationPayloadKey)
 
@@ -71,15 +90,37 @@ func (h *AppointmentHandler) CreateAppointment(ctx *gin.Context) {
 }
 
 type listAppointmentRequest struct {
+	cursorPageRequest
 	CustomerID  string		`form:"customerId"`
 	QuoteID  	 	string		`form:"quoteId"`
 	StartDate  	string		`form:"startDate"`
 	EndDate  		string		`form:"endDate"`
 	ByState 		string		`form:"state"`
-	Skip   		 	uint64		`form:"skip" binding:"required,min=0"`
-	Limit  		 	uint64		`form:"limit" binding:"required,min=5"`
+	IncludeDeleted	bool		`form:"includeDeleted"`
 }
 
+// ListAppointments godoc
+//
+// @Summary        List appointments
+// @Description    List appointments with pagination, optionally filtered by client, quote, state and slot start date
+// @Tags           Appointments
+// @Accept         json
+// @Produce        json
+// @Security       BearerAuth
+// @Param          customerId     query   string false  "Client ID"
+// @Param          quoteId        query   string false  "Quote ID"
+// @Param          startDate      query   string false  "Slots starting from (RFC3339)"
+// @Param          endDate        query   string false  "Slots starting until (RFC3339)"
+// @Param          state          query   string false  "State" Enums(booked, pending, cancelled, completed)
+// @Param          skip           query   uint64 false  "Page number, starting at 1, ignored with cursor"
+// @Param          limit          query   uint64 true   "Limit"
+// @Param          cursor         query   string false  "Cursor of the next page"
+// @Param          includeDeleted query   bool   false  "Include deleted appointments, admins only"
+// @Success        200    {object}  meta  "Appointments displayed"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        403    {object}  errorResponse  "Forbidden error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/appointments/all [get]
 func (h *AppointmentHandler) ListAppointments(ctx *gin.Context) {
 	var req listAppointmentRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
@@ -97,7 +138,7 @@ func (h *AppointmentHandler) ListAppointments(ctx *gin.Context) {
 	if req.CustomerID != "" {
 		id, err := uuid.Parse(req.CustomerID)
 		if err != nil {
-			validationError(ctx, fmt.Errorf("invalid customerId: %v", err))
+			validationError(ctx, newRequestError("customerId", "uuid"))
 			return
 		}
 		customerID = &id
@@ -106,7 +147,7 @@ func (h *AppointmentHandler) ListAppointments(ctx *gin.Context) {
 	if req.QuoteID != "" {
 		id, err := uuid.Parse(req.QuoteID)
 		if err != nil {
-			validationError(ctx, fmt.Errorf("invalid quoteId: %v", err))
+			validationError(ctx, newRequestError("quoteId", "uuid"))
 			return
 		}
 		quoteID = &id
@@ -120,7 +161,7 @@ func (h *AppointmentHandler) ListAppointments(ctx *gin.Context) {
 	if req.StartDate != "" {
 		t, err := time.Parse(time.RFC3339, req.StartDate)
 		if err != nil {
-			validationError(ctx, fmt.Errorf("invalid startDate format (must be RFC3339)"))
+			validationError(ctx, newRequestError("startDate", "date"))
 			return
 		}
 		startDate = &t
@@ -128,7 +169,7 @@ func (h *AppointmentHandler) ListAppointments(ctx *gin.Context) {
 	if req.EndDate != "" {
 		t, err := time.Parse(time.RFC3339, req.EndDate)
 		if err != nil {
-			validationError(ctx, fmt.Errorf("invalid endDate format (must be RFC3339)"))
+			validationError(ctx, newRequestError("endDate", "date"))
 			return
 		}
 		endDate = &t
@@ -139,11 +180,23 @@ func (h *AppointmentHandler) ListAppointments(ctx *gin.Context) {
 	if req.ByState != "" {
 		s := domain.AppointmentStatus(req.ByState)
 		if s != domain.Booked && s != domain.Cancelled && s != domain.Pending && s != domain.Completed {
-			validationError(ctx, fmt.Errorf("invalid state value, must be 'booked', 'pending', 'cancelled' or 'completed'"))
+			validationError(ctx, newRequestError("state", "oneof", "booked pending cancelled completed"))
 		}
 		// validate against your enum if needed...
 	}
 
+	// Las citas eliminadas solo se listan para admins
+	if req.IncludeDeleted && getAuthPayload(ctx, authorizationPayloadKey).Role != domain.Admin {
+		handleError(ctx, domain.ErrForbidden)
+		return
+	}
+
+	after, err := parseCursor(req.Cursor)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
 	// Build the filter
 	filter := port.AppointmentFilter{
 		CustomerID: customerID,
@@ -153,10 +206,12 @@ func (h *AppointmentHandler) ListAppointments(ctx *gin.Context) {
 		ByState:    state,
 		Skip:       req.Skip,
 		Limit:      req.Limit,
+		After:      after,
+		IncludeDeleted: req.IncludeDeleted,
 	}
 
 	// Obtener los appointments
-	appointments, err := h.svc.ListAppointments(ctx, filter)
+	appointments, total, next, err := h.svc.ListAppointments(ctx, filter)
 	if err != nil {
 		handleError(ctx, err)
 		return
@@ -168,21 +223,35 @@ func (h *AppointmentHandler) ListAppointments(ctx *gin.Context) {
 		responses = append(responses, *newAppointmentResponse(&s))
 	}
 
-	meta := newMeta(uint64(len(appointments)), req.Limit, req.Skip)
-	handleSuccess(ctx, toMap(meta, responses, "appointments"))
+	meta := newCursorPageMeta(ctx, total, req.cursorPageRequest, next)
+	handleList(ctx, meta, responses, "appointments")
 }
 
+// GetAppointment godoc
+//
+// @Summary        Get an appointment
+// @Description    Get an appointment by id
+// @Tags           Appointments
+// @Accept         json
+// @Produce        json
+// @Security       BearerAuth
+// @Param          id   path    string true   "Appointment ID"
+// @Success        200  {object}  appointmentResponse  "Appointment displayed"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/appointments/{id} [get]
 func (qh *AppointmentHandler) GetAppointment(ctx *gin.Context) {
 	id := ctx.DefaultQuery("id", "")
 
 	if id == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID parameter is required"})
+		validationError(ctx, newRequestError("id", "required"))
 		return
 	}
 
 	quoteID, err := uuid.Parse(id)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		validationError(ctx, newRequestError("id", "uuid"))
 		return
 	}
 
@@ -198,13 +267,30 @@ func (qh *AppointmentHandler) GetAppointment(ctx *gin.Context) {
 
 type updateAppointmentRequest struct {
 	SlotID 		uuid.UUID `json:"slotId" binding:"required"`
+	Version		int				`json:"version" binding:"min=0"`
 }
 
+// UpdateAppointment godoc
+//
+// @Summary        Reschedule an appointment
+// @Description    Move an appointment to another availability slot
+// @Tags           Appointments
+// @Accept         json
+// @Produce        json
+// @Security       BearerAuth
+// @Param          id          path    string                   true   "Appointment ID"
+// @Param          appointment body    updateAppointmentRequest true   "Appointment Data"
+// @Success        200    {object}  appointmentResponse  "Appointment updated"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        409    {object}  errorResponse  "Slot already booked or appointment modified concurrently"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/appointments/{id} [put]
 func (h *AppointmentHandler) UpdateAppointment(ctx *gin.Context) {
 	id := ctx.DefaultQuery("id", "")
 
 	if id == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID parameter is required"})
+		validationError(ctx, newRequestError("id", "required"))
 		return
 	}
 
@@ -221,7 +307,7 @@ func (h *AppointmentHandler) UpdateAppointment(ctx *gin.Context) {
 		return
 	}
 
-	updatedAppointment, err := h.svc.UpdateAppointment(ctx, &domain.Appointment{ID: appointmentId, SlotID: req.SlotID})
+	updatedAppointment, err := h.svc.UpdateAppointment(ctx, &domain.Appointment{ID: appointmentId, SlotID: req.SlotID, Version: req.Version})
 	if err != nil {
 		handleError(ctx, err)
 		return
@@ -230,17 +316,31 @@ func (h *AppointmentHandler) UpdateAppointment(ctx *gin.Context) {
 	handleSuccess(ctx, newAppointmentResponse(updatedAppointment))
 }
 
+// DeleteAppointment godoc
+//
+// @Summary        Delete an appointment
+// @Description    Delete an appointment by id, admins can still list it
+// @Tags           Appointments
+// @Accept         json
+// @Produce        json
+// @Security       BearerAuth
+// @Param          id   path    string true   "Appointment ID"
+// @Success        200  {object}  response       "Appointment deleted"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/appointments/{id} [delete]
 func (h *AppointmentHandler) DeleteAppointment(ctx *gin.Context) {
 	id := ctx.DefaultQuery("id", "")
 
 	if id == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID parameter is required"})
+		validationError(ctx, newRequestError("id", "required"))
 		return
 	}
 
 	appointmentID, err := uuid.Parse(id)
 	if err != nil {
-		validationError(ctx, fmt.Errorf("invalid id format"))
+		validationError(ctx, newRequestError("id", "uuid"))
 		return
 	}
 
@@ -249,5 +349,39 @@ func (h *AppointmentHandler) DeleteAppointment(ctx *gin.Context) {
 		return
 	}
 
-	handleSuccess(ctx, "Availability appointment deleted successfully")
+	handleSuccess(ctx, localize(ctx, "message.appointment_deleted"))
+}
+
+// CancelAppointment godoc
+//
+// @Summary        Cancel an appointment
+// @Description    Cancel a pending or booked appointment and free its slot, only its client or an admin can cancel it
+// @Tags           Appointments
+// @Accept         json
+// @Produce        json
+// @Security       BearerAuth
+// @Param          id   path    string true   "Appointment ID"
+// @Success        200  {object}  appointmentResponse  "Appointment cancelled"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        403  {object}  errorResponse  "Forbidden error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        409  {object}  errorResponse  "Appointment already cancelled or completed"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/appointments/{id}/cancel [post]
+func (h *AppointmentHandler) CancelAppointment(ctx *gin.Context) {
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	appointmentID, err := uuid.Parse(ctx.Query("id"))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	cancelled, err := h.svc.CancelAppointment(ctx, appointmentID, authPayload)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, newAppointmentResponse(cancelled))
 }
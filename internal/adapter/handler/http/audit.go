@@ -0,0 +1,264 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	sloggin "github.com/samber/slog-gin"
+)
+
+// auditBodyLimit is how much of a JSON body is read to keep its fields as the changes of an audit log,
+// larger bodies are audited without their changes
+const auditBodyLimit = 64 << 10
+
+// redactedValue replaces the values of the secret fields in the changes of an audit log
+const redactedValue = "[redacted]"
+
+// auditedMethods are the methods of the requests that change data
+var auditedMethods = map[string]bool{
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// secretFields are the fields, matched without case, whose values never reach the audit log
+var secretFields = []string{"password", "token", "secret"}
+
+// auditMiddleware is a middleware that records every request of an authenticated user that changes data
+// in the audit log, along with the status it got. It runs before the auth middleware of the route, so it
+// reads the JSON body ahead of the handler and puts it back, and looks for the user once the handler ran
+func auditMiddleware(svc port.AuditLogService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !auditedMethods[ctx.Request.Method] {
+			ctx.Next()
+			return
+		}
+
+		changes := readAuditChanges(ctx)
+		ctx.Next()
+
+		value, ok := ctx.Get(authorizationPayloadKey)
+		if !ok {
+			return
+		}
+		payload := value.(*domain.TokenPayload)
+
+		log := &domain.AuditLog{
+			ActorID:   payload.UserID,
+			ActorRole: payload.Role,
+			Method:    ctx.Request.Method,
+			Route:     ctx.FullPath(),
+			Path:      ctx.Request.URL.Path,
+			TargetID:  auditTarget(ctx),
+			Status:    ctx.Writer.Status(),
+			Changes:   changes,
+			RequestID: sloggin.GetRequestID(ctx),
+			SalonID:   payload.SalonID,
+		}
+
+		// The request is recorded even when the client went away, failures are logged by the
+		// service and don't change the response
+		_ = svc.RecordAuditLog(context.WithoutCancel(ctx.Request.Context()), log)
+	}
+}
+
+// readAuditChanges returns the fields of a JSON object body with the secrets redacted and puts the
+// body back for the handler. It returns nil for other bodies and for bodies over auditBodyLimit
+func readAuditChanges(ctx *gin.Context) map[string]any {
+	if ctx.Request.Body == nil || ctx.ContentType() != binding.MIMEJSON {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, auditBodyLimit+1))
+	ctx.Request.Body = readCloser{io.MultiReader(bytes.NewReader(body), ctx.Request.Body), ctx.Request.Body}
+	if err != nil || len(body) > auditBodyLimit {
+		return nil
+	}
+
+	var changes map[string]any
+	if err := json.Unmarshal(body, &changes); err != nil {
+		return nil
+	}
+
+	redactSecrets(changes)
+	return changes
+}
+
+// readCloser reads from a reader and closes a closer, it puts back a body that was partly read
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// redactSecrets replaces the values of the secret fields of changes and of the objects it holds
+func redactSecrets(changes map[string]any) {
+	for key, value := range changes {
+		if isSecretField(key) {
+			changes[key] = redactedValue
+			continue
+		}
+
+		switch value := value.(type) {
+		case map[string]any:
+			redactSecrets(value)
+		case []any:
+			for _, item := range value {
+				if object, ok := item.(map[string]any); ok {
+					redactSecrets(object)
+				}
+			}
+		}
+	}
+}
+
+// isSecretField reports whether a field holds a secret, like password or deviceToken
+func isSecretField(key string) bool {
+	key = strings.ToLower(key)
+	for _, secret := range secretFields {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// auditTarget returns the ID of the entity a request changed, taken from the id path or query parameter
+func auditTarget(ctx *gin.Context) *uuid.UUID {
+	id := ctx.Param("id")
+	if id == "" {
+		id = ctx.Query("id")
+	}
+
+	target, err := uuid.Parse(id)
+	if err != nil {
+		return nil
+	}
+	return &target
+}
+
+// AuditLogHandler represents the HTTP handler for inspecting the audit log
+type AuditLogHandler struct {
+	svc port.AuditLogService
+}
+
+// NewAuditLogHandler creates a new AuditLogHandler instance
+func NewAuditLogHandler(svc port.AuditLogService) *AuditLogHandler {
+	return &AuditLogHandler{
+		svc,
+	}
+}
+
+// auditLogResponse represents a request that changed data
+type auditLogResponse struct {
+	ID        uuid.UUID      `json:"id"`
+	ActorID   uuid.UUID      `json:"actorId"`
+	ActorRole string         `json:"actorRole" example:"admin"`
+	Method    string         `json:"method" example:"PUT"`
+	Route     string         `json:"route" example:"/v1/quotes/:id"`
+	Path      string         `json:"path" example:"/v1/quotes/6a3a8f5e-8a1c-4a8e-9a43-0d7f1f3f2c11"`
+	TargetID  *uuid.UUID     `json:"targetId,omitempty"`
+	Status    int            `json:"status" example:"200"`
+	Changes   map[string]any `json:"changes,omitempty"`
+	RequestID string         `json:"requestId,omitempty" example:"2f1c7d9e-5b8a-4c3e-9f6d-1a2b3c4d5e6f"`
+	CreatedAt string         `json:"createdAt" example:"2025-03-14T10:00:00Z"`
+}
+
+// newAuditLogResponse is a helper function to create a response body for handling audit log data
+func newAuditLogResponse(l *domain.AuditLog) *auditLogResponse {
+	return &auditLogResponse{
+		ID:        l.ID,
+		ActorID:   l.ActorID,
+		ActorRole: string(l.ActorRole),
+		Method:    l.Method,
+		Route:     l.Route,
+		Path:      l.Path,
+		TargetID:  l.TargetID,
+		Status:    l.Status,
+		Changes:   l.Changes,
+		RequestID: l.RequestID,
+		CreatedAt: l.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// listAuditLogsRequest represents the query for listing the audit log
+type listAuditLogsRequest struct {
+	pageRequest
+	ActorID  string `form:"actorId" binding:"omitempty,uuid" example:"6a3a8f5e-8a1c-4a8e-9a43-0d7f1f3f2c11"`
+	TargetID string `form:"targetId" binding:"omitempty,uuid" example:"6a3a8f5e-8a1c-4a8e-9a43-0d7f1f3f2c11"`
+	Method   string `form:"method" binding:"omitempty,oneof=POST PUT PATCH DELETE" example:"PUT"`
+	Route    string `form:"route" example:"/v1/quotes/:id"`
+}
+
+// ListAuditLogs godoc
+//
+// @Summary        List the audit log
+// @Description    List the requests of authenticated users that changed data, with the fields they sent, most recent first. Filter by targetId to see who changed an entity
+// @Tags           Audit
+// @Accept         json
+// @Produce        json
+// @Param          actorId   query   string false  "User who made the request"
+// @Param          targetId  query   string false  "Entity the request changed"
+// @Param          method    query   string false  "Method" Enums(POST, PUT, PATCH, DELETE)
+// @Param          route     query   string false  "Route template, e.g. /v1/quotes/:id"
+// @Param          skip      query   uint64 false  "Page number, starting at 1"
+// @Param          limit     query   uint64 true   "Limit"
+// @Success        200       {object}  meta  "Audit log displayed"
+// @Failure        400       {object}  errorResponse  "Validation error"
+// @Failure        401       {object}  errorResponse  "Unauthorized error"
+// @Failure        403       {object}  errorResponse  "Forbidden error"
+// @Failure        500       {object}  errorResponse  "Internal server error"
+// @Router         /v1/audit/logs [get]
+// @Security       BearerAuth
+func (ah *AuditLogHandler) ListAuditLogs(ctx *gin.Context) {
+	var req listAuditLogsRequest
+	var logsList []auditLogResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	filter := port.AuditLogFilter{
+		Method: req.Method,
+		Route:  req.Route,
+		Skip:   req.Skip,
+		Limit:  req.Limit,
+	}
+	if req.ActorID != "" {
+		actorID := uuid.MustParse(req.ActorID)
+		filter.ActorID = &actorID
+	}
+	if req.TargetID != "" {
+		targetID := uuid.MustParse(req.TargetID)
+		filter.TargetID = &targetID
+	}
+
+	logs, err := ah.svc.ListAuditLogs(ctx, filter)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, log := range logs {
+		logsList = append(logsList, *newAuditLogResponse(&log))
+	}
+
+	total := uint64(len(logsList))
+	meta := newPageMeta(ctx, total, req.pageRequest)
+	rsp := toMap(meta, logsList, "logs")
+
+	handleSuccess(ctx, rsp)
+}
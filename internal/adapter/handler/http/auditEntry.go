@@ -0,0 +1,146 @@
+package http
+
+import (
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AuditHandler represents the HTTP handler for inspecting the audit trail of the sensitive changes
+type AuditHandler struct {
+	svc port.AuditService
+}
+
+// NewAuditHandler creates a new AuditHandler instance
+func NewAuditHandler(svc port.AuditService) *AuditHandler {
+	return &AuditHandler{
+		svc,
+	}
+}
+
+// auditEntryResponse represents a sensitive change a service made
+type auditEntryResponse struct {
+	ID        uuid.UUID      `json:"id"`
+	Entity    string         `json:"entity" example:"typeOfService"`
+	EntityID  uuid.UUID      `json:"entityId"`
+	Action    string         `json:"action" example:"price.changed"`
+	ActorID   *uuid.UUID     `json:"actorId,omitempty"`
+	ActorRole string         `json:"actorRole,omitempty" example:"admin"`
+	Before    map[string]any `json:"before,omitempty"`
+	After     map[string]any `json:"after,omitempty"`
+	CreatedAt string         `json:"createdAt" example:"2025-03-14T10:00:00Z"`
+}
+
+// newAuditEntryResponse is a helper function to create a response body for handling audit entry data
+func newAuditEntryResponse(e *domain.AuditEntry) *auditEntryResponse {
+	return &auditEntryResponse{
+		ID:        e.ID,
+		Entity:    string(e.Entity),
+		EntityID:  e.EntityID,
+		Action:    string(e.Action),
+		ActorID:   e.ActorID,
+		ActorRole: string(e.ActorRole),
+		Before:    e.Before,
+		After:     e.After,
+		CreatedAt: e.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// listAuditEntriesRequest represents the query for listing the audit trail
+type listAuditEntriesRequest struct {
+	pageRequest
+	Entity    string `form:"entity" binding:"omitempty,oneof=user typeOfService paymentProof" example:"typeOfService"`
+	EntityID  string `form:"entityId" binding:"omitempty,uuid" example:"6a3a8f5e-8a1c-4a8e-9a43-0d7f1f3f2c11"`
+	Action    string `form:"action" binding:"omitempty,oneof=role.changed price.changed payment.approved payment.approvalUndone" example:"price.changed"`
+	ActorID   string `form:"actorId" binding:"omitempty,uuid" example:"6a3a8f5e-8a1c-4a8e-9a43-0d7f1f3f2c11"`
+	StartDate string `form:"startDate"`
+	EndDate   string `form:"endDate"`
+}
+
+// ListAuditEntries godoc
+//
+// @Summary        List the audit trail
+// @Description    List the sensitive changes the services made, price and role changes and payment approvals, with the values before and after them, most recent first. Filter by entity and entityId to see the history of an entity
+// @Tags           Audit
+// @Accept         json
+// @Produce        json
+// @Param          entity     query   string false  "Entity" Enums(user, typeOfService, paymentProof)
+// @Param          entityId   query   string false  "Entity that changed"
+// @Param          action     query   string false  "Action" Enums(role.changed, price.changed, payment.approved, payment.approvalUndone)
+// @Param          actorId    query   string false  "User who made the change"
+// @Param          startDate  query   string false  "Made from (RFC3339)"
+// @Param          endDate    query   string false  "Made before (RFC3339)"
+// @Param          skip       query   uint64 false  "Page number, starting at 1"
+// @Param          limit      query   uint64 true   "Limit"
+// @Success        200        {object}  meta  "Audit trail displayed"
+// @Failure        400        {object}  errorResponse  "Validation error"
+// @Failure        401        {object}  errorResponse  "Unauthorized error"
+// @Failure        403        {object}  errorResponse  "Forbidden error"
+// @Failure        500        {object}  errorResponse  "Internal server error"
+// @Router         /v1/audit/entries [get]
+// @Security       BearerAuth
+func (ah *AuditHandler) ListAuditEntries(ctx *gin.Context) {
+	var req listAuditEntriesRequest
+	var entriesList []auditEntryResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	filter := port.AuditEntryFilter{
+		Skip:  req.Skip,
+		Limit: req.Limit,
+	}
+	if req.Entity != "" {
+		entity := domain.AuditEntity(req.Entity)
+		filter.Entity = &entity
+	}
+	if req.EntityID != "" {
+		entityID := uuid.MustParse(req.EntityID)
+		filter.EntityID = &entityID
+	}
+	if req.Action != "" {
+		action := domain.AuditAction(req.Action)
+		filter.Action = &action
+	}
+	if req.ActorID != "" {
+		actorID := uuid.MustParse(req.ActorID)
+		filter.ActorID = &actorID
+	}
+	if req.StartDate != "" {
+		t, err := time.Parse(time.RFC3339, req.StartDate)
+		if err != nil {
+			validationError(ctx, newRequestError("startDate", "date"))
+			return
+		}
+		filter.StartDate = &t
+	}
+	if req.EndDate != "" {
+		t, err := time.Parse(time.RFC3339, req.EndDate)
+		if err != nil {
+			validationError(ctx, newRequestError("endDate", "date"))
+			return
+		}
+		filter.EndDate = &t
+	}
+
+	entries, total, err := ah.svc.ListAuditEntries(ctx, filter)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, entry := range entries {
+		entriesList = append(entriesList, *newAuditEntryResponse(&entry))
+	}
+
+	meta := newPageMeta(ctx, total, req.pageRequest)
+	rsp := toMap(meta, entriesList, "entries")
+
+	handleSuccess(ctx, rsp)
+}
@@ -0,0 +1,108 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingAuditLogService keeps the audit logs in memory
+type recordingAuditLogService struct {
+	logs []domain.AuditLog
+}
+
+func (s *recordingAuditLogService) RecordAuditLog(_ context.Context, log *domain.AuditLog) error {
+	s.logs = append(s.logs, *log)
+	return nil
+}
+
+func (s *recordingAuditLogService) ListAuditLogs(_ context.Context, _ port.AuditLogFilter) ([]domain.AuditLog, error) {
+	return s.logs, nil
+}
+
+func newAuditRouter(svc port.AuditLogService, payload *domain.TokenPayload, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(auditMiddleware(svc))
+
+	auth := func(ctx *gin.Context) {
+		if payload != nil {
+			ctx.Set(authorizationPayloadKey, payload)
+		}
+	}
+	router.PUT("/v1/quotes/:id", auth, handler)
+	router.GET("/v1/quotes/:id", auth, handler)
+	return router
+}
+
+func TestAuditMiddlewareRecordsMutations(t *testing.T) {
+	svc := &recordingAuditLogService{}
+	payload := &domain.TokenPayload{UserID: uuid.New(), Role: domain.Admin}
+	targetID := uuid.New()
+
+	var handlerBody string
+	router := newAuditRouter(svc, payload, func(ctx *gin.Context) {
+		body, _ := io.ReadAll(ctx.Request.Body)
+		handlerBody = string(body)
+		ctx.Status(http.StatusOK)
+	})
+
+	body := `{"price": 1500, "password": "hunter22", "details": {"apiToken": "abc"}}`
+	req := httptest.NewRequest(http.MethodPut, "/v1/quotes/"+targetID.String(), strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, body, handlerBody)
+	require.Len(t, svc.logs, 1)
+
+	log := svc.logs[0]
+	assert.Equal(t, payload.UserID, log.ActorID)
+	assert.Equal(t, domain.Admin, log.ActorRole)
+	assert.Equal(t, http.MethodPut, log.Method)
+	assert.Equal(t, "/v1/quotes/:id", log.Route)
+	assert.Equal(t, &targetID, log.TargetID)
+	assert.Equal(t, http.StatusOK, log.Status)
+	assert.Equal(t, float64(1500), log.Changes["price"])
+	assert.Equal(t, redactedValue, log.Changes["password"])
+	assert.Equal(t, map[string]any{"apiToken": redactedValue}, log.Changes["details"])
+}
+
+func TestAuditMiddlewareSkipsReadsAndAnonymousRequests(t *testing.T) {
+	svc := &recordingAuditLogService{}
+	handler := func(ctx *gin.Context) { ctx.Status(http.StatusOK) }
+
+	router := newAuditRouter(svc, &domain.TokenPayload{UserID: uuid.New()}, handler)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/quotes/x", nil))
+
+	router = newAuditRouter(svc, nil, handler)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/v1/quotes/x", strings.NewReader(`{}`)))
+
+	assert.Empty(t, svc.logs)
+}
+
+func TestAuditMiddlewareKeepsNonJSONBodies(t *testing.T) {
+	svc := &recordingAuditLogService{}
+	router := newAuditRouter(svc, &domain.TokenPayload{UserID: uuid.New()}, func(ctx *gin.Context) {
+		ctx.Status(http.StatusForbidden)
+	})
+
+	req := httptest.NewRequest(http.MethodPut, "/v1/quotes/not-an-id", strings.NewReader("description=Rosa"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.Len(t, svc.logs, 1)
+	assert.Nil(t, svc.logs[0].Changes)
+	assert.Nil(t, svc.logs[0].TargetID)
+	assert.Equal(t, http.StatusForbidden, svc.logs[0].Status)
+}
@@ -36,7 +36,7 @@ type loginRequest struct {
 //	@Failure		400		{object}	errorResponse	"Validation error"
 //	@Failure		401		{object}	errorResponse	"Unauthorized error"
 //	@Failure		500		{object}	errorResponse	"Internal server error"
-//	@Router			/users/login [post]
+//	@Router			/v1/users/login [post]
 func (ah *AuthHandler) Login(ctx *gin.Context) {
 	var req loginRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
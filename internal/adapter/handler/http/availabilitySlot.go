@@ -1,10 +1,8 @@
 package http
 
 import (
-	"fmt"
 	"harajuku/backend/internal/core/domain"
 	"harajuku/backend/internal/core/port"
-	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -34,6 +32,8 @@ type availabilitySlotResponse struct {
 	StartTime string    `json:"startTime"`
 	EndTime   string    `json:"endTime"`
 	IsBooked  bool      `json:"isBooked"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	Version   int        `json:"version"`
 }
 
 func newAvailabilitySlotResponse(slot *domain.AvailabilitySlot) *availabilitySlotResponse {
@@ -43,9 +43,26 @@ func newAvailabilitySlotResponse(slot *domain.AvailabilitySlot) *availabilitySlo
 		StartTime: slot.StartTime.Format(time.RFC3339),
 		EndTime:   slot.EndTime.Format(time.RFC3339),
 		IsBooked:  slot.IsBooked,
+		DeletedAt: slot.DeletedAt,
+		Version:   slot.Version,
 	}
 }
 
+// CreateSlot godoc
+//
+// @Summary        Create an availability slot
+// @Description    Open a slot of the authenticated admin for appointments
+// @Tags           AvailabilitySlots
+// @Accept         json
+// @Produce        json
+// @Security       BearerAuth
+// @Param          slot   body    createAvailabilitySlotRequest true   "Slot Data, times in RFC3339"
+// @Success        200    {object}  availabilitySlotResponse  "Slot created"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        403    {object}  errorResponse  "Forbidden error"
+// @Failure        409    {object}  errorResponse  "Slot overlaps another one"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/availabilityslots [post]
 func (h *AvailabilitySlotHandler) CreateSlot(ctx *gin.Context) {
 	auth := getAuthPayload(ctx, authorizationPayloadKey)
 
@@ -57,18 +74,18 @@ func (h *AvailabilitySlotHandler) CreateSlot(ctx *gin.Context) {
 
 	start, err := time.Parse(time.RFC3339, req.StartTime)
 	if err != nil {
-		validationError(ctx, fmt.Errorf("invalid startTime format"))
+		validationError(ctx, newRequestError("startTime", "date"))
 		return
 	}
 
 	end, err := time.Parse(time.RFC3339, req.EndTime)
 	if err != nil {
-		validationError(ctx, fmt.Errorf("invalid endTime format"))
+		validationError(ctx, newRequestError("endTime", "date"))
 		return
 	}
 
 	if !end.After(start) {
-		validationError(ctx, fmt.Errorf("endTime must be after startTime"))
+		validationError(ctx, newRequestError("endTime", "after", "startTime"))
 		return
 	}
 
@@ -90,13 +107,36 @@ func (h *AvailabilitySlotHandler) CreateSlot(ctx *gin.Context) {
 }
 
 type listAvailabilitySlotRequest struct {
+	cursorPageRequest
 	StartDate  string `form:"start_date"`  // No es required
 	EndDate  	 string `form:"end_date"`  // No es required
 	State  		 string `form:"state"`  // No es required (antes era IsBooked *bool)
-	Skip   		 uint64 `form:"skip" binding:"required,min=0"`
-	Limit  		 uint64 `form:"limit" binding:"required,min=5"`
+	IncludeDeleted bool `form:"includeDeleted"`  // Solo para admins
 }
 
+// ListSlots godoc
+//
+// @Summary        List availability slots
+// @Description    List availability slots with pagination, optionally filtered by start date and state
+// @Tags           AvailabilitySlots
+// @Accept         json
+// @Produce        json
+// @Security       BearerAuth
+// @Param          start_date     query   string false  "Slots starting from (RFC3339)"
+// @Param          end_date       query   string false  "Slots starting until (RFC3339)"
+// @Param          state          query   string false  "State" Enums(free, booked)
+// @Param          skip           query   uint64 false  "Page number, starting at 1, ignored with cursor"
+// @Param          limit          query   uint64 true   "Limit"
+// @Param          cursor         query   string false  "Cursor of the next page"
+// @Param          includeDeleted query   bool   false  "Include deleted slots, admins only"
+// @Param          If-None-Match  header  string false  "ETag of the cached response"
+// @Success        200    {object}  meta  "Slots displayed"
+// @Header         200  {string}  ETag  "Weak ETag of the response"
+// @Success        304  "Not modified"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        403    {object}  errorResponse  "Forbidden error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/availabilityslots [get]
 func (h *AvailabilitySlotHandler) ListSlots(ctx *gin.Context) {
 	var req listAvailabilitySlotRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
@@ -114,7 +154,7 @@ func (h *AvailabilitySlotHandler) ListSlots(ctx *gin.Context) {
 	if req.StartDate != "" {
 		t, err := time.Parse(time.RFC3339, req.StartDate)
 		if err != nil {
-			validationError(ctx, fmt.Errorf("invalid start_date format (must be RFC3339)"))
+			validationError(ctx, newRequestError("start_date", "date"))
 			return
 		}
 		startDate = &t
@@ -124,7 +164,7 @@ func (h *AvailabilitySlotHandler) ListSlots(ctx *gin.Context) {
 	if req.EndDate != "" {
 		t, err := time.Parse(time.RFC3339, req.EndDate)
 		if err != nil {
-			validationError(ctx, fmt.Errorf("invalid end_date format (must be RFC3339)"))
+			validationError(ctx, newRequestError("end_date", "date"))
 			return
 		}
 		endDate = &t
@@ -135,12 +175,24 @@ func (h *AvailabilitySlotHandler) ListSlots(ctx *gin.Context) {
 	if req.State != "" {
 		s := port.SlotState(req.State)
 		if s != port.SlotStateFree && s != port.SlotStateBooked {
-			validationError(ctx, fmt.Errorf("invalid state value, must be 'free' or 'booked'"))
+			validationError(ctx, newRequestError("state", "oneof", "free booked"))
 			return
 		}
 		state = &s
 	}
 
+	// Los slots eliminados solo se listan para admins
+	if req.IncludeDeleted && getAuthPayload(ctx, authorizationPayloadKey).Role != domain.Admin {
+		handleError(ctx, domain.ErrForbidden)
+		return
+	}
+
+	after, err := parseCursor(req.Cursor)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
 	// Construir el filtro con *time.Time
 	filter := port.AvailabilitySlotFilter{
 		StartDate: startDate,
@@ -148,10 +200,12 @@ func (h *AvailabilitySlotHandler) ListSlots(ctx *gin.Context) {
 		ByState:   state,
 		Skip:      req.Skip,
 		Limit:     req.Limit,
+		After:     after,
+		IncludeDeleted: req.IncludeDeleted,
 	}
 
 	// Obtener los slots
-	slots, err := h.svc.ListAvailabilitySlots(ctx, filter)
+	slots, total, next, err := h.svc.ListAvailabilitySlots(ctx, filter)
 	if err != nil {
 		handleError(ctx, err)
 		return
@@ -163,8 +217,8 @@ func (h *AvailabilitySlotHandler) ListSlots(ctx *gin.Context) {
 		responses = append(responses, *newAvailabilitySlotResponse(&s))
 	}
 
-	meta := newMeta(uint64(len(slots)), req.Limit, req.Skip)
-	handleSuccess(ctx, toMap(meta, responses, "slots"))
+	meta := newCursorPageMeta(ctx, total, req.cursorPageRequest, next)
+	handleCacheable(ctx, toMap(meta, responses, "slots"))
 }
 
 // Función auxiliar para convertir string vacío a nil
@@ -178,13 +232,31 @@ func nilIfEmpty(s string) *string {
 type updateAvailabilitySlotRequest struct {
 	StartTime string `json:"startTime" binding:"required"`
 	EndTime   string `json:"endTime" binding:"required"`
+	Version   int    `json:"version" binding:"min=0"`
 }
 
+// UpdateSlot godoc
+//
+// @Summary        Update an availability slot
+// @Description    Change the times of an availability slot
+// @Tags           AvailabilitySlots
+// @Accept         json
+// @Produce        json
+// @Security       BearerAuth
+// @Param          id     path    string                        true   "Slot ID"
+// @Param          slot   body    updateAvailabilitySlotRequest true   "Slot Data, times in RFC3339"
+// @Success        200    {object}  availabilitySlotResponse  "Slot updated"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        403    {object}  errorResponse  "Forbidden error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        409    {object}  errorResponse  "Slot modified concurrently"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/availabilityslots/{id} [put]
 func (h *AvailabilitySlotHandler) UpdateSlot(ctx *gin.Context) {
 	id := ctx.DefaultQuery("id", "")
 
 	if id == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID parameter is required"})
+		validationError(ctx, newRequestError("id", "required"))
 		return
 	}
 
@@ -203,18 +275,18 @@ func (h *AvailabilitySlotHandler) UpdateSlot(ctx *gin.Context) {
 
 	start, err := time.Parse(time.RFC3339, req.StartTime)
 	if err != nil {
-		validationError(ctx, fmt.Errorf("invalid startTime format"))
+		validationError(ctx, newRequestError("startTime", "date"))
 		return
 	}
 
 	end, err := time.Parse(time.RFC3339, req.EndTime)
 	if err != nil {
-		validationError(ctx, fmt.Errorf("invalid endTime format"))
+		validationError(ctx, newRequestError("endTime", "date"))
 		return
 	}
 
 	if !end.After(start) {
-		validationError(ctx, fmt.Errorf("endTime must be after startTime"))
+		validationError(ctx, newRequestError("endTime", "after", "startTime"))
 		return
 	}
 
@@ -227,6 +299,11 @@ func (h *AvailabilitySlotHandler) UpdateSlot(ctx *gin.Context) {
 	slot.StartTime = start
 	slot.EndTime = end
 
+	// Una versión enviada por el cliente debe seguir siendo la actual
+	if req.Version != 0 {
+		slot.Version = req.Version
+	}
+
 	updatedSlot, err := h.svc.UpdateAvailabilitySlot(ctx, slot)
 	if err != nil {
 		handleError(ctx, err)
@@ -236,17 +313,33 @@ func (h *AvailabilitySlotHandler) UpdateSlot(ctx *gin.Context) {
 	handleSuccess(ctx, newAvailabilitySlotResponse(updatedSlot))
 }
 
+// DeleteSlot godoc
+//
+// @Summary        Delete an availability slot
+// @Description    Delete a free availability slot by id
+// @Tags           AvailabilitySlots
+// @Accept         json
+// @Produce        json
+// @Security       BearerAuth
+// @Param          id   path    string true   "Slot ID"
+// @Success        200  {object}  response       "Slot deleted"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        403  {object}  errorResponse  "Forbidden error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        409  {object}  errorResponse  "Slot has an appointment"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/availabilityslots/{id} [delete]
 func (h *AvailabilitySlotHandler) DeleteSlot(ctx *gin.Context) {
 	id := ctx.DefaultQuery("id", "")
 
 	if id == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID parameter is required"})
+		validationError(ctx, newRequestError("id", "required"))
 		return
 	}
 
 	slotID, err := uuid.Parse(id)
 	if err != nil {
-		validationError(ctx, fmt.Errorf("invalid id format"))
+		validationError(ctx, newRequestError("id", "uuid"))
 		return
 	}
 
@@ -257,7 +350,7 @@ func (h *AvailabilitySlotHandler) DeleteSlot(ctx *gin.Context) {
 	}
 
 	if slot.IsBooked {
-		handleError(ctx, fmt.Errorf("cannot delete a booked slot"))
+		handleError(ctx, newRequestError("slot", "booked"))
 		return
 	}
 
@@ -266,5 +359,5 @@ func (h *AvailabilitySlotHandler) DeleteSlot(ctx *gin.Context) {
 		return
 	}
 
-	handleSuccess(ctx, "Availability slot deleted successfully")
+	handleSuccess(ctx, localize(ctx, "message.availability_slot_deleted"))
 }
@@ -0,0 +1,196 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// compressibleContentTypes are the content types worth compressing, images, videos, PDFs and other
+// downloads are already compressed and are sent as they are
+var compressibleContentTypes = []string{
+	"application/json",
+	"application/problem+json",
+	"application/javascript",
+	"application/xml",
+	"image/svg+xml",
+	"text/css",
+	"text/csv",
+	"text/html",
+	"text/plain",
+	"text/xml",
+}
+
+// compressionMiddleware is a middleware that gzips the responses of clients accepting it, skipping the
+// responses smaller than minSize, which gzip would barely shrink, and the content types not worth compressing.
+// level is a gzip level from 1 (fastest) to 9 (smallest), 0 disables the compression
+func compressionMiddleware(level, minSize int) (gin.HandlerFunc, error) {
+	if level == 0 {
+		return func(ctx *gin.Context) {
+			ctx.Next()
+		}, nil
+	}
+
+	if _, err := gzip.NewWriterLevel(nil, level); err != nil {
+		return nil, err
+	}
+
+	writers := &sync.Pool{
+		New: func() interface{} {
+			gz, _ := gzip.NewWriterLevel(nil, level)
+			return gz
+		},
+	}
+
+	return func(ctx *gin.Context) {
+		ctx.Header("Vary", "Accept-Encoding")
+		if !acceptsGzip(ctx.GetHeader("Accept-Encoding")) {
+			ctx.Next()
+			return
+		}
+
+		writer := &gzipResponseWriter{ResponseWriter: ctx.Writer, writers: writers, minSize: minSize}
+		ctx.Writer = writer
+		defer func() {
+			writer.close()
+			ctx.Writer = writer.ResponseWriter
+		}()
+
+		ctx.Next()
+	}, nil
+}
+
+// acceptsGzip reports whether an Accept-Encoding header lists gzip without a zero quality
+func acceptsGzip(header string) bool {
+	for _, encoding := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(encoding), ";")
+		if name != "gzip" && name != "*" {
+			continue
+		}
+
+		q, ok := strings.CutPrefix(strings.TrimSpace(params), "q=")
+		if !ok {
+			return true
+		}
+		if quality, err := strconv.ParseFloat(q, 64); err == nil && quality > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// gzipResponseWriter holds the body back until it reaches minSize, then it decides whether to compress
+// it from the response headers, and writes the rest of the body through gzip or as it is
+type gzipResponseWriter struct {
+	gin.ResponseWriter
+	writers *sync.Pool
+	minSize int
+
+	buffer  bytes.Buffer
+	decided bool
+	gz      *gzip.Writer
+}
+
+func (w *gzipResponseWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.buffer.Write(data)
+		if w.buffer.Len() < w.minSize {
+			return len(data), nil
+		}
+
+		if err := w.decide(); err != nil {
+			return 0, err
+		}
+		return len(data), nil
+	}
+
+	if w.gz != nil {
+		return w.gz.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *gzipResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends what was written so far, streamed responses are compressed no matter their size
+func (w *gzipResponseWriter) Flush() {
+	if !w.decided {
+		w.minSize = 0
+		w.decide()
+	}
+	if w.gz != nil {
+		w.gz.Flush()
+	}
+
+	w.ResponseWriter.Flush()
+}
+
+// decide starts compressing when the response is worth it and writes the buffered body
+func (w *gzipResponseWriter) decide() error {
+	w.decided = true
+
+	if w.compressible() {
+		header := w.Header()
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+
+		w.gz = w.writers.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+		_, err := w.gz.Write(w.buffer.Bytes())
+		return err
+	}
+
+	_, err := w.ResponseWriter.Write(w.buffer.Bytes())
+	return err
+}
+
+// compressible reports whether the response has a compressible content type, is large enough and
+// isn't already encoded or a partial download
+func (w *gzipResponseWriter) compressible() bool {
+	header := w.Header()
+	if w.buffer.Len() < w.minSize || header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "" {
+		return false
+	}
+
+	status := w.Status()
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusPartialContent || status == http.StatusNotModified {
+		return false
+	}
+
+	contentType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+
+	for _, compressible := range compressibleContentTypes {
+		if contentType == compressible {
+			return true
+		}
+	}
+	return false
+}
+
+// close writes the body still held back and ends the gzip stream
+func (w *gzipResponseWriter) close() {
+	if !w.decided {
+		if w.buffer.Len() == 0 {
+			return
+		}
+		w.decide()
+	}
+
+	if w.gz != nil {
+		w.gz.Close()
+		w.writers.Put(w.gz)
+		w.gz = nil
+	}
+}
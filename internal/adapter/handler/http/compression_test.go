@@ -0,0 +1,86 @@
+package http
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCompressedRouter(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	compression, err := compressionMiddleware(6, 100)
+	require.NoError(t, err)
+	router.Use(compression)
+
+	router.GET("/list", func(ctx *gin.Context) {
+		handleSuccess(ctx, strings.Repeat("quote ", 100))
+	})
+	router.GET("/small", func(ctx *gin.Context) {
+		handleSuccess(ctx, "quote")
+	})
+	router.GET("/image", func(ctx *gin.Context) {
+		ctx.Data(http.StatusOK, "image/jpeg", []byte(strings.Repeat("x", 500)))
+	})
+
+	return router
+}
+
+func get(router *gin.Engine, path, acceptEncoding string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCompressionMiddlewareCompressesJSON(t *testing.T) {
+	rec := get(newCompressedRouter(t), "/list", "br, gzip;q=0.8")
+
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+
+	gz, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"success":true`)
+}
+
+func TestCompressionMiddlewareSkips(t *testing.T) {
+	router := newCompressedRouter(t)
+
+	tests := map[string]struct {
+		path           string
+		acceptEncoding string
+	}{
+		"not accepted":    {"/list", ""},
+		"zero quality":    {"/list", "gzip;q=0"},
+		"small response":  {"/small", "gzip"},
+		"binary download": {"/image", "gzip"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := get(router, tt.path, tt.acceptEncoding)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Empty(t, rec.Header().Get("Content-Encoding"))
+			assert.NotEmpty(t, rec.Body.String())
+		})
+	}
+}
+
+func TestCompressionMiddlewareInvalidLevel(t *testing.T) {
+	_, err := compressionMiddleware(12, 100)
+	assert.Error(t, err)
+}
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"harajuku/backend/internal/adapter/config"
+
+	"github.com/gin-contrib/cors"
+)
+
+// localHosts are the hosts the CORS dev mode allows on any port
+var localHosts = map[string]bool{
+	"localhost": true,
+	"127.0.0.1": true,
+	"::1":       true,
+}
+
+// originPattern is an allowed origin, host is either a full host or, for wildcard subdomains,
+// the suffix the host must end with, e.g. ".harajuku.mx"
+type originPattern struct {
+	scheme   string
+	host     string
+	wildcard bool
+}
+
+// originMatcher decides which browser origins may call the API
+type originMatcher struct {
+	patterns []originPattern
+	devMode  bool
+}
+
+// newCORSConfig builds the CORS configuration of the router from the allowed origins, failing on
+// the origins that aren't valid rather than letting every browser request be refused
+func newCORSConfig(config *config.HTTP) (cors.Config, error) {
+	matcher, err := newOriginMatcher(config.AllowedOrigins, config.CORSDevMode)
+	if err != nil {
+		return cors.Config{}, err
+	}
+
+	if matcher.devMode && config.Env == "production" {
+		return cors.Config{}, fmt.Errorf("the CORS dev mode can't be enabled in production")
+	}
+
+	if len(matcher.patterns) == 0 && !matcher.devMode {
+		return cors.Config{}, fmt.Errorf("no CORS origins are allowed, set HTTP_ALLOWED_ORIGINS or enable HTTP_CORS_DEV_MODE")
+	}
+
+	corsConfig := cors.DefaultConfig()
+	corsConfig.AllowOriginFunc = matcher.allows
+	corsConfig.AllowHeaders = append(
+		corsConfig.AllowHeaders,
+		"Authorization",
+		"Content-Type",
+		"Content-Disposition",
+		"X-Requested-With",
+		"If-None-Match",
+		healthTokenHeaderKey,
+	)
+	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+	// If you need the frontend to read the token or other headers back:
+	corsConfig.ExposeHeaders = []string{"Authorization", "Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-Quota-Limit", "X-Quota-Remaining", "X-Quota-Reset", "Deprecation", "Link", "X-Total-Count", "X-Next-Cursor", "ETag"}
+	corsConfig.AllowCredentials = true
+
+	return corsConfig, corsConfig.Validate()
+}
+
+// newOriginMatcher parses the allowed origins separated by commas, such as https://harajuku.mx
+// or https://*.harajuku.mx. Blank entries left by stray commas are skipped
+func newOriginMatcher(origins string, devMode bool) (*originMatcher, error) {
+	matcher := &originMatcher{devMode: devMode}
+
+	for _, origin := range strings.Split(origins, ",") {
+		origin = strings.TrimSpace(origin)
+		if origin == "" {
+			continue
+		}
+
+		pattern, err := parseOriginPattern(origin)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CORS origin %q: %w", origin, err)
+		}
+		matcher.patterns = append(matcher.patterns, pattern)
+	}
+
+	return matcher, nil
+}
+
+// parseOriginPattern parses an allowed origin, which is a scheme and a host with an optional port and nothing else
+func parseOriginPattern(origin string) (originPattern, error) {
+	if origin == "*" {
+		return originPattern{}, fmt.Errorf("every origin can't be allowed along with credentials")
+	}
+
+	scheme, host, found := strings.Cut(origin, "://")
+	if !found || (scheme != "http" && scheme != "https") {
+		return originPattern{}, fmt.Errorf("it must start with http:// or https://")
+	}
+
+	pattern := originPattern{scheme: scheme, host: strings.ToLower(host)}
+	if rest, ok := strings.CutPrefix(pattern.host, "*."); ok {
+		pattern.host = "." + rest
+		pattern.wildcard = true
+	}
+
+	// The rest must be a bare host, without path, query or other wildcards
+	check := strings.TrimPrefix(pattern.host, ".")
+	parsed, err := url.Parse(scheme + "://" + check)
+	if err != nil || parsed.Host != check || parsed.Hostname() == "" || strings.Contains(check, "*") {
+		return originPattern{}, fmt.Errorf("it must be a scheme and a host, a wildcard can only replace the first subdomain")
+	}
+
+	return pattern, nil
+}
+
+// allows reports whether a browser may call the API from origin
+func (m *originMatcher) allows(origin string) bool {
+	parsed, err := url.Parse(origin)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+
+	scheme, host := parsed.Scheme, strings.ToLower(parsed.Host)
+	if m.devMode && localHosts[strings.ToLower(parsed.Hostname())] {
+		return true
+	}
+
+	for _, pattern := range m.patterns {
+		if pattern.scheme != scheme {
+			continue
+		}
+
+		if !pattern.wildcard && pattern.host == host {
+			return true
+		}
+		if pattern.wildcard && strings.HasSuffix(host, pattern.host) && isSubdomain(host, pattern.host) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSubdomain reports whether host has at least one label before suffix, so
+// https://*.harajuku.mx doesn't allow https://.harajuku.mx
+func isSubdomain(host, suffix string) bool {
+	label := strings.TrimSuffix(host, suffix)
+	if label == "" {
+		return false
+	}
+
+	// A port belongs to the suffix, hosts under it can't add one
+	_, _, err := net.SplitHostPort(label)
+	return err != nil
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"testing"
+
+	"harajuku/backend/internal/adapter/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCORSConfigRejectsInvalidOrigins(t *testing.T) {
+	for _, origins := range []string{
+		"*",
+		"harajuku.mx",
+		"ftp://harajuku.mx",
+		"https://harajuku.mx/app",
+		"https://app.*.harajuku.mx",
+		"https://harajuku.mx;https://admin.harajuku.mx",
+	} {
+		_, err := newCORSConfig(&config.HTTP{AllowedOrigins: origins})
+		assert.Error(t, err, origins)
+	}
+}
+
+func TestNewCORSConfigRequiresOrigins(t *testing.T) {
+	_, err := newCORSConfig(&config.HTTP{AllowedOrigins: " , "})
+	assert.Error(t, err)
+
+	_, err = newCORSConfig(&config.HTTP{CORSDevMode: true})
+	assert.NoError(t, err)
+
+	_, err = newCORSConfig(&config.HTTP{Env: "production", CORSDevMode: true})
+	assert.Error(t, err)
+}
+
+func TestCORSAllowsOrigins(t *testing.T) {
+	corsConfig, err := newCORSConfig(&config.HTTP{AllowedOrigins: "https://harajuku.mx, https://*.harajuku.mx,"})
+	require.NoError(t, err)
+
+	allowed := map[string]bool{
+		"https://harajuku.mx":          true,
+		"https://admin.harajuku.mx":    true,
+		"https://a.b.harajuku.mx":      true,
+		"https://HARAJUKU.mx":          true,
+		"http://harajuku.mx":           false,
+		"https://harajuku.mx:8443":     false,
+		"https://admin.harajuku.mx:80": false,
+		"https://evilharajuku.mx":      false,
+		"https://harajuku.mx.evil.com": false,
+		"http://localhost:3000":        false,
+	}
+	for origin, want := range allowed {
+		assert.Equal(t, want, corsConfig.AllowOriginFunc(origin), origin)
+	}
+}
+
+func TestCORSDevModeAllowsLocalhost(t *testing.T) {
+	corsConfig, err := newCORSConfig(&config.HTTP{AllowedOrigins: "https://harajuku.mx", CORSDevMode: true})
+	require.NoError(t, err)
+
+	for _, origin := range []string{"http://localhost:3000", "http://127.0.0.1:5173", "http://[::1]:8080", "https://harajuku.mx"} {
+		assert.True(t, corsConfig.AllowOriginFunc(origin), origin)
+	}
+	assert.False(t, corsConfig.AllowOriginFunc("http://localhost.evil.com"))
+}
@@ -0,0 +1,354 @@
+package http
+
+import (
+	"strings"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CouponHandler represents the HTTP handler for coupon-related requests
+type CouponHandler struct {
+	svc port.CouponService
+}
+
+// NewCouponHandler creates a new CouponHandler instance
+func NewCouponHandler(svc port.CouponService) *CouponHandler {
+	return &CouponHandler{
+		svc,
+	}
+}
+
+// couponResponse represents a coupon response body
+type couponResponse struct {
+	ID                      uuid.UUID   `json:"id"`
+	Code                    string      `json:"code" example:"VERANO25"`
+	DiscountType            string      `json:"discountType" example:"percentage"`
+	Value                   float64     `json:"value" example:"25"`
+	Currency                string      `json:"currency,omitempty" example:"MXN"`
+	StartsAt                string      `json:"startsAt" example:"2025-06-01T00:00:00Z"`
+	EndsAt                  string      `json:"endsAt" example:"2025-09-01T00:00:00Z"`
+	MaxRedemptions          int         `json:"maxRedemptions" example:"100"`
+	MaxRedemptionsPerClient int         `json:"maxRedemptionsPerClient" example:"1"`
+	TypeOfServiceIDs        []uuid.UUID `json:"typeOfServiceIds"`
+	Redemptions             int         `json:"redemptions" example:"12"`
+}
+
+// newCouponResponse is a helper function to create a response body for handling coupon data
+func newCouponResponse(c *domain.Coupon) *couponResponse {
+	typeOfServiceIDs := c.TypeOfServiceIDs
+	if typeOfServiceIDs == nil {
+		typeOfServiceIDs = []uuid.UUID{}
+	}
+
+	return &couponResponse{
+		ID:                      c.ID,
+		Code:                    c.Code,
+		DiscountType:            string(c.DiscountType),
+		Value:                   c.Value,
+		Currency:                string(c.Currency),
+		StartsAt:                c.StartsAt.Format(time.RFC3339),
+		EndsAt:                  c.EndsAt.Format(time.RFC3339),
+		MaxRedemptions:          c.MaxRedemptions,
+		MaxRedemptionsPerClient: c.MaxRedemptionsPerClient,
+		TypeOfServiceIDs:        typeOfServiceIDs,
+		Redemptions:             c.Redemptions,
+	}
+}
+
+// couponRedemptionResponse represents a coupon redemption response body
+type couponRedemptionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	CouponID   uuid.UUID `json:"couponId"`
+	QuoteID    uuid.UUID `json:"quoteId"`
+	ClientID   uuid.UUID `json:"clientId"`
+	RedeemedAt string    `json:"redeemedAt" example:"2025-06-14T18:30:00Z"`
+}
+
+// newCouponRedemptionResponse is a helper function to create a response body for handling coupon redemption data
+func newCouponRedemptionResponse(r *domain.CouponRedemption) *couponRedemptionResponse {
+	return &couponRedemptionResponse{
+		ID:         r.ID,
+		CouponID:   r.CouponID,
+		QuoteID:    r.QuoteID,
+		ClientID:   r.ClientID,
+		RedeemedAt: r.RedeemedAt.Format(time.RFC3339),
+	}
+}
+
+// couponRequest represents the request body for creating or updating a coupon.
+// Zero usage limits leave the coupon unlimited and an empty list of types of service
+// makes it apply to every service
+type couponRequest struct {
+	Code                    string      `json:"code" binding:"required,max=32,alphanum" example:"VERANO25"`
+	DiscountType            string      `json:"discountType" binding:"required,oneof=percentage fixed" example:"percentage"`
+	Value                   float64     `json:"value" binding:"required,gt=0" example:"25"`
+	Currency                string      `json:"currency" binding:"required_if=DiscountType fixed,omitempty,len=3" example:"MXN"`
+	StartsAt                time.Time   `json:"startsAt" binding:"required" example:"2025-06-01T00:00:00Z"`
+	EndsAt                  time.Time   `json:"endsAt" binding:"required" example:"2025-09-01T00:00:00Z"`
+	MaxRedemptions          int         `json:"maxRedemptions" binding:"min=0" example:"100"`
+	MaxRedemptionsPerClient int         `json:"maxRedemptionsPerClient" binding:"min=0" example:"1"`
+	TypeOfServiceIDs        []uuid.UUID `json:"typeOfServiceIds"`
+}
+
+// toDomain converts the request into a coupon, percentage discounts carry no currency
+func (req *couponRequest) toDomain(id uuid.UUID) *domain.Coupon {
+	coupon := &domain.Coupon{
+		ID:                      id,
+		Code:                    req.Code,
+		DiscountType:            domain.DiscountType(req.DiscountType),
+		Value:                   req.Value,
+		StartsAt:                req.StartsAt,
+		EndsAt:                  req.EndsAt,
+		MaxRedemptions:          req.MaxRedemptions,
+		MaxRedemptionsPerClient: req.MaxRedemptionsPerClient,
+		TypeOfServiceIDs:        req.TypeOfServiceIDs,
+	}
+
+	if coupon.DiscountType == domain.DiscountFixed {
+		coupon.Currency = domain.Currency(strings.ToUpper(req.Currency))
+	}
+
+	return coupon
+}
+
+// CreateCoupon godoc
+//
+// @Summary        Register a new coupon
+// @Description    Create a discount code clients apply to their quotes within its validity window and usage limits
+// @Tags           Coupons
+// @Accept         json
+// @Produce        json
+// @Param          coupon body    couponRequest true   "Coupon Data"
+// @Success        200    {object}  couponResponse  "Coupon created"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        401    {object}  errorResponse  "Unauthorized error"
+// @Failure        403    {object}  errorResponse  "Forbidden error"
+// @Failure        404    {object}  errorResponse  "Type of service not found"
+// @Failure        409    {object}  errorResponse  "Code already taken"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/coupons [post]
+// @Security       BearerAuth
+func (ch *CouponHandler) CreateCoupon(ctx *gin.Context) {
+	var req couponRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	created, err := ch.svc.CreateCoupon(ctx, req.toDomain(uuid.New()))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newCouponResponse(created)
+	handleSuccess(ctx, rsp)
+}
+
+// listCouponsRequest represents the query for listing coupons
+type listCouponsRequest struct {
+	pageRequest
+}
+
+// ListCoupons godoc
+//
+// @Summary        List coupons
+// @Description    List coupons with pagination and how many quotes each was applied to
+// @Tags           Coupons
+// @Accept         json
+// @Produce        json
+// @Param          skip   query   uint64 false  "Page number, starting at 1"
+// @Param          limit  query   uint64 true   "Limit"
+// @Success        200    {object}  meta  "Coupons displayed"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        401    {object}  errorResponse  "Unauthorized error"
+// @Failure        403    {object}  errorResponse  "Forbidden error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/coupons/all [get]
+// @Security       BearerAuth
+func (ch *CouponHandler) ListCoupons(ctx *gin.Context) {
+	var req listCouponsRequest
+	var couponsList []couponResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	coupons, err := ch.svc.ListCoupons(ctx, req.Skip, req.Limit)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, coupon := range coupons {
+		couponsList = append(couponsList, *newCouponResponse(&coupon))
+	}
+
+	total := uint64(len(couponsList))
+	meta := newPageMeta(ctx, total, req.pageRequest)
+	rsp := toMap(meta, couponsList, "coupons")
+
+	handleSuccess(ctx, rsp)
+}
+
+// GetCoupon godoc
+//
+// @Summary        Get a coupon
+// @Description    Get a coupon by id
+// @Tags           Coupons
+// @Accept         json
+// @Produce        json
+// @Param          id   query   string true   "Coupon ID"
+// @Success        200  {object}  couponResponse  "Coupon displayed"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        401  {object}  errorResponse  "Unauthorized error"
+// @Failure        403  {object}  errorResponse  "Forbidden error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/coupons [get]
+// @Security       BearerAuth
+func (ch *CouponHandler) GetCoupon(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	coupon, err := ch.svc.GetCoupon(ctx, id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newCouponResponse(coupon)
+	handleSuccess(ctx, rsp)
+}
+
+// UpdateCoupon godoc
+//
+// @Summary        Update a coupon
+// @Description    Update a coupon by id, quotes it was already applied to keep their discount
+// @Tags           Coupons
+// @Accept         json
+// @Produce        json
+// @Param          id     query   string        true   "Coupon ID"
+// @Param          coupon body    couponRequest true   "Coupon Data"
+// @Success        200    {object}  couponResponse  "Coupon updated"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        401    {object}  errorResponse  "Unauthorized error"
+// @Failure        403    {object}  errorResponse  "Forbidden error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        409    {object}  errorResponse  "Code already taken"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/coupons [put]
+// @Security       BearerAuth
+func (ch *CouponHandler) UpdateCoupon(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	var req couponRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	updated, err := ch.svc.UpdateCoupon(ctx, req.toDomain(id))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newCouponResponse(updated)
+	handleSuccess(ctx, rsp)
+}
+
+// DeleteCoupon godoc
+//
+// @Summary        Delete a coupon
+// @Description    Delete a coupon and its redemptions, quotes it was applied to keep their discount
+// @Tags           Coupons
+// @Accept         json
+// @Produce        json
+// @Param          id   query   string true   "Coupon ID"
+// @Success        200    {object}  string  "Coupon deleted successfully"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        401    {object}  errorResponse  "Unauthorized error"
+// @Failure        403    {object}  errorResponse  "Forbidden error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/coupons [delete]
+// @Security       BearerAuth
+func (ch *CouponHandler) DeleteCoupon(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	err = ch.svc.DeleteCoupon(ctx, id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, localize(ctx, "message.coupon_deleted"))
+}
+
+// ListCouponRedemptions godoc
+//
+// @Summary        List the redemptions of a coupon
+// @Description    List the quotes a coupon was applied to with pagination, the latest first
+// @Tags           Coupons
+// @Accept         json
+// @Produce        json
+// @Param          id     query   string true   "Coupon ID"
+// @Param          skip   query   uint64 false  "Page number, starting at 1"
+// @Param          limit  query   uint64 true   "Limit"
+// @Success        200    {object}  meta  "Redemptions displayed"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        401    {object}  errorResponse  "Unauthorized error"
+// @Failure        403    {object}  errorResponse  "Forbidden error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/coupons/redemptions [get]
+// @Security       BearerAuth
+func (ch *CouponHandler) ListCouponRedemptions(ctx *gin.Context) {
+	var req pageRequest
+	var redemptionsList []couponRedemptionResponse
+
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	redemptions, err := ch.svc.ListCouponRedemptions(ctx, id, req.Skip, req.Limit)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, redemption := range redemptions {
+		redemptionsList = append(redemptionsList, *newCouponRedemptionResponse(&redemption))
+	}
+
+	total := uint64(len(redemptionsList))
+	meta := newPageMeta(ctx, total, req)
+	rsp := toMap(meta, redemptionsList, "redemptions")
+
+	handleSuccess(ctx, rsp)
+}
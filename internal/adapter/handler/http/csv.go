@@ -0,0 +1,149 @@
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+const (
+	// mimeCSV is the content type list endpoints answer with when the client accepts it
+	mimeCSV = "text/csv"
+	// csvFlushRows is how many rows are written before flushing them to the client
+	csvFlushRows = 100
+)
+
+// handleList sends a page of items as JSON with its meta, or as CSV when the Accept header asks
+// for it. The CSV pagination goes in the X-Total-Count and X-Next-Cursor headers instead of the meta
+func handleList(ctx *gin.Context, m meta, items any, key string) {
+	if ctx.NegotiateFormat(binding.MIMEJSON, mimeCSV) != mimeCSV {
+		handleSuccess(ctx, toMap(m, items, key))
+		return
+	}
+
+	ctx.Header("X-Total-Count", strconv.FormatUint(m.Total, 10))
+	if m.NextCursor != "" {
+		ctx.Header("X-Next-Cursor", m.NextCursor)
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, key))
+	ctx.Header("Content-Type", mimeCSV+"; charset=utf-8")
+	ctx.Status(http.StatusOK)
+
+	if err := writeCSV(ctx.Writer, items); err != nil {
+		ctx.Error(err)
+	}
+}
+
+// writeCSV streams a slice of response structs as CSV, the header row holds the json names of their
+// fields. Fields without a json name are left out and nested objects are written as JSON
+func writeCSV(w gin.ResponseWriter, items any) error {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("csv: %T is not a slice", items)
+	}
+
+	t := v.Type().Elem()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("csv: %s is not a struct", t)
+	}
+
+	columns, names := csvColumns(t)
+	writer := csv.NewWriter(w)
+	if err := writer.Write(names); err != nil {
+		return err
+	}
+
+	row := make([]string, len(columns))
+	for i := 0; i < v.Len(); i++ {
+		item := reflect.Indirect(v.Index(i))
+		for j, column := range columns {
+			cell, err := csvCell(item.Field(column))
+			if err != nil {
+				return err
+			}
+			row[j] = cell
+		}
+
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+
+		if (i+1)%csvFlushRows == 0 {
+			writer.Flush()
+			w.Flush()
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvColumns returns the indexes and json names of the exported fields of t
+func csvColumns(t reflect.Type) ([]int, []string) {
+	var columns []int
+	var names []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		columns = append(columns, i)
+		names = append(names, name)
+	}
+
+	return columns, names
+}
+
+// csvCell formats a field for a CSV cell, nil pointers are left empty
+func csvCell(field reflect.Value) (string, error) {
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return "", nil
+		}
+		field = field.Elem()
+	}
+
+	switch value := field.Interface().(type) {
+	case time.Time:
+		return value.Format(time.RFC3339), nil
+	case fmt.Stringer:
+		return value.String(), nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		return field.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(field.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(field.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(field.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(field.Float(), 'f', -1, 64), nil
+	}
+
+	encoded, err := json.Marshal(field.Interface())
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
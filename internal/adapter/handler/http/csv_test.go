@@ -0,0 +1,84 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newListRouter(items []appointmentResponse, m meta) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/appointments", func(ctx *gin.Context) {
+		handleList(ctx, m, items, "appointments")
+	})
+	return router
+}
+
+func getWithAccept(router *gin.Engine, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/appointments", nil)
+	req.Header.Set("Accept", accept)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandleListWritesCSV(t *testing.T) {
+	id := uuid.MustParse("6a3a8f5e-8a1c-4a8e-9a43-0d7f1f3f2c11")
+	deletedAt := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	items := []appointmentResponse{
+		{ID: id, UserID: id, SlotID: id, QuoteID: id, Status: domain.Booked, Version: 2},
+		{ID: id, UserID: id, SlotID: id, QuoteID: id, Status: domain.Cancelled, DeletedAt: &deletedAt, Version: 1},
+	}
+	m := meta{Total: 40, Limit: 2, Skip: 1, NextCursor: "next"}
+
+	rec := getWithAccept(newListRouter(items, m), "text/csv")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="appointments.csv"`, rec.Header().Get("Content-Disposition"))
+	assert.Equal(t, "40", rec.Header().Get("X-Total-Count"))
+	assert.Equal(t, "next", rec.Header().Get("X-Next-Cursor"))
+
+	uid := id.String()
+	assert.Equal(t, "id,userId,slotId,quoteId,status,deletedAt,version\n"+
+		uid+","+uid+","+uid+","+uid+",booked,,2\n"+
+		uid+","+uid+","+uid+","+uid+",cancelled,2025-01-02T10:00:00Z,1\n", rec.Body.String())
+}
+
+func TestHandleListDefaultsToJSON(t *testing.T) {
+	router := newListRouter(nil, meta{Limit: 5, Skip: 1})
+
+	for _, accept := range []string{"", "*/*", "application/json", "application/json, text/csv;q=0.5"} {
+		rec := getWithAccept(router, accept)
+		assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"), accept)
+	}
+}
+
+func TestCSVCellEncodesNestedObjectsAsJSON(t *testing.T) {
+	items := []quoteResponse{{
+		Description:    `Tattoo, "small"`,
+		CurrencyFormat: currencyFormatResponse{},
+	}}
+
+	router := gin.New()
+	router.GET("/quotes", func(ctx *gin.Context) {
+		handleList(ctx, meta{Total: 1, Limit: 5, Skip: 1}, items, "quotes")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/quotes", nil)
+	req.Header.Set("Accept", "text/csv")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Contains(t, rec.Body.String(), `"Tattoo, ""small"""`)
+	assert.Contains(t, rec.Body.String(), `"{""symbol"":`)
+}
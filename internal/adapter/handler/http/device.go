@@ -0,0 +1,116 @@
+package http
+
+import (
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// DeviceHandler represents the HTTP handler for registering the devices push notifications are sent to
+type DeviceHandler struct {
+	svc port.DeviceService
+}
+
+// NewDeviceHandler creates a new DeviceHandler instance
+func NewDeviceHandler(svc port.DeviceService) *DeviceHandler {
+	return &DeviceHandler{
+		svc,
+	}
+}
+
+// deviceResponse represents a registered device
+type deviceResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Platform  string    `json:"platform" example:"android"`
+	CreatedAt string    `json:"createdAt" example:"2025-03-14T10:00:00Z"`
+	UpdatedAt string    `json:"updatedAt" example:"2025-03-14T10:00:00Z"`
+}
+
+// newDeviceResponse is a helper function to create a response body for handling device data
+func newDeviceResponse(d *domain.Device) *deviceResponse {
+	return &deviceResponse{
+		ID:        d.ID,
+		Platform:  string(d.Platform),
+		CreatedAt: d.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: d.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// registerDeviceRequest represents the request body for registering a device
+type registerDeviceRequest struct {
+	Token    string `json:"token" binding:"required,max=4096" example:"fcm-registration-token"`
+	Platform string `json:"platform" binding:"required,oneof=android ios web" example:"android"`
+}
+
+// RegisterDevice godoc
+//
+// @Summary        Register a device
+// @Description    Register the FCM token of a device of the authenticated user so appointment and quote updates are pushed to it, registering a token again refreshes it
+// @Tags           Users
+// @Accept         json
+// @Produce        json
+// @Param          device body    registerDeviceRequest true   "Device Data"
+// @Success        200    {object}  deviceResponse  "Device registered"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        401    {object}  errorResponse  "Unauthorized error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/users/me/devices [post]
+// @Security       BearerAuth
+func (dh *DeviceHandler) RegisterDevice(ctx *gin.Context) {
+	var req registerDeviceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	device, err := dh.svc.RegisterDevice(ctx, &domain.Device{
+		UserID:   authPayload.UserID,
+		Token:    req.Token,
+		Platform: domain.DevicePlatform(req.Platform),
+	})
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newDeviceResponse(device)
+	handleSuccess(ctx, rsp)
+}
+
+// UnregisterDevice godoc
+//
+// @Summary        Unregister a device
+// @Description    Stop pushing notifications to a device of the authenticated user, e.g. when they sign out of the app
+// @Tags           Users
+// @Accept         json
+// @Produce        json
+// @Param          token  query   string true   "Device token"
+// @Success        200    {object}  string  "Device unregistered successfully"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        401    {object}  errorResponse  "Unauthorized error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/users/me/devices [delete]
+// @Security       BearerAuth
+func (dh *DeviceHandler) UnregisterDevice(ctx *gin.Context) {
+	token := ctx.Query("token")
+	if token == "" {
+		validationError(ctx, newRequestError("token", "required"))
+		return
+	}
+
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	err := dh.svc.UnregisterDevice(ctx, authPayload.UserID, token)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, localize(ctx, "message.device_unregistered"))
+}
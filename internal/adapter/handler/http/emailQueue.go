@@ -0,0 +1,240 @@
+package http
+
+import (
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// EmailQueueHandler represents the HTTP handler for inspecting the email delivery queue
+type EmailQueueHandler struct {
+	svc port.EmailQueueService
+}
+
+// NewEmailQueueHandler creates a new EmailQueueHandler instance
+func NewEmailQueueHandler(svc port.EmailQueueService) *EmailQueueHandler {
+	return &EmailQueueHandler{
+		svc,
+	}
+}
+
+// queuedEmailResponse represents an email that could not be delivered
+type queuedEmailResponse struct {
+	ID        uuid.UUID `json:"id"`
+	To        []string  `json:"to" example:"cliente@example.com"`
+	Subject   string    `json:"subject" example:"Respuesta a su cotización"`
+	Attempts  int       `json:"attempts" example:"5"`
+	LastError string    `json:"lastError" example:"email sending failed with status: 503"`
+	CreatedAt string    `json:"createdAt" example:"2025-03-14T10:00:00Z"`
+}
+
+// newQueuedEmailResponse is a helper function to create a response body for handling queued email data
+func newQueuedEmailResponse(e *domain.QueuedEmail) *queuedEmailResponse {
+	return &queuedEmailResponse{
+		ID:        e.ID,
+		To:        e.To,
+		Subject:   e.Subject,
+		Attempts:  e.Attempts,
+		LastError: e.LastError,
+		CreatedAt: e.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// listDeadLettersRequest represents the query for listing undelivered emails
+type listDeadLettersRequest struct {
+	pageRequest
+}
+
+// ListDeadLetters godoc
+//
+// @Summary        List undelivered emails
+// @Description    List the emails moved to the dead-letter list after running out of delivery attempts, most recent first
+// @Tags           Emails
+// @Accept         json
+// @Produce        json
+// @Param          skip   query   uint64 false  "Page number, starting at 1"
+// @Param          limit  query   uint64 true   "Limit"
+// @Success        200    {object}  meta  "Undelivered emails displayed"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/emails/deadletters [get]
+func (eh *EmailQueueHandler) ListDeadLetters(ctx *gin.Context) {
+	var req listDeadLettersRequest
+	var emailsList []queuedEmailResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	emails, err := eh.svc.ListDeadLetters(ctx, req.Skip, req.Limit)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, email := range emails {
+		emailsList = append(emailsList, *newQueuedEmailResponse(&email))
+	}
+
+	total := uint64(len(emailsList))
+	meta := newPageMeta(ctx, total, req.pageRequest)
+	rsp := toMap(meta, emailsList, "emails")
+
+	handleSuccess(ctx, rsp)
+}
+
+// RetryDeadLetter godoc
+//
+// @Summary        Retry an undelivered email
+// @Description    Put an email from the dead-letter list back in the delivery queue with fresh attempts
+// @Tags           Emails
+// @Accept         json
+// @Produce        json
+// @Param          id   query   string true   "Email ID"
+// @Success        200  {object}  queuedEmailResponse  "Email queued"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/emails/deadletters/retry [post]
+func (eh *EmailQueueHandler) RetryDeadLetter(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	email, err := eh.svc.RetryDeadLetter(ctx, id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newQueuedEmailResponse(email)
+	handleSuccess(ctx, rsp)
+}
+
+// DiscardDeadLetter godoc
+//
+// @Summary        Discard an undelivered email
+// @Description    Delete an email from the dead-letter list for good, it is never sent
+// @Tags           Emails
+// @Accept         json
+// @Produce        json
+// @Param          id   query   string true   "Email ID"
+// @Success        200  {object}  response  "Email discarded"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/emails/deadletters [delete]
+func (eh *EmailQueueHandler) DiscardDeadLetter(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	if err := eh.svc.DiscardDeadLetter(ctx, id); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, nil)
+}
+
+// emailLogResponse represents an attempt to deliver an email
+type emailLogResponse struct {
+	ID                uuid.UUID `json:"id"`
+	EmailID           uuid.UUID `json:"emailId"`
+	Recipients        []string  `json:"recipients" example:"cliente@example.com"`
+	Template          string    `json:"template,omitempty" example:"quote-state-changed"`
+	Subject           string    `json:"subject" example:"Respuesta a su cotización"`
+	Status            string    `json:"status" example:"sent"`
+	ProviderMessageID string    `json:"providerMessageId,omitempty" example:"0100018e3a7c9f1d-6b1f2c3d"`
+	Error             string    `json:"error,omitempty" example:"email sending failed with status: 503"`
+	Attempt           int       `json:"attempt" example:"1"`
+	CreatedAt         string    `json:"createdAt" example:"2025-03-14T10:00:00Z"`
+}
+
+// newEmailLogResponse is a helper function to create a response body for handling email log data
+func newEmailLogResponse(l *domain.EmailLog) *emailLogResponse {
+	return &emailLogResponse{
+		ID:                l.ID,
+		EmailID:           l.EmailID,
+		Recipients:        l.Recipients,
+		Template:          string(l.Template),
+		Subject:           l.Subject,
+		Status:            string(l.Status),
+		ProviderMessageID: l.ProviderMessageID,
+		Error:             l.Error,
+		Attempt:           l.Attempt,
+		CreatedAt:         l.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// listEmailLogsRequest represents the query for listing the email delivery log
+type listEmailLogsRequest struct {
+	pageRequest
+	Recipient string `form:"recipient" binding:"omitempty,email" example:"cliente@example.com"`
+	Status    string `form:"status" binding:"omitempty,oneof=sent failed" example:"failed"`
+	Template  string `form:"template" example:"quote-state-changed"`
+}
+
+// ListEmailLogs godoc
+//
+// @Summary        List email delivery attempts
+// @Description    List every attempt to deliver an email with its outcome and provider message ID, most recent first
+// @Tags           Emails
+// @Accept         json
+// @Produce        json
+// @Param          recipient  query   string false  "Recipient email"
+// @Param          status     query   string false  "Status" Enums(sent, failed)
+// @Param          template   query   string false  "Template"
+// @Param          skip       query   uint64 false  "Page number, starting at 1"
+// @Param          limit      query   uint64 true   "Limit"
+// @Success        200        {object}  meta  "Email delivery attempts displayed"
+// @Failure        400        {object}  errorResponse  "Validation error"
+// @Failure        500        {object}  errorResponse  "Internal server error"
+// @Router         /v1/emails/logs [get]
+func (eh *EmailQueueHandler) ListEmailLogs(ctx *gin.Context) {
+	var req listEmailLogsRequest
+	var logsList []emailLogResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	filter := port.EmailLogFilter{
+		Recipient: req.Recipient,
+		Skip:      req.Skip,
+		Limit:     req.Limit,
+	}
+	if req.Status != "" {
+		status := domain.EmailStatus(req.Status)
+		filter.Status = &status
+	}
+	if req.Template != "" {
+		template := domain.EmailTemplate(req.Template)
+		filter.Template = &template
+	}
+
+	logs, err := eh.svc.ListEmailLogs(ctx, filter)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, log := range logs {
+		logsList = append(logsList, *newEmailLogResponse(&log))
+	}
+
+	total := uint64(len(logsList))
+	meta := newPageMeta(ctx, total, req.pageRequest)
+	rsp := toMap(meta, logsList, "logs")
+
+	handleSuccess(ctx, rsp)
+}
@@ -0,0 +1,42 @@
+package http
+
+import (
+	"net/http"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errorReporterKey is the key of the error reporter in the gin context
+const errorReporterKey = "error_reporter"
+
+// errorReportMiddleware is a middleware that makes reporter available to handleError, and recovers the panics of
+// the handlers, reporting them and answering with an internal error instead of gin's empty response
+func errorReportMiddleware(reporter port.ErrorReporter) gin.HandlerFunc {
+	recovery := gin.CustomRecovery(func(ctx *gin.Context, recovered any) {
+		reporter.ReportPanic(ctx, recovered)
+
+		// The panic is reported already, handleAbort would report the internal error it answers with as well
+		statusCode, code := lookupError(domain.ErrInternal)
+		ctx.AbortWithStatusJSON(statusCode, newErrorResponse(ctx, code, domain.ErrInternal))
+	})
+
+	return func(ctx *gin.Context) {
+		ctx.Set(errorReporterKey, reporter)
+		recovery(ctx)
+	}
+}
+
+// reportError reports err when it answers the request with an internal error, the errors of the
+// client are not worth reporting. The services log the cause, which the report points to through its request ID
+func reportError(ctx *gin.Context, statusCode int, err error) {
+	if statusCode != http.StatusInternalServerError {
+		return
+	}
+
+	if reporter, ok := ctx.Value(errorReporterKey).(port.ErrorReporter); ok {
+		reporter.Report(ctx, err)
+	}
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingReporter is a port.ErrorReporter recording what it was sent
+type recordingReporter struct {
+	errors []error
+	panics []any
+}
+
+func (r *recordingReporter) Report(ctx context.Context, err error) {
+	r.errors = append(r.errors, err)
+}
+
+func (r *recordingReporter) ReportPanic(ctx context.Context, recovered any) {
+	r.panics = append(r.panics, recovered)
+}
+
+func (r *recordingReporter) Flush(ctx context.Context) error {
+	return nil
+}
+
+func TestErrorReportMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reporter := &recordingReporter{}
+	router := gin.New()
+	router.Use(errorReportMiddleware(reporter))
+	router.GET("/panic", func(ctx *gin.Context) {
+		panic("nil map")
+	})
+	router.GET("/internal", func(ctx *gin.Context) {
+		handleError(ctx, errors.New("connection reset"))
+	})
+	router.GET("/missing", func(ctx *gin.Context) {
+		handleError(ctx, domain.ErrDataNotFound)
+	})
+
+	serve := func(path string) int {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec.Code
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	var rsp errorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rsp))
+	assert.Equal(t, "internal_error", rsp.Code)
+	assert.Equal(t, []any{"nil map"}, reporter.panics)
+
+	assert.Equal(t, http.StatusInternalServerError, serve("/internal"))
+	assert.Equal(t, http.StatusNotFound, serve("/missing"))
+	require.Len(t, reporter.errors, 1)
+	assert.EqualError(t, reporter.errors[0], "connection reset")
+}
@@ -0,0 +1,57 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleCacheable sends a success response like handleSuccess with a weak ETag of its body, or a 304
+// without body when the If-None-Match header of the client already holds it. The ETag hashes the body
+// rather than the version of the entity because the signed file URLs, and the images of a quote, change
+// without the version changing
+func handleCacheable(ctx *gin.Context, data any) {
+	body, err := json.Marshal(newResponse(true, localize(ctx, "message.success"), data))
+	if err != nil {
+		handleError(ctx, domain.ErrInternal)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+
+	// Browsers keep the response but check it is still current before using it
+	ctx.Header("ETag", etag)
+	ctx.Header("Cache-Control", "private, no-cache")
+	// The message of the body is translated
+	ctx.Writer.Header().Add("Vary", "Accept-Language")
+
+	if etagMatches(ctx.GetHeader("If-None-Match"), etag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// etagMatches reports whether an If-None-Match header lists etag, comparing them weakly as GET requests do
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCacheableRouter(description *string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/quote", func(ctx *gin.Context) {
+		handleCacheable(ctx, quoteResponse{Description: *description})
+	})
+	return router
+}
+
+func getIfNoneMatch(router *gin.Engine, etag string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/quote", nil)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandleCacheableReturnsNotModified(t *testing.T) {
+	description := "Tattoo"
+	router := newCacheableRouter(&description)
+
+	first := getIfNoneMatch(router, "")
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, etag)
+	assert.Contains(t, first.Body.String(), `"description":"Tattoo"`)
+
+	// The same body keeps its ETag, so the client's copy is still current
+	again := getIfNoneMatch(router, etag)
+	assert.Equal(t, http.StatusNotModified, again.Code)
+	assert.Empty(t, again.Body.String())
+	assert.Equal(t, etag, again.Header().Get("ETag"))
+
+	// Changing the quote changes the ETag
+	description = "Piercing"
+	changed := getIfNoneMatch(router, etag)
+	assert.Equal(t, http.StatusOK, changed.Code)
+	assert.NotEqual(t, etag, changed.Header().Get("ETag"))
+}
+
+func TestEtagMatches(t *testing.T) {
+	etag := `W/"abc"`
+
+	assert.True(t, etagMatches(`W/"abc"`, etag))
+	assert.True(t, etagMatches(`"abc"`, etag))
+	assert.True(t, etagMatches(`W/"xyz", W/"abc"`, etag))
+	assert.True(t, etagMatches(`*`, etag))
+	assert.False(t, etagMatches(``, etag))
+	assert.False(t, etagMatches(`W/"xyz"`, etag))
+}
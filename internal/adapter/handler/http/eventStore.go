@@ -0,0 +1,122 @@
+package http
+
+import (
+	"encoding/json"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// EventStoreHandler represents the HTTP handler for reading the event store
+type EventStoreHandler struct {
+	svc port.EventStoreService
+}
+
+// NewEventStoreHandler creates a new EventStoreHandler instance
+func NewEventStoreHandler(svc port.EventStoreService) *EventStoreHandler {
+	return &EventStoreHandler{
+		svc,
+	}
+}
+
+// storedEventResponse represents a domain event recorded in the event store
+type storedEventResponse struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       string          `json:"type" example:"quote.created"`
+	Payload    json.RawMessage `json:"payload" swaggertype:"object"`
+	OccurredAt string          `json:"occurredAt" example:"2025-03-14T10:00:00Z"`
+	RecordedAt string          `json:"recordedAt" example:"2025-03-14T10:00:00Z"`
+}
+
+// newStoredEventResponse is a helper function to create a response body for handling stored event data
+func newStoredEventResponse(e *domain.StoredEvent) *storedEventResponse {
+	return &storedEventResponse{
+		ID:         e.ID,
+		Type:       string(e.Type),
+		Payload:    e.Payload,
+		OccurredAt: e.OccurredAt.Format(time.RFC3339),
+		RecordedAt: e.RecordedAt.Format(time.RFC3339),
+	}
+}
+
+// listEventsRequest represents the query for listing the event store
+type listEventsRequest struct {
+	pageRequest
+	Type      string `form:"type" binding:"omitempty,oneof=quote.created paymentProof.uploaded appointment.booked payment.reviewed" example:"quote.created"`
+	StartDate string `form:"startDate"`
+	EndDate   string `form:"endDate"`
+}
+
+// ListEvents godoc
+//
+// @Summary        List the stored events
+// @Description    List the domain events recorded in the event store in the order they occurred, optionally of a type and between dates
+// @Tags           Events
+// @Accept         json
+// @Produce        json
+// @Param          type       query   string false  "Event type" Enums(quote.created, paymentProof.uploaded, appointment.booked, payment.reviewed)
+// @Param          startDate  query   string false  "Occurred from (RFC3339)"
+// @Param          endDate    query   string false  "Occurred before (RFC3339)"
+// @Param          skip       query   uint64 false  "Page number, starting at 1"
+// @Param          limit      query   uint64 true   "Limit"
+// @Success        200        {object}  meta  "Events displayed"
+// @Failure        400        {object}  errorResponse  "Validation error"
+// @Failure        401        {object}  errorResponse  "Unauthorized error"
+// @Failure        403        {object}  errorResponse  "Forbidden error"
+// @Failure        500        {object}  errorResponse  "Internal server error"
+// @Router         /v1/events [get]
+// @Security       BearerAuth
+func (eh *EventStoreHandler) ListEvents(ctx *gin.Context) {
+	var req listEventsRequest
+	var eventsList []storedEventResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	filter := port.EventFilter{
+		Skip:  req.Skip,
+		Limit: req.Limit,
+	}
+	if req.Type != "" {
+		eventType := domain.EventType(req.Type)
+		filter.Type = &eventType
+	}
+	if req.StartDate != "" {
+		t, err := time.Parse(time.RFC3339, req.StartDate)
+		if err != nil {
+			validationError(ctx, newRequestError("startDate", "date"))
+			return
+		}
+		filter.StartDate = &t
+	}
+	if req.EndDate != "" {
+		t, err := time.Parse(time.RFC3339, req.EndDate)
+		if err != nil {
+			validationError(ctx, newRequestError("endDate", "date"))
+			return
+		}
+		filter.EndDate = &t
+	}
+
+	events, err := eh.svc.ListEvents(ctx, filter)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, event := range events {
+		eventsList = append(eventsList, *newStoredEventResponse(&event))
+	}
+
+	total := uint64(len(eventsList))
+	meta := newPageMeta(ctx, total, req.pageRequest)
+	rsp := toMap(meta, eventsList, "events")
+
+	handleSuccess(ctx, rsp)
+}
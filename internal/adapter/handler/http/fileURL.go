@@ -0,0 +1,27 @@
+package http
+
+import (
+	"log/slog"
+
+	"harajuku/backend/internal/core/port"
+)
+
+// fileURLs resolves the URL returned to clients for a stored file
+type fileURLs struct {
+	signer port.FileURLSigner
+}
+
+// url returns a signed URL for the file at path, or the path itself when signing is disabled or fails
+func (f fileURLs) url(path string) string {
+	if f.signer == nil || path == "" {
+		return path
+	}
+
+	signed, err := f.signer.SignURL(path)
+	if err != nil {
+		slog.Error("File URL signing failed", "path", path, "error", err)
+		return path
+	}
+
+	return signed
+}
@@ -0,0 +1,35 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GraphQLHandler represents the HTTP handler of the GraphQL gateway of the dashboard
+type GraphQLHandler struct {
+	server http.Handler
+}
+
+// NewGraphQLHandler creates a new GraphQLHandler instance, server is nil when the gateway is disabled
+func NewGraphQLHandler(server http.Handler) *GraphQLHandler {
+	return &GraphQLHandler{
+		server,
+	}
+}
+
+// Query godoc
+//
+// @Summary        Query the GraphQL gateway
+// @Description    Run a GraphQL query over quotes, appointments, availability slots and types of service, resolving a quote's images and appointment. Clients only see their own quotes and appointments. Only served when HTTP_GRAPHQL is true
+// @Tags           GraphQL
+// @Accept         json
+// @Produce        json
+// @Success        200  {object}  map[string]interface{}  "Query result, with the errors of the fields that failed"
+// @Failure        401  {object}  errorResponse  "Unauthorized error"
+// @Failure        404  {object}  errorResponse  "The gateway is disabled"
+// @Router         /graphql [post]
+// @Security       BearerAuth
+func (gh *GraphQLHandler) Query(ctx *gin.Context) {
+	gh.server.ServeHTTP(ctx.Writer, ctx.Request)
+}
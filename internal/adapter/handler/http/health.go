@@ -0,0 +1,139 @@
+package http
+
+import (
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler represents the HTTP handler for health checks
+type HealthHandler struct {
+	svc port.HealthService
+}
+
+// NewHealthHandler creates a new HealthHandler instance
+func NewHealthHandler(svc port.HealthService) *HealthHandler {
+	return &HealthHandler{
+		svc,
+	}
+}
+
+// poolStatsResponse represents the statistics of the connection pool
+type poolStatsResponse struct {
+	MaxConns          int32   `json:"maxConns" example:"10"`
+	TotalConns        int32   `json:"totalConns" example:"4"`
+	AcquiredConns     int32   `json:"acquiredConns" example:"1"`
+	IdleConns         int32   `json:"idleConns" example:"3"`
+	AcquireCount      int64   `json:"acquireCount" example:"1520"`
+	AcquireDurationMs float64 `json:"acquireDurationMs" example:"84.2"`
+	EmptyAcquires     int64   `json:"emptyAcquires" example:"12"`
+}
+
+// databaseHealthResponse represents the state of the database
+type databaseHealthResponse struct {
+	LatencyMs        float64            `json:"latencyMs" example:"0.8"`
+	Pool             *poolStatsResponse `json:"pool"`
+	MigrationVersion uint               `json:"migrationVersion" example:"42"`
+	MigrationDirty   bool               `json:"migrationDirty" example:"false"`
+}
+
+// newDatabaseHealthResponse is a helper function to create a response body for handling the database health
+func newDatabaseHealthResponse(h *domain.DatabaseHealth) *databaseHealthResponse {
+	rsp := &databaseHealthResponse{
+		LatencyMs:        float64(h.Latency.Microseconds()) / 1000,
+		MigrationVersion: h.MigrationVersion,
+		MigrationDirty:   h.MigrationDirty,
+	}
+
+	if h.Pool != nil {
+		rsp.Pool = &poolStatsResponse{
+			MaxConns:          h.Pool.MaxConns,
+			TotalConns:        h.Pool.TotalConns,
+			AcquiredConns:     h.Pool.AcquiredConns,
+			IdleConns:         h.Pool.IdleConns,
+			AcquireCount:      h.Pool.AcquireCount,
+			AcquireDurationMs: float64(h.Pool.AcquireDuration.Microseconds()) / 1000,
+			EmptyAcquires:     h.Pool.EmptyAcquires,
+		}
+	}
+
+	return rsp
+}
+
+// componentHealthResponse represents the outcome of checking a dependency
+type componentHealthResponse struct {
+	Name      string  `json:"name" example:"postgres"`
+	Healthy   bool    `json:"healthy" example:"true"`
+	LatencyMs float64 `json:"latencyMs" example:"1.2"`
+}
+
+// newComponentHealthResponse is a helper function to create a response body for handling the health of a dependency
+func newComponentHealthResponse(c *domain.ComponentHealth) *componentHealthResponse {
+	return &componentHealthResponse{
+		Name:      c.Name,
+		Healthy:   c.Healthy,
+		LatencyMs: float64(c.Latency.Microseconds()) / 1000,
+	}
+}
+
+// Liveness godoc
+//
+// @Summary        Liveness probe
+// @Description    Answer as long as the process is serving requests, without checking the dependencies so a database outage doesn't get the pods restarted
+// @Tags           Health
+// @Produce        json
+// @Success        200  {object}  response  "Alive"
+// @Router         /healthz [get]
+func (hh *HealthHandler) Liveness(ctx *gin.Context) {
+	handleSuccess(ctx, nil)
+}
+
+// Readiness godoc
+//
+// @Summary        Readiness probe
+// @Description    Check postgres, redis and the file storage can be reached, each within the readiness timeout
+// @Tags           Health
+// @Produce        json
+// @Success        200  {array}   componentHealthResponse  "Ready"
+// @Failure        503  {object}  errorResponse  "A dependency can't be reached"
+// @Router         /readyz [get]
+func (hh *HealthHandler) Readiness(ctx *gin.Context) {
+	components, err := hh.svc.Readiness(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := make([]componentHealthResponse, 0, len(components))
+	for _, component := range components {
+		rsp = append(rsp, *newComponentHealthResponse(&component))
+	}
+
+	handleSuccess(ctx, rsp)
+}
+
+// DatabaseHealth godoc
+//
+// @Summary        Check the database
+// @Description    Ping the database and report the latency, the connection pool statistics and the schema version. Meant for load balancers, which send the internal health token, and for admins
+// @Tags           Health
+// @Produce        json
+// @Param          X-Health-Token  header  string  false  "Internal health token"
+// @Success        200  {object}  databaseHealthResponse  "Database is healthy"
+// @Failure        401  {object}  errorResponse  "Unauthorized error"
+// @Failure        403  {object}  errorResponse  "Forbidden error"
+// @Failure        503  {object}  errorResponse  "Database is unavailable"
+// @Router         /healthz/db [get]
+// @Security       BearerAuth
+func (hh *HealthHandler) DatabaseHealth(ctx *gin.Context) {
+	health, err := hh.svc.DatabaseHealth(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newDatabaseHealthResponse(health)
+
+	handleSuccess(ctx, rsp)
+}
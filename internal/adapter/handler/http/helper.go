@@ -1,6 +1,9 @@
 package http
 
 import (
+	"mime"
+	"net/http"
+	"path/filepath"
 	"strconv"
 
 	"harajuku/backend/internal/core/domain"
@@ -19,6 +22,14 @@ func getAuthPayload(ctx *gin.Context, key string) *domain.TokenPayload {
 	return ctx.MustGet(key).(*domain.TokenPayload)
 }
 
+// parseCursor is a helper function to decode an optional pagination cursor, nil when there is none
+func parseCursor(token string) (*domain.Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	return domain.DecodeCursor(token)
+}
+
 // toMap is a helper function to add meta and data to a map
 func toMap(m meta, data any, key string) map[string]any {
 	return map[string]any{
@@ -26,3 +37,17 @@ func toMap(m meta, data any, key string) map[string]any {
 		key:    data,
 	}
 }
+
+// sendAttachment is a helper function to download a stored file, files uploaded before
+// their metadata was recorded fall back to their storage key and a sniffed content type
+func sendAttachment(ctx *gin.Context, data []byte, url, fileName, contentType string) {
+	if fileName == "" {
+		fileName = filepath.Base(url)
+	}
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	ctx.Data(http.StatusOK, contentType, data)
+}
@@ -0,0 +1,288 @@
+package http
+
+import (
+	"encoding/json"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// JobQueueHandler represents the HTTP handler for inspecting the background job queue
+type JobQueueHandler struct {
+	svc port.JobQueueService
+}
+
+// NewJobQueueHandler creates a new JobQueueHandler instance
+func NewJobQueueHandler(svc port.JobQueueService) *JobQueueHandler {
+	return &JobQueueHandler{
+		svc,
+	}
+}
+
+// jobResponse represents a background job
+type jobResponse struct {
+	ID            uuid.UUID `json:"id"`
+	Type          string    `json:"type" example:"build-report"`
+	Attempts      int       `json:"attempts" example:"5"`
+	LastError     string    `json:"lastError,omitempty" example:"failed to connect to the database"`
+	CreatedAt     string    `json:"createdAt" example:"2025-03-14T10:00:00Z"`
+	NextAttemptAt string    `json:"nextAttemptAt" example:"2025-03-14T10:05:00Z"`
+}
+
+// newJobResponse is a helper function to create a response body for handling job data
+func newJobResponse(j *domain.Job) *jobResponse {
+	return &jobResponse{
+		ID:            j.ID,
+		Type:          string(j.Type),
+		Attempts:      j.Attempts,
+		LastError:     j.LastError,
+		CreatedAt:     j.CreatedAt.Format(time.RFC3339),
+		NextAttemptAt: j.NextAttemptAt.Format(time.RFC3339),
+	}
+}
+
+// jobDetailResponse represents a background job with its payload and where it is
+type jobDetailResponse struct {
+	jobResponse
+	Status  domain.JobStatus `json:"status" example:"dead"`
+	Payload json.RawMessage  `json:"payload" swaggertype:"object"`
+}
+
+// newJobDetailResponse is a helper function to create a response body for handling job details
+func newJobDetailResponse(j *domain.Job, status domain.JobStatus) *jobDetailResponse {
+	return &jobDetailResponse{
+		*newJobResponse(j),
+		status,
+		j.Payload,
+	}
+}
+
+// jobID parses the job ID of the id path parameter
+func jobID(ctx *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// listQueuedJobsRequest represents the query for listing the queued jobs
+type listQueuedJobsRequest struct {
+	pageRequest
+}
+
+// ListQueued godoc
+//
+// @Summary        List queued jobs
+// @Description    List the background jobs waiting to run, including those deferred after a failed attempt, the next one due first
+// @Tags           Jobs
+// @Accept         json
+// @Produce        json
+// @Param          skip   query   uint64 false  "Page number, starting at 1"
+// @Param          limit  query   uint64 true   "Limit"
+// @Success        200    {object}  meta  "Queued jobs displayed"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        401    {object}  errorResponse  "Unauthorized error"
+// @Failure        403    {object}  errorResponse  "Forbidden error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/jobs [get]
+// @Security       BearerAuth
+func (jh *JobQueueHandler) ListQueued(ctx *gin.Context) {
+	var req listQueuedJobsRequest
+	var jobsList []jobResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	jobs, err := jh.svc.ListQueued(ctx, req.Skip, req.Limit)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, job := range jobs {
+		jobsList = append(jobsList, *newJobResponse(&job))
+	}
+
+	total := uint64(len(jobsList))
+	meta := newPageMeta(ctx, total, req.pageRequest)
+	rsp := toMap(meta, jobsList, "jobs")
+
+	handleSuccess(ctx, rsp)
+}
+
+// GetJob godoc
+//
+// @Summary        Get a job
+// @Description    Get a queued or failed background job with its payload and last error
+// @Tags           Jobs
+// @Accept         json
+// @Produce        json
+// @Param          id   path    string true   "Job ID"
+// @Success        200  {object}  jobDetailResponse  "Job displayed"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        401  {object}  errorResponse  "Unauthorized error"
+// @Failure        403  {object}  errorResponse  "Forbidden error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/jobs/{id} [get]
+// @Security       BearerAuth
+func (jh *JobQueueHandler) GetJob(ctx *gin.Context) {
+	id, ok := jobID(ctx)
+	if !ok {
+		return
+	}
+
+	job, status, err := jh.svc.GetJob(ctx, id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newJobDetailResponse(job, status)
+	handleSuccess(ctx, rsp)
+}
+
+// RetryJob godoc
+//
+// @Summary        Retry a job
+// @Description    Run a queued job right away instead of waiting for its next attempt, or put a failed one back in the queue with fresh attempts
+// @Tags           Jobs
+// @Accept         json
+// @Produce        json
+// @Param          id   path    string true   "Job ID"
+// @Success        200  {object}  jobResponse  "Job queued"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        401  {object}  errorResponse  "Unauthorized error"
+// @Failure        403  {object}  errorResponse  "Forbidden error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/jobs/{id}/retry [post]
+// @Security       BearerAuth
+func (jh *JobQueueHandler) RetryJob(ctx *gin.Context) {
+	id, ok := jobID(ctx)
+	if !ok {
+		return
+	}
+
+	job, err := jh.svc.RetryJob(ctx, id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newJobResponse(job)
+	handleSuccess(ctx, rsp)
+}
+
+// DiscardJob godoc
+//
+// @Summary        Discard a job
+// @Description    Delete a queued or failed background job for good, it never runs again
+// @Tags           Jobs
+// @Accept         json
+// @Produce        json
+// @Param          id   path    string true   "Job ID"
+// @Success        200  {object}  response  "Job discarded"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        401  {object}  errorResponse  "Unauthorized error"
+// @Failure        403  {object}  errorResponse  "Forbidden error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/jobs/{id} [delete]
+// @Security       BearerAuth
+func (jh *JobQueueHandler) DiscardJob(ctx *gin.Context) {
+	id, ok := jobID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := jh.svc.DiscardJob(ctx, id); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, nil)
+}
+
+// ListDeadLetters godoc
+//
+// @Summary        List failed jobs
+// @Description    List the background jobs moved to the dead-letter list after running out of attempts or being rejected, most recent first
+// @Tags           Jobs
+// @Accept         json
+// @Produce        json
+// @Param          skip   query   uint64 false  "Page number, starting at 1"
+// @Param          limit  query   uint64 true   "Limit"
+// @Success        200    {object}  meta  "Failed jobs displayed"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        401    {object}  errorResponse  "Unauthorized error"
+// @Failure        403    {object}  errorResponse  "Forbidden error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/jobs/deadletters [get]
+// @Security       BearerAuth
+func (jh *JobQueueHandler) ListDeadLetters(ctx *gin.Context) {
+	var req listDeadLettersRequest
+	var jobsList []jobResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	jobs, err := jh.svc.ListDeadLetters(ctx, req.Skip, req.Limit)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, job := range jobs {
+		jobsList = append(jobsList, *newJobResponse(&job))
+	}
+
+	total := uint64(len(jobsList))
+	meta := newPageMeta(ctx, total, req.pageRequest)
+	rsp := toMap(meta, jobsList, "jobs")
+
+	handleSuccess(ctx, rsp)
+}
+
+// RetryDeadLetter godoc
+//
+// @Summary        Retry a failed job
+// @Description    Put a job from the dead-letter list back in the queue with fresh attempts
+// @Tags           Jobs
+// @Accept         json
+// @Produce        json
+// @Param          id   query   string true   "Job ID"
+// @Success        200  {object}  jobResponse  "Job queued"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        401  {object}  errorResponse  "Unauthorized error"
+// @Failure        403  {object}  errorResponse  "Forbidden error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/jobs/deadletters/retry [post]
+// @Security       BearerAuth
+func (jh *JobQueueHandler) RetryDeadLetter(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	job, err := jh.svc.RetryDeadLetter(ctx, id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newJobResponse(job)
+	handleSuccess(ctx, rsp)
+}
@@ -0,0 +1,153 @@
+package http
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed locales
+var localeFiles embed.FS
+
+// fallbackLanguage is the language of the messages for requests that don't ask for one, it stays English
+// for the clients written against the previous messages
+const fallbackLanguage = domain.LanguageEnglish
+
+// supportedLanguages are the languages with a message catalog in locales
+var supportedLanguages = []domain.Language{domain.LanguageSpanish, domain.LanguageEnglish}
+
+// catalog maps message keys to their text in one language, texts may hold fmt verbs for the arguments given to translate
+type catalog map[string]string
+
+// catalogs holds the message catalog of every supported language, they are embedded so a broken one fails on startup
+var catalogs = mustLoadCatalogs()
+
+// mustLoadCatalogs parses the message catalogs and panics when one can't be read
+func mustLoadCatalogs() map[domain.Language]catalog {
+	catalogs := make(map[domain.Language]catalog, len(supportedLanguages))
+	for _, lang := range supportedLanguages {
+		data, err := localeFiles.ReadFile(fmt.Sprintf("locales/%s.json", lang))
+		if err != nil {
+			panic(err)
+		}
+
+		var c catalog
+		if err := json.Unmarshal(data, &c); err != nil {
+			panic(fmt.Errorf("failed to parse %s message catalog: %w", lang, err))
+		}
+		catalogs[lang] = c
+	}
+
+	return catalogs
+}
+
+// translate returns the message of key in lang formatted with args, falling back to the
+// fallback language and then to the key itself when the message is missing
+func translate(lang domain.Language, key string, args ...any) string {
+	message, ok := catalogs[lang][key]
+	if !ok {
+		message, ok = catalogs[fallbackLanguage][key]
+	}
+	if !ok {
+		return key
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// localize returns the message of key in the language of the request
+func localize(ctx *gin.Context, key string, args ...any) string {
+	return translate(requestLanguage(ctx), key, args...)
+}
+
+// requestLanguage returns the language the response is written in and sets it as the Content-Language
+// header. It is the first supported language of the Accept-Language header, otherwise the preferred
+// language of the authenticated user, otherwise the fallback language
+func requestLanguage(ctx *gin.Context) domain.Language {
+	lang, ok := parseAcceptLanguage(ctx.GetHeader("Accept-Language"))
+	if !ok {
+		lang = fallbackLanguage
+		if value, exists := ctx.Get(authorizationPayloadKey); exists {
+			if payload, isPayload := value.(*domain.TokenPayload); isPayload && payload.Language.IsValid() {
+				lang = payload.Language
+			}
+		}
+	}
+
+	ctx.Header("Content-Language", string(lang))
+	return lang
+}
+
+// parseAcceptLanguage returns the supported language the Accept-Language header prefers, by quality
+// and then by order. Regional tags like es-MX match their language
+func parseAcceptLanguage(header string) (domain.Language, bool) {
+	type candidate struct {
+		lang    domain.Language
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+
+		lang := domain.Language(base)
+		if !lang.IsValid() {
+			continue
+		}
+
+		quality := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality > 0 {
+			candidates = append(candidates, candidate{lang, quality})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	return candidates[0].lang, true
+}
+
+// requestError is an error a handler finds in a field of the request after binding it, its message
+// comes from the same catalog entries as the validation errors of the binding
+type requestError struct {
+	field string
+	key   string
+	args  []any
+}
+
+// newRequestError creates an error for field, key names its message among the field messages of the catalog
+func newRequestError(field, key string, args ...any) error {
+	return &requestError{field: field, key: key, args: args}
+}
+
+// Error returns the message of the error in the fallback language
+func (e *requestError) Error() string {
+	return e.message(fallbackLanguage)
+}
+
+// message returns the message of the error in lang
+func (e *requestError) message(lang domain.Language) string {
+	return fmt.Sprintf("%s %s", e.field, translate(lang, "field."+e.key, e.args...))
+}
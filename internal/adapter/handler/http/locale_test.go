@@ -0,0 +1,138 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogsHaveTheSameKeys(t *testing.T) {
+	fallback := catalogs[fallbackLanguage]
+	for _, lang := range supportedLanguages {
+		for key := range fallback {
+			assert.Contains(t, catalogs[lang], key, "%s catalog", lang)
+		}
+		for key := range catalogs[lang] {
+			assert.Contains(t, fallback, key, "%s catalog", lang)
+		}
+	}
+}
+
+func TestEveryErrorHasAMessage(t *testing.T) {
+	for err, code := range errorCodeMap {
+		message, ok := catalogs[fallbackLanguage]["error."+code]
+		if assert.True(t, ok, "missing message for %q", code) {
+			assert.Equal(t, err.Error(), message)
+		}
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   domain.Language
+		ok     bool
+	}{
+		{"", "", false},
+		{"es", domain.LanguageSpanish, true},
+		{"es-MX,es;q=0.9,en;q=0.8", domain.LanguageSpanish, true},
+		{"fr-FR, en;q=0.5, es;q=0.7", domain.LanguageSpanish, true},
+		{"EN-us", domain.LanguageEnglish, true},
+		{"es;q=0, en", domain.LanguageEnglish, true},
+		{"fr, de;q=0.9, *;q=0.1", "", false},
+	}
+
+	for _, tt := range tests {
+		lang, ok := parseAcceptLanguage(tt.header)
+		assert.Equal(t, tt.ok, ok, tt.header)
+		assert.Equal(t, tt.want, lang, tt.header)
+	}
+}
+
+func serveLocalized(t *testing.T, acceptLanguage string, payload *domain.TokenPayload, handler gin.HandlerFunc) (*httptest.ResponseRecorder, errorResponse) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(fieldName)
+	}
+
+	router := gin.New()
+	router.POST("/", func(ctx *gin.Context) {
+		if payload != nil {
+			ctx.Set(authorizationPayloadKey, payload)
+		}
+		handler(ctx)
+	})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"password": "123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	if acceptLanguage != "" {
+		req.Header.Set("Accept-Language", acceptLanguage)
+	}
+	router.ServeHTTP(rec, req)
+
+	var rsp errorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rsp))
+	return rec, rsp
+}
+
+func TestErrorsAreTranslated(t *testing.T) {
+	rec, rsp := serveLocalized(t, "es-MX", nil, func(ctx *gin.Context) {
+		handleError(ctx, domain.ErrDataNotFound)
+	})
+
+	assert.Equal(t, "es", rec.Header().Get("Content-Language"))
+	assert.Equal(t, "not_found", rsp.Code)
+	assert.Equal(t, "no se encontraron los datos", rsp.Message)
+}
+
+func TestValidationErrorsAreTranslated(t *testing.T) {
+	_, rsp := serveLocalized(t, "es", nil, func(ctx *gin.Context) {
+		var req loginRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			validationError(ctx, err)
+		}
+	})
+
+	assert.Contains(t, rsp.Fields, fieldError{Field: "email", Code: "required", Message: "es obligatorio"})
+
+	_, rsp = serveLocalized(t, "es", nil, func(ctx *gin.Context) {
+		validationError(ctx, newRequestError("endTime", "after", "startTime"))
+	})
+
+	assert.Equal(t, "validation_failed", rsp.Code)
+	assert.Equal(t, "endTime debe ser posterior a startTime", rsp.Message)
+	assert.Equal(t, []fieldError{{Field: "endTime", Code: "after", Message: "debe ser posterior a startTime"}}, rsp.Fields)
+}
+
+func TestLanguageFallsBackToTheUserPreference(t *testing.T) {
+	payload := &domain.TokenPayload{Language: domain.LanguageSpanish}
+
+	rec, rsp := serveLocalized(t, "", payload, func(ctx *gin.Context) {
+		handleError(ctx, domain.ErrForbidden)
+	})
+	assert.Equal(t, "es", rec.Header().Get("Content-Language"))
+	assert.Equal(t, "el usuario no tiene permiso para acceder al recurso", rsp.Message)
+
+	_, rsp = serveLocalized(t, "en", payload, func(ctx *gin.Context) {
+		handleError(ctx, domain.ErrForbidden)
+	})
+	assert.Equal(t, domain.ErrForbidden.Error(), rsp.Message)
+
+	rec, _ = serveLocalized(t, "", nil, func(ctx *gin.Context) {
+		handleError(ctx, domain.ErrForbidden)
+	})
+	assert.Equal(t, "en", rec.Header().Get("Content-Language"))
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LogLevelHandler represents the HTTP handler for changing the level of the logs
+type LogLevelHandler struct {
+	svc port.LogLevelService
+}
+
+// NewLogLevelHandler creates a new LogLevelHandler instance
+func NewLogLevelHandler(svc port.LogLevelService) *LogLevelHandler {
+	return &LogLevelHandler{
+		svc,
+	}
+}
+
+// logLevelResponse represents the level the server logs at
+type logLevelResponse struct {
+	Level   string  `json:"level" example:"debug"`
+	ResetAt *string `json:"resetAt,omitempty" example:"2025-03-14T10:30:00Z"`
+}
+
+// newLogLevelResponse is a helper function to create a response body for handling the log level
+func newLogLevelResponse(l *domain.LogLevel) logLevelResponse {
+	rsp := logLevelResponse{
+		Level: strings.ToLower(l.Level.String()),
+	}
+
+	if l.ResetAt != nil {
+		resetAt := l.ResetAt.Format(time.RFC3339)
+		rsp.ResetAt = &resetAt
+	}
+
+	return rsp
+}
+
+// GetLogLevel godoc
+//
+// @Summary        Get the log level
+// @Description    Get the level the server answering the request logs at, and when a temporary change ends
+// @Tags           Logs
+// @Accept         json
+// @Produce        json
+// @Success        200  {object}  logLevelResponse  "Log level displayed"
+// @Failure        401  {object}  errorResponse  "Unauthorized error"
+// @Failure        403  {object}  errorResponse  "Forbidden error"
+// @Router         /v1/logs/level [get]
+// @Security       BearerAuth
+func (lh *LogLevelHandler) GetLogLevel(ctx *gin.Context) {
+	level := lh.svc.GetLogLevel(ctx)
+
+	rsp := newLogLevelResponse(level)
+	handleSuccess(ctx, rsp)
+}
+
+// setLogLevelRequest represents the request body for changing the log level
+type setLogLevelRequest struct {
+	Level    string `json:"level" binding:"required,oneof=debug info warn error" example:"debug"`
+	Duration string `json:"duration" example:"30m"`
+}
+
+// SetLogLevel godoc
+//
+// @Summary        Change the log level
+// @Description    Change the level the server answering the request logs at, without a restart. With a duration the level goes back to the startup one once it passes. Every server keeps its own level. Only the admins of the default salon change it
+// @Tags           Logs
+// @Accept         json
+// @Produce        json
+// @Param          setLogLevelRequest  body      setLogLevelRequest  true  "Log level"
+// @Success        200                 {object}  logLevelResponse  "Log level changed"
+// @Failure        400                 {object}  errorResponse  "Validation error"
+// @Failure        401                 {object}  errorResponse  "Unauthorized error"
+// @Failure        403                 {object}  errorResponse  "Forbidden error"
+// @Router         /v1/logs/level [put]
+// @Security       BearerAuth
+func (lh *LogLevelHandler) SetLogLevel(ctx *gin.Context) {
+	var req setLogLevelRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	var duration time.Duration
+	if req.Duration != "" {
+		var err error
+		duration, err = time.ParseDuration(req.Duration)
+		if err != nil || duration <= 0 {
+			validationError(ctx, newRequestError("duration", "duration"))
+			return
+		}
+	}
+
+	var level slog.Level
+	_ = level.UnmarshalText([]byte(req.Level))
+
+	rsp := newLogLevelResponse(lh.svc.SetLogLevel(ctx, level, duration))
+	handleSuccess(ctx, rsp)
+}
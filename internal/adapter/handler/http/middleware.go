@@ -1,13 +1,18 @@
 package http
 
 import (
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"strings"
 
 	"harajuku/backend/internal/core/domain"
 	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
 
 	"github.com/gin-gonic/gin"
+	sloggin "github.com/samber/slog-gin"
 )
 
 const (
@@ -17,6 +22,8 @@ const (
 	authorizationType = "bearer"
 	// authorizationPayloadKey is the key for authorization payload in the context
 	authorizationPayloadKey = "authorization_payload"
+	// healthTokenHeaderKey is the key for the header internal callers send the health token in
+	healthTokenHeaderKey = "X-Health-Token"
 )
 
 // authMiddleware is a middleware to check if the user is authenticated
@@ -27,39 +34,85 @@ func authMiddleware(token port.TokenService) gin.HandlerFunc {
       return
     }
 
-		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
-
-		isEmpty := len(authorizationHeader) == 0
-		if isEmpty {
-			err := domain.ErrEmptyAuthorizationHeader
+		payload, err := verifyAuthorization(ctx, token)
+		if err != nil {
 			handleAbort(ctx, err)
 			return
 		}
 
-		fields := strings.Fields(authorizationHeader)
-		isValid := len(fields) == 2
-		if !isValid {
-			err := domain.ErrInvalidAuthorizationHeader
-			handleAbort(ctx, err)
-			return
+		setAuthPayload(ctx, payload)
+		ctx.Next()
+	}
+}
+
+// setAuthPayload stores the payload of the verified token for the handlers, makes the user the actor of the
+// changes the services audit, restricts the request to their salon and adds them to the logs of the request
+func setAuthPayload(ctx *gin.Context, payload *domain.TokenPayload) {
+	ctx.Set(authorizationPayloadKey, payload)
+	reqCtx := util.WithSalon(util.WithActor(ctx.Request.Context(), payload), payload.SalonID)
+	ctx.Request = ctx.Request.WithContext(util.WithLogAttrs(reqCtx, slog.String("user_id", payload.UserID.String()), slog.String("salon_id", payload.SalonID.String())))
+}
+
+// logContextMiddleware is a middleware that adds the request ID and the route to every log written while the
+// request is handled, services log with the request context so their logs can be told apart
+func logContextMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		attrs := []slog.Attr{slog.String("request_id", sloggin.GetRequestID(ctx))}
+		if route := ctx.FullPath(); route != "" {
+			attrs = append(attrs, slog.String("route", ctx.Request.Method+" "+route))
 		}
 
-		currentAuthorizationType := strings.ToLower(fields[0])
-		if currentAuthorizationType != authorizationType {
-			err := domain.ErrInvalidAuthorizationType
-			handleAbort(ctx, err)
+		ctx.Request = ctx.Request.WithContext(util.WithLogAttrs(ctx.Request.Context(), attrs...))
+		ctx.Next()
+	}
+}
+
+// verifyAuthorization verifies the bearer token of the authorization header and returns its payload
+func verifyAuthorization(ctx *gin.Context, token port.TokenService) (*domain.TokenPayload, error) {
+	authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
+
+	isEmpty := len(authorizationHeader) == 0
+	if isEmpty {
+		return nil, domain.ErrEmptyAuthorizationHeader
+	}
+
+	fields := strings.Fields(authorizationHeader)
+	isValid := len(fields) == 2
+	if !isValid {
+		return nil, domain.ErrInvalidAuthorizationHeader
+	}
+
+	currentAuthorizationType := strings.ToLower(fields[0])
+	if currentAuthorizationType != authorizationType {
+		return nil, domain.ErrInvalidAuthorizationType
+	}
+
+	accessToken := fields[1]
+	return token.VerifyToken(accessToken)
+}
+
+// internalOrAdminMiddleware is a middleware to let through internal callers such as load balancers,
+// which send the health token, and admins. Without a health token configured only admins get through
+func internalOrAdminMiddleware(token port.TokenService, healthToken string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		sent := ctx.GetHeader(healthTokenHeaderKey)
+		if healthToken != "" && subtle.ConstantTimeCompare([]byte(sent), []byte(healthToken)) == 1 {
+			ctx.Next()
 			return
 		}
 
-		accessToken := fields[1]
-		payload, err := token.VerifyToken(accessToken)
-
+		payload, err := verifyAuthorization(ctx, token)
 		if err != nil {
 			handleAbort(ctx, err)
 			return
 		}
 
-		ctx.Set(authorizationPayloadKey, payload)
+		if payload.Role != domain.Admin {
+			handleAbort(ctx, domain.ErrForbidden)
+			return
+		}
+
+		setAuthPayload(ctx, payload)
 		ctx.Next()
 	}
 }
@@ -89,3 +142,26 @@ func adminMiddleware() gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// salonManagerMiddleware is a middleware to check the admin belongs to the default salon, which manages
+// the settings shared by every salon
+func salonManagerMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !util.ManagesSalons(ctx.Request.Context()) {
+			handleAbort(ctx, domain.ErrForbidden)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// deprecatedMiddleware marks the responses of a deprecated route and links to the route replacing it,
+// e.g. "/v1/quotes/{id}" for "/v1/quotes?id=", so clients notice before the route is removed
+func deprecatedMiddleware(successor string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Header("Deprecation", "true")
+		ctx.Header("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", successor))
+		ctx.Next()
+	}
+}
@@ -0,0 +1,219 @@
+package http
+
+import (
+	"encoding/json"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// NotificationHandler represents the HTTP handler for a user's in-app notifications
+type NotificationHandler struct {
+	svc    port.NotificationService
+	events port.EventDispatcher
+}
+
+// NewNotificationHandler creates a new NotificationHandler instance
+func NewNotificationHandler(svc port.NotificationService, events port.EventDispatcher) *NotificationHandler {
+	return &NotificationHandler{
+		svc,
+		events,
+	}
+}
+
+// streamHeartbeat is how often an idle stream sends a comment so proxies keep the connection open
+const streamHeartbeat = 30 * time.Second
+
+// streamEventResponse represents a domain event pushed to the admin dashboards
+type streamEventResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Payload    any       `json:"payload" swaggertype:"object"`
+	OccurredAt string    `json:"occurredAt" example:"2025-03-14T10:00:00Z"`
+}
+
+// notificationResponse represents an in-app notification
+type notificationResponse struct {
+	ID        uuid.UUID            `json:"id"`
+	Type      domain.EmailTemplate `json:"type" example:"quote-state-changed"`
+	Payload   json.RawMessage      `json:"payload" swaggertype:"object"`
+	Read      bool                 `json:"read" example:"false"`
+	CreatedAt string               `json:"createdAt" example:"2025-03-14T10:00:00Z"`
+}
+
+// newNotificationResponse is a helper function to create a response body for handling notification data
+func newNotificationResponse(n *domain.Notification) *notificationResponse {
+	return &notificationResponse{
+		ID:        n.ID,
+		Type:      n.Type,
+		Payload:   n.Payload,
+		Read:      n.Read,
+		CreatedAt: n.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// listNotificationsRequest represents the query for listing the notifications of the authenticated user
+type listNotificationsRequest struct {
+	pageRequest
+	Unread bool `form:"unread"`
+}
+
+// ListNotifications godoc
+//
+// @Summary        List notifications
+// @Description    List the notifications of the authenticated user, newest first, along with how many are unread
+// @Tags           Notifications
+// @Accept         json
+// @Produce        json
+// @Param          skip    query   uint64 false  "Page number, starting at 1"
+// @Param          limit   query   uint64 true   "Limit"
+// @Param          unread  query   bool   false  "Only unread notifications"
+// @Success        200     {object}  meta  "Notifications displayed"
+// @Failure        400     {object}  errorResponse  "Validation error"
+// @Failure        401     {object}  errorResponse  "Unauthorized error"
+// @Failure        500     {object}  errorResponse  "Internal server error"
+// @Router         /v1/notifications [get]
+// @Security       BearerAuth
+func (nh *NotificationHandler) ListNotifications(ctx *gin.Context) {
+	var req listNotificationsRequest
+	var notificationsList []notificationResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	notifications, unread, err := nh.svc.ListNotifications(ctx, port.NotificationFilter{
+		UserID: authPayload.UserID,
+		Unread: req.Unread,
+		Skip:   req.Skip,
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, notification := range notifications {
+		notificationsList = append(notificationsList, *newNotificationResponse(&notification))
+	}
+
+	total := uint64(len(notificationsList))
+	meta := newPageMeta(ctx, total, req.pageRequest)
+	rsp := toMap(meta, notificationsList, "notifications")
+	rsp["unread"] = unread
+
+	handleSuccess(ctx, rsp)
+}
+
+// MarkNotificationAsRead godoc
+//
+// @Summary        Mark a notification as read
+// @Description    Mark one of the authenticated user's notifications as read
+// @Tags           Notifications
+// @Accept         json
+// @Produce        json
+// @Param          id   query   string true   "Notification ID"
+// @Success        200  {object}  response  "Notification marked as read"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        401  {object}  errorResponse  "Unauthorized error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/notifications/read [patch]
+// @Security       BearerAuth
+func (nh *NotificationHandler) MarkAsRead(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	err = nh.svc.MarkAsRead(ctx, authPayload.UserID, id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, nil)
+}
+
+// MarkAllNotificationsAsRead godoc
+//
+// @Summary        Mark every notification as read
+// @Description    Mark all the notifications of the authenticated user as read
+// @Tags           Notifications
+// @Accept         json
+// @Produce        json
+// @Success        200  {object}  response  "Notifications marked as read"
+// @Failure        401  {object}  errorResponse  "Unauthorized error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/notifications/read/all [patch]
+// @Security       BearerAuth
+func (nh *NotificationHandler) MarkAllAsRead(ctx *gin.Context) {
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	err := nh.svc.MarkAllAsRead(ctx, authPayload.UserID)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, nil)
+}
+
+// StreamNotifications godoc
+//
+// @Summary        Stream admin events
+// @Description    Push domain events of the salon of the admin, such as a new quote or an uploaded payment proof, to a connected admin dashboard as Server-Sent Events, the event name is its type
+// @Tags           Notifications
+// @Produce        text/event-stream
+// @Success        200  {object}  streamEventResponse  "Event stream"
+// @Failure        401  {object}  errorResponse  "Unauthorized error"
+// @Failure        403  {object}  errorResponse  "Forbidden error"
+// @Router         /v1/notifications/stream [get]
+// @Security       BearerAuth
+func (nh *NotificationHandler) StreamNotifications(ctx *gin.Context) {
+	events, unsubscribe := nh.events.Subscribe(domain.EventQuoteCreated, domain.EventPaymentProofUploaded)
+	defer unsubscribe()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	// Keep nginx from buffering the stream
+	ctx.Header("X-Accel-Buffering", "no")
+
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			// The events of other salons are skipped, the stream is kept open
+			if !util.InSalon(ctx.Request.Context(), &event) {
+				return true
+			}
+			ctx.SSEvent(string(event.Type), streamEventResponse{
+				ID:         event.ID,
+				Payload:    event.Payload,
+				OccurredAt: event.OccurredAt.Format(time.RFC3339),
+			})
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		}
+	})
+}
@@ -0,0 +1,22 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/swaggo/swag"
+)
+
+// openAPISpecPath is where the raw spec is served under /docs
+const openAPISpecPath = "/openapi.json"
+
+// serveOpenAPISpec sends the spec generated by swag from the handler annotations, run `task swag` after changing them
+func serveOpenAPISpec(ctx *gin.Context) {
+	doc, err := swag.ReadDoc()
+	if err != nil {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", []byte(doc))
+}
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	_ "harajuku/backend/docs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeOpenAPISpec(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/docs/openapi.json", serveOpenAPISpec)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var spec struct {
+		Paths map[string]interface{} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &spec))
+
+	// Every resource is documented, including the ones annotated last
+	for _, path := range []string{"/v1/quotes/{id}", "/v1/availabilityslots/{id}", "/v1/appointments/{id}/cancel", "/v1/paymentproofs/{id}", "/v1/quoteimages/all"} {
+		assert.Contains(t, spec.Paths, path)
+	}
+}
@@ -0,0 +1,116 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// pageRequest holds the pagination parameters every list route takes. skip is the page number
+// starting at 1, 0 is the first page as well
+type pageRequest struct {
+	Skip  uint64 `form:"skip" binding:"min=0" example:"1"`
+	Limit uint64 `form:"limit" binding:"required,min=5" example:"10"`
+}
+
+// page returns the page number of the request
+func (p pageRequest) page() uint64 {
+	if p.Skip == 0 {
+		return 1
+	}
+	return p.Skip
+}
+
+// cursorPageRequest adds the cursor of the lists ordered by time, which resume after the
+// next_cursor of the previous page instead of skipping pages
+type cursorPageRequest struct {
+	pageRequest
+	Cursor string `form:"cursor"`
+}
+
+// newPageMeta returns the meta of a page and sets its Link header (RFC 5988) with the first,
+// previous, next and last pages
+func newPageMeta(ctx *gin.Context, total uint64, req pageRequest) meta {
+	page := req.page()
+	m := meta{Total: total, Limit: req.Limit, Skip: page}
+
+	last := uint64(1)
+	if req.Limit > 0 && total > 0 {
+		last = (total + req.Limit - 1) / req.Limit
+	}
+
+	links := []pageLink{
+		{"first", pageURL(ctx, 1, "")},
+		{"last", pageURL(ctx, last, "")},
+	}
+	if page > 1 {
+		m.Prev = pageURL(ctx, page-1, "")
+		links = append(links, pageLink{"prev", m.Prev})
+	}
+	if page < last {
+		m.Next = pageURL(ctx, page+1, "")
+		links = append(links, pageLink{"next", m.Next})
+	}
+
+	setLinkHeader(ctx, links)
+	return m
+}
+
+// newCursorPageMeta returns the meta of a page of a list ordered by time, next is the cursor after
+// the page, nil on the last page. A page requested by cursor links to the next one by cursor, as a
+// cursor can't go back, and a page requested by skip links like any other list
+func newCursorPageMeta(ctx *gin.Context, total uint64, req cursorPageRequest, next *domain.Cursor) meta {
+	if req.Cursor == "" {
+		m := newPageMeta(ctx, total, req.pageRequest)
+		if next != nil {
+			m.NextCursor = next.Encode()
+		}
+		return m
+	}
+
+	m := meta{Total: total, Limit: req.Limit}
+	links := []pageLink{{"first", pageURL(ctx, 1, "")}}
+	if next != nil {
+		m.NextCursor = next.Encode()
+		m.Next = pageURL(ctx, 0, m.NextCursor)
+		links = append(links, pageLink{"next", m.Next})
+	}
+
+	setLinkHeader(ctx, links)
+	return m
+}
+
+// pageLink is a relation of the Link header
+type pageLink struct {
+	rel string
+	url string
+}
+
+// pageURL returns the URL of the request for another page, given by its number or, when cursor
+// isn't empty, by cursor. The other query parameters are kept so the filters carry over
+func pageURL(ctx *gin.Context, page uint64, cursor string) string {
+	query := ctx.Request.URL.Query()
+	if cursor != "" {
+		query.Del("skip")
+		query.Set("cursor", cursor)
+	} else {
+		query.Del("cursor")
+		query.Set("skip", strconv.FormatUint(page, 10))
+	}
+
+	return ctx.Request.URL.Path + "?" + query.Encode()
+}
+
+// setLinkHeader adds the links of the page to the Link header, after any link already set
+func setLinkHeader(ctx *gin.Context, links []pageLink) {
+	values := make([]string, 0, len(links))
+	for _, link := range links {
+		values = append(values, fmt.Sprintf(`<%s>; rel="%s"`, link.url, link.rel))
+	}
+
+	ctx.Writer.Header().Add("Link", strings.Join(values, ", "))
+}
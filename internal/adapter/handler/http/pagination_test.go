@@ -0,0 +1,98 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type listTestRequest struct {
+	cursorPageRequest
+	State string `form:"state"`
+}
+
+// newPaginatedRouter lists total items, handing out next as the cursor after every page
+func newPaginatedRouter(total uint64, next *domain.Cursor) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/v1/quotes/all", func(ctx *gin.Context) {
+		var req listTestRequest
+		if err := ctx.ShouldBindQuery(&req); err != nil {
+			validationError(ctx, err)
+			return
+		}
+
+		handleSuccess(ctx, newCursorPageMeta(ctx, total, req.cursorPageRequest, next))
+	})
+	return router
+}
+
+func getPage(t *testing.T, router *gin.Engine, query string) (*httptest.ResponseRecorder, meta) {
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/quotes/all?"+query, nil))
+
+	var rsp struct {
+		Data meta `json:"data"`
+	}
+	if rec.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rsp))
+	}
+	return rec, rsp.Data
+}
+
+func TestPageMetaLinksAdjacentPages(t *testing.T) {
+	rec, m := getPage(t, newPaginatedRouter(23, nil), "state=approved&limit=5&skip=2")
+
+	assert.Equal(t, meta{
+		Total: 23,
+		Limit: 5,
+		Skip:  2,
+		Next:  "/v1/quotes/all?limit=5&skip=3&state=approved",
+		Prev:  "/v1/quotes/all?limit=5&skip=1&state=approved",
+	}, m)
+	assert.Equal(t, `</v1/quotes/all?limit=5&skip=1&state=approved>; rel="first", `+
+		`</v1/quotes/all?limit=5&skip=5&state=approved>; rel="last", `+
+		`</v1/quotes/all?limit=5&skip=1&state=approved>; rel="prev", `+
+		`</v1/quotes/all?limit=5&skip=3&state=approved>; rel="next"`, rec.Header().Get("Link"))
+}
+
+func TestPageMetaStartsAtPageOne(t *testing.T) {
+	// skip=0 and no skip are the first page, like skip=1
+	for _, query := range []string{"limit=5", "limit=5&skip=0", "limit=5&skip=1"} {
+		_, m := getPage(t, newPaginatedRouter(7, nil), query)
+
+		assert.Equal(t, uint64(1), m.Skip, query)
+		assert.Empty(t, m.Prev, query)
+		assert.Equal(t, "/v1/quotes/all?limit=5&skip=2", m.Next, query)
+	}
+
+	_, m := getPage(t, newPaginatedRouter(7, nil), "limit=5&skip=2")
+	assert.Empty(t, m.Next)
+}
+
+func TestCursorPageMetaLinksNextCursor(t *testing.T) {
+	next := &domain.Cursor{Time: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC), ID: uuid.New()}
+	rec, m := getPage(t, newPaginatedRouter(23, next), "limit=5&cursor=abc")
+
+	assert.Equal(t, next.Encode(), m.NextCursor)
+	assert.Equal(t, "/v1/quotes/all?cursor="+next.Encode()+"&limit=5", m.Next)
+	assert.Empty(t, m.Prev)
+	assert.Equal(t, `</v1/quotes/all?limit=5&skip=1>; rel="first", <`+m.Next+`>; rel="next"`, rec.Header().Get("Link"))
+}
+
+func TestPageRequestRequiresLimit(t *testing.T) {
+	rec, _ := getPage(t, newPaginatedRouter(23, nil), "skip=1")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec, _ = getPage(t, newPaginatedRouter(23, nil), "limit=4")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
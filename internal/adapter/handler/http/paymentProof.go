@@ -1,10 +1,7 @@
 package http
 
 import (
-	"fmt"
-	"io"
 	"net/http"
-	"path/filepath"
 
 	"harajuku/backend/internal/core/domain"
 	"harajuku/backend/internal/core/port"
@@ -14,11 +11,13 @@ import (
 )
 
 type PaymentProofHandler struct {
-	svc port.PaymentProofService
+	svc  port.PaymentProofService
+	urls fileURLs
 }
 
-func NewPaymentProofHandler(svc port.PaymentProofService) *PaymentProofHandler {
-	return &PaymentProofHandler{svc: svc}
+// NewPaymentProofHandler crea una nueva instancia de PaymentProofHandler, signer puede ser nil para devolver las rutas sin firmar
+func NewPaymentProofHandler(svc port.PaymentProofService, signer port.FileURLSigner) *PaymentProofHandler {
+	return &PaymentProofHandler{svc: svc, urls: fileURLs{signer}}
 }
 
 // Request para creación
@@ -36,53 +35,69 @@ type updatePaymentProofRequest struct {
 	IsReviewed *bool  `json:"isReviewed"` // Opcional para actualizar estado
 }
 
+// Request para revisar un comprobante, el ID en el cuerpo solo se usa en la ruta obsoleta
+type reviewPaymentProofRequest struct {
+	ID         string `json:"id"`
+	IsReviewed *bool  `json:"is_reviewed" binding:"required"`
+}
+
 // Response común
 type paymentProofResponse struct {
-	ID         uuid.UUID `json:"id"`
-	QuoteID    uuid.UUID `json:"quoteId"`
-	URL        string    `json:"url"`
-	IsReviewed bool      `json:"isReviewed"`
+	ID          uuid.UUID `json:"id"`
+	QuoteID     uuid.UUID `json:"quoteId"`
+	URL         string    `json:"url"`
+	IsReviewed  bool      `json:"isReviewed"`
+	FileName    string    `json:"fileName" example:"comprobante.pdf"`
+	Size        int64     `json:"size" example:"204800"`
+	ContentType string    `json:"contentType" example:"application/pdf"`
+	Checksum    string    `json:"checksum"`
 }
 
-func newPaymentProofResponse(p *domain.PaymentProof) paymentProofResponse {
+func newPaymentProofResponse(p *domain.PaymentProof, urls fileURLs) paymentProofResponse {
 	return paymentProofResponse{
-		ID:         p.ID,
-		QuoteID:    p.QuoteID,
-		URL:        p.URL,
-		IsReviewed: p.IsReviewed,
+		ID:          p.ID,
+		QuoteID:     p.QuoteID,
+		URL:         urls.url(p.URL),
+		IsReviewed:  p.IsReviewed,
+		FileName:    p.FileName,
+		Size:        p.Size,
+		ContentType: p.ContentType,
+		Checksum:    p.Checksum,
 	}
 }
 
-// CreatePaymentProof crea un comprobante nuevo
+// CreatePaymentProof godoc
+//
+// @Summary        Upload a payment proof
+// @Description    Attach a payment receipt to a quote awaiting its payment
+// @Tags           PaymentProofs
+// @Accept         multipart/form-data
+// @Produce        json
+// @Security       BearerAuth
+// @Param          quoteId  formData  string  true  "Quote ID"
+// @Param          file     formData  file    true  "Receipt (PDF, JPEG, PNG, WebP)"
+// @Success        201      {object}  paymentProofResponse  "Payment proof created"
+// @Failure        400      {object}  errorResponse  "Validation error"
+// @Failure        404      {object}  errorResponse  "Quote not found"
+// @Failure        413      {object}  errorResponse  "File too large"
+// @Failure        415      {object}  errorResponse  "Unsupported file type"
+// @Failure        500      {object}  errorResponse  "Internal server error"
+// @Router         /v1/paymentproofs [post]
 func (h *PaymentProofHandler) CreatePaymentProof(ctx *gin.Context) {
-	// Parse multipart form
-	if err := ctx.Request.ParseMultipartForm(10 << 20); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to parse multipart form: " + err.Error()})
-		return
-	}
-
 	quoteIDStr := ctx.Request.FormValue("quoteId")
 	if quoteIDStr == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "quoteId is required"})
+		validationError(ctx, newRequestError("quoteId", "required"))
 		return
 	}
 
 	quoteID, err := uuid.Parse(quoteIDStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid quoteId format"})
+		validationError(ctx, newRequestError("quoteId", "uuid"))
 		return
 	}
 
-	file, fileHeader, err := ctx.Request.FormFile("file")
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "file is required: " + err.Error()})
-		return
-	}
-	defer file.Close()
-
-	fileBytes, err := io.ReadAll(file)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read file: " + err.Error()})
+	file, ok := formFile(ctx, "file")
+	if !ok {
 		return
 	}
 
@@ -92,26 +107,38 @@ func (h *PaymentProofHandler) CreatePaymentProof(ctx *gin.Context) {
 		IsReviewed: false, // default
 	}
 
-	created, err := h.svc.CreatePaymentProof(ctx, paymentProof, fileBytes, fileHeader.Filename)
+	created, err := h.svc.CreatePaymentProof(ctx, paymentProof, file, file.Size(), file.Name())
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleError(ctx, file.uploadError(err))
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, newPaymentProofResponse(created))
+	ctx.JSON(http.StatusCreated, newPaymentProofResponse(created, h.urls))
 }
 
-// GetPaymentProof obtiene un comprobante por ID y descarga su archivo
+// GetPaymentProofByID godoc
+//
+// @Summary        Download a payment proof
+// @Description    Download the file of a payment proof with its original name and content type
+// @Tags           PaymentProofs
+// @Produce        octet-stream
+// @Security       BearerAuth
+// @Param          id   path    string true   "Payment proof ID"
+// @Success        200  {file}    file           "Payment proof file"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/paymentproofs/{id} [get]
 func (h *PaymentProofHandler) GetPaymentProofByID(ctx *gin.Context) {
 	idStr := ctx.DefaultQuery("id", "")
 	if idStr == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID parameter is required"})
+		validationError(ctx, newRequestError("id", "required"))
 		return
 	}
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		validationError(ctx, newRequestError("id", "uuid"))
 		return
 	}
 
@@ -122,15 +149,26 @@ func (h *PaymentProofHandler) GetPaymentProofByID(ctx *gin.Context) {
 		return
 	}
 
-	// Detectar tipo MIME
-	mimeType := http.DetectContentType(fileData)
-
-	// Establecer headers para descarga
-	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filepath.Base(paymentProof.URL)))
-	ctx.Data(http.StatusOK, mimeType, fileData)
+	// Descargar con el nombre y tipo MIME originales
+	sendAttachment(ctx, fileData, paymentProof.URL, paymentProof.FileName, paymentProof.ContentType)
 }
 
-// ListPaymentProofs lista comprobantes con filtros opcionales: quoteId e isReviewed
+// GetPaymentProofs godoc
+//
+// @Summary        List payment proofs
+// @Description    List payment proofs with pagination, optionally filtered by quote and review state
+// @Tags           PaymentProofs
+// @Accept         json
+// @Produce        json
+// @Security       BearerAuth
+// @Param          quoteId    query   string false  "Quote ID"
+// @Param          isReviewed query   bool   false  "Review state"
+// @Param          skip       query   uint64 false  "Page number, starting at 1"
+// @Param          limit      query   uint64 true   "Limit"
+// @Success        200    {object}  meta  "Payment proofs displayed"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/paymentproofs/all [get]
 func (h *PaymentProofHandler) GetPaymentProofs(ctx *gin.Context) {
 	filter := port.PaymentProofFilter{}
 
@@ -138,7 +176,7 @@ func (h *PaymentProofHandler) GetPaymentProofs(ctx *gin.Context) {
 		if quoteID, err := uuid.Parse(quoteIDStr); err == nil {
 			filter.QuoteID = &quoteID
 		} else {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "quoteId inválido"})
+			validationError(ctx, newRequestError("quoteId", "uuid"))
 			return
 		}
 	}
@@ -151,66 +189,76 @@ func (h *PaymentProofHandler) GetPaymentProofs(ctx *gin.Context) {
 			val := false
 			filter.IsReviewed = &val
 		} else {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "isReviewed debe ser true o false"})
-			return
-		}
-	}
-
-	// Paginación opcional
-	if skipStr := ctx.Query("skip"); skipStr != "" {
-		var skip uint64
-		_, err := fmt.Sscan(skipStr, &skip)
-		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "skip inválido"})
+			validationError(ctx, newRequestError("isReviewed", "oneof", "true false"))
 			return
 		}
-		filter.Skip = skip
 	}
 
-	if limitStr := ctx.Query("limit"); limitStr != "" {
-		var limit uint64
-		_, err := fmt.Sscan(limitStr, &limit)
-		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "limit inválido"})
-			return
-		}
-		filter.Limit = limit
+	var page pageRequest
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		validationError(ctx, err)
+		return
 	}
+	filter.Skip = page.Skip
+	filter.Limit = page.Limit
 
-	paymentProofs, err := h.svc.GetPaymentProofs(ctx, filter)
+	paymentProofs, total, err := h.svc.GetPaymentProofs(ctx, filter)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleError(ctx, err)
 		return
 	}
 
-	var response []paymentProofResponse
+	response := make([]paymentProofResponse, 0, len(paymentProofs))
 	for _, p := range paymentProofs {
-		response = append(response, newPaymentProofResponse(&p))
+		response = append(response, newPaymentProofResponse(&p, h.urls))
 	}
 
-	ctx.JSON(http.StatusOK, response)
+	meta := newPageMeta(ctx, total, page)
+	handleSuccess(ctx, toMap(meta, response, "paymentProofs"))
 }
 
-// UpdatePaymentProof actualiza solo el campo IsReviewed de un comprobante existente
+// UpdatePaymentProof godoc
+//
+// @Summary        Review a payment proof
+// @Description    Mark a payment proof as reviewed or not
+// @Tags           PaymentProofs
+// @Accept         json
+// @Produce        json
+// @Security       BearerAuth
+// @Param          id     path    string true   "Payment proof ID"
+// @Param          review body    reviewPaymentProofRequest true   "Review"
+// @Success        200    {object}  paymentProofResponse  "Payment proof updated"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        403    {object}  errorResponse  "Forbidden error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/paymentproofs/{id} [put]
 func (h *PaymentProofHandler) UpdatePaymentProof(ctx *gin.Context) {
-	var req struct {
-		ID         string `json:"id" binding:"required"`
-		IsReviewed *bool  `json:"is_reviewed" binding:"required"`
-	}
+	var req reviewPaymentProofRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		validationError(ctx, err)
+		return
+	}
+
+	// El ID va en la ruta, el del cuerpo queda para la ruta obsoleta
+	idStr := ctx.Param("id")
+	if idStr == "" {
+		idStr = req.ID
+	}
+	if idStr == "" {
+		validationError(ctx, newRequestError("id", "required"))
 		return
 	}
 
-	id, err := uuid.Parse(req.ID)
+	id, err := uuid.Parse(idStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		validationError(ctx, newRequestError("id", "uuid"))
 		return
 	}
 
 	existing, _, err := h.svc.GetPaymentProofByID(ctx, id)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "No encontrado"})
+		handleError(ctx, err)
 		return
 	}
 
@@ -219,24 +267,38 @@ func (h *PaymentProofHandler) UpdatePaymentProof(ctx *gin.Context) {
 
 	updated, err := h.svc.UpdatePaymentProof(ctx, existing)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleError(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, newPaymentProofResponse(updated))
+	ctx.JSON(http.StatusOK, newPaymentProofResponse(updated, h.urls))
 }
 
-// DeletePaymentProof elimina un comprobante por ID
+// DeletePaymentProof godoc
+//
+// @Summary        Delete a payment proof
+// @Description    Delete a payment proof and its file by id
+// @Tags           PaymentProofs
+// @Accept         json
+// @Produce        json
+// @Security       BearerAuth
+// @Param          id   path    string true   "Payment proof ID"
+// @Success        200  {object}  response       "Payment proof deleted"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        403  {object}  errorResponse  "Forbidden error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/paymentproofs/{id} [delete]
 func (h *PaymentProofHandler) DeletePaymentProof(ctx *gin.Context) {
 	idStr := ctx.DefaultQuery("id", "")
 	if idStr == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID parameter is required"})
+		validationError(ctx, newRequestError("id", "required"))
 		return
 	}
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		validationError(ctx, fmt.Errorf("invalid id format"))
+		validationError(ctx, newRequestError("id", "uuid"))
 		return
 	}
 
@@ -245,5 +307,5 @@ func (h *PaymentProofHandler) DeletePaymentProof(ctx *gin.Context) {
 		return
 	}
 
-	handleSuccess(ctx, "Payment proof deleted successfully")
+	handleSuccess(ctx, localize(ctx, "message.payment_proof_deleted"))
 }
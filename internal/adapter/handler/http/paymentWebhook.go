@@ -0,0 +1,98 @@
+package http
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PaymentWebhookHandler represents the HTTP handler for payment provider callbacks
+type PaymentWebhookHandler struct {
+	svc port.PaymentWebhookService
+}
+
+// NewPaymentWebhookHandler creates a new PaymentWebhookHandler instance
+func NewPaymentWebhookHandler(svc port.PaymentWebhookService) *PaymentWebhookHandler {
+	return &PaymentWebhookHandler{
+		svc,
+	}
+}
+
+// paymentWebhookRequest represents the query of a payment callback
+type paymentWebhookRequest struct {
+	Provider string `form:"provider" binding:"required,oneof=stripe mercadopago bank" example:"stripe"`
+}
+
+// paymentEventResponse represents a payment event response body
+type paymentEventResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Provider   string    `json:"provider" example:"stripe"`
+	ExternalID string    `json:"externalId" example:"evt_1NqD2k"`
+	QuoteID    uuid.UUID `json:"quoteId"`
+	Amount     float64   `json:"amount" example:"350.5"`
+	Status     string    `json:"status" example:"succeeded"`
+	ReceivedAt string    `json:"receivedAt"`
+}
+
+// newPaymentEventResponse is a helper function to create a response body for handling payment event data
+func newPaymentEventResponse(e *domain.PaymentEvent) paymentEventResponse {
+	return paymentEventResponse{
+		ID:         e.ID,
+		Provider:   string(e.Provider),
+		ExternalID: e.ExternalID,
+		QuoteID:    e.QuoteID,
+		Amount:     e.Amount,
+		Status:     string(e.Status),
+		ReceivedAt: e.ReceivedAt.Format(time.RFC3339),
+	}
+}
+
+// HandlePaymentWebhook godoc
+//
+//	@Summary		Receive a payment provider callback
+//	@Description	Verify the provider signature, record the payment event and approve the quote once it is fully paid
+//	@Tags			Webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			provider	query		string					true	"Payment provider"	Enums(stripe, mercadopago, bank)
+//	@Success		200			{object}	paymentEventResponse	"Payment event recorded"
+//	@Failure		400			{object}	errorResponse			"Validation error"
+//	@Failure		401			{object}	errorResponse			"Invalid signature"
+//	@Failure		404			{object}	errorResponse			"Quote not found"
+//	@Failure		500			{object}	errorResponse			"Internal server error"
+//	@Router			/v1/webhooks/payments [post]
+func (ph *PaymentWebhookHandler) HandlePaymentWebhook(ctx *gin.Context) {
+	var req paymentWebhookRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	// The signature is computed over the raw body, so it must not be re-encoded
+	payload, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		handleError(ctx, domain.ErrInvalidPaymentEvent)
+		return
+	}
+
+	headers := make(map[string]string, len(ctx.Request.Header))
+	for key := range ctx.Request.Header {
+		headers[strings.ToLower(key)] = ctx.GetHeader(key)
+	}
+
+	event, err := ph.svc.HandlePaymentEvent(ctx, domain.PaymentProvider(req.Provider), headers, payload)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newPaymentEventResponse(event)
+
+	handleSuccess(ctx, rsp)
+}
@@ -0,0 +1,270 @@
+package http
+
+import (
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PromotionHandler represents the HTTP handler for promotion-related requests
+type PromotionHandler struct {
+	svc port.PromotionService
+}
+
+// NewPromotionHandler creates a new PromotionHandler instance
+func NewPromotionHandler(svc port.PromotionService) *PromotionHandler {
+	return &PromotionHandler{
+		svc,
+	}
+}
+
+// promotionResponse represents a promotion response body
+type promotionResponse struct {
+	ID               uuid.UUID   `json:"id"`
+	Name             string      `json:"name" example:"Buen Fin"`
+	DiscountType     string      `json:"discountType" example:"percentage"`
+	Value            float64     `json:"value" example:"15"`
+	Currency         string      `json:"currency,omitempty" example:"MXN"`
+	StartsAt         string      `json:"startsAt" example:"2025-11-14T00:00:00Z"`
+	EndsAt           string      `json:"endsAt" example:"2025-11-18T00:00:00Z"`
+	TypeOfServiceIDs []uuid.UUID `json:"typeOfServiceIds"`
+}
+
+// newPromotionResponse is a helper function to create a response body for handling promotion data
+func newPromotionResponse(p *domain.Promotion) *promotionResponse {
+	typeOfServiceIDs := p.TypeOfServiceIDs
+	if typeOfServiceIDs == nil {
+		typeOfServiceIDs = []uuid.UUID{}
+	}
+
+	return &promotionResponse{
+		ID:               p.ID,
+		Name:             p.Name,
+		DiscountType:     string(p.DiscountType),
+		Value:            p.Value,
+		Currency:         string(p.Currency),
+		StartsAt:         p.StartsAt.Format(time.RFC3339),
+		EndsAt:           p.EndsAt.Format(time.RFC3339),
+		TypeOfServiceIDs: typeOfServiceIDs,
+	}
+}
+
+// promotionRequest represents the request body for creating or updating a promotion.
+// An empty list of types of service makes the promotion apply to every service
+type promotionRequest struct {
+	Name             string      `json:"name" binding:"required" sanitize:"text" example:"Buen Fin"`
+	DiscountType     string      `json:"discountType" binding:"required,oneof=percentage fixed" example:"percentage"`
+	Value            float64     `json:"value" binding:"required,gt=0" example:"15"`
+	Currency         string      `json:"currency" binding:"required_if=DiscountType fixed,omitempty,len=3" example:"MXN"`
+	StartsAt         time.Time   `json:"startsAt" binding:"required" example:"2025-11-14T00:00:00Z"`
+	EndsAt           time.Time   `json:"endsAt" binding:"required" example:"2025-11-18T00:00:00Z"`
+	TypeOfServiceIDs []uuid.UUID `json:"typeOfServiceIds"`
+}
+
+// toDomain converts the request into a promotion, percentage discounts carry no currency
+func (req *promotionRequest) toDomain(id uuid.UUID) *domain.Promotion {
+	promotion := &domain.Promotion{
+		ID:               id,
+		Name:             req.Name,
+		DiscountType:     domain.DiscountType(req.DiscountType),
+		Value:            req.Value,
+		StartsAt:         req.StartsAt,
+		EndsAt:           req.EndsAt,
+		TypeOfServiceIDs: req.TypeOfServiceIDs,
+	}
+
+	if promotion.DiscountType == domain.DiscountFixed {
+		promotion.Currency = domain.Currency(strings.ToUpper(req.Currency))
+	}
+
+	return promotion
+}
+
+// CreatePromotion godoc
+//
+// @Summary        Register a new promotion
+// @Description    Create a percentage or fixed discount applied to quotes priced within its validity window
+// @Tags           Promotions
+// @Accept         json
+// @Produce        json
+// @Param          promotion body    promotionRequest true   "Promotion Data"
+// @Success        200    {object}  promotionResponse  "Promotion created"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        404    {object}  errorResponse  "Type of service not found"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/promotions [post]
+func (ph *PromotionHandler) CreatePromotion(ctx *gin.Context) {
+	var req promotionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	created, err := ph.svc.CreatePromotion(ctx, req.toDomain(uuid.New()))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newPromotionResponse(created)
+	handleSuccess(ctx, rsp)
+}
+
+// listPromotionsRequest represents the query for listing promotions
+type listPromotionsRequest struct {
+	pageRequest
+	TypeOfServiceID *string `form:"typeOfServiceId" binding:"omitempty,uuid"`
+	Active          bool    `form:"active"`
+}
+
+// ListPromotions godoc
+//
+// @Summary        List promotions
+// @Description    List promotions with pagination, optionally only the ones active now or applying to a type of service
+// @Tags           Promotions
+// @Accept         json
+// @Produce        json
+// @Param          typeOfServiceId query   string false  "Type of Service ID"
+// @Param          active query   bool   false  "Only promotions active now"
+// @Param          skip   query   uint64 false  "Page number, starting at 1"
+// @Param          limit  query   uint64 true   "Limit"
+// @Success        200    {object}  meta  "Promotions displayed"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/promotions/all [get]
+func (ph *PromotionHandler) ListPromotions(ctx *gin.Context) {
+	var req listPromotionsRequest
+	var promotionsList []promotionResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	filter := port.PromotionFilter{
+		TypeOfServiceID: parseOptionalUUID(req.TypeOfServiceID),
+		Skip:            req.Skip,
+		Limit:           req.Limit,
+	}
+
+	if req.Active {
+		now := time.Now()
+		filter.ActiveAt = &now
+	}
+
+	promotions, err := ph.svc.ListPromotions(ctx, filter)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, promotion := range promotions {
+		promotionsList = append(promotionsList, *newPromotionResponse(&promotion))
+	}
+
+	total := uint64(len(promotionsList))
+	meta := newPageMeta(ctx, total, req.pageRequest)
+	rsp := toMap(meta, promotionsList, "promotions")
+
+	handleSuccess(ctx, rsp)
+}
+
+// GetPromotion godoc
+//
+// @Summary        Get a promotion
+// @Description    Get a promotion by id
+// @Tags           Promotions
+// @Accept         json
+// @Produce        json
+// @Param          id   query   string true   "Promotion ID"
+// @Success        200  {object}  promotionResponse  "Promotion displayed"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/promotions [get]
+func (ph *PromotionHandler) GetPromotion(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	promotion, err := ph.svc.GetPromotion(ctx, id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newPromotionResponse(promotion)
+	handleSuccess(ctx, rsp)
+}
+
+// UpdatePromotion godoc
+//
+// @Summary        Update a promotion
+// @Description    Update a promotion by id, quotes already priced keep their discount
+// @Tags           Promotions
+// @Accept         json
+// @Produce        json
+// @Param          id        query   string           true   "Promotion ID"
+// @Param          promotion body    promotionRequest true   "Promotion Data"
+// @Success        200    {object}  promotionResponse  "Promotion updated"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/promotions [put]
+func (ph *PromotionHandler) UpdatePromotion(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	var req promotionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	updated, err := ph.svc.UpdatePromotion(ctx, req.toDomain(id))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newPromotionResponse(updated)
+	handleSuccess(ctx, rsp)
+}
+
+// DeletePromotion godoc
+//
+// @Summary        Delete a promotion
+// @Description    Delete a promotion, quotes priced with it keep their discount
+// @Tags           Promotions
+// @Accept         json
+// @Produce        json
+// @Param          id   query   string true   "Promotion ID"
+// @Success        200    {object}  string  "Promotion deleted successfully"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/promotions [delete]
+func (ph *PromotionHandler) DeletePromotion(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	err = ph.svc.DeletePromotion(ctx, id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, localize(ctx, "message.promotion_deleted"))
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+)
+
+// quotaMiddleware is a middleware that counts the request against the daily quota of the user's role for action,
+// it runs after the auth middleware. Requests the handler fails are given back to the user, and when the quotas
+// can't be counted the request is let through, like with the rate limits
+func quotaMiddleware(svc port.QuotaService, action domain.QuotaAction) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		value, ok := ctx.Get(authorizationPayloadKey)
+		if !ok || ctx.Request.Method == http.MethodOptions {
+			ctx.Next()
+			return
+		}
+		payload := value.(*domain.TokenPayload)
+
+		result, err := svc.Consume(ctx, payload.UserID, payload.Role, action)
+		if err != nil {
+			slog.ErrorContext(ctx, "Quota count failed, letting the request through", "action", action, "error", err)
+			ctx.Next()
+			return
+		}
+		if result == nil {
+			ctx.Next()
+			return
+		}
+
+		ctx.Header("X-Quota-Limit", strconv.Itoa(result.Limit))
+		ctx.Header("X-Quota-Remaining", strconv.Itoa(result.Remaining))
+		ctx.Header("X-Quota-Reset", result.ResetAt.Format(time.RFC3339))
+		if !result.Allowed {
+			ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(time.Until(result.ResetAt).Seconds()))))
+			handleAbort(ctx, domain.ErrQuotaExceeded)
+			return
+		}
+
+		ctx.Next()
+
+		if ctx.Writer.Status() >= http.StatusBadRequest {
+			_ = svc.Release(context.WithoutCancel(ctx.Request.Context()), payload.UserID, action)
+		}
+	}
+}
+
+// QuotaHandler represents the HTTP handler for managing the daily quotas of the roles
+type QuotaHandler struct {
+	svc port.QuotaService
+}
+
+// NewQuotaHandler creates a new QuotaHandler instance
+func NewQuotaHandler(svc port.QuotaService) *QuotaHandler {
+	return &QuotaHandler{
+		svc,
+	}
+}
+
+// quotaResponse represents the daily quota of a role for an action
+type quotaResponse struct {
+	Role   string `json:"role" example:"client"`
+	Action string `json:"action" example:"quote_creation"`
+	Limit  int    `json:"limit" example:"10"`
+}
+
+// newQuotaResponse is a helper function to create a response body for handling quota data
+func newQuotaResponse(q *domain.Quota) quotaResponse {
+	return quotaResponse{
+		Role:   string(q.Role),
+		Action: string(q.Action),
+		Limit:  q.Limit,
+	}
+}
+
+// ListQuotas godoc
+//
+// @Summary        List the daily quotas
+// @Description    List how many quotes and file uploads the users of each role may make per day, 0 is no limit
+// @Tags           Quotas
+// @Accept         json
+// @Produce        json
+// @Success        200  {array}   quotaResponse  "Quotas displayed"
+// @Failure        401  {object}  errorResponse  "Unauthorized error"
+// @Failure        403  {object}  errorResponse  "Forbidden error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/quotas [get]
+// @Security       BearerAuth
+func (qh *QuotaHandler) ListQuotas(ctx *gin.Context) {
+	quotas, err := qh.svc.ListQuotas(ctx)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := make([]quotaResponse, 0, len(quotas))
+	for _, quota := range quotas {
+		rsp = append(rsp, newQuotaResponse(&quota))
+	}
+
+	handleSuccess(ctx, rsp)
+}
+
+// setQuotaRequest represents the request body for changing a daily quota
+type setQuotaRequest struct {
+	Role   domain.UserRole `json:"role" binding:"required,user_role" example:"client"`
+	Action string          `json:"action" binding:"required,oneof=quote_creation file_upload" example:"quote_creation"`
+	Limit  int             `json:"limit" binding:"min=0" example:"10"`
+}
+
+// SetQuota godoc
+//
+// @Summary        Change a daily quota
+// @Description    Change how many times a day the users of a role may perform an action, 0 removes the limit. It applies to the counts of the day already started. Quotas are shared by every salon, so only the admins of the default salon change them
+// @Tags           Quotas
+// @Accept         json
+// @Produce        json
+// @Param          setQuotaRequest  body      setQuotaRequest  true  "Quota"
+// @Success        200              {object}  quotaResponse  "Quota changed"
+// @Failure        400              {object}  errorResponse  "Validation error"
+// @Failure        401              {object}  errorResponse  "Unauthorized error"
+// @Failure        403              {object}  errorResponse  "Forbidden error"
+// @Failure        500              {object}  errorResponse  "Internal server error"
+// @Router         /v1/quotas [put]
+// @Security       BearerAuth
+func (qh *QuotaHandler) SetQuota(ctx *gin.Context) {
+	var req setQuotaRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	quota := domain.Quota{
+		Role:   req.Role,
+		Action: domain.QuotaAction(req.Action),
+		Limit:  req.Limit,
+	}
+
+	if err := qh.svc.SetQuota(ctx, &quota); err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newQuotaResponse(&quota)
+	handleSuccess(ctx, rsp)
+}
@@ -0,0 +1,153 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingQuotas allows the first actions of every user and records what was consumed and released
+type countingQuotas struct {
+	limit    int
+	consumed map[domain.QuotaAction]int
+	released int
+	err      error
+}
+
+func (q *countingQuotas) Consume(ctx context.Context, userID uuid.UUID, role domain.UserRole, action domain.QuotaAction) (*domain.QuotaResult, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	if q.limit == 0 {
+		return nil, nil
+	}
+
+	resetAt := domain.QuotaResetAt(time.Now())
+	if q.consumed[action] >= q.limit {
+		return &domain.QuotaResult{Limit: q.limit, ResetAt: resetAt}, nil
+	}
+	q.consumed[action]++
+	return &domain.QuotaResult{Allowed: true, Limit: q.limit, Remaining: q.limit - q.consumed[action], ResetAt: resetAt}, nil
+}
+
+func (q *countingQuotas) Release(ctx context.Context, userID uuid.UUID, action domain.QuotaAction) error {
+	q.consumed[action]--
+	q.released++
+	return nil
+}
+
+func (q *countingQuotas) ListQuotas(ctx context.Context) ([]domain.Quota, error) {
+	return nil, nil
+}
+
+func (q *countingQuotas) SetQuota(ctx context.Context, quota *domain.Quota) error {
+	return nil
+}
+
+func newQuotaRouter(quotas *countingQuotas, status int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	payload := &domain.TokenPayload{UserID: uuid.New(), Role: domain.Client}
+
+	router.POST("/quotes", func(ctx *gin.Context) {
+		ctx.Set(authorizationPayloadKey, payload)
+		ctx.Next()
+	}, quotaMiddleware(quotas, domain.QuotaQuoteCreation), func(ctx *gin.Context) {
+		ctx.Status(status)
+	})
+
+	return router
+}
+
+func TestQuotaMiddlewareRejectsOverTheQuota(t *testing.T) {
+	quotas := &countingQuotas{limit: 2, consumed: map[domain.QuotaAction]int{}}
+	router := newQuotaRouter(quotas, http.StatusOK)
+
+	var codes []int
+	var rec *httptest.ResponseRecorder
+	for i := 0; i < 3; i++ {
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/quotes", nil))
+		codes = append(codes, rec.Code)
+	}
+
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+	assert.Equal(t, "2", rec.Header().Get("X-Quota-Limit"))
+	assert.Equal(t, "0", rec.Header().Get("X-Quota-Remaining"))
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	var body errorResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "quota_exceeded", body.Code)
+}
+
+func TestQuotaMiddlewareReleasesFailedRequests(t *testing.T) {
+	quotas := &countingQuotas{limit: 1, consumed: map[domain.QuotaAction]int{}}
+	router := newQuotaRouter(quotas, http.StatusBadRequest)
+
+	for i := 0; i < 3; i++ {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/quotes", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+
+	assert.Equal(t, 3, quotas.released)
+	assert.Equal(t, 0, quotas.consumed[domain.QuotaQuoteCreation])
+}
+
+func TestQuotaMiddlewareLetsThroughWithoutQuota(t *testing.T) {
+	tests := map[string]*countingQuotas{
+		"no limit":    {consumed: map[domain.QuotaAction]int{}},
+		"store fails": {limit: 1, consumed: map[domain.QuotaAction]int{}, err: errors.New("redis down")},
+	}
+
+	for name, quotas := range tests {
+		t.Run(name, func(t *testing.T) {
+			router := newQuotaRouter(quotas, http.StatusOK)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/quotes", nil))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Empty(t, rec.Header().Get("X-Quota-Limit"))
+		})
+	}
+}
+
+func TestSalonManagerMiddleware(t *testing.T) {
+	tests := map[string]struct {
+		salon uuid.UUID
+		code  int
+	}{
+		"default salon": {domain.DefaultSalonID, http.StatusOK},
+		"other salon":   {uuid.New(), http.StatusForbidden},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.PUT("/quotas", func(ctx *gin.Context) {
+				setAuthPayload(ctx, &domain.TokenPayload{UserID: uuid.New(), Role: domain.Admin, SalonID: tt.salon})
+				ctx.Next()
+			}, salonManagerMiddleware(), func(ctx *gin.Context) {
+				ctx.Status(http.StatusOK)
+			})
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/quotas", nil))
+
+			assert.Equal(t, tt.code, rec.Code)
+		})
+	}
+}
@@ -1,11 +1,8 @@
 package http
 
 import (
-	"fmt"
 	"harajuku/backend/internal/core/domain"
 	"harajuku/backend/internal/core/port"
-	"io"
-	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -14,25 +11,34 @@ import (
 
 // QuoteHandler representa el controlador HTTP para las solicitudes relacionadas con cotizaciones
 type QuoteHandler struct {
-	svc port.QuoteService
+	svc  port.QuoteService
+	urls fileURLs
 }
 
-// NewQuoteHandler crea una nueva instancia de QuoteHandler
-func NewQuoteHandler(svc port.QuoteService) *QuoteHandler {
+// NewQuoteHandler crea una nueva instancia de QuoteHandler, signer puede ser nil para devolver las rutas sin firmar
+func NewQuoteHandler(svc port.QuoteService, signer port.FileURLSigner) *QuoteHandler {
 	return &QuoteHandler{
 		svc,
+		fileURLs{signer},
 	}
 }
 
 // quoteResponse representa la respuesta
 type quoteResponse struct {
-	ID              uuid.UUID `json:"id"`
-	TypeOfServiceID uuid.UUID `json:"typeOfServiceID"`
-	ClientID        uuid.UUID `json:"clientID"`
-	Description     string    `json:"description"`
-	State           string    `json:"state"`
-	Price           float64   `json:"price"`
-	Time            string    `json:"time"`
+	ID              uuid.UUID              `json:"id"`
+	TypeOfServiceID uuid.UUID              `json:"typeOfServiceID"`
+	ClientID        uuid.UUID              `json:"clientID"`
+	Description     string                 `json:"description"`
+	State           string                 `json:"state"`
+	Price           float64                `json:"price"`
+	Currency        string                 `json:"currency"`
+	CurrencyFormat  currencyFormatResponse `json:"currencyFormat"`
+	Discount        *quoteDiscountResponse `json:"discount"`
+	Coupon          *quoteCouponResponse   `json:"coupon"`
+	Total           float64                `json:"total"`
+	Time            string                 `json:"time"`
+	DeletedAt       *time.Time             `json:"deletedAt,omitempty"`
+	Version         int                    `json:"version"`
 }
 
 // newQuoteResponse convierte un objeto domain. Quote en una respuesta de cotización
@@ -44,39 +50,88 @@ func newQuoteResponse(q *domain.Quote) *quoteResponse {
 		Description:     q.Description,
 		State:           string(q.State),
 		Price:           q.Price,
+		Currency:        string(q.Currency),
+		CurrencyFormat:  newCurrencyFormatResponse(q.Currency),
+		Discount:        newQuoteDiscountResponse(q),
+		Coupon:          newQuoteCouponResponse(q),
+		Total:           q.Total(),
 		Time:            q.Time.Format(time.RFC3339),
+		DeletedAt:       q.DeletedAt,
+		Version:         q.Version,
+	}
+}
+
+// quoteDiscountResponse representa la línea de descuento de una cotización
+type quoteDiscountResponse struct {
+	PromotionID *uuid.UUID `json:"promotionId"`
+	Amount      float64    `json:"amount"`
+}
+
+// newQuoteDiscountResponse devuelve la línea de descuento, o nil si la cotización no tiene descuento
+func newQuoteDiscountResponse(q *domain.Quote) *quoteDiscountResponse {
+	if q.Discount == 0 {
+		return nil
+	}
+
+	return &quoteDiscountResponse{
+		PromotionID: q.PromotionID,
+		Amount:      q.Discount,
+	}
+}
+
+// quoteCouponResponse representa la línea del cupón aplicado a una cotización
+type quoteCouponResponse struct {
+	CouponID uuid.UUID `json:"couponId"`
+	Amount   float64   `json:"amount"`
+}
+
+// newQuoteCouponResponse devuelve la línea del cupón, o nil si la cotización no tiene cupón
+func newQuoteCouponResponse(q *domain.Quote) *quoteCouponResponse {
+	if q.CouponID == nil {
+		return nil
+	}
+
+	return &quoteCouponResponse{
+		CouponID: *q.CouponID,
+		Amount:   q.CouponDiscount,
 	}
 }
 
 // quoteResponse representa la respuesta
 type quoteImageResponse struct {
-	ID      uuid.UUID `json:"id"`
-	QuoteID uuid.UUID `json:"quoteId"`
-	URL     string    `json:"url"`
+	ID          uuid.UUID `json:"id"`
+	QuoteID     uuid.UUID `json:"quoteId"`
+	URL         string    `json:"url"`
+	FileName    string    `json:"fileName" example:"referencia.jpg"`
+	Size        int64     `json:"size" example:"204800"`
+	ContentType string    `json:"contentType" example:"image/jpeg"`
+	Checksum    string    `json:"checksum"`
 }
 
 // quoteResponse representa la respuesta
 type quoteResponseWithImages struct {
-	ID              uuid.UUID            `json:"id"`
-	TypeOfServiceID uuid.UUID            `json:"typeOfServiceID"`
-	ClientID        uuid.UUID            `json:"clientID"`
-	Description     string               `json:"description"`
-	State           string               `json:"state"`
-	Price           float64              `json:"price"`
-	Time            string               `json:"time"`
-	Images          []quoteImageResponse `json:"images"`
+	ID              uuid.UUID              `json:"id"`
+	TypeOfServiceID uuid.UUID              `json:"typeOfServiceID"`
+	ClientID        uuid.UUID              `json:"clientID"`
+	Description     string                 `json:"description"`
+	State           string                 `json:"state"`
+	Price           float64                `json:"price"`
+	Currency        string                 `json:"currency"`
+	CurrencyFormat  currencyFormatResponse `json:"currencyFormat"`
+	Discount        *quoteDiscountResponse `json:"discount"`
+	Coupon          *quoteCouponResponse   `json:"coupon"`
+	Total           float64                `json:"total"`
+	Time            string                 `json:"time"`
+	Version         int                    `json:"version"`
+	Images          []quoteImageResponse   `json:"images"`
 }
 
 // newQuoteResponse convierte un objeto domain. Quote en una respuesta de cotización
-func newQuoteResponseWithImages(q *domain.Quote, images []domain.QuoteImage) *quoteResponseWithImages {
+func newQuoteResponseWithImages(q *domain.Quote, images []domain.QuoteImage, urls fileURLs) *quoteResponseWithImages {
 	// build the slice of image responses
 	respImgs := make([]quoteImageResponse, len(images))
 	for i, img := range images {
-		respImgs[i] = quoteImageResponse{
-			ID:      img.ID,
-			QuoteID: img.QuoteID,
-			URL:     img.URL,
-		}
+		respImgs[i] = newQuoteImageResponse(&img, urls)
 	}
 
 	return &quoteResponseWithImages{
@@ -86,7 +141,13 @@ func newQuoteResponseWithImages(q *domain.Quote, images []domain.QuoteImage) *qu
 		Description:     q.Description,
 		State:           string(q.State),
 		Price:           q.Price,
+		Currency:        string(q.Currency),
+		CurrencyFormat:  newCurrencyFormatResponse(q.Currency),
+		Discount:        newQuoteDiscountResponse(q),
+		Coupon:          newQuoteCouponResponse(q),
+		Total:           q.Total(),
 		Time:            q.Time.Format(time.RFC3339),
+		Version:         q.Version,
 		Images:          respImgs,
 	}
 }
@@ -94,7 +155,7 @@ func newQuoteResponseWithImages(q *domain.Quote, images []domain.QuoteImage) *qu
 // createQuoteRequest representa el cuerpo de la solicitud para crear una cotización
 type createQuoteRequest struct {
 	TypeOfServiceID string `form:"typeOfServiceID" binding:"required"`
-	Description     string `form:"description" binding:"required"`
+	Description     string `form:"description" binding:"required" sanitize:"text"`
 }
 
 // CreateQuote godoc
@@ -106,11 +167,14 @@ type createQuoteRequest struct {
 // @Produce        json
 // @Param          typeOfServiceID  formData  string  true  "Type of Service ID (UUID format)"
 // @Param          description      formData  string  true  "Description"
-// @Param          file             formData  file    true  "Attachment file"
+// @Param          file             formData  file    true  "Image (JPEG, PNG, WebP) or video (MP4, WebM) up to 500 MB"
 // @Success        200              {object}  quoteResponse  "Quote created"
 // @Failure        400              {object}  errorResponse  "Validation error"
+// @Failure        413              {object}  errorResponse  "File too large"
+// @Failure        415              {object}  errorResponse  "Unsupported file type"
+// @Failure        422              {object}  errorResponse  "File rejected by the antivirus"
 // @Failure        500              {object}  errorResponse  "Internal server error"
-// @Router         /quotes [post]
+// @Router         /v1/quotes [post]
 func (qh *QuoteHandler) CreateQuote(ctx *gin.Context) {
 	// Get user ID from auth token
 	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
@@ -122,12 +186,6 @@ func (qh *QuoteHandler) CreateQuote(ctx *gin.Context) {
 
 	userID := authPayload.UserID
 
-	// Parse multipart form
-	if err := ctx.Request.ParseMultipartForm(10 << 20); err != nil {
-		validationError(ctx, fmt.Errorf("failed to parse multipart form: %v", err))
-		return
-	}
-
 	// Bind the form data
 	var req createQuoteRequest
 	if err := ctx.ShouldBind(&req); err != nil {
@@ -138,21 +196,13 @@ func (qh *QuoteHandler) CreateQuote(ctx *gin.Context) {
 	// Parse UUIDs
 	typeOfServiceID, err := uuid.Parse(req.TypeOfServiceID)
 	if err != nil {
-		validationError(ctx, fmt.Errorf("invalid typeOfServiceID format"))
+		validationError(ctx, newRequestError("typeOfServiceID", "uuid"))
 		return
 	}
 
 	// Get the file
-	file, fileHeader, err := ctx.Request.FormFile("file")
-	if err != nil {
-		validationError(ctx, fmt.Errorf("file is required: %v", err))
-		return
-	}
-	defer file.Close()
-
-	fileBytes, err := io.ReadAll(file)
-	if err != nil {
-		handleError(ctx, fmt.Errorf("failed to read file: %v", err))
+	file, ok := formFile(ctx, "file")
+	if !ok {
 		return
 	}
 
@@ -166,9 +216,9 @@ func (qh *QuoteHandler) CreateQuote(ctx *gin.Context) {
 		Price:           0,
 	}
 
-	createdQuote, err := qh.svc.CreateQuote(ctx, &quote, fileBytes, fileHeader.Filename)
+	createdQuote, err := qh.svc.CreateQuote(ctx, &quote, file, file.Size(), file.Name())
 	if err != nil {
-		handleError(ctx, err)
+		handleError(ctx, file.uploadError(err))
 		return
 	}
 
@@ -178,28 +228,30 @@ func (qh *QuoteHandler) CreateQuote(ctx *gin.Context) {
 
 // listQuotesRequest representa los parámetros de la consulta para listar cotizaciones
 type listQuotesRequest struct {
+	cursorPageRequest
 	TypeOfServiceID *string `form:"typeOfServiceId"`
 	ClientID        *string `form:"clientId"`
 	StartDate       *string `form:"startDate"`
 	EndDate         *string `form:"endDate"`
 	State           *string `form:"state"`
-	Skip            uint64  `form:"skip" binding:"required,min=0"`
-	Limit           uint64  `form:"limit" binding:"required,min=5"`
+	IncludeDeleted  bool    `form:"includeDeleted"`
 }
 
 // ListQuotes godoc
 //
 //	@Summary		List quotes
-//	@Description	List quotes with pagination
+//	@Description	List quotes ordered by time, paginated with skip or with the next_cursor of the previous page
 //	@Tags			Quotes
 //	@Accept			json
 //	@Produce		json
-//	@Param			skip	query		uint64			true	"Skip"
+//	@Param			skip	query		uint64			false	"Page number, starting at 1, ignored with cursor"
 //	@Param			limit	query		uint64			true	"Limit"
+//	@Param			cursor	query		string			false	"Cursor"
+//	@Param			includeDeleted	query	bool	false	"Include soft-deleted quotes (admin only)"
 //	@Success		200		{object}	meta			"Quotes displayed"
 //	@Failure		400		{object}	errorResponse	"Validation error"
 //	@Failure		500		{object}	errorResponse	"Internal server error"
-//	@Router			/quotes [get]
+//	@Router			/v1/quotes/all [get]
 func (qh *QuoteHandler) ListQuotes(ctx *gin.Context) {
 	var req listQuotesRequest
 	var quotesList []quoteResponse
@@ -217,12 +269,18 @@ func (qh *QuoteHandler) ListQuotes(ctx *gin.Context) {
 		return
 	}
 
+	// Deleted quotes are only listed for admins
+	if req.IncludeDeleted && authPayload.Role != domain.Admin {
+		handleError(ctx, domain.ErrForbidden)
+		return
+	}
+
 	var typeOfServiceId *uuid.UUID
 
 	if req.TypeOfServiceID != nil {
 		id, err := uuid.Parse(*req.TypeOfServiceID)
 		if err != nil {
-			validationError(ctx, fmt.Errorf("invalid typeOfServiceId: %v", err))
+			validationError(ctx, newRequestError("typeOfServiceId", "uuid"))
 			return
 		}
 		typeOfServiceId = &id
@@ -233,7 +291,7 @@ func (qh *QuoteHandler) ListQuotes(ctx *gin.Context) {
 	if req.ClientID != nil {
 		id, err := uuid.Parse(*req.ClientID)
 		if err != nil {
-			validationError(ctx, fmt.Errorf("invalid clientId: %v", err))
+			validationError(ctx, newRequestError("clientId", "uuid"))
 			return
 		}
 		clientId = &id
@@ -248,7 +306,7 @@ func (qh *QuoteHandler) ListQuotes(ctx *gin.Context) {
 	if req.StartDate != nil {
 		t, err := time.Parse(time.RFC3339, *req.StartDate)
 		if err != nil {
-			validationError(ctx, fmt.Errorf("invalid startDate format (must be RFC3339)"))
+			validationError(ctx, newRequestError("startDate", "date"))
 			return
 		}
 		startDate = &t
@@ -259,7 +317,7 @@ func (qh *QuoteHandler) ListQuotes(ctx *gin.Context) {
 	if req.EndDate != nil {
 		t, err := time.Parse(time.RFC3339, *req.EndDate)
 		if err != nil {
-			validationError(ctx, fmt.Errorf("invalid endDate format (must be RFC3339)"))
+			validationError(ctx, newRequestError("endDate", "date"))
 			return
 		}
 		endDate = &t
@@ -270,13 +328,19 @@ func (qh *QuoteHandler) ListQuotes(ctx *gin.Context) {
 	if req.State != nil {
 		s := domain.QuoteState(*req.State)
 		if s != domain.QuotePending && s != domain.QuoteApproved &&
-			s != domain.QuoteRejected && s != domain.QuoteRequiresProof {
-			validationError(ctx, fmt.Errorf("invalid state value"))
+			s != domain.QuoteRejected && s != domain.QuoteRequiresProof && s != domain.QuoteExpired {
+			validationError(ctx, newRequestError("state", "oneof", "pending approved rejected requires_proof expired"))
 			return
 		}
 		state = &s
 	}
 
+	after, err := parseCursor(req.Cursor)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
 	// Build the filter
 	filter := port.QuoteFilter{
 		TypeOfServiceID: typeOfServiceId,
@@ -286,9 +350,11 @@ func (qh *QuoteHandler) ListQuotes(ctx *gin.Context) {
 		ByState:         state,
 		Skip:            req.Skip,
 		Limit:           req.Limit,
+		After:           after,
+		IncludeDeleted:  req.IncludeDeleted,
 	}
 
-	quotes, err := qh.svc.ListQuotes(ctx, filter)
+	quotes, total, next, err := qh.svc.ListQuotes(ctx, filter)
 	if err != nil {
 		handleError(ctx, err)
 		return
@@ -298,11 +364,8 @@ func (qh *QuoteHandler) ListQuotes(ctx *gin.Context) {
 		quotesList = append(quotesList, *newQuoteResponse(&quote))
 	}
 
-	total := uint64(len(quotesList))
-	meta := newMeta(total, req.Limit, req.Skip)
-	rsp := toMap(meta, quotesList, "quotes")
-
-	handleSuccess(ctx, rsp)
+	meta := newCursorPageMeta(ctx, total, req.cursorPageRequest, next)
+	handleList(ctx, meta, quotesList, "quotes")
 }
 
 // getQuoteRequest representa el cuerpo de la solicitud para obtener una cotización por ID
@@ -317,25 +380,26 @@ func (qh *QuoteHandler) ListQuotes(ctx *gin.Context) {
 //	@Tags			Quotes
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	query		string		true	"Quote ID"
+//	@Param			id	path		string		true	"Quote ID"
+//	@Param			If-None-Match	header	string	false	"ETag of the cached response"
 //	@Success		200	{object}	quoteResponse	"Quote displayed"
+//	@Header			200	{string}	ETag	"Weak ETag of the response"
+//	@Success		304	"Not modified"
 //	@Failure		400	{object}	errorResponse	"Validation error"
 //	@Failure		404	{object}	errorResponse	"Data not found error"
 //	@Failure		500	{object}	errorResponse	"Internal server error"
-//	@Router			/quotes [get]
-
+//	@Router			/v1/quotes/{id} [get]
 func (qh *QuoteHandler) GetQuote(ctx *gin.Context) {
-
 	id := ctx.DefaultQuery("id", "")
 
 	if id == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID parameter is required"})
+		validationError(ctx, newRequestError("id", "required"))
 		return
 	}
 
 	quoteID, err := uuid.Parse(id)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		validationError(ctx, newRequestError("id", "uuid"))
 		return
 	}
 
@@ -347,16 +411,17 @@ func (qh *QuoteHandler) GetQuote(ctx *gin.Context) {
 	}
 
 	// Responder con la cotización
-	rsp := newQuoteResponseWithImages(quote, images)
-	handleSuccess(ctx, rsp)
+	rsp := newQuoteResponseWithImages(quote, images, qh.urls)
+	handleCacheable(ctx, rsp)
 }
 
 // updateQuoteRequest representa el cuerpo de la solicitud para actualizar una cotización
 
 type updateQuoteRequest struct {
 	TypeOfServiceID *uuid.UUID `json:"typeOfServiceId,omitempty"`
-	Description     *string    `json:"description,omitempty"`
+	Description     *string    `json:"description,omitempty" sanitize:"text"`
 	Price           *float64   `json:"price,omitempty"`
+	Version         int        `json:"version" binding:"min=0"`
 }
 
 // UpdateQuote godoc
@@ -367,28 +432,29 @@ type updateQuoteRequest struct {
 //	@Accept			json
 //	@Produce		json
 //
-// @Param         id     query   string        true   "Quote ID"
+// @Param         id     path    string        true   "Quote ID"
 // @Param         quote  body    updateQuoteRequest   true   "Quote Data" (sin el ID)
 //
 //	@Success		200	{object}	quoteResponse	"Quote updated"
 //	@Failure		400	{object}	errorResponse	"Validation error"
 //	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		409	{object}	errorResponse	"Quote was modified concurrently"
 //	@Failure		500	{object}	errorResponse	"Internal server error"
-//	@Router			/quotes [put]
+//	@Router			/v1/quotes/{id} [put]
 func (qh *QuoteHandler) UpdateQuote(ctx *gin.Context) {
 	// Get user ID from auth token
 	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
 	idStr := ctx.DefaultQuery("id", "")
 
 	if idStr == "" {
-		validationError(ctx, fmt.Errorf("ID is required"))
+		validationError(ctx, newRequestError("id", "required"))
 		return
 	}
 
 	// Convertir el string a un UUID
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		validationError(ctx, fmt.Errorf("invalid UUID format"))
+		validationError(ctx, newRequestError("id", "uuid"))
 		return
 	}
 
@@ -416,7 +482,7 @@ func (qh *QuoteHandler) UpdateQuote(ctx *gin.Context) {
 		return
 	}
 
-	quote = &domain.Quote{ID: id}
+	quote = &domain.Quote{ID: id, Version: req.Version}
 
 	if req.TypeOfServiceID != nil {
 		quote.TypeOfServiceID = *req.TypeOfServiceID
@@ -454,27 +520,28 @@ func (qh *QuoteHandler) UpdateQuote(ctx *gin.Context) {
 //	@Accept			json
 //	@Produce		json
 //
-// @Param         id   query   string         true   "Quote ID"
+// @Param         id   path    string         true   "Quote ID"
 //
 //	@Success		200	{object}	string		"Quote deleted successfully"
 //	@Failure		400	{object}	errorResponse	"Validation error"
 //	@Failure		404	{object}	errorResponse	"Data not found error"
+//	@Failure		409	{object}	errorResponse	"Quote has an appointment"
 //	@Failure		500	{object}	errorResponse	"Internal server error"
-//	@Router			/quotes [delete]
+//	@Router			/v1/quotes/{id} [delete]
 func (qh *QuoteHandler) DeleteQuote(ctx *gin.Context) {
 	// Get user ID from auth token
 	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
 
 	idStr := ctx.DefaultQuery("id", "")
 	if idStr == "" {
-		validationError(ctx, fmt.Errorf("ID is required"))
+		validationError(ctx, newRequestError("id", "required"))
 		return
 	}
 
 	// Convertir el string a un UUID
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		validationError(ctx, fmt.Errorf("invalid UUID format"))
+		validationError(ctx, newRequestError("id", "uuid"))
 		return
 	}
 
@@ -496,7 +563,7 @@ func (qh *QuoteHandler) DeleteQuote(ctx *gin.Context) {
 		handleError(ctx, err)
 		return
 	}
-	handleSuccess(ctx, "Quote deleted successfully")
+	handleSuccess(ctx, localize(ctx, "message.quote_deleted"))
 }
 
 type changeQuoteStateRequest struct {
@@ -518,19 +585,19 @@ type changeQuoteStateRequest struct {
 //	@Failure		400	{object}	errorResponse	"Validation error"
 //	@Failure		404	{object}	errorResponse	"Data not found error"
 //	@Failure		500	{object}	errorResponse	"Internal server error"
-//	@Router			/quotes [put]
+//	@Router			/v1/quotes/state [patch]
 func (qh *QuoteHandler) ChangeQuoteState(ctx *gin.Context) {
 	idStr := ctx.DefaultQuery("id", "")
 
 	if idStr == "" {
-		validationError(ctx, fmt.Errorf("ID is required"))
+		validationError(ctx, newRequestError("id", "required"))
 		return
 	}
 
 	// Convertir el string a un UUID
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		validationError(ctx, fmt.Errorf("invalid UUID format"))
+		validationError(ctx, newRequestError("id", "uuid"))
 		return
 	}
 
@@ -546,7 +613,7 @@ func (qh *QuoteHandler) ChangeQuoteState(ctx *gin.Context) {
 	if req.State != nil {
 		s := domain.QuoteState(*req.State)
 		if s != domain.QuoteApproved && s != domain.QuoteRequiresProof && s != domain.QuoteRejected && s != domain.QuotePendingPayment {
-			validationError(ctx, fmt.Errorf("invalid state value it has to be either 'approved', 'rejected', 'requires_proof' or 'pending_payment'"))
+			validationError(ctx, newRequestError("state", "oneof", "approved rejected requires_proof pending_payment"))
 			return
 		}
 		state = s
@@ -562,3 +629,104 @@ func (qh *QuoteHandler) ChangeQuoteState(ctx *gin.Context) {
 	rsp := newQuoteResponse(quote)
 	handleSuccess(ctx, rsp)
 }
+
+// applyCouponRequest representa el cuerpo de la solicitud para aplicar un cupón a una cotización
+type applyCouponRequest struct {
+	Code string `json:"code" binding:"required,max=32" example:"VERANO25"`
+}
+
+// ApplyCoupon godoc
+//
+// @Summary        Apply a coupon to a quote
+// @Description    Apply a coupon code to a quote still waiting to be paid, replacing the one it had. The coupon is taken off the price left by the promotion
+// @Tags           Quotes
+// @Accept         json
+// @Produce        json
+// @Param          id      path    string              true   "Quote ID"
+// @Param          coupon  body    applyCouponRequest  true   "Coupon code"
+// @Success        200     {object}  quoteResponse  "Coupon applied"
+// @Failure        400     {object}  errorResponse  "Validation error"
+// @Failure        401     {object}  errorResponse  "Unauthorized error"
+// @Failure        404     {object}  errorResponse  "Quote or coupon not found"
+// @Failure        409     {object}  errorResponse  "Coupon unavailable or not applicable to the quote"
+// @Failure        500     {object}  errorResponse  "Internal server error"
+// @Router         /v1/quotes/{id}/coupon [put]
+// @Security       BearerAuth
+func (qh *QuoteHandler) ApplyCoupon(ctx *gin.Context) {
+	id, ok := qh.ownedQuoteID(ctx)
+	if !ok {
+		return
+	}
+
+	var req applyCouponRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	quote, err := qh.svc.ApplyCoupon(ctx, id, req.Code)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newQuoteResponse(quote)
+	handleSuccess(ctx, rsp)
+}
+
+// RemoveCoupon godoc
+//
+// @Summary        Remove the coupon of a quote
+// @Description    Remove the coupon applied to a quote still waiting to be paid, giving its use back
+// @Tags           Quotes
+// @Accept         json
+// @Produce        json
+// @Param          id   path    string true   "Quote ID"
+// @Success        200  {object}  quoteResponse  "Coupon removed"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        401  {object}  errorResponse  "Unauthorized error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        409  {object}  errorResponse  "Quote no longer waiting to be paid"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/quotes/{id}/coupon [delete]
+// @Security       BearerAuth
+func (qh *QuoteHandler) RemoveCoupon(ctx *gin.Context) {
+	id, ok := qh.ownedQuoteID(ctx)
+	if !ok {
+		return
+	}
+
+	quote, err := qh.svc.RemoveCoupon(ctx, id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newQuoteResponse(quote)
+	handleSuccess(ctx, rsp)
+}
+
+// ownedQuoteID devuelve el id de la cotización de la solicitud, respondiendo con el error cuando
+// no es válido o la cotización es de otro cliente
+func (qh *QuoteHandler) ownedQuoteID(ctx *gin.Context) (uuid.UUID, bool) {
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return uuid.Nil, false
+	}
+
+	quote, _, err := qh.svc.GetQuote(ctx, id)
+	if err != nil {
+		handleError(ctx, err)
+		return uuid.Nil, false
+	}
+
+	if authPayload.Role == domain.Client && quote.ClientID != authPayload.UserID {
+		handleError(ctx, domain.ErrUnauthorized)
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
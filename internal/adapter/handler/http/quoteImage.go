@@ -1,9 +1,7 @@
 package http
 
 import (
-	"fmt"
 	"net/http"
-	"path/filepath"
 
 	"harajuku/backend/internal/core/domain"
 	"harajuku/backend/internal/core/port"
@@ -13,32 +11,91 @@ import (
 )
 
 type QuoteImageHandler struct {
-	svc port.QuoteImageService
+	svc  port.QuoteImageService
+	urls fileURLs
 }
 
-func NewQuoteImageHandler(svc port.QuoteImageService) *QuoteImageHandler {
-	return &QuoteImageHandler{svc: svc}
+// NewQuoteImageHandler crea una nueva instancia de QuoteImageHandler, signer puede ser nil para devolver las rutas sin firmar
+func NewQuoteImageHandler(svc port.QuoteImageService, signer port.FileURLSigner) *QuoteImageHandler {
+	return &QuoteImageHandler{svc: svc, urls: fileURLs{signer}}
 }
 
-func newQuoteImageResponse(q *domain.QuoteImage) quoteImageResponse {
+func newQuoteImageResponse(q *domain.QuoteImage, urls fileURLs) quoteImageResponse {
 	return quoteImageResponse{
-		ID:      q.ID,
-		QuoteID: q.QuoteID,
-		URL:     q.URL,
+		ID:          q.ID,
+		QuoteID:     q.QuoteID,
+		URL:         urls.url(q.URL),
+		FileName:    q.FileName,
+		Size:        q.Size,
+		ContentType: q.ContentType,
+		Checksum:    q.Checksum,
 	}
 }
 
-// GetQuoteImageByID descarga la imagen asociada al ID
+// CreateQuoteImage godoc
+//
+// @Summary        Add an image to a quote
+// @Description    Append an image or video to a quote of the authenticated client, admins can add them to any quote
+// @Tags           QuoteImages
+// @Accept         multipart/form-data
+// @Produce        json
+// @Security       BearerAuth
+// @Param          quoteId  formData  string  true  "Quote ID"
+// @Param          file     formData  file    true  "Image (JPEG, PNG, WebP) or video (MP4, WebM)"
+// @Success        200      {object}  quoteImageResponse  "Quote image created"
+// @Failure        400      {object}  errorResponse  "Validation error"
+// @Failure        403      {object}  errorResponse  "Forbidden error"
+// @Failure        404      {object}  errorResponse  "Quote not found"
+// @Failure        413      {object}  errorResponse  "File too large"
+// @Failure        415      {object}  errorResponse  "Unsupported file type"
+// @Failure        500      {object}  errorResponse  "Internal server error"
+// @Router         /v1/quoteimages [post]
+func (h *QuoteImageHandler) CreateQuoteImage(ctx *gin.Context) {
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	quoteID, err := uuid.Parse(ctx.Request.FormValue("quoteId"))
+	if err != nil {
+		validationError(ctx, newRequestError("quoteId", "uuid"))
+		return
+	}
+
+	file, ok := formFile(ctx, "file")
+	if !ok {
+		return
+	}
+
+	created, err := h.svc.CreateQuoteImage(ctx, quoteID, authPayload, file, file.Size(), file.Name())
+	if err != nil {
+		handleError(ctx, file.uploadError(err))
+		return
+	}
+
+	handleSuccess(ctx, newQuoteImageResponse(created, h.urls))
+}
+
+// GetQuoteImageByID godoc
+//
+// @Summary        Download a quote image
+// @Description    Download the file of a quote image with its original name and content type
+// @Tags           QuoteImages
+// @Produce        octet-stream
+// @Security       BearerAuth
+// @Param          id   query   string true   "Quote image ID"
+// @Success        200  {file}    file           "Quote image file"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/quoteimages [get]
 func (h *QuoteImageHandler) GetQuoteImageByID(ctx *gin.Context) {
 	idStr := ctx.DefaultQuery("id", "")
 	if idStr == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID parameter is required"})
+		validationError(ctx, newRequestError("id", "required"))
 		return
 	}
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		validationError(ctx, newRequestError("id", "uuid"))
 		return
 	}
 
@@ -49,69 +106,82 @@ func (h *QuoteImageHandler) GetQuoteImageByID(ctx *gin.Context) {
 		return
 	}
 
-	// Detectar tipo MIME para respuesta correcta
-	mimeType := http.DetectContentType(fileData)
-
-	// Establecer headers para descargar el archivo con el nombre original
-	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filepath.Base(quoteImage.URL)))
-	ctx.Data(http.StatusOK, mimeType, fileData)
+	// Descargar el archivo con el nombre y tipo MIME originales
+	sendAttachment(ctx, fileData, quoteImage.URL, quoteImage.FileName, quoteImage.ContentType)
 }
 
-// GetQuoteImages lista imágenes filtrando por quoteId
+// GetQuoteImages godoc
+//
+// @Summary        List quote images
+// @Description    List the images of the quotes, optionally of a single quote
+// @Tags           QuoteImages
+// @Accept         json
+// @Produce        json
+// @Security       BearerAuth
+// @Param          quoteId query   string false  "Quote ID"
+// @Param          skip    query   uint64 false  "Page number, starting at 1"
+// @Param          limit   query   uint64 true   "Limit"
+// @Success        200     {array}   quoteImageResponse  "Quote images displayed"
+// @Failure        400     {object}  errorResponse  "Validation error"
+// @Failure        500     {object}  errorResponse  "Internal server error"
+// @Router         /v1/quoteimages/all [get]
 func (h *QuoteImageHandler) GetQuoteImages(ctx *gin.Context) {
 	var quoteID *uuid.UUID
-	var skip, limit uint64
 
 	if quoteIDStr := ctx.Query("quoteId"); quoteIDStr != "" {
 		parsedID, err := uuid.Parse(quoteIDStr)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "quoteId inválido"})
+			validationError(ctx, newRequestError("quoteId", "uuid"))
 			return
 		}
 		quoteID = &parsedID
 	}
 
-	if skipStr := ctx.Query("skip"); skipStr != "" {
-		_, err := fmt.Sscan(skipStr, &skip)
-		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "skip inválido"})
-			return
-		}
-	}
-
-	if limitStr := ctx.Query("limit"); limitStr != "" {
-		_, err := fmt.Sscan(limitStr, &limit)
-		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "limit inválido"})
-			return
-		}
+	var page pageRequest
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		validationError(ctx, err)
+		return
 	}
 
-	quoteImages, err := h.svc.GetQuoteImages(ctx, quoteID, skip, limit)
+	quoteImages, err := h.svc.GetQuoteImages(ctx, quoteID, page.Skip, page.Limit)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		handleError(ctx, err)
 		return
 	}
 
 	var response []quoteImageResponse
 	for _, q := range quoteImages {
-		response = append(response, newQuoteImageResponse(&q))
+		response = append(response, newQuoteImageResponse(&q, h.urls))
 	}
 
 	ctx.JSON(http.StatusOK, response)
 }
 
-// DeleteQuoteImage elimina una imagen de cotización por ID
+// DeleteQuoteImage godoc
+//
+// @Summary        Delete a quote image
+// @Description    Delete a quote image and its file by id
+// @Tags           QuoteImages
+// @Accept         json
+// @Produce        json
+// @Security       BearerAuth
+// @Param          id   query   string true   "Quote image ID"
+// @Success        200  {object}  response       "Quote image deleted"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        403  {object}  errorResponse  "Forbidden error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/quoteimages [delete]
 func (h *QuoteImageHandler) DeleteQuoteImage(ctx *gin.Context) {
 	idStr := ctx.DefaultQuery("id", "")
 	if idStr == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID parameter is required"})
+		validationError(ctx, newRequestError("id", "required"))
 		return
 	}
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		validationError(ctx, fmt.Errorf("invalid id format"))
+		validationError(ctx, newRequestError("id", "uuid"))
 		return
 	}
 
@@ -120,5 +190,5 @@ func (h *QuoteImageHandler) DeleteQuoteImage(ctx *gin.Context) {
 		return
 	}
 
-	handleSuccess(ctx, "Quote image deleted successfully")
+	handleSuccess(ctx, localize(ctx, "message.quote_image_deleted"))
 }
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimits are the rate limits of the route groups, a nil limit leaves its group unlimited
+type RateLimits struct {
+	// Auth applies to login and registration, counted per IP
+	Auth *domain.RateLimit
+	// Upload applies to the routes receiving files, counted per user
+	Upload *domain.RateLimit
+}
+
+// rateLimitMiddleware is a middleware that takes a token from the bucket of the route group for every request.
+// Requests are counted per user when the auth middleware ran before, and per IP otherwise. When the limiter
+// fails the request is let through, an outage of redis shouldn't take the API down with it
+func rateLimitMiddleware(limiter port.RateLimitRepository, group string, limit *domain.RateLimit) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if limit == nil || ctx.Request.Method == http.MethodOptions {
+			ctx.Next()
+			return
+		}
+
+		subject := "ip:" + ctx.ClientIP()
+		if payload, ok := ctx.Get(authorizationPayloadKey); ok {
+			subject = "user:" + payload.(*domain.TokenPayload).UserID.String()
+		}
+
+		result, err := limiter.Take(ctx, "rateLimit:"+group+":"+subject, *limit)
+		if err != nil {
+			slog.ErrorContext(ctx, "Rate limiter failed, letting the request through", "group", group, "error", err)
+			ctx.Next()
+			return
+		}
+
+		ctx.Header("X-RateLimit-Limit", strconv.Itoa(limit.Requests))
+		ctx.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		if !result.Allowed {
+			ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds()))))
+			handleAbort(ctx, domain.ErrTooManyRequests)
+			return
+		}
+
+		ctx.Next()
+	}
+}
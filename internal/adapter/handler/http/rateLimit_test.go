@@ -0,0 +1,99 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingLimiter allows the first requests of every key and records the keys it was asked about
+type countingLimiter struct {
+	allowed int
+	taken   map[string]int
+	err     error
+}
+
+func (l *countingLimiter) Take(ctx context.Context, key string, limit domain.RateLimit) (*domain.RateLimitResult, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	l.taken[key]++
+	if l.taken[key] > l.allowed {
+		return &domain.RateLimitResult{RetryAfter: 1500 * time.Millisecond}, nil
+	}
+	return &domain.RateLimitResult{Allowed: true, Remaining: l.allowed - l.taken[key]}, nil
+}
+
+func (l *countingLimiter) Close() error {
+	return nil
+}
+
+func newRateLimitedRouter(limiter *countingLimiter, payload *domain.TokenPayload) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	limit := &domain.RateLimit{Requests: limiter.allowed, Period: time.Minute}
+
+	router.POST("/login", func(ctx *gin.Context) {
+		if payload != nil {
+			ctx.Set(authorizationPayloadKey, payload)
+		}
+		ctx.Next()
+	}, rateLimitMiddleware(limiter, "auth", limit), func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	return router
+}
+
+func TestRateLimitMiddlewareRejectsOverTheLimit(t *testing.T) {
+	limiter := &countingLimiter{allowed: 2, taken: map[string]int{}}
+	router := newRateLimitedRouter(limiter, nil)
+
+	var codes []int
+	for i := 0; i < 3; i++ {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/login", nil)
+		req.RemoteAddr = "203.0.113.7:4312"
+		router.ServeHTTP(rec, req)
+		codes = append(codes, rec.Code)
+
+		if rec.Code == http.StatusTooManyRequests {
+			assert.Equal(t, "2", rec.Header().Get("Retry-After"))
+		}
+	}
+
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+	assert.Equal(t, 3, limiter.taken["rateLimit:auth:ip:203.0.113.7"])
+}
+
+func TestRateLimitMiddlewareCountsPerUser(t *testing.T) {
+	limiter := &countingLimiter{allowed: 1, taken: map[string]int{}}
+	userID := uuid.New()
+	router := newRateLimitedRouter(limiter, &domain.TokenPayload{UserID: userID})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/login", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 1, limiter.taken["rateLimit:auth:user:"+userID.String()])
+}
+
+func TestRateLimitMiddlewareLetsThroughWhenTheLimiterFails(t *testing.T) {
+	limiter := &countingLimiter{allowed: 1, err: errors.New("connection refused")}
+	router := newRateLimitedRouter(limiter, nil)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/login", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,269 @@
+package http
+
+import (
+	"math"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReportHandler represents the HTTP handler for report-related requests
+type ReportHandler struct {
+	svc port.ReportService
+}
+
+// NewReportHandler creates a new ReportHandler instance
+func NewReportHandler(svc port.ReportService) *ReportHandler {
+	return &ReportHandler{
+		svc,
+	}
+}
+
+// requestReportRequest represents the request body for requesting a report
+type requestReportRequest struct {
+	Kind      string     `json:"kind" binding:"required,oneof=quotes appointments" example:"quotes"`
+	StartDate *time.Time `json:"startDate" example:"2025-03-01T00:00:00Z"`
+	EndDate   *time.Time `json:"endDate" example:"2025-04-01T00:00:00Z"`
+}
+
+// RequestReport godoc
+//
+// @Summary        Request a report
+// @Description    Queue a CSV report of the quotes or appointments between the optional dates, which is emailed to the admin once it is built
+// @Tags           Reports
+// @Accept         json
+// @Produce        json
+// @Param          requestReportRequest  body      requestReportRequest  true  "Report"
+// @Success        202                   {object}  jobResponse  "Report queued"
+// @Failure        400                   {object}  errorResponse  "Validation error"
+// @Failure        401                   {object}  errorResponse  "Unauthorized error"
+// @Failure        403                   {object}  errorResponse  "Forbidden error"
+// @Failure        500                   {object}  errorResponse  "Internal server error"
+// @Router         /v1/reports [post]
+// @Security       BearerAuth
+func (rh *ReportHandler) RequestReport(ctx *gin.Context) {
+	var req requestReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	job, err := rh.svc.RequestReport(ctx, &domain.ReportJob{
+		Kind:        domain.ReportKind(req.Kind),
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		RequestedBy: authPayload.UserID,
+	})
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newJobResponse(job)
+	handleAccepted(ctx, rsp)
+}
+
+// staffUtilizationRequest represents the query for the staff utilization report
+type staffUtilizationRequest struct {
+	StartDate string `form:"startDate"`
+	EndDate   string `form:"endDate"`
+}
+
+// staffUtilizationResponse represents the slot time an admin offered over the period and how much was booked
+type staffUtilizationResponse struct {
+	AdminID        uuid.UUID `json:"adminId"`
+	AdminName      string    `json:"adminName" example:"Ana López"`
+	Slots          int       `json:"slots" example:"40"`
+	BookedSlots    int       `json:"bookedSlots" example:"30"`
+	CompletedSlots int       `json:"completedSlots" example:"24"`
+	OfferedHours   float64   `json:"offeredHours" example:"60"`
+	BookedHours    float64   `json:"bookedHours" example:"45"`
+	CompletedHours float64   `json:"completedHours" example:"36"`
+	BookedRate     float64   `json:"bookedRate" example:"0.75"`
+	CompletedRate  float64   `json:"completedRate" example:"0.6"`
+}
+
+// newStaffUtilizationResponse is a helper function to create a response body for handling utilization data,
+// hours and rates are rounded to two decimals
+func newStaffUtilizationResponse(u *domain.StaffUtilization) *staffUtilizationResponse {
+	round := func(v float64) float64 {
+		return math.Round(v*100) / 100
+	}
+
+	return &staffUtilizationResponse{
+		AdminID:        u.AdminID,
+		AdminName:      u.AdminName,
+		Slots:          u.Slots,
+		BookedSlots:    u.BookedSlots,
+		CompletedSlots: u.CompletedSlots,
+		OfferedHours:   round(u.Offered.Hours()),
+		BookedHours:    round(u.Booked.Hours()),
+		CompletedHours: round(u.Completed.Hours()),
+		BookedRate:     round(u.BookedRate()),
+		CompletedRate:  round(u.CompletedRate()),
+	}
+}
+
+// GetStaffUtilization godoc
+//
+// @Summary        Staff utilization report
+// @Description    Compare the hours every admin offered in availability slots starting between the dates with the hours booked and completed, as JSON or, with Accept: text/csv, as CSV
+// @Tags           Reports
+// @Accept         json
+// @Produce        json
+// @Param          startDate  query     string  true  "Slots starting from (RFC3339)"
+// @Param          endDate    query     string  true  "Slots starting before (RFC3339)"
+// @Success        200        {object}  meta  "Utilization displayed"
+// @Failure        400        {object}  errorResponse  "Validation error"
+// @Failure        401        {object}  errorResponse  "Unauthorized error"
+// @Failure        403        {object}  errorResponse  "Forbidden error"
+// @Failure        500        {object}  errorResponse  "Internal server error"
+// @Router         /v1/reports/utilization [get]
+// @Security       BearerAuth
+func (rh *ReportHandler) GetStaffUtilization(ctx *gin.Context) {
+	var req staffUtilizationRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	startDate, err := time.Parse(time.RFC3339, req.StartDate)
+	if err != nil {
+		validationError(ctx, newRequestError("startDate", "date"))
+		return
+	}
+	endDate, err := time.Parse(time.RFC3339, req.EndDate)
+	if err != nil {
+		validationError(ctx, newRequestError("endDate", "date"))
+		return
+	}
+
+	utilization, err := rh.svc.GetStaffUtilization(ctx, startDate, endDate)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	utilizationList := []staffUtilizationResponse{}
+	for _, u := range utilization {
+		utilizationList = append(utilizationList, *newStaffUtilizationResponse(&u))
+	}
+
+	total := uint64(len(utilizationList))
+	handleList(ctx, meta{Total: total}, utilizationList, "utilization")
+}
+
+// clientRetentionRequest represents the query for the client retention report
+type clientRetentionRequest struct {
+	StartDate string `form:"startDate"`
+	EndDate   string `form:"endDate"`
+	Top       uint64 `form:"top" binding:"omitempty,min=1,max=100" example:"10"`
+}
+
+// clientRetentionMonthResponse represents the clients who visited in a month
+type clientRetentionMonthResponse struct {
+	Month            string `json:"month" example:"2025-03"`
+	NewClients       int    `json:"newClients" example:"12"`
+	ReturningClients int    `json:"returningClients" example:"30"`
+	Visits           int    `json:"visits" example:"51"`
+}
+
+// clientSpendResponse represents what a client spent on their visits in a currency
+type clientSpendResponse struct {
+	ClientID   uuid.UUID `json:"clientId"`
+	ClientName string    `json:"clientName" example:"Sofía Hernández"`
+	Visits     int       `json:"visits" example:"6"`
+	Spent      float64   `json:"spent" example:"4350"`
+	Currency   string    `json:"currency" example:"MXN"`
+}
+
+// clientRetentionResponse represents the client retention report
+type clientRetentionResponse struct {
+	Months []clientRetentionMonthResponse `json:"months"`
+	// AverageWeeksBetweenVisits is zero when no client visited twice
+	AverageWeeksBetweenVisits float64               `json:"averageWeeksBetweenVisits" example:"5.5"`
+	TopClients                []clientSpendResponse `json:"topClients"`
+}
+
+// newClientRetentionResponse is a helper function to create a response body for handling retention data
+func newClientRetentionResponse(r *domain.ClientRetention) *clientRetentionResponse {
+	rsp := &clientRetentionResponse{
+		Months:                    []clientRetentionMonthResponse{},
+		AverageWeeksBetweenVisits: math.Round(r.AverageVisitGap.Hours()/(7*24)*100) / 100,
+		TopClients:                []clientSpendResponse{},
+	}
+
+	for _, m := range r.Months {
+		rsp.Months = append(rsp.Months, clientRetentionMonthResponse{
+			Month:            m.Month.Format("2006-01"),
+			NewClients:       m.NewClients,
+			ReturningClients: m.ReturningClients,
+			Visits:           m.Visits,
+		})
+	}
+	for _, c := range r.TopClients {
+		rsp.TopClients = append(rsp.TopClients, clientSpendResponse{
+			ClientID:   c.ClientID,
+			ClientName: c.ClientName,
+			Visits:     c.Visits,
+			Spent:      math.Round(c.Spent*100) / 100,
+			Currency:   string(c.Currency),
+		})
+	}
+
+	return rsp
+}
+
+// GetClientRetention godoc
+//
+// @Summary        Client retention report
+// @Description    Count the new and returning clients of every month between the dates, the average weeks between two visits of a client and the clients who spent the most, a visit being a completed appointment
+// @Tags           Reports
+// @Accept         json
+// @Produce        json
+// @Param          startDate  query     string  true   "Visits from (RFC3339)"
+// @Param          endDate    query     string  true   "Visits before (RFC3339)"
+// @Param          top        query     uint64  false  "Number of top clients, 10 by default"
+// @Success        200        {object}  clientRetentionResponse  "Retention displayed"
+// @Failure        400        {object}  errorResponse  "Validation error"
+// @Failure        401        {object}  errorResponse  "Unauthorized error"
+// @Failure        403        {object}  errorResponse  "Forbidden error"
+// @Failure        500        {object}  errorResponse  "Internal server error"
+// @Router         /v1/reports/retention [get]
+// @Security       BearerAuth
+func (rh *ReportHandler) GetClientRetention(ctx *gin.Context) {
+	var req clientRetentionRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	startDate, err := time.Parse(time.RFC3339, req.StartDate)
+	if err != nil {
+		validationError(ctx, newRequestError("startDate", "date"))
+		return
+	}
+	endDate, err := time.Parse(time.RFC3339, req.EndDate)
+	if err != nil {
+		validationError(ctx, newRequestError("endDate", "date"))
+		return
+	}
+	if req.Top == 0 {
+		req.Top = 10
+	}
+
+	retention, err := rh.svc.GetClientRetention(ctx, startDate, endDate, req.Top)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newClientRetentionResponse(retention)
+	handleSuccess(ctx, rsp)
+}
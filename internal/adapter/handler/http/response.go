@@ -1,14 +1,18 @@
 package http
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"harajuku/backend/internal/core/domain"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	sloggin "github.com/samber/slog-gin"
 )
 
 // response represents a response body format
@@ -31,16 +35,13 @@ func newResponse(success bool, message string, data any) response {
 type meta struct {
 	Total uint64 `json:"total" example:"100"`
 	Limit uint64 `json:"limit" example:"10"`
-	Skip  uint64 `json:"skip" example:"0"`
-}
-
-// newMeta is a helper function to create metadata for a paginated response
-func newMeta(total, limit, skip uint64) meta {
-	return meta{
-		Total: total,
-		Limit: limit,
-		Skip:  skip,
-	}
+	// Skip is the page number, starting at 1
+	Skip uint64 `json:"skip" example:"1"`
+	// Next and Prev are the URLs of the adjacent pages, the same as the Link header, empty at either end
+	Next string `json:"next,omitempty" example:"/v1/quotes/all?limit=10&skip=2"`
+	Prev string `json:"prev,omitempty"`
+	// NextCursor resumes the listing after this page, it is empty on the last page
+	NextCursor string `json:"next_cursor,omitempty" example:"MjAyNS0wMS0wMVQxMDowMDowMFp8..."`
 }
 
 // authResponse represents an authentication response body
@@ -59,21 +60,45 @@ func newAuthResponse(token string, role domain.UserRole) authResponse {
 
 // userResponse represents a user response body
 type userResponse struct {
-	ID             uuid.UUID `json:"id" example:"1"`
-	Name           string    `json:"name" example:"Juan"`
-	LastName       string    `json:"lastName" example:"Pérez"`
-	secondLastname string    `json:"secondLastName" example:"Hernández"`
-	Email          string    `json:"email" example:"test@example.com"`
+	ID                    uuid.UUID `json:"id" example:"1"`
+	Name                  string    `json:"name" example:"Juan"`
+	LastName              string    `json:"lastName" example:"Pérez"`
+	secondLastname        string    `json:"secondLastName" example:"Hernández"`
+	Email                 string    `json:"email" example:"test@example.com"`
+	PreferredLanguage     string    `json:"preferredLanguage" example:"es"`
+	Phone                 string    `json:"phone" example:"+5215512345678"`
+	EmailNotifications    bool      `json:"emailNotifications" example:"true"`
+	SMSNotifications      bool      `json:"smsNotifications" example:"true"`
+	WhatsAppNotifications bool      `json:"whatsAppNotifications" example:"true"`
 }
 
 // newUserResponse is a helper function to create a response body for handling user data
 func newUserResponse(user *domain.User) userResponse {
 	return userResponse{
-		ID:             user.ID,
-		Name:           user.Name,
-		LastName:       user.LastName,
-		secondLastname: user.SecondLastName,
-		Email:          user.Email,
+		ID:                    user.ID,
+		Name:                  user.Name,
+		LastName:              user.LastName,
+		secondLastname:        user.SecondLastName,
+		Email:                 user.Email,
+		PreferredLanguage:     string(user.PreferredLanguage.OrDefault()),
+		Phone:                 user.Phone,
+		EmailNotifications:    user.NotificationPreferences.Email,
+		SMSNotifications:      user.NotificationPreferences.SMS,
+		WhatsAppNotifications: user.NotificationPreferences.WhatsApp,
+	}
+}
+
+// currencyFormatResponse tells clients how to display amounts of a currency
+type currencyFormatResponse struct {
+	Symbol   string `json:"symbol" example:"$"`
+	Decimals int    `json:"decimals" example:"2"`
+}
+
+// newCurrencyFormatResponse is a helper function to create the formatting hints of a currency
+func newCurrencyFormatResponse(c domain.Currency) currencyFormatResponse {
+	return currencyFormatResponse{
+		Symbol:   c.Symbol(),
+		Decimals: c.Decimals(),
 	}
 }
 
@@ -93,47 +118,168 @@ var errorStatusMap = map[error]int{
 	domain.ErrNoUpdatedData:              http.StatusBadRequest,
 	domain.ErrInsufficientStock:          http.StatusBadRequest,
 	domain.ErrInsufficientPayment:        http.StatusBadRequest,
+	domain.ErrInvalidSignature:           http.StatusUnauthorized,
+	domain.ErrInvalidPaymentEvent:        http.StatusBadRequest,
+	domain.ErrTypeOfServiceArchived:      http.StatusConflict,
+	domain.ErrServiceNotOffered:          http.StatusConflict,
+	domain.ErrUnsupportedCurrency:        http.StatusBadRequest,
+	domain.ErrInvalidDurationRange:       http.StatusBadRequest,
+	domain.ErrInvalidPromotion:           http.StatusBadRequest,
+	domain.ErrInvalidWebhook:             http.StatusBadRequest,
+	domain.ErrDuplicateFile:              http.StatusConflict,
+	domain.ErrFileTooLarge:               http.StatusRequestEntityTooLarge,
+	domain.ErrRequestTooLarge:            http.StatusRequestEntityTooLarge,
+	domain.ErrTooManyFiles:               http.StatusBadRequest,
+	domain.ErrUnsupportedFileType:        http.StatusUnsupportedMediaType,
+	domain.ErrInfectedFile:               http.StatusUnprocessableEntity,
+	domain.ErrInvalidImage:               http.StatusBadRequest,
+	domain.ErrImageTooLarge:              http.StatusRequestEntityTooLarge,
+	domain.ErrPhoneRequired:              http.StatusBadRequest,
+	domain.ErrInvalidCursor:              http.StatusBadRequest,
+	domain.ErrDatabaseUnavailable:        http.StatusServiceUnavailable,
+	domain.ErrNotReady:                   http.StatusServiceUnavailable,
+	domain.ErrTooManyRequests:            http.StatusTooManyRequests,
+	domain.ErrQuotaExceeded:              http.StatusTooManyRequests,
+	domain.ErrInvalidQuota:               http.StatusBadRequest,
+	domain.ErrInvalidReport:              http.StatusBadRequest,
+	domain.ErrStaffInactive:              http.StatusConflict,
+	domain.ErrInvalidCoupon:              http.StatusBadRequest,
+	domain.ErrCouponUnavailable:          http.StatusConflict,
+	domain.ErrCouponNotApplicable:        http.StatusConflict,
+}
+
+// errorCodeMap is a map of defined errors and the stable codes clients can rely on, unlike the messages
+var errorCodeMap = map[error]string{
+	domain.ErrInternal:                   "internal_error",
+	domain.ErrDataNotFound:               "not_found",
+	domain.ErrConflictingData:            "conflict",
+	domain.ErrInvalidCredentials:         "invalid_credentials",
+	domain.ErrUnauthorized:               "unauthorized",
+	domain.ErrEmptyAuthorizationHeader:   "missing_authorization",
+	domain.ErrInvalidAuthorizationHeader: "invalid_authorization",
+	domain.ErrInvalidAuthorizationType:   "invalid_authorization_type",
+	domain.ErrInvalidToken:               "invalid_token",
+	domain.ErrExpiredToken:               "expired_token",
+	domain.ErrForbidden:                  "forbidden",
+	domain.ErrNoUpdatedData:              "no_updated_data",
+	domain.ErrInsufficientStock:          "insufficient_stock",
+	domain.ErrInsufficientPayment:        "insufficient_payment",
+	domain.ErrInvalidSignature:           "invalid_signature",
+	domain.ErrInvalidPaymentEvent:        "invalid_payment_event",
+	domain.ErrTypeOfServiceArchived:      "type_of_service_archived",
+	domain.ErrServiceNotOffered:          "service_not_offered",
+	domain.ErrUnsupportedCurrency:        "unsupported_currency",
+	domain.ErrInvalidDurationRange:       "invalid_duration_range",
+	domain.ErrInvalidPromotion:           "invalid_promotion",
+	domain.ErrInvalidWebhook:             "invalid_webhook",
+	domain.ErrDuplicateFile:              "duplicate_file",
+	domain.ErrFileTooLarge:               "file_too_large",
+	domain.ErrRequestTooLarge:            "request_too_large",
+	domain.ErrTooManyFiles:               "too_many_files",
+	domain.ErrUnsupportedFileType:        "unsupported_file_type",
+	domain.ErrInfectedFile:               "infected_file",
+	domain.ErrInvalidImage:               "invalid_image",
+	domain.ErrImageTooLarge:              "image_too_large",
+	domain.ErrPhoneRequired:              "phone_required",
+	domain.ErrInvalidCursor:              "invalid_cursor",
+	domain.ErrDatabaseUnavailable:        "database_unavailable",
+	domain.ErrNotReady:                   "not_ready",
+	domain.ErrTooManyRequests:            "too_many_requests",
+	domain.ErrQuotaExceeded:              "quota_exceeded",
+	domain.ErrInvalidQuota:               "invalid_quota",
+	domain.ErrInvalidReport:              "invalid_report",
+	domain.ErrStaffInactive:              "staff_inactive",
+	domain.ErrInvalidCoupon:              "invalid_coupon",
+	domain.ErrCouponUnavailable:          "coupon_unavailable",
+	domain.ErrCouponNotApplicable:        "coupon_not_applicable",
+}
+
+const (
+	// validationErrorCode is the code of a request whose fields failed validation
+	validationErrorCode = "validation_failed"
+	// invalidRequestErrorCode is the code of a request that could not be read, e.g. malformed JSON
+	invalidRequestErrorCode = "invalid_request"
+)
+
+// definedError returns the defined error err is or wraps
+func definedError(err error) (error, bool) {
+	if _, ok := errorStatusMap[err]; ok {
+		return err, true
+	}
+
+	for target := range errorStatusMap {
+		if errors.Is(err, target) {
+			return target, true
+		}
+	}
+
+	return nil, false
+}
+
+// lookupError returns the status code and the error code of err, wrapped errors match the error they wrap.
+// Errors that aren't defined are internal errors
+func lookupError(err error) (int, string) {
+	if target, ok := definedError(err); ok {
+		return errorStatusMap[target], errorCodeMap[target]
+	}
+
+	return http.StatusInternalServerError, errorCodeMap[domain.ErrInternal]
 }
 
-// validationError sends an error response for some specific request validation error
+// validationError sends an error response for some specific request validation error,
+// with the fields that failed when the binding of the request rejected them
 func validationError(ctx *gin.Context, err error) {
-	errMsgs := parseError(err)
-	errRsp := newErrorResponse(errMsgs)
+	// Binding fails as well when the body went over its size limit
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		handleError(ctx, domain.ErrRequestTooLarge)
+		return
+	}
+
+	code := invalidRequestErrorCode
+	fields := parseFieldErrors(requestLanguage(ctx), err)
+	if len(fields) > 0 {
+		code = validationErrorCode
+	}
+
+	errRsp := newErrorResponse(ctx, code, err)
+	errRsp.Fields = fields
 	ctx.JSON(http.StatusBadRequest, errRsp)
 }
 
 // handleError determines the status code of an error and returns a JSON response with the error message and status code
 func handleError(ctx *gin.Context, err error) {
-	statusCode, ok := errorStatusMap[err]
-	if !ok {
-		statusCode = http.StatusInternalServerError
-	}
+	statusCode, code := lookupError(err)
+	reportError(ctx, statusCode, err)
 
-	errMsg := parseError(err)
-	errRsp := newErrorResponse(errMsg)
+	errRsp := newErrorResponse(ctx, code, err)
 	ctx.JSON(statusCode, errRsp)
 }
 
 // handleAbort sends an error response and aborts the request with the specified status code and error message
 func handleAbort(ctx *gin.Context, err error) {
-	statusCode, ok := errorStatusMap[err]
-	if !ok {
-		statusCode = http.StatusInternalServerError
-	}
+	statusCode, code := lookupError(err)
+	reportError(ctx, statusCode, err)
 
-	errMsg := parseError(err)
-	errRsp := newErrorResponse(errMsg)
+	errRsp := newErrorResponse(ctx, code, err)
 	ctx.AbortWithStatusJSON(statusCode, errRsp)
 }
 
-// parseError parses error messages from the error object and returns a slice of error messages
-func parseError(err error) []string {
+// parseError returns the messages of err in lang. Defined errors and the errors found in the fields of
+// the request are translated, any other error, like malformed JSON, keeps its own message
+func parseError(lang domain.Language, err error) []string {
 	var errMsgs []string
 
-	if errors.As(err, &validator.ValidationErrors{}) {
-		for _, err := range err.(validator.ValidationErrors) {
-			errMsgs = append(errMsgs, err.Error())
+	var validationErrs validator.ValidationErrors
+	var reqErr *requestError
+	if errors.As(err, &validationErrs) {
+		for _, fe := range validationErrs {
+			errMsgs = append(errMsgs, fmt.Sprintf("%s %s", fe.Field(), fieldErrorMessage(lang, fe)))
 		}
+	} else if errors.As(err, &reqErr) {
+		errMsgs = append(errMsgs, reqErr.message(lang))
+	} else if target, ok := definedError(err); ok {
+		errMsgs = append(errMsgs, translate(lang, "error."+errorCodeMap[target]))
 	} else {
 		errMsgs = append(errMsgs, err.Error())
 	}
@@ -141,22 +287,80 @@ func parseError(err error) []string {
 	return errMsgs
 }
 
+// parseFieldErrors returns the fields a binding error is about in lang, empty when it isn't about fields
+func parseFieldErrors(lang domain.Language, err error) []fieldError {
+	var fields []fieldError
+
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	var reqErr *requestError
+	switch {
+	case errors.As(err, &validationErrs):
+		for _, fe := range validationErrs {
+			fields = append(fields, fieldError{
+				Field:   fe.Field(),
+				Code:    fe.Tag(),
+				Message: fieldErrorMessage(lang, fe),
+			})
+		}
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		fields = append(fields, fieldError{
+			Field:   typeErr.Field,
+			Code:    "type",
+			Message: translate(lang, "field.type", typeErr.Type.Kind()),
+		})
+	case errors.As(err, &reqErr):
+		fields = append(fields, fieldError{
+			Field:   reqErr.field,
+			Code:    reqErr.key,
+			Message: translate(lang, "field."+reqErr.key, reqErr.args...),
+		})
+	}
+
+	return fields
+}
+
+// fieldError represents a field of the request that failed validation
+type fieldError struct {
+	Field   string `json:"field" example:"email"`
+	Code    string `json:"code" example:"required"`
+	Message string `json:"message" example:"is required"`
+}
+
 // errorResponse represents an error response body format
 type errorResponse struct {
-	Success  bool     `json:"success" example:"false"`
-	Messages []string `json:"messages" example:"Error message 1, Error message 2"`
+	Success bool `json:"success" example:"false"`
+	// Code identifies the error for clients, it doesn't change when the message does
+	Code    string `json:"code" example:"validation_failed"`
+	Message string `json:"message" example:"email is required"`
+	// Messages holds every message, kept for clients reading the previous format
+	Messages  []string     `json:"messages" example:"Error message 1, Error message 2"`
+	Fields    []fieldError `json:"fields,omitempty"`
+	RequestID string       `json:"requestId" example:"2f1c7d9e-5b8a-4c3e-9f6d-1a2b3c4d5e6f"`
 }
 
-// newErrorResponse is a helper function to create an error response body
-func newErrorResponse(errMsgs []string) errorResponse {
+// newErrorResponse is a helper function to create an error response body in the language of the
+// request, the request ID is the one the request was logged with
+func newErrorResponse(ctx *gin.Context, code string, err error) errorResponse {
+	errMsgs := parseError(requestLanguage(ctx), err)
+
 	return errorResponse{
-		Success:  false,
-		Messages: errMsgs,
+		Success:   false,
+		Code:      code,
+		Message:   strings.Join(errMsgs, ", "),
+		Messages:  errMsgs,
+		RequestID: sloggin.GetRequestID(ctx),
 	}
 }
 
 // handleSuccess sends a success response with the specified status code and optional data
 func handleSuccess(ctx *gin.Context, data any) {
-	rsp := newResponse(true, "Success", data)
+	rsp := newResponse(true, localize(ctx, "message.success"), data)
 	ctx.JSON(http.StatusOK, rsp)
 }
+
+// handleAccepted sends a success response for work that was queued to be done in the background
+func handleAccepted(ctx *gin.Context, data any) {
+	rsp := newResponse(true, localize(ctx, "message.success"), data)
+	ctx.JSON(http.StatusAccepted, rsp)
+}
@@ -0,0 +1,116 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	sloggin "github.com/samber/slog-gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serve runs handler behind the request logger, which assigns the request IDs, and decodes the error response
+func serve(t *testing.T, body string, handler gin.HandlerFunc) (int, errorResponse) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(fieldName)
+	}
+
+	router := gin.New()
+	router.Use(sloggin.New(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	router.POST("/", handler)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-Id", "req-123")
+	router.ServeHTTP(rec, req)
+
+	var rsp errorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rsp))
+	return rec.Code, rsp
+}
+
+func TestValidationErrorReturnsFieldErrors(t *testing.T) {
+	code, rsp := serve(t, `{"password": "123"}`, func(ctx *gin.Context) {
+		var req loginRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			validationError(ctx, err)
+		}
+	})
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "validation_failed", rsp.Code)
+	assert.Equal(t, "req-123", rsp.RequestID)
+	assert.Contains(t, rsp.Fields, fieldError{Field: "email", Code: "required", Message: "is required"})
+	assert.Contains(t, rsp.Messages, "email is required")
+}
+
+func TestValidationErrorReturnsTypeErrors(t *testing.T) {
+	_, rsp := serve(t, `{"email": 42, "password": "12345678"}`, func(ctx *gin.Context) {
+		var req loginRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			validationError(ctx, err)
+		}
+	})
+
+	assert.Equal(t, "validation_failed", rsp.Code)
+	assert.Equal(t, []fieldError{{Field: "email", Code: "type", Message: "must be a string"}}, rsp.Fields)
+}
+
+func TestValidationErrorWithoutFields(t *testing.T) {
+	_, rsp := serve(t, `{`, func(ctx *gin.Context) {
+		var req loginRequest
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			validationError(ctx, err)
+		}
+	})
+
+	assert.Equal(t, "invalid_request", rsp.Code)
+	assert.Empty(t, rsp.Fields)
+	assert.NotEmpty(t, rsp.Message)
+}
+
+func TestHandleErrorMatchesWrappedErrors(t *testing.T) {
+	code, rsp := serve(t, ``, func(ctx *gin.Context) {
+		handleError(ctx, fmt.Errorf("quote %d: %w", 7, domain.ErrDataNotFound))
+	})
+
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, "not_found", rsp.Code)
+	assert.Equal(t, "req-123", rsp.RequestID)
+}
+
+func TestHandleErrorUnknownErrorsAreInternal(t *testing.T) {
+	code, rsp := serve(t, ``, func(ctx *gin.Context) {
+		handleError(ctx, fmt.Errorf("something broke"))
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.Equal(t, "internal_error", rsp.Code)
+}
+
+func TestEveryErrorHasACode(t *testing.T) {
+	codes := map[string]error{}
+	for err := range errorStatusMap {
+		code, ok := errorCodeMap[err]
+		assert.True(t, ok, "missing code for %q", err)
+
+		other, taken := codes[code]
+		assert.False(t, taken, "code %q is used by %q and %q", code, err, other)
+		codes[code] = err
+	}
+}
@@ -1,11 +1,16 @@
 package http
 
 import (
+	"context"
+	"crypto/tls"
+	"expvar"
 	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
 	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
 	"harajuku/backend/internal/core/port"
 
 	"github.com/gin-contrib/cors"
@@ -20,12 +25,17 @@ import (
 // Router is a wrapper for HTTP router
 type Router struct {
 	*gin.Engine
+	// tlsConfig is nil when the server serves plain HTTP
+	tlsConfig *tls.Config
 }
 
 // NewRouter creates a new HTTP router
 func NewRouter(
 	config *config.HTTP,
 	token port.TokenService,
+	limiter port.RateLimitRepository,
+	rateLimits RateLimits,
+	reporter port.ErrorReporter,
 	userHandler UserHandler,
 	authHandler AuthHandler,
 	quoteHandler QuoteHandler,
@@ -34,41 +44,96 @@ func NewRouter(
 	appointmentHandler AppointmentHandler,
 	paymentProofHandler PaymentProofHandler,
 	quoteImageHandler QuoteImageHandler,
+	paymentWebhookHandler PaymentWebhookHandler,
+	serviceCategoryHandler ServiceCategoryHandler,
+	promotionHandler PromotionHandler,
+	emailQueueHandler EmailQueueHandler,
+	notificationHandler NotificationHandler,
+	webhookHandler WebhookHandler,
+	deviceHandler DeviceHandler,
+	healthHandler HealthHandler,
+	auditLogHandler AuditLogHandler,
+	quotaHandler QuotaHandler,
+	logLevelHandler LogLevelHandler,
+	jobQueueHandler JobQueueHandler,
+	reportHandler ReportHandler,
+	eventStoreHandler EventStoreHandler,
+	auditHandler AuditHandler,
+	salonHandler SalonHandler,
+	staffHandler StaffHandler,
+	couponHandler CouponHandler,
+	graphQLHandler GraphQLHandler,
 ) (*Router, error) {
 	// Disable debug mode in production
 	if config.Env == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	// Requests are sanitized on binding, before they are validated
+	if _, ok := binding.Validator.(*sanitizingValidator); !ok {
+		binding.Validator = newSanitizingValidator(binding.Validator)
+	}
+
 	// Custom validators
 	v, ok := binding.Validator.Engine().(*validator.Validate)
 	if ok {
 		if err := v.RegisterValidation("user_role", userRoleValidator); err != nil {
 			return nil, err
 		}
+		if err := v.RegisterValidation("slug", slugValidator); err != nil {
+			return nil, err
+		}
+		v.RegisterTagNameFunc(fieldName)
 	}
 
 	// CORS
-	ginConfig := cors.DefaultConfig()
-	allowedOrigins := config.AllowedOrigins
-	originsList := strings.Split(allowedOrigins, ",")
-	ginConfig.AllowOrigins = originsList
-	ginConfig.AllowHeaders = append(
-		ginConfig.AllowHeaders,
-		"Authorization",
-		"Content-Type",
-    "Content-Disposition",
-		"X-Requested-With",
-	)
-	ginConfig.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
-	// If you need the frontend to read the token or other headers back:
-	ginConfig.ExposeHeaders = []string{"Authorization"}
-	ginConfig.AllowCredentials = true
+	corsConfig, err := newCORSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := newTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
 	router := gin.New()
-	router.Use(cors.New(ginConfig), sloggin.New(slog.Default()), gin.Recovery())
+	router.UseH2C = config.H2C
+	if config.TrustedProxies != "" {
+		proxies := strings.Split(config.TrustedProxies, ",")
+		for i := range proxies {
+			proxies[i] = strings.TrimSpace(proxies[i])
+		}
+		if err := router.SetTrustedProxies(proxies); err != nil {
+			return nil, err
+		}
+	}
+	// Handlers pass the gin context on to the services, falling back to the request context lets
+	// the spans started by the tracing middleware reach the database, redis and storage adapters
+	router.ContextWithFallback = true
+	compression, err := compressionMiddleware(config.CompressionLevel, config.CompressionMinSize)
+	if err != nil {
+		return nil, err
+	}
+	// The slow request log is disabled without a threshold
+	var slowRequest time.Duration
+	if config.SlowRequestThreshold != "" {
+		slowRequest, err = time.ParseDuration(config.SlowRequestThreshold)
+		if err != nil {
+			return nil, err
+		}
+	}
+	router.Use(tracingMiddleware(), slowRequestMiddleware(slowRequest), cors.New(corsConfig), sloggin.New(slog.Default()), logContextMiddleware(), compression, errorReportMiddleware(reporter), bodyLimitMiddleware(config.MaxBodySize), auditMiddleware(auditLogHandler.svc))
+	uploads := newRouteUploadLimits(config.MaxUploadSize, config.MaxMediaUploadSize)
 
-	// Swagger
-	router.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	// Swagger, with the raw spec for client generators
+	swaggerUI := ginSwagger.WrapHandler(swaggerFiles.Handler)
+	router.GET("/docs/*any", func(ctx *gin.Context) {
+		if ctx.Param("any") == openAPISpecPath {
+			serveOpenAPISpec(ctx)
+			return
+		}
+		swaggerUI(ctx)
+	})
 
 	//Options
 	router.OPTIONS("/*any", func(c *gin.Context) {
@@ -77,19 +142,42 @@ func NewRouter(
 	// API
 	v1 := router.Group("/v1")
 
+	// Health checks (public probes, internal + admin for the details)
+	router.GET("/healthz", healthHandler.Liveness)
+	router.GET("/readyz", healthHandler.Readiness)
+	router.GET("/healthz/db", internalOrAdminMiddleware(token, config.HealthToken), healthHandler.DatabaseHealth)
+
+	// Metrics (admins of the default salon), published with expvar
+	v1.GET("/metrics", authMiddleware(token), adminMiddleware(), salonManagerMiddleware(), gin.WrapH(expvar.Handler()))
+
 	// Users (unauthenticated + authenticated)
-	v1.POST("/users/", userHandler.Register)
-	v1.POST("/users/login", authHandler.Login)
+	v1.POST("/users/", rateLimitMiddleware(limiter, "auth", rateLimits.Auth), salonMiddleware(salonHandler.svc), userHandler.Register)
+	v1.POST("/users/login", rateLimitMiddleware(limiter, "auth", rateLimits.Auth), authHandler.Login)
 	v1.GET("/users/", authMiddleware(token), userHandler.ListUsers)
 	v1.GET("/users/:id", authMiddleware(token), userHandler.GetUser)
+	v1.PUT("/users/me/notifications", authMiddleware(token), userHandler.UpdateNotificationPreferences)
+	v1.POST("/users/me/devices", authMiddleware(token), deviceHandler.RegisterDevice)
+	v1.DELETE("/users/me/devices", authMiddleware(token), deviceHandler.UnregisterDevice)
+
+	// Salons (admin)
+	v1.GET("/salons/all", authMiddleware(token), adminMiddleware(), salonHandler.ListSalons)
+	v1.GET("/salons", authMiddleware(token), adminMiddleware(), salonHandler.GetSalon)
+	v1.POST("/salons", authMiddleware(token), adminMiddleware(), salonHandler.CreateSalon)
+	v1.PUT("/salons", authMiddleware(token), adminMiddleware(), salonHandler.UpdateSalon)
 
 	// Quotes (authenticated, admin for PATCH)
-	v1.POST("/quotes", authMiddleware(token), quoteHandler.CreateQuote)
+	v1.POST("/quotes", authMiddleware(token), rateLimitMiddleware(limiter, "upload", rateLimits.Upload), quotaMiddleware(quotaHandler.svc, domain.QuotaQuoteCreation), uploadMiddleware(uploads.Quote), quoteHandler.CreateQuote)
 	v1.GET("/quotes/all", authMiddleware(token), quoteHandler.ListQuotes)
-	v1.GET("/quotes", authMiddleware(token), quoteHandler.GetQuote)
-	v1.PUT("/quotes", authMiddleware(token), quoteHandler.UpdateQuote)
+	v1.GET("/quotes/:id", idFromPath, authMiddleware(token), quoteHandler.GetQuote)
+	v1.PUT("/quotes/:id", idFromPath, authMiddleware(token), quoteHandler.UpdateQuote)
 	v1.PATCH("/quotes/state", authMiddleware(token), adminMiddleware(), quoteHandler.ChangeQuoteState)
-	v1.DELETE("/quotes", authMiddleware(token), quoteHandler.DeleteQuote)
+	v1.DELETE("/quotes/:id", idFromPath, authMiddleware(token), quoteHandler.DeleteQuote)
+	v1.PUT("/quotes/:id/coupon", idFromPath, authMiddleware(token), quoteHandler.ApplyCoupon)
+	v1.DELETE("/quotes/:id/coupon", idFromPath, authMiddleware(token), quoteHandler.RemoveCoupon)
+	// Deprecated, use the path routes
+	v1.GET("/quotes", deprecatedMiddleware("/v1/quotes/{id}"), authMiddleware(token), quoteHandler.GetQuote)
+	v1.PUT("/quotes", deprecatedMiddleware("/v1/quotes/{id}"), authMiddleware(token), quoteHandler.UpdateQuote)
+	v1.DELETE("/quotes", deprecatedMiddleware("/v1/quotes/{id}"), authMiddleware(token), quoteHandler.DeleteQuote)
 
 	// TypeOfService (authenticated, admin for write ops)
 	v1.GET("/typesofservice/all", authMiddleware(token), typeOfServiceHandler.ListTypeOfServices)
@@ -97,41 +185,205 @@ func NewRouter(
 	v1.POST("/typesofservice", authMiddleware(token), adminMiddleware(), typeOfServiceHandler.CreateTypeOfService)
 	v1.PUT("/typesofservice", authMiddleware(token), adminMiddleware(), typeOfServiceHandler.UpdateTypeOfService)
 	v1.DELETE("/typesofservice", authMiddleware(token), adminMiddleware(), typeOfServiceHandler.DeleteTypeOfService)
+	v1.PATCH("/typesofservice/archive", authMiddleware(token), adminMiddleware(), typeOfServiceHandler.ArchiveTypeOfService)
+	v1.POST("/typesofservice/offerings", authMiddleware(token), adminMiddleware(), typeOfServiceHandler.AddServiceOffering)
+	v1.DELETE("/typesofservice/offerings", authMiddleware(token), adminMiddleware(), typeOfServiceHandler.RemoveServiceOffering)
+	v1.GET("/typesofservice/images", authMiddleware(token), typeOfServiceHandler.GetTypeOfServiceImage)
+	v1.POST("/typesofservice/images", authMiddleware(token), adminMiddleware(), rateLimitMiddleware(limiter, "upload", rateLimits.Upload), quotaMiddleware(quotaHandler.svc, domain.QuotaFileUpload), uploadMiddleware(uploads.TypeOfServiceImage), typeOfServiceHandler.AddTypeOfServiceImage)
+	v1.DELETE("/typesofservice/images", authMiddleware(token), adminMiddleware(), typeOfServiceHandler.DeleteTypeOfServiceImage)
+
+	// ServiceCategories (authenticated, admin for write ops)
+	v1.GET("/servicecategories/all", authMiddleware(token), serviceCategoryHandler.ListServiceCategories)
+	v1.GET("/servicecategories", authMiddleware(token), serviceCategoryHandler.GetServiceCategory)
+	v1.POST("/servicecategories", authMiddleware(token), adminMiddleware(), serviceCategoryHandler.CreateServiceCategory)
+	v1.PUT("/servicecategories", authMiddleware(token), adminMiddleware(), serviceCategoryHandler.UpdateServiceCategory)
+	v1.DELETE("/servicecategories", authMiddleware(token), adminMiddleware(), serviceCategoryHandler.DeleteServiceCategory)
+
+	// Promotions (authenticated, admin for write ops)
+	v1.GET("/promotions/all", authMiddleware(token), promotionHandler.ListPromotions)
+	v1.GET("/promotions", authMiddleware(token), promotionHandler.GetPromotion)
+	v1.POST("/promotions", authMiddleware(token), adminMiddleware(), promotionHandler.CreatePromotion)
+	v1.PUT("/promotions", authMiddleware(token), adminMiddleware(), promotionHandler.UpdatePromotion)
+	v1.DELETE("/promotions", authMiddleware(token), adminMiddleware(), promotionHandler.DeletePromotion)
+
+	// Coupons (admin), clients apply them to their quotes
+	v1.GET("/coupons/all", authMiddleware(token), adminMiddleware(), couponHandler.ListCoupons)
+	v1.GET("/coupons", authMiddleware(token), adminMiddleware(), couponHandler.GetCoupon)
+	v1.POST("/coupons", authMiddleware(token), adminMiddleware(), couponHandler.CreateCoupon)
+	v1.PUT("/coupons", authMiddleware(token), adminMiddleware(), couponHandler.UpdateCoupon)
+	v1.DELETE("/coupons", authMiddleware(token), adminMiddleware(), couponHandler.DeleteCoupon)
+	v1.GET("/coupons/redemptions", authMiddleware(token), adminMiddleware(), couponHandler.ListCouponRedemptions)
+
+	// Staff (authenticated, admin for write ops)
+	v1.GET("/staff/all", authMiddleware(token), staffHandler.ListStaff)
+	v1.GET("/staff", authMiddleware(token), staffHandler.GetStaff)
+	v1.POST("/staff", authMiddleware(token), adminMiddleware(), staffHandler.CreateStaff)
+	v1.PUT("/staff", authMiddleware(token), adminMiddleware(), staffHandler.UpdateStaff)
+	v1.DELETE("/staff", authMiddleware(token), adminMiddleware(), staffHandler.DeleteStaff)
+	v1.GET("/staff/photo", authMiddleware(token), staffHandler.GetStaffPhoto)
+	v1.PUT("/staff/photo", authMiddleware(token), adminMiddleware(), rateLimitMiddleware(limiter, "upload", rateLimits.Upload), quotaMiddleware(quotaHandler.svc, domain.QuotaFileUpload), uploadMiddleware(uploads.StaffPhoto), staffHandler.SetStaffPhoto)
 
 	// AvailabilitySlots
 	v1.POST("/availabilityslots", authMiddleware(token), adminMiddleware(), availabilitySlotHandler.CreateSlot)
 	v1.GET("/availabilityslots", authMiddleware(token), availabilitySlotHandler.ListSlots)
-	v1.PUT("/availabilityslots", authMiddleware(token), adminMiddleware(), availabilitySlotHandler.UpdateSlot)
-	v1.DELETE("/availabilityslots", authMiddleware(token), adminMiddleware(), availabilitySlotHandler.DeleteSlot)
+	v1.PUT("/availabilityslots/:id", idFromPath, authMiddleware(token), adminMiddleware(), availabilitySlotHandler.UpdateSlot)
+	v1.DELETE("/availabilityslots/:id", idFromPath, authMiddleware(token), adminMiddleware(), availabilitySlotHandler.DeleteSlot)
+	// Deprecated, use the path routes
+	v1.PUT("/availabilityslots", deprecatedMiddleware("/v1/availabilityslots/{id}"), authMiddleware(token), adminMiddleware(), availabilitySlotHandler.UpdateSlot)
+	v1.DELETE("/availabilityslots", deprecatedMiddleware("/v1/availabilityslots/{id}"), authMiddleware(token), adminMiddleware(), availabilitySlotHandler.DeleteSlot)
 
 	// Appointments (authenticated)
 	v1.POST("/appointments", authMiddleware(token), appointmentHandler.CreateAppointment)
 	v1.GET("/appointments/all", authMiddleware(token), appointmentHandler.ListAppointments)
-	v1.GET("/appointments", authMiddleware(token), appointmentHandler.GetAppointment)
-	v1.PUT("/appointments", authMiddleware(token), appointmentHandler.UpdateAppointment)
-	v1.DELETE("/appointments", authMiddleware(token), appointmentHandler.DeleteAppointment)
+	v1.GET("/appointments/:id", idFromPath, authMiddleware(token), appointmentHandler.GetAppointment)
+	v1.PUT("/appointments/:id", idFromPath, authMiddleware(token), appointmentHandler.UpdateAppointment)
+	v1.DELETE("/appointments/:id", idFromPath, authMiddleware(token), appointmentHandler.DeleteAppointment)
+	v1.POST("/appointments/:id/cancel", idFromPath, authMiddleware(token), appointmentHandler.CancelAppointment)
+	// Deprecated, use the path routes
+	v1.GET("/appointments", deprecatedMiddleware("/v1/appointments/{id}"), authMiddleware(token), appointmentHandler.GetAppointment)
+	v1.PUT("/appointments", deprecatedMiddleware("/v1/appointments/{id}"), authMiddleware(token), appointmentHandler.UpdateAppointment)
+	v1.DELETE("/appointments", deprecatedMiddleware("/v1/appointments/{id}"), authMiddleware(token), appointmentHandler.DeleteAppointment)
 
 	// PaymentProofs (authenticated, admin for write ops)
-	v1.POST("/paymentproofs", authMiddleware(token), paymentProofHandler.CreatePaymentProof)
-	v1.GET("/paymentproofs", authMiddleware(token), paymentProofHandler.GetPaymentProofByID)
+	v1.POST("/paymentproofs", authMiddleware(token), rateLimitMiddleware(limiter, "upload", rateLimits.Upload), quotaMiddleware(quotaHandler.svc, domain.QuotaFileUpload), uploadMiddleware(uploads.PaymentProof), paymentProofHandler.CreatePaymentProof)
 	v1.GET("/paymentproofs/all", authMiddleware(token), paymentProofHandler.GetPaymentProofs)
-	v1.PUT("/paymentproofs", authMiddleware(token), adminMiddleware(), paymentProofHandler.UpdatePaymentProof)
-	v1.DELETE("/paymentproofs", authMiddleware(token), adminMiddleware(), paymentProofHandler.DeletePaymentProof)
+	v1.GET("/paymentproofs/:id", idFromPath, authMiddleware(token), paymentProofHandler.GetPaymentProofByID)
+	v1.PUT("/paymentproofs/:id", authMiddleware(token), adminMiddleware(), paymentProofHandler.UpdatePaymentProof)
+	v1.DELETE("/paymentproofs/:id", idFromPath, authMiddleware(token), adminMiddleware(), paymentProofHandler.DeletePaymentProof)
+	// Deprecated, use the path routes
+	v1.GET("/paymentproofs", deprecatedMiddleware("/v1/paymentproofs/{id}"), authMiddleware(token), paymentProofHandler.GetPaymentProofByID)
+	v1.PUT("/paymentproofs", deprecatedMiddleware("/v1/paymentproofs/{id}"), authMiddleware(token), adminMiddleware(), paymentProofHandler.UpdatePaymentProof)
+	v1.DELETE("/paymentproofs", deprecatedMiddleware("/v1/paymentproofs/{id}"), authMiddleware(token), adminMiddleware(), paymentProofHandler.DeletePaymentProof)
 
 	// QuoteImages (authenticated, admin for delete)
 	v1.GET("/quoteimages/all", authMiddleware(token), quoteImageHandler.GetQuoteImages)
 	v1.GET("/quoteimages", authMiddleware(token), quoteImageHandler.GetQuoteImageByID)
+	v1.POST("/quoteimages", authMiddleware(token), rateLimitMiddleware(limiter, "upload", rateLimits.Upload), quotaMiddleware(quotaHandler.svc, domain.QuotaFileUpload), uploadMiddleware(uploads.Quote), quoteImageHandler.CreateQuoteImage)
 	v1.DELETE("/quoteimages", authMiddleware(token), adminMiddleware(), quoteImageHandler.DeleteQuoteImage)
-	// If adding these later:
-	// v1.POST("/quoteimages", authMiddleware(token), quoteImageHandler.CreateQuoteImage)
+	// If adding this later:
 	// v1.PUT("/quoteimages", authMiddleware(token), quoteImageHandler.UpdateQuoteImage)
 
+	// Emails (admin)
+	v1.GET("/emails/deadletters", authMiddleware(token), adminMiddleware(), emailQueueHandler.ListDeadLetters)
+	v1.POST("/emails/deadletters/retry", authMiddleware(token), adminMiddleware(), emailQueueHandler.RetryDeadLetter)
+	v1.DELETE("/emails/deadletters", authMiddleware(token), adminMiddleware(), emailQueueHandler.DiscardDeadLetter)
+	v1.GET("/emails/logs", authMiddleware(token), adminMiddleware(), emailQueueHandler.ListEmailLogs)
+
+	// Jobs (admin)
+	v1.GET("/jobs/deadletters", authMiddleware(token), adminMiddleware(), jobQueueHandler.ListDeadLetters)
+	v1.POST("/jobs/deadletters/retry", authMiddleware(token), adminMiddleware(), jobQueueHandler.RetryDeadLetter)
+	v1.GET("/jobs", authMiddleware(token), adminMiddleware(), jobQueueHandler.ListQueued)
+	v1.GET("/jobs/:id", authMiddleware(token), adminMiddleware(), jobQueueHandler.GetJob)
+	v1.POST("/jobs/:id/retry", authMiddleware(token), adminMiddleware(), jobQueueHandler.RetryJob)
+	v1.DELETE("/jobs/:id", authMiddleware(token), adminMiddleware(), jobQueueHandler.DiscardJob)
+
+	// Reports (admin)
+	v1.POST("/reports", authMiddleware(token), adminMiddleware(), reportHandler.RequestReport)
+	v1.GET("/reports/utilization", authMiddleware(token), adminMiddleware(), reportHandler.GetStaffUtilization)
+	v1.GET("/reports/retention", authMiddleware(token), adminMiddleware(), reportHandler.GetClientRetention)
+
+	// Audit log (admin)
+	v1.GET("/audit/logs", authMiddleware(token), adminMiddleware(), auditLogHandler.ListAuditLogs)
+	v1.GET("/audit/entries", authMiddleware(token), adminMiddleware(), auditHandler.ListAuditEntries)
+
+	// Event store (admin)
+	v1.GET("/events", authMiddleware(token), adminMiddleware(), eventStoreHandler.ListEvents)
+
+	// Quotas (admin)
+	v1.GET("/quotas", authMiddleware(token), adminMiddleware(), quotaHandler.ListQuotas)
+	v1.PUT("/quotas", authMiddleware(token), adminMiddleware(), salonManagerMiddleware(), quotaHandler.SetQuota)
+
+	// Log level (admin)
+	v1.GET("/logs/level", authMiddleware(token), adminMiddleware(), logLevelHandler.GetLogLevel)
+	v1.PUT("/logs/level", authMiddleware(token), adminMiddleware(), salonManagerMiddleware(), logLevelHandler.SetLogLevel)
+
+	// Webhooks (admin)
+	v1.GET("/webhooks/all", authMiddleware(token), adminMiddleware(), webhookHandler.ListWebhooks)
+	v1.GET("/webhooks", authMiddleware(token), adminMiddleware(), webhookHandler.GetWebhook)
+	v1.POST("/webhooks", authMiddleware(token), adminMiddleware(), webhookHandler.CreateWebhook)
+	v1.PUT("/webhooks", authMiddleware(token), adminMiddleware(), webhookHandler.UpdateWebhook)
+	v1.DELETE("/webhooks", authMiddleware(token), adminMiddleware(), webhookHandler.DeleteWebhook)
+
+	// Notifications (authenticated, scoped to the caller, admin for the event stream)
+	v1.GET("/notifications", authMiddleware(token), notificationHandler.ListNotifications)
+	v1.GET("/notifications/stream", authMiddleware(token), adminMiddleware(), notificationHandler.StreamNotifications)
+	v1.PATCH("/notifications/read", authMiddleware(token), notificationHandler.MarkAsRead)
+	v1.PATCH("/notifications/read/all", authMiddleware(token), notificationHandler.MarkAllAsRead)
+
+	// Webhooks (unauthenticated, verified by provider signature)
+	v1.POST("/webhooks/payments", paymentWebhookHandler.HandlePaymentWebhook)
+
+	// GraphQL gateway of the dashboard (authenticated, clients see their own quotes and appointments)
+	if graphQLHandler.server != nil {
+		router.POST("/graphql", authMiddleware(token), graphQLHandler.Query)
+	}
+
+	// API v2, resources are addressed by path and translated for the v1 handlers
+	v2 := router.Group("/v2")
+
+	// Quotes (authenticated, admin for PATCH)
+	v2.POST("/quotes", authMiddleware(token), rateLimitMiddleware(limiter, "upload", rateLimits.Upload), quotaMiddleware(quotaHandler.svc, domain.QuotaQuoteCreation), uploadMiddleware(uploads.Quote), quoteHandler.CreateQuote)
+	v2.GET("/quotes", authMiddleware(token), quoteHandler.ListQuotes)
+	v2.GET("/quotes/:id", idFromPath, authMiddleware(token), quoteHandler.GetQuote)
+	v2.PUT("/quotes/:id", idFromPath, authMiddleware(token), quoteHandler.UpdateQuote)
+	v2.PATCH("/quotes/:id/state", idFromPath, authMiddleware(token), adminMiddleware(), quoteHandler.ChangeQuoteState)
+	v2.DELETE("/quotes/:id", idFromPath, authMiddleware(token), quoteHandler.DeleteQuote)
+	v2.PUT("/quotes/:id/coupon", idFromPath, authMiddleware(token), quoteHandler.ApplyCoupon)
+	v2.DELETE("/quotes/:id/coupon", idFromPath, authMiddleware(token), quoteHandler.RemoveCoupon)
+
+	// QuoteImages (authenticated)
+	v2.GET("/quotes/:id/images", quoteIDFromPath, authMiddleware(token), quoteImageHandler.GetQuoteImages)
+	v2.POST("/quotes/:id/images", quoteIDFromPath, authMiddleware(token), rateLimitMiddleware(limiter, "upload", rateLimits.Upload), quotaMiddleware(quotaHandler.svc, domain.QuotaFileUpload), uploadMiddleware(uploads.Quote), quoteImageHandler.CreateQuoteImage)
+
+	// Appointments (authenticated)
+	v2.POST("/appointments", authMiddleware(token), appointmentHandler.CreateAppointment)
+	v2.GET("/appointments", authMiddleware(token), appointmentHandler.ListAppointments)
+	v2.GET("/appointments/:id", idFromPath, authMiddleware(token), appointmentHandler.GetAppointment)
+	v2.PUT("/appointments/:id", idFromPath, authMiddleware(token), appointmentHandler.UpdateAppointment)
+	v2.DELETE("/appointments/:id", idFromPath, authMiddleware(token), appointmentHandler.DeleteAppointment)
+	v2.POST("/appointments/:id/cancel", idFromPath, authMiddleware(token), appointmentHandler.CancelAppointment)
+
 	return &Router{
-		router,
+		Engine:    router,
+		tlsConfig: tlsConfig,
 	}, nil
 }
 
-// Serve starts the HTTP server
-func (r *Router) Serve(listenAddr string) error {
-	return r.Run(listenAddr)
+// Serve starts the HTTP server, over TLS with HTTP/2 when it has a certificate, and blocks until ctx is
+// done, then it stops accepting connections and waits up to shutdownTimeout for the requests in flight.
+// Connections still open after that, such as notification streams, are closed
+func (r *Router) Serve(ctx context.Context, listenAddr string, shutdownTimeout time.Duration) error {
+	server := &http.Server{
+		Addr:      listenAddr,
+		Handler:   r.Engine.Handler(),
+		TLSConfig: r.tlsConfig,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		if server.TLSConfig != nil {
+			// The certificate comes from the TLS configuration
+			errs <- server.ListenAndServeTLS("", "")
+			return
+		}
+		errs <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("Shutting down the HTTP server", "timeout", shutdownTimeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("Requests still running after the shutdown timeout, closing their connections", "error", err)
+		return server.Close()
+	}
+
+	return nil
 }
@@ -0,0 +1,248 @@
+package http
+
+import (
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// salonHeaderKey is the header naming the salon a user registers in
+const salonHeaderKey = "X-Salon-ID"
+
+// SalonHandler represents the HTTP handler for salon-related requests
+type SalonHandler struct {
+	svc port.SalonService
+}
+
+// NewSalonHandler creates a new SalonHandler instance
+func NewSalonHandler(svc port.SalonService) *SalonHandler {
+	return &SalonHandler{
+		svc,
+	}
+}
+
+// salonResponse represents a salon response body
+type salonResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name" example:"Harajuku Roma"`
+	Slug      string    `json:"slug" example:"harajuku-roma"`
+	CreatedAt time.Time `json:"createdAt" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt time.Time `json:"updatedAt" example:"1970-01-01T00:00:00Z"`
+}
+
+// newSalonResponse is a helper function to create a response body for handling salon data
+func newSalonResponse(s *domain.Salon) *salonResponse {
+	return &salonResponse{
+		ID:        s.ID,
+		Name:      s.Name,
+		Slug:      s.Slug,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+// salonRequest represents the request body for creating or updating a salon
+type salonRequest struct {
+	Name string `json:"name" binding:"required,max=100" sanitize:"text" example:"Harajuku Roma"`
+	Slug string `json:"slug" binding:"required,max=64,slug" example:"harajuku-roma"`
+}
+
+// CreateSalon godoc
+//
+// @Summary        Register a new salon
+// @Description    Create a new salon, only the admins of the default salon manage them
+// @Tags           Salons
+// @Accept         json
+// @Produce        json
+// @Param          salon  body    salonRequest  true   "Salon Data"
+// @Success        200    {object}  salonResponse  "Salon created"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        401    {object}  errorResponse  "Unauthorized error"
+// @Failure        403    {object}  errorResponse  "Forbidden error"
+// @Failure        409    {object}  errorResponse  "Slug already taken"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/salons [post]
+// @Security       BearerAuth
+func (sh *SalonHandler) CreateSalon(ctx *gin.Context) {
+	var req salonRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	salon := &domain.Salon{
+		ID:   uuid.New(),
+		Name: req.Name,
+		Slug: req.Slug,
+	}
+
+	created, err := sh.svc.CreateSalon(ctx, salon)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newSalonResponse(created)
+	handleSuccess(ctx, rsp)
+}
+
+// listSalonsRequest represents the query for listing salons
+type listSalonsRequest struct {
+	pageRequest
+}
+
+// ListSalons godoc
+//
+// @Summary        List salons
+// @Description    List the salons with pagination, only the admins of the default salon see them all
+// @Tags           Salons
+// @Accept         json
+// @Produce        json
+// @Param          skip   query   uint64 false  "Page number, starting at 1"
+// @Param          limit  query   uint64 true   "Limit"
+// @Success        200    {object}  meta  "Salons displayed"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        401    {object}  errorResponse  "Unauthorized error"
+// @Failure        403    {object}  errorResponse  "Forbidden error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/salons/all [get]
+// @Security       BearerAuth
+func (sh *SalonHandler) ListSalons(ctx *gin.Context) {
+	var req listSalonsRequest
+	var salonsList []salonResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	salons, err := sh.svc.ListSalons(ctx, req.Skip, req.Limit)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, salon := range salons {
+		salonsList = append(salonsList, *newSalonResponse(&salon))
+	}
+
+	total := uint64(len(salonsList))
+	meta := newPageMeta(ctx, total, req.pageRequest)
+	rsp := toMap(meta, salonsList, "salons")
+
+	handleSuccess(ctx, rsp)
+}
+
+// GetSalon godoc
+//
+// @Summary        Get a salon
+// @Description    Get a salon by id, the admins of the other salons only get their own
+// @Tags           Salons
+// @Accept         json
+// @Produce        json
+// @Param          id   query   string true   "Salon ID"
+// @Success        200  {object}  salonResponse  "Salon displayed"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        401  {object}  errorResponse  "Unauthorized error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/salons [get]
+// @Security       BearerAuth
+func (sh *SalonHandler) GetSalon(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	salon, err := sh.svc.GetSalon(ctx, id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newSalonResponse(salon)
+	handleSuccess(ctx, rsp)
+}
+
+// UpdateSalon godoc
+//
+// @Summary        Update a salon
+// @Description    Rename a salon or change its slug by id
+// @Tags           Salons
+// @Accept         json
+// @Produce        json
+// @Param          id     query   string        true   "Salon ID"
+// @Param          salon  body    salonRequest  true   "Salon Data"
+// @Success        200    {object}  salonResponse  "Salon updated"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        401    {object}  errorResponse  "Unauthorized error"
+// @Failure        403    {object}  errorResponse  "Forbidden error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        409    {object}  errorResponse  "Slug already taken"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/salons [put]
+// @Security       BearerAuth
+func (sh *SalonHandler) UpdateSalon(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	var req salonRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	salon := &domain.Salon{
+		ID:   id,
+		Name: req.Name,
+		Slug: req.Slug,
+	}
+
+	updated, err := sh.svc.UpdateSalon(ctx, salon)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newSalonResponse(updated)
+	handleSuccess(ctx, rsp)
+}
+
+// salonMiddleware is a middleware that makes the unauthenticated request in the salon named by the
+// X-Salon-ID header, so the users registering are created in it. Without the header the request is
+// made in the default salon, an unknown salon is not found
+func salonMiddleware(svc port.SalonService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		header := ctx.GetHeader(salonHeaderKey)
+		if header == "" {
+			ctx.Request = ctx.Request.WithContext(util.WithSalon(ctx.Request.Context(), domain.DefaultSalonID))
+			ctx.Next()
+			return
+		}
+
+		id, err := uuid.Parse(header)
+		if err != nil {
+			validationError(ctx, newRequestError(salonHeaderKey, "uuid"))
+			ctx.Abort()
+			return
+		}
+
+		salon, err := svc.GetSalon(ctx, id)
+		if err != nil {
+			handleAbort(ctx, err)
+			return
+		}
+
+		ctx.Request = ctx.Request.WithContext(util.WithSalon(ctx.Request.Context(), salon.ID))
+		ctx.Next()
+	}
+}
@@ -0,0 +1,122 @@
+package http
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"golang.org/x/net/html"
+)
+
+// sanitizeTag marks the free-text fields of a request, like names and descriptions, that are shown in the
+// admin dashboard and in emails. Their HTML is stripped before the request is validated
+const sanitizeTag = "sanitize"
+
+// maxSanitizePasses bounds how many times a value is stripped, escaped markup like &lt;b&gt; turns into
+// tags once its text is unescaped and is stripped on the next pass
+const maxSanitizePasses = 3
+
+// sanitizingValidator strips the HTML of the fields marked with the sanitize tag of every bound
+// request, then validates it with the validator it wraps
+type sanitizingValidator struct {
+	binding.StructValidator
+}
+
+// newSanitizingValidator wraps validator so binding sanitizes the requests before validating them
+func newSanitizingValidator(validator binding.StructValidator) binding.StructValidator {
+	return &sanitizingValidator{validator}
+}
+
+// ValidateStruct sanitizes obj and validates it
+func (v *sanitizingValidator) ValidateStruct(obj any) error {
+	sanitizeStruct(reflect.ValueOf(obj))
+	return v.StructValidator.ValidateStruct(obj)
+}
+
+// sanitizeStruct strips the HTML of the string and string slice fields of value marked with the
+// sanitize tag, and of the fields of the structs it embeds
+func sanitizeStruct(value reflect.Value) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.Anonymous {
+			sanitizeStruct(value.Field(i))
+			continue
+		}
+		if field.Tag.Get(sanitizeTag) != "text" || !field.IsExported() {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if fieldValue.Kind() == reflect.Pointer {
+			if fieldValue.IsNil() {
+				continue
+			}
+			fieldValue = fieldValue.Elem()
+		}
+		if fieldValue.Kind() == reflect.String && fieldValue.CanSet() {
+			fieldValue.SetString(sanitizeText(fieldValue.String()))
+		}
+		if fieldValue.Kind() == reflect.Slice && fieldValue.Type().Elem().Kind() == reflect.String {
+			for j := 0; j < fieldValue.Len(); j++ {
+				fieldValue.Index(j).SetString(sanitizeText(fieldValue.Index(j).String()))
+			}
+		}
+	}
+}
+
+// sanitizeText strips the HTML tags and comments of s, along with the content of script and style
+// elements, and trims the spaces left around it. The text is kept unescaped, "Tom & Jerry" stays
+// as it is and the templates escape it when rendering
+func sanitizeText(s string) string {
+	for i := 0; i < maxSanitizePasses; i++ {
+		stripped := stripHTML(s)
+		if stripped == s {
+			break
+		}
+		s = stripped
+	}
+
+	return strings.TrimSpace(s)
+}
+
+// stripHTML returns the text of s without its markup
+func stripHTML(s string) string {
+	if !strings.ContainsAny(s, "<&") {
+		return s
+	}
+
+	var b strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(s))
+	skip := ""
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			// The input is a string, so the only error is its end
+			return b.String()
+		case html.TextToken:
+			if skip == "" {
+				b.Write(tokenizer.Text())
+			}
+		case html.StartTagToken:
+			name, _ := tokenizer.TagName()
+			if tag := string(name); tag == "script" || tag == "style" {
+				skip = tag
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			if string(name) == skip {
+				skip = ""
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Tatuaje pequeño", "Tatuaje pequeño"},
+		{"Tom & Jerry", "Tom & Jerry"},
+		{"size < 5cm and > 2cm", "size < 5cm and > 2cm"},
+		{"<b>bold</b> text", "bold text"},
+		{`<img src=x onerror="alert(1)">Ana`, "Ana"},
+		{"<script>alert(1)</script>Hola", "Hola"},
+		{"<style>body{}</style>Hola<!-- comment -->", "Hola"},
+		{"&lt;script&gt;alert(1)&lt;/script&gt;Hola", "Hola"},
+		{"  <p>  Juan  </p>  ", "Juan"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, sanitizeText(tt.in), tt.in)
+	}
+}
+
+func TestSanitizingValidatorStripsTaggedFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	validator := binding.Validator
+	binding.Validator = newSanitizingValidator(validator)
+	t.Cleanup(func() { binding.Validator = validator })
+
+	var got updateQuoteRequest
+	var bindErr error
+	router := gin.New()
+	router.POST("/", func(ctx *gin.Context) {
+		bindErr = ctx.ShouldBindJSON(&got)
+	})
+
+	body := `{"description": "<b>Rosa</b> en el brazo<script>alert(1)</script>", "version": 1}`
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.NoError(t, bindErr)
+	require.NotNil(t, got.Description)
+	assert.Equal(t, "Rosa en el brazo", *got.Description)
+}
+
+func TestSanitizingValidatorRunsBeforeValidation(t *testing.T) {
+	validator := binding.Validator
+	binding.Validator = newSanitizingValidator(validator)
+	t.Cleanup(func() { binding.Validator = validator })
+
+	req := serviceCategoryRequest{Name: "<script>alert(1)</script>"}
+	assert.Error(t, binding.Validator.ValidateStruct(&req))
+	assert.Empty(t, req.Name)
+}
+
+func TestSanitizingValidatorStripsTaggedSlices(t *testing.T) {
+	validator := binding.Validator
+	binding.Validator = newSanitizingValidator(validator)
+	t.Cleanup(func() { binding.Validator = validator })
+
+	req := updateStaffRequest{DisplayName: "Mariana", Specialties: []string{"<i>balayage</i>", " cortes bob "}}
+	_ = binding.Validator.ValidateStruct(&req)
+	assert.Equal(t, []string{"balayage", "cortes bob"}, req.Specialties)
+}
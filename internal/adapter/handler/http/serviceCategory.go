@@ -0,0 +1,222 @@
+package http
+
+import (
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ServiceCategoryHandler represents the HTTP handler for service-category-related requests
+type ServiceCategoryHandler struct {
+	svc port.ServiceCategoryService
+}
+
+// NewServiceCategoryHandler creates a new ServiceCategoryHandler instance
+func NewServiceCategoryHandler(svc port.ServiceCategoryService) *ServiceCategoryHandler {
+	return &ServiceCategoryHandler{
+		svc,
+	}
+}
+
+// serviceCategoryResponse represents a service category response body
+type serviceCategoryResponse struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name" example:"Color"`
+}
+
+// newServiceCategoryResponse is a helper function to create a response body for handling service category data
+func newServiceCategoryResponse(c *domain.ServiceCategory) *serviceCategoryResponse {
+	return &serviceCategoryResponse{
+		ID:   c.ID,
+		Name: c.Name,
+	}
+}
+
+// serviceCategoryRequest represents the request body for creating or updating a service category
+type serviceCategoryRequest struct {
+	Name string `json:"name" binding:"required" sanitize:"text" example:"Color"`
+}
+
+// CreateServiceCategory godoc
+//
+// @Summary        Register a new service category
+// @Description    Create a new category to group types of service
+// @Tags           ServiceCategories
+// @Accept         json
+// @Produce        json
+// @Param          category body    serviceCategoryRequest true   "Category Data"
+// @Success        200    {object}  serviceCategoryResponse  "Service category created"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        409    {object}  errorResponse  "Data conflict error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/servicecategories [post]
+func (sch *ServiceCategoryHandler) CreateServiceCategory(ctx *gin.Context) {
+	var req serviceCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	category := &domain.ServiceCategory{
+		ID:   uuid.New(),
+		Name: req.Name,
+	}
+
+	created, err := sch.svc.CreateServiceCategory(ctx, category)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newServiceCategoryResponse(created)
+	handleSuccess(ctx, rsp)
+}
+
+// listServiceCategoriesRequest represents the query for listing service categories
+type listServiceCategoriesRequest struct {
+	pageRequest
+}
+
+// ListServiceCategories godoc
+//
+// @Summary        List service categories
+// @Description    List service categories with pagination
+// @Tags           ServiceCategories
+// @Accept         json
+// @Produce        json
+// @Param          skip   query   uint64 false  "Page number, starting at 1"
+// @Param          limit  query   uint64 true   "Limit"
+// @Success        200    {object}  meta  "Service categories displayed"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/servicecategories/all [get]
+func (sch *ServiceCategoryHandler) ListServiceCategories(ctx *gin.Context) {
+	var req listServiceCategoriesRequest
+	var categoriesList []serviceCategoryResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	categories, err := sch.svc.ListServiceCategories(ctx, req.Skip, req.Limit)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, category := range categories {
+		categoriesList = append(categoriesList, *newServiceCategoryResponse(&category))
+	}
+
+	total := uint64(len(categoriesList))
+	meta := newPageMeta(ctx, total, req.pageRequest)
+	rsp := toMap(meta, categoriesList, "serviceCategories")
+
+	handleSuccess(ctx, rsp)
+}
+
+// GetServiceCategory godoc
+//
+// @Summary        Get a service category
+// @Description    Get a service category by id
+// @Tags           ServiceCategories
+// @Accept         json
+// @Produce        json
+// @Param          id   query   string true   "Service Category ID"
+// @Success        200  {object}  serviceCategoryResponse  "Service category displayed"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/servicecategories [get]
+func (sch *ServiceCategoryHandler) GetServiceCategory(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	category, err := sch.svc.GetServiceCategory(ctx, id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newServiceCategoryResponse(category)
+	handleSuccess(ctx, rsp)
+}
+
+// UpdateServiceCategory godoc
+//
+// @Summary        Update a service category
+// @Description    Rename a service category by id
+// @Tags           ServiceCategories
+// @Accept         json
+// @Produce        json
+// @Param          id       query   string                 true   "Service Category ID"
+// @Param          category body    serviceCategoryRequest true   "Category Data"
+// @Success        200    {object}  serviceCategoryResponse  "Service category updated"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        409    {object}  errorResponse  "Data conflict error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/servicecategories [put]
+func (sch *ServiceCategoryHandler) UpdateServiceCategory(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	var req serviceCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	category := &domain.ServiceCategory{
+		ID:   id,
+		Name: req.Name,
+	}
+
+	updated, err := sch.svc.UpdateServiceCategory(ctx, category)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newServiceCategoryResponse(updated)
+	handleSuccess(ctx, rsp)
+}
+
+// DeleteServiceCategory godoc
+//
+// @Summary        Delete a service category
+// @Description    Delete a service category that no type of service belongs to
+// @Tags           ServiceCategories
+// @Accept         json
+// @Produce        json
+// @Param          id   query   string true   "Service Category ID"
+// @Success        200    {object}  string  "Service category deleted successfully"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        409    {object}  errorResponse  "Category still in use"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/servicecategories [delete]
+func (sch *ServiceCategoryHandler) DeleteServiceCategory(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	err = sch.svc.DeleteServiceCategory(ctx, id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, localize(ctx, "message.service_category_deleted"))
+}
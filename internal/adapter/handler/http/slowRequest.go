@@ -0,0 +1,70 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// slowRequestMiddleware is a middleware that logs the requests taking longer than threshold with their
+// route, user and status, to catch the endpoints degrading over time. When the request is traced the log
+// tells the time spent waiting on the database and the cache apart. A zero threshold disables it
+func slowRequestMiddleware(threshold time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if threshold <= 0 {
+			ctx.Next()
+			return
+		}
+
+		var timings *util.Timings
+		if trace.SpanFromContext(ctx.Request.Context()).IsRecording() {
+			var reqCtx context.Context
+			reqCtx, timings = util.WithTimings(ctx.Request.Context())
+			ctx.Request = ctx.Request.WithContext(reqCtx)
+		}
+
+		started := time.Now()
+		ctx.Next()
+
+		elapsed := time.Since(started)
+		if elapsed < threshold {
+			return
+		}
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched route"
+		}
+
+		attrs := []any{
+			"method", ctx.Request.Method,
+			"route", route,
+			"status", ctx.Writer.Status(),
+			"duration", elapsed,
+			"threshold", threshold,
+		}
+
+		// The routes without authentication have no user
+		if payload, ok := ctx.Get(authorizationPayloadKey); ok {
+			attrs = append(attrs, "user_id", payload.(*domain.TokenPayload).UserID)
+		}
+
+		if timings != nil {
+			for _, dependency := range []string{"db", "cache"} {
+				timing := timings.Get(dependency)
+				attrs = append(attrs, slog.Group(dependency,
+					"duration", timing.Duration,
+					"calls", timing.Calls,
+				))
+			}
+		}
+
+		slog.WarnContext(ctx.Request.Context(), "Slow request", attrs...)
+	}
+}
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlowRequestMiddleware(t *testing.T) {
+	var logs bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	router := gin.New()
+	router.Use(slowRequestMiddleware(20 * time.Millisecond))
+	router.GET("/quotes/:id", func(ctx *gin.Context) {
+		ctx.Set(authorizationPayloadKey, &domain.TokenPayload{UserID: userID})
+		if ctx.Query("slow") != "" {
+			time.Sleep(30 * time.Millisecond)
+		}
+		ctx.Status(http.StatusOK)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/quotes/1", nil))
+	assert.Empty(t, logs.String())
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/quotes/1?slow=true", nil))
+	assert.Contains(t, logs.String(), `msg="Slow request"`)
+	assert.Contains(t, logs.String(), "route=/quotes/:id")
+	assert.Contains(t, logs.String(), "user_id="+userID.String())
+	// Without tracing there is no breakdown
+	assert.NotContains(t, logs.String(), "db.duration")
+}
@@ -0,0 +1,377 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// StaffHandler represents the HTTP handler for staff-related requests
+type StaffHandler struct {
+	svc  port.StaffService
+	urls fileURLs
+}
+
+// NewStaffHandler creates a new StaffHandler instance, signer may be nil to return the photo paths unsigned
+func NewStaffHandler(svc port.StaffService, signer port.FileURLSigner) *StaffHandler {
+	return &StaffHandler{
+		svc,
+		fileURLs{signer},
+	}
+}
+
+// staffResponse represents a staff member response body
+type staffResponse struct {
+	ID               uuid.UUID   `json:"id"`
+	UserID           uuid.UUID   `json:"userId"`
+	DisplayName      string      `json:"displayName" example:"Mariana"`
+	Bio              string      `json:"bio" example:"Colorista con diez años de experiencia"`
+	Specialties      []string    `json:"specialties" example:"balayage,cortes bob"`
+	PhotoURL         string      `json:"photoUrl,omitempty"`
+	Active           bool        `json:"active" example:"true"`
+	TypeOfServiceIDs []uuid.UUID `json:"typeOfServiceIds"`
+	CreatedAt        time.Time   `json:"createdAt" example:"1970-01-01T00:00:00Z"`
+	UpdatedAt        time.Time   `json:"updatedAt" example:"1970-01-01T00:00:00Z"`
+}
+
+// newStaffResponse is a helper function to create a response body for handling staff data
+func newStaffResponse(s *domain.Staff, urls fileURLs) *staffResponse {
+	specialties := s.Specialties
+	if specialties == nil {
+		specialties = []string{}
+	}
+
+	typeOfServiceIDs := s.TypeOfServiceIDs
+	if typeOfServiceIDs == nil {
+		typeOfServiceIDs = []uuid.UUID{}
+	}
+
+	return &staffResponse{
+		ID:               s.ID,
+		UserID:           s.UserID,
+		DisplayName:      s.DisplayName,
+		Bio:              s.Bio,
+		Specialties:      specialties,
+		PhotoURL:         urls.url(s.PhotoURL),
+		Active:           s.Active,
+		TypeOfServiceIDs: typeOfServiceIDs,
+		CreatedAt:        s.CreatedAt,
+		UpdatedAt:        s.UpdatedAt,
+	}
+}
+
+// createStaffRequest represents the request body for adding an admin to the staff directory
+type createStaffRequest struct {
+	UserID      string   `json:"userId" binding:"required,uuid"`
+	DisplayName string   `json:"displayName" binding:"required,max=100" sanitize:"text" example:"Mariana"`
+	Bio         string   `json:"bio" binding:"max=2000" sanitize:"text" example:"Colorista con diez años de experiencia"`
+	Specialties []string `json:"specialties" binding:"max=20,dive,max=50" sanitize:"text" example:"balayage,cortes bob"`
+}
+
+// CreateStaff godoc
+//
+// @Summary        Add a staff member
+// @Description    Create the profile of an admin in the staff directory, the admin's availability slots and service offerings are the stylist's
+// @Tags           Staff
+// @Accept         json
+// @Produce        json
+// @Param          staff  body    createStaffRequest  true   "Staff Data"
+// @Success        200    {object}  staffResponse  "Staff member created"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        401    {object}  errorResponse  "Unauthorized error"
+// @Failure        403    {object}  errorResponse  "User is not an admin"
+// @Failure        404    {object}  errorResponse  "User not found"
+// @Failure        409    {object}  errorResponse  "The admin already has a profile"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/staff [post]
+// @Security       BearerAuth
+func (sh *StaffHandler) CreateStaff(ctx *gin.Context) {
+	var req createStaffRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	staff := &domain.Staff{
+		ID:          uuid.New(),
+		UserID:      uuid.MustParse(req.UserID),
+		DisplayName: req.DisplayName,
+		Bio:         req.Bio,
+		Specialties: req.Specialties,
+		Active:      true,
+	}
+
+	created, err := sh.svc.CreateStaff(ctx, staff)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newStaffResponse(created, sh.urls)
+	handleSuccess(ctx, rsp)
+}
+
+// listStaffRequest represents the query for listing the staff directory
+type listStaffRequest struct {
+	pageRequest
+	Active          *bool   `form:"active"`
+	Specialty       string  `form:"specialty" binding:"max=50"`
+	TypeOfServiceID *string `form:"typeOfServiceId" binding:"omitempty,uuid"`
+}
+
+// ListStaff godoc
+//
+// @Summary        List the staff directory
+// @Description    List the stylists with pagination, optionally by activity, specialty or the type of service they offer
+// @Tags           Staff
+// @Accept         json
+// @Produce        json
+// @Param          active           query   bool   false  "Only the active or the inactive stylists"
+// @Param          specialty        query   string false  "Specialty"
+// @Param          typeOfServiceId  query   string false  "Type of Service ID"
+// @Param          skip   query   uint64 false  "Page number, starting at 1"
+// @Param          limit  query   uint64 true   "Limit"
+// @Success        200    {object}  meta  "Staff displayed"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        401    {object}  errorResponse  "Unauthorized error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/staff/all [get]
+// @Security       BearerAuth
+func (sh *StaffHandler) ListStaff(ctx *gin.Context) {
+	var req listStaffRequest
+	var staffList []staffResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	staff, err := sh.svc.ListStaff(ctx, port.StaffFilter{
+		Active:          req.Active,
+		Specialty:       req.Specialty,
+		TypeOfServiceID: parseOptionalUUID(req.TypeOfServiceID),
+		Skip:            req.Skip,
+		Limit:           req.Limit,
+	})
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, s := range staff {
+		staffList = append(staffList, *newStaffResponse(&s, sh.urls))
+	}
+
+	total := uint64(len(staffList))
+	meta := newPageMeta(ctx, total, req.pageRequest)
+	rsp := toMap(meta, staffList, "staff")
+
+	handleSuccess(ctx, rsp)
+}
+
+// GetStaff godoc
+//
+// @Summary        Get a staff member
+// @Description    Get a stylist of the staff directory by id
+// @Tags           Staff
+// @Accept         json
+// @Produce        json
+// @Param          id   query   string true   "Staff ID"
+// @Success        200  {object}  staffResponse  "Staff member displayed"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        401  {object}  errorResponse  "Unauthorized error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/staff [get]
+// @Security       BearerAuth
+func (sh *StaffHandler) GetStaff(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	staff, err := sh.svc.GetStaff(ctx, id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newStaffResponse(staff, sh.urls)
+	handleSuccess(ctx, rsp)
+}
+
+// updateStaffRequest represents the request body for updating a staff member
+type updateStaffRequest struct {
+	DisplayName string   `json:"displayName" binding:"required,max=100" sanitize:"text" example:"Mariana"`
+	Bio         string   `json:"bio" binding:"max=2000" sanitize:"text" example:"Colorista con diez años de experiencia"`
+	Specialties []string `json:"specialties" binding:"max=20,dive,max=50" sanitize:"text" example:"balayage,cortes bob"`
+	Active      *bool    `json:"active" binding:"required" example:"true"`
+}
+
+// UpdateStaff godoc
+//
+// @Summary        Update a staff member
+// @Description    Update the profile of a stylist by id, an inactive stylist keeps its slots but is given no new slots or services
+// @Tags           Staff
+// @Accept         json
+// @Produce        json
+// @Param          id     query   string              true   "Staff ID"
+// @Param          staff  body    updateStaffRequest  true   "Staff Data"
+// @Success        200    {object}  staffResponse  "Staff member updated"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        401    {object}  errorResponse  "Unauthorized error"
+// @Failure        403    {object}  errorResponse  "Forbidden error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/staff [put]
+// @Security       BearerAuth
+func (sh *StaffHandler) UpdateStaff(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	var req updateStaffRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	staff := &domain.Staff{
+		ID:          id,
+		DisplayName: req.DisplayName,
+		Bio:         req.Bio,
+		Specialties: req.Specialties,
+		Active:      *req.Active,
+	}
+
+	updated, err := sh.svc.UpdateStaff(ctx, staff)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newStaffResponse(updated, sh.urls)
+	handleSuccess(ctx, rsp)
+}
+
+// DeleteStaff godoc
+//
+// @Summary        Delete a staff member
+// @Description    Remove a stylist from the staff directory, the admin account keeps its slots and service offerings
+// @Tags           Staff
+// @Accept         json
+// @Produce        json
+// @Param          id   query   string true   "Staff ID"
+// @Success        200    {object}  string  "Staff member deleted successfully"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        401    {object}  errorResponse  "Unauthorized error"
+// @Failure        403    {object}  errorResponse  "Forbidden error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/staff [delete]
+// @Security       BearerAuth
+func (sh *StaffHandler) DeleteStaff(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	err = sh.svc.DeleteStaff(ctx, id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, localize(ctx, "message.staff_deleted"))
+}
+
+// setStaffPhotoRequest represents the form for uploading the photo of a staff member
+type setStaffPhotoRequest struct {
+	ID string `form:"id" binding:"required,uuid"`
+}
+
+// SetStaffPhoto godoc
+//
+// @Summary        Upload the photo of a staff member
+// @Description    Upload the photo of a stylist, replacing the previous one
+// @Tags           Staff
+// @Accept         multipart/form-data
+// @Produce        json
+// @Param          id    formData  string true   "Staff ID"
+// @Param          file  formData  file   true   "Photo"
+// @Success        200    {object}  staffResponse  "Staff photo uploaded"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        401    {object}  errorResponse  "Unauthorized error"
+// @Failure        403    {object}  errorResponse  "Forbidden error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        413    {object}  errorResponse  "File too large"
+// @Failure        415    {object}  errorResponse  "Unsupported file type"
+// @Failure        422    {object}  errorResponse  "File rejected by the antivirus"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/staff/photo [put]
+// @Security       BearerAuth
+func (sh *StaffHandler) SetStaffPhoto(ctx *gin.Context) {
+	var req setStaffPhotoRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	file, ok := formFile(ctx, "file")
+	if !ok {
+		return
+	}
+
+	staff, err := sh.svc.SetStaffPhoto(ctx, uuid.MustParse(req.ID), file, file.Size(), file.Name())
+	if err != nil {
+		handleError(ctx, file.uploadError(err))
+		return
+	}
+
+	rsp := newStaffResponse(staff, sh.urls)
+	handleSuccess(ctx, rsp)
+}
+
+// GetStaffPhoto godoc
+//
+// @Summary        Get the photo of a staff member
+// @Description    Download the photo of a stylist by the id of the staff member
+// @Tags           Staff
+// @Produce        octet-stream
+// @Param          id   query   string true   "Staff ID"
+// @Success        200  {file}    file  "Image file"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        401  {object}  errorResponse  "Unauthorized error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/staff/photo [get]
+// @Security       BearerAuth
+func (sh *StaffHandler) GetStaffPhoto(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	staff, fileData, err := sh.svc.GetStaffPhoto(ctx, id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	mimeType := http.DetectContentType(fileData)
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%s", filepath.Base(staff.PhotoURL)))
+	ctx.Data(http.StatusOK, mimeType, fileData)
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+
+	"harajuku/backend/internal/adapter/config"
+
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// newTLSConfig returns the TLS configuration of the server, with the certificate files or with certificates
+// from Let's Encrypt for the autocert domains, and nil when the server serves plain HTTP. The certificate
+// files are loaded here so a wrong path fails on startup
+func newTLSConfig(config *config.HTTP) (*tls.Config, error) {
+	hasFiles := config.TLSCertFile != "" || config.TLSKeyFile != ""
+	domains := splitList(config.AutocertDomains)
+
+	switch {
+	case hasFiles && len(domains) > 0:
+		return nil, fmt.Errorf("TLS takes either a certificate file or autocert domains, not both")
+	case hasFiles:
+		if config.TLSCertFile == "" || config.TLSKeyFile == "" {
+			return nil, fmt.Errorf("TLS needs both the certificate file and the key file")
+		}
+
+		cert, err := tls.LoadX509KeyPair(config.TLSCertFile, config.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the TLS certificate: %w", err)
+		}
+
+		return &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{cert},
+			NextProtos:   []string{"h2", "http/1.1"},
+		}, nil
+	case len(domains) > 0:
+		// Certificates are validated with TLS-ALPN-01 on the server's own port, which has to be 443
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(domains...),
+			Cache:      autocert.DirCache(config.AutocertCacheDir),
+			Email:      config.AutocertEmail,
+		}
+
+		tlsConfig := manager.TLSConfig()
+		tlsConfig.MinVersion = tls.VersionTLS12
+		return tlsConfig, nil
+	}
+
+	return nil, nil
+}
+
+// splitList splits a list separated by commas, skipping blank entries
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,116 @@
+package http
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSelfSignedCert writes a certificate for 127.0.0.1 and its key as PEM files
+func writeSelfSignedCert(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	certFile, keyFile := writeSelfSignedCert(t)
+
+	tlsConfig, err := newTLSConfig(&config.HTTP{})
+	assert.NoError(t, err)
+	assert.Nil(t, tlsConfig)
+
+	tlsConfig, err = newTLSConfig(&config.HTTP{TLSCertFile: certFile, TLSKeyFile: keyFile})
+	require.NoError(t, err)
+	assert.Len(t, tlsConfig.Certificates, 1)
+
+	tlsConfig, err = newTLSConfig(&config.HTTP{AutocertDomains: "api.harajuku.mx, ", AutocertCacheDir: t.TempDir()})
+	require.NoError(t, err)
+	assert.NotNil(t, tlsConfig.GetCertificate)
+
+	for _, cfg := range []config.HTTP{
+		{TLSCertFile: certFile},
+		{TLSCertFile: certFile, TLSKeyFile: filepath.Join(t.TempDir(), "missing.pem")},
+		{TLSCertFile: certFile, TLSKeyFile: keyFile, AutocertDomains: "api.harajuku.mx"},
+	} {
+		_, err := newTLSConfig(&cfg)
+		assert.Error(t, err)
+	}
+}
+
+func TestServeOverTLSWithHTTP2(t *testing.T) {
+	certFile, keyFile := writeSelfSignedCert(t)
+	tlsConfig, err := newTLSConfig(&config.HTTP{TLSCertFile: certFile, TLSKeyFile: keyFile})
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/", func(ctx *gin.Context) { ctx.String(http.StatusOK, ctx.Request.Proto) })
+	router := &Router{Engine: engine, tlsConfig: tlsConfig}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- router.Serve(ctx, addr, time.Second) }()
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, <-done)
+	})
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+		ForceAttemptHTTP2: true,
+	}}
+
+	var rsp *http.Response
+	require.Eventually(t, func() bool {
+		rsp, err = client.Get("https://" + addr + "/")
+		return err == nil
+	}, 2*time.Second, 20*time.Millisecond)
+	defer rsp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, rsp.StatusCode)
+	assert.Equal(t, 2, rsp.ProtoMajor)
+}
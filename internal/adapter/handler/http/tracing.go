@@ -0,0 +1,54 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracingMiddleware is a middleware that starts a server span for every request but the probes, continuing
+// the trace of the caller when it sends a traceparent header. The span is named after the route instead of
+// the path so requests to the same handler are grouped together
+func tracingMiddleware() gin.HandlerFunc {
+	tracer := otel.Tracer("harajuku/backend/http")
+
+	return func(ctx *gin.Context) {
+		// Probes run every few seconds and would drown the traces worth looking at
+		if path := ctx.Request.URL.Path; path == "/healthz" || path == "/readyz" {
+			ctx.Next()
+			return
+		}
+
+		reqCtx := otel.GetTextMapPropagator().Extract(ctx.Request.Context(), propagation.HeaderCarrier(ctx.Request.Header))
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched route"
+		}
+
+		reqCtx, span := tracer.Start(reqCtx, ctx.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", ctx.Request.Method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", ctx.Request.URL.Path),
+				attribute.String("client.address", ctx.ClientIP()),
+			),
+		)
+		defer span.End()
+
+		ctx.Request = ctx.Request.WithContext(reqCtx)
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
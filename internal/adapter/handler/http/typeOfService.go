@@ -5,6 +5,9 @@ import (
 	"harajuku/backend/internal/core/domain"
 	"harajuku/backend/internal/core/port"
 	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -12,36 +15,90 @@ import (
 
 // TypeOfServiceHandler representa el controlador HTTP para las solicitudes relacionadas con tipos de servicio
 type TypeOfServiceHandler struct {
-	svc port.TypeOfServiceService
+	svc  port.TypeOfServiceService
+	urls fileURLs
 }
 
-// NewTypeOfServiceHandler crea una nueva instancia de TypeOfServiceHandler
-func NewTypeOfServiceHandler(svc port.TypeOfServiceService) *TypeOfServiceHandler {
+// NewTypeOfServiceHandler crea una nueva instancia de TypeOfServiceHandler, signer puede ser nil para devolver las rutas sin firmar
+func NewTypeOfServiceHandler(svc port.TypeOfServiceService, signer port.FileURLSigner) *TypeOfServiceHandler {
 	return &TypeOfServiceHandler{
 		svc,
+		fileURLs{signer},
 	}
 }
 
 // typeOfServiceResponse representa la respuesta
 type typeOfServiceResponse struct {
-	ID    uuid.UUID `json:"id"`
-	Name  string    `json:"name"`
-	Price float64   `json:"price"`
+	ID                      uuid.UUID                    `json:"id"`
+	Name                    string                       `json:"name"`
+	Description             string                       `json:"description"`
+	PreparationInstructions string                       `json:"preparationInstructions"`
+	Price                   float64                      `json:"price"`
+	Currency                string                       `json:"currency"`
+	CurrencyFormat          currencyFormatResponse       `json:"currencyFormat"`
+	DurationMinutes         int                          `json:"durationMinutes"`
+	DurationMinMinutes      *int                         `json:"durationMinMinutes"`
+	DurationMaxMinutes      *int                         `json:"durationMaxMinutes"`
+	CategoryID              *uuid.UUID                   `json:"categoryId"`
+	Archived                bool                         `json:"archived"`
+	Images                  []typeOfServiceImageResponse `json:"images"`
+	OfferedBy               []uuid.UUID                  `json:"offeredBy"`
+	DeletedAt               *time.Time                   `json:"deletedAt,omitempty"`
+}
+
+// typeOfServiceImageResponse representa una imagen de muestra de un tipo de servicio
+type typeOfServiceImageResponse struct {
+	ID              uuid.UUID `json:"id"`
+	TypeOfServiceID uuid.UUID `json:"typeOfServiceId"`
+	URL             string    `json:"url"`
+}
+
+// newTypeOfServiceImageResponse convierte un objeto domain.TypeOfServiceImage en una respuesta de imagen
+func newTypeOfServiceImageResponse(i *domain.TypeOfServiceImage, urls fileURLs) typeOfServiceImageResponse {
+	return typeOfServiceImageResponse{
+		ID:              i.ID,
+		TypeOfServiceID: i.TypeOfServiceID,
+		URL:             urls.url(i.URL),
+	}
 }
 
 // newTypeOfServiceResponse convierte un objeto domain.TypeOfService en una respuesta de tipo de servicio
-func newTypeOfServiceResponse(s *domain.TypeOfService) *typeOfServiceResponse {
+func newTypeOfServiceResponse(s *domain.TypeOfService, urls fileURLs) *typeOfServiceResponse {
+	images := make([]typeOfServiceImageResponse, len(s.Images))
+	for i, image := range s.Images {
+		images[i] = newTypeOfServiceImageResponse(&image, urls)
+	}
+
 	return &typeOfServiceResponse{
-		ID:    s.ID,
-		Name:  s.Name,
-		Price: s.Price,
+		ID:                      s.ID,
+		Name:                    s.Name,
+		Description:             s.Description,
+		PreparationInstructions: s.PreparationInstructions,
+		Price:                   s.Price,
+		Currency:                string(s.Currency),
+		CurrencyFormat:          newCurrencyFormatResponse(s.Currency),
+		DurationMinutes:         s.DurationMinutes,
+		DurationMinMinutes:      s.DurationMinMinutes,
+		DurationMaxMinutes:      s.DurationMaxMinutes,
+		CategoryID:              s.CategoryID,
+		Archived:                s.Archived,
+		Images:                  images,
+		OfferedBy:               s.OfferedBy,
+		DeletedAt:               s.DeletedAt,
 	}
 }
 
 // createTypeOfServiceRequest representa el cuerpo de la solicitud para crear un tipo de servicio
 type createTypeOfServiceRequest struct {
-	Name  string  `json:"name" binding:"required"`
-	Price float64 `json:"price" binding:"required"`
+	Name                    string  `json:"name" binding:"required" sanitize:"text"`
+	Description             string  `json:"description" binding:"max=2000" sanitize:"text"`
+	PreparationInstructions string  `json:"preparationInstructions" binding:"max=2000"`
+	Price                   float64 `json:"price" binding:"required"`
+	Currency                string  `json:"currency" binding:"omitempty,len=3" example:"MXN"`
+	DurationMinutes         int     `json:"durationMinutes" binding:"required,min=1"`
+	DurationMinMinutes      *int    `json:"durationMinMinutes" binding:"omitempty,min=1"`
+	DurationMaxMinutes      *int    `json:"durationMaxMinutes" binding:"omitempty,min=1"`
+	CategoryID              *string `json:"categoryId" binding:"omitempty,uuid"`
 }
 
 // CreateTypeOfService godoc
@@ -53,10 +110,15 @@ type createTypeOfServiceRequest struct {
 // @Produce        json
 // @Param          name   body    string  true   "Name"
 // @Param          price  body    float64 true  "Price"
+// @Param          durationMinutes  body    int true  "Duration in minutes"
+// @Param          description  body    string false  "Description"
+// @Param          preparationInstructions  body    string false  "Preparation instructions"
+// @Param          durationMinMinutes  body    int false  "Estimated minimum duration in minutes"
+// @Param          durationMaxMinutes  body    int false  "Estimated maximum duration in minutes"
 // @Success        200    {object}  typeOfServiceResponse  "Type of service created"
 // @Failure        400    {object}  errorResponse  "Validation error"
 // @Failure        500    {object}  errorResponse  "Internal server error"
-// @Router         /type-of-services [post]
+// @Router         /v1/typesofservice [post]
 func (tsh *TypeOfServiceHandler) CreateTypeOfService(ctx *gin.Context) {
 	var req createTypeOfServiceRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -65,9 +127,16 @@ func (tsh *TypeOfServiceHandler) CreateTypeOfService(ctx *gin.Context) {
 	}
 
 	service := &domain.TypeOfService{
-		ID:    uuid.New(),
-		Name:  req.Name,
-		Price: req.Price,
+		ID:                      uuid.New(),
+		Name:                    req.Name,
+		Description:             req.Description,
+		PreparationInstructions: req.PreparationInstructions,
+		Price:                   req.Price,
+		Currency:                domain.Currency(strings.ToUpper(req.Currency)),
+		DurationMinutes:         req.DurationMinutes,
+		DurationMinMinutes:      req.DurationMinMinutes,
+		DurationMaxMinutes:      req.DurationMaxMinutes,
+		CategoryID:              parseOptionalUUID(req.CategoryID),
 	}
 
 	createdService, err := tsh.svc.CreateTypeOfService(ctx, service)
@@ -76,14 +145,18 @@ func (tsh *TypeOfServiceHandler) CreateTypeOfService(ctx *gin.Context) {
 		return
 	}
 
-	rsp := newTypeOfServiceResponse(createdService)
+	rsp := newTypeOfServiceResponse(createdService, tsh.urls)
 	handleSuccess(ctx, rsp)
 }
 
 // listTypeOfServicesRequest representa los parámetros de la consulta para listar tipos de servicio
 type listTypeOfServicesRequest struct {
-	Skip  uint64 `form:"skip" binding:"required,min=0"`
-	Limit uint64 `form:"limit" binding:"required,min=5"`
+	pageRequest
+	CategoryID      *string `form:"categoryId" binding:"omitempty,uuid"`
+	IncludeArchived bool    `form:"includeArchived"`
+	IncludeDeleted  bool    `form:"includeDeleted"`
+	Name            string  `form:"name"`
+	PriceOrder      string  `form:"priceOrder" binding:"omitempty,oneof=asc desc"`
 }
 
 // ListTypeOfServices godoc
@@ -93,12 +166,20 @@ type listTypeOfServicesRequest struct {
 // @Tags           TypeOfServices
 // @Accept         json
 // @Produce        json
-// @Param          skip   query   uint64 true   "Skip"
+// @Param          skip   query   uint64 false  "Page number, starting at 1"
 // @Param          limit  query   uint64 true   "Limit"
+// @Param          categoryId  query   string false   "Service Category ID"
+// @Param          includeArchived  query   bool false   "Include archived types of service (admin only)"
+// @Param          includeDeleted  query   bool false   "Include soft-deleted types of service (admin only)"
+// @Param          name  query   string false   "Search by name"
+// @Param          priceOrder  query   string false   "Sort by price" Enums(asc, desc)
+// @Param          If-None-Match  header  string false  "ETag of the cached response"
 // @Success        200    {object}  meta  "Types of services displayed"
+// @Header         200  {string}  ETag  "Weak ETag of the response"
+// @Success        304  "Not modified"
 // @Failure        400    {object}  errorResponse  "Validation error"
 // @Failure        500    {object}  errorResponse  "Internal server error"
-// @Router         /type-of-services [get]
+// @Router         /v1/typesofservice/all [get]
 func (tsh *TypeOfServiceHandler) ListTypeOfServices(ctx *gin.Context) {
 	var req listTypeOfServicesRequest
 	var servicesList []typeOfServiceResponse
@@ -108,21 +189,37 @@ func (tsh *TypeOfServiceHandler) ListTypeOfServices(ctx *gin.Context) {
 		return
 	}
 
-	services, err := tsh.svc.ListTypeOfServices(ctx, req.Skip, req.Limit)
+	// Archived and deleted types of service are only listed for admins managing the catalog
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+	if (req.IncludeArchived || req.IncludeDeleted) && authPayload.Role != domain.Admin {
+		handleError(ctx, domain.ErrForbidden)
+		return
+	}
+
+	filter := port.TypeOfServiceFilter{
+		CategoryID:      parseOptionalUUID(req.CategoryID),
+		IncludeArchived: req.IncludeArchived,
+		IncludeDeleted:  req.IncludeDeleted,
+		Name:            strings.TrimSpace(req.Name),
+		PriceOrder:      req.PriceOrder,
+		Skip:            req.Skip,
+		Limit:           req.Limit,
+	}
+
+	services, total, err := tsh.svc.ListTypeOfServices(ctx, filter)
 	if err != nil {
 		handleError(ctx, err)
 		return
 	}
 
 	for _, service := range services {
-		servicesList = append(servicesList, *newTypeOfServiceResponse(&service))
+		servicesList = append(servicesList, *newTypeOfServiceResponse(&service, tsh.urls))
 	}
 
-	total := uint64(len(servicesList))
-	meta := newMeta(total, req.Limit, req.Skip)
+	meta := newPageMeta(ctx, total, req.pageRequest)
 	rsp := toMap(meta, servicesList, "typeOfServices")
 
-	handleSuccess(ctx, rsp)
+	handleCacheable(ctx, rsp)
 }
 
 // getTypeOfServiceRequest representa el cuerpo de la solicitud para obtener un tipo de servicio por ID
@@ -135,22 +232,25 @@ func (tsh *TypeOfServiceHandler) ListTypeOfServices(ctx *gin.Context) {
 // @Accept         json
 // @Produce        json
 // @Param          id   query   string true   "Type of Service ID"
+// @Param          If-None-Match  header  string false  "ETag of the cached response"
 // @Success        200  {object}  typeOfServiceResponse  "Type of service displayed"
+// @Header         200  {string}  ETag  "Weak ETag of the response"
+// @Success        304  "Not modified"
 // @Failure        400  {object}  errorResponse  "Validation error"
 // @Failure        404  {object}  errorResponse  "Data not found error"
 // @Failure        500  {object}  errorResponse  "Internal server error"
-// @Router         /type-of-services [get]
+// @Router         /v1/typesofservice [get]
 func (tsh *TypeOfServiceHandler) GetTypeOfService(ctx *gin.Context) {
 	id := ctx.DefaultQuery("id", "")
 
 	if id == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID parameter is required"})
+		validationError(ctx, newRequestError("id", "required"))
 		return
 	}
 
 	serviceID, err := uuid.Parse(id)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		validationError(ctx, newRequestError("id", "uuid"))
 		return
 	}
 
@@ -162,14 +262,21 @@ func (tsh *TypeOfServiceHandler) GetTypeOfService(ctx *gin.Context) {
 	}
 
 	// Responder con el tipo de servicio
-	rsp := newTypeOfServiceResponse(service)
-	handleSuccess(ctx, rsp)
+	rsp := newTypeOfServiceResponse(service, tsh.urls)
+	handleCacheable(ctx, rsp)
 }
 
 // updateTypeOfServiceRequest representa el cuerpo de la solicitud para actualizar un tipo de servicio
 type updateTypeOfServiceRequest struct {
-	Name  string  `json:"name" binding:"required"`
-	Price float64 `json:"price" binding:"required"`
+	Name                    string  `json:"name" binding:"required" sanitize:"text"`
+	Description             string  `json:"description" binding:"max=2000" sanitize:"text"`
+	PreparationInstructions string  `json:"preparationInstructions" binding:"max=2000"`
+	Price                   float64 `json:"price" binding:"required"`
+	Currency                string  `json:"currency" binding:"omitempty,len=3" example:"MXN"`
+	DurationMinutes         int     `json:"durationMinutes" binding:"required,min=1"`
+	DurationMinMinutes      *int    `json:"durationMinMinutes" binding:"omitempty,min=1"`
+	DurationMaxMinutes      *int    `json:"durationMaxMinutes" binding:"omitempty,min=1"`
+	CategoryID              *string `json:"categoryId" binding:"omitempty,uuid"`
 }
 
 // UpdateTypeOfService godoc
@@ -187,12 +294,12 @@ type updateTypeOfServiceRequest struct {
 // @Failure        400    {object}  errorResponse  "Validation error"
 // @Failure        404    {object}  errorResponse  "Data not found error"
 // @Failure        500    {object}  errorResponse  "Internal server error"
-// @Router         /type-of-services [put]
+// @Router         /v1/typesofservice [put]
 func (tsh *TypeOfServiceHandler) UpdateTypeOfService(ctx *gin.Context) {
 	id := ctx.DefaultQuery("id", "")
 
 	if id == "" {
-		validationError(ctx, fmt.Errorf("ID is required"))
+		validationError(ctx, newRequestError("id", "required"))
 		return
 	}
 
@@ -203,9 +310,16 @@ func (tsh *TypeOfServiceHandler) UpdateTypeOfService(ctx *gin.Context) {
 	}
 
 	service := &domain.TypeOfService{
-		ID:    uuid.MustParse(id),
-		Name:  req.Name,
-		Price: req.Price,
+		ID:                      uuid.MustParse(id),
+		Name:                    req.Name,
+		Description:             req.Description,
+		PreparationInstructions: req.PreparationInstructions,
+		Price:                   req.Price,
+		Currency:                domain.Currency(strings.ToUpper(req.Currency)),
+		DurationMinutes:         req.DurationMinutes,
+		DurationMinMinutes:      req.DurationMinMinutes,
+		DurationMaxMinutes:      req.DurationMaxMinutes,
+		CategoryID:              parseOptionalUUID(req.CategoryID),
 	}
 
 	updatedService, err := tsh.svc.UpdateTypeOfService(ctx, service)
@@ -214,14 +328,14 @@ func (tsh *TypeOfServiceHandler) UpdateTypeOfService(ctx *gin.Context) {
 		return
 	}
 
-	rsp := newTypeOfServiceResponse(updatedService)
+	rsp := newTypeOfServiceResponse(updatedService, tsh.urls)
 	handleSuccess(ctx, rsp)
 }
 
 // DeleteTypeOfService godoc
 //
 // @Summary        Delete a type of service
-// @Description    Delete a type of service by id
+// @Description    Soft delete a type of service by id, admins can still list it with includeDeleted
 // @Tags           TypeOfServices
 // @Accept         json
 // @Produce        json
@@ -232,17 +346,17 @@ func (tsh *TypeOfServiceHandler) UpdateTypeOfService(ctx *gin.Context) {
 // @Failure        400    {object}  errorResponse  "Validation error"
 // @Failure        404    {object}  errorResponse  "Data not found error"
 // @Failure        500    {object}  errorResponse  "Internal server error"
-// @Router         /type-of-services [delete]
+// @Router         /v1/typesofservice [delete]
 func (tsh *TypeOfServiceHandler) DeleteTypeOfService(ctx *gin.Context) {
 	idStr := ctx.DefaultQuery("id", "")
 	if idStr == "" {
-		validationError(ctx, fmt.Errorf("ID is required"))
+		validationError(ctx, newRequestError("id", "required"))
 		return
 	}
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		validationError(ctx, fmt.Errorf("invalid UUID format"))
+		validationError(ctx, newRequestError("id", "uuid"))
 		return
 	}
 
@@ -252,5 +366,241 @@ func (tsh *TypeOfServiceHandler) DeleteTypeOfService(ctx *gin.Context) {
 		return
 	}
 
-	handleSuccess(ctx, "Type of service deleted successfully")
+	handleSuccess(ctx, localize(ctx, "message.type_of_service_deleted"))
+}
+
+// archiveTypeOfServiceRequest representa el cuerpo de la solicitud para archivar un tipo de servicio
+type archiveTypeOfServiceRequest struct {
+	Archived *bool `json:"archived" binding:"required"`
+}
+
+// ArchiveTypeOfService godoc
+//
+// @Summary        Archive a type of service
+// @Description    Hide a type of service from new quotes, or restore it, while keeping it resolvable for existing quotes
+// @Tags           TypeOfServices
+// @Accept         json
+// @Produce        json
+// @Param          id       query   string                      true   "Type of Service ID"
+// @Param          archived body    archiveTypeOfServiceRequest true   "Archived flag"
+// @Success        200    {object}  typeOfServiceResponse  "Type of service updated"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/typesofservice/archive [patch]
+func (tsh *TypeOfServiceHandler) ArchiveTypeOfService(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	var req archiveTypeOfServiceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	service, err := tsh.svc.ArchiveTypeOfService(ctx, id, *req.Archived)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newTypeOfServiceResponse(service, tsh.urls)
+	handleSuccess(ctx, rsp)
+}
+
+// serviceOfferingRequest representa el administrador que ofrece un tipo de servicio
+type serviceOfferingRequest struct {
+	TypeOfServiceID string `json:"typeOfServiceId" form:"typeOfServiceId" binding:"required,uuid"`
+	AdminID         string `json:"adminId" form:"adminId" binding:"required,uuid"`
+}
+
+// serviceOfferingResponse representa la respuesta de una oferta de servicio
+type serviceOfferingResponse struct {
+	TypeOfServiceID uuid.UUID `json:"typeOfServiceId"`
+	AdminID         uuid.UUID `json:"adminId"`
+}
+
+// AddServiceOffering godoc
+//
+// @Summary        Offer a type of service
+// @Description    Mark an admin as performing a type of service
+// @Tags           TypeOfServices
+// @Accept         json
+// @Produce        json
+// @Param          offering body    serviceOfferingRequest true   "Offering"
+// @Success        200    {object}  serviceOfferingResponse  "Service offering created"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        403    {object}  errorResponse  "User is not an admin"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        409    {object}  errorResponse  "Data conflict error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/typesofservice/offerings [post]
+func (tsh *TypeOfServiceHandler) AddServiceOffering(ctx *gin.Context) {
+	var req serviceOfferingRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	offering, err := tsh.svc.AddServiceOffering(ctx, &domain.ServiceOffering{
+		AdminID:         uuid.MustParse(req.AdminID),
+		TypeOfServiceID: uuid.MustParse(req.TypeOfServiceID),
+	})
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := serviceOfferingResponse{
+		TypeOfServiceID: offering.TypeOfServiceID,
+		AdminID:         offering.AdminID,
+	}
+	handleSuccess(ctx, rsp)
+}
+
+// RemoveServiceOffering godoc
+//
+// @Summary        Stop offering a type of service
+// @Description    Remove an admin from a type of service
+// @Tags           TypeOfServices
+// @Produce        json
+// @Param          typeOfServiceId  query   string true   "Type of Service ID"
+// @Param          adminId          query   string true   "Admin ID"
+// @Success        200    {object}  string  "Service offering deleted successfully"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/typesofservice/offerings [delete]
+func (tsh *TypeOfServiceHandler) RemoveServiceOffering(ctx *gin.Context) {
+	var req serviceOfferingRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	err := tsh.svc.RemoveServiceOffering(ctx, &domain.ServiceOffering{
+		AdminID:         uuid.MustParse(req.AdminID),
+		TypeOfServiceID: uuid.MustParse(req.TypeOfServiceID),
+	})
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, localize(ctx, "message.service_offering_deleted"))
+}
+
+// addTypeOfServiceImageRequest representa el formulario para subir una imagen de muestra
+type addTypeOfServiceImageRequest struct {
+	TypeOfServiceID string `form:"typeOfServiceId" binding:"required,uuid"`
+}
+
+// AddTypeOfServiceImage godoc
+//
+// @Summary        Upload a type of service image
+// @Description    Upload a showcase image for a type of service
+// @Tags           TypeOfServices
+// @Accept         multipart/form-data
+// @Produce        json
+// @Param          typeOfServiceId  formData  string true   "Type of Service ID"
+// @Param          file             formData  file   true   "Image"
+// @Success        200    {object}  typeOfServiceImageResponse  "Type of service image uploaded"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        413    {object}  errorResponse  "File too large"
+// @Failure        415    {object}  errorResponse  "Unsupported file type"
+// @Failure        422    {object}  errorResponse  "File rejected by the antivirus"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/typesofservice/images [post]
+func (tsh *TypeOfServiceHandler) AddTypeOfServiceImage(ctx *gin.Context) {
+	var req addTypeOfServiceImageRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	file, ok := formFile(ctx, "file")
+	if !ok {
+		return
+	}
+
+	image, err := tsh.svc.AddTypeOfServiceImage(ctx, uuid.MustParse(req.TypeOfServiceID), file, file.Size(), file.Name())
+	if err != nil {
+		handleError(ctx, file.uploadError(err))
+		return
+	}
+
+	rsp := newTypeOfServiceImageResponse(image, tsh.urls)
+	handleSuccess(ctx, rsp)
+}
+
+// GetTypeOfServiceImage godoc
+//
+// @Summary        Get a type of service image
+// @Description    Download a showcase image by id
+// @Tags           TypeOfServices
+// @Produce        octet-stream
+// @Param          id   query   string true   "Type of Service Image ID"
+// @Success        200  {file}    file  "Image file"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/typesofservice/images [get]
+func (tsh *TypeOfServiceHandler) GetTypeOfServiceImage(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	image, fileData, err := tsh.svc.GetTypeOfServiceImage(ctx, id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	mimeType := http.DetectContentType(fileData)
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%s", filepath.Base(image.URL)))
+	ctx.Data(http.StatusOK, mimeType, fileData)
+}
+
+// DeleteTypeOfServiceImage godoc
+//
+// @Summary        Delete a type of service image
+// @Description    Delete a showcase image by id
+// @Tags           TypeOfServices
+// @Produce        json
+// @Param          id   query   string true   "Type of Service Image ID"
+// @Success        200    {object}  string  "Type of service image deleted successfully"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/typesofservice/images [delete]
+func (tsh *TypeOfServiceHandler) DeleteTypeOfServiceImage(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	err = tsh.svc.DeleteTypeOfServiceImage(ctx, id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, localize(ctx, "message.type_of_service_image_deleted"))
+}
+
+// parseOptionalUUID converts an already validated optional UUID string
+func parseOptionalUUID(s *string) *uuid.UUID {
+	if s == nil {
+		return nil
+	}
+	id := uuid.MustParse(*s)
+	return &id
 }
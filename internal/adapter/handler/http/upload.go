@@ -0,0 +1,312 @@
+package http
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxFieldSize is the maximum size in bytes of a text field of a multipart upload
+const maxFieldSize = 64 << 10
+
+// sniffLen is how many bytes of a file http.DetectContentType looks at
+const sniffLen = 512
+
+// uploadFileKey is the key of the file streamed by uploadMiddleware in the context
+const uploadFileKey = "upload_file"
+
+// uploadLimits describes the multipart uploads a route accepts
+type uploadLimits struct {
+	// MaxBodySize is the maximum size in bytes of the whole request body
+	MaxBodySize int64
+	// MaxFiles is the maximum number of files across all form fields
+	MaxFiles int
+	// ContentTypes are the content types allowed for the files, detected from their content
+	ContentTypes []string
+}
+
+var (
+	// imageContentTypes are the image formats accepted for uploads
+	imageContentTypes = []string{"image/jpeg", "image/png", "image/webp"}
+
+	// videoContentTypes are the video formats accepted for uploads
+	videoContentTypes = []string{"video/mp4", "video/webm"}
+)
+
+// routeUploadLimits are the limits of the routes accepting uploads
+type routeUploadLimits struct {
+	// Quote applies to the images and videos of the hair attached to a quote,
+	// long videos are streamed to the file storage in parts instead of being held in memory
+	Quote uploadLimits
+	// PaymentProof applies to payment receipts, which are often PDFs
+	PaymentProof uploadLimits
+	// TypeOfServiceImage applies to the gallery images of a type of service
+	TypeOfServiceImage uploadLimits
+	// StaffPhoto applies to the photos of the staff directory
+	StaffPhoto uploadLimits
+}
+
+// newRouteUploadLimits sizes the upload limits, maxMediaSize applies to the uploads that may be videos
+// and maxSize to the rest
+func newRouteUploadLimits(maxSize, maxMediaSize int64) routeUploadLimits {
+	return routeUploadLimits{
+		Quote: uploadLimits{
+			MaxBodySize:  maxMediaSize,
+			MaxFiles:     1,
+			ContentTypes: append(append([]string{}, imageContentTypes...), videoContentTypes...),
+		},
+		PaymentProof: uploadLimits{
+			MaxBodySize:  maxSize,
+			MaxFiles:     1,
+			ContentTypes: append([]string{"application/pdf"}, imageContentTypes...),
+		},
+		TypeOfServiceImage: uploadLimits{
+			MaxBodySize:  maxSize,
+			MaxFiles:     1,
+			ContentTypes: imageContentTypes,
+		},
+		StaffPhoto: uploadLimits{
+			MaxBodySize:  maxSize,
+			MaxFiles:     1,
+			ContentTypes: imageContentTypes,
+		},
+	}
+}
+
+// limitedBody is a request body limited to the default body size, which keeps the raw body
+// so the routes accepting uploads can apply their own limit instead
+type limitedBody struct {
+	io.ReadCloser
+	raw io.ReadCloser
+}
+
+// bodyLimitMiddleware is a middleware that limits the size of the request bodies to maxSize bytes,
+// reading past it fails with a *http.MaxBytesError that is answered with ErrRequestTooLarge
+func bodyLimitMiddleware(maxSize int64) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Body != nil && ctx.Request.Body != http.NoBody {
+			ctx.Request.Body = &limitedBody{
+				ReadCloser: http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxSize),
+				raw:        ctx.Request.Body,
+			}
+		}
+
+		ctx.Next()
+	}
+}
+
+// uploadMiddleware is a middleware that streams a multipart upload instead of parsing the whole form.
+// The text fields before the file are read into the form so the handlers bind them as usual, the file
+// is left in the body for the handler to stream to the storage, so it must be the last field. The request
+// is rejected when the body, the number of files or the file's content type exceed the limits
+func uploadMiddleware(limits uploadLimits) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method == http.MethodOptions {
+			ctx.Next()
+			return
+		}
+
+		// The upload limit replaces the default body limit
+		body := ctx.Request.Body
+		if limited, ok := body.(*limitedBody); ok {
+			body = limited.raw
+		}
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, body, limits.MaxBodySize)
+
+		reader, err := ctx.Request.MultipartReader()
+		if err != nil {
+			validationError(ctx, newRequestError("body", "multipart"))
+			ctx.Abort()
+			return
+		}
+
+		values := url.Values{}
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				abortUpload(ctx, err)
+				return
+			}
+
+			if part.FileName() == "" {
+				value, err := io.ReadAll(io.LimitReader(part, maxFieldSize+1))
+				if err != nil {
+					abortUpload(ctx, err)
+					return
+				}
+				if len(value) > maxFieldSize {
+					validationError(ctx, newRequestError(part.FormName(), "max_length", strconv.Itoa(maxFieldSize)))
+					ctx.Abort()
+					return
+				}
+				values.Add(part.FormName(), string(value))
+				continue
+			}
+
+			// The content type is detected from the first bytes, which stay buffered for the handler
+			head := bufio.NewReaderSize(part, sniffLen)
+			sniffed, err := head.Peek(sniffLen)
+			if err != nil && err != io.EOF {
+				abortUpload(ctx, err)
+				return
+			}
+
+			if !allowedContentType(http.DetectContentType(sniffed), limits.ContentTypes) {
+				handleAbort(ctx, domain.ErrUnsupportedFileType)
+				return
+			}
+
+			ctx.Set(uploadFileKey, &uploadFile{
+				field:    part.FormName(),
+				name:     part.FileName(),
+				part:     head,
+				reader:   reader,
+				maxFiles: limits.MaxFiles,
+				files:    1,
+			})
+			break
+		}
+
+		// The fields are bound from the form, as ParseMultipartForm would have left them
+		form := ctx.Request.URL.Query()
+		for key, value := range values {
+			form[key] = append(form[key], value...)
+		}
+		ctx.Request.Form = form
+		ctx.Request.PostForm = values
+		ctx.Request.MultipartForm = &multipart.Form{Value: values}
+
+		ctx.Next()
+	}
+}
+
+// abortUpload answers a multipart upload that could not be read, a body over the limit is too large
+// and any other failure a malformed form
+func abortUpload(ctx *gin.Context, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		handleAbort(ctx, domain.ErrFileTooLarge)
+		return
+	}
+	validationError(ctx, newRequestError("body", "multipart"))
+	ctx.Abort()
+}
+
+// uploadFile is the file of a multipart upload, read straight from the request body as the
+// handler streams it to the storage. It remembers the first error reading it, so a storage failure
+// caused by the client can be told apart from one of the storage
+type uploadFile struct {
+	field string
+	name  string
+	part  io.Reader
+	// reader continues the form after the file, where any other file counts against maxFiles
+	reader   *multipart.Reader
+	maxFiles int
+	files    int
+	err      error
+}
+
+// Read reads the file, limited by the body limit of the route
+func (f *uploadFile) Read(p []byte) (int, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+
+	n, err := f.part.Read(p)
+	if err == io.EOF {
+		err = f.finish()
+	}
+	if err != nil && err != io.EOF {
+		f.err = err
+	}
+	return n, err
+}
+
+// finish reads the rest of the form once the file is over, failing when it holds more files than allowed
+func (f *uploadFile) finish() error {
+	for {
+		part, err := f.reader.NextPart()
+		if err != nil {
+			return err
+		}
+
+		if part.FileName() != "" {
+			f.files++
+			if f.files > f.maxFiles {
+				return domain.ErrTooManyFiles
+			}
+		}
+
+		if _, err := io.Copy(io.Discard, part); err != nil {
+			return err
+		}
+	}
+}
+
+// uploadError is the error answered for an upload whose handling failed with err, the errors reading
+// the file take precedence as the storage can't tell them from its own
+func (f *uploadFile) uploadError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(f.err, &maxBytesErr):
+		return domain.ErrFileTooLarge
+	case f.err == domain.ErrTooManyFiles:
+		return domain.ErrTooManyFiles
+	}
+	return err
+}
+
+// Name returns the name the client gave the file
+func (f *uploadFile) Name() string {
+	return f.name
+}
+
+// Size returns -1, the size of a streamed file is only known once it is read
+func (f *uploadFile) Size() int64 {
+	return -1
+}
+
+// formFile returns the file of the request streamed by uploadMiddleware, answering the request when
+// the form has none in the field
+func formFile(ctx *gin.Context, field string) (*uploadFile, bool) {
+	value, ok := ctx.Get(uploadFileKey)
+	if !ok {
+		validationError(ctx, newRequestError(field, "required"))
+		return nil, false
+	}
+
+	file := value.(*uploadFile)
+	if file.field != field {
+		validationError(ctx, newRequestError(field, "required"))
+		return nil, false
+	}
+
+	return file, true
+}
+
+// allowedContentType reports whether a detected content type, ignoring its parameters, is in the allowed list
+func allowedContentType(contentType string, allowed []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, a := range allowed {
+		if mediaType == a {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBodyLimitedRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(bodyLimitMiddleware(64))
+
+	router.POST("/json", func(ctx *gin.Context) {
+		var req struct {
+			Description string `json:"description"`
+		}
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			validationError(ctx, err)
+			return
+		}
+		ctx.Status(http.StatusOK)
+	})
+	router.POST("/upload", uploadMiddleware(uploadLimits{
+		MaxBodySize:  1 << 20,
+		MaxFiles:     1,
+		ContentTypes: []string{"text/plain"},
+	}), func(ctx *gin.Context) {
+		file, ok := formFile(ctx, "file")
+		if !ok {
+			return
+		}
+
+		// The file is streamed the way the storage reads it
+		data, err := io.ReadAll(file)
+		if err != nil {
+			handleError(ctx, file.uploadError(domain.ErrInternal))
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"note": ctx.Request.FormValue("note"), "name": file.Name(), "size": len(data)})
+	})
+
+	return router
+}
+
+func TestBodyLimitMiddleware(t *testing.T) {
+	router := newBodyLimitedRouter()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/json", strings.NewReader(`{"description":"corto"}`)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	body := `{"description":"` + strings.Repeat("largo", 20) + `"}`
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/json", strings.NewReader(body)))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"request_too_large"`)
+}
+
+func TestBodyLimitMiddlewareLetsUploadsUseTheirLimit(t *testing.T) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	file, err := form.CreateFormFile("file", "notes.txt")
+	require.NoError(t, err)
+	_, err = file.Write([]byte(strings.Repeat("hair notes ", 100)))
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	rec := httptest.NewRecorder()
+	newBodyLimitedRouter().ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+// newUpload builds a multipart request with the fields, then a file for each of the contents
+func newUpload(t *testing.T, fields map[string]string, files ...string) *http.Request {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	for name, value := range fields {
+		require.NoError(t, form.WriteField(name, value))
+	}
+	for _, content := range files {
+		file, err := form.CreateFormFile("file", "notes.txt")
+		require.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, form.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return req
+}
+
+func TestUploadMiddlewareStreamsTheFile(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newBodyLimitedRouter().ServeHTTP(rec, newUpload(t, map[string]string{"note": "balayage"}, strings.Repeat("hair notes ", 100)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"note":"balayage","name":"notes.txt","size":1100}`, rec.Body.String())
+}
+
+func TestUploadMiddlewareRejectsUploads(t *testing.T) {
+	tests := []struct {
+		name   string
+		req    *http.Request
+		status int
+		code   string
+	}{
+		{"unsupported content type", newUpload(t, nil, "\x89PNG\r\n\x1a\n"), http.StatusUnsupportedMediaType, "unsupported_file_type"},
+		{"file over the limit", newUpload(t, nil, strings.Repeat("hair notes ", 100_000)), http.StatusRequestEntityTooLarge, "file_too_large"},
+		{"second file", newUpload(t, nil, "hair notes", "more notes"), http.StatusBadRequest, "too_many_files"},
+		{"no file", newUpload(t, map[string]string{"note": "balayage"}), http.StatusBadRequest, "validation_failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			newBodyLimitedRouter().ServeHTTP(rec, tt.req)
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+			assert.Contains(t, rec.Body.String(), `"code":"`+tt.code+`"`)
+		})
+	}
+}
@@ -23,11 +23,15 @@ func NewUserHandler(svc port.UserService) *UserHandler {
 
 // registerRequest represents the request body for creating a user
 type registerRequest struct {
-	Name           string `json:"name" binding:"required" example:"John"`
-	LastName       string `json:"lastName" binding:"required" example:"Doe"`
-	SecondLastName string `json:"SecondLastName" example:"Doe"`
+	Name           string `json:"name" binding:"required" sanitize:"text" example:"John"`
+	LastName       string `json:"lastName" binding:"required" sanitize:"text" example:"Doe"`
+	SecondLastName string `json:"SecondLastName" sanitize:"text" example:"Doe"`
 	Email          string `json:"email" binding:"required,email" example:"test@example.com"`
 	Password       string `json:"password" binding:"required,min=8" example:"12345678"`
+	// PreferredLanguage is the language emails are sent in, Spanish when omitted
+	PreferredLanguage string `json:"preferredLanguage" binding:"omitempty,oneof=es en" example:"es"`
+	// Phone is the number in E.164 format text messages are sent to
+	Phone string `json:"phone" binding:"omitempty,e164" example:"+5215512345678"`
 }
 
 // Register godoc
@@ -44,7 +48,7 @@ type registerRequest struct {
 //	@Failure		404				{object}	errorResponse	"Data not found error"
 //	@Failure		409				{object}	errorResponse	"Data conflict error"
 //	@Failure		500				{object}	errorResponse	"Internal server error"
-//	@Router			/users [post]
+//	@Router			/v1/users [post]
 func (uh *UserHandler) Register(ctx *gin.Context) {
 	var req registerRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -55,12 +59,14 @@ func (uh *UserHandler) Register(ctx *gin.Context) {
 	user_id := uuid.New()
 
 	user := domain.User{
-		ID:             user_id,
-		Name:           req.Name,
-		Email:          req.Email,
-		LastName:       req.LastName,
-		SecondLastName: req.SecondLastName,
-		Password:       req.Password,
+		ID:                user_id,
+		Name:              req.Name,
+		Email:             req.Email,
+		LastName:          req.LastName,
+		SecondLastName:    req.SecondLastName,
+		Password:          req.Password,
+		PreferredLanguage: domain.Language(req.PreferredLanguage),
+		Phone:             req.Phone,
 	}
 
 	_, err := uh.svc.Register(ctx, &user)
@@ -76,8 +82,7 @@ func (uh *UserHandler) Register(ctx *gin.Context) {
 
 // listUsersRequest represents the request body for listing users
 type listUsersRequest struct {
-	Skip    uint64             `form:"skip" binding:"min=0" example:"0"`
-	Limit   uint64             `form:"limit" binding:"required,min=1" example:"5"`
+	pageRequest
 	Filters userFiltersRequest `form:"filters"`
 }
 
@@ -96,13 +101,13 @@ type userFiltersRequest struct {
 //	@Tags			Users
 //	@Accept			json
 //	@Produce		json
-//	@Param			skip		query		uint64				false	"Skip"	default(0)
-//	@Param			limit		query		uint64				false	"Limit"	default(10)
+//	@Param			skip		query		uint64				false	"Page number, starting at 1"	default(1)
+//	@Param			limit		query		uint64				true	"Limit"
 //	@Param			filters		query		userFiltersRequest	false	"Filters"
 //	@Success		200			{object}	meta				"Users displayed"
 //	@Failure		400			{object}	errorResponse		"Validation error"
 //	@Failure		500			{object}	errorResponse		"Internal server error"
-//	@Router			/users [get]
+//	@Router			/v1/users [get]
 //	@Security		BearerAuth
 func (uh *UserHandler) ListUsers(ctx *gin.Context) {
 	var req listUsersRequest
@@ -130,7 +135,7 @@ func (uh *UserHandler) ListUsers(ctx *gin.Context) {
 		"rawQuery", ctx.Request.URL.RawQuery,
 	)
 
-	users, err := uh.svc.ListUsers(ctx, req.Skip, req.Limit, filters)
+	users, total, err := uh.svc.ListUsers(ctx, req.Skip, req.Limit, filters)
 	if err != nil {
 		handleError(ctx, err)
 		return
@@ -140,11 +145,8 @@ func (uh *UserHandler) ListUsers(ctx *gin.Context) {
 		usersList = append(usersList, newUserResponse(&user))
 	}
 
-	total := uint64(len(usersList))
-	meta := newMeta(total, req.Limit, req.Skip)
-	rsp := toMap(meta, usersList, "users")
-
-	handleSuccess(ctx, rsp)
+	meta := newPageMeta(ctx, total, req.pageRequest)
+	handleList(ctx, meta, usersList, "users")
 }
 
 // getUserRequest represents the request body for getting a user
@@ -164,7 +166,7 @@ type getUserRequest struct {
 //	@Failure		400	{object}	errorResponse	"Validation error"
 //	@Failure		404	{object}	errorResponse	"Data not found error"
 //	@Failure		500	{object}	errorResponse	"Internal server error"
-//	@Router			/users/{id} [get]
+//	@Router			/v1/users/{id} [get]
 //	@Security		BearerAuth
 func (uh *UserHandler) GetUser(ctx *gin.Context) {
 	var req getUserRequest
@@ -186,18 +188,19 @@ func (uh *UserHandler) GetUser(ctx *gin.Context) {
 
 // updateUserRequest represents the request body for updating a user
 type updateUserRequest struct {
-	Name           string          `json:"name" binding:"omitempty,required" example:"John Doe"`
-	LastName       string          `json:"name" binding:"omitempty,required" example:"John Doe"`
-	SecondLastName string          `json:"name" binding:"omitempty,required" example:"John Doe"`
-	Email          string          `json:"email" binding:"omitempty,required,email" example:"test@example.com"`
-	Password       string          `json:"password" binding:"omitempty,required,min=8" example:"12345678"`
-	Role           domain.UserRole `json:"role" binding:"omitempty,required,user_role" example:"admin"`
+	Name              string          `json:"name" binding:"omitempty,required" sanitize:"text" example:"John Doe"`
+	LastName          string          `json:"lastName" binding:"omitempty,required" sanitize:"text" example:"Doe"`
+	SecondLastName    string          `json:"secondLastName" binding:"omitempty,required" sanitize:"text" example:"Smith"`
+	Email             string          `json:"email" binding:"omitempty,required,email" example:"test@example.com"`
+	Password          string          `json:"password" binding:"omitempty,required,min=8" example:"12345678"`
+	Role              domain.UserRole `json:"role" binding:"omitempty,required,user_role" example:"admin"`
+	PreferredLanguage string          `json:"preferredLanguage" binding:"omitempty,oneof=es en" example:"en"`
 }
 
 // UpdateUser godoc
 //
 //	@Summary		Update a user
-//	@Description	Update a user's name, email, password, or role by id
+//	@Description	Update a user's name, email, password, role or preferred language by id
 //	@Tags			Users
 //	@Accept			json
 //	@Produce		json
@@ -209,7 +212,7 @@ type updateUserRequest struct {
 //	@Failure		403					{object}	errorResponse		"Forbidden error"
 //	@Failure		404					{object}	errorResponse		"Data not found error"
 //	@Failure		500					{object}	errorResponse		"Internal server error"
-//	@Router			/users/{id} [put]
+//	@Router			/v1/users/{id} [put]
 //	@Security		BearerAuth
 func (uh *UserHandler) UpdateUser(ctx *gin.Context) {
 	var req updateUserRequest
@@ -227,13 +230,14 @@ func (uh *UserHandler) UpdateUser(ctx *gin.Context) {
 	}
 
 	user := domain.User{
-		ID:             id,
-		Name:           req.Name,
-		LastName:       req.LastName,
-		SecondLastName: req.SecondLastName,
-		Email:          req.Email,
-		Password:       req.Password,
-		Role:           req.Role,
+		ID:                id,
+		Name:              req.Name,
+		LastName:          req.LastName,
+		SecondLastName:    req.SecondLastName,
+		Email:             req.Email,
+		Password:          req.Password,
+		Role:              req.Role,
+		PreferredLanguage: domain.Language(req.PreferredLanguage),
 	}
 
 	_, err = uh.svc.UpdateUser(ctx, &user)
@@ -247,6 +251,57 @@ func (uh *UserHandler) UpdateUser(ctx *gin.Context) {
 	handleSuccess(ctx, rsp)
 }
 
+// updateNotificationPreferencesRequest represents the request body for choosing how the authenticated user is notified,
+// emails stay on unless they are turned off explicitly
+type updateNotificationPreferencesRequest struct {
+	Phone    string `json:"phone" binding:"omitempty,e164" example:"+5215512345678"`
+	Email    *bool  `json:"emailNotifications" example:"true"`
+	SMS      bool   `json:"smsNotifications" example:"true"`
+	WhatsApp bool   `json:"whatsAppNotifications" example:"true"`
+}
+
+// UpdateNotificationPreferences godoc
+//
+//	@Summary		Update notification preferences
+//	@Description	Set the phone of the authenticated user, whether they receive notification emails and whether appointment confirmations and reminders are also sent to their phone by SMS or WhatsApp. In-app notifications are always on
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Param			updateNotificationPreferencesRequest	body		updateNotificationPreferencesRequest	true	"Notification preferences"
+//	@Success		200										{object}	userResponse							"Notification preferences updated"
+//	@Failure		400										{object}	errorResponse							"Validation error"
+//	@Failure		401										{object}	errorResponse							"Unauthorized error"
+//	@Failure		404										{object}	errorResponse							"Data not found error"
+//	@Failure		500										{object}	errorResponse							"Internal server error"
+//	@Router			/v1/users/me/notifications [put]
+//	@Security		BearerAuth
+func (uh *UserHandler) UpdateNotificationPreferences(ctx *gin.Context) {
+	var req updateNotificationPreferencesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	authPayload := getAuthPayload(ctx, authorizationPayloadKey)
+
+	preferences := domain.DefaultNotificationPreferences()
+	if req.Email != nil {
+		preferences.Email = *req.Email
+	}
+	preferences.SMS = req.SMS
+	preferences.WhatsApp = req.WhatsApp
+
+	user, err := uh.svc.UpdateNotificationPreferences(ctx, authPayload.UserID, req.Phone, preferences)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newUserResponse(user)
+
+	handleSuccess(ctx, rsp)
+}
+
 // deleteUserRequest represents the request body for deleting a user
 type deleteUserRequest struct {
 	ID uuid.UUID `uri:"id" binding:"required,min=1" example:"1"`
@@ -266,7 +321,7 @@ type deleteUserRequest struct {
 //	@Failure		403	{object}	errorResponse	"Forbidden error"
 //	@Failure		404	{object}	errorResponse	"Data not found error"
 //	@Failure		500	{object}	errorResponse	"Internal server error"
-//	@Router			/users/{id} [delete]
+//	@Router			/v1/users/{id} [delete]
 //	@Security		BearerAuth
 func (uh *UserHandler) DeleteUser(ctx *gin.Context) {
 	var req deleteUserRequest
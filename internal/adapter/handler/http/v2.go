@@ -0,0 +1,26 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// pathToQuery translates the path parameters of a route into the query parameters the handlers of the
+// /v1 query style read, keyed by path parameter name, so both styles share the same handlers.
+// It must run before anything reads the query, as gin caches it on first use
+func pathToQuery(params map[string]string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		query := ctx.Request.URL.Query()
+		for param, key := range params {
+			query.Set(key, ctx.Param(param))
+		}
+		ctx.Request.URL.RawQuery = query.Encode()
+
+		ctx.Next()
+	}
+}
+
+// Translations of the path parameters, shared by /v2 and the /v1 path routes
+var (
+	idFromPath      = pathToQuery(map[string]string{"id": "id"})
+	quoteIDFromPath = pathToQuery(map[string]string{"id": "quoteId"})
+)
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathToQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.GET("/v2/quotes/:id/images", quoteIDFromPath, func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, "%s %s", ctx.Query("quoteId"), ctx.Query("limit"))
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v2/quotes/42/images?limit=10", nil))
+
+	// The path parameter is added next to the query the v1 handler already reads
+	assert.Equal(t, "42 10", rec.Body.String())
+}
+
+func TestPathToQueryOverridesQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	router.GET("/v2/appointments/:id", idFromPath, func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, "%v", ctx.QueryArray("id"))
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v2/appointments/42?id=7", nil))
+
+	assert.Equal(t, "[42]", rec.Body.String())
+}
@@ -1,7 +1,12 @@
 package http
 
 import (
+	"reflect"
+	"regexp"
+	"strings"
+
 	"harajuku/backend/internal/core/domain"
+
 	"github.com/go-playground/validator/v10"
 )
 
@@ -16,3 +21,67 @@ var userRoleValidator validator.Func = func(fl validator.FieldLevel) bool {
 		return false
 	}
 }
+
+// slugPattern matches lowercase words of letters and digits joined by hyphens
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// slugValidator is a custom validator for the slugs that identify salons in URLs
+var slugValidator validator.Func = func(fl validator.FieldLevel) bool {
+	return slugPattern.MatchString(fl.Field().String())
+}
+
+// fieldName names the fields in validation errors as clients send them, after their json,
+// form or uri tag, instead of after the Go struct field
+func fieldName(fld reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name, _, _ := strings.Cut(fld.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+
+	return fld.Name
+}
+
+// fieldErrorMessage describes a failed validation rule in words in lang, the rule itself is sent as the code
+func fieldErrorMessage(lang domain.Language, fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required", "required_if", "required_with", "required_without":
+		return translate(lang, "field.required")
+	case "email":
+		return translate(lang, "field.email")
+	case "uuid", "uuid4":
+		return translate(lang, "field.uuid")
+	case "url", "http_url":
+		return translate(lang, "field.url")
+	case "e164":
+		return translate(lang, "field.e164")
+	case "oneof":
+		return translate(lang, "field.oneof", fe.Param())
+	case "min", "gte":
+		if fe.Kind() == reflect.String || fe.Kind() == reflect.Slice {
+			return translate(lang, "field.min_length", fe.Param())
+		}
+		return translate(lang, "field.min", fe.Param())
+	case "max", "lte":
+		if fe.Kind() == reflect.String || fe.Kind() == reflect.Slice {
+			return translate(lang, "field.max_length", fe.Param())
+		}
+		return translate(lang, "field.max", fe.Param())
+	case "gt":
+		return translate(lang, "field.gt", fe.Param())
+	case "lt":
+		return translate(lang, "field.lt", fe.Param())
+	case "len":
+		return translate(lang, "field.len", fe.Param())
+	case "user_role":
+		return translate(lang, "field.user_role")
+	case "slug":
+		return translate(lang, "field.slug")
+	default:
+		return translate(lang, "field.invalid", fe.Tag())
+	}
+}
@@ -0,0 +1,245 @@
+package http
+
+import (
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// WebhookHandler represents the HTTP handler for webhook subscription requests
+type WebhookHandler struct {
+	svc port.WebhookService
+}
+
+// NewWebhookHandler creates a new WebhookHandler instance
+func NewWebhookHandler(svc port.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		svc,
+	}
+}
+
+// webhookResponse represents a webhook response body, the secret is only shown when the webhook is created
+type webhookResponse struct {
+	ID         uuid.UUID `json:"id"`
+	URL        string    `json:"url" example:"https://hooks.zapier.com/hooks/catch/123/abc"`
+	Secret     string    `json:"secret,omitempty" example:"whsec_5f2b..."`
+	EventTypes []string  `json:"eventTypes" example:"quote.created,appointment.booked"`
+	Active     bool      `json:"active" example:"true"`
+	CreatedAt  string    `json:"createdAt" example:"2025-03-14T10:00:00Z"`
+	UpdatedAt  string    `json:"updatedAt" example:"2025-03-14T10:00:00Z"`
+}
+
+// newWebhookResponse is a helper function to create a response body for handling webhook data
+func newWebhookResponse(w *domain.Webhook) *webhookResponse {
+	eventTypes := make([]string, len(w.EventTypes))
+	for i, t := range w.EventTypes {
+		eventTypes[i] = string(t)
+	}
+
+	return &webhookResponse{
+		ID:         w.ID,
+		URL:        w.URL,
+		EventTypes: eventTypes,
+		Active:     w.Active,
+		CreatedAt:  w.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  w.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// webhookRequest represents the request body for creating or updating a webhook.
+// A secret is generated when none is given on creation and kept when none is given on update
+type webhookRequest struct {
+	URL        string   `json:"url" binding:"required,url" example:"https://hooks.zapier.com/hooks/catch/123/abc"`
+	Secret     string   `json:"secret" binding:"omitempty,min=16"`
+	EventTypes []string `json:"eventTypes" binding:"required,min=1" example:"quote.created,appointment.booked"`
+	Active     *bool    `json:"active" example:"true"`
+}
+
+// toDomain converts the request into a webhook, webhooks are active unless told otherwise
+func (req *webhookRequest) toDomain(id uuid.UUID) *domain.Webhook {
+	webhook := &domain.Webhook{
+		ID:     id,
+		URL:    req.URL,
+		Secret: req.Secret,
+		Active: req.Active == nil || *req.Active,
+	}
+
+	for _, t := range req.EventTypes {
+		webhook.EventTypes = append(webhook.EventTypes, domain.EventType(t))
+	}
+
+	return webhook
+}
+
+// CreateWebhook godoc
+//
+// @Summary        Register a new webhook
+// @Description    Subscribe an external URL to domain events, they are posted as JSON signed with HMAC-SHA256 in the X-Harajuku-Signature header
+// @Tags           Webhooks
+// @Accept         json
+// @Produce        json
+// @Param          webhook body    webhookRequest true   "Webhook Data"
+// @Success        200    {object}  webhookResponse  "Webhook created"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/webhooks [post]
+func (wh *WebhookHandler) CreateWebhook(ctx *gin.Context) {
+	var req webhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	created, err := wh.svc.CreateWebhook(ctx, req.toDomain(uuid.Nil))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newWebhookResponse(created)
+	rsp.Secret = created.Secret
+	handleSuccess(ctx, rsp)
+}
+
+// listWebhooksRequest represents the query for listing webhooks
+type listWebhooksRequest struct {
+	pageRequest
+}
+
+// ListWebhooks godoc
+//
+// @Summary        List webhooks
+// @Description    List the webhook subscriptions with pagination
+// @Tags           Webhooks
+// @Accept         json
+// @Produce        json
+// @Param          skip   query   uint64 false  "Page number, starting at 1"
+// @Param          limit  query   uint64 true   "Limit"
+// @Success        200    {object}  meta  "Webhooks displayed"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/webhooks/all [get]
+func (wh *WebhookHandler) ListWebhooks(ctx *gin.Context) {
+	var req listWebhooksRequest
+	var webhooksList []webhookResponse
+
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	webhooks, err := wh.svc.ListWebhooks(ctx, req.Skip, req.Limit)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	for _, webhook := range webhooks {
+		webhooksList = append(webhooksList, *newWebhookResponse(&webhook))
+	}
+
+	total := uint64(len(webhooksList))
+	meta := newPageMeta(ctx, total, req.pageRequest)
+	rsp := toMap(meta, webhooksList, "webhooks")
+
+	handleSuccess(ctx, rsp)
+}
+
+// GetWebhook godoc
+//
+// @Summary        Get a webhook
+// @Description    Get a webhook subscription by id
+// @Tags           Webhooks
+// @Accept         json
+// @Produce        json
+// @Param          id   query   string true   "Webhook ID"
+// @Success        200  {object}  webhookResponse  "Webhook displayed"
+// @Failure        400  {object}  errorResponse  "Validation error"
+// @Failure        404  {object}  errorResponse  "Data not found error"
+// @Failure        500  {object}  errorResponse  "Internal server error"
+// @Router         /v1/webhooks [get]
+func (wh *WebhookHandler) GetWebhook(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	webhook, err := wh.svc.GetWebhook(ctx, id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newWebhookResponse(webhook)
+	handleSuccess(ctx, rsp)
+}
+
+// UpdateWebhook godoc
+//
+// @Summary        Update a webhook
+// @Description    Update the URL, event types, secret or state of a webhook subscription
+// @Tags           Webhooks
+// @Accept         json
+// @Produce        json
+// @Param          id       query   string         true   "Webhook ID"
+// @Param          webhook  body    webhookRequest true   "Webhook Data"
+// @Success        200    {object}  webhookResponse  "Webhook updated"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/webhooks [put]
+func (wh *WebhookHandler) UpdateWebhook(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	var req webhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		validationError(ctx, err)
+		return
+	}
+
+	updated, err := wh.svc.UpdateWebhook(ctx, req.toDomain(id))
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	rsp := newWebhookResponse(updated)
+	handleSuccess(ctx, rsp)
+}
+
+// DeleteWebhook godoc
+//
+// @Summary        Delete a webhook
+// @Description    Delete a webhook subscription, events are no longer posted to it
+// @Tags           Webhooks
+// @Accept         json
+// @Produce        json
+// @Param          id   query   string true   "Webhook ID"
+// @Success        200    {object}  string  "Webhook deleted successfully"
+// @Failure        400    {object}  errorResponse  "Validation error"
+// @Failure        404    {object}  errorResponse  "Data not found error"
+// @Failure        500    {object}  errorResponse  "Internal server error"
+// @Router         /v1/webhooks [delete]
+func (wh *WebhookHandler) DeleteWebhook(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.DefaultQuery("id", ""))
+	if err != nil {
+		validationError(ctx, newRequestError("id", "uuid"))
+		return
+	}
+
+	err = wh.svc.DeleteWebhook(ctx, id)
+	if err != nil {
+		handleError(ctx, err)
+		return
+	}
+
+	handleSuccess(ctx, localize(ctx, "message.webhook_deleted"))
+}
@@ -0,0 +1,41 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"harajuku/backend/internal/core/util"
+)
+
+/**
+ * contextHandler implements slog.Handler interface
+ * and adds the attributes of the context, such as the request ID, the route and the user, to every record
+ */
+type contextHandler struct {
+	slog.Handler
+}
+
+// newContextHandler wraps handler so the records logged with a context carry its attributes
+func newContextHandler(handler slog.Handler) slog.Handler {
+	return &contextHandler{handler}
+}
+
+// Handle adds the attributes of ctx to the record before handling it
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if attrs := util.LogAttrs(ctx); len(attrs) > 0 {
+		r = r.Clone()
+		r.AddAttrs(attrs...)
+	}
+
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs returns a handler adding attrs, which still adds the attributes of the context
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup returns a handler nesting the attributes in the group, which still adds the attributes of the context
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,58 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"harajuku/backend/internal/core/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// logRecord logs msg with ctx through a context handler and returns the decoded record
+func logRecord(t *testing.T, ctx context.Context, msg string) map[string]any {
+	t.Helper()
+
+	var buf bytes.Buffer
+	logger := slog.New(newContextHandler(slog.NewJSONHandler(&buf, nil))).With("app", "harajuku")
+	logger.ErrorContext(ctx, msg, "error", "boom")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	return record
+}
+
+func TestContextHandler(t *testing.T) {
+	t.Run("adds the attributes of the context", func(t *testing.T) {
+		ctx := util.WithLogAttrs(context.Background(), slog.String("request_id", "req-1"), slog.String("route", "GET /v1/quotes"))
+		ctx = util.WithLogAttrs(ctx, slog.String("user_id", "user-1"))
+
+		record := logRecord(t, ctx, "transaction failed")
+		assert.Equal(t, "transaction failed", record["msg"])
+		assert.Equal(t, "boom", record["error"])
+		assert.Equal(t, "harajuku", record["app"])
+		assert.Equal(t, "req-1", record["request_id"])
+		assert.Equal(t, "GET /v1/quotes", record["route"])
+		assert.Equal(t, "user-1", record["user_id"])
+	})
+
+	t.Run("logs without a context as before", func(t *testing.T) {
+		record := logRecord(t, context.Background(), "transaction failed")
+		assert.Equal(t, "boom", record["error"])
+		assert.NotContains(t, record, "request_id")
+	})
+
+	t.Run("derived contexts don't share attributes", func(t *testing.T) {
+		parent := util.WithLogAttrs(context.Background(), slog.String("request_id", "req-1"))
+		first := util.WithLogAttrs(parent, slog.String("user_id", "user-1"))
+		second := util.WithLogAttrs(parent, slog.String("user_id", "user-2"))
+
+		assert.Equal(t, "user-1", logRecord(t, first, "first")["user_id"])
+		assert.Equal(t, "user-2", logRecord(t, second, "second")["user_id"])
+		assert.Len(t, util.LogAttrs(parent), 1)
+	})
+}
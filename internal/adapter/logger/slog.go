@@ -12,6 +12,14 @@ import (
 // logger is the default logger used by the application
 var logger *slog.Logger
 
+// level is the level of the application logs, admins can change it while the server runs
+var level = new(slog.LevelVar)
+
+// Level returns the level of the application logs, setting it takes effect on the next log
+func Level() *slog.LevelVar {
+	return level
+}
+
 // Set sets the logger configuration based on the environment
 func Set(config *config.App) {
 	// Create common options
@@ -20,8 +28,9 @@ func Set(config *config.App) {
 	}
 
 	// Default logger (development)
+	level.Set(slog.LevelDebug)
 	logger = slog.New(
-		slog.NewTextHandler(os.Stderr, opts), // Added options here
+		newContextHandler(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})),
 	)
 
 	if config.Env == "production" {
@@ -34,15 +43,16 @@ func Set(config *config.App) {
 		}
 
 		// Production uses Info level by default
+		level.Set(slog.LevelInfo)
 		prodOpts := &slog.HandlerOptions{
-			Level: slog.LevelInfo,
+			Level: level,
 		}
 
 		logger = slog.New(
-			slogmulti.Fanout(
+			newContextHandler(slogmulti.Fanout(
 				slog.NewJSONHandler(logRotate, prodOpts),
 				slog.NewTextHandler(os.Stderr, opts), // Keep debug for stderr
-			),
+			)),
 		)
 	}
 
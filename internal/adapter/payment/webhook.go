@@ -0,0 +1,329 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+// signatureTolerance is how old a timestamped signature can be before it is rejected
+const signatureTolerance = 5 * time.Minute
+
+/**
+ * WebhookVerifier implements port.PaymentWebhookVerifier interface
+ * and knows how each payment provider signs and shapes its callbacks
+ */
+type WebhookVerifier struct {
+	secrets map[domain.PaymentProvider]string
+	now     func() time.Time
+	// mercadoPagoAPI and mercadoPagoToken fetch the payments MercadoPago notifies
+	mercadoPagoAPI   string
+	mercadoPagoToken string
+	client           *http.Client
+}
+
+// New creates a new payment webhook verifier instance
+func New(config *config.PaymentWebhook) port.PaymentWebhookVerifier {
+	return &WebhookVerifier{
+		secrets: map[domain.PaymentProvider]string{
+			domain.PaymentProviderStripe:      config.StripeSecret,
+			domain.PaymentProviderMercadoPago: config.MercadoPagoSecret,
+			domain.PaymentProviderBank:        config.BankSecret,
+		},
+		now:              time.Now,
+		mercadoPagoAPI:   strings.TrimSuffix(config.MercadoPagoAPIURL, "/"),
+		mercadoPagoToken: config.MercadoPagoAccessToken,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// Verify checks the provider signature and decodes the payload into a payment event
+func (wv *WebhookVerifier) Verify(ctx context.Context, provider domain.PaymentProvider, headers map[string]string, payload []byte) (*domain.PaymentEvent, error) {
+	secret := wv.secrets[provider]
+	if secret == "" {
+		return nil, domain.ErrInvalidSignature
+	}
+
+	switch provider {
+	case domain.PaymentProviderStripe:
+		return wv.verifyStripe(secret, headers, payload)
+	case domain.PaymentProviderMercadoPago:
+		return wv.verifyMercadoPago(ctx, secret, headers, payload)
+	case domain.PaymentProviderBank:
+		return wv.verifyBank(secret, headers, payload)
+	}
+
+	return nil, domain.ErrInvalidPaymentEvent
+}
+
+// stripeEvent is the subset of a Stripe event used to build a payment event
+type stripeEvent struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Data struct {
+		Object struct {
+			ID                string            `json:"id"`
+			Amount            int64             `json:"amount"`
+			AmountTotal       int64             `json:"amount_total"`
+			ClientReferenceID string            `json:"client_reference_id"`
+			Metadata          map[string]string `json:"metadata"`
+		} `json:"object"`
+	} `json:"data"`
+}
+
+// verifyStripe validates the Stripe-Signature header ("t=...,v1=...") over "<t>.<payload>"
+func (wv *WebhookVerifier) verifyStripe(secret string, headers map[string]string, payload []byte) (*domain.PaymentEvent, error) {
+	timestamp, signatures := parseSignatureHeader(headers["stripe-signature"], "t")
+	if err := wv.checkTimestamp(timestamp); err != nil {
+		return nil, err
+	}
+
+	expected := sign(secret, timestamp+"."+string(payload))
+	if !matchAny(expected, signatures) {
+		return nil, domain.ErrInvalidSignature
+	}
+
+	var event stripeEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, domain.ErrInvalidPaymentEvent
+	}
+
+	object := event.Data.Object
+	reference := object.Metadata["quoteId"]
+	if reference == "" {
+		reference = object.ClientReferenceID
+	}
+
+	amount := object.Amount
+	if amount == 0 {
+		amount = object.AmountTotal
+	}
+
+	var status domain.PaymentEventStatus
+	switch event.Type {
+	case "payment_intent.succeeded", "checkout.session.completed":
+		status = domain.PaymentSucceeded
+	case "payment_intent.payment_failed", "checkout.session.expired":
+		status = domain.PaymentFailed
+	default:
+		status = domain.PaymentPending
+	}
+
+	// Stripe reports amounts in the smallest currency unit
+	return newPaymentEvent(domain.PaymentProviderStripe, event.ID, reference, float64(amount)/100, status)
+}
+
+// mercadoPagoNotification is the part of a MercadoPago payment notification that is signed, the ID of the payment
+type mercadoPagoNotification struct {
+	Data struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+// mercadoPagoPayment is the subset of a payment of the MercadoPago API used to build a payment event
+type mercadoPagoPayment struct {
+	Status            string  `json:"status"`
+	ExternalReference string  `json:"external_reference"`
+	TransactionAmount float64 `json:"transaction_amount"`
+}
+
+// verifyMercadoPago validates the x-signature header ("ts=...,v1=...") over the MercadoPago manifest. The manifest
+// only signs the payment ID, so the payment is fetched from the MercadoPago API instead of trusting the body
+func (wv *WebhookVerifier) verifyMercadoPago(ctx context.Context, secret string, headers map[string]string, payload []byte) (*domain.PaymentEvent, error) {
+	if wv.mercadoPagoToken == "" {
+		return nil, domain.ErrInvalidSignature
+	}
+
+	timestamp, signatures := parseSignatureHeader(headers["x-signature"], "ts")
+	if err := wv.checkTimestamp(timestamp); err != nil {
+		return nil, err
+	}
+
+	var notification mercadoPagoNotification
+	if err := json.Unmarshal(payload, &notification); err != nil {
+		return nil, domain.ErrInvalidPaymentEvent
+	}
+
+	paymentID := notification.Data.ID
+	manifest := "id:" + strings.ToLower(paymentID) + ";request-id:" + headers["x-request-id"] + ";ts:" + timestamp + ";"
+	if !matchAny(sign(secret, manifest), signatures) {
+		return nil, domain.ErrInvalidSignature
+	}
+	if paymentID == "" {
+		return nil, domain.ErrInvalidPaymentEvent
+	}
+
+	payment, err := wv.fetchMercadoPagoPayment(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var status domain.PaymentEventStatus
+	switch payment.Status {
+	case "approved":
+		status = domain.PaymentSucceeded
+	case "rejected", "cancelled", "refunded", "charged_back":
+		status = domain.PaymentFailed
+	default:
+		status = domain.PaymentPending
+	}
+
+	// MercadoPago notifies every status of a payment under the same ID,
+	// so a pending notification must not hide the approval that follows it
+	externalID := paymentID + ":" + payment.Status
+
+	return newPaymentEvent(domain.PaymentProviderMercadoPago, externalID, payment.ExternalReference, payment.TransactionAmount, status)
+}
+
+// fetchMercadoPagoPayment gets the payment with id from the MercadoPago API, a payment it doesn't know is an invalid event
+func (wv *WebhookVerifier) fetchMercadoPagoPayment(ctx context.Context, id string) (*mercadoPagoPayment, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wv.mercadoPagoAPI+"/v1/payments/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create mercadopago request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+wv.mercadoPagoToken)
+
+	resp, err := wv.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch mercadopago payment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, domain.ErrInvalidPaymentEvent
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("mercadopago answered %d fetching payment %s", resp.StatusCode, id)
+	}
+
+	var payment mercadoPagoPayment
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&payment); err != nil {
+		return nil, fmt.Errorf("failed to decode mercadopago payment: %w", err)
+	}
+
+	return &payment, nil
+}
+
+// bankEvent is the payload agreed with the bank for transfer notifications
+type bankEvent struct {
+	ID        string  `json:"id"`
+	Reference string  `json:"reference"`
+	Amount    float64 `json:"amount"`
+	Status    string  `json:"status"`
+}
+
+// verifyBank validates the x-signature header ("t=...,v1=...") over "<t>.<payload>", as Stripe signs
+func (wv *WebhookVerifier) verifyBank(secret string, headers map[string]string, payload []byte) (*domain.PaymentEvent, error) {
+	timestamp, signatures := parseSignatureHeader(headers["x-signature"], "t")
+	if err := wv.checkTimestamp(timestamp); err != nil {
+		return nil, err
+	}
+
+	if !matchAny(sign(secret, timestamp+"."+string(payload)), signatures) {
+		return nil, domain.ErrInvalidSignature
+	}
+
+	var event bankEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, domain.ErrInvalidPaymentEvent
+	}
+
+	status := domain.PaymentEventStatus(event.Status)
+	if status != domain.PaymentSucceeded && status != domain.PaymentFailed {
+		status = domain.PaymentPending
+	}
+
+	return newPaymentEvent(domain.PaymentProviderBank, event.ID, event.Reference, event.Amount, status)
+}
+
+// checkTimestamp rejects signatures without a timestamp or outside the tolerance window
+func (wv *WebhookVerifier) checkTimestamp(timestamp string) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return domain.ErrInvalidSignature
+	}
+
+	age := wv.now().Sub(time.Unix(seconds, 0))
+	if age > signatureTolerance || age < -signatureTolerance {
+		return domain.ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// newPaymentEvent builds a payment event, the reference must be the ID of the paid quote
+func newPaymentEvent(provider domain.PaymentProvider, externalID, reference string, amount float64, status domain.PaymentEventStatus) (*domain.PaymentEvent, error) {
+	if externalID == "" {
+		return nil, domain.ErrInvalidPaymentEvent
+	}
+
+	quoteID, err := uuid.Parse(reference)
+	if err != nil {
+		return nil, domain.ErrInvalidPaymentEvent
+	}
+
+	return &domain.PaymentEvent{
+		ID:         uuid.New(),
+		Provider:   provider,
+		ExternalID: externalID,
+		QuoteID:    quoteID,
+		Amount:     amount,
+		Status:     status,
+	}, nil
+}
+
+// parseSignatureHeader splits "key=value" pairs, returning the timestamp and every v1 signature
+func parseSignatureHeader(header, timestampKey string) (string, []string) {
+	var timestamp string
+	var signatures []string
+
+	for _, part := range strings.Split(header, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			continue
+		}
+
+		switch key {
+		case timestampKey:
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	return timestamp, signatures
+}
+
+// sign returns the hex encoded HMAC-SHA256 of message
+func sign(secret, message string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// matchAny compares expected against each candidate in constant time
+func matchAny(expected string, candidates []string) bool {
+	for _, candidate := range candidates {
+		if hmac.Equal([]byte(expected), []byte(strings.ToLower(candidate))) {
+			return true
+		}
+	}
+	return false
+}
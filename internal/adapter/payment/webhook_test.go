@@ -0,0 +1,204 @@
+package payment
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const quoteID = "7f3c2a64-3f5d-4e0b-9a47-2c1d6f0b8e11"
+
+func newTestVerifier() *WebhookVerifier {
+	verifier := New(&config.PaymentWebhook{
+		StripeSecret:           "whsec_stripe",
+		MercadoPagoSecret:      "mp_secret",
+		BankSecret:             "bank_secret",
+		MercadoPagoAccessToken: "mp_token",
+	}).(*WebhookVerifier)
+	verifier.now = func() time.Time { return time.Unix(1700000000, 0) }
+	return verifier
+}
+
+func TestVerifyStripe(t *testing.T) {
+	verifier := newTestVerifier()
+	payload := []byte(`{"id":"evt_1","type":"payment_intent.succeeded","data":{"object":{"amount":35050,"metadata":{"quoteId":"` + quoteID + `"}}}}`)
+	timestamp := strconv.FormatInt(verifier.now().Unix(), 10)
+
+	t.Run("valid signature", func(t *testing.T) {
+		headers := map[string]string{
+			"stripe-signature": "t=" + timestamp + ",v1=" + sign("whsec_stripe", timestamp+"."+string(payload)),
+		}
+
+		event, err := verifier.Verify(context.Background(), domain.PaymentProviderStripe, headers, payload)
+		require.NoError(t, err)
+		assert.Equal(t, "evt_1", event.ExternalID)
+		assert.Equal(t, quoteID, event.QuoteID.String())
+		assert.Equal(t, 350.5, event.Amount)
+		assert.Equal(t, domain.PaymentSucceeded, event.Status)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		headers := map[string]string{
+			"stripe-signature": "t=" + timestamp + ",v1=" + sign("whsec_stripe", timestamp+"."+string(payload)),
+		}
+
+		_, err := verifier.Verify(context.Background(), domain.PaymentProviderStripe, headers, append(payload, ' '))
+		assert.Equal(t, domain.ErrInvalidSignature, err)
+	})
+
+	t.Run("expired timestamp", func(t *testing.T) {
+		old := strconv.FormatInt(verifier.now().Add(-10*time.Minute).Unix(), 10)
+		headers := map[string]string{
+			"stripe-signature": "t=" + old + ",v1=" + sign("whsec_stripe", old+"."+string(payload)),
+		}
+
+		_, err := verifier.Verify(context.Background(), domain.PaymentProviderStripe, headers, payload)
+		assert.Equal(t, domain.ErrInvalidSignature, err)
+	})
+}
+
+// mercadoPagoAPI serves the payments of MercadoPago from statuses, by payment ID
+func mercadoPagoAPI(t *testing.T, statuses map[string]string) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer mp_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		status, ok := statuses[r.URL.Path[len("/v1/payments/"):]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"id":123,"status":"` + status + `","external_reference":"` + quoteID + `","transaction_amount":350.5}`))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestVerifyMercadoPago(t *testing.T) {
+	verifier := newTestVerifier()
+	statuses := map[string]string{"123": "approved"}
+	verifier.mercadoPagoAPI = mercadoPagoAPI(t, statuses)
+	timestamp := strconv.FormatInt(verifier.now().Unix(), 10)
+
+	notify := func(body, ts string) (*domain.PaymentEvent, error) {
+		headers := map[string]string{
+			"x-signature":  "ts=" + ts + ",v1=" + sign("mp_secret", "id:123;request-id:req-1;ts:"+ts+";"),
+			"x-request-id": "req-1",
+		}
+		return verifier.Verify(context.Background(), domain.PaymentProviderMercadoPago, headers, []byte(body))
+	}
+
+	t.Run("valid signature", func(t *testing.T) {
+		event, err := notify(`{"data":{"id":"123"}}`, timestamp)
+		require.NoError(t, err)
+		assert.Equal(t, "123:approved", event.ExternalID)
+		assert.Equal(t, quoteID, event.QuoteID.String())
+		assert.Equal(t, 350.5, event.Amount)
+		assert.Equal(t, domain.PaymentSucceeded, event.Status)
+	})
+
+	t.Run("the payment comes from the API, not from the body", func(t *testing.T) {
+		statuses["123"] = "in_process"
+		t.Cleanup(func() { statuses["123"] = "approved" })
+
+		event, err := notify(`{"data":{"id":"123","status":"approved","external_reference":"`+quoteID+`","transaction_amount":99999}}`, timestamp)
+		require.NoError(t, err)
+		assert.Equal(t, domain.PaymentPending, event.Status)
+		assert.Equal(t, 350.5, event.Amount)
+	})
+
+	t.Run("each status of a payment is a distinct event", func(t *testing.T) {
+		statuses["123"] = "in_process"
+		pending, err := notify(`{"data":{"id":"123"}}`, timestamp)
+		require.NoError(t, err)
+		statuses["123"] = "approved"
+		approved, err := notify(`{"data":{"id":"123"}}`, timestamp)
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.PaymentPending, pending.Status)
+		assert.NotEqual(t, pending.ExternalID, approved.ExternalID)
+	})
+
+	t.Run("unknown payment", func(t *testing.T) {
+		delete(statuses, "123")
+		t.Cleanup(func() { statuses["123"] = "approved" })
+
+		_, err := notify(`{"data":{"id":"123"}}`, timestamp)
+		assert.Equal(t, domain.ErrInvalidPaymentEvent, err)
+	})
+
+	t.Run("API unavailable", func(t *testing.T) {
+		api := verifier.mercadoPagoAPI
+		verifier.mercadoPagoAPI = "http://127.0.0.1:0"
+		t.Cleanup(func() { verifier.mercadoPagoAPI = api })
+
+		_, err := notify(`{"data":{"id":"123"}}`, timestamp)
+		assert.Error(t, err)
+		assert.NotEqual(t, domain.ErrInvalidPaymentEvent, err)
+	})
+
+	t.Run("expired timestamp", func(t *testing.T) {
+		old := strconv.FormatInt(verifier.now().Add(-10*time.Minute).Unix(), 10)
+
+		_, err := notify(`{"data":{"id":"123"}}`, old)
+		assert.Equal(t, domain.ErrInvalidSignature, err)
+	})
+}
+
+func TestVerifyBank(t *testing.T) {
+	verifier := newTestVerifier()
+	timestamp := strconv.FormatInt(verifier.now().Unix(), 10)
+
+	signed := func(ts string, payload []byte) map[string]string {
+		return map[string]string{"x-signature": "t=" + ts + ",v1=" + sign("bank_secret", ts+"."+string(payload))}
+	}
+
+	t.Run("valid signature", func(t *testing.T) {
+		payload := []byte(`{"id":"tx-9","reference":"` + quoteID + `","amount":100,"status":"failed"}`)
+
+		event, err := verifier.Verify(context.Background(), domain.PaymentProviderBank, signed(timestamp, payload), payload)
+		require.NoError(t, err)
+		assert.Equal(t, domain.PaymentFailed, event.Status)
+	})
+
+	t.Run("expired timestamp", func(t *testing.T) {
+		payload := []byte(`{"id":"tx-9","reference":"` + quoteID + `","amount":100,"status":"succeeded"}`)
+		old := strconv.FormatInt(verifier.now().Add(-10*time.Minute).Unix(), 10)
+
+		_, err := verifier.Verify(context.Background(), domain.PaymentProviderBank, signed(old, payload), payload)
+		assert.Equal(t, domain.ErrInvalidSignature, err)
+	})
+
+	t.Run("signature without a timestamp", func(t *testing.T) {
+		payload := []byte(`{"id":"tx-9","reference":"` + quoteID + `","amount":100,"status":"succeeded"}`)
+		headers := map[string]string{"x-signature": sign("bank_secret", string(payload))}
+
+		_, err := verifier.Verify(context.Background(), domain.PaymentProviderBank, headers, payload)
+		assert.Equal(t, domain.ErrInvalidSignature, err)
+	})
+
+	t.Run("reference is not a quote", func(t *testing.T) {
+		payload := []byte(`{"id":"tx-9","reference":"invoice-42","amount":100,"status":"succeeded"}`)
+
+		_, err := verifier.Verify(context.Background(), domain.PaymentProviderBank, signed(timestamp, payload), payload)
+		assert.Equal(t, domain.ErrInvalidPaymentEvent, err)
+	})
+}
+
+func TestVerifyMissingSecret(t *testing.T) {
+	verifier := New(&config.PaymentWebhook{})
+
+	_, err := verifier.Verify(context.Background(), domain.PaymentProviderBank, map[string]string{}, []byte(`{}`))
+	assert.Equal(t, domain.ErrInvalidSignature, err)
+}
@@ -0,0 +1,112 @@
+package clamav
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/port"
+)
+
+// chunkSize is the size of the chunks streamed to clamd, well under its StreamMaxLength
+const chunkSize = 64 << 10
+
+/**
+ * ClamAV implements port.FileScanner interface
+ * and scans files with a clamd daemon through its INSTREAM command
+ */
+type ClamAV struct {
+	addr    string
+	timeout time.Duration
+}
+
+// New creates a new ClamAV scanner talking to the clamd daemon at cfg.Addr
+func New(cfg *config.ClamAV) (port.FileScanner, error) {
+	timeout, err := time.ParseDuration(cfg.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clamav timeout: %w", err)
+	}
+
+	return &ClamAV{
+		addr:    cfg.Addr,
+		timeout: timeout,
+	}, nil
+}
+
+// Scan streams r to clamd and returns the name of the signature it matched, if any
+func (c *ClamAV) Scan(ctx context.Context, r io.Reader) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	// Unblock reads and writes as soon as the context ends
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, chunkSize)
+	size := make([]byte, 4)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, werr := conn.Write(size); werr != nil {
+				return "", werr
+			}
+			if _, werr := conn.Write(buf[:n]); werr != nil {
+				return "", werr
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// A zero length chunk ends the stream
+	binary.BigEndian.PutUint32(size, 0)
+	if _, err := conn.Write(size); err != nil {
+		return "", err
+	}
+
+	reply, err := bufio.NewReader(conn).ReadBytes(0)
+	if err != nil {
+		return "", err
+	}
+
+	return parseReply(string(bytes.TrimRight(reply, "\x00")))
+}
+
+// parseReply interprets clamd's answer, "stream: OK" or "stream: <signature> FOUND"
+func parseReply(reply string) (string, error) {
+	result := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+
+	switch {
+	case result == "OK":
+		return "", nil
+	case strings.HasSuffix(result, " FOUND"):
+		return strings.TrimSuffix(result, " FOUND"), nil
+	default:
+		return "", fmt.Errorf("clamav: %s", result)
+	}
+}
@@ -0,0 +1,89 @@
+package clamav
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"harajuku/backend/internal/adapter/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClamd accepts one INSTREAM session and answers with reply when the stream contains infected, "OK" otherwise
+func fakeClamd(t *testing.T, infected, reply string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		command, err := r.ReadString(0)
+		if err != nil || command != "zINSTREAM\x00" {
+			return
+		}
+
+		var stream strings.Builder
+		size := make([]byte, 4)
+		for {
+			if _, err := io.ReadFull(r, size); err != nil {
+				return
+			}
+			n := binary.BigEndian.Uint32(size)
+			if n == 0 {
+				break
+			}
+			if _, err := io.CopyN(&stream, r, int64(n)); err != nil {
+				return
+			}
+		}
+
+		answer := "stream: OK"
+		if strings.Contains(stream.String(), infected) {
+			answer = "stream: " + reply
+		}
+		conn.Write([]byte(answer + "\x00"))
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestScanCleanFile(t *testing.T) {
+	addr := fakeClamd(t, "EICAR", "Eicar-Signature FOUND")
+	scanner, err := New(&config.ClamAV{Addr: addr, Timeout: "5s"})
+	require.NoError(t, err)
+
+	threat, err := scanner.Scan(context.Background(), strings.NewReader(strings.Repeat("mechón", 50000)))
+	require.NoError(t, err)
+	assert.Empty(t, threat)
+}
+
+func TestScanInfectedFile(t *testing.T) {
+	addr := fakeClamd(t, "EICAR", "Eicar-Signature FOUND")
+	scanner, err := New(&config.ClamAV{Addr: addr, Timeout: "5s"})
+	require.NoError(t, err)
+
+	threat, err := scanner.Scan(context.Background(), strings.NewReader("X5O!P%@AP[4\\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*"))
+	require.NoError(t, err)
+	assert.Equal(t, "Eicar-Signature", threat)
+}
+
+func TestScanError(t *testing.T) {
+	addr := fakeClamd(t, "", "INSTREAM size limit exceeded. ERROR")
+	scanner, err := New(&config.ClamAV{Addr: addr, Timeout: "5s"})
+	require.NoError(t, err)
+
+	_, err = scanner.Scan(context.Background(), strings.NewReader("too big"))
+	assert.Error(t, err)
+}
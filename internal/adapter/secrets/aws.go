@@ -0,0 +1,114 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+)
+
+/**
+ * AWSSecretsManager implements the Provider interface
+ * and reads secrets from AWS Secrets Manager through its JSON API
+ */
+type AWSSecretsManager struct {
+	url         string
+	region      string
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	client      *http.Client
+}
+
+// getSecretValueResponse is the part of the GetSecretValue response that holds the secret
+type getSecretValueResponse struct {
+	SecretString string `json:"SecretString"`
+	SecretBinary string `json:"SecretBinary"`
+}
+
+// awsErrorResponse is the body Secrets Manager answers failed requests with
+type awsErrorResponse struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+}
+
+// NewAWSSecretsManager creates a new AWS Secrets Manager provider for region, credentials are taken from the default AWS chain
+func NewAWSSecretsManager(ctx context.Context, region string) (Provider, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load aws config: %w", err)
+	}
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("aws secrets manager needs a region")
+	}
+
+	return &AWSSecretsManager{
+		url:         fmt.Sprintf("https://secretsmanager.%s.amazonaws.com/", cfg.Region),
+		region:      cfg.Region,
+		credentials: cfg.Credentials,
+		signer:      v4.NewSigner(),
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}, nil
+}
+
+// GetSecret returns the current version of the secret whose name or ARN is name
+func (sm *AWSSecretsManager) GetSecret(ctx context.Context, name string) (string, error) {
+	body, err := json.Marshal(map[string]string{"SecretId": name})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sm.url, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+
+	creds, err := sm.credentials.Retrieve(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get aws credentials: %w", err)
+	}
+
+	hash := sha256.Sum256(body)
+	if err := sm.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), "secretsmanager", sm.region, time.Now()); err != nil {
+		return "", fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	res, err := sm.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		var body awsErrorResponse
+		_ = json.NewDecoder(res.Body).Decode(&body)
+		return "", fmt.Errorf("secrets manager answered with status %d: %s: %s", res.StatusCode, body.Type, body.Message)
+	}
+
+	var secret getSecretValueResponse
+	if err := json.NewDecoder(res.Body).Decode(&secret); err != nil {
+		return "", fmt.Errorf("failed to decode secret: %w", err)
+	}
+
+	if secret.SecretString == "" && secret.SecretBinary != "" {
+		value, err := base64.StdEncoding.DecodeString(secret.SecretBinary)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode binary secret: %w", err)
+		}
+		return string(value), nil
+	}
+
+	return secret.SecretString, nil
+}
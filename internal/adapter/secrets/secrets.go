@@ -0,0 +1,66 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Provider fetches secrets from a secrets manager. The providers take their settings as arguments instead of
+// a config struct, since they are used while the config is being loaded
+type Provider interface {
+	// GetSecret returns the value of the secret called name, JSON for secrets holding several fields
+	GetSecret(ctx context.Context, name string) (string, error)
+}
+
+// Resolver resolves references to secrets, fetching each secret once however many fields are read from it
+type Resolver struct {
+	provider Provider
+	secrets  map[string]string
+}
+
+// NewResolver creates a new Resolver reading the secrets from provider
+func NewResolver(provider Provider) *Resolver {
+	return &Resolver{
+		provider,
+		map[string]string{},
+	}
+}
+
+// Resolve returns the value ref points to. A reference is the name of a secret, or the name and a field of
+// a JSON secret separated by #, like harajuku/production#db_password
+func (r *Resolver) Resolve(ctx context.Context, ref string) (string, error) {
+	name, field, hasField := strings.Cut(ref, "#")
+	if name == "" || (hasField && field == "") {
+		return "", fmt.Errorf("invalid secret reference %q", ref)
+	}
+
+	secret, ok := r.secrets[name]
+	if !ok {
+		var err error
+		secret, err = r.provider.GetSecret(ctx, name)
+		if err != nil {
+			return "", fmt.Errorf("failed to get secret %s: %w", name, err)
+		}
+		r.secrets[name] = secret
+	}
+
+	if !hasField {
+		return secret, nil
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(secret), &fields); err != nil {
+		return "", fmt.Errorf("secret %s isn't a JSON object, so it has no field %s", name, field)
+	}
+
+	value, ok := fields[field]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no field %s", name, field)
+	}
+	if s, isString := value.(string); isString {
+		return s, nil
+	}
+	return fmt.Sprint(value), nil
+}
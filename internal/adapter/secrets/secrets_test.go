@@ -0,0 +1,81 @@
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingProvider returns fixed secrets and counts how many times each one is fetched
+type countingProvider struct {
+	secrets map[string]string
+	fetched map[string]int
+}
+
+func (p *countingProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	p.fetched[name]++
+	return p.secrets[name], nil
+}
+
+func TestResolverResolve(t *testing.T) {
+	provider := &countingProvider{
+		secrets: map[string]string{
+			"harajuku/production": `{"db_password":"s3cr3t","api_token":"tok","retries":3}`,
+			"token-key":           "a1b2c3",
+		},
+		fetched: map[string]int{},
+	}
+	resolver := NewResolver(provider)
+	ctx := context.Background()
+
+	value, err := resolver.Resolve(ctx, "harajuku/production#db_password")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", value)
+
+	value, err = resolver.Resolve(ctx, "harajuku/production#retries")
+	require.NoError(t, err)
+	assert.Equal(t, "3", value)
+
+	value, err = resolver.Resolve(ctx, "token-key")
+	require.NoError(t, err)
+	assert.Equal(t, "a1b2c3", value)
+
+	// Every field comes from one read of the secret
+	assert.Equal(t, 1, provider.fetched["harajuku/production"])
+
+	for _, ref := range []string{"harajuku/production#missing", "token-key#field", "#field", "harajuku/production#"} {
+		_, err := resolver.Resolve(ctx, ref)
+		assert.Error(t, err, ref)
+	}
+}
+
+func TestVaultGetSecret(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+
+		assert.Equal(t, "/v1/kv/data/harajuku/production", r.URL.Path)
+		w.Write([]byte(`{"data":{"data":{"db_password":"s3cr3t"},"metadata":{"version":2}}}`))
+	}))
+	defer server.Close()
+
+	vault, err := NewVault(server.URL, "root", "kv", "")
+	require.NoError(t, err)
+
+	secret, err := vault.GetSecret(context.Background(), "harajuku/production")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"db_password":"s3cr3t"}`, secret)
+
+	denied, err := NewVault(server.URL, "wrong", "kv", "")
+	require.NoError(t, err)
+
+	_, err = denied.GetSecret(context.Background(), "harajuku/production")
+	assert.ErrorContains(t, err, "permission denied")
+}
@@ -0,0 +1,90 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+/**
+ * Vault implements the Provider interface
+ * and reads secrets from a HashiCorp Vault KV version 2 engine
+ */
+type Vault struct {
+	addr      string
+	token     string
+	mount     string
+	namespace string
+	client    *http.Client
+}
+
+// vaultResponse is the part of a KV version 2 read that holds the secret
+type vaultResponse struct {
+	Data struct {
+		Data map[string]any `json:"data"`
+	} `json:"data"`
+}
+
+// vaultErrorResponse is the body Vault answers failed requests with
+type vaultErrorResponse struct {
+	Errors []string `json:"errors"`
+}
+
+// NewVault creates a new Vault provider reading from the KV engine mounted at mount, namespace is only set on Vault Enterprise
+func NewVault(addr, token, mount, namespace string) (Provider, error) {
+	if addr == "" || token == "" || mount == "" {
+		return nil, errors.New("invalid vault configuration")
+	}
+
+	return &Vault{
+		addr:      strings.TrimSuffix(addr, "/"),
+		token:     token,
+		mount:     strings.Trim(mount, "/"),
+		namespace: namespace,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}, nil
+}
+
+// GetSecret returns the fields of the latest version of the secret at the path name as a JSON object
+func (v *Vault) GetSecret(ctx context.Context, name string) (string, error) {
+	endpoint := fmt.Sprintf("%s/v1/%s/data/%s", v.addr, v.mount, (&url.URL{Path: strings.Trim(name, "/")}).EscapedPath())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("X-Vault-Token", v.token)
+	if v.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+
+	res, err := v.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		var body vaultErrorResponse
+		_ = json.NewDecoder(res.Body).Decode(&body)
+		return "", fmt.Errorf("vault answered with status %d: %s", res.StatusCode, strings.Join(body.Errors, ", "))
+	}
+
+	var secret vaultResponse
+	if err := json.NewDecoder(res.Body).Decode(&secret); err != nil {
+		return "", fmt.Errorf("failed to decode secret: %w", err)
+	}
+
+	fields, err := json.Marshal(secret.Data.Data)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode secret: %w", err)
+	}
+	return string(fields), nil
+}
@@ -3,50 +3,196 @@ package awsS3
 import (
 	"bytes"
 	"context"
+	"errors"
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
 	"harajuku/backend/internal/core/port"
 	"io"
+	"time"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/s3"
-	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
+const (
+	// MinPartSize is the smallest part S3 accepts in a multipart upload, except for the last one
+	MinPartSize = 5 << 20
+	// maxParts is the maximum number of parts of a multipart upload
+	maxParts = 10000
+)
+
+// Client is the part of the S3 API used by the adapter, *s3.Client implements it and tests can inject a fake
+type Client interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+	CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error)
+	UploadPart(ctx context.Context, params *s3.UploadPartInput, optFns ...func(*s3.Options)) (*s3.UploadPartOutput, error)
+	CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error)
+	AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error)
+	HeadBucket(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error)
+	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+}
+
 type AwsS3 struct {
-	client s3iface.S3API
-	bucket string
+	client   Client
+	bucket   string
+	partSize int64
+}
+
+// NewAwsS3 creates a file repository on an S3 bucket, files larger than partSize are uploaded in parts of that size
+func NewAwsS3(client Client, bucket string, partSize int64) port.FileRepository {
+	if partSize < MinPartSize {
+		partSize = MinPartSize
+	}
+
+	return &AwsS3{
+		client:   client,
+		bucket:   bucket,
+		partSize: partSize,
+	}
 }
 
-func NewAwsS3(sess *session.Session, bucket string) port.FileRepository {
-	return &AwsS3 {
-		client: s3.New(sess),
-		bucket: bucket,
+// NewClient creates an S3 client from the configuration. Credentials fall back to the default chain
+// (environment, shared files, instance role) when no keys are given, and an endpoint points the client
+// at an S3-compatible store such as MinIO
+func NewClient(ctx context.Context, cfg *config.AwsS3) (*s3.Client, error) {
+	opts := []func(*awsconfig.LoadOptions) error{
+		awsconfig.WithRegion(cfg.Region),
+		awsconfig.WithRetryMaxAttempts(cfg.MaxAttempts),
+	}
+
+	if cfg.AccessKey != "" {
+		opts = append(opts, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretKey, ""),
+		))
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, err
 	}
+
+	return s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		o.UsePathStyle = cfg.UsePathStyle
+
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+			// Third-party stores do not all support the integrity checksums S3 now sends by default
+			o.RequestChecksumCalculation = aws.RequestChecksumCalculationWhenRequired
+			o.ResponseChecksumValidation = aws.ResponseChecksumValidationWhenRequired
+		}
+	}), nil
 }
 
-func (a *AwsS3) Save(ctx context.Context, data []byte, name string) (string, error) {
-	input := &s3.PutObjectInput{
+// Save streams the file to the bucket holding at most one part in memory. Files that fit in a
+// single part are sent with one request, larger ones through a multipart upload that is aborted on failure
+func (a *AwsS3) Save(ctx context.Context, r io.Reader, size int64, name string) (string, error) {
+	partSize := a.partSize
+	// Grow the parts so very large files stay under the limit of parts per upload
+	if size > partSize*maxParts {
+		partSize = size/maxParts + 1
+	}
+
+	part := make([]byte, partSize)
+	n, err := io.ReadFull(r, part)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		_, err = a.client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:        aws.String(a.bucket),
+			Key:           aws.String(name),
+			Body:          bytes.NewReader(part[:n]),
+			ContentLength: aws.Int64(int64(n)),
+		})
+		if err != nil {
+			return "", err
+		}
+		return name, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	upload, err := a.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
 		Bucket: aws.String(a.bucket),
 		Key:    aws.String(name),
-		Body:   bytes.NewReader(data),
+	})
+	if err != nil {
+		return "", err
 	}
 
-	_, err := a.client.PutObjectWithContext(ctx, input)
+	err = a.uploadParts(ctx, upload.UploadId, name, r, part)
 	if err != nil {
-		return "", err
+		// Abort even when the request was cancelled, otherwise the parts keep being billed
+		abortCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+		defer cancel()
+
+		_, abortErr := a.client.AbortMultipartUpload(abortCtx, &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(a.bucket),
+			Key:      aws.String(name),
+			UploadId: upload.UploadId,
+		})
+		return "", errors.Join(err, abortErr)
 	}
 
 	return name, nil
 }
 
+// uploadParts sends the already read first part and the rest of r, then completes the upload
+func (a *AwsS3) uploadParts(ctx context.Context, uploadID *string, name string, r io.Reader, part []byte) error {
+	var completed []types.CompletedPart
+
+	for number := int32(1); ; number++ {
+		output, err := a.client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:        aws.String(a.bucket),
+			Key:           aws.String(name),
+			UploadId:      uploadID,
+			PartNumber:    aws.Int32(number),
+			Body:          bytes.NewReader(part),
+			ContentLength: aws.Int64(int64(len(part))),
+		})
+		if err != nil {
+			return err
+		}
+
+		completed = append(completed, types.CompletedPart{
+			ETag:       output.ETag,
+			PartNumber: aws.Int32(number),
+		})
+
+		n, err := io.ReadFull(r, part[:cap(part)])
+		if err == io.EOF {
+			break
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		part = part[:n]
+	}
+
+	_, err := a.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(a.bucket),
+		Key:             aws.String(name),
+		UploadId:        uploadID,
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: completed},
+	})
+	return err
+}
+
 func (a *AwsS3) Get(ctx context.Context, path string) ([]byte, error) {
 	input := &s3.GetObjectInput{
 		Bucket: aws.String(a.bucket),
 		Key:    aws.String(path),
 	}
 
-	result, err := a.client.GetObjectWithContext(ctx, input)
+	result, err := a.client.GetObject(ctx, input)
 	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, domain.ErrDataNotFound
+		}
 		return nil, err
 	}
 	defer result.Body.Close()
@@ -60,6 +206,37 @@ func (a *AwsS3) Delete(ctx context.Context, path string) error {
 		Key:    aws.String(path),
 	}
 
-	_, err := a.client.DeleteObjectWithContext(ctx, input)
+	_, err := a.client.DeleteObject(ctx, input)
+	return err
+}
+
+// ListFiles implements port.FileLister, listing the bucket a page at a time
+func (a *AwsS3) ListFiles(ctx context.Context, fn func(key string, modifiedAt time.Time) error) error {
+	paginator := s3.NewListObjectsV2Paginator(a.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(a.bucket),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, object := range page.Contents {
+			err := fn(aws.ToString(object.Key), aws.ToTime(object.LastModified))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Ping implements port.HealthChecker, checking the bucket exists and the credentials can reach it
+func (a *AwsS3) Ping(ctx context.Context) error {
+	_, err := a.client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(a.bucket),
+	})
 	return err
 }
@@ -0,0 +1,185 @@
+package awsS3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient keeps objects and pending multipart uploads in memory
+type fakeClient struct {
+	objects  map[string][]byte
+	uploads  map[string][][]byte
+	aborted  []string
+	failPart int32
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		objects: map[string][]byte{},
+		uploads: map[string][][]byte{},
+	}
+}
+
+func (f *fakeClient) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	data, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.objects[*params.Bucket+"/"+*params.Key] = data
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (f *fakeClient) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	data, ok := f.objects[*params.Bucket+"/"+*params.Key]
+	if !ok {
+		return nil, &types.NoSuchKey{Message: aws.String("not found")}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(data))}, nil
+}
+
+func (f *fakeClient) DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error) {
+	delete(f.objects, *params.Bucket+"/"+*params.Key)
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+func (f *fakeClient) CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error) {
+	id := *params.Bucket + "/" + *params.Key
+	f.uploads[id] = nil
+	return &s3.CreateMultipartUploadOutput{UploadId: aws.String(id)}, nil
+}
+
+func (f *fakeClient) UploadPart(ctx context.Context, params *s3.UploadPartInput, optFns ...func(*s3.Options)) (*s3.UploadPartOutput, error) {
+	if *params.PartNumber == f.failPart {
+		return nil, errors.New("connection reset")
+	}
+
+	data, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	f.uploads[*params.UploadId] = append(f.uploads[*params.UploadId], data)
+	return &s3.UploadPartOutput{ETag: aws.String("etag")}, nil
+}
+
+func (f *fakeClient) CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error) {
+	f.objects[*params.UploadId] = bytes.Join(f.uploads[*params.UploadId], nil)
+	delete(f.uploads, *params.UploadId)
+	return &s3.CompleteMultipartUploadOutput{}, nil
+}
+
+func (f *fakeClient) AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error) {
+	f.aborted = append(f.aborted, *params.UploadId)
+	delete(f.uploads, *params.UploadId)
+	return &s3.AbortMultipartUploadOutput{}, nil
+}
+
+func (f *fakeClient) HeadBucket(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+	return &s3.HeadBucketOutput{}, nil
+}
+
+// ListObjectsV2 lists the objects of the bucket in key order, two per page
+func (f *fakeClient) ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	var keys []string
+	for id := range f.objects {
+		key, found := strings.CutPrefix(id, *params.Bucket+"/")
+		if found && key > aws.ToString(params.ContinuationToken) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	output := &s3.ListObjectsV2Output{}
+	for _, key := range keys {
+		if len(output.Contents) == 2 {
+			output.IsTruncated = aws.Bool(true)
+			output.NextContinuationToken = output.Contents[1].Key
+			break
+		}
+		output.Contents = append(output.Contents, types.Object{Key: aws.String(key), LastModified: aws.Time(time.Now())})
+	}
+	return output, nil
+}
+
+func TestSaveGetDelete(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	storage := NewAwsS3(client, "harajuku", MinPartSize)
+
+	key, err := storage.Save(ctx, strings.NewReader("mechón"), -1, "quotes/42/photo.jpg")
+	require.NoError(t, err)
+	assert.Equal(t, "quotes/42/photo.jpg", key)
+	assert.Contains(t, client.objects, "harajuku/quotes/42/photo.jpg")
+
+	data, err := storage.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("mechón"), data)
+
+	require.NoError(t, storage.Delete(ctx, key))
+
+	_, err = storage.Get(ctx, key)
+	assert.Equal(t, domain.ErrDataNotFound, err)
+}
+
+func TestSaveMultipart(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	storage := NewAwsS3(client, "harajuku", MinPartSize)
+
+	video := bytes.Repeat([]byte("v"), 2*MinPartSize+1234)
+
+	_, err := storage.Save(ctx, bytes.NewReader(video), -1, "quotes/42/video.mp4")
+	require.NoError(t, err)
+	assert.Equal(t, video, client.objects["harajuku/quotes/42/video.mp4"])
+	assert.Empty(t, client.uploads)
+}
+
+func TestSaveMultipartAbortsOnFailure(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	client.failPart = 2
+	storage := NewAwsS3(client, "harajuku", MinPartSize)
+
+	video := bytes.Repeat([]byte("v"), 3*MinPartSize)
+
+	_, err := storage.Save(ctx, bytes.NewReader(video), -1, "quotes/42/video.mp4")
+	assert.Error(t, err)
+	assert.Equal(t, []string{"harajuku/quotes/42/video.mp4"}, client.aborted)
+	assert.Empty(t, client.uploads)
+	assert.NotContains(t, client.objects, "harajuku/quotes/42/video.mp4")
+}
+
+func TestListFiles(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	storage := NewAwsS3(client, "harajuku", MinPartSize)
+
+	keys := []string{"proofs/7/receipt.pdf", "quotes/42/photo.jpg", "quotes/42/video.mp4", "thumbnails/quotes/42/photo.jpg"}
+	for _, key := range keys {
+		_, err := storage.Save(ctx, strings.NewReader("x"), 1, key)
+		require.NoError(t, err)
+	}
+
+	var listed []string
+	err := storage.(port.FileLister).ListFiles(ctx, func(key string, modifiedAt time.Time) error {
+		assert.False(t, modifiedAt.IsZero())
+		listed = append(listed, key)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, keys, listed)
+}
@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrMiss is returned when the key is not in the cache
+var ErrMiss = errors.New("cache miss")
+
+/**
+ * Memory implements port.CacheRepository interface
+ * and keeps the values in an in-process LRU, so the API can run without a redis server
+ */
+type Memory struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	tags    map[string]map[string]struct{}
+	lru     *list.List
+}
+
+// memoryEntry is a value stored in the LRU
+type memoryEntry struct {
+	key       string
+	value     []byte
+	tags      []string
+	expiresAt time.Time
+}
+
+// NewMemory creates a new in-memory cache holding at most size values
+func NewMemory(size int) *Memory {
+	return &Memory{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		tags:    make(map[string]map[string]struct{}),
+		lru:     list.New(),
+	}
+}
+
+// Set stores the value in the cache, evicting the least recently used value when it is full
+func (m *Memory) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.entries[key]; ok {
+		m.remove(elem)
+	}
+
+	entry := &memoryEntry{key: key, value: append([]byte(nil), value...), tags: tags}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+
+	m.entries[key] = m.lru.PushFront(entry)
+	for _, tag := range tags {
+		if m.tags[tag] == nil {
+			m.tags[tag] = make(map[string]struct{})
+		}
+		m.tags[tag][key] = struct{}{}
+	}
+
+	for m.size > 0 && m.lru.Len() > m.size {
+		m.remove(m.lru.Back())
+	}
+
+	return nil
+}
+
+// Get retrieves the value from the cache
+func (m *Memory) Get(ctx context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, ErrMiss
+	}
+
+	entry := elem.Value.(*memoryEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		m.remove(elem)
+		return nil, ErrMiss
+	}
+
+	m.lru.MoveToFront(elem)
+	return entry.value, nil
+}
+
+// Delete removes the value from the cache
+func (m *Memory) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.entries[key]; ok {
+		m.remove(elem)
+	}
+	return nil
+}
+
+// DeleteByPrefix removes the values from the cache with the given prefix,
+// written like the redis patterns the services use ("quotes:*")
+func (m *Memory) DeleteByPrefix(ctx context.Context, prefix string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prefix = strings.TrimSuffix(prefix, "*")
+	for key, elem := range m.entries {
+		if strings.HasPrefix(key, prefix) {
+			m.remove(elem)
+		}
+	}
+	return nil
+}
+
+// DeleteByTag removes every value stored with the given tag
+func (m *Memory) DeleteByTag(ctx context.Context, tag string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key := range m.tags[tag] {
+		m.remove(m.entries[key])
+	}
+	return nil
+}
+
+// Close releases the values held by the cache
+func (m *Memory) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = make(map[string]*list.Element)
+	m.tags = make(map[string]map[string]struct{})
+	m.lru.Init()
+	return nil
+}
+
+// remove drops an element from the LRU and its tags, the caller must hold the lock
+func (m *Memory) remove(elem *list.Element) {
+	entry := elem.Value.(*memoryEntry)
+
+	m.lru.Remove(elem)
+	delete(m.entries, entry.key)
+
+	for _, tag := range entry.tags {
+		delete(m.tags[tag], entry.key)
+		if len(m.tags[tag]) == 0 {
+			delete(m.tags, tag)
+		}
+	}
+}
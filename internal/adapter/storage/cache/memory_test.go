@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemory(2)
+
+	require.NoError(t, cache.Set(ctx, "quote:1", []byte("1"), 0))
+	require.NoError(t, cache.Set(ctx, "quote:2", []byte("2"), 0))
+
+	// Reading quote:1 makes quote:2 the least recently used
+	_, err := cache.Get(ctx, "quote:1")
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Set(ctx, "quote:3", []byte("3"), 0))
+
+	_, err = cache.Get(ctx, "quote:2")
+	assert.ErrorIs(t, err, ErrMiss)
+
+	value, err := cache.Get(ctx, "quote:1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1"), value)
+}
+
+func TestMemoryExpires(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemory(10)
+
+	require.NoError(t, cache.Set(ctx, "quote:1", []byte("1"), time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+
+	_, err := cache.Get(ctx, "quote:1")
+	assert.ErrorIs(t, err, ErrMiss)
+}
+
+func TestMemoryDeleteByPrefix(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemory(10)
+
+	require.NoError(t, cache.Set(ctx, "quotes:1-10", []byte("[]"), 0))
+	require.NoError(t, cache.Set(ctx, "quotes:2-10", []byte("[]"), 0))
+	require.NoError(t, cache.Set(ctx, "quote:1", []byte("1"), 0))
+
+	require.NoError(t, cache.DeleteByPrefix(ctx, "quotes:*"))
+
+	_, err := cache.Get(ctx, "quotes:1-10")
+	assert.ErrorIs(t, err, ErrMiss)
+	_, err = cache.Get(ctx, "quotes:2-10")
+	assert.ErrorIs(t, err, ErrMiss)
+	_, err = cache.Get(ctx, "quote:1")
+	assert.NoError(t, err)
+}
+
+func TestMemoryDeleteByTag(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemory(10)
+
+	require.NoError(t, cache.Set(ctx, "quotes:1-10", []byte("[]"), 0, "quotes"))
+	require.NoError(t, cache.Set(ctx, "quotes:2-10", []byte("[]"), 0, "quotes"))
+	require.NoError(t, cache.Set(ctx, "quotesReport:2024", []byte("{}"), 0))
+
+	require.NoError(t, cache.DeleteByTag(ctx, "quotes"))
+
+	_, err := cache.Get(ctx, "quotes:1-10")
+	assert.ErrorIs(t, err, ErrMiss)
+	_, err = cache.Get(ctx, "quotes:2-10")
+	assert.ErrorIs(t, err, ErrMiss)
+	// Untagged keys are left alone
+	_, err = cache.Get(ctx, "quotesReport:2024")
+	assert.NoError(t, err)
+	assert.Empty(t, cache.tags)
+}
+
+func TestNoop(t *testing.T) {
+	ctx := context.Background()
+	cache := NewNoop()
+
+	require.NoError(t, cache.Set(ctx, "quote:1", []byte("1"), 0))
+
+	_, err := cache.Get(ctx, "quote:1")
+	assert.ErrorIs(t, err, ErrMiss)
+}
@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"context"
+	"expvar"
+	"strings"
+	"sync"
+	"time"
+
+	"harajuku/backend/internal/core/port"
+)
+
+/**
+ * Metrics implements port.CacheRepository interface
+ * and wraps another cache, counting hits, misses, sets and invalidations per key prefix
+ */
+type Metrics struct {
+	cache port.CacheRepository
+	mu    sync.Mutex
+	vars  *expvar.Map
+}
+
+// NewMetrics creates a new instrumented cache publishing its counters in vars,
+// one map per key prefix such as "quotes"
+func NewMetrics(cache port.CacheRepository, vars *expvar.Map) *Metrics {
+	return &Metrics{
+		cache: cache,
+		vars:  vars,
+	}
+}
+
+// Set stores the value in the cache
+func (m *Metrics) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	m.count(key, "sets")
+	return m.cache.Set(ctx, key, value, ttl, tags...)
+}
+
+// Get retrieves the value from the cache, any error counts as a miss since that is how callers treat it
+func (m *Metrics) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := m.cache.Get(ctx, key)
+	if err != nil {
+		m.count(key, "misses")
+	} else {
+		m.count(key, "hits")
+	}
+	return value, err
+}
+
+// Delete removes the value from the cache
+func (m *Metrics) Delete(ctx context.Context, key string) error {
+	m.count(key, "invalidations")
+	return m.cache.Delete(ctx, key)
+}
+
+// DeleteByPrefix removes the values from the cache with the given prefix
+func (m *Metrics) DeleteByPrefix(ctx context.Context, prefix string) error {
+	m.count(prefix, "invalidations")
+	return m.cache.DeleteByPrefix(ctx, prefix)
+}
+
+// DeleteByTag removes every value stored with the given tag, counted under the tag
+// since the services tag their lists with the key prefix
+func (m *Metrics) DeleteByTag(ctx context.Context, tag string) error {
+	m.count(tag, "invalidations")
+	return m.cache.DeleteByTag(ctx, tag)
+}
+
+// Close closes the connection to the cache server
+func (m *Metrics) Close() error {
+	return m.cache.Close()
+}
+
+// count increments the counter of the key prefix
+func (m *Metrics) count(key, counter string) {
+	prefix, _, _ := strings.Cut(key, ":")
+
+	m.mu.Lock()
+	vars, ok := m.vars.Get(prefix).(*expvar.Map)
+	if !ok {
+		vars = new(expvar.Map).Init()
+		m.vars.Set(prefix, vars)
+	}
+	m.mu.Unlock()
+
+	vars.Add(counter, 1)
+}
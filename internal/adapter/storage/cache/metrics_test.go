@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"context"
+	"expvar"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsCountsPerPrefix(t *testing.T) {
+	ctx := context.Background()
+	vars := new(expvar.Map).Init()
+	cache := NewMetrics(NewMemory(10), vars)
+
+	_, err := cache.Get(ctx, "quotes:1-10")
+	assert.ErrorIs(t, err, ErrMiss)
+	require.NoError(t, cache.Set(ctx, "quotes:1-10", []byte("[]"), 0))
+	_, err = cache.Get(ctx, "quotes:1-10")
+	require.NoError(t, err)
+	_, err = cache.Get(ctx, "quotes:1-10")
+	require.NoError(t, err)
+	require.NoError(t, cache.DeleteByPrefix(ctx, "quotes:*"))
+	require.NoError(t, cache.Delete(ctx, "quote:7b0c"))
+
+	quotes := vars.Get("quotes").(*expvar.Map)
+	assert.Equal(t, "2", quotes.Get("hits").String())
+	assert.Equal(t, "1", quotes.Get("misses").String())
+	assert.Equal(t, "1", quotes.Get("sets").String())
+	assert.Equal(t, "1", quotes.Get("invalidations").String())
+
+	quote := vars.Get("quote").(*expvar.Map)
+	assert.Equal(t, "1", quote.Get("invalidations").String())
+	assert.Nil(t, quote.Get("hits"))
+}
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"harajuku/backend/internal/core/port"
+)
+
+/**
+ * Namespace implements port.CacheRepository interface
+ * and wraps another cache, prefixing every key, pattern and tag so environments sharing
+ * a redis server don't read each other's values and a new version starts from an empty cache
+ */
+type Namespace struct {
+	cache  port.CacheRepository
+	prefix string
+}
+
+// NewNamespace creates a new namespaced cache, the prefix is added as is so it should end with ":"
+func NewNamespace(cache port.CacheRepository, prefix string) *Namespace {
+	return &Namespace{
+		cache,
+		prefix,
+	}
+}
+
+// Set stores the value in the cache
+func (n *Namespace) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	namespaced := make([]string, len(tags))
+	for i, tag := range tags {
+		namespaced[i] = n.prefix + tag
+	}
+	return n.cache.Set(ctx, n.prefix+key, value, ttl, namespaced...)
+}
+
+// Get retrieves the value from the cache
+func (n *Namespace) Get(ctx context.Context, key string) ([]byte, error) {
+	return n.cache.Get(ctx, n.prefix+key)
+}
+
+// Delete removes the value from the cache
+func (n *Namespace) Delete(ctx context.Context, key string) error {
+	return n.cache.Delete(ctx, n.prefix+key)
+}
+
+// DeleteByPrefix removes the values from the cache with the given prefix
+func (n *Namespace) DeleteByPrefix(ctx context.Context, prefix string) error {
+	return n.cache.DeleteByPrefix(ctx, n.prefix+prefix)
+}
+
+// DeleteByTag removes every value stored with the given tag
+func (n *Namespace) DeleteByTag(ctx context.Context, tag string) error {
+	return n.cache.DeleteByTag(ctx, n.prefix+tag)
+}
+
+// Close closes the connection to the cache server
+func (n *Namespace) Close() error {
+	return n.cache.Close()
+}
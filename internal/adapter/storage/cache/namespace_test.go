@@ -0,0 +1,35 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespacesDoNotShareValues(t *testing.T) {
+	ctx := context.Background()
+	shared := NewMemory(10)
+	staging := NewNamespace(shared, "harajuku:staging:v1:")
+	production := NewNamespace(shared, "harajuku:production:v1:")
+
+	require.NoError(t, staging.Set(ctx, "quotes:1-10", []byte("staging"), 0, "quotes"))
+	require.NoError(t, production.Set(ctx, "quotes:1-10", []byte("production"), 0, "quotes"))
+
+	value, err := production.Get(ctx, "quotes:1-10")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("production"), value)
+
+	require.NoError(t, staging.DeleteByTag(ctx, "quotes"))
+	require.NoError(t, staging.DeleteByPrefix(ctx, "quotes:*"))
+
+	_, err = staging.Get(ctx, "quotes:1-10")
+	assert.ErrorIs(t, err, ErrMiss)
+	_, err = production.Get(ctx, "quotes:1-10")
+	assert.NoError(t, err)
+
+	// A new version starts from an empty cache
+	_, err = NewNamespace(shared, "harajuku:production:v2:").Get(ctx, "quotes:1-10")
+	assert.ErrorIs(t, err, ErrMiss)
+}
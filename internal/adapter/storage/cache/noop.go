@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+/**
+ * Noop implements port.CacheRepository interface
+ * and caches nothing, every read is a miss so the services always go to the database
+ */
+type Noop struct{}
+
+// NewNoop creates a new cache that caches nothing
+func NewNoop() *Noop {
+	return &Noop{}
+}
+
+// Set discards the value
+func (Noop) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	return nil
+}
+
+// Get always misses
+func (Noop) Get(ctx context.Context, key string) ([]byte, error) {
+	return nil, ErrMiss
+}
+
+// Delete does nothing
+func (Noop) Delete(ctx context.Context, key string) error {
+	return nil
+}
+
+// DeleteByPrefix does nothing
+func (Noop) DeleteByPrefix(ctx context.Context, prefix string) error {
+	return nil
+}
+
+// DeleteByTag does nothing
+func (Noop) DeleteByTag(ctx context.Context, tag string) error {
+	return nil
+}
+
+// Close does nothing
+func (Noop) Close() error {
+	return nil
+}
@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"harajuku/backend/internal/core/port"
+)
+
+/**
+ * Policy implements port.CacheRepository interface
+ * and wraps another cache, giving every value stored without a TTL the one configured for its entity
+ * so nothing is kept forever
+ */
+type Policy struct {
+	cache      port.CacheRepository
+	defaultTTL time.Duration
+	ttls       map[string]time.Duration
+}
+
+// NewPolicy creates a new cache policy over cache, entities without a TTL of their own use defaultTTL
+func NewPolicy(cache port.CacheRepository, defaultTTL time.Duration, ttls map[string]time.Duration) *Policy {
+	return &Policy{
+		cache,
+		defaultTTL,
+		ttls,
+	}
+}
+
+// ParseTTLs parses the TTLs of entities written as "quote:10m,quotes:2m", the entity is the cache key prefix
+func ParseTTLs(s string) (map[string]time.Duration, error) {
+	ttls := make(map[string]time.Duration)
+
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		entity, value, found := strings.Cut(pair, ":")
+		entity = strings.TrimSpace(entity)
+		if !found || entity == "" {
+			return nil, fmt.Errorf("invalid cache TTL %q", pair)
+		}
+
+		ttl, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("invalid cache TTL for %s", entity)
+		}
+
+		ttls[entity] = ttl
+	}
+
+	return ttls, nil
+}
+
+// TTL returns how long the value under key is kept, keys are named "<entity>:<params>"
+func (p *Policy) TTL(key string) time.Duration {
+	entity, _, _ := strings.Cut(key, ":")
+	if ttl, ok := p.ttls[entity]; ok {
+		return ttl
+	}
+	return p.defaultTTL
+}
+
+// Set stores the value for the TTL of its entity, unless the caller asks for a TTL of its own
+func (p *Policy) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	if ttl == 0 {
+		ttl = p.TTL(key)
+	}
+	return p.cache.Set(ctx, key, value, ttl, tags...)
+}
+
+// Get retrieves the value from the cache
+func (p *Policy) Get(ctx context.Context, key string) ([]byte, error) {
+	return p.cache.Get(ctx, key)
+}
+
+// Delete removes the value from the cache
+func (p *Policy) Delete(ctx context.Context, key string) error {
+	return p.cache.Delete(ctx, key)
+}
+
+// DeleteByPrefix removes the values from the cache with the given prefix
+func (p *Policy) DeleteByPrefix(ctx context.Context, prefix string) error {
+	return p.cache.DeleteByPrefix(ctx, prefix)
+}
+
+// DeleteByTag removes every value stored with the given tag
+func (p *Policy) DeleteByTag(ctx context.Context, tag string) error {
+	return p.cache.DeleteByTag(ctx, tag)
+}
+
+// Close closes the connection to the cache server
+func (p *Policy) Close() error {
+	return p.cache.Close()
+}
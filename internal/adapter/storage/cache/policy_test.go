@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ttlRecorder is a cache that only records the TTL values are stored with
+type ttlRecorder struct {
+	ttls map[string]time.Duration
+}
+
+func (c *ttlRecorder) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	c.ttls[key] = ttl
+	return nil
+}
+func (c *ttlRecorder) Get(ctx context.Context, key string) ([]byte, error) { return nil, nil }
+func (c *ttlRecorder) Delete(ctx context.Context, key string) error        { return nil }
+func (c *ttlRecorder) DeleteByPrefix(ctx context.Context, prefix string) error {
+	return nil
+}
+func (c *ttlRecorder) DeleteByTag(ctx context.Context, tag string) error { return nil }
+func (c *ttlRecorder) Close() error                                      { return nil }
+
+func TestPolicySet(t *testing.T) {
+	ttls, err := ParseTTLs("quote:10m, quotes:2m")
+	require.NoError(t, err)
+
+	recorder := &ttlRecorder{ttls: make(map[string]time.Duration)}
+	policy := NewPolicy(recorder, time.Hour, ttls)
+
+	ctx := context.Background()
+	require.NoError(t, policy.Set(ctx, "quote:7b0c", nil, 0))
+	require.NoError(t, policy.Set(ctx, "quotes:1-10", nil, 0))
+	require.NoError(t, policy.Set(ctx, "promotion:3f1e", nil, 0))
+	require.NoError(t, policy.Set(ctx, "users:1-10", nil, 5*time.Minute))
+
+	assert.Equal(t, map[string]time.Duration{
+		"quote:7b0c":     10 * time.Minute,
+		"quotes:1-10":    2 * time.Minute,
+		"promotion:3f1e": time.Hour,
+		"users:1-10":     5 * time.Minute,
+	}, recorder.ttls)
+}
+
+func TestParseTTLsInvalid(t *testing.T) {
+	for _, s := range []string{"quote", "quote:soon", "quote:-1m", ":10m"} {
+		_, err := ParseTTLs(s)
+		assert.Error(t, err, s)
+	}
+}
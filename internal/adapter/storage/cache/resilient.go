@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"harajuku/backend/internal/core/port"
+)
+
+/**
+ * Resilient implements port.CacheRepository interface
+ * and wraps another cache so that writes are best-effort, a cache outage is logged
+ * instead of failing the request that tried to update the cache
+ */
+type Resilient struct {
+	cache port.CacheRepository
+}
+
+// NewResilient creates a new resilient cache over cache
+func NewResilient(cache port.CacheRepository) *Resilient {
+	return &Resilient{
+		cache,
+	}
+}
+
+// Set stores the value in the cache, failures are only logged
+func (r *Resilient) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	if err := r.cache.Set(ctx, key, value, ttl, tags...); err != nil {
+		slog.WarnContext(ctx, "cache set failed", "key", key, "error", err)
+	}
+	return nil
+}
+
+// Get retrieves the value from the cache, callers already treat any error as a cache miss
+func (r *Resilient) Get(ctx context.Context, key string) ([]byte, error) {
+	return r.cache.Get(ctx, key)
+}
+
+// Delete removes the value from the cache, failures are only logged
+func (r *Resilient) Delete(ctx context.Context, key string) error {
+	if err := r.cache.Delete(ctx, key); err != nil {
+		slog.WarnContext(ctx, "cache delete failed", "key", key, "error", err)
+	}
+	return nil
+}
+
+// DeleteByPrefix removes the values from the cache with the given prefix, failures are only logged
+func (r *Resilient) DeleteByPrefix(ctx context.Context, prefix string) error {
+	if err := r.cache.DeleteByPrefix(ctx, prefix); err != nil {
+		slog.WarnContext(ctx, "cache delete by prefix failed", "prefix", prefix, "error", err)
+	}
+	return nil
+}
+
+// DeleteByTag removes every value stored with the given tag, failures are only logged
+func (r *Resilient) DeleteByTag(ctx context.Context, tag string) error {
+	if err := r.cache.DeleteByTag(ctx, tag); err != nil {
+		slog.WarnContext(ctx, "cache delete by tag failed", "tag", tag, "error", err)
+	}
+	return nil
+}
+
+// Close closes the connection to the cache server
+func (r *Resilient) Close() error {
+	return r.cache.Close()
+}
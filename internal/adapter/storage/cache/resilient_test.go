@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errCacheDown = errors.New("dial tcp 127.0.0.1:6379: connect: connection refused")
+
+// downCache is a cache whose server is unreachable
+type downCache struct{}
+
+func (downCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	return errCacheDown
+}
+func (downCache) Get(ctx context.Context, key string) ([]byte, error) { return nil, errCacheDown }
+func (downCache) Delete(ctx context.Context, key string) error        { return errCacheDown }
+func (downCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	return errCacheDown
+}
+func (downCache) DeleteByTag(ctx context.Context, tag string) error { return errCacheDown }
+func (downCache) Close() error                                      { return nil }
+
+func TestResilientCacheDown(t *testing.T) {
+	ctx := context.Background()
+	cache := NewResilient(downCache{})
+
+	assert.NoError(t, cache.Set(ctx, "quote:7b0c", []byte("{}"), 0))
+	assert.NoError(t, cache.Delete(ctx, "quote:7b0c"))
+	assert.NoError(t, cache.DeleteByPrefix(ctx, "quotes:*"))
+	assert.NoError(t, cache.DeleteByTag(ctx, "quotes"))
+
+	// Reads still fail so the caller falls back to the database
+	_, err := cache.Get(ctx, "quote:7b0c")
+	assert.ErrorIs(t, err, errCacheDown)
+}
+
+func TestResilientPolicyCacheDown(t *testing.T) {
+	ctx := context.Background()
+	cache := NewResilient(NewPolicy(downCache{}, time.Hour, nil))
+
+	assert.NoError(t, cache.Set(ctx, "appointments:1-10", []byte("[]"), 0))
+}
@@ -0,0 +1,75 @@
+package cloudfront
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignURL(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	keyFile := filepath.Join(t.TempDir(), "cloudfront.pem")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+
+	signer, err := New(&config.CloudFront{
+		Domain:         "https://files.example.com/",
+		KeyPairID:      "K2JCJMDEHXQW5F",
+		PrivateKeyFile: keyFile,
+		TTL:            "10m",
+	})
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	signer.(*CloudFront).now = func() time.Time { return now }
+
+	signed, err := signer.SignURL("quotes/42/foto de perfil.jpg")
+	require.NoError(t, err)
+
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "files.example.com", u.Host)
+	assert.Equal(t, "/quotes/42/foto%20de%20perfil.jpg", u.EscapedPath())
+
+	query := u.Query()
+	assert.Equal(t, "1700000600", query.Get("Expires"))
+	assert.Equal(t, "K2JCJMDEHXQW5F", query.Get("Key-Pair-Id"))
+
+	// CloudFront verifies the signature against the canned policy rebuilt from the URL
+	policy, err := cannedPolicy("https://files.example.com/quotes/42/foto%20de%20perfil.jpg", 1700000600)
+	require.NoError(t, err)
+
+	decoder := strings.NewReplacer("-", "+", "_", "=", "~", "/")
+	signature, err := base64.StdEncoding.DecodeString(decoder.Replace(query.Get("Signature")))
+	require.NoError(t, err)
+
+	hash := sha1.Sum(policy)
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA1, hash[:], signature))
+}
+
+func TestNewRejectsInvalidKey(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "cloudfront.pem")
+	require.NoError(t, os.WriteFile(keyFile, []byte("not a key"), 0o600))
+
+	_, err := New(&config.CloudFront{Domain: "https://files.example.com", PrivateKeyFile: keyFile, TTL: "10m"})
+	assert.Error(t, err)
+}
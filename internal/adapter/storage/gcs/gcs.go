@@ -0,0 +1,110 @@
+package gcs
+
+import (
+	"context"
+	"errors"
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"io"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+// GCS implements port.FileRepository on a Google Cloud Storage bucket
+type GCS struct {
+	client *storage.Client
+	bucket string
+}
+
+// New creates a Google Cloud Storage client for the configured bucket
+func New(ctx context.Context, cfg *config.GCS) (port.FileRepository, error) {
+	var opts []option.ClientOption
+	if cfg.CredentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(cfg.CredentialsFile))
+	}
+
+	client, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GCS{
+		client: client,
+		bucket: cfg.Bucket,
+	}, nil
+}
+
+// Save streams a file under the given name, files smaller than a chunk go in a single request
+func (g *GCS) Save(ctx context.Context, r io.Reader, size int64, name string) (string, error) {
+	w := g.client.Bucket(g.bucket).Object(name).NewWriter(ctx)
+	if size >= 0 && size < googleapi.DefaultUploadChunkSize {
+		w.ChunkSize = 0
+	}
+
+	_, err := io.Copy(w, r)
+	if err != nil {
+		w.Close()
+		return "", err
+	}
+
+	err = w.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// Get downloads a stored file
+func (g *GCS) Get(ctx context.Context, path string) ([]byte, error) {
+	r, err := g.client.Bucket(g.bucket).Object(path).NewReader(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+	defer r.Close()
+
+	return io.ReadAll(r)
+}
+
+// Delete removes a stored file, deleting a missing file is not an error as with S3
+func (g *GCS) Delete(ctx context.Context, path string) error {
+	err := g.client.Bucket(g.bucket).Object(path).Delete(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// ListFiles implements port.FileLister, iterating over the objects of the bucket
+func (g *GCS) ListFiles(ctx context.Context, fn func(key string, modifiedAt time.Time) error) error {
+	it := g.client.Bucket(g.bucket).Objects(ctx, nil)
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = fn(attrs.Name, attrs.Updated)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Ping implements port.HealthChecker, checking the bucket exists and the credentials can reach it
+func (g *GCS) Ping(ctx context.Context) error {
+	_, err := g.client.Bucket(g.bucket).Attrs(ctx)
+	return err
+}
@@ -0,0 +1,137 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Local implements port.FileRepository on a directory of the local filesystem,
+// files are stored under the same keys the S3 adapter would use
+type Local struct {
+	dir string
+}
+
+// NewLocal creates the storage directory if needed and returns a local file repository
+func NewLocal(dir string) (port.FileRepository, error) {
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Local{
+		dir: dir,
+	}, nil
+}
+
+// path resolves a key inside the storage directory, keys cannot escape it
+func (l *Local) path(name string) string {
+	return filepath.Join(l.dir, filepath.Clean("/"+name))
+}
+
+// Save copies the file through a temporary file so readers never see a partial upload
+func (l *Local) Save(ctx context.Context, r io.Reader, size int64, name string) (string, error) {
+	path := l.path(name)
+
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if size >= 0 && written != size {
+		return "", io.ErrUnexpectedEOF
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// Get reads a stored file
+func (l *Local) Get(ctx context.Context, path string) ([]byte, error) {
+	data, err := os.ReadFile(l.path(path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, domain.ErrDataNotFound
+	}
+
+	return data, err
+}
+
+// Delete removes a stored file, deleting a missing file is not an error as with S3
+func (l *Local) Delete(ctx context.Context, path string) error {
+	err := os.Remove(l.path(path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// ListFiles implements port.FileLister, walking the storage directory. Temporary files of uploads in
+// progress are skipped
+func (l *Local) ListFiles(ctx context.Context, fn func(key string, modifiedAt time.Time) error) error {
+	return filepath.WalkDir(l.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".upload-") {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		info, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		key, err := filepath.Rel(l.dir, path)
+		if err != nil {
+			return err
+		}
+
+		return fn(filepath.ToSlash(key), info.ModTime())
+	})
+}
+
+// Ping implements port.HealthChecker, checking the storage directory is still there
+func (l *Local) Ping(ctx context.Context) error {
+	info, err := os.Stat(l.dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", l.dir)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package local
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveGetDelete(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	storage, err := NewLocal(dir)
+	require.NoError(t, err)
+
+	key, err := storage.Save(ctx, bytes.NewReader([]byte("mechón")), int64(len("mechón")), "quotes/42/photo.jpg")
+	require.NoError(t, err)
+	assert.Equal(t, "quotes/42/photo.jpg", key)
+
+	data, err := storage.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("mechón"), data)
+
+	require.NoError(t, storage.Delete(ctx, key))
+	require.NoError(t, storage.Delete(ctx, key))
+
+	_, err = storage.Get(ctx, key)
+	assert.Equal(t, domain.ErrDataNotFound, err)
+}
+
+func TestKeysStayInsideTheDirectory(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	storage, err := NewLocal(filepath.Join(dir, "uploads"))
+	require.NoError(t, err)
+
+	_, err = storage.Save(ctx, bytes.NewReader([]byte("data")), 4, "../../escaped.txt")
+	require.NoError(t, err)
+
+	_, err = os.Stat(filepath.Join(dir, "escaped.txt"))
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = os.Stat(filepath.Join(dir, "uploads", "escaped.txt"))
+	assert.NoError(t, err)
+}
+
+func TestSaveRejectsTruncatedUploads(t *testing.T) {
+	storage, err := NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = storage.Save(context.Background(), bytes.NewReader([]byte("short")), 10, "truncated.txt")
+	assert.Error(t, err)
+}
+
+func TestListFiles(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	storage, err := NewLocal(dir)
+	require.NoError(t, err)
+
+	for _, key := range []string{"quotes/42/photo.jpg", "proofs/7/receipt.pdf"} {
+		_, err := storage.Save(ctx, bytes.NewReader([]byte("x")), 1, key)
+		require.NoError(t, err)
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "quotes", ".upload-123"), []byte("partial"), 0o644))
+
+	var keys []string
+	err = storage.(*Local).ListFiles(ctx, func(key string, modifiedAt time.Time) error {
+		assert.False(t, modifiedAt.IsZero())
+		keys = append(keys, key)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"quotes/42/photo.jpg", "proofs/7/receipt.pdf"}, keys)
+}
@@ -0,0 +1,225 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+)
+
+// appointmentRow is a stored appointment along with when its reminder was sent
+type appointmentRow struct {
+	domain.Appointment
+	reminderSentAt *time.Time
+}
+
+// AppointmentRepository implements port.AppointmentRepository interface on an in-memory database
+type AppointmentRepository struct {
+	db *DB
+}
+
+// NewAppointmentRepository creates a new in-memory appointment repository instance
+func NewAppointmentRepository(db *DB) *AppointmentRepository {
+	return &AppointmentRepository{
+		db,
+	}
+}
+
+// CreateAppointment stores a new appointment at its first version, a quote has a single appointment
+// that is not deleted
+func (r *AppointmentRepository) CreateAppointment(ctx context.Context, appointment *domain.Appointment) (*domain.Appointment, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	for _, row := range r.db.appointments {
+		if row.QuoteID == appointment.QuoteID && row.DeletedAt == nil {
+			return nil, domain.ErrConflictingData
+		}
+	}
+
+	if appointment.ID == uuid.Nil {
+		appointment.ID = uuid.New()
+	}
+	appointment.Version = 1
+	appointment.SalonID = util.SalonOr(ctx, appointment.SalonID)
+
+	r.db.appointments[appointment.ID] = appointmentRow{Appointment: *appointment}
+	r.db.insert(appointment.ID)
+
+	return appointment, nil
+}
+
+// GetAppointmentByID gets an appointment by ID, soft-deleted appointments are not found
+func (r *AppointmentRepository) GetAppointmentByID(ctx context.Context, id uuid.UUID) (*domain.Appointment, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	row, ok := r.db.appointments[id]
+	if !ok || row.DeletedAt != nil || !util.InSalon(ctx, &row.Appointment) {
+		return nil, domain.ErrDataNotFound
+	}
+
+	return &row.Appointment, nil
+}
+
+// ListAppointments returns a page of appointments ordered by the start of their slot and the cursor of
+// the next one
+func (r *AppointmentRepository) ListAppointments(ctx context.Context, filter port.AppointmentFilter) ([]domain.Appointment, *domain.Cursor, error) {
+	rows := r.list(ctx, filter)
+	cursor := func(row slotAppointment) domain.Cursor {
+		return domain.Cursor{Time: row.startTime, ID: row.ID}
+	}
+
+	rows = paginate(rows, filter.After, filter.Skip, filter.Limit, cursor)
+
+	var appointments []domain.Appointment
+	for _, row := range rows {
+		appointments = append(appointments, row.Appointment)
+	}
+
+	return appointments, nextCursor(rows, filter.Limit, cursor), nil
+}
+
+// CountAppointments counts the appointments matching the filter, ignoring pagination
+func (r *AppointmentRepository) CountAppointments(ctx context.Context, filter port.AppointmentFilter) (uint64, error) {
+	return uint64(len(r.list(ctx, filter))), nil
+}
+
+// slotAppointment is an appointment joined with the start of its slot
+type slotAppointment struct {
+	domain.Appointment
+	startTime time.Time
+}
+
+// list returns the appointments of the salon of ctx matching the filter ordered by the start of their
+// slot, appointments without a slot are left out as with the join of postgres
+func (r *AppointmentRepository) list(ctx context.Context, filter port.AppointmentFilter) []slotAppointment {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	var rows []slotAppointment
+	for _, row := range r.db.appointments {
+		slot, ok := r.db.slots[row.SlotID]
+		if !ok {
+			continue
+		}
+		if row.DeletedAt != nil && !filter.IncludeDeleted {
+			continue
+		}
+		if !util.InSalon(ctx, &row.Appointment) {
+			continue
+		}
+		if filter.CustomerID != nil && row.UserID != *filter.CustomerID {
+			continue
+		}
+		if filter.QuoteID != nil && row.QuoteID != *filter.QuoteID {
+			continue
+		}
+		if filter.SlotID != nil && row.SlotID != *filter.SlotID {
+			continue
+		}
+		if filter.ByState != nil && row.Status != *filter.ByState {
+			continue
+		}
+		if !inRange(slot.StartTime, filter.StartDate, filter.EndDate) {
+			continue
+		}
+		rows = append(rows, slotAppointment{row.Appointment, slot.StartTime})
+	}
+
+	byCursor(rows, func(row slotAppointment) domain.Cursor {
+		return domain.Cursor{Time: row.startTime, ID: row.ID}
+	})
+
+	return rows
+}
+
+// UpdateAppointment updates the non-empty fields of an appointment. An appointment changed since it
+// was read is a conflict
+func (r *AppointmentRepository) UpdateAppointment(ctx context.Context, appointment *domain.Appointment) (*domain.Appointment, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	row, ok := r.db.appointments[appointment.ID]
+	if !ok || row.Version != appointment.Version || !util.InSalon(ctx, &row.Appointment) {
+		return nil, domain.ErrConflictingData
+	}
+
+	setIfNotZero(&row.UserID, appointment.UserID)
+	setIfNotZero(&row.SlotID, appointment.SlotID)
+	setIfNotZero(&row.QuoteID, appointment.QuoteID)
+	setIfNotZero(&row.Status, appointment.Status)
+	row.Version++
+
+	r.db.appointments[appointment.ID] = row
+	*appointment = row.Appointment
+
+	return appointment, nil
+}
+
+// DeleteAppointment soft deletes an appointment by ID
+func (r *AppointmentRepository) DeleteAppointment(ctx context.Context, id uuid.UUID) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	row, ok := r.db.appointments[id]
+	if !ok || !util.InSalon(ctx, &row.Appointment) || !softDeleted(&row.DeletedAt) {
+		return domain.ErrDataNotFound
+	}
+
+	r.db.appointments[id] = row
+
+	return nil
+}
+
+// ListDueReminders returns the booked appointments starting between now and until whose reminder
+// was not sent, ordered by the start of their slot
+func (r *AppointmentRepository) ListDueReminders(ctx context.Context, until time.Time) ([]domain.Appointment, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	now := time.Now()
+
+	var rows []slotAppointment
+	for _, row := range r.db.appointments {
+		slot, ok := r.db.slots[row.SlotID]
+		if !ok || row.Status != domain.Booked || row.reminderSentAt != nil || row.DeletedAt != nil {
+			continue
+		}
+		if !slot.StartTime.After(now) || slot.StartTime.After(until) {
+			continue
+		}
+		rows = append(rows, slotAppointment{row.Appointment, slot.StartTime})
+	}
+
+	byCursor(rows, func(row slotAppointment) domain.Cursor {
+		return domain.Cursor{Time: row.startTime, ID: row.ID}
+	})
+
+	var appointments []domain.Appointment
+	for _, row := range rows {
+		appointments = append(appointments, row.Appointment)
+	}
+
+	return appointments, nil
+}
+
+// MarkReminderSent records that the reminder of the appointment was sent
+func (r *AppointmentRepository) MarkReminderSent(ctx context.Context, id uuid.UUID, at time.Time) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	row, ok := r.db.appointments[id]
+	if !ok {
+		return nil
+	}
+
+	row.reminderSentAt = &at
+	r.db.appointments[id] = row
+
+	return nil
+}
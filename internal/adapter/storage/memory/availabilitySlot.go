@@ -0,0 +1,172 @@
+package memory
+
+import (
+	"context"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+)
+
+// AvailabilitySlotRepository implements port.AvailabilitySlotRepository interface on an in-memory database
+type AvailabilitySlotRepository struct {
+	db *DB
+}
+
+// NewAvailabilitySlotRepository creates a new in-memory availability slot repository instance
+func NewAvailabilitySlotRepository(db *DB) *AvailabilitySlotRepository {
+	return &AvailabilitySlotRepository{
+		db,
+	}
+}
+
+// CreateAvailabilitySlot stores a new slot at its first version
+func (r *AvailabilitySlotRepository) CreateAvailabilitySlot(ctx context.Context, slot *domain.AvailabilitySlot) (*domain.AvailabilitySlot, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if slot.ID == uuid.Nil {
+		slot.ID = uuid.New()
+	}
+	slot.Version = 1
+	slot.SalonID = util.SalonOr(ctx, slot.SalonID)
+
+	r.db.slots[slot.ID] = *slot
+	r.db.insert(slot.ID)
+
+	return slot, nil
+}
+
+// GetAvailabilitySlotByID gets a slot by ID, soft-deleted slots are not found
+func (r *AvailabilitySlotRepository) GetAvailabilitySlotByID(ctx context.Context, id uuid.UUID) (*domain.AvailabilitySlot, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	slot, ok := r.db.slots[id]
+	if !ok || slot.DeletedAt != nil || !util.InSalon(ctx, &slot) {
+		return nil, domain.ErrDataNotFound
+	}
+
+	return &slot, nil
+}
+
+// slotCursor is the position of a slot in a listing ordered by start time
+func slotCursor(s domain.AvailabilitySlot) domain.Cursor {
+	return domain.Cursor{Time: s.StartTime, ID: s.ID}
+}
+
+// ListAvailabilitySlots returns a page of slots ordered by start time and the cursor of the next one
+func (r *AvailabilitySlotRepository) ListAvailabilitySlots(ctx context.Context, filter port.AvailabilitySlotFilter) ([]domain.AvailabilitySlot, *domain.Cursor, error) {
+	slots := paginate(r.list(ctx, filter), filter.After, filter.Skip, filter.Limit, slotCursor)
+
+	return slots, nextCursor(slots, filter.Limit, slotCursor), nil
+}
+
+// CountAvailabilitySlots counts the slots matching the filter, ignoring pagination
+func (r *AvailabilitySlotRepository) CountAvailabilitySlots(ctx context.Context, filter port.AvailabilitySlotFilter) (uint64, error) {
+	return uint64(len(r.list(ctx, filter))), nil
+}
+
+// list returns the slots of the salon of ctx matching the filter ordered by start time. The state of a slot comes from its
+// appointments as in the postgres repository: free slots are not booked and have no appointment but
+// pending ones, booked slots have a booked or completed appointment
+func (r *AvailabilitySlotRepository) list(ctx context.Context, filter port.AvailabilitySlotFilter) []domain.AvailabilitySlot {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	var slots []domain.AvailabilitySlot
+	for _, slot := range r.db.slots {
+		if slot.DeletedAt != nil && !filter.IncludeDeleted {
+			continue
+		}
+		if !util.InSalon(ctx, &slot) {
+			continue
+		}
+		if filter.UserID != nil && slot.AdminID != *filter.UserID {
+			continue
+		}
+		if !inRange(slot.StartTime, filter.StartDate, filter.EndDate) {
+			continue
+		}
+		if filter.ByState != nil && !r.inState(slot, *filter.ByState) {
+			continue
+		}
+		slots = append(slots, slot)
+	}
+
+	byCursor(slots, slotCursor)
+
+	return slots
+}
+
+// inState reports whether the slot is in state, the caller holds the lock
+func (r *AvailabilitySlotRepository) inState(slot domain.AvailabilitySlot, state port.SlotState) bool {
+	var appointments []domain.AppointmentStatus
+	for _, row := range r.db.appointments {
+		if row.SlotID == slot.ID && row.DeletedAt == nil {
+			appointments = append(appointments, row.Status)
+		}
+	}
+
+	switch state {
+	case port.SlotStateFree:
+		if slot.IsBooked {
+			return false
+		}
+		for _, status := range appointments {
+			if status != domain.Pending {
+				return false
+			}
+		}
+		return true
+	case port.SlotStateBooked:
+		for _, status := range appointments {
+			if status == domain.Booked || status == domain.Completed {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// UpdateAvailabilitySlot updates the non-empty fields of a slot, whether it is booked is always written.
+// A slot changed since it was read is a conflict
+func (r *AvailabilitySlotRepository) UpdateAvailabilitySlot(ctx context.Context, slot *domain.AvailabilitySlot) (*domain.AvailabilitySlot, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	stored, ok := r.db.slots[slot.ID]
+	if !ok || stored.Version != slot.Version || !util.InSalon(ctx, &stored) {
+		return nil, domain.ErrConflictingData
+	}
+
+	setIfNotZero(&stored.AdminID, slot.AdminID)
+	setIfNotZero(&stored.StartTime, slot.StartTime)
+	setIfNotZero(&stored.EndTime, slot.EndTime)
+	stored.IsBooked = slot.IsBooked
+	stored.Version++
+
+	r.db.slots[slot.ID] = stored
+	*slot = stored
+
+	return slot, nil
+}
+
+// DeleteAvailabilitySlot soft deletes a slot by ID
+func (r *AvailabilitySlotRepository) DeleteAvailabilitySlot(ctx context.Context, id uuid.UUID) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	slot, ok := r.db.slots[id]
+	if !ok || !util.InSalon(ctx, &slot) || !softDeleted(&slot.DeletedAt) {
+		return domain.ErrDataNotFound
+	}
+
+	r.db.slots[id] = slot
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAvailabilitySlotsByState(t *testing.T) {
+	ctx := context.Background()
+	db := New()
+	slots := NewAvailabilitySlotRepository(db)
+	appointments := NewAppointmentRepository(db)
+	start := time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC)
+
+	slot := func(hours int) *domain.AvailabilitySlot {
+		created, err := slots.CreateAvailabilitySlot(ctx, &domain.AvailabilitySlot{
+			AdminID:   uuid.New(),
+			StartTime: start.Add(time.Duration(hours) * time.Hour),
+			EndTime:   start.Add(time.Duration(hours+1) * time.Hour),
+		})
+		require.NoError(t, err)
+		return created
+	}
+	book := func(slot *domain.AvailabilitySlot, status domain.AppointmentStatus) {
+		_, err := appointments.CreateAppointment(ctx, &domain.Appointment{UserID: uuid.New(), SlotID: slot.ID, QuoteID: uuid.New(), Status: status})
+		require.NoError(t, err)
+	}
+
+	free := slot(0)
+	requested := slot(1)
+	book(requested, domain.Pending)
+	booked := slot(2)
+	book(booked, domain.Booked)
+
+	state := port.SlotStateFree
+	listed, _, err := slots.ListAvailabilitySlots(ctx, port.AvailabilitySlotFilter{ByState: &state})
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, free.ID, listed[0].ID)
+	assert.Equal(t, requested.ID, listed[1].ID)
+
+	state = port.SlotStateBooked
+	listed, _, err = slots.ListAvailabilitySlots(ctx, port.AvailabilitySlotFilter{ByState: &state})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, booked.ID, listed[0].ID)
+}
+
+func TestAppointmentsAreListedBySlotStart(t *testing.T) {
+	ctx := context.Background()
+	db := New()
+	slots := NewAvailabilitySlotRepository(db)
+	appointments := NewAppointmentRepository(db)
+	start := time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC)
+
+	late, err := slots.CreateAvailabilitySlot(ctx, &domain.AvailabilitySlot{AdminID: uuid.New(), StartTime: start.Add(time.Hour), EndTime: start.Add(2 * time.Hour)})
+	require.NoError(t, err)
+	early, err := slots.CreateAvailabilitySlot(ctx, &domain.AvailabilitySlot{AdminID: uuid.New(), StartTime: start, EndTime: start.Add(time.Hour)})
+	require.NoError(t, err)
+
+	quoteID := uuid.New()
+	_, err = appointments.CreateAppointment(ctx, &domain.Appointment{UserID: uuid.New(), SlotID: late.ID, QuoteID: quoteID, Status: domain.Pending})
+	require.NoError(t, err)
+	_, err = appointments.CreateAppointment(ctx, &domain.Appointment{UserID: uuid.New(), SlotID: early.ID, QuoteID: uuid.New(), Status: domain.Pending})
+	require.NoError(t, err)
+
+	// A quote has a single appointment
+	_, err = appointments.CreateAppointment(ctx, &domain.Appointment{UserID: uuid.New(), SlotID: early.ID, QuoteID: quoteID, Status: domain.Pending})
+	assert.Equal(t, domain.ErrConflictingData, err)
+
+	listed, next, err := appointments.ListAppointments(ctx, port.AppointmentFilter{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, early.ID, listed[0].SlotID)
+	require.NotNil(t, next)
+	assert.Equal(t, start, next.Time)
+
+	listed, _, err = appointments.ListAppointments(ctx, port.AppointmentFilter{After: next, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, late.ID, listed[0].SlotID)
+}
@@ -0,0 +1,199 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"sort"
+	"sync"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+)
+
+/**
+ * DB keeps the rows of the in-memory repositories in maps, the repositories created on the same DB
+ * see each other's rows the way the postgres repositories share a database
+ */
+type DB struct {
+	mu            sync.RWMutex
+	users         map[uuid.UUID]domain.User
+	quotes        map[uuid.UUID]domain.Quote
+	quoteImages   map[uuid.UUID]domain.QuoteImage
+	slots         map[uuid.UUID]domain.AvailabilitySlot
+	appointments  map[uuid.UUID]appointmentRow
+	paymentProofs map[uuid.UUID]domain.PaymentProof
+	// seq numbers the rows in insertion order, for the listings postgres leaves unordered
+	seq  uint64
+	rows map[uuid.UUID]uint64
+}
+
+// New creates an empty in-memory database
+func New() *DB {
+	return &DB{
+		users:         make(map[uuid.UUID]domain.User),
+		quotes:        make(map[uuid.UUID]domain.Quote),
+		quoteImages:   make(map[uuid.UUID]domain.QuoteImage),
+		slots:         make(map[uuid.UUID]domain.AvailabilitySlot),
+		appointments:  make(map[uuid.UUID]appointmentRow),
+		paymentProofs: make(map[uuid.UUID]domain.PaymentProof),
+		rows:          make(map[uuid.UUID]uint64),
+	}
+}
+
+// withTx runs fn and restores the rows it found when fn fails, so a failed transaction leaves nothing behind.
+// Transactions are not isolated, the rows other callers write while fn runs are lost with a rollback
+func (db *DB) withTx(fn func() error) error {
+	db.mu.RLock()
+	snapshot := db.clone()
+	db.mu.RUnlock()
+
+	err := fn()
+	if err != nil {
+		db.mu.Lock()
+		db.restore(snapshot)
+		db.mu.Unlock()
+	}
+
+	return err
+}
+
+// clone copies the rows, the caller holds the lock
+func (db *DB) clone() *DB {
+	return &DB{
+		users:         maps.Clone(db.users),
+		quotes:        maps.Clone(db.quotes),
+		quoteImages:   maps.Clone(db.quoteImages),
+		slots:         maps.Clone(db.slots),
+		appointments:  maps.Clone(db.appointments),
+		paymentProofs: maps.Clone(db.paymentProofs),
+		seq:           db.seq,
+		rows:          maps.Clone(db.rows),
+	}
+}
+
+// restore puts back the rows of a clone, the caller holds the lock
+func (db *DB) restore(snapshot *DB) {
+	db.users = snapshot.users
+	db.quotes = snapshot.quotes
+	db.quoteImages = snapshot.quoteImages
+	db.slots = snapshot.slots
+	db.appointments = snapshot.appointments
+	db.paymentProofs = snapshot.paymentProofs
+	db.seq = snapshot.seq
+	db.rows = snapshot.rows
+}
+
+// insert records the insertion order of the row with id, the caller holds the lock
+func (db *DB) insert(id uuid.UUID) {
+	db.seq++
+	db.rows[id] = db.seq
+}
+
+// byInsertion sorts rows in the order they were inserted
+func byInsertion[T any](db *DB, rows []T, id func(T) uuid.UUID) {
+	sort.Slice(rows, func(i, j int) bool {
+		return db.rows[id(rows[i])] < db.rows[id(rows[j])]
+	})
+}
+
+// byCursor sorts rows by their time with the id breaking ties, as the keyset listings of postgres do
+func byCursor[T any](rows []T, key func(T) domain.Cursor) {
+	sort.Slice(rows, func(i, j int) bool {
+		return cursorLess(key(rows[i]), key(rows[j]))
+	})
+}
+
+// cursorLess reports whether a comes before b in a keyset listing
+func cursorLess(a, b domain.Cursor) bool {
+	if !a.Time.Equal(b.Time) {
+		return a.Time.Before(b.Time)
+	}
+	return a.ID.String() < b.ID.String()
+}
+
+// paginate returns the page of sorted rows given by a cursor or a page number, the cursor wins as with
+// postgres. A zero limit returns every row
+func paginate[T any](rows []T, after *domain.Cursor, skip, limit uint64, key func(T) domain.Cursor) []T {
+	if after != nil {
+		start := sort.Search(len(rows), func(i int) bool {
+			return cursorLess(*after, key(rows[i]))
+		})
+		rows = rows[start:]
+	} else {
+		rows = page(rows, skip, limit)
+	}
+
+	if limit > 0 && uint64(len(rows)) > limit {
+		rows = rows[:limit]
+	}
+
+	return rows
+}
+
+// page returns the rows of page skip, pages are numbered from 1 as in the postgres repositories
+func page[T any](rows []T, skip, limit uint64) []T {
+	if limit == 0 {
+		return rows
+	}
+
+	offset := uint64(0)
+	if skip > 1 {
+		offset = (skip - 1) * limit
+	}
+	if offset >= uint64(len(rows)) {
+		return nil
+	}
+
+	end := offset + limit
+	if end > uint64(len(rows)) {
+		end = uint64(len(rows))
+	}
+
+	return rows[offset:end]
+}
+
+// nextCursor returns the cursor after the last row when the rows fill a page, nil when there is no page after them
+func nextCursor[T any](rows []T, limit uint64, key func(T) domain.Cursor) *domain.Cursor {
+	if limit == 0 || len(rows) == 0 || uint64(len(rows)) < limit {
+		return nil
+	}
+
+	next := key(rows[len(rows)-1])
+	return &next
+}
+
+// inRange reports whether t is within the optional bounds, both included
+func inRange(t time.Time, start, end *time.Time) bool {
+	if start != nil && t.Before(*start) {
+		return false
+	}
+	if end != nil && t.After(*end) {
+		return false
+	}
+	return true
+}
+
+// quoteInSalon reports whether the rows hanging from the quote can be seen from ctx, like the images and
+// payment proofs of quotes in its salon. The caller holds the lock
+func (db *DB) quoteInSalon(ctx context.Context, quoteID uuid.UUID) bool {
+	if _, ok := util.Salon(ctx); !ok {
+		return true
+	}
+
+	quote, ok := db.quotes[quoteID]
+	return ok && util.InSalon(ctx, &quote)
+}
+
+// softDeleted marks the row as deleted now, returning false when it is already deleted
+func softDeleted(deletedAt **time.Time) bool {
+	if *deletedAt != nil {
+		return false
+	}
+
+	now := time.Now()
+	*deletedAt = &now
+	return true
+}
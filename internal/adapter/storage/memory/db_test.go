@@ -0,0 +1,153 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The repositories can stand in for the postgres ones
+var (
+	_ port.UserRepository             = (*UserRepository)(nil)
+	_ port.QuoteRepository            = (*QuoteRepository)(nil)
+	_ port.QuoteImageRepository       = (*QuoteImageRepository)(nil)
+	_ port.AvailabilitySlotRepository = (*AvailabilitySlotRepository)(nil)
+	_ port.AppointmentRepository      = (*AppointmentRepository)(nil)
+	_ port.PaymentProofRepository     = (*PaymentProofRepository)(nil)
+	_ port.FileRepository             = (*FileRepository)(nil)
+	_ port.FileLister                 = (*FileRepository)(nil)
+)
+
+func TestListQuotesByPageAndCursor(t *testing.T) {
+	ctx := context.Background()
+	quotes := NewQuoteRepository(New())
+	clientID := uuid.New()
+	start := time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC)
+
+	// Inserted out of order, listed by time
+	for _, hours := range []int{3, 1, 4, 0, 2} {
+		_, err := quotes.CreateQuote(ctx, &domain.Quote{ClientID: clientID, Time: start.Add(time.Duration(hours) * time.Hour), State: domain.QuotePending})
+		require.NoError(t, err)
+	}
+	_, err := quotes.CreateQuote(ctx, &domain.Quote{ClientID: uuid.New(), Time: start, State: domain.QuotePending})
+	require.NoError(t, err)
+
+	filter := port.QuoteFilter{ClientID: &clientID, Skip: 2, Limit: 2}
+	page, next, err := quotes.ListQuotes(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, start.Add(2*time.Hour), page[0].Time)
+	assert.Equal(t, start.Add(3*time.Hour), page[1].Time)
+	require.NotNil(t, next)
+
+	page, next, err = quotes.ListQuotes(ctx, port.QuoteFilter{ClientID: &clientID, After: next, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, start.Add(4*time.Hour), page[0].Time)
+	assert.Nil(t, next)
+
+	total, err := quotes.CountQuotes(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), total)
+}
+
+func TestUpdateQuoteLocksTheVersion(t *testing.T) {
+	ctx := context.Background()
+	quotes := NewQuoteRepository(New())
+
+	quote, err := quotes.CreateQuote(ctx, &domain.Quote{ClientID: uuid.New(), Time: time.Now(), State: domain.QuotePending, Price: 450})
+	require.NoError(t, err)
+	assert.Equal(t, 1, quote.Version)
+
+	stale := *quote
+	updated, err := quotes.UpdateQuote(ctx, &domain.Quote{ID: quote.ID, State: domain.QuoteApproved, Version: quote.Version})
+	require.NoError(t, err)
+	assert.Equal(t, domain.QuoteApproved, updated.State)
+	assert.Equal(t, 450.0, updated.Price)
+	assert.Equal(t, 2, updated.Version)
+
+	_, err = quotes.UpdateQuote(ctx, &stale)
+	assert.Equal(t, domain.ErrConflictingData, err)
+}
+
+func TestDeleteQuoteIsSoft(t *testing.T) {
+	ctx := context.Background()
+	quotes := NewQuoteRepository(New())
+
+	quote, err := quotes.CreateQuote(ctx, &domain.Quote{ClientID: uuid.New(), Time: time.Now(), State: domain.QuotePending})
+	require.NoError(t, err)
+
+	require.NoError(t, quotes.DeleteQuote(ctx, quote.ID))
+	assert.Equal(t, domain.ErrDataNotFound, quotes.DeleteQuote(ctx, quote.ID))
+
+	_, err = quotes.GetQuoteByID(ctx, quote.ID)
+	assert.Equal(t, domain.ErrDataNotFound, err)
+
+	listed, _, err := quotes.ListQuotes(ctx, port.QuoteFilter{IncludeDeleted: true})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.NotNil(t, listed[0].DeletedAt)
+}
+
+func TestQuotesAreScopedToTheirSalon(t *testing.T) {
+	db := New()
+	quotes := NewQuoteRepository(db)
+	proofs := NewPaymentProofRepository(db)
+	roma := util.WithSalon(context.Background(), uuid.New())
+	condesa := util.WithSalon(context.Background(), uuid.New())
+
+	quote, err := quotes.CreateQuote(roma, &domain.Quote{ClientID: uuid.New(), Time: time.Now(), State: domain.QuotePending})
+	require.NoError(t, err)
+	proof, err := proofs.CreatePaymentProof(roma, &domain.PaymentProof{QuoteID: quote.ID})
+	require.NoError(t, err)
+
+	// Created in the salon of the context
+	salon, _ := util.Salon(roma)
+	assert.Equal(t, salon, quote.SalonID)
+
+	// Out of reach from another salon, along with its payment proof
+	_, err = quotes.GetQuoteByID(condesa, quote.ID)
+	assert.Equal(t, domain.ErrDataNotFound, err)
+	_, err = quotes.UpdateQuote(condesa, &domain.Quote{ID: quote.ID, State: domain.QuoteApproved, Version: quote.Version})
+	assert.Equal(t, domain.ErrConflictingData, err)
+	assert.Equal(t, domain.ErrDataNotFound, quotes.DeleteQuote(condesa, quote.ID))
+	_, err = proofs.GetPaymentProofByID(condesa, proof.ID)
+	assert.Equal(t, domain.ErrDataNotFound, err)
+
+	listed, _, err := quotes.ListQuotes(condesa, port.QuoteFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+
+	// Outside of a salon every quote is reached
+	listed, _, err = quotes.ListQuotes(context.Background(), port.QuoteFilter{})
+	require.NoError(t, err)
+	assert.Len(t, listed, 1)
+
+	_, err = quotes.GetQuoteByID(roma, quote.ID)
+	assert.NoError(t, err)
+}
+
+func TestWithTxRollsBack(t *testing.T) {
+	ctx := context.Background()
+	quotes := NewQuoteRepository(New())
+
+	err := quotes.WithTx(ctx, func(repo port.QuoteRepository) error {
+		_, err := repo.CreateQuote(ctx, &domain.Quote{ClientID: uuid.New(), Time: time.Now(), State: domain.QuotePending})
+		require.NoError(t, err)
+		return errors.New("upload failed")
+	})
+	require.Error(t, err)
+
+	total, err := quotes.CountQuotes(ctx, port.QuoteFilter{})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+}
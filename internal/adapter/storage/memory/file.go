@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+)
+
+// storedFile is the content of a file and when it was written
+type storedFile struct {
+	data       []byte
+	modifiedAt time.Time
+}
+
+/**
+ * FileRepository implements port.FileRepository interface
+ * and keeps the files in memory, they are lost when the process exits
+ */
+type FileRepository struct {
+	mu    sync.RWMutex
+	files map[string]storedFile
+}
+
+// NewFileRepository creates an empty in-memory file storage
+func NewFileRepository() *FileRepository {
+	return &FileRepository{
+		files: make(map[string]storedFile),
+	}
+}
+
+// Save reads the whole file before storing it, so readers never see a partial upload
+func (f *FileRepository) Save(ctx context.Context, r io.Reader, size int64, name string) (string, error) {
+	var buf bytes.Buffer
+
+	written, err := io.Copy(&buf, r)
+	if err != nil {
+		return "", err
+	}
+	if size >= 0 && written != size {
+		return "", io.ErrUnexpectedEOF
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.files[name] = storedFile{data: buf.Bytes(), modifiedAt: time.Now()}
+
+	return name, nil
+}
+
+// Get returns a copy of a stored file
+func (f *FileRepository) Get(ctx context.Context, path string) ([]byte, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	file, ok := f.files[path]
+	if !ok {
+		return nil, domain.ErrDataNotFound
+	}
+
+	return bytes.Clone(file.data), nil
+}
+
+// Delete removes a stored file, deleting a missing file is not an error as with S3
+func (f *FileRepository) Delete(ctx context.Context, path string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.files, path)
+
+	return nil
+}
+
+// ListFiles implements port.FileLister, calling fn with the files ordered by key
+func (f *FileRepository) ListFiles(ctx context.Context, fn func(key string, modifiedAt time.Time) error) error {
+	f.mu.RLock()
+	keys := make([]string, 0, len(f.files))
+	modified := make(map[string]time.Time, len(f.files))
+	for key, file := range f.files {
+		keys = append(keys, key)
+		modified[key] = file.modifiedAt
+	}
+	f.mu.RUnlock()
+
+	sort.Strings(keys)
+
+	// The lock is released so fn can delete the files it is called with
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(key, modified[key]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSaveGetDelete(t *testing.T) {
+	ctx := context.Background()
+	storage := NewFileRepository()
+
+	key, err := storage.Save(ctx, bytes.NewReader([]byte("mechón")), int64(len("mechón")), "quotes/42/photo.jpg")
+	require.NoError(t, err)
+	assert.Equal(t, "quotes/42/photo.jpg", key)
+
+	data, err := storage.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("mechón"), data)
+
+	var keys []string
+	require.NoError(t, storage.ListFiles(ctx, func(key string, modifiedAt time.Time) error {
+		keys = append(keys, key)
+		return storage.Delete(ctx, key)
+	}))
+	assert.Equal(t, []string{"quotes/42/photo.jpg"}, keys)
+
+	_, err = storage.Get(ctx, key)
+	assert.Equal(t, domain.ErrDataNotFound, err)
+	require.NoError(t, storage.Delete(ctx, key))
+}
+
+func TestFileSaveRejectsTruncatedUploads(t *testing.T) {
+	storage := NewFileRepository()
+
+	_, err := storage.Save(context.Background(), bytes.NewReader([]byte("mech")), 10, "quotes/42/photo.jpg")
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	_, err = storage.Get(context.Background(), "quotes/42/photo.jpg")
+	assert.Equal(t, domain.ErrDataNotFound, err)
+}
@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"context"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+// PaymentProofRepository implements port.PaymentProofRepository interface on an in-memory database
+type PaymentProofRepository struct {
+	db *DB
+}
+
+// NewPaymentProofRepository creates a new in-memory payment proof repository instance
+func NewPaymentProofRepository(db *DB) *PaymentProofRepository {
+	return &PaymentProofRepository{
+		db,
+	}
+}
+
+// CreatePaymentProof stores a new payment proof
+func (r *PaymentProofRepository) CreatePaymentProof(ctx context.Context, proof *domain.PaymentProof) (*domain.PaymentProof, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if proof.ID == uuid.Nil {
+		proof.ID = uuid.New()
+	}
+
+	r.db.paymentProofs[proof.ID] = *proof
+	r.db.insert(proof.ID)
+
+	return proof, nil
+}
+
+// UpdatePaymentProof updates only whether a payment proof is reviewed
+func (r *PaymentProofRepository) UpdatePaymentProof(ctx context.Context, proof *domain.PaymentProof) (*domain.PaymentProof, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	stored, ok := r.db.paymentProofs[proof.ID]
+	if !ok || !r.db.quoteInSalon(ctx, stored.QuoteID) {
+		return nil, domain.ErrDataNotFound
+	}
+
+	stored.IsReviewed = proof.IsReviewed
+
+	r.db.paymentProofs[proof.ID] = stored
+	*proof = stored
+
+	return proof, nil
+}
+
+// DeletePaymentProof deletes a payment proof by ID
+func (r *PaymentProofRepository) DeletePaymentProof(ctx context.Context, id uuid.UUID) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if proof, ok := r.db.paymentProofs[id]; ok && r.db.quoteInSalon(ctx, proof.QuoteID) {
+		delete(r.db.paymentProofs, id)
+	}
+
+	return nil
+}
+
+// GetPaymentProofByID gets a payment proof by ID
+func (r *PaymentProofRepository) GetPaymentProofByID(ctx context.Context, id uuid.UUID) (*domain.PaymentProof, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	proof, ok := r.db.paymentProofs[id]
+	if !ok || !r.db.quoteInSalon(ctx, proof.QuoteID) {
+		return nil, domain.ErrDataNotFound
+	}
+
+	return &proof, nil
+}
+
+// GetPaymentProofByQuoteID gets the payment proof of a quote, nil without an error when it has none yet
+func (r *PaymentProofRepository) GetPaymentProofByQuoteID(ctx context.Context, quoteID uuid.UUID) (*domain.PaymentProof, error) {
+	proofs := r.list(ctx, port.PaymentProofFilter{QuoteID: &quoteID})
+	if len(proofs) == 0 {
+		return nil, nil
+	}
+
+	return &proofs[0], nil
+}
+
+// GetPaymentProofs returns a page of payment proofs in the order they were uploaded, with optional filters
+func (r *PaymentProofRepository) GetPaymentProofs(ctx context.Context, filter port.PaymentProofFilter) ([]domain.PaymentProof, error) {
+	return page(r.list(ctx, filter), filter.Skip, filter.Limit), nil
+}
+
+// CountPaymentProofs counts the payment proofs matching the filter, ignoring pagination
+func (r *PaymentProofRepository) CountPaymentProofs(ctx context.Context, filter port.PaymentProofFilter) (uint64, error) {
+	return uint64(len(r.list(ctx, filter))), nil
+}
+
+// list returns the payment proofs of the quotes in the salon of ctx matching the filter in the order they
+// were uploaded
+func (r *PaymentProofRepository) list(ctx context.Context, filter port.PaymentProofFilter) []domain.PaymentProof {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	var proofs []domain.PaymentProof
+	for _, proof := range r.db.paymentProofs {
+		if filter.QuoteID != nil && proof.QuoteID != *filter.QuoteID {
+			continue
+		}
+		if filter.IsReviewed != nil && proof.IsReviewed != *filter.IsReviewed {
+			continue
+		}
+		if filter.Checksum != nil && proof.Checksum != *filter.Checksum {
+			continue
+		}
+		if !r.db.quoteInSalon(ctx, proof.QuoteID) {
+			continue
+		}
+		proofs = append(proofs, proof)
+	}
+
+	byInsertion(r.db, proofs, func(p domain.PaymentProof) uuid.UUID {
+		return p.ID
+	})
+
+	return proofs
+}
+
+// WithTx runs fn with the repository, rolling back what it wrote when it fails
+func (r *PaymentProofRepository) WithTx(ctx context.Context, fn func(repo port.PaymentProofRepository) error) error {
+	return r.db.withTx(func() error {
+		return fn(r)
+	})
+}
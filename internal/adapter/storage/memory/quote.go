@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"context"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+)
+
+// QuoteRepository implements port.QuoteRepository interface on an in-memory database
+type QuoteRepository struct {
+	db *DB
+}
+
+// NewQuoteRepository creates a new in-memory quote repository instance
+func NewQuoteRepository(db *DB) *QuoteRepository {
+	return &QuoteRepository{
+		db,
+	}
+}
+
+// CreateQuote stores a new quote at its first version
+func (r *QuoteRepository) CreateQuote(ctx context.Context, quote *domain.Quote) (*domain.Quote, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if quote.ID == uuid.Nil {
+		quote.ID = uuid.New()
+	}
+	quote.Version = 1
+	quote.SalonID = util.SalonOr(ctx, quote.SalonID)
+
+	r.db.quotes[quote.ID] = *quote
+	r.db.insert(quote.ID)
+
+	return quote, nil
+}
+
+// GetQuoteByID gets a quote by ID, soft-deleted quotes are not found
+func (r *QuoteRepository) GetQuoteByID(ctx context.Context, id uuid.UUID) (*domain.Quote, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	quote, ok := r.db.quotes[id]
+	if !ok || quote.DeletedAt != nil || !util.InSalon(ctx, &quote) {
+		return nil, domain.ErrDataNotFound
+	}
+
+	return &quote, nil
+}
+
+// quoteCursor is the position of a quote in a listing ordered by time
+func quoteCursor(q domain.Quote) domain.Cursor {
+	return domain.Cursor{Time: q.Time, ID: q.ID}
+}
+
+// ListQuotes returns a page of quotes ordered by time and the cursor of the next one
+func (r *QuoteRepository) ListQuotes(ctx context.Context, filter port.QuoteFilter) ([]domain.Quote, *domain.Cursor, error) {
+	quotes := paginate(r.list(ctx, filter), filter.After, filter.Skip, filter.Limit, quoteCursor)
+
+	return quotes, nextCursor(quotes, filter.Limit, quoteCursor), nil
+}
+
+// CountQuotes counts the quotes matching the filter, ignoring pagination
+func (r *QuoteRepository) CountQuotes(ctx context.Context, filter port.QuoteFilter) (uint64, error) {
+	return uint64(len(r.list(ctx, filter))), nil
+}
+
+// list returns the quotes of the salon of ctx matching the filter ordered by time
+func (r *QuoteRepository) list(ctx context.Context, filter port.QuoteFilter) []domain.Quote {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	var quotes []domain.Quote
+	for _, q := range r.db.quotes {
+		if q.DeletedAt != nil && !filter.IncludeDeleted {
+			continue
+		}
+		if !util.InSalon(ctx, &q) {
+			continue
+		}
+		if filter.TypeOfServiceID != nil && q.TypeOfServiceID != *filter.TypeOfServiceID {
+			continue
+		}
+		if filter.ClientID != nil && q.ClientID != *filter.ClientID {
+			continue
+		}
+		if filter.ByState != nil && q.State != *filter.ByState {
+			continue
+		}
+		if !inRange(q.Time, filter.StartDate, filter.EndDate) {
+			continue
+		}
+		quotes = append(quotes, q)
+	}
+
+	byCursor(quotes, quoteCursor)
+
+	return quotes
+}
+
+// UpdateQuote updates the non-empty fields of a quote, the promotion and discount are always written.
+// A quote changed since it was read is a conflict
+func (r *QuoteRepository) UpdateQuote(ctx context.Context, quote *domain.Quote) (*domain.Quote, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	stored, ok := r.db.quotes[quote.ID]
+	if !ok || stored.Version != quote.Version || !util.InSalon(ctx, &stored) {
+		return nil, domain.ErrConflictingData
+	}
+
+	setIfNotZero(&stored.TypeOfServiceID, quote.TypeOfServiceID)
+	setIfNotZero(&stored.ClientID, quote.ClientID)
+	setIfNotZero(&stored.Time, quote.Time)
+	setIfNotZero(&stored.Description, quote.Description)
+	setIfNotZero(&stored.State, quote.State)
+	setIfNotZero(&stored.Price, quote.Price)
+	setIfNotZero(&stored.Currency, quote.Currency)
+	stored.PromotionID = quote.PromotionID
+	stored.Discount = quote.Discount
+	stored.CouponID = quote.CouponID
+	stored.CouponDiscount = quote.CouponDiscount
+	stored.Version++
+
+	r.db.quotes[quote.ID] = stored
+	*quote = stored
+
+	return quote, nil
+}
+
+// DeleteQuote soft deletes a quote by ID
+func (r *QuoteRepository) DeleteQuote(ctx context.Context, id uuid.UUID) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	quote, ok := r.db.quotes[id]
+	if !ok || !util.InSalon(ctx, &quote) || !softDeleted(&quote.DeletedAt) {
+		return domain.ErrDataNotFound
+	}
+
+	r.db.quotes[id] = quote
+
+	return nil
+}
+
+// WithTx runs fn with the repository, rolling back what it wrote when it fails
+func (r *QuoteRepository) WithTx(ctx context.Context, fn func(repo port.QuoteRepository) error) error {
+	return r.db.withTx(func() error {
+		return fn(r)
+	})
+}
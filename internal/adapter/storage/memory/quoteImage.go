@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"context"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+// QuoteImageRepository implements port.QuoteImageRepository interface on an in-memory database
+type QuoteImageRepository struct {
+	db *DB
+}
+
+// NewQuoteImageRepository creates a new in-memory quote image repository instance
+func NewQuoteImageRepository(db *DB) *QuoteImageRepository {
+	return &QuoteImageRepository{
+		db,
+	}
+}
+
+// CreateQuoteImage stores a new quote image
+func (r *QuoteImageRepository) CreateQuoteImage(ctx context.Context, image *domain.QuoteImage) (*domain.QuoteImage, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if image.ID == uuid.Nil {
+		image.ID = uuid.New()
+	}
+
+	r.db.quoteImages[image.ID] = *image
+	r.db.insert(image.ID)
+
+	return image, nil
+}
+
+// UpdateQuoteImage replaces a quote image
+func (r *QuoteImageRepository) UpdateQuoteImage(ctx context.Context, image *domain.QuoteImage) (*domain.QuoteImage, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if stored, ok := r.db.quoteImages[image.ID]; !ok || !r.db.quoteInSalon(ctx, stored.QuoteID) {
+		return nil, domain.ErrDataNotFound
+	}
+
+	r.db.quoteImages[image.ID] = *image
+
+	return image, nil
+}
+
+// DeleteQuoteImage deletes a quote image by ID
+func (r *QuoteImageRepository) DeleteQuoteImage(ctx context.Context, id uuid.UUID) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if image, ok := r.db.quoteImages[id]; ok && r.db.quoteInSalon(ctx, image.QuoteID) {
+		delete(r.db.quoteImages, id)
+	}
+
+	return nil
+}
+
+// GetQuoteImageByID gets a quote image by ID
+func (r *QuoteImageRepository) GetQuoteImageByID(ctx context.Context, id uuid.UUID) (*domain.QuoteImage, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	image, ok := r.db.quoteImages[id]
+	if !ok || !r.db.quoteInSalon(ctx, image.QuoteID) {
+		return nil, domain.ErrDataNotFound
+	}
+
+	return &image, nil
+}
+
+// GetQuoteImages returns a page of quote images in the order they were uploaded, with optional filters
+func (r *QuoteImageRepository) GetQuoteImages(ctx context.Context, skip, limit uint64, filters domain.QuoteImageFilters) ([]domain.QuoteImage, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	var images []domain.QuoteImage
+	for _, image := range r.db.quoteImages {
+		if filters.QuoteID != nil && image.QuoteID != *filters.QuoteID {
+			continue
+		}
+		if filters.Checksum != nil && image.Checksum != *filters.Checksum {
+			continue
+		}
+		if !r.db.quoteInSalon(ctx, image.QuoteID) {
+			continue
+		}
+		images = append(images, image)
+	}
+
+	byInsertion(r.db, images, func(i domain.QuoteImage) uuid.UUID {
+		return i.ID
+	})
+
+	return page(images, skip, limit), nil
+}
+
+// WithTx runs fn with the repository, rolling back what it wrote when it fails
+func (r *QuoteImageRepository) WithTx(ctx context.Context, fn func(repo port.QuoteImageRepository) error) error {
+	return r.db.withTx(func() error {
+		return fn(r)
+	})
+}
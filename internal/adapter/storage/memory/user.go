@@ -0,0 +1,215 @@
+package memory
+
+import (
+	"context"
+	"strings"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+)
+
+// UserRepository implements port.UserRepository interface on an in-memory database
+type UserRepository struct {
+	db *DB
+}
+
+// NewUserRepository creates a new in-memory user repository instance
+func NewUserRepository(db *DB) *UserRepository {
+	return &UserRepository{
+		db,
+	}
+}
+
+// CreateUser stores a new user, emails are unique as in the users table
+func (r *UserRepository) CreateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if r.emailTaken(user.Email, uuid.Nil) {
+		return nil, domain.ErrConflictingData
+	}
+
+	if user.ID == uuid.Nil {
+		user.ID = uuid.New()
+	}
+	if user.Role == "" {
+		user.Role = domain.Client
+	}
+	user.PreferredLanguage = user.PreferredLanguage.OrDefault()
+	user.SalonID = util.SalonOr(ctx, user.SalonID)
+
+	r.db.users[user.ID] = *user
+	r.db.insert(user.ID)
+
+	return user, nil
+}
+
+// GetUserByID gets a user by ID
+func (r *UserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	user, ok := r.db.users[id]
+	if !ok || !util.InSalon(ctx, &user) {
+		return nil, domain.ErrDataNotFound
+	}
+
+	return &user, nil
+}
+
+// GetUserByEmail gets a user by email
+func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	for _, user := range r.db.users {
+		if user.Email == email && util.InSalon(ctx, &user) {
+			return &user, nil
+		}
+	}
+
+	return nil, domain.ErrDataNotFound
+}
+
+// GetAdminsEmails returns the emails of the admin users
+func (r *UserRepository) GetAdminsEmails(ctx context.Context) ([]string, error) {
+	admins, err := r.GetAdmins(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var emails []string
+	for _, admin := range admins {
+		emails = append(emails, admin.Email)
+	}
+
+	return emails, nil
+}
+
+// GetAdmins returns every admin user
+func (r *UserRepository) GetAdmins(ctx context.Context) ([]domain.User, error) {
+	return r.list(ctx, domain.UserFilters{Role: domain.Admin}), nil
+}
+
+// ListUsers lists a page of users ordered by id, with optional filters
+func (r *UserRepository) ListUsers(ctx context.Context, skip, limit uint64, filters domain.UserFilters) ([]domain.User, error) {
+	return page(r.list(ctx, filters), skip, limit), nil
+}
+
+// CountUsers counts the users matching the filters, ignoring pagination
+func (r *UserRepository) CountUsers(ctx context.Context, filters domain.UserFilters) (uint64, error) {
+	return uint64(len(r.list(ctx, filters))), nil
+}
+
+// list returns the users of the salon of ctx matching the filters ordered by id, names match
+// case-insensitively as with ILIKE
+func (r *UserRepository) list(ctx context.Context, filters domain.UserFilters) []domain.User {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	var users []domain.User
+	for _, user := range r.db.users {
+		if !containsFold(user.Name, filters.Name) ||
+			!containsFold(user.LastName, filters.LastName) ||
+			!containsFold(user.SecondLastName, filters.SecondLastName) {
+			continue
+		}
+		if filters.Role != "" && user.Role != filters.Role {
+			continue
+		}
+		if !util.InSalon(ctx, &user) {
+			continue
+		}
+		users = append(users, user)
+	}
+
+	byCursor(users, func(u domain.User) domain.Cursor {
+		return domain.Cursor{ID: u.ID}
+	})
+
+	return users
+}
+
+// UpdateUser updates the non-empty fields of a user
+func (r *UserRepository) UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	stored, ok := r.db.users[user.ID]
+	if !ok || !util.InSalon(ctx, &stored) {
+		return nil, domain.ErrDataNotFound
+	}
+	if user.Email != "" && r.emailTaken(user.Email, user.ID) {
+		return nil, domain.ErrConflictingData
+	}
+
+	setIfNotZero(&stored.Name, user.Name)
+	setIfNotZero(&stored.LastName, user.LastName)
+	setIfNotZero(&stored.SecondLastName, user.SecondLastName)
+	setIfNotZero(&stored.Email, user.Email)
+	setIfNotZero(&stored.Password, user.Password)
+	setIfNotZero(&stored.Role, user.Role)
+	setIfNotZero(&stored.PreferredLanguage, user.PreferredLanguage)
+	setIfNotZero(&stored.Phone, user.Phone)
+
+	r.db.users[user.ID] = stored
+	*user = stored
+
+	return user, nil
+}
+
+// UpdateNotificationPreferences replaces the phone and notification preferences of a user
+func (r *UserRepository) UpdateNotificationPreferences(ctx context.Context, user *domain.User) (*domain.User, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	stored, ok := r.db.users[user.ID]
+	if !ok || !util.InSalon(ctx, &stored) {
+		return nil, domain.ErrDataNotFound
+	}
+
+	stored.Phone = user.Phone
+	stored.NotificationPreferences = user.NotificationPreferences
+
+	r.db.users[user.ID] = stored
+	*user = stored
+
+	return user, nil
+}
+
+// DeleteUser deletes a user by ID
+func (r *UserRepository) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if user, ok := r.db.users[id]; ok && util.InSalon(ctx, &user) {
+		delete(r.db.users, id)
+	}
+
+	return nil
+}
+
+// emailTaken reports whether another user than id has the email, the caller holds the lock
+func (r *UserRepository) emailTaken(email string, id uuid.UUID) bool {
+	for _, user := range r.db.users {
+		if user.Email == email && user.ID != id {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold reports whether s contains substr ignoring case, an empty substr matches everything
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// setIfNotZero overwrites field with value unless value is empty, as the partial updates of postgres do
+func setIfNotZero[T comparable](field *T, value T) {
+	var zero T
+	if value != zero {
+		*field = value
+	}
+}
@@ -0,0 +1,32 @@
+package minio
+
+import (
+	"context"
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/adapter/storage/awsS3"
+	"harajuku/backend/internal/core/port"
+)
+
+// New returns a file repository for an S3-compatible object store such as MinIO,
+// it reuses the S3 adapter with a custom endpoint and path-style addressing
+func New(ctx context.Context, cfg *config.Minio) (port.FileRepository, error) {
+	scheme := "http://"
+	if cfg.UseSSL {
+		scheme = "https://"
+	}
+
+	client, err := awsS3.NewClient(ctx, &config.AwsS3{
+		Bucket:       cfg.Bucket,
+		Region:       cfg.Region,
+		Endpoint:     scheme + cfg.Endpoint,
+		AccessKey:    cfg.AccessKey,
+		SecretKey:    cfg.SecretKey,
+		UsePathStyle: true,
+		MaxAttempts:  3,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return awsS3.NewAwsS3(client, cfg.Bucket, awsS3.MinPartSize), nil
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"embed"
 	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/golang-migrate/migrate/v4"
@@ -14,6 +18,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/codes"
 )
 
 // migrationsFS embeds the migrations folder
@@ -28,11 +33,19 @@ type Conn interface {
 	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
 }
 
-// DB wraps a connection (pool or transaction) and squirrel builder
+// DB wraps a connection (pool or transaction) and squirrel builder,
+// read-only queries can go to the replicas through Reader
 type DB struct {
 	Conn         Conn
 	QueryBuilder squirrel.StatementBuilderType
 	url          string
+	pool         *pgxpool.Pool
+	tx           pgx.Tx
+	replicas     []*pgxpool.Pool
+	next         *atomic.Uint64
+	queryTimeout time.Duration
+	slowQuery    time.Duration
+	queryLog     queryLog
 }
 
 // New creates a new database pool instance
@@ -42,29 +55,200 @@ func New(ctx context.Context, cfg *config.DB) (*DB, error) {
 	url := fmt.Sprintf("%s://%s:%s@%s:%s/%s?sslmode=disable",
 		cfg.Connection, cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Name,
 	)
-	pool, err := pgxpool.New(ctx, url)
+	queryTimeout, err := parseOptionalDuration(cfg.QueryTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query timeout: %w", err)
+	}
+	slowQuery, err := parseOptionalDuration(cfg.SlowQueryThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("invalid slow query threshold: %w", err)
+	}
+
+	pool, err := connect(ctx, url, cfg)
 	if err != nil {
 		return nil, err
 	}
-	if err = pool.Ping(ctx); err != nil {
+	replicas, err := connectReplicas(ctx, cfg)
+	if err != nil {
+		pool.Close()
 		return nil, err
 	}
+
 	builder := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
-	return &DB{Conn: pool, QueryBuilder: builder, url: url}, nil
+	db := &DB{
+		QueryBuilder: builder,
+		url:          url,
+		pool:         pool,
+		replicas:     replicas,
+		next:         new(atomic.Uint64),
+		queryTimeout: queryTimeout,
+		slowQuery:    slowQuery,
+		queryLog:     queryLog{sampleRatio: cfg.QueryLogSampleRatio, args: cfg.QueryLogArgs},
+	}
+	db.Conn = db.timed(pool)
+	return db, nil
+}
+
+// parseOptionalDuration parses a duration, an empty value is zero
+func parseOptionalDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// timed wraps conn with the query timeout and the slow query log when they are configured
+func (db *DB) timed(conn Conn) Conn {
+	if db.queryTimeout <= 0 && db.slowQuery <= 0 {
+		return conn
+	}
+	return &timedConn{conn: conn, timeout: db.queryTimeout, slowQuery: db.slowQuery, queryLog: db.queryLog}
+}
+
+// connect opens a pool with the pool settings of the config, zero settings keep the pgxpool defaults
+func connect(ctx context.Context, url string, cfg *config.DB) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		return nil, err
+	}
+
+	poolConfig.ConnConfig.Tracer = queryTracer{}
+
+	if cfg.MaxConns > 0 {
+		poolConfig.MaxConns = int32(cfg.MaxConns)
+	}
+	if cfg.MinConns > 0 {
+		poolConfig.MinConns = int32(cfg.MinConns)
+	}
+	if cfg.MaxConnLifetime != "" {
+		poolConfig.MaxConnLifetime, err = time.ParseDuration(cfg.MaxConnLifetime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max conn lifetime: %w", err)
+		}
+	}
+	if cfg.HealthCheckPeriod != "" {
+		poolConfig.HealthCheckPeriod, err = time.ParseDuration(cfg.HealthCheckPeriod)
+		if err != nil {
+			return nil, fmt.Errorf("invalid health check period: %w", err)
+		}
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err = pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+// connectReplicas opens a pool for every replica URL, separated by commas
+func connectReplicas(ctx context.Context, cfg *config.DB) ([]*pgxpool.Pool, error) {
+	var replicas []*pgxpool.Pool
+
+	for _, url := range strings.Split(cfg.ReplicaURLs, ",") {
+		url = strings.TrimSpace(url)
+		if url == "" {
+			continue
+		}
+
+		pool, err := connect(ctx, url, cfg)
+		if err != nil {
+			for _, replica := range replicas {
+				replica.Close()
+			}
+			return nil, fmt.Errorf("connect to replica: %w", err)
+		}
+
+		replicas = append(replicas, pool)
+	}
+
+	return replicas, nil
 }
 
-// BeginTx starts a new transaction and returns a new DB wrapping it
+// Reader returns the connection for read-only queries, the replicas take turns when there are any.
+// Replicas lag behind the primary, so reads that must see a write just made keep using Conn,
+// and a transaction always reads from itself
+func (db *DB) Reader() Conn {
+	if len(db.replicas) == 0 {
+		return db.Conn
+	}
+
+	i := db.next.Add(1)
+	return db.timed(db.replicas[i%uint64(len(db.replicas))])
+}
+
+// Stats returns the statistics of the primary pool, nil inside a transaction
+func (db *DB) Stats() *domain.PoolStats {
+	if db.pool == nil {
+		return nil
+	}
+
+	stat := db.pool.Stat()
+	return &domain.PoolStats{
+		MaxConns:        stat.MaxConns(),
+		TotalConns:      stat.TotalConns(),
+		AcquiredConns:   stat.AcquiredConns(),
+		IdleConns:       stat.IdleConns(),
+		AcquireCount:    stat.AcquireCount(),
+		AcquireDuration: stat.AcquireDuration(),
+		EmptyAcquires:   stat.EmptyAcquireCount(),
+	}
+}
+
+// BeginTx starts a new transaction and returns a new DB wrapping it,
+// inside a transaction it starts a savepoint instead
 func (db *DB) BeginTx(ctx context.Context) (*DB, error) {
-	tx, err := db.Conn.(*pgxpool.Pool).Begin(ctx)
+	var tx pgx.Tx
+	var err error
+	switch {
+	case db.tx != nil:
+		tx, err = db.tx.Begin(ctx)
+	case db.pool != nil:
+		tx, err = db.pool.Begin(ctx)
+	default:
+		err = fmt.Errorf("no connection")
+	}
 	if err != nil {
 		return nil, fmt.Errorf("begin tx: %w", err)
 	}
 	builder := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
-	return &DB{Conn: tx, QueryBuilder: builder, url: db.url}, nil
+	txDB := &DB{QueryBuilder: builder, url: db.url, tx: tx, queryTimeout: db.queryTimeout, slowQuery: db.slowQuery, queryLog: db.queryLog}
+	txDB.Conn = txDB.timed(tx)
+	return txDB, nil
+}
+
+// Commit commits the transaction started by BeginTx
+func (db *DB) Commit(ctx context.Context) error {
+	if db.tx == nil {
+		return fmt.Errorf("commit: not in a transaction")
+	}
+	return db.tx.Commit(ctx)
+}
+
+// Rollback rolls back the transaction started by BeginTx
+func (db *DB) Rollback(ctx context.Context) error {
+	if db.tx == nil {
+		return fmt.Errorf("rollback: not in a transaction")
+	}
+	return db.tx.Rollback(ctx)
 }
 
 // WithTx executes fn inside a transaction, rolling back on error
-func (db *DB) WithTx(ctx context.Context, fn func(txDB *DB) error) error {
+func (db *DB) WithTx(ctx context.Context, fn func(txDB *DB) error) (err error) {
+	// The span covers the transaction from BEGIN to COMMIT or ROLLBACK
+	ctx, span := tracer.Start(ctx, "transaction")
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	// Start transaction
 	txDB, err := db.BeginTx(ctx)
 	if err != nil {
@@ -72,33 +256,68 @@ func (db *DB) WithTx(ctx context.Context, fn func(txDB *DB) error) error {
 	}
 	// Ensure rollback on panic or error
 	defer func() {
-		_ = txDB.Conn.(interface{ Rollback(ctx context.Context) error }).Rollback(ctx)
+		_ = txDB.Rollback(ctx)
 	}()
 	// Execute user function
 	if err := fn(txDB); err != nil {
 		return err
 	}
 	// Commit
-	return txDB.Conn.(interface{ Commit(ctx context.Context) error }).Commit(ctx)
+	return txDB.Commit(ctx)
 }
 
 // Migrate runs DB migrations
 func (db *DB) Migrate() error {
-	driver, err := iofs.New(migrationsFS, "migrations")
+	m, err := db.migrator()
 	if err != nil {
 		return err
 	}
-	m, err := migrate.NewWithSourceInstance("iofs", driver, db.url)
+	err = m.Up()
+	if err != nil && err != migrate.ErrNoChange {
+		return err
+	}
+	return nil
+}
+
+// MigrateDown rolls back the given number of migrations
+func (db *DB) MigrateDown(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("invalid number of migrations to roll back: %d", steps)
+	}
+	m, err := db.migrator()
 	if err != nil {
 		return err
 	}
-	err = m.Up()
+	err = m.Steps(-steps)
 	if err != nil && err != migrate.ErrNoChange {
 		return err
 	}
 	return nil
 }
 
+// MigrationVersion returns the current schema version and whether the last migration failed halfway,
+// the version is 0 when no migration has been applied
+func (db *DB) MigrationVersion() (uint, bool, error) {
+	m, err := db.migrator()
+	if err != nil {
+		return 0, false, err
+	}
+	version, dirty, err := m.Version()
+	if err == migrate.ErrNilVersion {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+// migrator creates a migrate instance reading the embedded migrations
+func (db *DB) migrator() (*migrate.Migrate, error) {
+	driver, err := iofs.New(migrationsFS, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return migrate.NewWithSourceInstance("iofs", driver, db.url)
+}
+
 // ErrorCode extracts Postgres error code
 func (db *DB) ErrorCode(err error) string {
 	if pgErr, ok := err.(*pgconn.PgError); ok {
@@ -107,9 +326,12 @@ func (db *DB) ErrorCode(err error) string {
 	return ""
 }
 
-// Close closes the underlying pool
+// Close closes the underlying pool and the replicas
 func (db *DB) Close() {
-	if pool, ok := db.Conn.(*pgxpool.Pool); ok {
-		pool.Close()
+	if db.pool != nil {
+		db.pool.Close()
+	}
+	for _, replica := range db.replicas {
+		replica.Close()
 	}
 }
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Ping implements port.HealthChecker, checking the primary can be reached
+func (db *DB) Ping(ctx context.Context) error {
+	if db.pool == nil {
+		return fmt.Errorf("ping: no pool")
+	}
+	return db.pool.Ping(ctx)
+}
+
+// DatabaseHealth implements port.HealthRepository, pinging the primary and reading the schema
+// version from the table of golang-migrate instead of opening a migrator on every check
+func (db *DB) DatabaseHealth(ctx context.Context) (*domain.DatabaseHealth, error) {
+	if db.pool == nil {
+		return nil, fmt.Errorf("health check: no pool")
+	}
+
+	started := time.Now()
+	if err := db.pool.Ping(ctx); err != nil {
+		return nil, fmt.Errorf("ping: %w", err)
+	}
+	health := &domain.DatabaseHealth{
+		Latency: time.Since(started),
+		Pool:    db.Stats(),
+	}
+
+	var version int64
+	err := db.Conn.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).
+		Scan(&version, &health.MigrationDirty)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("migration version: %w", err)
+	}
+	health.MigrationVersion = uint(version)
+
+	return health, nil
+}
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "PaymentEvent";
+DROP TYPE IF EXISTS payment_event_status_enum;
+DROP TYPE IF EXISTS payment_provider_enum;
@@ -0,0 +1,16 @@
+CREATE TYPE "payment_provider_enum" AS ENUM ('stripe', 'mercadopago', 'bank');
+CREATE TYPE "payment_event_status_enum" AS ENUM ('succeeded', 'failed', 'pending');
+
+CREATE TABLE "PaymentEvent" (
+	"id" UUID NOT NULL UNIQUE,
+	"provider" payment_provider_enum NOT NULL,
+	"externalId" TEXT NOT NULL,
+	"quoteId" UUID NOT NULL,
+	"amount" REAL NOT NULL,
+	"status" payment_event_status_enum NOT NULL,
+	"receivedAt" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY ("id"),
+	FOREIGN KEY ("quoteId") REFERENCES "Quote"("id") ON UPDATE CASCADE ON DELETE RESTRICT
+);
+
+CREATE UNIQUE INDEX "paymentEvent_provider_externalId" ON "PaymentEvent" ("provider", "externalId");
@@ -0,0 +1,2 @@
+ALTER TABLE "TypeOfService"
+	ADD COLUMN "durationMinutes" INTEGER NOT NULL DEFAULT 60 CHECK ("durationMinutes" > 0);
@@ -0,0 +1,3 @@
+ALTER TABLE "TypeOfService" DROP COLUMN IF EXISTS "categoryId";
+
+DROP TABLE IF EXISTS "ServiceCategory" CASCADE;
@@ -0,0 +1,9 @@
+CREATE TABLE "ServiceCategory" (
+	"id" UUID NOT NULL UNIQUE,
+	"name" TEXT NOT NULL UNIQUE,
+	PRIMARY KEY("id")
+);
+
+ALTER TABLE "TypeOfService"
+	ADD COLUMN "categoryId" UUID,
+	ADD FOREIGN KEY("categoryId") REFERENCES "ServiceCategory"("id") ON UPDATE CASCADE ON DELETE RESTRICT;
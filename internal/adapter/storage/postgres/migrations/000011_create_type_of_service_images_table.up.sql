@@ -0,0 +1,7 @@
+CREATE TABLE "TypeOfServiceImages" (
+	"id" UUID NOT NULL UNIQUE,
+	"typeOfServiceId" UUID NOT NULL,
+	"url" TEXT NOT NULL,
+	PRIMARY KEY("id"),
+	FOREIGN KEY("typeOfServiceId") REFERENCES "TypeOfService"("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+ALTER TABLE "TypeOfService" DROP COLUMN IF EXISTS "archived";
@@ -0,0 +1,2 @@
+ALTER TABLE "TypeOfService"
+	ADD COLUMN "archived" BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,14 @@
+CREATE TABLE "ServiceOffering" (
+	"adminId" UUID NOT NULL,
+	"typeOfServiceId" UUID NOT NULL,
+	PRIMARY KEY("adminId", "typeOfServiceId"),
+	FOREIGN KEY("adminId") REFERENCES "users"("id") ON UPDATE CASCADE ON DELETE CASCADE,
+	FOREIGN KEY("typeOfServiceId") REFERENCES "TypeOfService"("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- Existing admins keep offering every existing type of service so current bookings stay valid
+INSERT INTO "ServiceOffering" ("adminId", "typeOfServiceId")
+SELECT u."id", t."id"
+FROM "users" u
+CROSS JOIN "TypeOfService" t
+WHERE u."role" = 'admin';
@@ -0,0 +1,3 @@
+ALTER TABLE "Quote" DROP COLUMN IF EXISTS "currency";
+
+ALTER TABLE "TypeOfService" DROP COLUMN IF EXISTS "currency";
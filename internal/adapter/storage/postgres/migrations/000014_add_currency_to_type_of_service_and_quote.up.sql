@@ -0,0 +1,5 @@
+ALTER TABLE "TypeOfService"
+	ADD COLUMN "currency" CHAR(3) NOT NULL DEFAULT 'MXN';
+
+ALTER TABLE "Quote"
+	ADD COLUMN "currency" CHAR(3) NOT NULL DEFAULT 'MXN';
@@ -0,0 +1,6 @@
+ALTER TABLE "TypeOfService"
+	DROP CONSTRAINT IF EXISTS "TypeOfService_durationRange_check",
+	DROP COLUMN IF EXISTS "durationMaxMinutes",
+	DROP COLUMN IF EXISTS "durationMinMinutes",
+	DROP COLUMN IF EXISTS "preparationInstructions",
+	DROP COLUMN IF EXISTS "description";
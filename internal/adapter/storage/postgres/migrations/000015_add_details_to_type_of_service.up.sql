@@ -0,0 +1,7 @@
+ALTER TABLE "TypeOfService"
+	ADD COLUMN "description" TEXT NOT NULL DEFAULT '',
+	ADD COLUMN "preparationInstructions" TEXT NOT NULL DEFAULT '',
+	ADD COLUMN "durationMinMinutes" INTEGER CHECK ("durationMinMinutes" > 0),
+	ADD COLUMN "durationMaxMinutes" INTEGER CHECK ("durationMaxMinutes" > 0),
+	ADD CONSTRAINT "TypeOfService_durationRange_check"
+		CHECK ("durationMinMinutes" IS NULL OR "durationMaxMinutes" IS NULL OR "durationMinMinutes" <= "durationMaxMinutes");
@@ -0,0 +1,7 @@
+ALTER TABLE "Quote"
+	DROP COLUMN IF EXISTS "discount",
+	DROP COLUMN IF EXISTS "promotionId";
+
+DROP TABLE IF EXISTS "PromotionTypeOfService";
+DROP TABLE IF EXISTS "Promotion";
+DROP TYPE IF EXISTS "promotion_discount_type_enum";
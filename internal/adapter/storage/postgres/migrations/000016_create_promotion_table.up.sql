@@ -0,0 +1,30 @@
+CREATE TYPE "promotion_discount_type_enum" AS ENUM ('percentage', 'fixed');
+
+CREATE TABLE "Promotion" (
+	"id" UUID NOT NULL UNIQUE,
+	"name" TEXT NOT NULL,
+	"discountType" promotion_discount_type_enum NOT NULL,
+	"value" REAL NOT NULL CHECK ("value" > 0),
+	"currency" CHAR(3),
+	"startsAt" TIMESTAMPTZ NOT NULL,
+	"endsAt" TIMESTAMPTZ NOT NULL,
+	PRIMARY KEY("id"),
+	CHECK ("startsAt" < "endsAt"),
+	CHECK ("discountType" <> 'percentage' OR "value" <= 100),
+	CHECK ("discountType" <> 'fixed' OR "currency" IS NOT NULL)
+);
+
+CREATE TABLE "PromotionTypeOfService" (
+	"promotionId" UUID NOT NULL,
+	"typeOfServiceId" UUID NOT NULL,
+	PRIMARY KEY("promotionId", "typeOfServiceId"),
+	FOREIGN KEY("promotionId") REFERENCES "Promotion"("id") ON UPDATE CASCADE ON DELETE CASCADE,
+	FOREIGN KEY("typeOfServiceId") REFERENCES "TypeOfService"("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX "promotion_window" ON "Promotion" ("startsAt", "endsAt");
+
+ALTER TABLE "Quote"
+	ADD COLUMN "promotionId" UUID,
+	ADD COLUMN "discount" REAL NOT NULL DEFAULT 0,
+	ADD FOREIGN KEY("promotionId") REFERENCES "Promotion"("id") ON UPDATE CASCADE ON DELETE SET NULL;
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS "paymentProof_checksum";
+DROP INDEX IF EXISTS "quoteImages_checksum";
+
+ALTER TABLE "PaymentProof"
+	DROP COLUMN IF EXISTS "checksum",
+	DROP COLUMN IF EXISTS "contentType",
+	DROP COLUMN IF EXISTS "size",
+	DROP COLUMN IF EXISTS "fileName";
+
+ALTER TABLE "QuoteImages"
+	DROP COLUMN IF EXISTS "checksum",
+	DROP COLUMN IF EXISTS "contentType",
+	DROP COLUMN IF EXISTS "size",
+	DROP COLUMN IF EXISTS "fileName";
@@ -0,0 +1,14 @@
+ALTER TABLE "QuoteImages"
+	ADD COLUMN "fileName" TEXT NOT NULL DEFAULT '',
+	ADD COLUMN "size" BIGINT NOT NULL DEFAULT 0 CHECK ("size" >= 0),
+	ADD COLUMN "contentType" TEXT NOT NULL DEFAULT '',
+	ADD COLUMN "checksum" TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE "PaymentProof"
+	ADD COLUMN "fileName" TEXT NOT NULL DEFAULT '',
+	ADD COLUMN "size" BIGINT NOT NULL DEFAULT 0 CHECK ("size" >= 0),
+	ADD COLUMN "contentType" TEXT NOT NULL DEFAULT '',
+	ADD COLUMN "checksum" TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX "quoteImages_checksum" ON "QuoteImages" ("checksum");
+CREATE INDEX "paymentProof_checksum" ON "PaymentProof" ("checksum");
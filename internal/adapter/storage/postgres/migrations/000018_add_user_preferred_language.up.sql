@@ -0,0 +1,2 @@
+ALTER TABLE "users"
+	ADD COLUMN "preferredLanguage" VARCHAR(2) NOT NULL DEFAULT 'es' CHECK ("preferredLanguage" IN ('es', 'en'));
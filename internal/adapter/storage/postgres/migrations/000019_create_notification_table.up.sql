@@ -0,0 +1,12 @@
+CREATE TABLE "Notification" (
+	"id" UUID NOT NULL UNIQUE,
+	"userId" UUID NOT NULL,
+	"type" TEXT NOT NULL,
+	"payload" JSONB NOT NULL DEFAULT '{}',
+	"read" BOOLEAN NOT NULL DEFAULT FALSE,
+	"createdAt" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY("id"),
+	FOREIGN KEY("userId") REFERENCES "users"("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX "notification_user_read" ON "Notification" ("userId", "read", "createdAt" DESC);
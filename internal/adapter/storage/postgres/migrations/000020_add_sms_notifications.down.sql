@@ -0,0 +1,5 @@
+ALTER TABLE "Appointment" DROP COLUMN IF EXISTS "reminderSentAt";
+
+ALTER TABLE "users"
+	DROP COLUMN IF EXISTS "smsNotifications",
+	DROP COLUMN IF EXISTS "phone";
@@ -0,0 +1,6 @@
+ALTER TABLE "users"
+	ADD COLUMN "phone" TEXT NOT NULL DEFAULT '',
+	ADD COLUMN "smsNotifications" BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE "Appointment"
+	ADD COLUMN "reminderSentAt" TIMESTAMPTZ;
@@ -0,0 +1,2 @@
+ALTER TABLE "users"
+	ADD COLUMN "whatsAppNotifications" BOOLEAN NOT NULL DEFAULT FALSE;
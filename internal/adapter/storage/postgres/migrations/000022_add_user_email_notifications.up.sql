@@ -0,0 +1,2 @@
+ALTER TABLE "users"
+	ADD COLUMN "emailNotifications" BOOLEAN NOT NULL DEFAULT TRUE;
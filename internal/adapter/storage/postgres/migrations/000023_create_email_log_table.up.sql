@@ -0,0 +1,16 @@
+CREATE TABLE "EmailLog" (
+	"id" UUID NOT NULL UNIQUE,
+	"emailId" UUID NOT NULL,
+	"recipients" TEXT[] NOT NULL,
+	"template" TEXT,
+	"subject" TEXT NOT NULL,
+	"status" TEXT NOT NULL,
+	"providerMessageId" TEXT,
+	"error" TEXT,
+	"attempt" INTEGER NOT NULL,
+	"createdAt" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY("id")
+);
+
+CREATE INDEX "email_log_created_at" ON "EmailLog" ("createdAt" DESC);
+CREATE INDEX "email_log_recipients" ON "EmailLog" USING GIN ("recipients");
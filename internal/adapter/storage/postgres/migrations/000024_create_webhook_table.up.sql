@@ -0,0 +1,12 @@
+CREATE TABLE "Webhook" (
+	"id" UUID NOT NULL UNIQUE,
+	"url" TEXT NOT NULL,
+	"secret" TEXT NOT NULL,
+	"eventTypes" TEXT[] NOT NULL,
+	"active" BOOLEAN NOT NULL DEFAULT TRUE,
+	"createdAt" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	"updatedAt" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY("id")
+);
+
+CREATE INDEX "webhook_event_types" ON "Webhook" USING GIN ("eventTypes");
@@ -0,0 +1,12 @@
+CREATE TABLE "Device" (
+	"id" UUID NOT NULL UNIQUE,
+	"userId" UUID NOT NULL,
+	"token" TEXT NOT NULL UNIQUE,
+	"platform" TEXT NOT NULL,
+	"createdAt" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	"updatedAt" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY("id"),
+	FOREIGN KEY("userId") REFERENCES "users"("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX "device_user" ON "Device" ("userId");
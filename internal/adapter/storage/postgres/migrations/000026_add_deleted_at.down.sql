@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "Appointment_quoteId_key";
+ALTER TABLE "Appointment" ADD CONSTRAINT "Appointment_quoteId_key" UNIQUE ("quoteId");
+
+ALTER TABLE "TypeOfService" DROP COLUMN IF EXISTS "deletedAt";
+ALTER TABLE "AvailabilitySlot" DROP COLUMN IF EXISTS "deletedAt";
+ALTER TABLE "Appointment" DROP COLUMN IF EXISTS "deletedAt";
+ALTER TABLE "Quote" DROP COLUMN IF EXISTS "deletedAt";
@@ -0,0 +1,15 @@
+ALTER TABLE "Quote"
+	ADD COLUMN "deletedAt" TIMESTAMPTZ;
+
+ALTER TABLE "Appointment"
+	ADD COLUMN "deletedAt" TIMESTAMPTZ;
+
+ALTER TABLE "AvailabilitySlot"
+	ADD COLUMN "deletedAt" TIMESTAMPTZ;
+
+ALTER TABLE "TypeOfService"
+	ADD COLUMN "deletedAt" TIMESTAMPTZ;
+
+-- A deleted appointment must not keep its quote from being booked again
+ALTER TABLE "Appointment" DROP CONSTRAINT IF EXISTS "Appointment_quoteId_key";
+CREATE UNIQUE INDEX "Appointment_quoteId_key" ON "Appointment" ("quoteId") WHERE "deletedAt" IS NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE "AvailabilitySlot" DROP COLUMN IF EXISTS "version";
+ALTER TABLE "Appointment" DROP COLUMN IF EXISTS "version";
+ALTER TABLE "Quote" DROP COLUMN IF EXISTS "version";
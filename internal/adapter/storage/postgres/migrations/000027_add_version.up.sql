@@ -0,0 +1,8 @@
+ALTER TABLE "Quote"
+	ADD COLUMN "version" INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE "Appointment"
+	ADD COLUMN "version" INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE "AvailabilitySlot"
+	ADD COLUMN "version" INTEGER NOT NULL DEFAULT 1;
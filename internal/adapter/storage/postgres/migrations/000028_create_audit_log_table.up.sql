@@ -0,0 +1,19 @@
+CREATE TABLE "AuditLog" (
+	"id" UUID NOT NULL UNIQUE,
+	"actorId" UUID NOT NULL,
+	"actorRole" TEXT NOT NULL,
+	"method" TEXT NOT NULL,
+	"route" TEXT NOT NULL,
+	"path" TEXT NOT NULL,
+	"targetId" UUID,
+	"status" INTEGER NOT NULL,
+	"changes" JSONB,
+	"requestId" TEXT,
+	"createdAt" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY("id")
+);
+
+-- The log outlives the users and the entities it names, so neither is a foreign key
+CREATE INDEX "audit_log_created_at" ON "AuditLog" ("createdAt" DESC);
+CREATE INDEX "audit_log_target" ON "AuditLog" ("targetId", "createdAt" DESC);
+CREATE INDEX "audit_log_actor" ON "AuditLog" ("actorId", "createdAt" DESC);
@@ -0,0 +1,7 @@
+-- Postgres can't drop an enum value, the type is made again without it
+UPDATE "Quote" SET "state" = 'rejected' WHERE "state" = 'expired';
+
+ALTER TYPE "quote_state_enum" RENAME TO "quote_state_enum_old";
+CREATE TYPE "quote_state_enum" AS ENUM ('pending', 'approved', 'rejected', 'requires_proof', 'pending_payment');
+ALTER TABLE "Quote" ALTER COLUMN "state" TYPE "quote_state_enum" USING "state"::text::"quote_state_enum";
+DROP TYPE "quote_state_enum_old";
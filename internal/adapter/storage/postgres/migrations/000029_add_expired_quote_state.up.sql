@@ -0,0 +1 @@
+ALTER TYPE "quote_state_enum" ADD VALUE IF NOT EXISTS 'expired';
@@ -0,0 +1,12 @@
+CREATE TABLE "Outbox" (
+	"id" UUID NOT NULL UNIQUE,
+	"kind" TEXT NOT NULL,
+	"payload" JSONB NOT NULL,
+	"attempts" INTEGER NOT NULL DEFAULT 0,
+	"lastError" TEXT,
+	"availableAt" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	"createdAt" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY("id")
+);
+
+CREATE INDEX "outbox_available_at" ON "Outbox" ("availableAt");
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS "notification_created_at";
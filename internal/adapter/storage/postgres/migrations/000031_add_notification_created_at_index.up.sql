@@ -0,0 +1,2 @@
+-- The retention cleanup deletes the oldest notifications of every user at once
+CREATE INDEX "notification_created_at" ON "Notification" ("createdAt");
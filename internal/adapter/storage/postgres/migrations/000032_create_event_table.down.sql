@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "Event";
+DROP FUNCTION IF EXISTS "event_append_only"();
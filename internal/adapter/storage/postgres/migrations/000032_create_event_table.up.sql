@@ -0,0 +1,21 @@
+CREATE TABLE "Event" (
+	"id" UUID NOT NULL UNIQUE,
+	"type" TEXT NOT NULL,
+	"payload" JSONB NOT NULL,
+	"occurredAt" TIMESTAMPTZ NOT NULL,
+	"recordedAt" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY("id")
+);
+
+CREATE INDEX "event_occurred_at" ON "Event" ("occurredAt");
+CREATE INDEX "event_type_occurred_at" ON "Event" ("type", "occurredAt");
+
+-- The store is the history projections are rebuilt from, rows are only ever added
+CREATE FUNCTION "event_append_only"() RETURNS TRIGGER AS $$
+BEGIN
+	RAISE EXCEPTION 'the Event table is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "event_append_only" BEFORE UPDATE OR DELETE ON "Event"
+	FOR EACH ROW EXECUTE FUNCTION "event_append_only"();
@@ -0,0 +1,17 @@
+CREATE TABLE "AuditEntry" (
+	"id" UUID NOT NULL UNIQUE,
+	"entity" TEXT NOT NULL,
+	"entityId" UUID NOT NULL,
+	"action" TEXT NOT NULL,
+	"actorId" UUID,
+	"actorRole" TEXT,
+	"before" JSONB,
+	"after" JSONB,
+	"createdAt" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY("id")
+);
+
+-- Like the audit log the entries outlive the users and the entities they name, so neither is a foreign key
+CREATE INDEX "audit_entry_created_at" ON "AuditEntry" ("createdAt" DESC);
+CREATE INDEX "audit_entry_entity" ON "AuditEntry" ("entity", "entityId", "createdAt" DESC);
+CREATE INDEX "audit_entry_actor" ON "AuditEntry" ("actorId", "createdAt" DESC);
@@ -0,0 +1,7 @@
+ALTER TABLE "Appointment" DROP COLUMN IF EXISTS "salonId";
+ALTER TABLE "Quote" DROP COLUMN IF EXISTS "salonId";
+ALTER TABLE "AvailabilitySlot" DROP COLUMN IF EXISTS "salonId";
+ALTER TABLE "TypeOfService" DROP COLUMN IF EXISTS "salonId";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "salonId";
+
+DROP TABLE IF EXISTS "Salon";
@@ -0,0 +1,32 @@
+CREATE TABLE "Salon" (
+	"id" UUID NOT NULL UNIQUE,
+	"name" TEXT NOT NULL,
+	"slug" TEXT NOT NULL UNIQUE,
+	"createdAt" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	"updatedAt" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY("id")
+);
+
+-- The rows created before there were salons belong to the first one, it stays the default of new rows
+INSERT INTO "Salon" ("id", "name", "slug") VALUES ('00000000-0000-0000-0000-000000000001', 'Harajuku', 'harajuku');
+
+ALTER TABLE "users"
+	ADD COLUMN "salonId" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES "Salon" ("id");
+
+ALTER TABLE "TypeOfService"
+	ADD COLUMN "salonId" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES "Salon" ("id");
+
+ALTER TABLE "AvailabilitySlot"
+	ADD COLUMN "salonId" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES "Salon" ("id");
+
+ALTER TABLE "Quote"
+	ADD COLUMN "salonId" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES "Salon" ("id");
+
+ALTER TABLE "Appointment"
+	ADD COLUMN "salonId" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES "Salon" ("id");
+
+CREATE INDEX "users_salon" ON "users" ("salonId");
+CREATE INDEX "type_of_service_salon" ON "TypeOfService" ("salonId");
+CREATE INDEX "availability_slot_salon" ON "AvailabilitySlot" ("salonId", "startTime");
+CREATE INDEX "quote_salon" ON "Quote" ("salonId", "time");
+CREATE INDEX "appointment_salon" ON "Appointment" ("salonId");
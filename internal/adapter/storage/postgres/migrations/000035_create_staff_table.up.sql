@@ -0,0 +1,18 @@
+CREATE TABLE "Staff" (
+	"id" UUID NOT NULL UNIQUE,
+	"userId" UUID NOT NULL UNIQUE,
+	"salonId" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES "Salon" ("id"),
+	"displayName" TEXT NOT NULL,
+	"bio" TEXT NOT NULL DEFAULT '',
+	"specialties" TEXT[] NOT NULL DEFAULT '{}',
+	"photoUrl" TEXT,
+	"active" BOOLEAN NOT NULL DEFAULT TRUE,
+	"createdAt" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	"updatedAt" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY("id"),
+	-- The profile is the admin's, its slots and service offerings hang from the same account
+	FOREIGN KEY("userId") REFERENCES "users"("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX "staff_salon" ON "Staff" ("salonId", "displayName");
+CREATE INDEX "staff_specialties" ON "Staff" USING GIN ("specialties");
@@ -0,0 +1,7 @@
+ALTER TABLE "Quote"
+	DROP COLUMN IF EXISTS "couponDiscount",
+	DROP COLUMN IF EXISTS "couponId";
+
+DROP TABLE IF EXISTS "CouponRedemption";
+DROP TABLE IF EXISTS "CouponTypeOfService";
+DROP TABLE IF EXISTS "Coupon";
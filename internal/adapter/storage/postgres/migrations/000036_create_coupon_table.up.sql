@@ -0,0 +1,46 @@
+CREATE TABLE "Coupon" (
+	"id" UUID NOT NULL UNIQUE,
+	"salonId" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES "Salon" ("id"),
+	"code" TEXT NOT NULL,
+	"discountType" promotion_discount_type_enum NOT NULL,
+	"value" REAL NOT NULL CHECK ("value" > 0),
+	"currency" CHAR(3),
+	"startsAt" TIMESTAMPTZ NOT NULL,
+	"endsAt" TIMESTAMPTZ NOT NULL,
+	"maxRedemptions" INTEGER NOT NULL DEFAULT 0 CHECK ("maxRedemptions" >= 0),
+	"maxRedemptionsPerClient" INTEGER NOT NULL DEFAULT 0 CHECK ("maxRedemptionsPerClient" >= 0),
+	PRIMARY KEY("id"),
+	-- Each salon hands out its own codes
+	UNIQUE("salonId", "code"),
+	CHECK ("code" = UPPER("code")),
+	CHECK ("startsAt" < "endsAt"),
+	CHECK ("discountType" <> 'percentage' OR "value" <= 100),
+	CHECK ("discountType" <> 'fixed' OR "currency" IS NOT NULL)
+);
+
+CREATE TABLE "CouponTypeOfService" (
+	"couponId" UUID NOT NULL,
+	"typeOfServiceId" UUID NOT NULL,
+	PRIMARY KEY("couponId", "typeOfServiceId"),
+	FOREIGN KEY("couponId") REFERENCES "Coupon"("id") ON UPDATE CASCADE ON DELETE CASCADE,
+	FOREIGN KEY("typeOfServiceId") REFERENCES "TypeOfService"("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE TABLE "CouponRedemption" (
+	"id" UUID NOT NULL UNIQUE,
+	"couponId" UUID NOT NULL,
+	"quoteId" UUID NOT NULL UNIQUE,
+	"clientId" UUID NOT NULL,
+	"redeemedAt" TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+	PRIMARY KEY("id"),
+	FOREIGN KEY("couponId") REFERENCES "Coupon"("id") ON UPDATE CASCADE ON DELETE CASCADE,
+	FOREIGN KEY("quoteId") REFERENCES "Quote"("id") ON UPDATE CASCADE ON DELETE CASCADE,
+	FOREIGN KEY("clientId") REFERENCES "users"("id") ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX "coupon_redemption_client" ON "CouponRedemption" ("couponId", "clientId");
+
+ALTER TABLE "Quote"
+	ADD COLUMN "couponId" UUID,
+	ADD COLUMN "couponDiscount" REAL NOT NULL DEFAULT 0,
+	ADD FOREIGN KEY("couponId") REFERENCES "Coupon"("id") ON UPDATE CASCADE ON DELETE SET NULL;
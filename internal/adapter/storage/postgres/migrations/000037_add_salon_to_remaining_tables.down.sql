@@ -0,0 +1,23 @@
+DROP INDEX IF EXISTS "event_salon";
+DROP INDEX IF EXISTS "promotion_salon";
+DROP INDEX IF EXISTS "email_log_salon";
+DROP INDEX IF EXISTS "audit_entry_salon";
+DROP INDEX IF EXISTS "audit_log_salon";
+DROP INDEX IF EXISTS "webhook_salon";
+
+ALTER TABLE "ServiceOffering" DROP COLUMN IF EXISTS "salonId";
+ALTER TABLE "TypeOfServiceImages" DROP COLUMN IF EXISTS "salonId";
+ALTER TABLE "PaymentEvent" DROP COLUMN IF EXISTS "salonId";
+ALTER TABLE "Device" DROP COLUMN IF EXISTS "salonId";
+ALTER TABLE "Notification" DROP COLUMN IF EXISTS "salonId";
+ALTER TABLE "Outbox" DROP COLUMN IF EXISTS "salonId";
+ALTER TABLE "Event" DROP COLUMN IF EXISTS "salonId";
+
+ALTER TABLE "ServiceCategory" DROP COLUMN IF EXISTS "salonId";
+ALTER TABLE "ServiceCategory" ADD CONSTRAINT "ServiceCategory_name_key" UNIQUE ("name");
+
+ALTER TABLE "Promotion" DROP COLUMN IF EXISTS "salonId";
+ALTER TABLE "EmailLog" DROP COLUMN IF EXISTS "salonId";
+ALTER TABLE "AuditEntry" DROP COLUMN IF EXISTS "salonId";
+ALTER TABLE "AuditLog" DROP COLUMN IF EXISTS "salonId";
+ALTER TABLE "Webhook" DROP COLUMN IF EXISTS "salonId";
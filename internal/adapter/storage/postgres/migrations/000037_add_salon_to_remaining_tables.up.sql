@@ -0,0 +1,68 @@
+-- The rows already there belong to the default salon, unless they hang from a row of another one
+ALTER TABLE "Webhook"
+	ADD COLUMN "salonId" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES "Salon" ("id");
+
+ALTER TABLE "AuditLog"
+	ADD COLUMN "salonId" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES "Salon" ("id");
+
+ALTER TABLE "AuditEntry"
+	ADD COLUMN "salonId" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES "Salon" ("id");
+
+ALTER TABLE "EmailLog"
+	ADD COLUMN "salonId" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES "Salon" ("id");
+
+ALTER TABLE "Promotion"
+	ADD COLUMN "salonId" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES "Salon" ("id");
+
+ALTER TABLE "ServiceCategory"
+	ADD COLUMN "salonId" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES "Salon" ("id"),
+	DROP CONSTRAINT "ServiceCategory_name_key",
+	ADD UNIQUE ("salonId", "name");
+
+ALTER TABLE "Event"
+	ADD COLUMN "salonId" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES "Salon" ("id");
+
+ALTER TABLE "Outbox"
+	ADD COLUMN "salonId" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES "Salon" ("id");
+
+ALTER TABLE "Notification"
+	ADD COLUMN "salonId" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES "Salon" ("id");
+
+UPDATE "Notification" SET "salonId" = u."salonId"
+FROM "users" u
+WHERE u."id" = "Notification"."userId";
+
+ALTER TABLE "Device"
+	ADD COLUMN "salonId" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES "Salon" ("id");
+
+UPDATE "Device" SET "salonId" = u."salonId"
+FROM "users" u
+WHERE u."id" = "Device"."userId";
+
+ALTER TABLE "PaymentEvent"
+	ADD COLUMN "salonId" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES "Salon" ("id");
+
+UPDATE "PaymentEvent" SET "salonId" = q."salonId"
+FROM "Quote" q
+WHERE q."id" = "PaymentEvent"."quoteId";
+
+ALTER TABLE "TypeOfServiceImages"
+	ADD COLUMN "salonId" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES "Salon" ("id");
+
+UPDATE "TypeOfServiceImages" SET "salonId" = t."salonId"
+FROM "TypeOfService" t
+WHERE t."id" = "TypeOfServiceImages"."typeOfServiceId";
+
+ALTER TABLE "ServiceOffering"
+	ADD COLUMN "salonId" UUID NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES "Salon" ("id");
+
+UPDATE "ServiceOffering" SET "salonId" = t."salonId"
+FROM "TypeOfService" t
+WHERE t."id" = "ServiceOffering"."typeOfServiceId";
+
+CREATE INDEX "webhook_salon" ON "Webhook" ("salonId");
+CREATE INDEX "audit_log_salon" ON "AuditLog" ("salonId", "createdAt" DESC);
+CREATE INDEX "audit_entry_salon" ON "AuditEntry" ("salonId", "createdAt" DESC);
+CREATE INDEX "email_log_salon" ON "EmailLog" ("salonId", "createdAt" DESC);
+CREATE INDEX "promotion_salon" ON "Promotion" ("salonId", "startsAt", "endsAt");
+CREATE INDEX "event_salon" ON "Event" ("salonId", "occurredAt");
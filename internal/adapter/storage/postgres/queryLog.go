@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+)
+
+// queryLog is how the queries are logged, their args hold names, emails and phone numbers so only their
+// types are logged unless args is set
+type queryLog struct {
+	// sampleRatio is the fraction of the queries logged at the debug level
+	sampleRatio float64
+	args        bool
+}
+
+// LogQuery logs sql and its args at the debug level, for a sample of the queries. Nothing is built when the
+// debug level is disabled, so the call costs next to nothing in production
+func (db *DB) LogQuery(ctx context.Context, sql string, args []any) {
+	if !slog.Default().Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	if db.queryLog.sampleRatio < 1 && rand.Float64() >= db.queryLog.sampleRatio {
+		return
+	}
+
+	slog.DebugContext(ctx, "Executing query", "sql", sql, "args", db.queryLog.redact(args))
+}
+
+// redact returns args as they are when their values are logged, or their types otherwise
+func (l queryLog) redact(args []any) []any {
+	if l.args {
+		return args
+	}
+
+	redacted := make([]any, len(args))
+	for i, arg := range args {
+		if arg == nil {
+			redacted[i] = "NULL"
+			continue
+		}
+		redacted[i] = fmt.Sprintf("<%T>", arg)
+	}
+
+	return redacted
+}
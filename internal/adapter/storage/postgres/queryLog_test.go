@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogQuery(t *testing.T) {
+	var logs bytes.Buffer
+	level := new(slog.LevelVar)
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: level})))
+
+	sql := `SELECT "id" FROM "User" WHERE "email" = $1 AND "id" = $2 AND "deletedAt" IS $3`
+	args := []any{"ana@harajuku.mx", uuid.New(), nil}
+
+	t.Run("redacts the args", func(t *testing.T) {
+		logs.Reset()
+		level.Set(slog.LevelDebug)
+		db := &DB{queryLog: queryLog{sampleRatio: 1}}
+
+		db.LogQuery(context.Background(), sql, args)
+		assert.Contains(t, logs.String(), "Executing query")
+		assert.Contains(t, logs.String(), "[<string> <uuid.UUID> NULL]")
+		assert.NotContains(t, logs.String(), "ana@harajuku.mx")
+	})
+
+	t.Run("logs the values when asked to", func(t *testing.T) {
+		logs.Reset()
+		db := &DB{queryLog: queryLog{sampleRatio: 1, args: true}}
+
+		db.LogQuery(context.Background(), sql, args)
+		assert.Contains(t, logs.String(), "ana@harajuku.mx")
+	})
+
+	t.Run("samples the queries", func(t *testing.T) {
+		logs.Reset()
+		db := &DB{queryLog: queryLog{sampleRatio: 0.5}}
+
+		for range 1000 {
+			db.LogQuery(context.Background(), sql, args)
+		}
+		logged := strings.Count(logs.String(), "Executing query")
+		assert.Greater(t, logged, 350)
+		assert.Less(t, logged, 650)
+	})
+
+	t.Run("logs nothing above the debug level", func(t *testing.T) {
+		logs.Reset()
+		level.Set(slog.LevelInfo)
+		db := &DB{queryLog: queryLog{sampleRatio: 1}}
+
+		db.LogQuery(context.Background(), sql, args)
+		assert.Empty(t, logs.String())
+	})
+}
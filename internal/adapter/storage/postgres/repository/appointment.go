@@ -6,7 +6,8 @@ import (
 	"harajuku/backend/internal/adapter/storage/postgres"
 	"harajuku/backend/internal/core/domain"
 	"harajuku/backend/internal/core/port"
-	"log"
+	"harajuku/backend/internal/core/util"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
@@ -26,17 +27,19 @@ func NewAppointmentRepository(db *postgres.DB) *AppointmentRepository {
 
 // CreateAppointment crea un nuevo availability appointment en la base de datos
 func (r *AppointmentRepository) CreateAppointment(ctx context.Context, appointment *domain.Appointment) (*domain.Appointment, error) {
+	appointment.SalonID = util.SalonOr(ctx, appointment.SalonID)
+
 	query := r.db.QueryBuilder.Insert("\"Appointment\""). // Ajustar el nombre de la tabla si es necesario
-								Columns("id", "\"clientId\"", "\"slotId\"", "\"quoteId\"", "\"status\"").
-								Values(appointment.ID, appointment.UserID, appointment.SlotID, appointment.QuoteID, appointment.Status).
-								Suffix("RETURNING id")
+								Columns("id", "\"clientId\"", "\"slotId\"", "\"quoteId\"", "\"status\"", "\"salonId\"").
+								Values(appointment.ID, appointment.UserID, appointment.SlotID, appointment.QuoteID, appointment.Status, appointment.SalonID).
+								Suffix(`RETURNING id, "version"`)
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&appointment.ID)
+	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&appointment.ID, &appointment.Version)
 	if err != nil {
 		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == "23505" {
 			// You might also inspect pgErr.Constraint to be extra sure it’s the quoteId constraint
@@ -52,17 +55,21 @@ func (r *AppointmentRepository) CreateAppointment(ctx context.Context, appointme
 func (r *AppointmentRepository) GetAppointmentByID(ctx context.Context, id uuid.UUID) (*domain.Appointment, error) {
 	var appointment domain.Appointment
 
-	query := r.db.QueryBuilder.Select("id", "\"clientId\"", "\"slotId\"", "\"quoteId\"", "\"status\"").
+	query := r.db.QueryBuilder.Select("id", "\"clientId\"", "\"slotId\"", "\"quoteId\"", "\"status\"", "\"version\"", "\"salonId\"").
 		From("\"Appointment\"").
 		Where(sq.Eq{"id": id}).
+		Where(inSalon(ctx, `"salonId"`)).
 		Limit(1)
 
+	query = notDeleted(query, `"Appointment"`, false)
+
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&appointment.ID, &appointment.UserID, &appointment.SlotID, &appointment.QuoteID, &appointment.Status)
+	// Read from the primary, the appointment services check this version before they write
+	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&appointment.ID, &appointment.UserID, &appointment.SlotID, &appointment.QuoteID, &appointment.Status, &appointment.Version, &appointment.SalonID)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, domain.ErrDataNotFound
@@ -73,10 +80,13 @@ func (r *AppointmentRepository) GetAppointmentByID(ctx context.Context, id uuid.
 	return &appointment, nil
 }
 
-// ListAppointments obtiene una lista de availability appointments de la base de datos
-func (r *AppointmentRepository) ListAppointments(ctx context.Context, filter port.AppointmentFilter) ([]domain.Appointment, error) {
-	log.Printf("Iniciando ListAppointments con filtro: %+v", filter)
+// appointmentKeyset ordena las citas por la hora de inicio de su slot
+var appointmentKeyset = keyset{`"AvailabilitySlot"."startTime"`, `"Appointment"."id"`}
+
+// ListAppointments obtiene una página de appointments de la base de datos y el cursor de la siguiente
+func (r *AppointmentRepository) ListAppointments(ctx context.Context, filter port.AppointmentFilter) ([]domain.Appointment, *domain.Cursor, error) {
 	var appointments []domain.Appointment
+	var lastStart time.Time
 
 	query := r.db.QueryBuilder.
 		Select(
@@ -85,52 +95,27 @@ func (r *AppointmentRepository) ListAppointments(ctx context.Context, filter por
 			`"Appointment"."slotId"`,
 			`"Appointment"."quoteId"`,
 			`"Appointment"."status"`,
+			`"Appointment"."deletedAt"`,
+			`"Appointment"."version"`,
+			`"Appointment"."salonId"`,
+			`"AvailabilitySlot"."startTime"`,
 		).
 		From(`"Appointment"`).
 		Join(`"AvailabilitySlot" ON "Appointment"."slotId" = "AvailabilitySlot"."id"`)
 
-	// Filter by Customer ID (Appointment.clientId)
-	if filter.CustomerID != nil {
-		query = query.Where(sq.Eq{"Appointment.clientId": *filter.CustomerID})
-	}
-
-	// Filter by Quote ID
-	if filter.QuoteID != nil {
-		query = query.Where(sq.Eq{"Appointment.quoteId": *filter.QuoteID})
-	}
-
-	// Filter by Appointment status
-	if filter.ByState != nil {
-		query = query.Where(sq.Eq{"Appointment.status": *filter.ByState})
-	}
-
-	// Filter by AvailabilitySlot.startTime
-	if filter.StartDate != nil {
-		query = query.Where(sq.GtOrEq{`"AvailabilitySlot"."startTime"`: *filter.StartDate})
-	}
-
-	if filter.EndDate != nil {
-		query = query.Where(sq.LtOrEq{`"AvailabilitySlot"."startTime"`: *filter.EndDate})
-	}
-
-	// Paginación (skip = número de página - 1)
-	if filter.Limit > 0 {
-		offset := ((filter.Skip - 1) * filter.Limit)
-		log.Printf("Configurando paginación - Limit: %d, Offset: %d", filter.Limit, offset)
-		query = query.Limit(filter.Limit).Offset(offset)
-	}
+	query = applyAppointmentFilter(ctx, query, filter)
+	query = appointmentKeyset.paginate(query, filter.After, filter.Skip, filter.Limit)
 
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return nil, fmt.Errorf("error building query: %w", err)
+		return nil, nil, fmt.Errorf("error building query: %w", err)
 	}
 
-	log.Printf("SQL generado: %s", sql)
-	log.Printf("Parámetros SQL: %v", args)
+	r.db.LogQuery(ctx, sql, args)
 
-	rows, err := r.db.Conn.Query(ctx, sql, args...)
+	rows, err := r.db.Reader().Query(ctx, sql, args...)
 	if err != nil {
-		return nil, fmt.Errorf("error al ejecutar consulta: %w", err)
+		return nil, nil, fmt.Errorf("error al ejecutar consulta: %w", err)
 	}
 	defer rows.Close()
 
@@ -142,46 +127,124 @@ func (r *AppointmentRepository) ListAppointments(ctx context.Context, filter por
 			&appointment.SlotID,
 			&appointment.QuoteID,
 			&appointment.Status,
+			&appointment.DeletedAt,
+			&appointment.Version,
+			&appointment.SalonID,
+			&lastStart,
 		); err != nil {
-			return nil, fmt.Errorf("Error while reading data: %w", err)
+			return nil, nil, fmt.Errorf("Error while reading data: %w", err)
 		}
 		appointments = append(appointments, appointment)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("Error while processing results: %w", err)
+		return nil, nil, fmt.Errorf("Error while processing results: %w", err)
+	}
+
+	// Las citas no guardan la hora de su slot, el cursor toma la de la última fila leída
+	next := nextCursor(appointments, filter.Limit, func(a domain.Appointment) domain.Cursor {
+		return domain.Cursor{Time: lastStart, ID: a.ID}
+	})
+
+	return appointments, next, nil
+}
+
+// CountAppointments counts the appointments matching the filter, ignoring pagination
+func (r *AppointmentRepository) CountAppointments(ctx context.Context, filter port.AppointmentFilter) (uint64, error) {
+	query := r.db.QueryBuilder.Select(`"Appointment"."id"`).
+		From(`"Appointment"`).
+		Join(`"AvailabilitySlot" ON "Appointment"."slotId" = "AvailabilitySlot"."id"`)
+
+	return countRows(ctx, r.db.Reader(), applyAppointmentFilter(ctx, query, filter))
+}
+
+// applyAppointmentFilter adds the conditions shared by the listing and its count
+func applyAppointmentFilter(ctx context.Context, query sq.SelectBuilder, filter port.AppointmentFilter) sq.SelectBuilder {
+	query = notDeleted(query, `"Appointment"`, filter.IncludeDeleted).
+		Where(inSalon(ctx, `"Appointment"."salonId"`))
+
+	// Filter by Customer ID (Appointment.clientId)
+	if filter.CustomerID != nil {
+		query = query.Where(sq.Eq{"Appointment.clientId": *filter.CustomerID})
+	}
+
+	// Filter by Quote ID
+	if filter.QuoteID != nil {
+		query = query.Where(sq.Eq{"Appointment.quoteId": *filter.QuoteID})
+	}
+
+	// Filter by AvailabilitySlot ID
+	if filter.SlotID != nil {
+		query = query.Where(sq.Eq{`"Appointment"."slotId"`: *filter.SlotID})
+	}
+
+	// Filter by Appointment status
+	if filter.ByState != nil {
+		query = query.Where(sq.Eq{"Appointment.status": *filter.ByState})
+	}
+
+	// Filter by AvailabilitySlot.startTime
+	if filter.StartDate != nil {
+		query = query.Where(sq.GtOrEq{`"AvailabilitySlot"."startTime"`: *filter.StartDate})
 	}
 
-	return appointments, nil
+	if filter.EndDate != nil {
+		query = query.Where(sq.LtOrEq{`"AvailabilitySlot"."startTime"`: *filter.EndDate})
+	}
+
+	return query
+}
+
+// appointmentUpdate contiene las columnas que cambia UpdateAppointment, los campos nil no se modifican
+type appointmentUpdate struct {
+	UserID  *uuid.UUID                `db:"clientId"`
+	SlotID  *uuid.UUID                `db:"slotId"`
+	QuoteID *uuid.UUID                `db:"quoteId"`
+	Status  *domain.AppointmentStatus `db:"status"`
 }
 
 // UpdateAppointment actualiza un availability appointment existente en la base de datos
 func (r *AppointmentRepository) UpdateAppointment(ctx context.Context, appointment *domain.Appointment) (*domain.Appointment, error) {
-	query := r.db.QueryBuilder.Update("\"Appointment\"").
-		Set("\"clientId\"", appointment.UserID).
-		Set("\"slotId\"", appointment.SlotID).
-		Set("\"quoteId\"", appointment.QuoteID).
-		Set("\"status\"", appointment.Status).
+	query := setPartial(r.db.QueryBuilder.Update("\"Appointment\""), appointmentUpdate{
+		UserID:  optional(appointment.UserID),
+		SlotID:  optional(appointment.SlotID),
+		QuoteID: optional(appointment.QuoteID),
+		Status:  optional(appointment.Status),
+	}).
 		Where(sq.Eq{"id": appointment.ID}).
-		Suffix("RETURNING *")
+		Where(inSalon(ctx, `"salonId"`)).
+		Suffix(`RETURNING id, "clientId", "slotId", "quoteId", "status", "version", "salonId"`)
+
+	query = lockVersion(query, appointment.Version)
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&appointment.ID, &appointment.UserID, &appointment.SlotID, &appointment.QuoteID, &appointment.Status)
+	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&appointment.ID, &appointment.UserID, &appointment.SlotID, &appointment.QuoteID, &appointment.Status, &appointment.Version, &appointment.SalonID)
 	if err != nil {
+		// La cita cambió desde que se leyó
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrConflictingData
+		}
 		return nil, err
 	}
 
 	return appointment, nil
 }
 
-// DeleteAppointment elimina un availability appointment por ID desde la base de datos
+// DeleteAppointment elimina lógicamente un appointment por ID, los admins aún pueden listarlo
 func (r *AppointmentRepository) DeleteAppointment(ctx context.Context, id uuid.UUID) error {
-	query := r.db.QueryBuilder.Delete("\"Appointment\"").
-		Where(sq.Eq{"id": id})
+	return softDelete(ctx, r.db, `"Appointment"`, id)
+}
+
+func (r *AppointmentRepository) MarkAsBookedByQuoteID(ctx context.Context, quoteID uuid.UUID) error {
+	query := r.db.QueryBuilder.
+		Update("\"Appointment\"").
+		Set("status", "booked").
+		Set(`"version"`, sq.Expr(`"version" + 1`)).
+		Where(sq.Eq{"\"quoteId\"": quoteID})
 
 	sql, args, err := query.ToSql()
 	if err != nil {
@@ -189,18 +252,66 @@ func (r *AppointmentRepository) DeleteAppointment(ctx context.Context, id uuid.U
 	}
 
 	_, err = r.db.Conn.Exec(ctx, sql, args...)
+	return err
+}
+
+// ListDueReminders obtiene las citas reservadas que empiezan antes de until y cuyo recordatorio no se ha enviado
+func (r *AppointmentRepository) ListDueReminders(ctx context.Context, until time.Time) ([]domain.Appointment, error) {
+	var appointments []domain.Appointment
+
+	query := r.db.QueryBuilder.
+		Select(
+			`"Appointment"."id"`,
+			`"Appointment"."clientId"`,
+			`"Appointment"."slotId"`,
+			`"Appointment"."quoteId"`,
+			`"Appointment"."status"`,
+			`"Appointment"."salonId"`,
+		).
+		From(`"Appointment"`).
+		Join(`"AvailabilitySlot" ON "Appointment"."slotId" = "AvailabilitySlot"."id"`).
+		Where(sq.Eq{`"Appointment"."status"`: domain.Booked}).
+		Where(sq.Eq{`"Appointment"."reminderSentAt"`: nil}).
+		Where(sq.Eq{`"Appointment"."deletedAt"`: nil}).
+		Where(sq.Gt{`"AvailabilitySlot"."startTime"`: time.Now()}).
+		Where(sq.LtOrEq{`"AvailabilitySlot"."startTime"`: until}).
+		OrderBy(`"AvailabilitySlot"."startTime"`)
+
+	sql, args, err := query.ToSql()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	rows, err := r.db.Conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var appointment domain.Appointment
+		if err := rows.Scan(
+			&appointment.ID,
+			&appointment.UserID,
+			&appointment.SlotID,
+			&appointment.QuoteID,
+			&appointment.Status,
+			&appointment.SalonID,
+		); err != nil {
+			return nil, err
+		}
+		appointments = append(appointments, appointment)
 	}
 
-	return nil
+	return appointments, rows.Err()
 }
 
-func (r *AppointmentRepository) MarkAsBookedByQuoteID(ctx context.Context, quoteID uuid.UUID) error {
+// MarkReminderSent registra que el recordatorio de la cita se envió
+func (r *AppointmentRepository) MarkReminderSent(ctx context.Context, id uuid.UUID, at time.Time) error {
 	query := r.db.QueryBuilder.
-		Update("\"Appointment\"").
-		Set("status", "booked").
-		Where(sq.Eq{"\"quoteId\"": quoteID})
+		Update(`"Appointment"`).
+		Set(`"reminderSentAt"`, at).
+		Where(sq.Eq{"id": id})
 
 	sql, args, err := query.ToSql()
 	if err != nil {
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// AuditRepository implements port.AuditRepository interface and provides access to the postgres database
+type AuditRepository struct {
+	db *postgres.DB
+}
+
+// NewAuditRepository creates a new audit repository instance
+func NewAuditRepository(db *postgres.DB) *AuditRepository {
+	return &AuditRepository{
+		db,
+	}
+}
+
+// auditEntryColumns are the columns selected for an audit entry, in scan order
+var auditEntryColumns = []string{
+	"id",
+	"entity",
+	"\"entityId\"",
+	"action",
+	"\"actorId\"",
+	"COALESCE(\"actorRole\", '')",
+	"before",
+	"after",
+	"\"createdAt\"",
+	"\"salonId\"",
+}
+
+// scanAuditEntry scans a row selected with auditEntryColumns
+func scanAuditEntry(row pgx.Row, e *domain.AuditEntry) error {
+	return row.Scan(
+		&e.ID,
+		&e.Entity,
+		&e.EntityID,
+		&e.Action,
+		&e.ActorID,
+		&e.ActorRole,
+		&e.Before,
+		&e.After,
+		&e.CreatedAt,
+		&e.SalonID,
+	)
+}
+
+// CreateAuditEntry inserts an audited change into the database
+func (r *AuditRepository) CreateAuditEntry(ctx context.Context, entry *domain.AuditEntry) error {
+	entry.SalonID = util.SalonOr(ctx, entry.SalonID)
+
+	query := r.db.QueryBuilder.Insert("\"AuditEntry\"").
+		Columns("id", "entity", "\"entityId\"", "action", "\"actorId\"", "\"actorRole\"", "before", "after", "\"createdAt\"", "\"salonId\"").
+		Values(
+			entry.ID,
+			entry.Entity,
+			entry.EntityID,
+			entry.Action,
+			entry.ActorID,
+			nullString(string(entry.ActorRole)),
+			entry.Before,
+			entry.After,
+			entry.CreatedAt,
+			entry.SalonID,
+		)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Conn.Exec(ctx, sql, args...)
+	return err
+}
+
+// ListAuditEntries selects the audited changes, newest first
+func (r *AuditRepository) ListAuditEntries(ctx context.Context, filter port.AuditEntryFilter) ([]domain.AuditEntry, error) {
+	var entries []domain.AuditEntry
+
+	query := applyAuditEntryFilter(ctx, r.db.QueryBuilder.Select(auditEntryColumns...).From("\"AuditEntry\""), filter).
+		OrderBy("\"createdAt\" DESC", "id")
+
+	// Paginación (skip = número de página)
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit).Offset(pageOffset(filter.Skip, filter.Limit))
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	r.db.LogQuery(ctx, sql, args)
+
+	rows, err := r.db.Reader().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e domain.AuditEntry
+		if err := scanAuditEntry(rows, &e); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// CountAuditEntries counts the audited changes matching the filter, ignoring pagination
+func (r *AuditRepository) CountAuditEntries(ctx context.Context, filter port.AuditEntryFilter) (uint64, error) {
+	query := r.db.QueryBuilder.Select("id").From("\"AuditEntry\"")
+
+	return countRows(ctx, r.db.Reader(), applyAuditEntryFilter(ctx, query, filter))
+}
+
+// applyAuditEntryFilter adds the conditions shared by the listing and its count
+func applyAuditEntryFilter(ctx context.Context, query sq.SelectBuilder, filter port.AuditEntryFilter) sq.SelectBuilder {
+	query = query.Where(inSalon(ctx, "\"salonId\""))
+
+	if filter.Entity != nil {
+		query = query.Where(sq.Eq{"entity": *filter.Entity})
+	}
+	if filter.EntityID != nil {
+		query = query.Where(sq.Eq{"\"entityId\"": *filter.EntityID})
+	}
+	if filter.Action != nil {
+		query = query.Where(sq.Eq{"action": *filter.Action})
+	}
+	if filter.ActorID != nil {
+		query = query.Where(sq.Eq{"\"actorId\"": *filter.ActorID})
+	}
+	if filter.StartDate != nil {
+		query = query.Where(sq.GtOrEq{"\"createdAt\"": *filter.StartDate})
+	}
+	if filter.EndDate != nil {
+		query = query.Where(sq.Lt{"\"createdAt\"": *filter.EndDate})
+	}
+
+	return query
+}
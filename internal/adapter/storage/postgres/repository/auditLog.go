@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// AuditLogRepository implements port.AuditLogRepository interface and provides access to the postgres database
+type AuditLogRepository struct {
+	db *postgres.DB
+}
+
+// NewAuditLogRepository creates a new audit log repository instance
+func NewAuditLogRepository(db *postgres.DB) *AuditLogRepository {
+	return &AuditLogRepository{
+		db,
+	}
+}
+
+// auditLogColumns are the columns selected for an audit log, in scan order
+var auditLogColumns = []string{
+	"id",
+	"\"actorId\"",
+	"\"actorRole\"",
+	"method",
+	"route",
+	"path",
+	"\"targetId\"",
+	"status",
+	"changes",
+	"COALESCE(\"requestId\", '')",
+	"\"createdAt\"",
+	"\"salonId\"",
+}
+
+// scanAuditLog scans a row selected with auditLogColumns
+func scanAuditLog(row pgx.Row, l *domain.AuditLog) error {
+	return row.Scan(
+		&l.ID,
+		&l.ActorID,
+		&l.ActorRole,
+		&l.Method,
+		&l.Route,
+		&l.Path,
+		&l.TargetID,
+		&l.Status,
+		&l.Changes,
+		&l.RequestID,
+		&l.CreatedAt,
+		&l.SalonID,
+	)
+}
+
+// CreateAuditLog inserts an audited request into the database
+func (r *AuditLogRepository) CreateAuditLog(ctx context.Context, log *domain.AuditLog) error {
+	log.SalonID = util.SalonOr(ctx, log.SalonID)
+
+	query := r.db.QueryBuilder.Insert("\"AuditLog\"").
+		Columns("id", "\"actorId\"", "\"actorRole\"", "method", "route", "path", "\"targetId\"", "status", "changes", "\"requestId\"", "\"createdAt\"", "\"salonId\"").
+		Values(
+			log.ID,
+			log.ActorID,
+			log.ActorRole,
+			log.Method,
+			log.Route,
+			log.Path,
+			log.TargetID,
+			log.Status,
+			log.Changes,
+			nullString(log.RequestID),
+			log.CreatedAt,
+			log.SalonID,
+		)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Conn.Exec(ctx, sql, args...)
+	return err
+}
+
+// ListAuditLogs selects the audited requests, newest first
+func (r *AuditLogRepository) ListAuditLogs(ctx context.Context, filter port.AuditLogFilter) ([]domain.AuditLog, error) {
+	var logs []domain.AuditLog
+
+	query := r.db.QueryBuilder.Select(auditLogColumns...).
+		From("\"AuditLog\"").
+		Where(inSalon(ctx, "\"salonId\"")).
+		OrderBy("\"createdAt\" DESC", "id")
+
+	if filter.ActorID != nil {
+		query = query.Where(sq.Eq{"\"actorId\"": *filter.ActorID})
+	}
+	if filter.TargetID != nil {
+		query = query.Where(sq.Eq{"\"targetId\"": *filter.TargetID})
+	}
+	if filter.Method != "" {
+		query = query.Where(sq.Eq{"method": filter.Method})
+	}
+	if filter.Route != "" {
+		query = query.Where(sq.Eq{"route": filter.Route})
+	}
+
+	// Paginación (skip = número de página)
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit).Offset(pageOffset(filter.Skip, filter.Limit))
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	r.db.LogQuery(ctx, sql, args)
+
+	rows, err := r.db.Reader().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l domain.AuditLog
+		if err := scanAuditLog(rows, &l); err != nil {
+			return nil, err
+		}
+		logs = append(logs, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
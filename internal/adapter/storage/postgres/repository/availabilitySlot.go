@@ -6,7 +6,9 @@ import (
 	"harajuku/backend/internal/adapter/storage/postgres"
 	"harajuku/backend/internal/core/domain"
 	"harajuku/backend/internal/core/port"
-	"log"
+	"harajuku/backend/internal/core/util"
+	"log/slog"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
@@ -25,17 +27,19 @@ func NewAvailabilitySlotRepository(db *postgres.DB) *AvailabilitySlotRepository
 
 // CreateAvailabilitySlot crea un nuevo availability slot en la base de datos
 func (r *AvailabilitySlotRepository) CreateAvailabilitySlot(ctx context.Context, slot *domain.AvailabilitySlot) (*domain.AvailabilitySlot, error) {
+	slot.SalonID = util.SalonOr(ctx, slot.SalonID)
+
 	query := r.db.QueryBuilder.Insert("\"AvailabilitySlot\""). // Ajustar el nombre de la tabla si es necesario
-									Columns("id", "\"adminId\"", "\"startTime\"", "\"endTime\"", "\"isBooked\"").
-									Values(slot.ID, slot.AdminID, slot.StartTime, slot.EndTime, slot.IsBooked).
-									Suffix("RETURNING id")
+									Columns("id", "\"adminId\"", "\"startTime\"", "\"endTime\"", "\"isBooked\"", "\"salonId\"").
+									Values(slot.ID, slot.AdminID, slot.StartTime, slot.EndTime, slot.IsBooked, slot.SalonID).
+									Suffix(`RETURNING id, "version"`)
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&slot.ID)
+	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&slot.ID, &slot.Version)
 	if err != nil {
 		return nil, err
 	}
@@ -47,17 +51,21 @@ func (r *AvailabilitySlotRepository) CreateAvailabilitySlot(ctx context.Context,
 func (r *AvailabilitySlotRepository) GetAvailabilitySlotByID(ctx context.Context, id uuid.UUID) (*domain.AvailabilitySlot, error) {
 	var slot domain.AvailabilitySlot
 
-	query := r.db.QueryBuilder.Select("id", "\"adminId\"", "\"startTime\"", "\"endTime\"", "\"isBooked\"").
+	query := r.db.QueryBuilder.Select("id", "\"adminId\"", "\"startTime\"", "\"endTime\"", "\"isBooked\"", "\"version\"", "\"salonId\"").
 		From("\"AvailabilitySlot\"").
 		Where(sq.Eq{"id": id}).
+		Where(inSalon(ctx, `"salonId"`)).
 		Limit(1)
 
+	query = notDeleted(query, `"AvailabilitySlot"`, false)
+
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&slot.ID, &slot.AdminID, &slot.StartTime, &slot.EndTime, &slot.IsBooked)
+	// Booking reads the slot before reserving it, so a lagging replica must not answer
+	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&slot.ID, &slot.AdminID, &slot.StartTime, &slot.EndTime, &slot.IsBooked, &slot.Version, &slot.SalonID)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, domain.ErrDataNotFound
@@ -68,9 +76,11 @@ func (r *AvailabilitySlotRepository) GetAvailabilitySlotByID(ctx context.Context
 	return &slot, nil
 }
 
-// ListAvailabilitySlots obtiene una lista de availability slots de la base de datos
-func (r *AvailabilitySlotRepository) ListAvailabilitySlots(ctx context.Context, filter port.AvailabilitySlotFilter) ([]domain.AvailabilitySlot, error) {
-	log.Printf("Iniciando ListAvailabilitySlots con filtro: %+v", filter)
+// availabilitySlotKeyset ordena los slots por su hora de inicio
+var availabilitySlotKeyset = keyset{`"AvailabilitySlot"."startTime"`, `"AvailabilitySlot"."id"`}
+
+// ListAvailabilitySlots obtiene una página de availability slots de la base de datos y el cursor de la siguiente
+func (r *AvailabilitySlotRepository) ListAvailabilitySlots(ctx context.Context, filter port.AvailabilitySlotFilter) ([]domain.AvailabilitySlot, *domain.Cursor, error) {
 	var slots []domain.AvailabilitySlot
 
 	query := r.db.QueryBuilder.
@@ -80,19 +90,70 @@ func (r *AvailabilitySlotRepository) ListAvailabilitySlots(ctx context.Context,
 			`"AvailabilitySlot"."startTime"`,
 			`"AvailabilitySlot"."endTime"`,
 			`"AvailabilitySlot"."isBooked"`,
+			`"AvailabilitySlot"."deletedAt"`,
+			`"AvailabilitySlot"."version"`,
+			`"AvailabilitySlot"."salonId"`,
 		).
 		From(`"AvailabilitySlot"`)
 
-	// Paginación (skip = número de página - 1)
-	if filter.Limit > 0 {
-		offset := ((filter.Skip - 1) * filter.Limit)
-		log.Printf("Configurando paginación - Limit: %d, Offset: %d", filter.Limit, offset)
-		query = query.Limit(filter.Limit).Offset(offset)
+	query = applyAvailabilitySlotFilter(ctx, query, filter)
+	query = availabilitySlotKeyset.paginate(query, filter.After, filter.Skip, filter.Limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error al construir la consulta: %w", err)
+	}
+	r.db.LogQuery(ctx, sql, args)
+
+	rows, err := r.db.Reader().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error al ejecutar consulta: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var slot domain.AvailabilitySlot
+		if err := rows.Scan(
+			&slot.ID,
+			&slot.AdminID,
+			&slot.StartTime,
+			&slot.EndTime,
+			&slot.IsBooked,
+			&slot.DeletedAt,
+			&slot.Version,
+			&slot.SalonID,
+		); err != nil {
+			return nil, nil, fmt.Errorf("error al leer datos: %w", err)
+		}
+		slots = append(slots, slot)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error al procesar resultados: %w", err)
 	}
 
+	next := nextCursor(slots, filter.Limit, func(slot domain.AvailabilitySlot) domain.Cursor {
+		return domain.Cursor{Time: slot.StartTime, ID: slot.ID}
+	})
+
+	return slots, next, nil
+}
+
+// CountAvailabilitySlots counts the availability slots matching the filter, ignoring pagination
+func (r *AvailabilitySlotRepository) CountAvailabilitySlots(ctx context.Context, filter port.AvailabilitySlotFilter) (uint64, error) {
+	query := r.db.QueryBuilder.Select(`"AvailabilitySlot"."id"`).
+		From(`"AvailabilitySlot"`)
+
+	return countRows(ctx, r.db.Reader(), applyAvailabilitySlotFilter(ctx, query, filter))
+}
+
+// applyAvailabilitySlotFilter adds the conditions and joins shared by the listing and its count
+func applyAvailabilitySlotFilter(ctx context.Context, query sq.SelectBuilder, filter port.AvailabilitySlotFilter) sq.SelectBuilder {
+	query = notDeleted(query, `"AvailabilitySlot"`, filter.IncludeDeleted).
+		Where(inSalon(ctx, `"AvailabilitySlot"."salonId"`))
+
 	// Filtro por adminID
 	if filter.UserID != nil {
-		log.Printf("Aplicando filtro por UserID: %v", *filter.UserID)
 		query = query.Where(sq.Eq{`"AvailabilitySlot"."adminId"`: *filter.UserID})
 	}
 
@@ -109,9 +170,8 @@ func (r *AvailabilitySlotRepository) ListAvailabilitySlots(ctx context.Context,
 	if filter.ByState != nil {
 		switch *filter.ByState {
 		case port.SlotStateFree:
-			log.Println("Configurando JOIN para slots libres")
 			query = query.
-				LeftJoin(`"Appointment" ON "AvailabilitySlot"."id" = "Appointment"."slotId"`).
+				LeftJoin(`"Appointment" ON "AvailabilitySlot"."id" = "Appointment"."slotId" AND "Appointment"."deletedAt" IS NULL`).
 				Where(sq.Or{
 					// Slots sin appointment asociado
 					sq.Expr(`"Appointment"."id" IS NULL`),
@@ -122,9 +182,8 @@ func (r *AvailabilitySlotRepository) ListAvailabilitySlots(ctx context.Context,
 				Where(sq.Eq{`"AvailabilitySlot"."isBooked"`: false})
 
 		case port.SlotStateBooked:
-			log.Println("Configurando JOIN para slots reservados")
 			query = query.
-				Join(`"Appointment" ON "AvailabilitySlot"."id" = "Appointment"."slotId"`).
+				Join(`"Appointment" ON "AvailabilitySlot"."id" = "Appointment"."slotId" AND "Appointment"."deletedAt" IS NULL`).
 				Where(sq.Or{
 					sq.Eq{`"Appointment"."status"`: "booked"},
 					sq.Eq{`"Appointment"."status"`: "completed"},
@@ -132,107 +191,72 @@ func (r *AvailabilitySlotRepository) ListAvailabilitySlots(ctx context.Context,
 		}
 	}
 
-	sql, args, err := query.ToSql()
-	if err != nil {
-		return nil, fmt.Errorf("error al construir la consulta: %w", err)
-	}
-	log.Printf("SQL generado: %s", sql)
-	log.Printf("Parámetros SQL: %v", args)
-
-	rows, err := r.db.Conn.Query(ctx, sql, args...)
-	if err != nil {
-		return nil, fmt.Errorf("error al ejecutar consulta: %w", err)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var slot domain.AvailabilitySlot
-		if err := rows.Scan(
-			&slot.ID,
-			&slot.AdminID,
-			&slot.StartTime,
-			&slot.EndTime,
-			&slot.IsBooked,
-		); err != nil {
-			return nil, fmt.Errorf("error al leer datos: %w", err)
-		}
-		slots = append(slots, slot)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error al procesar resultados: %w", err)
-	}
+	return query
+}
 
-	log.Printf("Consulta completada exitosamente. Slots encontrados: %d", len(slots))
-	return slots, nil
+// availabilitySlotUpdate contiene las columnas que cambia UpdateAvailabilitySlot, los campos nil no se modifican
+type availabilitySlotUpdate struct {
+	AdminID   *uuid.UUID `db:"adminId"`
+	StartTime *time.Time `db:"startTime"`
+	EndTime   *time.Time `db:"endTime"`
 }
 
 // UpdateAvailabilitySlot actualiza un availability slot existente en la base de datos
 func (r *AvailabilitySlotRepository) UpdateAvailabilitySlot(ctx context.Context, slot *domain.AvailabilitySlot) (*domain.AvailabilitySlot, error) {
-	query := r.db.QueryBuilder.Update("\"AvailabilitySlot\"").
-		Set("\"adminId\"", slot.AdminID).
-		Set("\"startTime\"", slot.StartTime).
-		Set("\"endTime\"", slot.EndTime).
+	query := setPartial(r.db.QueryBuilder.Update("\"AvailabilitySlot\""), availabilitySlotUpdate{
+		AdminID:   optional(slot.AdminID),
+		StartTime: optional(slot.StartTime),
+		EndTime:   optional(slot.EndTime),
+	}).
 		Set("\"isBooked\"", slot.IsBooked).
 		Where(sq.Eq{"id": slot.ID}).
-		Suffix("RETURNING *")
+		Where(inSalon(ctx, `"salonId"`)).
+		Suffix(`RETURNING id, "adminId", "startTime", "endTime", "isBooked", "version", "salonId"`)
+
+	query = lockVersion(query, slot.Version)
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&slot.ID, &slot.AdminID, &slot.StartTime, &slot.EndTime, &slot.IsBooked)
+	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&slot.ID, &slot.AdminID, &slot.StartTime, &slot.EndTime, &slot.IsBooked, &slot.Version, &slot.SalonID)
 	if err != nil {
+		// El slot cambió desde que se leyó
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrConflictingData
+		}
 		return nil, err
 	}
 
 	return slot, nil
 }
 
-// DeleteAvailabilitySlot elimina un availability slot por ID desde la base de datos
+// DeleteAvailabilitySlot elimina lógicamente un availability slot por ID, los admins aún pueden listarlo
 func (r *AvailabilitySlotRepository) DeleteAvailabilitySlot(ctx context.Context, id uuid.UUID) error {
-	query := r.db.QueryBuilder.Delete("\"AvailabilitySlot\"").
-		Where(sq.Eq{"id": id})
-
-	sql, args, err := query.ToSql()
-	if err != nil {
-		return err
-	}
-
-	_, err = r.db.Conn.Exec(ctx, sql, args...)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return softDelete(ctx, r.db, `"AvailabilitySlot"`, id)
 }
 
 // MarkSlotsAsBookedByQuoteID actualiza todos los AvailabilitySlot relacionados a una Quote aprobada
 func (r *AvailabilitySlotRepository) MarkSlotsAsBookedByQuoteID(ctx context.Context, quoteID uuid.UUID) error {
-	log.Printf("Marking slots as booked for quote ID: %s\n", quoteID)
-
 	sql := `
         UPDATE "AvailabilitySlot"
-        SET "isBooked" = TRUE
+        SET "isBooked" = TRUE, "version" = "version" + 1
         WHERE "id" IN (
             SELECT "slotId" FROM "Appointment" WHERE "quoteId" = $1
         )
         AND "isBooked" = FALSE
     `
 
+	r.db.LogQuery(ctx, sql, []any{quoteID})
 	cmdTag, err := r.db.Conn.Exec(ctx, sql, quoteID)
 	if err != nil {
 		return fmt.Errorf("failed to mark slots as booked: %w", err)
 	}
 
-	rowsAffected := cmdTag.RowsAffected()
-	log.Printf("Rows affected: %d\n", rowsAffected)
-
-	// Opcional: Decide si 0 filas afectadas es realmente un error en tu caso de uso
-	if rowsAffected == 0 {
-		log.Println("No availability slots found to update for quote ID:", quoteID)
-		return nil // o mantener el error si es realmente un caso anómalo
+	// Una quote sin slots libres no es un error, ya estaban reservados
+	if cmdTag.RowsAffected() == 0 {
+		slog.DebugContext(ctx, "No availability slots to mark as booked", "quote_id", quoteID)
 	}
 
 	return nil
@@ -0,0 +1,419 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/util"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// CouponRepository implements port.CouponRepository interface and provides access to the postgres database
+type CouponRepository struct {
+	db *postgres.DB
+}
+
+// NewCouponRepository creates a new coupon repository instance
+func NewCouponRepository(db *postgres.DB) *CouponRepository {
+	return &CouponRepository{
+		db,
+	}
+}
+
+// couponColumns are the columns selected for a coupon, in scan order; the types of service are aggregated
+// into an array and the redemptions counted
+var couponColumns = []string{
+	"id",
+	"code",
+	"\"discountType\"",
+	"value",
+	"COALESCE(currency, '')",
+	"\"startsAt\"",
+	"\"endsAt\"",
+	"\"maxRedemptions\"",
+	"\"maxRedemptionsPerClient\"",
+	"ARRAY(SELECT \"typeOfServiceId\" FROM \"CouponTypeOfService\" WHERE \"couponId\" = \"Coupon\".id)",
+	"(SELECT COUNT(*) FROM \"CouponRedemption\" WHERE \"couponId\" = \"Coupon\".id)",
+	"\"salonId\"",
+}
+
+// scanCoupon scans a row selected with couponColumns
+func scanCoupon(row pgx.Row, c *domain.Coupon) error {
+	return row.Scan(
+		&c.ID,
+		&c.Code,
+		&c.DiscountType,
+		&c.Value,
+		&c.Currency,
+		&c.StartsAt,
+		&c.EndsAt,
+		&c.MaxRedemptions,
+		&c.MaxRedemptionsPerClient,
+		&c.TypeOfServiceIDs,
+		&c.Redemptions,
+		&c.SalonID,
+	)
+}
+
+// couponRedemptionColumns are the columns selected for a coupon redemption, in scan order
+var couponRedemptionColumns = []string{
+	"id",
+	"\"couponId\"",
+	"\"quoteId\"",
+	"\"clientId\"",
+	"\"redeemedAt\"",
+}
+
+// scanCouponRedemption scans a row selected with couponRedemptionColumns
+func scanCouponRedemption(row pgx.Row, r *domain.CouponRedemption) error {
+	return row.Scan(
+		&r.ID,
+		&r.CouponID,
+		&r.QuoteID,
+		&r.ClientID,
+		&r.RedeemedAt,
+	)
+}
+
+// CreateCoupon inserts a new coupon and its types of service into the database
+func (r *CouponRepository) CreateCoupon(ctx context.Context, coupon *domain.Coupon) (*domain.Coupon, error) {
+	coupon.SalonID = util.SalonOr(ctx, coupon.SalonID)
+
+	err := r.db.WithTx(ctx, func(txDB *postgres.DB) error {
+		query := txDB.QueryBuilder.Insert("\"Coupon\"").
+			Columns("id", "code", "\"discountType\"", "value", "currency", "\"startsAt\"", "\"endsAt\"", "\"maxRedemptions\"", "\"maxRedemptionsPerClient\"", "\"salonId\"").
+			Values(coupon.ID, coupon.Code, coupon.DiscountType, coupon.Value, nullableCurrency(coupon.Currency), coupon.StartsAt, coupon.EndsAt, coupon.MaxRedemptions, coupon.MaxRedemptionsPerClient, coupon.SalonID)
+
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = txDB.Conn.Exec(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+
+		return insertCouponTypesOfService(ctx, txDB, coupon)
+	})
+	if err != nil {
+		switch r.db.ErrorCode(err) {
+		case "23505":
+			return nil, domain.ErrConflictingData
+		case "23503":
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return coupon, nil
+}
+
+// GetCouponByID retrieves a coupon by ID
+func (r *CouponRepository) GetCouponByID(ctx context.Context, id uuid.UUID) (*domain.Coupon, error) {
+	return r.getCoupon(ctx, sq.Eq{"id": id})
+}
+
+// GetCouponByCode retrieves a coupon by its normalized code, among those of the salon of ctx
+func (r *CouponRepository) GetCouponByCode(ctx context.Context, code string) (*domain.Coupon, error) {
+	return r.getCoupon(ctx, sq.Eq{"code": code})
+}
+
+// getCoupon retrieves the coupon matching a condition
+func (r *CouponRepository) getCoupon(ctx context.Context, where sq.Eq) (*domain.Coupon, error) {
+	var c domain.Coupon
+
+	query := r.db.QueryBuilder.Select(couponColumns...).
+		From("\"Coupon\"").
+		Where(where).
+		Where(inSalon(ctx, `"salonId"`)).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanCoupon(r.db.Reader().QueryRow(ctx, sql, args...), &c)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// ListCoupons retrieves a list of coupons ordered by the start of their validity window
+func (r *CouponRepository) ListCoupons(ctx context.Context, skip, limit uint64) ([]domain.Coupon, error) {
+	var coupons []domain.Coupon
+
+	query := r.db.QueryBuilder.Select(couponColumns...).
+		From("\"Coupon\"").
+		Where(inSalon(ctx, `"salonId"`)).
+		OrderBy("\"startsAt\" DESC", "code").
+		Limit(limit).
+		Offset(pageOffset(skip, limit))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Reader().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c domain.Coupon
+		if err := scanCoupon(rows, &c); err != nil {
+			return nil, err
+		}
+		coupons = append(coupons, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return coupons, nil
+}
+
+// UpdateCoupon updates a coupon and replaces its types of service, the redemptions it already has are kept
+func (r *CouponRepository) UpdateCoupon(ctx context.Context, coupon *domain.Coupon) (*domain.Coupon, error) {
+	err := r.db.WithTx(ctx, func(txDB *postgres.DB) error {
+		query := txDB.QueryBuilder.Update("\"Coupon\"").
+			Set("code", coupon.Code).
+			Set("\"discountType\"", coupon.DiscountType).
+			Set("value", coupon.Value).
+			Set("currency", nullableCurrency(coupon.Currency)).
+			Set("\"startsAt\"", coupon.StartsAt).
+			Set("\"endsAt\"", coupon.EndsAt).
+			Set("\"maxRedemptions\"", coupon.MaxRedemptions).
+			Set("\"maxRedemptionsPerClient\"", coupon.MaxRedemptionsPerClient).
+			Where(sq.Eq{"id": coupon.ID}).
+			Where(inSalon(ctx, `"salonId"`))
+
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return err
+		}
+
+		tag, err := txDB.Conn.Exec(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return domain.ErrDataNotFound
+		}
+
+		deleteQuery := txDB.QueryBuilder.Delete("\"CouponTypeOfService\"").
+			Where(sq.Eq{"\"couponId\"": coupon.ID})
+
+		sql, args, err = deleteQuery.ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = txDB.Conn.Exec(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+
+		return insertCouponTypesOfService(ctx, txDB, coupon)
+	})
+	if err != nil {
+		switch r.db.ErrorCode(err) {
+		case "23505":
+			return nil, domain.ErrConflictingData
+		case "23503":
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return r.GetCouponByID(ctx, coupon.ID)
+}
+
+// DeleteCoupon deletes a coupon by ID with its redemptions, quotes it was applied to keep their discount
+func (r *CouponRepository) DeleteCoupon(ctx context.Context, id uuid.UUID) error {
+	query := r.db.QueryBuilder.Delete("\"Coupon\"").
+		Where(sq.Eq{"id": id}).
+		Where(inSalon(ctx, `"salonId"`))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Conn.Exec(ctx, sql, args...)
+	return err
+}
+
+// RedeemCoupon records the redemption of a coupon, replacing the one its quote already has. The coupon is
+// locked while its redemptions are counted so concurrent redemptions can't go over its limits
+func (r *CouponRepository) RedeemCoupon(ctx context.Context, redemption *domain.CouponRedemption) (*domain.CouponRedemption, error) {
+	err := r.db.WithTx(ctx, func(txDB *postgres.DB) error {
+		var maxRedemptions, maxRedemptionsPerClient int
+
+		lockQuery := txDB.QueryBuilder.Select("\"maxRedemptions\"", "\"maxRedemptionsPerClient\"").
+			From("\"Coupon\"").
+			Where(sq.Eq{"id": redemption.CouponID}).
+			Where(inSalon(ctx, `"salonId"`)).
+			Suffix("FOR UPDATE")
+
+		sql, args, err := lockQuery.ToSql()
+		if err != nil {
+			return err
+		}
+
+		err = txDB.Conn.QueryRow(ctx, sql, args...).Scan(&maxRedemptions, &maxRedemptionsPerClient)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return domain.ErrDataNotFound
+			}
+			return err
+		}
+
+		// The redemption of the quote being replaced doesn't count against the limits
+		deleteQuery := txDB.QueryBuilder.Delete("\"CouponRedemption\"").
+			Where(sq.Eq{"\"quoteId\"": redemption.QuoteID})
+
+		sql, args, err = deleteQuery.ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = txDB.Conn.Exec(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+
+		var total, byClient int
+
+		countQuery := txDB.QueryBuilder.Select("COUNT(*)").
+			Column(sq.Expr("COUNT(*) FILTER (WHERE \"clientId\" = ?)", redemption.ClientID)).
+			From("\"CouponRedemption\"").
+			Where(sq.Eq{"\"couponId\"": redemption.CouponID})
+
+		sql, args, err = countQuery.ToSql()
+		if err != nil {
+			return err
+		}
+
+		err = txDB.Conn.QueryRow(ctx, sql, args...).Scan(&total, &byClient)
+		if err != nil {
+			return err
+		}
+
+		if (maxRedemptions > 0 && total >= maxRedemptions) ||
+			(maxRedemptionsPerClient > 0 && byClient >= maxRedemptionsPerClient) {
+			return domain.ErrCouponUnavailable
+		}
+
+		insertQuery := txDB.QueryBuilder.Insert("\"CouponRedemption\"").
+			Columns("id", "\"couponId\"", "\"quoteId\"", "\"clientId\"").
+			Values(redemption.ID, redemption.CouponID, redemption.QuoteID, redemption.ClientID).
+			Suffix("RETURNING " + strings.Join(couponRedemptionColumns, ", "))
+
+		sql, args, err = insertQuery.ToSql()
+		if err != nil {
+			return err
+		}
+
+		return scanCouponRedemption(txDB.Conn.QueryRow(ctx, sql, args...), redemption)
+	})
+	if err != nil {
+		if err == domain.ErrCouponUnavailable || err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		if errCode := r.db.ErrorCode(err); errCode == "23503" {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return redemption, nil
+}
+
+// ReleaseCoupon deletes the redemption of a quote, a quote without one is left as it is
+func (r *CouponRepository) ReleaseCoupon(ctx context.Context, quoteID uuid.UUID) error {
+	query := r.db.QueryBuilder.Delete("\"CouponRedemption\"").
+		Where(sq.Eq{"\"quoteId\"": quoteID})
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Conn.Exec(ctx, sql, args...)
+	return err
+}
+
+// ListCouponRedemptions retrieves the redemptions of a coupon, the latest first
+func (r *CouponRepository) ListCouponRedemptions(ctx context.Context, couponID uuid.UUID, skip, limit uint64) ([]domain.CouponRedemption, error) {
+	var redemptions []domain.CouponRedemption
+
+	query := r.db.QueryBuilder.Select(couponRedemptionColumns...).
+		From("\"CouponRedemption\"").
+		Where(sq.Eq{"\"couponId\"": couponID}).
+		OrderBy("\"redeemedAt\" DESC", "id").
+		Limit(limit).
+		Offset(pageOffset(skip, limit))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Reader().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var redemption domain.CouponRedemption
+		if err := scanCouponRedemption(rows, &redemption); err != nil {
+			return nil, err
+		}
+		redemptions = append(redemptions, redemption)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return redemptions, nil
+}
+
+// insertCouponTypesOfService links a coupon to the types of service it applies to
+func insertCouponTypesOfService(ctx context.Context, db *postgres.DB, coupon *domain.Coupon) error {
+	if len(coupon.TypeOfServiceIDs) == 0 {
+		return nil
+	}
+
+	query := db.QueryBuilder.Insert("\"CouponTypeOfService\"").
+		Columns("\"couponId\"", "\"typeOfServiceId\"")
+
+	for _, typeOfServiceID := range coupon.TypeOfServiceIDs {
+		query = query.Values(coupon.ID, typeOfServiceID)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Conn.Exec(ctx, sql, args...)
+	return err
+}
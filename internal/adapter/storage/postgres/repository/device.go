@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/util"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// DeviceRepository implements port.DeviceRepository interface and provides access to the postgres database
+type DeviceRepository struct {
+	db *postgres.DB
+}
+
+// NewDeviceRepository creates a new device repository instance
+func NewDeviceRepository(db *postgres.DB) *DeviceRepository {
+	return &DeviceRepository{
+		db,
+	}
+}
+
+// deviceColumns are the columns selected for a device, in scan order
+var deviceColumns = []string{
+	"id",
+	"\"userId\"",
+	"token",
+	"platform",
+	"\"createdAt\"",
+	"\"updatedAt\"",
+	"\"salonId\"",
+}
+
+// scanDevice scans a row selected with deviceColumns
+func scanDevice(row pgx.Row, d *domain.Device) error {
+	return row.Scan(
+		&d.ID,
+		&d.UserID,
+		&d.Token,
+		&d.Platform,
+		&d.CreatedAt,
+		&d.UpdatedAt,
+		&d.SalonID,
+	)
+}
+
+// UpsertDevice inserts a device, a token already registered is moved to the user, platform and salon given
+// since the same phone can be signed in to by another user
+func (r *DeviceRepository) UpsertDevice(ctx context.Context, device *domain.Device) (*domain.Device, error) {
+	device.SalonID = util.SalonOr(ctx, device.SalonID)
+
+	query := r.db.QueryBuilder.Insert("\"Device\"").
+		Columns("id", "\"userId\"", "token", "platform", "\"createdAt\"", "\"updatedAt\"", "\"salonId\"").
+		Values(device.ID, device.UserID, device.Token, device.Platform, device.CreatedAt, device.UpdatedAt, device.SalonID).
+		Suffix("ON CONFLICT (token) DO UPDATE SET \"userId\" = EXCLUDED.\"userId\", platform = EXCLUDED.platform, \"updatedAt\" = EXCLUDED.\"updatedAt\", \"salonId\" = EXCLUDED.\"salonId\"").
+		Suffix("RETURNING " + strings.Join(deviceColumns, ", "))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var upserted domain.Device
+	err = scanDevice(r.db.Conn.QueryRow(ctx, sql, args...), &upserted)
+	if err != nil {
+		if errCode := r.db.ErrorCode(err); errCode == "23503" {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &upserted, nil
+}
+
+// ListDevicesByUsers selects the devices of the users
+func (r *DeviceRepository) ListDevicesByUsers(ctx context.Context, userIDs []uuid.UUID) ([]domain.Device, error) {
+	var devices []domain.Device
+
+	if len(userIDs) == 0 {
+		return devices, nil
+	}
+
+	query := r.db.QueryBuilder.Select(deviceColumns...).
+		From("\"Device\"").
+		Where(sq.Eq{"\"userId\"": userIDs}).
+		Where(inSalon(ctx, "\"salonId\""))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	r.db.LogQuery(ctx, sql, args)
+
+	rows, err := r.db.Reader().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var d domain.Device
+		if err := scanDevice(rows, &d); err != nil {
+			return nil, err
+		}
+		devices = append(devices, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return devices, nil
+}
+
+// DeleteDevice deletes one of a user's devices by its token, devices of other users are not found
+func (r *DeviceRepository) DeleteDevice(ctx context.Context, userID uuid.UUID, token string) error {
+	query := r.db.QueryBuilder.Delete("\"Device\"").
+		Where(sq.Eq{"\"userId\"": userID, "token": token}).
+		Where(inSalon(ctx, "\"salonId\""))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := r.db.Conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrDataNotFound
+	}
+
+	return nil
+}
+
+// DeleteDeviceByToken deletes a device whatever its user
+func (r *DeviceRepository) DeleteDeviceByToken(ctx context.Context, token string) error {
+	query := r.db.QueryBuilder.Delete("\"Device\"").
+		Where(sq.Eq{"token": token}).
+		Where(inSalon(ctx, "\"salonId\""))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Conn.Exec(ctx, sql, args...)
+	return err
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// EmailLogRepository implements port.EmailLogRepository interface and provides access to the postgres database
+type EmailLogRepository struct {
+	db *postgres.DB
+}
+
+// NewEmailLogRepository creates a new email log repository instance
+func NewEmailLogRepository(db *postgres.DB) *EmailLogRepository {
+	return &EmailLogRepository{
+		db,
+	}
+}
+
+// emailLogColumns are the columns selected for an email log, in scan order
+var emailLogColumns = []string{
+	"id",
+	"\"emailId\"",
+	"recipients",
+	"COALESCE(template, '')",
+	"subject",
+	"status",
+	"COALESCE(\"providerMessageId\", '')",
+	"COALESCE(error, '')",
+	"attempt",
+	"\"createdAt\"",
+	"\"salonId\"",
+}
+
+// scanEmailLog scans a row selected with emailLogColumns
+func scanEmailLog(row pgx.Row, l *domain.EmailLog) error {
+	return row.Scan(
+		&l.ID,
+		&l.EmailID,
+		&l.Recipients,
+		&l.Template,
+		&l.Subject,
+		&l.Status,
+		&l.ProviderMessageID,
+		&l.Error,
+		&l.Attempt,
+		&l.CreatedAt,
+		&l.SalonID,
+	)
+}
+
+// CreateEmailLog inserts a delivery attempt into the database
+func (r *EmailLogRepository) CreateEmailLog(ctx context.Context, log *domain.EmailLog) error {
+	log.SalonID = util.SalonOr(ctx, log.SalonID)
+
+	query := r.db.QueryBuilder.Insert("\"EmailLog\"").
+		Columns("id", "\"emailId\"", "recipients", "template", "subject", "status", "\"providerMessageId\"", "error", "attempt", "\"createdAt\"", "\"salonId\"").
+		Values(
+			log.ID,
+			log.EmailID,
+			log.Recipients,
+			nullString(string(log.Template)),
+			log.Subject,
+			log.Status,
+			nullString(log.ProviderMessageID),
+			nullString(log.Error),
+			log.Attempt,
+			log.CreatedAt,
+			log.SalonID,
+		)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Conn.Exec(ctx, sql, args...)
+	return err
+}
+
+// ListEmailLogs selects the delivery attempts, newest first
+func (r *EmailLogRepository) ListEmailLogs(ctx context.Context, filter port.EmailLogFilter) ([]domain.EmailLog, error) {
+	var logs []domain.EmailLog
+
+	query := r.db.QueryBuilder.Select(emailLogColumns...).
+		From("\"EmailLog\"").
+		Where(inSalon(ctx, "\"salonId\"")).
+		OrderBy("\"createdAt\" DESC", "id")
+
+	if filter.Recipient != "" {
+		query = query.Where("recipients @> ARRAY[?]::TEXT[]", filter.Recipient)
+	}
+	if filter.Status != nil {
+		query = query.Where(sq.Eq{"status": *filter.Status})
+	}
+	if filter.Template != nil {
+		query = query.Where(sq.Eq{"template": *filter.Template})
+	}
+
+	// Paginación (skip = número de página)
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit).Offset(pageOffset(filter.Skip, filter.Limit))
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	r.db.LogQuery(ctx, sql, args)
+
+	rows, err := r.db.Reader().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l domain.EmailLog
+		if err := scanEmailLog(rows, &l); err != nil {
+			return nil, err
+		}
+		logs = append(logs, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+// DeleteEmailLogsBefore deletes up to limit delivery attempts made before before and returns how many it deleted
+func (r *EmailLogRepository) DeleteEmailLogsBefore(ctx context.Context, before time.Time, limit uint64) (uint64, error) {
+	// DELETE has no LIMIT, the oldest rows are picked by a subquery so every call is a short transaction
+	query := r.db.QueryBuilder.Delete("\"EmailLog\"").
+		Where("id IN (SELECT id FROM \"EmailLog\" WHERE \"createdAt\" < ? ORDER BY \"createdAt\" LIMIT ?)", before, limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	tag, err := r.db.Conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(tag.RowsAffected()), nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// EventStoreRepository implements port.EventStoreRepository interface and provides access to the postgres database
+type EventStoreRepository struct {
+	db *postgres.DB
+}
+
+// NewEventStoreRepository creates a new event store repository instance, on a transaction to record the events
+// along with the change they announce
+func NewEventStoreRepository(db *postgres.DB) *EventStoreRepository {
+	return &EventStoreRepository{
+		db,
+	}
+}
+
+// eventColumns are the columns selected for a stored event, in scan order
+var eventColumns = []string{
+	"id",
+	"type",
+	"payload",
+	"\"occurredAt\"",
+	"\"recordedAt\"",
+	"\"salonId\"",
+}
+
+// scanStoredEvent scans a row selected with eventColumns
+func scanStoredEvent(row pgx.Row, e *domain.StoredEvent) error {
+	return row.Scan(
+		&e.ID,
+		&e.Type,
+		&e.Payload,
+		&e.OccurredAt,
+		&e.RecordedAt,
+		&e.SalonID,
+	)
+}
+
+// AppendEvents inserts the events in a single statement, an event already stored is left as it is
+func (r *EventStoreRepository) AppendEvents(ctx context.Context, events ...*domain.StoredEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	query := r.db.QueryBuilder.Insert("\"Event\"").
+		Columns("id", "type", "payload", "\"occurredAt\"", "\"salonId\"").
+		Suffix("ON CONFLICT (id) DO NOTHING")
+
+	for _, e := range events {
+		e.SalonID = util.SalonOr(ctx, e.SalonID)
+		query = query.Values(e.ID, e.Type, e.Payload, e.OccurredAt, e.SalonID)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Conn.Exec(ctx, sql, args...)
+	return err
+}
+
+// ListEvents selects the stored events in the order they occurred
+func (r *EventStoreRepository) ListEvents(ctx context.Context, filter port.EventFilter) ([]domain.StoredEvent, error) {
+	var events []domain.StoredEvent
+
+	query := r.db.QueryBuilder.Select(eventColumns...).
+		From("\"Event\"").
+		Where(inSalon(ctx, "\"salonId\"")).
+		OrderBy("\"occurredAt\"", "id")
+
+	if filter.Type != nil {
+		query = query.Where(sq.Eq{"type": *filter.Type})
+	}
+	if filter.StartDate != nil {
+		query = query.Where(sq.GtOrEq{"\"occurredAt\"": *filter.StartDate})
+	}
+	if filter.EndDate != nil {
+		query = query.Where(sq.Lt{"\"occurredAt\"": *filter.EndDate})
+	}
+
+	// Paginación (skip = número de página)
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit).Offset(pageOffset(filter.Skip, filter.Limit))
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	r.db.LogQuery(ctx, sql, args)
+
+	rows, err := r.db.Reader().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e domain.StoredEvent
+		if err := scanStoredEvent(rows, &e); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"harajuku/backend/internal/adapter/storage/postgres"
+)
+
+// FileReferenceRepository implements port.FileReferenceRepository interface and provides access to the postgres database
+type FileReferenceRepository struct {
+	db *postgres.DB
+}
+
+// NewFileReferenceRepository creates a new file reference repository instance
+func NewFileReferenceRepository(db *postgres.DB) *FileReferenceRepository {
+	return &FileReferenceRepository{
+		db,
+	}
+}
+
+// GetReferencedFiles selects the keys among keys stored in the url of a quote image, a payment proof, a
+// type of service image or the photo of a staff member. Soft deleted rows still count, their files are
+// kept until the row is gone
+func (r *FileReferenceRepository) GetReferencedFiles(ctx context.Context, keys []string) (map[string]bool, error) {
+	referenced := make(map[string]bool)
+	if len(keys) == 0 {
+		return referenced, nil
+	}
+
+	sql := `
+        SELECT "url" FROM "QuoteImages" WHERE "url" = ANY($1)
+        UNION
+        SELECT "url" FROM "PaymentProof" WHERE "url" = ANY($1)
+        UNION
+        SELECT "url" FROM "TypeOfServiceImages" WHERE "url" = ANY($1)
+        UNION
+        SELECT "photoUrl" FROM "Staff" WHERE "photoUrl" = ANY($1)
+    `
+
+	// Read from the primary, a lagging replica could miss a file that was just referenced
+	rows, err := r.db.Conn.Query(ctx, sql, keys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+		err := rows.Scan(&key)
+		if err != nil {
+			return nil, err
+		}
+
+		referenced[key] = true
+	}
+
+	return referenced, rows.Err()
+}
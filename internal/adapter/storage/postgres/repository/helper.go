@@ -1,9 +1,45 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+
+	"harajuku/backend/internal/adapter/storage/postgres"
+
+	sq "github.com/Masterminds/squirrel"
 )
 
+// countRows counts the rows a listing query returns, ignoring its pagination.
+// The query runs as a subquery so joins and ordering count exactly as they list
+func countRows(ctx context.Context, conn postgres.Conn, query sq.SelectBuilder) (uint64, error) {
+	var total uint64
+
+	filtered := query.RemoveLimit().RemoveOffset().PlaceholderFormat(sq.Question)
+	sql, args, err := sq.Select("COUNT(*)").
+		FromSelect(filtered, "filtered").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	err = conn.QueryRow(ctx, sql, args...).Scan(&total)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// pageOffset returns the rows to skip before the page skip of limit rows. skip is the page number
+// starting at 1, 0 is the first page as well
+func pageOffset(skip, limit uint64) uint64 {
+	if skip <= 1 {
+		return 0
+	}
+	return (skip - 1) * limit
+}
+
 // nullString converts a string to sql.NullString for empty string check
 func nullString(value string) sql.NullString {
 	if value == "" {
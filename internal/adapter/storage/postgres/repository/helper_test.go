@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageOffset(t *testing.T) {
+	// skip is the page number, 0 and 1 both start at the first row
+	assert.Equal(t, uint64(0), pageOffset(0, 10))
+	assert.Equal(t, uint64(0), pageOffset(1, 10))
+	assert.Equal(t, uint64(10), pageOffset(2, 10))
+	assert.Equal(t, uint64(45), pageOffset(10, 5))
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"fmt"
+
+	"harajuku/backend/internal/core/domain"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// keyset orders a listing by a timestamp column with the id column breaking ties,
+// so pages resume after the last row seen instead of skipping an offset
+type keyset struct {
+	time string
+	id   string
+}
+
+// paginate orders the query by the keyset and limits it to a page. Given a cursor the page
+// starts right after it, otherwise the page skip is reached by offset
+func (k keyset) paginate(query sq.SelectBuilder, after *domain.Cursor, skip, limit uint64) sq.SelectBuilder {
+	query = query.OrderBy(k.time, k.id)
+
+	if after != nil {
+		query = query.Where(sq.Expr(fmt.Sprintf("(%s, %s) > (?, ?)", k.time, k.id), after.Time, after.ID))
+	} else if limit > 0 {
+		query = query.Offset(pageOffset(skip, limit))
+	}
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	return query
+}
+
+// nextCursor returns the cursor after the last row when the rows fill a page, nil when there is no page after them
+func nextCursor[T any](rows []T, limit uint64, key func(T) domain.Cursor) *domain.Cursor {
+	if limit == 0 || len(rows) == 0 || uint64(len(rows)) < limit {
+		return nil
+	}
+
+	next := key(rows[len(rows)-1])
+	return &next
+}
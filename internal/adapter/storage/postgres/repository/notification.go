@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// NotificationRepository implements port.NotificationRepository interface and provides access to the postgres database
+type NotificationRepository struct {
+	db *postgres.DB
+}
+
+// NewNotificationRepository creates a new notification repository instance
+func NewNotificationRepository(db *postgres.DB) *NotificationRepository {
+	return &NotificationRepository{
+		db,
+	}
+}
+
+// notificationColumns are the columns selected for a notification, in scan order
+var notificationColumns = []string{
+	"id",
+	"\"userId\"",
+	"type",
+	"payload",
+	"read",
+	"\"createdAt\"",
+	"\"salonId\"",
+}
+
+// scanNotification scans a row selected with notificationColumns
+func scanNotification(row pgx.Row, n *domain.Notification) error {
+	return row.Scan(
+		&n.ID,
+		&n.UserID,
+		&n.Type,
+		&n.Payload,
+		&n.Read,
+		&n.CreatedAt,
+		&n.SalonID,
+	)
+}
+
+// CreateNotifications inserts the notifications into the database in a single statement
+func (r *NotificationRepository) CreateNotifications(ctx context.Context, notifications []domain.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	query := r.db.QueryBuilder.Insert("\"Notification\"").
+		Columns("id", "\"userId\"", "type", "payload", "read", "\"createdAt\"", "\"salonId\"")
+
+	for _, n := range notifications {
+		query = query.Values(n.ID, n.UserID, n.Type, n.Payload, n.Read, n.CreatedAt, util.SalonOr(ctx, n.SalonID))
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Conn.Exec(ctx, sql, args...)
+	if err != nil {
+		if errCode := r.db.ErrorCode(err); errCode == "23503" {
+			return domain.ErrDataNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ListNotifications selects a user's notifications, newest first
+func (r *NotificationRepository) ListNotifications(ctx context.Context, filter port.NotificationFilter) ([]domain.Notification, error) {
+	var notifications []domain.Notification
+
+	query := r.db.QueryBuilder.Select(notificationColumns...).
+		From("\"Notification\"").
+		Where(sq.Eq{"\"userId\"": filter.UserID}).
+		Where(inSalon(ctx, "\"salonId\"")).
+		OrderBy("\"createdAt\" DESC", "id")
+
+	if filter.Unread {
+		query = query.Where(sq.Eq{"read": false})
+	}
+
+	// Paginación (skip = número de página)
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit).Offset(pageOffset(filter.Skip, filter.Limit))
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	r.db.LogQuery(ctx, sql, args)
+
+	rows, err := r.db.Reader().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var n domain.Notification
+		if err := scanNotification(rows, &n); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// CountUnread counts the notifications a user has not read
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID uuid.UUID) (uint64, error) {
+	var count uint64
+
+	query := r.db.QueryBuilder.Select("COUNT(*)").
+		From("\"Notification\"").
+		Where(sq.Eq{"\"userId\"": userID, "read": false}).
+		Where(inSalon(ctx, "\"salonId\""))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	err = r.db.Reader().QueryRow(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// MarkAsRead marks one of a user's notifications as read, notifications of other users are not found
+func (r *NotificationRepository) MarkAsRead(ctx context.Context, userID, id uuid.UUID) error {
+	query := r.db.QueryBuilder.Update("\"Notification\"").
+		Set("read", true).
+		Where(sq.Eq{"id": id, "\"userId\"": userID}).
+		Where(inSalon(ctx, "\"salonId\""))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := r.db.Conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrDataNotFound
+	}
+
+	return nil
+}
+
+// MarkAllAsRead marks every notification of a user as read
+func (r *NotificationRepository) MarkAllAsRead(ctx context.Context, userID uuid.UUID) error {
+	query := r.db.QueryBuilder.Update("\"Notification\"").
+		Set("read", true).
+		Where(sq.Eq{"\"userId\"": userID, "read": false}).
+		Where(inSalon(ctx, "\"salonId\""))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Conn.Exec(ctx, sql, args...)
+	return err
+}
+
+// DeleteNotificationsBefore deletes up to limit notifications created before before and returns how many it deleted
+func (r *NotificationRepository) DeleteNotificationsBefore(ctx context.Context, before time.Time, limit uint64) (uint64, error) {
+	// DELETE has no LIMIT, the oldest rows are picked by a subquery so every call is a short transaction
+	query := r.db.QueryBuilder.Delete("\"Notification\"").
+		Where("id IN (SELECT id FROM \"Notification\" WHERE \"createdAt\" < ? ORDER BY \"createdAt\" LIMIT ?)", before, limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	tag, err := r.db.Conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(tag.RowsAffected()), nil
+}
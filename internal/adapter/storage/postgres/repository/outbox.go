@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/util"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// OutboxRepository implements port.OutboxRepository interface and provides access to the postgres database
+type OutboxRepository struct {
+	db *postgres.DB
+}
+
+// NewOutboxRepository creates a new outbox repository instance, on a transaction to write the messages along with a change
+func NewOutboxRepository(db *postgres.DB) *OutboxRepository {
+	return &OutboxRepository{
+		db,
+	}
+}
+
+// outboxColumns are the columns selected for an outbox message, in scan order
+var outboxColumns = []string{
+	"id",
+	"kind",
+	"payload",
+	"attempts",
+	"COALESCE(\"lastError\", '')",
+	"\"availableAt\"",
+	"\"createdAt\"",
+	"\"salonId\"",
+}
+
+// scanOutboxMessage scans a row selected with outboxColumns
+func scanOutboxMessage(row pgx.Row, m *domain.OutboxMessage) error {
+	return row.Scan(
+		&m.ID,
+		&m.Kind,
+		&m.Payload,
+		&m.Attempts,
+		&m.LastError,
+		&m.AvailableAt,
+		&m.CreatedAt,
+		&m.SalonID,
+	)
+}
+
+// CreateOutboxMessages inserts the messages in a single statement
+func (r *OutboxRepository) CreateOutboxMessages(ctx context.Context, messages ...*domain.OutboxMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	query := r.db.QueryBuilder.Insert("\"Outbox\"").
+		Columns("id", "kind", "payload", "attempts", "\"availableAt\"", "\"createdAt\"", "\"salonId\"")
+
+	for _, m := range messages {
+		m.SalonID = util.SalonOr(ctx, m.SalonID)
+		query = query.Values(m.ID, m.Kind, m.Payload, m.Attempts, m.AvailableAt, m.CreatedAt, m.SalonID)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Conn.Exec(ctx, sql, args...)
+	return err
+}
+
+// ClaimOutboxMessages pushes the due messages back by lease and returns them, rows another relay is claiming
+// are skipped so every message goes to a single relay
+func (r *OutboxRepository) ClaimOutboxMessages(ctx context.Context, now time.Time, lease time.Duration, limit uint64) ([]domain.OutboxMessage, error) {
+	due := r.db.QueryBuilder.Select("id").
+		From("\"Outbox\"").
+		Where(sq.LtOrEq{"\"availableAt\"": now}).
+		Where(inSalon(ctx, "\"salonId\"")).
+		OrderBy("\"availableAt\"").
+		Limit(limit).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	query := r.db.QueryBuilder.Update("\"Outbox\"").
+		Set("\"availableAt\"", now.Add(lease)).
+		Set("attempts", sq.Expr("attempts + 1")).
+		Where(due.Prefix("id IN (").Suffix(")")).
+		Suffix("RETURNING " + strings.Join(outboxColumns, ", "))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []domain.OutboxMessage
+	for rows.Next() {
+		var message domain.OutboxMessage
+		err := scanOutboxMessage(rows, &message)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages, rows.Err()
+}
+
+// DeleteOutboxMessage deletes a relayed message
+func (r *OutboxRepository) DeleteOutboxMessage(ctx context.Context, id uuid.UUID) error {
+	query := r.db.QueryBuilder.Delete("\"Outbox\"").
+		Where(sq.Eq{"id": id}).
+		Where(inSalon(ctx, "\"salonId\""))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Conn.Exec(ctx, sql, args...)
+	return err
+}
+
+// RetryOutboxMessage records the error of a failed relay and makes the message due again at availableAt
+func (r *OutboxRepository) RetryOutboxMessage(ctx context.Context, id uuid.UUID, availableAt time.Time, lastError string) error {
+	query := r.db.QueryBuilder.Update("\"Outbox\"").
+		Set("\"availableAt\"", availableAt).
+		Set("\"lastError\"", lastError).
+		Where(sq.Eq{"id": id}).
+		Where(inSalon(ctx, "\"salonId\""))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Conn.Exec(ctx, sql, args...)
+	return err
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"fmt"
+	"reflect"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// setPartial sets the columns of a struct of optional fields, the fields are pointers tagged with
+// their column, e.g. `db:"lastName"`. A nil field keeps the current value of its column through
+// COALESCE, so callers only fill in what changed
+func setPartial(query sq.UpdateBuilder, fields interface{}) sq.UpdateBuilder {
+	v := reflect.ValueOf(fields)
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		column, ok := t.Field(i).Tag.Lookup("db")
+		if !ok {
+			continue
+		}
+
+		var value interface{}
+		if field := v.Field(i); !field.IsNil() {
+			value = field.Elem().Interface()
+		}
+
+		quoted := fmt.Sprintf("%q", column)
+		query = query.Set(quoted, sq.Expr(fmt.Sprintf("COALESCE(?, %s)", quoted), value))
+	}
+
+	return query
+}
+
+// optional returns a pointer to value for setPartial, nil when value is the zero value
+func optional[T comparable](value T) *T {
+	var zero T
+	if value == zero {
+		return nil
+	}
+
+	return &value
+}
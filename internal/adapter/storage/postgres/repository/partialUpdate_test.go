@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetPartial(t *testing.T) {
+	builder := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	sql, args, err := setPartial(builder.Update("users"), userUpdate{
+		Name:     optional("Mariana"),
+		LastName: optional("Mata"),
+	}).Where(sq.Eq{"id": 1}).ToSql()
+	require.NoError(t, err)
+
+	// Every column keeps its value unless the field is set, and each one is coalesced with itself
+	assert.Equal(t, `UPDATE users SET "name" = COALESCE($1, "name"), "lastName" = COALESCE($2, "lastName"), `+
+		`"secondLastName" = COALESCE($3, "secondLastName"), "email" = COALESCE($4, "email"), `+
+		`"password" = COALESCE($5, "password"), "role" = COALESCE($6, "role"), `+
+		`"preferredLanguage" = COALESCE($7, "preferredLanguage"), "phone" = COALESCE($8, "phone") WHERE id = $9`, sql)
+	assert.Equal(t, []interface{}{"Mariana", "Mata", nil, nil, nil, nil, nil, nil, 1}, args)
+}
+
+func TestSetPartialDereferencesValues(t *testing.T) {
+	builder := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	slotID := uuid.New()
+
+	_, args, err := setPartial(builder.Update(`"Appointment"`), appointmentUpdate{
+		SlotID: optional(slotID),
+		Status: optional(domain.Cancelled),
+	}).ToSql()
+	require.NoError(t, err)
+
+	assert.Equal(t, []interface{}{nil, slotID, nil, domain.Cancelled}, args)
+}
+
+func TestOptional(t *testing.T) {
+	assert.Nil(t, optional(""))
+	assert.Nil(t, optional(0.0))
+	assert.Nil(t, optional(uuid.Nil))
+	assert.Nil(t, optional(time.Time{}))
+
+	price := optional(450.0)
+	require.NotNil(t, price)
+	assert.Equal(t, 450.0, *price)
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/util"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+// PaymentEventRepository implements port.PaymentEventRepository interface and provides access to the postgres database
+type PaymentEventRepository struct {
+	db *postgres.DB
+}
+
+// NewPaymentEventRepository creates a new payment event repository instance
+func NewPaymentEventRepository(db *postgres.DB) *PaymentEventRepository {
+	return &PaymentEventRepository{
+		db,
+	}
+}
+
+// CreatePaymentEvent inserts a new payment event into the database
+func (r *PaymentEventRepository) CreatePaymentEvent(ctx context.Context, event *domain.PaymentEvent) (*domain.PaymentEvent, error) {
+	event.SalonID = util.SalonOr(ctx, event.SalonID)
+
+	query := r.db.QueryBuilder.Insert("\"PaymentEvent\"").
+		Columns("id", "provider", "\"externalId\"", "\"quoteId\"", "amount", "status", "\"salonId\"").
+		Values(event.ID, event.Provider, event.ExternalID, event.QuoteID, event.Amount, event.Status, event.SalonID).
+		Suffix("RETURNING \"receivedAt\"")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&event.ReceivedAt)
+	if err != nil {
+		switch r.db.ErrorCode(err) {
+		case "23505":
+			return nil, domain.ErrConflictingData
+		case "23503":
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// GetPaymentEventByExternalID selects a payment event by provider and the provider's own identifier
+func (r *PaymentEventRepository) GetPaymentEventByExternalID(ctx context.Context, provider domain.PaymentProvider, externalID string) (*domain.PaymentEvent, error) {
+	var event domain.PaymentEvent
+
+	query := r.db.QueryBuilder.Select("id", "provider", "\"externalId\"", "\"quoteId\"", "amount", "status", "\"receivedAt\"", "\"salonId\"").
+		From("\"PaymentEvent\"").
+		Where(sq.Eq{"provider": provider, "\"externalId\"": externalID}).
+		Where(inSalon(ctx, "\"salonId\"")).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(
+		&event.ID,
+		&event.Provider,
+		&event.ExternalID,
+		&event.QuoteID,
+		&event.Amount,
+		&event.Status,
+		&event.ReceivedAt,
+		&event.SalonID,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &event, nil
+}
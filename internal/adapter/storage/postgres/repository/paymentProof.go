@@ -5,7 +5,7 @@ import (
 	"harajuku/backend/internal/adapter/storage/postgres"
 	"harajuku/backend/internal/core/domain"
 	"harajuku/backend/internal/core/port"
-	"log/slog"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
@@ -24,24 +24,45 @@ func NewPaymentProofRepository(db *postgres.DB) *PaymentProofRepository {
 	}
 }
 
+// paymentProofColumns are the columns selected for a payment proof, in scan order
+var paymentProofColumns = []string{
+	"id",
+	"\"quoteId\"",
+	"url",
+	"\"isReviewed\"",
+	"\"fileName\"",
+	"size",
+	"\"contentType\"",
+	"checksum",
+}
+
+// scanPaymentProof scans a row selected with paymentProofColumns
+func scanPaymentProof(row pgx.Row, p *domain.PaymentProof) error {
+	return row.Scan(
+		&p.ID,
+		&p.QuoteID,
+		&p.URL,
+		&p.IsReviewed,
+		&p.FileName,
+		&p.Size,
+		&p.ContentType,
+		&p.Checksum,
+	)
+}
+
 // CreatePaymentProof inserts a new payment proof into the database
 func (r *PaymentProofRepository) CreatePaymentProof(ctx context.Context, paymentProof *domain.PaymentProof) (*domain.PaymentProof, error) {
 	query := r.db.QueryBuilder.Insert("\"PaymentProof\"").
-		Columns("id", "\"quoteId\"", "url").
-		Values(paymentProof.ID, paymentProof.QuoteID, paymentProof.URL).
-		Suffix("RETURNING *")
+		Columns("id", "\"quoteId\"", "url", "\"fileName\"", "size", "\"contentType\"", "checksum").
+		Values(paymentProof.ID, paymentProof.QuoteID, paymentProof.URL, paymentProof.FileName, paymentProof.Size, paymentProof.ContentType, paymentProof.Checksum).
+		Suffix("RETURNING " + strings.Join(paymentProofColumns, ", "))
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(
-		&paymentProof.ID,
-		&paymentProof.QuoteID,
-		&paymentProof.URL,
-		&paymentProof.IsReviewed,
-	)
+	err = scanPaymentProof(r.db.Conn.QueryRow(ctx, sql, args...), paymentProof)
 	if err != nil {
 		return nil, err
 	}
@@ -53,9 +74,10 @@ func (r *PaymentProofRepository) CreatePaymentProof(ctx context.Context, payment
 func (r *PaymentProofRepository) GetPaymentProofByID(ctx context.Context, id uuid.UUID) (*domain.PaymentProof, error) {
 	var paymentProof domain.PaymentProof
 
-	query := r.db.QueryBuilder.Select("*").
+	query := r.db.QueryBuilder.Select(paymentProofColumns...).
 		From("\"PaymentProof\"").
 		Where(sq.Eq{"id": id}).
+		Where(quoteInSalon(ctx)).
 		Limit(1)
 
 	sql, args, err := query.ToSql()
@@ -63,12 +85,7 @@ func (r *PaymentProofRepository) GetPaymentProofByID(ctx context.Context, id uui
 		return nil, err
 	}
 
-	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(
-		&paymentProof.ID,
-		&paymentProof.QuoteID,
-		&paymentProof.URL,
-		&paymentProof.IsReviewed,
-	)
+	err = scanPaymentProof(r.db.Reader().QueryRow(ctx, sql, args...), &paymentProof)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, domain.ErrDataNotFound
@@ -83,9 +100,10 @@ func (r *PaymentProofRepository) GetPaymentProofByID(ctx context.Context, id uui
 func (r *PaymentProofRepository) GetPaymentProofByQuoteID(ctx context.Context, quoteID uuid.UUID) (*domain.PaymentProof, error) {
 	var paymentProof domain.PaymentProof
 
-	query := r.db.QueryBuilder.Select("*").
+	query := r.db.QueryBuilder.Select(paymentProofColumns...).
 		From("\"PaymentProof\"").
 		Where(sq.Eq{"\"quoteId\"": quoteID}).
+		Where(quoteInSalon(ctx)).
 		Limit(1)
 
 	sql, args, err := query.ToSql()
@@ -93,12 +111,7 @@ func (r *PaymentProofRepository) GetPaymentProofByQuoteID(ctx context.Context, q
 		return nil, err
 	}
 
-	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(
-		&paymentProof.ID,
-		&paymentProof.QuoteID,
-		&paymentProof.URL,
-		&paymentProof.IsReviewed,
-	)
+	err = scanPaymentProof(r.db.Reader().QueryRow(ctx, sql, args...), &paymentProof)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, nil // No es error, simplemente no hay comprobante aún
@@ -113,24 +126,14 @@ func (r *PaymentProofRepository) GetPaymentProofByQuoteID(ctx context.Context, q
 func (r *PaymentProofRepository) GetPaymentProofs(ctx context.Context, filter port.PaymentProofFilter) ([]domain.PaymentProof, error) {
 	var paymentProofs []domain.PaymentProof
 
-	query := r.db.QueryBuilder.Select("id", "\"quoteId\"", "url", "\"isReviewed\"").
+	query := r.db.QueryBuilder.Select(paymentProofColumns...).
 		From("\"PaymentProof\"")
 
-	// Filtros opcionales
-	if filter.QuoteID != nil {
-		query = query.Where(sq.Eq{`"quoteId"`: *filter.QuoteID})
-	}
-	if filter.IsReviewed != nil {
-		query = query.Where(sq.Eq{`"isReviewed"`: *filter.IsReviewed})
-	}
+	query = applyPaymentProofFilter(ctx, query, filter)
 
-	// Paginación (skip = número de página - 1)
+	// Paginación (skip = número de página)
 	if filter.Limit > 0 {
-		offset := uint64(0)
-		if filter.Skip > 0 {
-			offset = (filter.Skip - 1) * filter.Limit
-		}
-		query = query.Limit(filter.Limit).Offset(offset)
+		query = query.Limit(filter.Limit).Offset(pageOffset(filter.Skip, filter.Limit))
 	}
 
 	sql, args, err := query.ToSql()
@@ -138,12 +141,9 @@ func (r *PaymentProofRepository) GetPaymentProofs(ctx context.Context, filter po
 		return nil, err
 	}
 
-	slog.DebugContext(ctx, "Executing query",
-		"sql", sql,
-		"args", args,
-		"filters", filter)
+	r.db.LogQuery(ctx, sql, args)
 
-	rows, err := r.db.Conn.Query(ctx, sql, args...)
+	rows, err := r.db.Reader().Query(ctx, sql, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -151,12 +151,7 @@ func (r *PaymentProofRepository) GetPaymentProofs(ctx context.Context, filter po
 
 	for rows.Next() {
 		var p domain.PaymentProof
-		err := rows.Scan(
-			&p.ID,
-			&p.QuoteID,
-			&p.URL,
-			&p.IsReviewed,
-		)
+		err := scanPaymentProof(rows, &p)
 		if err != nil {
 			return nil, err
 		}
@@ -170,24 +165,44 @@ func (r *PaymentProofRepository) GetPaymentProofs(ctx context.Context, filter po
 	return paymentProofs, nil
 }
 
+// CountPaymentProofs counts the payment proofs matching the filter, ignoring pagination
+func (r *PaymentProofRepository) CountPaymentProofs(ctx context.Context, filter port.PaymentProofFilter) (uint64, error) {
+	query := r.db.QueryBuilder.Select("id").
+		From("\"PaymentProof\"")
+
+	return countRows(ctx, r.db.Reader(), applyPaymentProofFilter(ctx, query, filter))
+}
+
+// applyPaymentProofFilter adds the optional filters shared by the listing and its count
+func applyPaymentProofFilter(ctx context.Context, query sq.SelectBuilder, filter port.PaymentProofFilter) sq.SelectBuilder {
+	query = query.Where(quoteInSalon(ctx))
+	if filter.QuoteID != nil {
+		query = query.Where(sq.Eq{`"quoteId"`: *filter.QuoteID})
+	}
+	if filter.IsReviewed != nil {
+		query = query.Where(sq.Eq{`"isReviewed"`: *filter.IsReviewed})
+	}
+	if filter.Checksum != nil {
+		query = query.Where(sq.Eq{"checksum": *filter.Checksum})
+	}
+
+	return query
+}
+
 // UpdatePaymentProof updates only the IsReviewed field of a payment proof
 func (r *PaymentProofRepository) UpdatePaymentProof(ctx context.Context, paymentProof *domain.PaymentProof) (*domain.PaymentProof, error) {
 	query := r.db.QueryBuilder.Update("\"PaymentProof\"").
 		Set("\"isReviewed\"", paymentProof.IsReviewed).
 		Where(sq.Eq{"id": paymentProof.ID}).
-		Suffix("RETURNING id, \"quoteId\", url, \"isReviewed\"")
+		Where(quoteInSalon(ctx)).
+		Suffix("RETURNING " + strings.Join(paymentProofColumns, ", "))
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(
-		&paymentProof.ID,
-		&paymentProof.QuoteID,
-		&paymentProof.URL,
-		&paymentProof.IsReviewed,
-	)
+	err = scanPaymentProof(r.db.Conn.QueryRow(ctx, sql, args...), paymentProof)
 	if err != nil {
 		return nil, err
 	}
@@ -198,7 +213,8 @@ func (r *PaymentProofRepository) UpdatePaymentProof(ctx context.Context, payment
 // DeletePaymentProof deletes a payment proof by ID from the database
 func (r *PaymentProofRepository) DeletePaymentProof(ctx context.Context, id uuid.UUID) error {
 	query := r.db.QueryBuilder.Delete("\"PaymentProof\"").
-		Where(sq.Eq{"id": id})
+		Where(sq.Eq{"id": id}).
+		Where(quoteInSalon(ctx))
 
 	sql, args, err := query.ToSql()
 	if err != nil {
@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// PromotionRepository implements port.PromotionRepository interface and provides access to the postgres database
+type PromotionRepository struct {
+	db *postgres.DB
+}
+
+// NewPromotionRepository creates a new promotion repository instance
+func NewPromotionRepository(db *postgres.DB) *PromotionRepository {
+	return &PromotionRepository{
+		db,
+	}
+}
+
+// promotionColumns are the columns selected for a promotion, in scan order; the types of service are aggregated into an array
+var promotionColumns = []string{
+	"id",
+	"name",
+	"\"discountType\"",
+	"value",
+	"COALESCE(currency, '')",
+	"\"startsAt\"",
+	"\"endsAt\"",
+	"ARRAY(SELECT \"typeOfServiceId\" FROM \"PromotionTypeOfService\" WHERE \"promotionId\" = \"Promotion\".id)",
+	"\"salonId\"",
+}
+
+// scanPromotion scans a row selected with promotionColumns
+func scanPromotion(row pgx.Row, p *domain.Promotion) error {
+	return row.Scan(
+		&p.ID,
+		&p.Name,
+		&p.DiscountType,
+		&p.Value,
+		&p.Currency,
+		&p.StartsAt,
+		&p.EndsAt,
+		&p.TypeOfServiceIDs,
+		&p.SalonID,
+	)
+}
+
+// nullableCurrency stores percentage promotions without a currency
+func nullableCurrency(c domain.Currency) *domain.Currency {
+	if c == "" {
+		return nil
+	}
+	return &c
+}
+
+// CreatePromotion inserts a new promotion and its types of service into the database
+func (r *PromotionRepository) CreatePromotion(ctx context.Context, promotion *domain.Promotion) (*domain.Promotion, error) {
+	promotion.SalonID = util.SalonOr(ctx, promotion.SalonID)
+
+	err := r.db.WithTx(ctx, func(txDB *postgres.DB) error {
+		query := txDB.QueryBuilder.Insert("\"Promotion\"").
+			Columns("id", "name", "\"discountType\"", "value", "currency", "\"startsAt\"", "\"endsAt\"", "\"salonId\"").
+			Values(promotion.ID, promotion.Name, promotion.DiscountType, promotion.Value, nullableCurrency(promotion.Currency), promotion.StartsAt, promotion.EndsAt, promotion.SalonID)
+
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = txDB.Conn.Exec(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+
+		return insertPromotionTypesOfService(ctx, txDB, promotion)
+	})
+	if err != nil {
+		if errCode := r.db.ErrorCode(err); errCode == "23503" {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+// GetPromotionByID retrieves a promotion by ID
+func (r *PromotionRepository) GetPromotionByID(ctx context.Context, id uuid.UUID) (*domain.Promotion, error) {
+	var p domain.Promotion
+
+	query := r.db.QueryBuilder.Select(promotionColumns...).
+		From("\"Promotion\"").
+		Where(sq.Eq{"id": id}).
+		Where(inSalon(ctx, "\"salonId\"")).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanPromotion(r.db.Reader().QueryRow(ctx, sql, args...), &p)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// ListPromotions retrieves a list of promotions ordered by the start of their validity window
+func (r *PromotionRepository) ListPromotions(ctx context.Context, filter port.PromotionFilter) ([]domain.Promotion, error) {
+	var promotions []domain.Promotion
+
+	query := r.db.QueryBuilder.Select(promotionColumns...).
+		From("\"Promotion\"").
+		Where(inSalon(ctx, "\"salonId\"")).
+		OrderBy("\"startsAt\" DESC", "name")
+
+	if filter.ActiveAt != nil {
+		query = query.Where(sq.LtOrEq{"\"startsAt\"": *filter.ActiveAt}).
+			Where(sq.Gt{"\"endsAt\"": *filter.ActiveAt})
+	}
+
+	if filter.TypeOfServiceID != nil {
+		query = query.Where(sq.Or{
+			sq.Expr("NOT EXISTS (SELECT 1 FROM \"PromotionTypeOfService\" WHERE \"promotionId\" = \"Promotion\".id)"),
+			sq.Expr("EXISTS (SELECT 1 FROM \"PromotionTypeOfService\" WHERE \"promotionId\" = \"Promotion\".id AND \"typeOfServiceId\" = ?)", *filter.TypeOfServiceID),
+		})
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit).
+			Offset(pageOffset(filter.Skip, filter.Limit))
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	r.db.LogQuery(ctx, sql, args)
+
+	rows, err := r.db.Reader().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p domain.Promotion
+		if err := scanPromotion(rows, &p); err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+// UpdatePromotion updates a promotion and replaces its types of service
+func (r *PromotionRepository) UpdatePromotion(ctx context.Context, promotion *domain.Promotion) (*domain.Promotion, error) {
+	err := r.db.WithTx(ctx, func(txDB *postgres.DB) error {
+		query := txDB.QueryBuilder.Update("\"Promotion\"").
+			Set("name", promotion.Name).
+			Set("\"discountType\"", promotion.DiscountType).
+			Set("value", promotion.Value).
+			Set("currency", nullableCurrency(promotion.Currency)).
+			Set("\"startsAt\"", promotion.StartsAt).
+			Set("\"endsAt\"", promotion.EndsAt).
+			Where(sq.Eq{"id": promotion.ID}).
+			Where(inSalon(ctx, "\"salonId\""))
+
+		sql, args, err := query.ToSql()
+		if err != nil {
+			return err
+		}
+
+		tag, err := txDB.Conn.Exec(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return domain.ErrDataNotFound
+		}
+
+		deleteQuery := txDB.QueryBuilder.Delete("\"PromotionTypeOfService\"").
+			Where(sq.Eq{"\"promotionId\"": promotion.ID})
+
+		sql, args, err = deleteQuery.ToSql()
+		if err != nil {
+			return err
+		}
+
+		_, err = txDB.Conn.Exec(ctx, sql, args...)
+		if err != nil {
+			return err
+		}
+
+		return insertPromotionTypesOfService(ctx, txDB, promotion)
+	})
+	if err != nil {
+		if errCode := r.db.ErrorCode(err); errCode == "23503" {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+// DeletePromotion deletes a promotion by ID, quotes priced with it keep their discount
+func (r *PromotionRepository) DeletePromotion(ctx context.Context, id uuid.UUID) error {
+	query := r.db.QueryBuilder.Delete("\"Promotion\"").
+		Where(sq.Eq{"id": id}).
+		Where(inSalon(ctx, "\"salonId\""))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Conn.Exec(ctx, sql, args...)
+	return err
+}
+
+// insertPromotionTypesOfService links a promotion to the types of service it applies to
+func insertPromotionTypesOfService(ctx context.Context, db *postgres.DB, promotion *domain.Promotion) error {
+	if len(promotion.TypeOfServiceIDs) == 0 {
+		return nil
+	}
+
+	query := db.QueryBuilder.Insert("\"PromotionTypeOfService\"").
+		Columns("\"promotionId\"", "\"typeOfServiceId\"")
+
+	for _, typeOfServiceID := range promotion.TypeOfServiceIDs {
+		query = query.Values(promotion.ID, typeOfServiceID)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Conn.Exec(ctx, sql, args...)
+	return err
+}
@@ -5,7 +5,8 @@ import (
 	"harajuku/backend/internal/adapter/storage/postgres"
 	"harajuku/backend/internal/core/domain"
 	"harajuku/backend/internal/core/port"
-	"log/slog"
+	"harajuku/backend/internal/core/util"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
@@ -24,17 +25,19 @@ func NewQuoteRepository(db *postgres.DB) *QuoteRepository {
 
 // CreateQuote creates a new quote in the database
 func (r *QuoteRepository) CreateQuote(ctx context.Context, quote *domain.Quote) (*domain.Quote, error) {
+	quote.SalonID = util.SalonOr(ctx, quote.SalonID)
+
 	query := r.db.QueryBuilder.Insert("\"Quote\"").
-		Columns("id", "\"typeOfServiceId\"", "\"clientId\"", "\"time\"", "\"description\"", "\"state\"", "\"price\"").
-		Values(quote.ID, quote.TypeOfServiceID, quote.ClientID, quote.Time, quote.Description, quote.State, quote.Price).
-		Suffix("RETURNING id")
+		Columns("id", "\"typeOfServiceId\"", "\"clientId\"", "\"time\"", "\"description\"", "\"state\"", "\"price\"", "\"currency\"", "\"promotionId\"", "\"discount\"", "\"couponId\"", "\"couponDiscount\"", "\"salonId\"").
+		Values(quote.ID, quote.TypeOfServiceID, quote.ClientID, quote.Time, quote.Description, quote.State, quote.Price, quote.Currency, quote.PromotionID, quote.Discount, quote.CouponID, quote.CouponDiscount, quote.SalonID).
+		Suffix("RETURNING id, \"version\"")
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&quote.ID)
+	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&quote.ID, &quote.Version)
 	if err != nil {
 		return nil, err
 	}
@@ -46,17 +49,21 @@ func (r *QuoteRepository) CreateQuote(ctx context.Context, quote *domain.Quote)
 func (r *QuoteRepository) GetQuoteByID(ctx context.Context, id uuid.UUID) (*domain.Quote, error) {
 	var q domain.Quote
 
-	query := r.db.QueryBuilder.Select("id", "\"typeOfServiceId\"", "\"clientId\"", "\"time\"", "\"description\"", "\"state\"", "\"price\"").
+	query := r.db.QueryBuilder.Select("id", "\"typeOfServiceId\"", "\"clientId\"", "\"time\"", "\"description\"", "\"state\"", "\"price\"", "\"currency\"", "\"promotionId\"", "\"discount\"", "\"couponId\"", "\"couponDiscount\"", "\"version\"", "\"salonId\"").
 		From("\"Quote\"").
 		Where(sq.Eq{"id": id}).
+		Where(inSalon(ctx, `"salonId"`)).
 		Limit(1)
 
+	query = notDeleted(query, `"Quote"`, false)
+
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&q.ID, &q.TypeOfServiceID, &q.ClientID, &q.Time, &q.Description, &q.State, &q.Price)
+	// Services read the row before writing it and compare its version, so the lookup stays on the primary
+	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&q.ID, &q.TypeOfServiceID, &q.ClientID, &q.Time, &q.Description, &q.State, &q.Price, &q.Currency, &q.PromotionID, &q.Discount, &q.CouponID, &q.CouponDiscount, &q.Version, &q.SalonID)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, domain.ErrDataNotFound
@@ -67,13 +74,65 @@ func (r *QuoteRepository) GetQuoteByID(ctx context.Context, id uuid.UUID) (*doma
 	return &q, nil
 }
 
-// ListQuotes retrieves a list of quotes from the database
-func (r *QuoteRepository) ListQuotes(ctx context.Context, filter port.QuoteFilter) ([]domain.Quote, error) {
+// quoteKeyset orders quotes by their time
+var quoteKeyset = keyset{`"time"`, "id"}
+
+// ListQuotes retrieves a page of quotes from the database and the cursor of the next one
+func (r *QuoteRepository) ListQuotes(ctx context.Context, filter port.QuoteFilter) ([]domain.Quote, *domain.Cursor, error) {
 	var quotes []domain.Quote
 
-	query := r.db.QueryBuilder.Select("id", "\"typeOfServiceId\"", "\"clientId\"", "time", "description", "state", "price").
+	query := r.db.QueryBuilder.Select("id", "\"typeOfServiceId\"", "\"clientId\"", "time", "description", "state", "price", "currency", "\"promotionId\"", "discount", "\"couponId\"", "\"couponDiscount\"", "\"deletedAt\"", "\"version\"", "\"salonId\"").
+		From("\"Quote\"")
+
+	query = applyQuoteFilter(ctx, query, filter)
+	query = quoteKeyset.paginate(query, filter.After, filter.Skip, filter.Limit)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Debug logging - crucial for troubleshooting
+	r.db.LogQuery(ctx, sql, args)
+
+	rows, err := r.db.Reader().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var q domain.Quote
+		if err := rows.Scan(&q.ID, &q.TypeOfServiceID, &q.ClientID, &q.Time, &q.Description, &q.State, &q.Price, &q.Currency, &q.PromotionID, &q.Discount, &q.CouponID, &q.CouponDiscount, &q.DeletedAt, &q.Version, &q.SalonID); err != nil {
+			return nil, nil, err
+		}
+		quotes = append(quotes, q)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	next := nextCursor(quotes, filter.Limit, func(q domain.Quote) domain.Cursor {
+		return domain.Cursor{Time: q.Time, ID: q.ID}
+	})
+
+	return quotes, next, nil
+}
+
+// CountQuotes counts the quotes matching the filter, ignoring pagination
+func (r *QuoteRepository) CountQuotes(ctx context.Context, filter port.QuoteFilter) (uint64, error) {
+	query := r.db.QueryBuilder.Select("id").
 		From("\"Quote\"")
 
+	return countRows(ctx, r.db.Reader(), applyQuoteFilter(ctx, query, filter))
+}
+
+// applyQuoteFilter adds the conditions shared by the listing and its count
+func applyQuoteFilter(ctx context.Context, query sq.SelectBuilder, filter port.QuoteFilter) sq.SelectBuilder {
+	query = notDeleted(query, `"Quote"`, filter.IncludeDeleted).
+		Where(inSalon(ctx, `"salonId"`))
+
 	if filter.TypeOfServiceID != nil {
 		query = query.Where(sq.Eq{`"typeOfServiceId"`: *filter.TypeOfServiceID})
 	}
@@ -94,82 +153,65 @@ func (r *QuoteRepository) ListQuotes(ctx context.Context, filter port.QuoteFilte
 		query = query.Where(sq.Eq{`"state"`: *filter.ByState})
 	}
 
-	// Paginación (skip = número de página - 1)
-	if filter.Limit > 0 {
-		offset := ((filter.Skip - 1) * filter.Limit)
-		query = query.Limit(filter.Limit).Offset(offset)
-	}
-
-	sql, args, err := query.ToSql()
-	if err != nil {
-		return nil, err
-	}
-
-	// Debug logging - crucial for troubleshooting
-	slog.DebugContext(ctx, "Executing query", "sql", sql, "args", args)
-
-	rows, err := r.db.Conn.Query(ctx, sql, args...)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var q domain.Quote
-		if err := rows.Scan(&q.ID, &q.TypeOfServiceID, &q.ClientID, &q.Time, &q.Description, &q.State, &q.Price); err != nil {
-			return nil, err
-		}
-		quotes = append(quotes, q)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
+	return query
+}
 
-	return quotes, nil
+// quoteUpdate holds the columns UpdateQuote changes, nil fields are left as they are
+type quoteUpdate struct {
+	TypeOfServiceID *uuid.UUID         `db:"typeOfServiceId"`
+	ClientID        *uuid.UUID         `db:"clientId"`
+	Time            *time.Time         `db:"time"`
+	Description     *string            `db:"description"`
+	State           *domain.QuoteState `db:"state"`
+	Price           *float64           `db:"price"`
+	// The currency follows the type of service and is only rewritten when it changes
+	Currency *domain.Currency `db:"currency"`
 }
 
-// UpdateQuote updates an existing quote in the database
+// UpdateQuote updates the non-empty fields of an existing quote in the database,
+// the promotion, the coupon and their discounts are always written as pricing may remove them
 func (r *QuoteRepository) UpdateQuote(ctx context.Context, quote *domain.Quote) (*domain.Quote, error) {
-	query := r.db.QueryBuilder.Update("\"Quote\"").
-		Set("\"typeOfServiceId\"", quote.TypeOfServiceID).
-		Set("\"clientId\"", quote.ClientID).
-		Set("time", quote.Time).
-		Set("description", quote.Description).
-		Set("state", quote.State).
-		Set("price", quote.Price).
+	query := setPartial(r.db.QueryBuilder.Update("\"Quote\""), quoteUpdate{
+		TypeOfServiceID: optional(quote.TypeOfServiceID),
+		ClientID:        optional(quote.ClientID),
+		Time:            optional(quote.Time),
+		Description:     optional(quote.Description),
+		State:           optional(quote.State),
+		Price:           optional(quote.Price),
+		Currency:        optional(quote.Currency),
+	}).
+		Set("\"promotionId\"", quote.PromotionID).
+		Set("discount", quote.Discount).
+		Set("\"couponId\"", quote.CouponID).
+		Set("\"couponDiscount\"", quote.CouponDiscount).
 		Where(sq.Eq{"id": quote.ID}).
-		Suffix("RETURNING *")
+		Where(inSalon(ctx, `"salonId"`))
+
+	query = lockVersion(query, quote.Version)
+
+	query = query.
+		Suffix("RETURNING id, \"typeOfServiceId\", \"clientId\", time, description, state, price, currency, \"promotionId\", discount, \"couponId\", \"couponDiscount\", \"version\", \"salonId\"")
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&quote.ID, &quote.TypeOfServiceID, &quote.ClientID, &quote.Time, &quote.Description, &quote.State, &quote.Price)
+	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&quote.ID, &quote.TypeOfServiceID, &quote.ClientID, &quote.Time, &quote.Description, &quote.State, &quote.Price, &quote.Currency, &quote.PromotionID, &quote.Discount, &quote.CouponID, &quote.CouponDiscount, &quote.Version, &quote.SalonID)
 	if err != nil {
+		// The quote changed since it was read
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrConflictingData
+		}
 		return nil, err
 	}
 
 	return quote, nil
 }
 
-// DeleteQuote deletes a quote by ID from the database
+// DeleteQuote soft deletes a quote by ID, it stays in the database for admins
 func (r *QuoteRepository) DeleteQuote(ctx context.Context, id uuid.UUID) error {
-	query := r.db.QueryBuilder.Delete("\"Quote\"").
-		Where(sq.Eq{"id": id})
-
-	sql, args, err := query.ToSql()
-	if err != nil {
-		return err
-	}
-
-	_, err = r.db.Conn.Exec(ctx, sql, args...)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return softDelete(ctx, r.db, `"Quote"`, id)
 }
 
 func (r *QuoteRepository) WithTx(
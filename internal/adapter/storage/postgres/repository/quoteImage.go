@@ -5,7 +5,7 @@ import (
 	"harajuku/backend/internal/adapter/storage/postgres"
 	"harajuku/backend/internal/core/domain"
 	"harajuku/backend/internal/core/port"
-	"log/slog"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
@@ -24,23 +24,43 @@ func NewQuoteImageRepository(db *postgres.DB) *QuoteImageRepository {
 	}
 }
 
+// quoteImageColumns are the columns selected for a quote image, in scan order
+var quoteImageColumns = []string{
+	"id",
+	"\"quoteId\"",
+	"url",
+	"\"fileName\"",
+	"size",
+	"\"contentType\"",
+	"checksum",
+}
+
+// scanQuoteImage scans a row selected with quoteImageColumns
+func scanQuoteImage(row pgx.Row, i *domain.QuoteImage) error {
+	return row.Scan(
+		&i.ID,
+		&i.QuoteID,
+		&i.URL,
+		&i.FileName,
+		&i.Size,
+		&i.ContentType,
+		&i.Checksum,
+	)
+}
+
 // CreateQuoteImage inserts a new quote image into the database
 func (r *QuoteImageRepository) CreateQuoteImage(ctx context.Context, quoteImage *domain.QuoteImage) (*domain.QuoteImage, error) {
 	query := r.db.QueryBuilder.Insert("\"QuoteImages\"").
-		Columns("id", "\"quoteId\"", "url").
-		Values(quoteImage.ID, quoteImage.QuoteID, quoteImage.URL).
-		Suffix("RETURNING *")
+		Columns("id", "\"quoteId\"", "url", "\"fileName\"", "size", "\"contentType\"", "checksum").
+		Values(quoteImage.ID, quoteImage.QuoteID, quoteImage.URL, quoteImage.FileName, quoteImage.Size, quoteImage.ContentType, quoteImage.Checksum).
+		Suffix("RETURNING " + strings.Join(quoteImageColumns, ", "))
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(
-		&quoteImage.ID,
-		&quoteImage.QuoteID,
-		&quoteImage.URL,
-	)
+	err = scanQuoteImage(r.db.Conn.QueryRow(ctx, sql, args...), quoteImage)
 	if err != nil {
 		return nil, err
 	}
@@ -52,9 +72,10 @@ func (r *QuoteImageRepository) CreateQuoteImage(ctx context.Context, quoteImage
 func (r *QuoteImageRepository) GetQuoteImageByID(ctx context.Context, id uuid.UUID) (*domain.QuoteImage, error) {
 	var quoteImage domain.QuoteImage
 
-	query := r.db.QueryBuilder.Select("*").
+	query := r.db.QueryBuilder.Select(quoteImageColumns...).
 		From("\"QuoteImages\"").
 		Where(sq.Eq{"id": id}).
+		Where(quoteInSalon(ctx)).
 		Limit(1)
 
 	sql, args, err := query.ToSql()
@@ -62,11 +83,7 @@ func (r *QuoteImageRepository) GetQuoteImageByID(ctx context.Context, id uuid.UU
 		return nil, err
 	}
 
-	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(
-		&quoteImage.ID,
-		&quoteImage.QuoteID,
-		&quoteImage.URL,
-	)
+	err = scanQuoteImage(r.db.Reader().QueryRow(ctx, sql, args...), &quoteImage)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, domain.ErrDataNotFound
@@ -82,39 +99,38 @@ func (r *QuoteImageRepository) GetQuoteImages(ctx context.Context, skip, limit u
 	var quoteImage domain.QuoteImage
 	var quoteImages []domain.QuoteImage
 
-	query := r.db.QueryBuilder.Select("*").
+	query := r.db.QueryBuilder.Select(quoteImageColumns...).
 		From("\"QuoteImages\"").
+		Where(quoteInSalon(ctx)).
 		Limit(limit).
-		Offset((skip - 1) * limit)
+		Offset(pageOffset(skip, limit))
 
 	// Apply filter for QuoteID
 	if filters.QuoteID != nil {
 		query = query.Where(sq.Eq{"\"quoteId\"": *filters.QuoteID})
 	}
 
+	// Apply filter for Checksum
+	if filters.Checksum != nil {
+		query = query.Where(sq.Eq{"checksum": *filters.Checksum})
+	}
+
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
 	// Debug logging - useful for troubleshooting
-	slog.DebugContext(ctx, "Executing query",
-		"sql", sql,
-		"args", args,
-		"filters", filters)
+	r.db.LogQuery(ctx, sql, args)
 
-	rows, err := r.db.Conn.Query(ctx, sql, args...)
+	rows, err := r.db.Reader().Query(ctx, sql, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		err := rows.Scan(
-			&quoteImage.ID,
-			&quoteImage.QuoteID,
-			&quoteImage.URL,
-		)
+		err := scanQuoteImage(rows, &quoteImage)
 		if err != nil {
 			return nil, err
 		}
@@ -130,19 +146,20 @@ func (r *QuoteImageRepository) UpdateQuoteImage(ctx context.Context, quoteImage
 	query := r.db.QueryBuilder.Update("\"QuoteImages\"").
 		Set("\"quoteId\"", quoteImage.QuoteID).
 		Set("url", quoteImage.URL).
+		Set("\"fileName\"", quoteImage.FileName).
+		Set("size", quoteImage.Size).
+		Set("\"contentType\"", quoteImage.ContentType).
+		Set("checksum", quoteImage.Checksum).
 		Where(sq.Eq{"id": quoteImage.ID}).
-		Suffix("RETURNING *")
+		Where(quoteInSalon(ctx)).
+		Suffix("RETURNING " + strings.Join(quoteImageColumns, ", "))
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(
-		&quoteImage.ID,
-		&quoteImage.QuoteID,
-		&quoteImage.URL,
-	)
+	err = scanQuoteImage(r.db.Conn.QueryRow(ctx, sql, args...), quoteImage)
 	if err != nil {
 		return nil, err
 	}
@@ -153,7 +170,8 @@ func (r *QuoteImageRepository) UpdateQuoteImage(ctx context.Context, quoteImage
 // DeleteQuoteImage deletes a quote image by ID from the database
 func (r *QuoteImageRepository) DeleteQuoteImage(ctx context.Context, id uuid.UUID) error {
 	query := r.db.QueryBuilder.Delete("\"QuoteImages\"").
-		Where(sq.Eq{"id": id})
+		Where(sq.Eq{"id": id}).
+		Where(quoteInSalon(ctx))
 
 	sql, args, err := query.ToSql()
 	if err != nil {
@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/core/domain"
+	"time"
+)
+
+// ReportRepository implements port.ReportRepository interface and provides access to the postgres database
+type ReportRepository struct {
+	db *postgres.DB
+}
+
+// NewReportRepository creates a new report repository instance
+func NewReportRepository(db *postgres.DB) *ReportRepository {
+	return &ReportRepository{
+		db,
+	}
+}
+
+// GetStaffUtilization sums the offered, booked and completed slot time of every admin with slots starting
+// between start and end, deleted slots and appointments left out. A slot counts as booked while it holds a
+// booked or completed appointment, as the booked slot filter does
+func (r *ReportRepository) GetStaffUtilization(ctx context.Context, start, end time.Time) ([]domain.StaffUtilization, error) {
+	var utilization []domain.StaffUtilization
+
+	sql := `
+        WITH slots AS (
+            SELECT
+                s."adminId",
+                EXTRACT(EPOCH FROM s."endTime" - s."startTime")::FLOAT8 AS seconds,
+                EXISTS (
+                    SELECT 1 FROM "Appointment" a
+                    WHERE a."slotId" = s."id" AND a."deletedAt" IS NULL AND a."status" IN ('booked', 'completed')
+                ) AS booked,
+                EXISTS (
+                    SELECT 1 FROM "Appointment" a
+                    WHERE a."slotId" = s."id" AND a."deletedAt" IS NULL AND a."status" = 'completed'
+                ) AS completed
+            FROM "AvailabilitySlot" s
+            WHERE s."deletedAt" IS NULL AND s."startTime" >= $1 AND s."startTime" < $2
+                AND ($3::UUID IS NULL OR s."salonId" = $3)
+        )
+        SELECT
+            u."id",
+            CONCAT_WS(' ', u."name", u."lastName", u."secondLastName"),
+            COUNT(*),
+            COUNT(*) FILTER (WHERE slots.booked),
+            COUNT(*) FILTER (WHERE slots.completed),
+            COALESCE(SUM(slots.seconds), 0),
+            COALESCE(SUM(slots.seconds) FILTER (WHERE slots.booked), 0),
+            COALESCE(SUM(slots.seconds) FILTER (WHERE slots.completed), 0)
+        FROM slots
+        JOIN "users" u ON u."id" = slots."adminId"
+        GROUP BY u."id"
+        ORDER BY u."name", u."lastName", u."id"
+    `
+
+	salon := salonArg(ctx)
+	r.db.LogQuery(ctx, sql, []any{start, end, salon})
+
+	rows, err := r.db.Reader().Query(ctx, sql, start, end, salon)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u domain.StaffUtilization
+		var offered, booked, completed float64
+		err := rows.Scan(
+			&u.AdminID,
+			&u.AdminName,
+			&u.Slots,
+			&u.BookedSlots,
+			&u.CompletedSlots,
+			&offered,
+			&booked,
+			&completed,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		u.Offered = secondsDuration(offered)
+		u.Booked = secondsDuration(booked)
+		u.Completed = secondsDuration(completed)
+		utilization = append(utilization, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return utilization, nil
+}
+
+// clientVisitsSQL selects the visits, the completed appointments that weren't deleted, with their client
+// and the start of their slot. The salon they are restricted to is its $3 argument
+const clientVisitsSQL = `
+        SELECT a."clientId", s."startTime"
+        FROM "Appointment" a
+        JOIN "AvailabilitySlot" s ON s."id" = a."slotId"
+        WHERE a."deletedAt" IS NULL AND a."status" = 'completed'
+            AND ($3::UUID IS NULL OR a."salonId" = $3)
+    `
+
+// ListClientRetentionMonths counts the new and returning clients of every month with visits between start and
+// end. A client is new in the month of their first visit ever, even when it is before start
+func (r *ReportRepository) ListClientRetentionMonths(ctx context.Context, start, end time.Time) ([]domain.ClientRetentionMonth, error) {
+	var months []domain.ClientRetentionMonth
+
+	sql := `
+        WITH visits AS (` + clientVisitsSQL + `),
+        firsts AS (
+            SELECT "clientId", DATE_TRUNC('month', MIN("startTime") AT TIME ZONE 'UTC') AS "firstMonth"
+            FROM visits
+            GROUP BY "clientId"
+        )
+        SELECT
+            DATE_TRUNC('month', v."startTime" AT TIME ZONE 'UTC') AS "month",
+            COUNT(DISTINCT v."clientId") FILTER (WHERE f."firstMonth" = DATE_TRUNC('month', v."startTime" AT TIME ZONE 'UTC')),
+            COUNT(DISTINCT v."clientId") FILTER (WHERE f."firstMonth" < DATE_TRUNC('month', v."startTime" AT TIME ZONE 'UTC')),
+            COUNT(*)
+        FROM visits v
+        JOIN firsts f ON f."clientId" = v."clientId"
+        WHERE v."startTime" >= $1 AND v."startTime" < $2
+        GROUP BY "month"
+        ORDER BY "month"
+    `
+
+	salon := salonArg(ctx)
+	r.db.LogQuery(ctx, sql, []any{start, end, salon})
+
+	rows, err := r.db.Reader().Query(ctx, sql, start, end, salon)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var m domain.ClientRetentionMonth
+		if err := rows.Scan(&m.Month, &m.NewClients, &m.ReturningClients, &m.Visits); err != nil {
+			return nil, err
+		}
+		months = append(months, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return months, nil
+}
+
+// GetAverageVisitGap averages the time between every visit between start and end and the previous visit of its
+// client, which may be before start. It is zero when no client in the period had visited before
+func (r *ReportRepository) GetAverageVisitGap(ctx context.Context, start, end time.Time) (time.Duration, error) {
+	sql := `
+        WITH visits AS (` + clientVisitsSQL + `),
+        gaps AS (
+            SELECT
+                "startTime",
+                "startTime" - LAG("startTime") OVER (PARTITION BY "clientId" ORDER BY "startTime") AS "gap"
+            FROM visits
+        )
+        SELECT COALESCE(AVG(EXTRACT(EPOCH FROM "gap")), 0)::FLOAT8
+        FROM gaps
+        WHERE "gap" IS NOT NULL AND "startTime" >= $1 AND "startTime" < $2
+    `
+
+	salon := salonArg(ctx)
+	r.db.LogQuery(ctx, sql, []any{start, end, salon})
+
+	var seconds float64
+	err := r.db.Reader().QueryRow(ctx, sql, start, end, salon).Scan(&seconds)
+	if err != nil {
+		return 0, err
+	}
+
+	return secondsDuration(seconds), nil
+}
+
+// ListTopClients selects the limit clients who spent the most on the quotes of their visits between start and
+// end, one row per client and currency
+func (r *ReportRepository) ListTopClients(ctx context.Context, start, end time.Time, limit uint64) ([]domain.ClientSpend, error) {
+	var clients []domain.ClientSpend
+
+	sql := `
+        SELECT
+            u."id",
+            CONCAT_WS(' ', u."name", u."lastName", u."secondLastName"),
+            COUNT(*),
+            SUM(q."price" - q."discount" - q."couponDiscount")::FLOAT8 AS "spent",
+            q."currency"
+        FROM "Appointment" a
+        JOIN "AvailabilitySlot" s ON s."id" = a."slotId"
+        JOIN "Quote" q ON q."id" = a."quoteId"
+        JOIN "users" u ON u."id" = a."clientId"
+        WHERE a."deletedAt" IS NULL AND a."status" = 'completed'
+            AND s."startTime" >= $1 AND s."startTime" < $2
+            AND ($4::UUID IS NULL OR a."salonId" = $4)
+        GROUP BY u."id", q."currency"
+        ORDER BY "spent" DESC, u."id"
+        LIMIT $3
+    `
+
+	salon := salonArg(ctx)
+	r.db.LogQuery(ctx, sql, []any{start, end, limit, salon})
+
+	rows, err := r.db.Reader().Query(ctx, sql, start, end, limit, salon)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c domain.ClientSpend
+		if err := rows.Scan(&c.ClientID, &c.ClientName, &c.Visits, &c.Spent, &c.Currency); err != nil {
+			return nil, err
+		}
+		clients = append(clients, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return clients, nil
+}
+
+// secondsDuration converts a number of seconds summed by postgres into a duration
+func secondsDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
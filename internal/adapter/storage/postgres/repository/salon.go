@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/util"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// inSalon restricts a query to the rows whose column is the salon of ctx. Outside of a salon, for the
+// workers and the scheduled tasks, the rows of every salon are reached
+func inSalon(ctx context.Context, column string) sq.Sqlizer {
+	if salon, ok := util.Salon(ctx); ok {
+		return sq.Eq{column: salon}
+	}
+	return sq.Eq{}
+}
+
+// quoteInSalon restricts a query on the rows hanging from a quote, like its images and payment proofs, to
+// those whose quote is in the salon of ctx
+func quoteInSalon(ctx context.Context) sq.Sqlizer {
+	if salon, ok := util.Salon(ctx); ok {
+		return sq.Expr(`"quoteId" IN (SELECT id FROM "Quote" WHERE "salonId" = ?)`, salon)
+	}
+	return sq.Eq{}
+}
+
+// salonArg is the argument of the raw queries restricted with a condition like
+// ($1::UUID IS NULL OR "salonId" = $1), nil outside of a salon
+func salonArg(ctx context.Context) *uuid.UUID {
+	if salon, ok := util.Salon(ctx); ok {
+		return &salon
+	}
+	return nil
+}
+
+// salonColumns are the columns of a salon in the order they are scanned
+const salonColumns = `id, name, slug, "createdAt", "updatedAt"`
+
+// SalonRepository implements port.SalonRepository interface and provides access to the postgres database
+type SalonRepository struct {
+	db *postgres.DB
+}
+
+// NewSalonRepository creates a new salon repository instance
+func NewSalonRepository(db *postgres.DB) *SalonRepository {
+	return &SalonRepository{
+		db,
+	}
+}
+
+// CreateSalon inserts a new salon into the database, the slug is unique
+func (r *SalonRepository) CreateSalon(ctx context.Context, salon *domain.Salon) (*domain.Salon, error) {
+	query := r.db.QueryBuilder.Insert(`"Salon"`).
+		Columns("id", "name", "slug").
+		Values(salon.ID, salon.Name, salon.Slug).
+		Suffix("RETURNING " + salonColumns)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&salon.ID, &salon.Name, &salon.Slug, &salon.CreatedAt, &salon.UpdatedAt)
+	if err != nil {
+		if errCode := r.db.ErrorCode(err); errCode == "23505" {
+			return nil, domain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return salon, nil
+}
+
+// GetSalonByID retrieves a salon by ID
+func (r *SalonRepository) GetSalonByID(ctx context.Context, id uuid.UUID) (*domain.Salon, error) {
+	var salon domain.Salon
+
+	query := r.db.QueryBuilder.Select(salonColumns).
+		From(`"Salon"`).
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Reader().QueryRow(ctx, sql, args...).Scan(&salon.ID, &salon.Name, &salon.Slug, &salon.CreatedAt, &salon.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &salon, nil
+}
+
+// ListSalons retrieves a list of salons ordered by name
+func (r *SalonRepository) ListSalons(ctx context.Context, skip, limit uint64) ([]domain.Salon, error) {
+	var salons []domain.Salon
+
+	query := r.db.QueryBuilder.Select(salonColumns).
+		From(`"Salon"`).
+		OrderBy("name", "id").
+		Limit(limit).
+		Offset(pageOffset(skip, limit))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	r.db.LogQuery(ctx, sql, args)
+
+	rows, err := r.db.Reader().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var salon domain.Salon
+		if err := rows.Scan(&salon.ID, &salon.Name, &salon.Slug, &salon.CreatedAt, &salon.UpdatedAt); err != nil {
+			return nil, err
+		}
+		salons = append(salons, salon)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return salons, nil
+}
+
+// UpdateSalon updates the name and slug of a salon
+func (r *SalonRepository) UpdateSalon(ctx context.Context, salon *domain.Salon) (*domain.Salon, error) {
+	query := r.db.QueryBuilder.Update(`"Salon"`).
+		Set("name", salon.Name).
+		Set("slug", salon.Slug).
+		Set(`"updatedAt"`, sq.Expr("NOW()")).
+		Where(sq.Eq{"id": salon.ID}).
+		Suffix("RETURNING " + salonColumns)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&salon.ID, &salon.Name, &salon.Slug, &salon.CreatedAt, &salon.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		if errCode := r.db.ErrorCode(err); errCode == "23505" {
+			return nil, domain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return salon, nil
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/util"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ServiceCategoryRepository implements port.ServiceCategoryRepository interface and provides access to the postgres database
+type ServiceCategoryRepository struct {
+	db *postgres.DB
+}
+
+// NewServiceCategoryRepository creates a new service category repository instance
+func NewServiceCategoryRepository(db *postgres.DB) *ServiceCategoryRepository {
+	return &ServiceCategoryRepository{
+		db,
+	}
+}
+
+// CreateServiceCategory inserts a new service category into the database
+func (r *ServiceCategoryRepository) CreateServiceCategory(ctx context.Context, category *domain.ServiceCategory) (*domain.ServiceCategory, error) {
+	category.SalonID = util.SalonOr(ctx, category.SalonID)
+
+	query := r.db.QueryBuilder.Insert("\"ServiceCategory\"").
+		Columns("id", "name", "\"salonId\"").
+		Values(category.ID, category.Name, category.SalonID).
+		Suffix("RETURNING id, name, \"salonId\"")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&category.ID, &category.Name, &category.SalonID)
+	if err != nil {
+		if errCode := r.db.ErrorCode(err); errCode == "23505" {
+			return nil, domain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// GetServiceCategoryByID retrieves a service category by ID
+func (r *ServiceCategoryRepository) GetServiceCategoryByID(ctx context.Context, id uuid.UUID) (*domain.ServiceCategory, error) {
+	var c domain.ServiceCategory
+
+	query := r.db.QueryBuilder.Select("id", "name", "\"salonId\"").
+		From("\"ServiceCategory\"").
+		Where(sq.Eq{"id": id}).
+		Where(inSalon(ctx, "\"salonId\"")).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Reader().QueryRow(ctx, sql, args...).Scan(&c.ID, &c.Name, &c.SalonID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// ListServiceCategories retrieves a list of service categories ordered by name
+func (r *ServiceCategoryRepository) ListServiceCategories(ctx context.Context, skip, limit uint64) ([]domain.ServiceCategory, error) {
+	var categories []domain.ServiceCategory
+
+	query := r.db.QueryBuilder.Select("id", "name", "\"salonId\"").
+		From("\"ServiceCategory\"").
+		Where(inSalon(ctx, "\"salonId\"")).
+		OrderBy("name").
+		Limit(limit).
+		Offset(pageOffset(skip, limit))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	r.db.LogQuery(ctx, sql, args)
+
+	rows, err := r.db.Reader().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c domain.ServiceCategory
+		if err := rows.Scan(&c.ID, &c.Name, &c.SalonID); err != nil {
+			return nil, err
+		}
+		categories = append(categories, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// UpdateServiceCategory updates an existing service category
+func (r *ServiceCategoryRepository) UpdateServiceCategory(ctx context.Context, category *domain.ServiceCategory) (*domain.ServiceCategory, error) {
+	query := r.db.QueryBuilder.Update("\"ServiceCategory\"").
+		Set("name", category.Name).
+		Where(sq.Eq{"id": category.ID}).
+		Where(inSalon(ctx, "\"salonId\"")).
+		Suffix("RETURNING id, name, \"salonId\"")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&category.ID, &category.Name, &category.SalonID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		if errCode := r.db.ErrorCode(err); errCode == "23505" {
+			return nil, domain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// DeleteServiceCategory deletes a service category by ID, categories still used by a type of service are kept
+func (r *ServiceCategoryRepository) DeleteServiceCategory(ctx context.Context, id uuid.UUID) error {
+	query := r.db.QueryBuilder.Delete("\"ServiceCategory\"").
+		Where(sq.Eq{"id": id}).
+		Where(inSalon(ctx, "\"salonId\""))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Conn.Exec(ctx, sql, args...)
+	if err != nil {
+		if errCode := r.db.ErrorCode(err); errCode == "23503" {
+			return domain.ErrConflictingData
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/util"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// ServiceOfferingRepository implements port.ServiceOfferingRepository interface and provides access to the postgres database
+type ServiceOfferingRepository struct {
+	db *postgres.DB
+}
+
+// NewServiceOfferingRepository creates a new service offering repository instance
+func NewServiceOfferingRepository(db *postgres.DB) *ServiceOfferingRepository {
+	return &ServiceOfferingRepository{
+		db,
+	}
+}
+
+// CreateServiceOffering records that an admin offers a type of service
+func (r *ServiceOfferingRepository) CreateServiceOffering(ctx context.Context, offering *domain.ServiceOffering) (*domain.ServiceOffering, error) {
+	offering.SalonID = util.SalonOr(ctx, offering.SalonID)
+
+	query := r.db.QueryBuilder.Insert("\"ServiceOffering\"").
+		Columns("\"adminId\"", "\"typeOfServiceId\"", "\"salonId\"").
+		Values(offering.AdminID, offering.TypeOfServiceID, offering.SalonID)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = r.db.Conn.Exec(ctx, sql, args...)
+	if err != nil {
+		switch r.db.ErrorCode(err) {
+		case "23505":
+			return nil, domain.ErrConflictingData
+		case "23503":
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return offering, nil
+}
+
+// DeleteServiceOffering removes an admin from a type of service
+func (r *ServiceOfferingRepository) DeleteServiceOffering(ctx context.Context, offering *domain.ServiceOffering) error {
+	query := r.db.QueryBuilder.Delete("\"ServiceOffering\"").
+		Where(sq.Eq{
+			"\"adminId\"":         offering.AdminID,
+			"\"typeOfServiceId\"": offering.TypeOfServiceID,
+		}).
+		Where(inSalon(ctx, "\"salonId\""))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := r.db.Conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrDataNotFound
+	}
+
+	return nil
+}
+
+// ListServiceOfferings selects the offerings of the given types of service in a single query
+func (r *ServiceOfferingRepository) ListServiceOfferings(ctx context.Context, typeOfServiceIDs []uuid.UUID) ([]domain.ServiceOffering, error) {
+	var offerings []domain.ServiceOffering
+
+	if len(typeOfServiceIDs) == 0 {
+		return offerings, nil
+	}
+
+	query := r.db.QueryBuilder.Select("\"adminId\"", "\"typeOfServiceId\"", "\"salonId\"").
+		From("\"ServiceOffering\"").
+		Where(sq.Eq{"\"typeOfServiceId\"": typeOfServiceIDs}).
+		Where(inSalon(ctx, "\"salonId\""))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	r.db.LogQuery(ctx, sql, args)
+
+	rows, err := r.db.Reader().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var offering domain.ServiceOffering
+		if err := rows.Scan(&offering.AdminID, &offering.TypeOfServiceID, &offering.SalonID); err != nil {
+			return nil, err
+		}
+		offerings = append(offerings, offering)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return offerings, nil
+}
+
+// IsServiceOffered reports whether an admin offers a type of service
+func (r *ServiceOfferingRepository) IsServiceOffered(ctx context.Context, adminID, typeOfServiceID uuid.UUID) (bool, error) {
+	var offered bool
+
+	query := r.db.QueryBuilder.Select("1").
+		Prefix("SELECT EXISTS (").
+		From("\"ServiceOffering\"").
+		Where(sq.Eq{
+			"\"adminId\"":         adminID,
+			"\"typeOfServiceId\"": typeOfServiceID,
+		}).
+		Where(inSalon(ctx, "\"salonId\"")).
+		Suffix(")")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&offered)
+	if err != nil {
+		return false, err
+	}
+
+	return offered, nil
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/core/domain"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// notDeleted hides the soft-deleted rows of table from the query, unless includeDeleted asks for them
+func notDeleted(query sq.SelectBuilder, table string, includeDeleted bool) sq.SelectBuilder {
+	if includeDeleted {
+		return query
+	}
+	return query.Where(sq.Eq{table + `."deletedAt"`: nil})
+}
+
+// softDelete marks the row of table with id as deleted instead of removing it, a row that does not
+// exist, is already deleted or belongs to another salon is not found
+func softDelete(ctx context.Context, db *postgres.DB, table string, id uuid.UUID) error {
+	query := db.QueryBuilder.Update(table).
+		Set(`"deletedAt"`, sq.Expr("NOW()")).
+		Where(sq.Eq{"id": id, `"deletedAt"`: nil}).
+		Where(inSalon(ctx, `"salonId"`))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := db.Conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrDataNotFound
+	}
+
+	return nil
+}
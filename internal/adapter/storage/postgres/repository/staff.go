@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// StaffRepository implements port.StaffRepository interface and provides access to the postgres database
+type StaffRepository struct {
+	db *postgres.DB
+}
+
+// NewStaffRepository creates a new staff repository instance
+func NewStaffRepository(db *postgres.DB) *StaffRepository {
+	return &StaffRepository{
+		db,
+	}
+}
+
+// staffColumns are the columns selected for a staff member, in scan order; the types of service
+// its admin offers are aggregated into an array
+var staffColumns = []string{
+	"id",
+	"\"userId\"",
+	"\"salonId\"",
+	"\"displayName\"",
+	"bio",
+	"specialties",
+	"COALESCE(\"photoUrl\", '')",
+	"active",
+	"ARRAY(SELECT \"typeOfServiceId\" FROM \"ServiceOffering\" WHERE \"adminId\" = \"Staff\".\"userId\")",
+	"\"createdAt\"",
+	"\"updatedAt\"",
+}
+
+// scanStaff scans a row selected with staffColumns
+func scanStaff(row pgx.Row, s *domain.Staff) error {
+	return row.Scan(
+		&s.ID,
+		&s.UserID,
+		&s.SalonID,
+		&s.DisplayName,
+		&s.Bio,
+		&s.Specialties,
+		&s.PhotoURL,
+		&s.Active,
+		&s.TypeOfServiceIDs,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+}
+
+// CreateStaff inserts a new staff member into the database, an admin has a single profile
+func (r *StaffRepository) CreateStaff(ctx context.Context, staff *domain.Staff) (*domain.Staff, error) {
+	staff.SalonID = util.SalonOr(ctx, staff.SalonID)
+
+	query := r.db.QueryBuilder.Insert("\"Staff\"").
+		Columns("id", "\"userId\"", "\"salonId\"", "\"displayName\"", "bio", "specialties", "active").
+		Values(staff.ID, staff.UserID, staff.SalonID, staff.DisplayName, staff.Bio, staff.Specialties, staff.Active).
+		Suffix("RETURNING " + strings.Join(staffColumns, ", "))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanStaff(r.db.Conn.QueryRow(ctx, sql, args...), staff)
+	if err != nil {
+		switch r.db.ErrorCode(err) {
+		case "23505":
+			return nil, domain.ErrConflictingData
+		case "23503":
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return staff, nil
+}
+
+// GetStaffByID retrieves a staff member by ID
+func (r *StaffRepository) GetStaffByID(ctx context.Context, id uuid.UUID) (*domain.Staff, error) {
+	return r.getStaff(ctx, sq.Eq{"id": id})
+}
+
+// GetStaffByUserID retrieves the staff member an admin account belongs to
+func (r *StaffRepository) GetStaffByUserID(ctx context.Context, userID uuid.UUID) (*domain.Staff, error) {
+	return r.getStaff(ctx, sq.Eq{"\"userId\"": userID})
+}
+
+// getStaff retrieves the staff member matching where in the salon of ctx
+func (r *StaffRepository) getStaff(ctx context.Context, where sq.Eq) (*domain.Staff, error) {
+	var s domain.Staff
+
+	query := r.db.QueryBuilder.Select(staffColumns...).
+		From("\"Staff\"").
+		Where(where).
+		Where(inSalon(ctx, `"salonId"`)).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanStaff(r.db.Reader().QueryRow(ctx, sql, args...), &s)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+// ListStaff retrieves a list of staff members ordered by name
+func (r *StaffRepository) ListStaff(ctx context.Context, filter port.StaffFilter) ([]domain.Staff, error) {
+	var staff []domain.Staff
+
+	query := r.db.QueryBuilder.Select(staffColumns...).
+		From("\"Staff\"").
+		Where(inSalon(ctx, `"salonId"`)).
+		OrderBy("\"displayName\"", "id").
+		Limit(filter.Limit).
+		Offset(pageOffset(filter.Skip, filter.Limit))
+
+	if filter.Active != nil {
+		query = query.Where(sq.Eq{"active": *filter.Active})
+	}
+
+	if filter.Specialty != "" {
+		query = query.Where(sq.Expr("? = ANY(specialties)", filter.Specialty))
+	}
+
+	if filter.TypeOfServiceID != nil {
+		query = query.Where(sq.Expr("EXISTS (SELECT 1 FROM \"ServiceOffering\" WHERE \"adminId\" = \"Staff\".\"userId\" AND \"typeOfServiceId\" = ?)", *filter.TypeOfServiceID))
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	r.db.LogQuery(ctx, sql, args)
+
+	rows, err := r.db.Reader().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s domain.Staff
+		if err := scanStaff(rows, &s); err != nil {
+			return nil, err
+		}
+		staff = append(staff, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return staff, nil
+}
+
+// UpdateStaff updates the profile of a staff member, including its photo
+func (r *StaffRepository) UpdateStaff(ctx context.Context, staff *domain.Staff) (*domain.Staff, error) {
+	query := r.db.QueryBuilder.Update("\"Staff\"").
+		Set("\"displayName\"", staff.DisplayName).
+		Set("bio", staff.Bio).
+		Set("specialties", staff.Specialties).
+		Set("\"photoUrl\"", nullString(staff.PhotoURL)).
+		Set("active", staff.Active).
+		Set("\"updatedAt\"", sq.Expr("NOW()")).
+		Where(sq.Eq{"id": staff.ID}).
+		Where(inSalon(ctx, `"salonId"`)).
+		Suffix("RETURNING " + strings.Join(staffColumns, ", "))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanStaff(r.db.Conn.QueryRow(ctx, sql, args...), staff)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return staff, nil
+}
+
+// DeleteStaff deletes a staff member by ID, the admin account and its slots are kept
+func (r *StaffRepository) DeleteStaff(ctx context.Context, id uuid.UUID) error {
+	query := r.db.QueryBuilder.Delete("\"Staff\"").
+		Where(sq.Eq{"id": id}).
+		Where(inSalon(ctx, `"salonId"`))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	tag, err := r.db.Conn.Exec(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrDataNotFound
+	}
+
+	return nil
+}
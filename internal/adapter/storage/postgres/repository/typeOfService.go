@@ -4,7 +4,9 @@ import (
 	"context"
 	"harajuku/backend/internal/adapter/storage/postgres"
 	"harajuku/backend/internal/core/domain"
-	"log/slog"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
@@ -21,11 +23,49 @@ func NewTypeOfServiceRepository(db *postgres.DB) *TypeOfServiceRepository {
 	}
 }
 
+// typeOfServiceColumns are the columns selected and returned for a type of service, in scan order
+var typeOfServiceColumns = []string{
+	"id",
+	"name",
+	"description",
+	"\"preparationInstructions\"",
+	"price",
+	"currency",
+	"\"durationMinutes\"",
+	"\"durationMinMinutes\"",
+	"\"durationMaxMinutes\"",
+	"\"categoryId\"",
+	"archived",
+	"\"deletedAt\"",
+	"\"salonId\"",
+}
+
+// scanTypeOfService scans a row selected with typeOfServiceColumns
+func scanTypeOfService(row pgx.Row, s *domain.TypeOfService) error {
+	return row.Scan(
+		&s.ID,
+		&s.Name,
+		&s.Description,
+		&s.PreparationInstructions,
+		&s.Price,
+		&s.Currency,
+		&s.DurationMinutes,
+		&s.DurationMinMinutes,
+		&s.DurationMaxMinutes,
+		&s.CategoryID,
+		&s.Archived,
+		&s.DeletedAt,
+		&s.SalonID,
+	)
+}
+
 // CreateTypeOfService inserts a new type of service into the database
 func (r *TypeOfServiceRepository) CreateTypeOfService(ctx context.Context, service *domain.TypeOfService) (*domain.TypeOfService, error) {
+	service.SalonID = util.SalonOr(ctx, service.SalonID)
+
 	query := r.db.QueryBuilder.Insert("\"TypeOfService\"").
-		Columns("id", "name", "price").
-		Values(service.ID, service.Name, service.Price).
+		Columns("id", "name", "description", "\"preparationInstructions\"", "price", "currency", "\"durationMinutes\"", "\"durationMinMinutes\"", "\"durationMaxMinutes\"", "\"categoryId\"", "\"salonId\"").
+		Values(service.ID, service.Name, service.Description, service.PreparationInstructions, service.Price, service.Currency, service.DurationMinutes, service.DurationMinMinutes, service.DurationMaxMinutes, service.CategoryID, service.SalonID).
 		Suffix("RETURNING id")
 
 	sql, args, err := query.ToSql()
@@ -35,6 +75,9 @@ func (r *TypeOfServiceRepository) CreateTypeOfService(ctx context.Context, servi
 
 	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&service.ID)
 	if err != nil {
+		if errCode := r.db.ErrorCode(err); errCode == "23503" {
+			return nil, domain.ErrDataNotFound
+		}
 		return nil, err
 	}
 
@@ -45,17 +88,21 @@ func (r *TypeOfServiceRepository) CreateTypeOfService(ctx context.Context, servi
 func (r *TypeOfServiceRepository) GetTypeOfServiceByID(ctx context.Context, id uuid.UUID) (*domain.TypeOfService, error) {
 	var s domain.TypeOfService
 
-	query := r.db.QueryBuilder.Select("id", "name", "price").
+	query := r.db.QueryBuilder.Select(typeOfServiceColumns...).
 		From("\"TypeOfService\"").
 		Where(sq.Eq{"id": id}).
+		Where(inSalon(ctx, `"salonId"`)).
 		Limit(1)
 
+	// Old quotes still resolve their deleted type of service, the services keep new changes away from it
+	query = notDeleted(query, `"TypeOfService"`, true)
+
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&s.ID, &s.Name, &s.Price)
+	err = scanTypeOfService(r.db.Reader().QueryRow(ctx, sql, args...), &s)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, domain.ErrDataNotFound
@@ -67,22 +114,34 @@ func (r *TypeOfServiceRepository) GetTypeOfServiceByID(ctx context.Context, id u
 }
 
 // ListTypeOfServices retrieves a list of types of service
-func (r *TypeOfServiceRepository) ListTypeOfServices(ctx context.Context, skip, limit uint64) ([]domain.TypeOfService, error) {
+func (r *TypeOfServiceRepository) ListTypeOfServices(ctx context.Context, filter port.TypeOfServiceFilter) ([]domain.TypeOfService, error) {
 	var services []domain.TypeOfService
 
-	query := r.db.QueryBuilder.Select("id", "name", "price").
-		From("\"TypeOfService\"").
-		Limit(limit).
-		Offset((skip - 1) * limit)
+	query := r.db.QueryBuilder.Select(typeOfServiceColumns...).
+		From("\"TypeOfService\"")
+
+	query = applyTypeOfServiceFilter(ctx, query, filter)
+
+	switch filter.PriceOrder {
+	case "asc":
+		query = query.OrderBy("price ASC", "name")
+	case "desc":
+		query = query.OrderBy("price DESC", "name")
+	default:
+		query = query.OrderBy("name")
+	}
+
+	query = query.Limit(filter.Limit).
+		Offset(pageOffset(filter.Skip, filter.Limit))
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	slog.DebugContext(ctx, "Executing query", "sql", sql, "args", args)
+	r.db.LogQuery(ctx, sql, args)
 
-	rows, err := r.db.Conn.Query(ctx, sql, args...)
+	rows, err := r.db.Reader().Query(ctx, sql, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -90,7 +149,7 @@ func (r *TypeOfServiceRepository) ListTypeOfServices(ctx context.Context, skip,
 
 	for rows.Next() {
 		var s domain.TypeOfService
-		if err := rows.Scan(&s.ID, &s.Name, &s.Price); err != nil {
+		if err := scanTypeOfService(rows, &s); err != nil {
 			return nil, err
 		}
 		services = append(services, s)
@@ -103,37 +162,96 @@ func (r *TypeOfServiceRepository) ListTypeOfServices(ctx context.Context, skip,
 	return services, nil
 }
 
+// CountTypeOfServices counts the types of service matching the filter, ignoring pagination
+func (r *TypeOfServiceRepository) CountTypeOfServices(ctx context.Context, filter port.TypeOfServiceFilter) (uint64, error) {
+	query := r.db.QueryBuilder.Select("id").
+		From("\"TypeOfService\"")
+
+	return countRows(ctx, r.db.Reader(), applyTypeOfServiceFilter(ctx, query, filter))
+}
+
+// likeEscaper escapes the LIKE wildcards so searches match them literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// applyTypeOfServiceFilter adds the conditions shared by the listing and its count
+func applyTypeOfServiceFilter(ctx context.Context, query sq.SelectBuilder, filter port.TypeOfServiceFilter) sq.SelectBuilder {
+	query = notDeleted(query, `"TypeOfService"`, filter.IncludeDeleted).
+		Where(inSalon(ctx, `"salonId"`))
+
+	if filter.CategoryID != nil {
+		query = query.Where(sq.Eq{`"categoryId"`: *filter.CategoryID})
+	}
+
+	if !filter.IncludeArchived {
+		query = query.Where(sq.Eq{"archived": false})
+	}
+
+	if filter.Name != "" {
+		query = query.Where(sq.ILike{"name": "%" + likeEscaper.Replace(filter.Name) + "%"})
+	}
+
+	return query
+}
+
 // UpdateTypeOfService updates an existing type of service
 func (r *TypeOfServiceRepository) UpdateTypeOfService(ctx context.Context, service *domain.TypeOfService) (*domain.TypeOfService, error) {
 	query := r.db.QueryBuilder.Update("\"TypeOfService\"").
 		Set("name", service.Name).
+		Set("description", service.Description).
+		Set("\"preparationInstructions\"", service.PreparationInstructions).
 		Set("price", service.Price).
+		Set("currency", service.Currency).
+		Set("\"durationMinutes\"", service.DurationMinutes).
+		Set("\"durationMinMinutes\"", service.DurationMinMinutes).
+		Set("\"durationMaxMinutes\"", service.DurationMaxMinutes).
+		Set("\"categoryId\"", service.CategoryID).
 		Where(sq.Eq{"id": service.ID}).
-		Suffix("RETURNING id, name, price")
+		Where(inSalon(ctx, `"salonId"`)).
+		Suffix("RETURNING " + strings.Join(typeOfServiceColumns, ", "))
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(&service.ID, &service.Name, &service.Price)
+	err = scanTypeOfService(r.db.Conn.QueryRow(ctx, sql, args...), service)
 	if err != nil {
+		if errCode := r.db.ErrorCode(err); errCode == "23503" {
+			return nil, domain.ErrDataNotFound
+		}
 		return nil, err
 	}
 
 	return service, nil
 }
 
-// DeleteTypeOfService deletes a type of service by ID
-func (r *TypeOfServiceRepository) DeleteTypeOfService(ctx context.Context, id uuid.UUID) error {
-	query := r.db.QueryBuilder.Delete("\"TypeOfService\"").
-		Where(sq.Eq{"id": id})
+// SetTypeOfServiceArchived sets the archived flag of a type of service
+func (r *TypeOfServiceRepository) SetTypeOfServiceArchived(ctx context.Context, id uuid.UUID, archived bool) (*domain.TypeOfService, error) {
+	var s domain.TypeOfService
+
+	query := r.db.QueryBuilder.Update("\"TypeOfService\"").
+		Set("archived", archived).
+		Where(sq.Eq{"id": id}).
+		Where(inSalon(ctx, `"salonId"`)).
+		Suffix("RETURNING " + strings.Join(typeOfServiceColumns, ", "))
 
 	sql, args, err := query.ToSql()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	err = scanTypeOfService(r.db.Conn.QueryRow(ctx, sql, args...), &s)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
 	}
 
-	_, err = r.db.Conn.Exec(ctx, sql, args...)
-	return err
+	return &s, nil
+}
+
+// DeleteTypeOfService soft deletes a type of service by ID, quotes keep pointing at it
+func (r *TypeOfServiceRepository) DeleteTypeOfService(ctx context.Context, id uuid.UUID) error {
+	return softDelete(ctx, r.db, `"TypeOfService"`, id)
 }
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/util"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// TypeOfServiceImageRepository implements port.TypeOfServiceImageRepository interface and provides access to the postgres database
+type TypeOfServiceImageRepository struct {
+	db *postgres.DB
+}
+
+// NewTypeOfServiceImageRepository creates a new type of service image repository instance
+func NewTypeOfServiceImageRepository(db *postgres.DB) *TypeOfServiceImageRepository {
+	return &TypeOfServiceImageRepository{
+		db,
+	}
+}
+
+// CreateTypeOfServiceImage inserts a new type of service image into the database
+func (r *TypeOfServiceImageRepository) CreateTypeOfServiceImage(ctx context.Context, image *domain.TypeOfServiceImage) (*domain.TypeOfServiceImage, error) {
+	image.SalonID = util.SalonOr(ctx, image.SalonID)
+
+	query := r.db.QueryBuilder.Insert("\"TypeOfServiceImages\"").
+		Columns("id", "\"typeOfServiceId\"", "url", "\"salonId\"").
+		Values(image.ID, image.TypeOfServiceID, image.URL, image.SalonID).
+		Suffix("RETURNING id, \"typeOfServiceId\", url, \"salonId\"")
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Conn.QueryRow(ctx, sql, args...).Scan(
+		&image.ID,
+		&image.TypeOfServiceID,
+		&image.URL,
+		&image.SalonID,
+	)
+	if err != nil {
+		if errCode := r.db.ErrorCode(err); errCode == "23503" {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return image, nil
+}
+
+// GetTypeOfServiceImageByID selects a type of service image by its ID
+func (r *TypeOfServiceImageRepository) GetTypeOfServiceImageByID(ctx context.Context, id uuid.UUID) (*domain.TypeOfServiceImage, error) {
+	var image domain.TypeOfServiceImage
+
+	query := r.db.QueryBuilder.Select("id", "\"typeOfServiceId\"", "url", "\"salonId\"").
+		From("\"TypeOfServiceImages\"").
+		Where(sq.Eq{"id": id}).
+		Where(inSalon(ctx, "\"salonId\"")).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Reader().QueryRow(ctx, sql, args...).Scan(
+		&image.ID,
+		&image.TypeOfServiceID,
+		&image.URL,
+		&image.SalonID,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &image, nil
+}
+
+// ListTypeOfServiceImages selects the images of the given types of service in a single query
+func (r *TypeOfServiceImageRepository) ListTypeOfServiceImages(ctx context.Context, typeOfServiceIDs []uuid.UUID) ([]domain.TypeOfServiceImage, error) {
+	var images []domain.TypeOfServiceImage
+
+	if len(typeOfServiceIDs) == 0 {
+		return images, nil
+	}
+
+	query := r.db.QueryBuilder.Select("id", "\"typeOfServiceId\"", "url", "\"salonId\"").
+		From("\"TypeOfServiceImages\"").
+		Where(sq.Eq{"\"typeOfServiceId\"": typeOfServiceIDs}).
+		Where(inSalon(ctx, "\"salonId\""))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	r.db.LogQuery(ctx, sql, args)
+
+	rows, err := r.db.Reader().Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var image domain.TypeOfServiceImage
+		if err := rows.Scan(&image.ID, &image.TypeOfServiceID, &image.URL, &image.SalonID); err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+// DeleteTypeOfServiceImage deletes a type of service image by ID
+func (r *TypeOfServiceImageRepository) DeleteTypeOfServiceImage(ctx context.Context, id uuid.UUID) error {
+	query := r.db.QueryBuilder.Delete("\"TypeOfServiceImages\"").
+		Where(sq.Eq{"id": id}).
+		Where(inSalon(ctx, "\"salonId\""))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Conn.Exec(ctx, sql, args...)
+	return err
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"harajuku/backend/internal/adapter/storage/postgres"
 	"harajuku/backend/internal/core/domain"
-	"log/slog"
+	"harajuku/backend/internal/core/util"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
@@ -29,8 +29,8 @@ func NewUserRepository(db *postgres.DB) *UserRepository {
 // CreateUser creates a new user in the database
 func (ur *UserRepository) CreateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
     query := ur.db.QueryBuilder.Insert("users").
-    Columns("id", "name", `"lastName"`, `"secondLastName"`, "email", "password"). // Use quoted identifiers
-    Values(user.ID, user.Name, user.LastName, user.SecondLastName, user.Email, user.Password).
+    Columns("id", "name", `"lastName"`, `"secondLastName"`, "email", "password", `"preferredLanguage"`, "phone", `"smsNotifications"`, `"whatsAppNotifications"`, `"emailNotifications"`, `"salonId"`). // Use quoted identifiers
+    Values(user.ID, user.Name, user.LastName, user.SecondLastName, user.Email, user.Password, user.PreferredLanguage.OrDefault(), user.Phone, user.NotificationPreferences.SMS, user.NotificationPreferences.WhatsApp, user.NotificationPreferences.Email, util.SalonOr(ctx, user.SalonID)).
     Suffix("RETURNING *")
 
     sql, args, err := query.ToSql()
@@ -46,6 +46,12 @@ func (ur *UserRepository) CreateUser(ctx context.Context, user *domain.User) (*d
         &user.Email,
         &user.Password,
         &user.Role,
+        &user.PreferredLanguage,
+        &user.Phone,
+        &user.NotificationPreferences.SMS,
+        &user.NotificationPreferences.WhatsApp,
+        &user.NotificationPreferences.Email,
+        &user.SalonID,
     )
 
     if err != nil {
@@ -65,6 +71,7 @@ func (ur *UserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*domai
 	query := ur.db.QueryBuilder.Select("*").
 		From("users").
 		Where(sq.Eq{"id": id}).
+		Where(inSalon(ctx, `"salonId"`)).
 		Limit(1)
 
 	sql, args, err := query.ToSql()
@@ -72,7 +79,7 @@ func (ur *UserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*domai
 		return nil, err
 	}
 
-	err = ur.db.Conn.QueryRow(ctx, sql, args...).Scan(
+	err = ur.db.Reader().QueryRow(ctx, sql, args...).Scan(
 		&user.ID,
 		&user.Name,
 		&user.LastName,
@@ -80,6 +87,12 @@ func (ur *UserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*domai
 		&user.Email,
 		&user.Password,
     &user.Role,
+    &user.PreferredLanguage,
+    &user.Phone,
+    &user.NotificationPreferences.SMS,
+    &user.NotificationPreferences.WhatsApp,
+    &user.NotificationPreferences.Email,
+    &user.SalonID,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -98,6 +111,7 @@ func (ur *UserRepository) GetUserByEmail(ctx context.Context, email string) (*do
 	query := ur.db.QueryBuilder.Select("*").
 		From("users").
 		Where(sq.Eq{"email": email}).
+		Where(inSalon(ctx, `"salonId"`)).
 		Limit(1)
 
 	sql, args, err := query.ToSql()
@@ -113,6 +127,12 @@ func (ur *UserRepository) GetUserByEmail(ctx context.Context, email string) (*do
     &user.Email,
     &user.Password,
     &user.Role,
+    &user.PreferredLanguage,
+    &user.Phone,
+    &user.NotificationPreferences.SMS,
+    &user.NotificationPreferences.WhatsApp,
+    &user.NotificationPreferences.Email,
+    &user.SalonID,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -132,14 +152,15 @@ func (ur *UserRepository) GetAdminsEmails(ctx context.Context) ([]string, error)
     query := ur.db.QueryBuilder.Select("email").
         From("users").
         OrderBy("id").
-        Where(sq.Eq{"role": "admin",})
+        Where(sq.Eq{"role": "admin",}).
+        Where(inSalon(ctx, `"salonId"`))
 
     sql, args, err := query.ToSql()
     if err != nil {
         return nil, err
     }
 
-    rows, err := ur.db.Conn.Query(ctx, sql, args...)
+    rows, err := ur.db.Reader().Query(ctx, sql, args...)
     if err != nil {
         return nil, err
     }
@@ -159,6 +180,54 @@ func (ur *UserRepository) GetAdminsEmails(ctx context.Context) ([]string, error)
     return emails, nil
 }
 
+// GetAdmins returns every admin user
+func (ur *UserRepository) GetAdmins(ctx context.Context) ([]domain.User, error) {
+    var admins []domain.User
+
+    query := ur.db.QueryBuilder.Select("*").
+        From("users").
+        OrderBy("id").
+        Where(sq.Eq{"role": domain.Admin}).
+        Where(inSalon(ctx, `"salonId"`))
+
+    sql, args, err := query.ToSql()
+    if err != nil {
+        return nil, err
+    }
+
+    rows, err := ur.db.Reader().Query(ctx, sql, args...)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    for rows.Next() {
+        var user domain.User
+        err := rows.Scan(
+            &user.ID,
+            &user.Name,
+            &user.LastName,
+            &user.SecondLastName,
+            &user.Email,
+            &user.Password,
+            &user.Role,
+            &user.PreferredLanguage,
+            &user.Phone,
+            &user.NotificationPreferences.SMS,
+            &user.NotificationPreferences.WhatsApp,
+            &user.NotificationPreferences.Email,
+            &user.SalonID,
+        )
+        if err != nil {
+            return nil, err
+        }
+
+        admins = append(admins, user)
+    }
+
+    return admins, rows.Err()
+}
+
 // ListUsers lists users from the database with optional filters
 func (ur *UserRepository) ListUsers(ctx context.Context, skip, limit uint64, filters domain.UserFilters) ([]domain.User, error) {
     var user domain.User
@@ -168,21 +237,9 @@ func (ur *UserRepository) ListUsers(ctx context.Context, skip, limit uint64, fil
         From("users").
         OrderBy("id").
         Limit(limit).
-        Offset((skip - 1) * limit)
+        Offset(pageOffset(skip, limit))
 
-    // Add filters if they are provided
-    if filters.Name != "" {
-        query = query.Where(sq.ILike{"name": "%" + filters.Name + "%"})
-    }
-    if filters.LastName != "" {
-        query = query.Where(sq.ILike{`"lastName"`: "%" + filters.LastName + "%"})
-    }
-    if filters.SecondLastName != "" {
-        query = query.Where(sq.ILike{`"secondLastName"`: "%" + filters.SecondLastName + "%"})
-    }
-    if filters.Role != "" {
-        query = query.Where(sq.Eq{"role": filters.Role})
-    }
+    query = applyUserFilters(ctx, query, filters)
 
     sql, args, err := query.ToSql()
     if err != nil {
@@ -190,12 +247,9 @@ func (ur *UserRepository) ListUsers(ctx context.Context, skip, limit uint64, fil
     }
 
     // Debug logging - crucial for troubleshooting
-    slog.DebugContext(ctx, "Executing query", 
-        "sql", sql, 
-        "args", args,
-        "filters", filters)
+    ur.db.LogQuery(ctx, sql, args)
 
-    rows, err := ur.db.Conn.Query(ctx, sql, args...)
+    rows, err := ur.db.Reader().Query(ctx, sql, args...)
     if err != nil {
         return nil, err
     }
@@ -210,6 +264,12 @@ func (ur *UserRepository) ListUsers(ctx context.Context, skip, limit uint64, fil
             &user.Email,
             &user.Password,
             &user.Role,
+            &user.PreferredLanguage,
+            &user.Phone,
+            &user.NotificationPreferences.SMS,
+            &user.NotificationPreferences.WhatsApp,
+            &user.NotificationPreferences.Email,
+            &user.SalonID,
         )
         if err != nil {
             return nil, err
@@ -221,23 +281,59 @@ func (ur *UserRepository) ListUsers(ctx context.Context, skip, limit uint64, fil
     return users, nil
 }
 
-// UpdateUser updates a user by ID in the database
+// CountUsers counts the users matching the filters, ignoring pagination
+func (ur *UserRepository) CountUsers(ctx context.Context, filters domain.UserFilters) (uint64, error) {
+    query := ur.db.QueryBuilder.Select("id").
+        From("users")
+
+    return countRows(ctx, ur.db.Reader(), applyUserFilters(ctx, query, filters))
+}
+
+// applyUserFilters adds the salon and the filters shared by the listing and its count, when they are provided
+func applyUserFilters(ctx context.Context, query sq.SelectBuilder, filters domain.UserFilters) sq.SelectBuilder {
+    query = query.Where(inSalon(ctx, `"salonId"`))
+    if filters.Name != "" {
+        query = query.Where(sq.ILike{"name": "%" + filters.Name + "%"})
+    }
+    if filters.LastName != "" {
+        query = query.Where(sq.ILike{`"lastName"`: "%" + filters.LastName + "%"})
+    }
+    if filters.SecondLastName != "" {
+        query = query.Where(sq.ILike{`"secondLastName"`: "%" + filters.SecondLastName + "%"})
+    }
+    if filters.Role != "" {
+        query = query.Where(sq.Eq{"role": filters.Role})
+    }
+
+    return query
+}
+
+// userUpdate holds the columns UpdateUser changes, nil fields are left as they are
+type userUpdate struct {
+	Name              *string          `db:"name"`
+	LastName          *string          `db:"lastName"`
+	SecondLastName    *string          `db:"secondLastName"`
+	Email             *string          `db:"email"`
+	Password          *string          `db:"password"`
+	Role              *domain.UserRole `db:"role"`
+	PreferredLanguage *domain.Language `db:"preferredLanguage"`
+	Phone             *string          `db:"phone"`
+}
+
+// UpdateUser updates the non-empty fields of a user by ID in the database
 func (ur *UserRepository) UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error) {
-	name := nullString(user.Name)
-	lastName := nullString(user.LastName)
-	secondLastName := nullString(user.SecondLastName)
-	email := nullString(user.Email)
-	password := nullString(user.Password)
-  role := nullString(string(user.Role))
-
-	query := ur.db.QueryBuilder.Update("users").
-		Set("name", sq.Expr("COALESCE(?, name)", name)).
-		Set(`"lastName"`, sq.Expr("COALESCE(?, name)", lastName)).
-		Set(`"secondLastName"`, sq.Expr("COALESCE(?, name)", secondLastName)).
-		Set("email", sq.Expr("COALESCE(?, email)", email)).
-		Set("password", sq.Expr("COALESCE(?, password)", password)).
-    Set("role", sq.Expr("COALESCE(?, role)", role)).
+	query := setPartial(ur.db.QueryBuilder.Update("users"), userUpdate{
+		Name:              optional(user.Name),
+		LastName:          optional(user.LastName),
+		SecondLastName:    optional(user.SecondLastName),
+		Email:             optional(user.Email),
+		Password:          optional(user.Password),
+		Role:              optional(user.Role),
+		PreferredLanguage: optional(user.PreferredLanguage),
+		Phone:             optional(user.Phone),
+	}).
 		Where(sq.Eq{"id": user.ID}).
+		Where(inSalon(ctx, `"salonId"`)).
 		Suffix("RETURNING *")
 
 	sql, args, err := query.ToSql()
@@ -253,6 +349,12 @@ func (ur *UserRepository) UpdateUser(ctx context.Context, user *domain.User) (*d
     &user.Email,
     &user.Password,
     &user.Role,
+    &user.PreferredLanguage,
+    &user.Phone,
+    &user.NotificationPreferences.SMS,
+    &user.NotificationPreferences.WhatsApp,
+    &user.NotificationPreferences.Email,
+    &user.SalonID,
 	)
 	if err != nil {
 		if errCode := ur.db.ErrorCode(err); errCode == "23505" {
@@ -264,10 +366,52 @@ func (ur *UserRepository) UpdateUser(ctx context.Context, user *domain.User) (*d
 	return user, nil
 }
 
+// UpdateNotificationPreferences replaces the phone and notification preferences of a user in the database
+func (ur *UserRepository) UpdateNotificationPreferences(ctx context.Context, user *domain.User) (*domain.User, error) {
+    query := ur.db.QueryBuilder.Update("users").
+        Set("phone", user.Phone).
+        Set(`"smsNotifications"`, user.NotificationPreferences.SMS).
+        Set(`"whatsAppNotifications"`, user.NotificationPreferences.WhatsApp).
+        Set(`"emailNotifications"`, user.NotificationPreferences.Email).
+        Where(sq.Eq{"id": user.ID}).
+        Where(inSalon(ctx, `"salonId"`)).
+        Suffix("RETURNING *")
+
+    sql, args, err := query.ToSql()
+    if err != nil {
+        return nil, err
+    }
+
+    err = ur.db.Conn.QueryRow(ctx, sql, args...).Scan(
+        &user.ID,
+        &user.Name,
+        &user.LastName,
+        &user.SecondLastName,
+        &user.Email,
+        &user.Password,
+        &user.Role,
+        &user.PreferredLanguage,
+        &user.Phone,
+        &user.NotificationPreferences.SMS,
+        &user.NotificationPreferences.WhatsApp,
+        &user.NotificationPreferences.Email,
+        &user.SalonID,
+    )
+    if err != nil {
+        if err == pgx.ErrNoRows {
+            return nil, domain.ErrDataNotFound
+        }
+        return nil, err
+    }
+
+    return user, nil
+}
+
 // DeleteUser deletes a user by ID from the database
 func (ur *UserRepository) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	query := ur.db.QueryBuilder.Delete("users").
-		Where(sq.Eq{"id": id})
+		Where(sq.Eq{"id": id}).
+		Where(inSalon(ctx, `"salonId"`))
 
 	sql, args, err := query.ToSql()
 	if err != nil {
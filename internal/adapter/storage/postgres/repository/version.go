@@ -0,0 +1,13 @@
+package repository
+
+import (
+	sq "github.com/Masterminds/squirrel"
+)
+
+// lockVersion applies the update only to the row still at version and bumps it, so the later
+// of two concurrent edits finds no row and is reported as conflicting instead of overwriting
+func lockVersion(query sq.UpdateBuilder, version int) sq.UpdateBuilder {
+	return query.
+		Set(`"version"`, sq.Expr(`"version" + 1`)).
+		Where(sq.Eq{`"version"`: version})
+}
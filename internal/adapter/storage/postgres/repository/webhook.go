@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/util"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// WebhookRepository implements port.WebhookRepository interface and provides access to the postgres database
+type WebhookRepository struct {
+	db *postgres.DB
+}
+
+// NewWebhookRepository creates a new webhook repository instance
+func NewWebhookRepository(db *postgres.DB) *WebhookRepository {
+	return &WebhookRepository{
+		db,
+	}
+}
+
+// webhookColumns are the columns selected for a webhook, in scan order
+var webhookColumns = []string{
+	"id",
+	"url",
+	"secret",
+	"\"eventTypes\"",
+	"active",
+	"\"createdAt\"",
+	"\"updatedAt\"",
+	"\"salonId\"",
+}
+
+// scanWebhook scans a row selected with webhookColumns
+func scanWebhook(row pgx.Row, w *domain.Webhook) error {
+	var eventTypes []string
+
+	err := row.Scan(
+		&w.ID,
+		&w.URL,
+		&w.Secret,
+		&eventTypes,
+		&w.Active,
+		&w.CreatedAt,
+		&w.UpdatedAt,
+		&w.SalonID,
+	)
+	if err != nil {
+		return err
+	}
+
+	w.EventTypes = make([]domain.EventType, len(eventTypes))
+	for i, t := range eventTypes {
+		w.EventTypes[i] = domain.EventType(t)
+	}
+
+	return nil
+}
+
+// eventTypeStrings converts the event types of a webhook for its text array column
+func eventTypeStrings(types []domain.EventType) []string {
+	values := make([]string, len(types))
+	for i, t := range types {
+		values[i] = string(t)
+	}
+	return values
+}
+
+// CreateWebhook inserts a new webhook into the database
+func (r *WebhookRepository) CreateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	webhook.SalonID = util.SalonOr(ctx, webhook.SalonID)
+
+	query := r.db.QueryBuilder.Insert("\"Webhook\"").
+		Columns("id", "url", "secret", "\"eventTypes\"", "active", "\"createdAt\"", "\"updatedAt\"", "\"salonId\"").
+		Values(
+			webhook.ID,
+			webhook.URL,
+			webhook.Secret,
+			eventTypeStrings(webhook.EventTypes),
+			webhook.Active,
+			webhook.CreatedAt,
+			webhook.UpdatedAt,
+			webhook.SalonID,
+		).
+		Suffix("RETURNING " + strings.Join(webhookColumns, ", "))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var created domain.Webhook
+	err = scanWebhook(r.db.Conn.QueryRow(ctx, sql, args...), &created)
+	if err != nil {
+		if errCode := r.db.ErrorCode(err); errCode == "23505" {
+			return nil, domain.ErrConflictingData
+		}
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// GetWebhookByID selects a webhook by id
+func (r *WebhookRepository) GetWebhookByID(ctx context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	var w domain.Webhook
+
+	query := r.db.QueryBuilder.Select(webhookColumns...).
+		From("\"Webhook\"").
+		Where(sq.Eq{"id": id}).
+		Where(inSalon(ctx, "\"salonId\"")).
+		Limit(1)
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanWebhook(r.db.Reader().QueryRow(ctx, sql, args...), &w)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+// ListWebhooks selects a list of webhooks with pagination, oldest first
+func (r *WebhookRepository) ListWebhooks(ctx context.Context, skip, limit uint64) ([]domain.Webhook, error) {
+	query := r.db.QueryBuilder.Select(webhookColumns...).
+		From("\"Webhook\"").
+		Where(inSalon(ctx, "\"salonId\"")).
+		OrderBy("\"createdAt\"", "id")
+
+	// Paginación (skip = número de página)
+	if limit > 0 {
+		query = query.Limit(limit).Offset(pageOffset(skip, limit))
+	}
+
+	return r.listWebhooks(ctx, query)
+}
+
+// ListActiveWebhooks selects the active webhooks of the salon of ctx subscribed to eventType
+func (r *WebhookRepository) ListActiveWebhooks(ctx context.Context, eventType domain.EventType) ([]domain.Webhook, error) {
+	query := r.db.QueryBuilder.Select(webhookColumns...).
+		From("\"Webhook\"").
+		Where(sq.Eq{"active": true}).
+		Where(inSalon(ctx, "\"salonId\"")).
+		Where("\"eventTypes\" @> ARRAY[?]::TEXT[]", string(eventType))
+
+	return r.listWebhooks(ctx, query)
+}
+
+// listWebhooks runs a select of webhookColumns and scans every row
+func (r *WebhookRepository) listWebhooks(ctx context.Context, query sq.SelectBuilder) ([]domain.Webhook, error) {
+	var webhooks []domain.Webhook
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	r.db.LogQuery(ctx, sql, args)
+
+	rows, err := r.db.Conn.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var w domain.Webhook
+		if err := scanWebhook(rows, &w); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, w)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// UpdateWebhook updates a webhook
+func (r *WebhookRepository) UpdateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	query := r.db.QueryBuilder.Update("\"Webhook\"").
+		Set("url", webhook.URL).
+		Set("secret", webhook.Secret).
+		Set("\"eventTypes\"", eventTypeStrings(webhook.EventTypes)).
+		Set("active", webhook.Active).
+		Set("\"updatedAt\"", webhook.UpdatedAt).
+		Where(sq.Eq{"id": webhook.ID}).
+		Where(inSalon(ctx, "\"salonId\"")).
+		Suffix("RETURNING " + strings.Join(webhookColumns, ", "))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var updated domain.Webhook
+	err = scanWebhook(r.db.Conn.QueryRow(ctx, sql, args...), &updated)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteWebhook deletes a webhook
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	query := r.db.QueryBuilder.Delete("\"Webhook\"").
+		Where(sq.Eq{"id": id}).
+		Where(inSalon(ctx, "\"salonId\""))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Conn.Exec(ctx, sql, args...)
+	return err
+}
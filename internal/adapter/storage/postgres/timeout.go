@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// timedConn cancels the queries of conn running longer than timeout and logs the ones
+// taking longer than slowQuery with their SQL and args, zero durations disable either
+type timedConn struct {
+	conn      Conn
+	timeout   time.Duration
+	slowQuery time.Duration
+	queryLog  queryLog
+}
+
+// Exec runs the statement within the timeout
+func (c *timedConn) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	ctx, done := c.start(ctx, sql, args)
+	defer done()
+
+	return c.conn.Exec(ctx, sql, args...)
+}
+
+// Query runs the query within the timeout, which keeps running until the rows are read or closed
+func (c *timedConn) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	ctx, done := c.start(ctx, sql, args)
+
+	rows, err := c.conn.Query(ctx, sql, args...)
+	if err != nil {
+		done()
+		return nil, err
+	}
+
+	return &timedRows{Rows: rows, done: done}, nil
+}
+
+// QueryRow runs the query within the timeout, which keeps running until the row is scanned
+func (c *timedConn) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	ctx, done := c.start(ctx, sql, args)
+
+	return &timedRow{row: c.conn.QueryRow(ctx, sql, args...), done: done}
+}
+
+// start bounds ctx by the timeout, the returned function ends the query and logs it when it was slow
+func (c *timedConn) start(ctx context.Context, sql string, args []interface{}) (context.Context, func()) {
+	cancel := context.CancelFunc(func() {})
+	if c.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+	}
+
+	started := time.Now()
+	return ctx, func() {
+		cancel()
+
+		elapsed := time.Since(started)
+		if c.slowQuery > 0 && elapsed >= c.slowQuery {
+			slog.WarnContext(ctx, "Slow query", "duration", elapsed, "sql", sql, "args", c.queryLog.redact(args))
+		}
+	}
+}
+
+// timedRows ends the query once the rows are read or closed
+type timedRows struct {
+	pgx.Rows
+	done func()
+	once sync.Once
+}
+
+func (r *timedRows) Next() bool {
+	if r.Rows.Next() {
+		return true
+	}
+
+	r.once.Do(r.done)
+	return false
+}
+
+func (r *timedRows) Close() {
+	r.Rows.Close()
+	r.once.Do(r.done)
+}
+
+// timedRow ends the query once the row is scanned
+type timedRow struct {
+	row  pgx.Row
+	done func()
+}
+
+func (r *timedRow) Scan(dest ...interface{}) error {
+	defer r.done()
+
+	return r.row.Scan(dest...)
+}
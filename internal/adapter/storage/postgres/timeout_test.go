@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+// slowConn is a connection whose statements take delay, or until their context is done
+type slowConn struct {
+	delay time.Duration
+}
+
+func (c slowConn) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	select {
+	case <-time.After(c.delay):
+		return pgconn.NewCommandTag("UPDATE 1"), nil
+	case <-ctx.Done():
+		return pgconn.CommandTag{}, ctx.Err()
+	}
+}
+
+func (c slowConn) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return nil, nil
+}
+
+func (c slowConn) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return nil
+}
+
+func TestTimedConnTimeout(t *testing.T) {
+	conn := &timedConn{conn: slowConn{delay: time.Second}, timeout: 10 * time.Millisecond}
+
+	_, err := conn.Exec(context.Background(), `UPDATE "Quote" SET "state" = $1`, "approved")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestTimedConnSlowQuery(t *testing.T) {
+	var logs bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	conn := &timedConn{conn: slowConn{delay: 20 * time.Millisecond}, slowQuery: 10 * time.Millisecond}
+
+	_, err := conn.Exec(context.Background(), `UPDATE "Quote" SET "state" = $1`, "approved")
+	assert.NoError(t, err)
+	assert.Contains(t, logs.String(), "Slow query")
+	assert.Contains(t, logs.String(), `UPDATE \"Quote\" SET \"state\" = $1`)
+	assert.Contains(t, logs.String(), "<string>")
+	assert.NotContains(t, logs.String(), "approved")
+
+	// The values are logged when the args are
+	logs.Reset()
+	conn.queryLog.args = true
+
+	_, err = conn.Exec(context.Background(), `UPDATE "Quote" SET "state" = $1`, "approved")
+	assert.NoError(t, err)
+	assert.Contains(t, logs.String(), "approved")
+
+	// Fast queries aren't logged
+	logs.Reset()
+	conn.slowQuery = time.Second
+
+	_, err = conn.Exec(context.Background(), `UPDATE "Quote" SET "state" = $1`, "approved")
+	assert.NoError(t, err)
+	assert.Empty(t, logs.String())
+}
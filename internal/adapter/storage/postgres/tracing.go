@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"harajuku/backend/internal/core/util"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer starts the spans of the queries and transactions
+var tracer = otel.Tracer("harajuku/backend/postgres")
+
+// queryTracer implements pgx.QueryTracer and wraps every query in a client span
+type queryTracer struct{}
+
+// queryStartKey is the context key of the time a query started, set when the request records its timings
+type queryStartKey struct{}
+
+// TraceQueryStart starts the span of a query, named after its statement, e.g. "INSERT"
+func (queryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if util.RecordsTimings(ctx) {
+		ctx = context.WithValue(ctx, queryStartKey{}, time.Now())
+	}
+
+	ctx, _ = tracer.Start(ctx, statement(data.SQL),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.query.text", data.SQL),
+		),
+	)
+	return ctx
+}
+
+// TraceQueryEnd ends the span of a query, a query finding no rows isn't an error
+func (queryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	if started, ok := ctx.Value(queryStartKey{}).(time.Time); ok {
+		util.RecordTiming(ctx, "db", time.Since(started))
+	}
+
+	span := trace.SpanFromContext(ctx)
+	if data.Err != nil && !errors.Is(data.Err, pgx.ErrNoRows) {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	}
+	span.SetAttributes(attribute.Int64("db.response.rows_affected", data.CommandTag.RowsAffected()))
+	span.End()
+}
+
+// statement returns the first keyword of sql, which names its span
+func statement(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "query"
+	}
+	return strings.ToUpper(fields[0])
+}
@@ -0,0 +1,64 @@
+package redis
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+
+	"harajuku/backend/internal/adapter/config"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// newClient connects to redis in the topology set by the config, Addr holds
+// the sentinel or cluster node addresses separated by commas
+func newClient(ctx context.Context, config *config.Redis) (redis.UniversalClient, error) {
+	addrs := strings.Split(config.Addr, ",")
+	for i := range addrs {
+		addrs[i] = strings.TrimSpace(addrs[i])
+	}
+
+	var tlsConfig *tls.Config
+	if config.TLS {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	var client redis.UniversalClient
+	switch config.Mode {
+	case "standalone":
+		client = redis.NewClient(&redis.Options{
+			Addr:      addrs[0],
+			Password:  config.Password,
+			DB:        0,
+			TLSConfig: tlsConfig,
+		})
+	case "sentinel":
+		client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       config.MasterName,
+			SentinelAddrs:    addrs,
+			SentinelPassword: config.SentinelPassword,
+			Password:         config.Password,
+			DB:               0,
+			TLSConfig:        tlsConfig,
+		})
+	case "cluster":
+		client = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:     addrs,
+			Password:  config.Password,
+			TLSConfig: tlsConfig,
+		})
+	default:
+		return nil, fmt.Errorf("unknown redis mode %q", config.Mode)
+	}
+
+	client.AddHook(newTracingHook())
+
+	_, err := client.Ping(ctx).Result()
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+
+	return client, nil
+}
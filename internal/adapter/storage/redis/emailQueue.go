@@ -0,0 +1,228 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// emailQueueKeys are the keys of the email queue, scheduled and dead hold the IDs ordered by time and the hashes hold the emails
+type emailQueueKeys struct {
+	scheduled  string
+	emails     string
+	dead       string
+	deadEmails string
+}
+
+// newEmailQueueKeys names the keys of the email queue. In a cluster the name is a hash tag
+// so all keys land in the same slot, as the queue updates them together in transactions
+func newEmailQueueKeys(mode string) emailQueueKeys {
+	name := "emailQueue"
+	if mode == "cluster" {
+		name = "{emailQueue}"
+	}
+
+	return emailQueueKeys{
+		scheduled:  name + ":scheduled",
+		emails:     name + ":emails",
+		dead:       name + ":dead",
+		deadEmails: name + ":deadEmails",
+	}
+}
+
+/**
+ * EmailQueue implements port.EmailQueueRepository interface
+ * and keeps the email delivery queue in redis
+ */
+type EmailQueue struct {
+	client redis.UniversalClient
+	keys   emailQueueKeys
+}
+
+// NewEmailQueue creates a new instance of EmailQueue
+func NewEmailQueue(ctx context.Context, config *config.Redis) (port.EmailQueueRepository, error) {
+	client, err := newClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EmailQueue{client, newEmailQueueKeys(config.Mode)}, nil
+}
+
+// Enqueue schedules the email to be sent at its NextAttemptAt
+func (q *EmailQueue) Enqueue(ctx context.Context, email *domain.QueuedEmail) error {
+	data, err := json.Marshal(email)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, q.keys.emails, email.ID.String(), data)
+		pipe.ZAdd(ctx, q.keys.scheduled, redis.Z{Score: float64(email.NextAttemptAt.UnixMilli()), Member: email.ID.String()})
+		return nil
+	})
+	return err
+}
+
+// Dequeue claims the next email due at now, removing it from the schedule is what makes the claim exclusive
+func (q *EmailQueue) Dequeue(ctx context.Context, now time.Time) (*domain.QueuedEmail, error) {
+	for {
+		ids, err := q.client.ZRangeByScore(ctx, q.keys.scheduled, &redis.ZRangeBy{
+			Min:   "-inf",
+			Max:   strconv.FormatInt(now.UnixMilli(), 10),
+			Count: 1,
+		}).Result()
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			return nil, domain.ErrDataNotFound
+		}
+
+		claimed, err := q.client.ZRem(ctx, q.keys.scheduled, ids[0]).Result()
+		if err != nil {
+			return nil, err
+		}
+		if claimed == 0 {
+			// Another worker claimed it first
+			continue
+		}
+
+		data, err := q.client.HGet(ctx, q.keys.emails, ids[0]).Bytes()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := q.client.HDel(ctx, q.keys.emails, ids[0]).Err(); err != nil {
+			return nil, err
+		}
+
+		var email domain.QueuedEmail
+		if err := json.Unmarshal(data, &email); err != nil {
+			return nil, err
+		}
+
+		return &email, nil
+	}
+}
+
+// Ping implements port.HealthChecker, checking the redis server holding the queue can be reached
+func (q *EmailQueue) Ping(ctx context.Context) error {
+	return q.client.Ping(ctx).Err()
+}
+
+// DeadLetter moves an email that ran out of attempts to the dead-letter list
+func (q *EmailQueue) DeadLetter(ctx context.Context, email *domain.QueuedEmail) error {
+	data, err := json.Marshal(email)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, q.keys.deadEmails, email.ID.String(), data)
+		pipe.ZAdd(ctx, q.keys.dead, redis.Z{Score: float64(time.Now().UnixMilli()), Member: email.ID.String()})
+		return nil
+	})
+	return err
+}
+
+// ListDeadLetters lists the dead-lettered emails, most recent failure first
+func (q *EmailQueue) ListDeadLetters(ctx context.Context, skip, limit uint64) ([]domain.QueuedEmail, error) {
+	start, stop := salonRange(ctx, skip, limit)
+
+	ids, err := q.client.ZRevRange(ctx, q.keys.dead, start, stop).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []domain.QueuedEmail{}, nil
+	}
+
+	values, err := q.client.HMGet(ctx, q.keys.deadEmails, ids...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	emails := make([]domain.QueuedEmail, 0, len(values))
+	for _, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		var email domain.QueuedEmail
+		if err := json.Unmarshal([]byte(data), &email); err != nil {
+			return nil, err
+		}
+		if util.InSalon(ctx, &email) {
+			emails = append(emails, email)
+		}
+	}
+
+	return salonPage(ctx, emails, skip, limit), nil
+}
+
+// RemoveDeadLetter takes an email out of the dead-letter list, the emails of other salons are not found
+func (q *EmailQueue) RemoveDeadLetter(ctx context.Context, id uuid.UUID) (*domain.QueuedEmail, error) {
+	if _, ok := util.Salon(ctx); ok {
+		data, err := q.client.HGet(ctx, q.keys.deadEmails, id.String()).Bytes()
+		if err == redis.Nil {
+			return nil, domain.ErrDataNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var email domain.QueuedEmail
+		if err := json.Unmarshal(data, &email); err != nil {
+			return nil, err
+		}
+		if !util.InSalon(ctx, &email) {
+			return nil, domain.ErrDataNotFound
+		}
+	}
+
+	removed, err := q.client.ZRem(ctx, q.keys.dead, id.String()).Result()
+	if err != nil {
+		return nil, err
+	}
+	if removed == 0 {
+		return nil, domain.ErrDataNotFound
+	}
+
+	data, err := q.client.HGet(ctx, q.keys.deadEmails, id.String()).Bytes()
+	if err == redis.Nil {
+		return nil, domain.ErrDataNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := q.client.HDel(ctx, q.keys.deadEmails, id.String()).Err(); err != nil {
+		return nil, err
+	}
+
+	var email domain.QueuedEmail
+	if err := json.Unmarshal(data, &email); err != nil {
+		return nil, err
+	}
+
+	return &email, nil
+}
+
+// Close closes the connection to the redis server holding the queue
+func (q *EmailQueue) Close() error {
+	return q.client.Close()
+}
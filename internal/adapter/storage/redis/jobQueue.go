@@ -0,0 +1,334 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// jobQueueKeys are the keys of the job queue, scheduled and dead hold the IDs ordered by time and the hashes hold the jobs
+type jobQueueKeys struct {
+	scheduled string
+	jobs      string
+	dead      string
+	deadJobs  string
+}
+
+// newJobQueueKeys names the keys of the job queue. In a cluster the name is a hash tag
+// so all keys land in the same slot, as the queue updates them together in transactions
+func newJobQueueKeys(mode string) jobQueueKeys {
+	name := "jobQueue"
+	if mode == "cluster" {
+		name = "{jobQueue}"
+	}
+
+	return jobQueueKeys{
+		scheduled: name + ":scheduled",
+		jobs:      name + ":jobs",
+		dead:      name + ":dead",
+		deadJobs:  name + ":deadJobs",
+	}
+}
+
+/**
+ * JobQueue implements port.JobQueueRepository interface
+ * and keeps the background job queue in redis
+ */
+type JobQueue struct {
+	client redis.UniversalClient
+	keys   jobQueueKeys
+}
+
+// NewJobQueue creates a new instance of JobQueue
+func NewJobQueue(ctx context.Context, config *config.Redis) (port.JobQueueRepository, error) {
+	client, err := newClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JobQueue{client, newJobQueueKeys(config.Mode)}, nil
+}
+
+// Enqueue schedules the job to run at its NextAttemptAt
+func (q *JobQueue) Enqueue(ctx context.Context, job *domain.Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, q.keys.jobs, job.ID.String(), data)
+		pipe.ZAdd(ctx, q.keys.scheduled, redis.Z{Score: float64(job.NextAttemptAt.UnixMilli()), Member: job.ID.String()})
+		return nil
+	})
+	return err
+}
+
+// Dequeue claims the next job due at now, removing it from the schedule is what makes the claim exclusive
+func (q *JobQueue) Dequeue(ctx context.Context, now time.Time) (*domain.Job, error) {
+	for {
+		ids, err := q.client.ZRangeByScore(ctx, q.keys.scheduled, &redis.ZRangeBy{
+			Min:   "-inf",
+			Max:   strconv.FormatInt(now.UnixMilli(), 10),
+			Count: 1,
+		}).Result()
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			return nil, domain.ErrDataNotFound
+		}
+
+		job, err := q.claim(ctx, ids[0])
+		if err == domain.ErrDataNotFound {
+			// Another worker claimed it first
+			continue
+		}
+
+		return job, err
+	}
+}
+
+// claim takes the job out of the schedule and returns it, ErrDataNotFound means someone else took it
+func (q *JobQueue) claim(ctx context.Context, id string) (*domain.Job, error) {
+	claimed, err := q.client.ZRem(ctx, q.keys.scheduled, id).Result()
+	if err != nil {
+		return nil, err
+	}
+	if claimed == 0 {
+		return nil, domain.ErrDataNotFound
+	}
+
+	data, err := q.client.HGet(ctx, q.keys.jobs, id).Bytes()
+	if err == redis.Nil {
+		return nil, domain.ErrDataNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := q.client.HDel(ctx, q.keys.jobs, id).Err(); err != nil {
+		return nil, err
+	}
+
+	var job domain.Job
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// ListQueued lists the jobs waiting in the queue, the next one due first
+func (q *JobQueue) ListQueued(ctx context.Context, skip, limit uint64) ([]domain.Job, error) {
+	start, stop := salonRange(ctx, skip, limit)
+
+	ids, err := q.client.ZRange(ctx, q.keys.scheduled, start, stop).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := q.jobs(ctx, q.keys.jobs, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return salonPage(ctx, jobs, skip, limit), nil
+}
+
+// GetQueued selects a job waiting in the queue by its ID
+func (q *JobQueue) GetQueued(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	return q.job(ctx, q.keys.jobs, id)
+}
+
+// RemoveQueued takes a job out of the queue, as a worker claiming it would
+func (q *JobQueue) RemoveQueued(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	// The jobs of other salons are not found
+	if _, err := q.job(ctx, q.keys.jobs, id); err != nil {
+		return nil, err
+	}
+
+	return q.claim(ctx, id.String())
+}
+
+// Ping implements port.HealthChecker, checking the redis server holding the queue can be reached
+func (q *JobQueue) Ping(ctx context.Context) error {
+	return q.client.Ping(ctx).Err()
+}
+
+// DeadLetter moves a job that ran out of attempts to the dead-letter list
+func (q *JobQueue) DeadLetter(ctx context.Context, job *domain.Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, q.keys.deadJobs, job.ID.String(), data)
+		pipe.ZAdd(ctx, q.keys.dead, redis.Z{Score: float64(time.Now().UnixMilli()), Member: job.ID.String()})
+		return nil
+	})
+	return err
+}
+
+// ListDeadLetters lists the dead-lettered jobs, most recent failure first
+func (q *JobQueue) ListDeadLetters(ctx context.Context, skip, limit uint64) ([]domain.Job, error) {
+	start, stop := salonRange(ctx, skip, limit)
+
+	ids, err := q.client.ZRevRange(ctx, q.keys.dead, start, stop).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := q.jobs(ctx, q.keys.deadJobs, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return salonPage(ctx, jobs, skip, limit), nil
+}
+
+// GetDeadLetter selects a dead-lettered job by its ID
+func (q *JobQueue) GetDeadLetter(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	return q.job(ctx, q.keys.deadJobs, id)
+}
+
+// pageRange returns the first and last index of the page skip of limit entries, skip is the page
+// number starting at 1 and no limit is every entry
+func pageRange(skip, limit uint64) (int64, int64) {
+	start, stop := int64(0), int64(-1)
+	if limit > 0 {
+		if skip > 0 {
+			start = int64((skip - 1) * limit)
+		}
+		stop = start + int64(limit) - 1
+	}
+	return start, stop
+}
+
+// salonRange returns the first and last index of the entries read for the page skip of limit entries.
+// In a salon it is every entry, as the entries of other salons are filtered out before paging
+func salonRange(ctx context.Context, skip, limit uint64) (int64, int64) {
+	if _, ok := util.Salon(ctx); ok {
+		return 0, -1
+	}
+	return pageRange(skip, limit)
+}
+
+// salonPage returns the page skip of limit of the entries read with salonRange, which are already
+// the page outside of a salon
+func salonPage[T any](ctx context.Context, entries []T, skip, limit uint64) []T {
+	if _, ok := util.Salon(ctx); !ok {
+		return entries
+	}
+
+	start, stop := pageRange(skip, limit)
+	if start >= int64(len(entries)) {
+		return []T{}
+	}
+	if stop < 0 || stop >= int64(len(entries)) {
+		return entries[start:]
+	}
+	return entries[start : stop+1]
+}
+
+// job selects the job with the ID from the hash
+func (q *JobQueue) job(ctx context.Context, hash string, id uuid.UUID) (*domain.Job, error) {
+	data, err := q.client.HGet(ctx, hash, id.String()).Bytes()
+	if err == redis.Nil {
+		return nil, domain.ErrDataNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var job domain.Job
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, err
+	}
+	if !util.InSalon(ctx, &job) {
+		return nil, domain.ErrDataNotFound
+	}
+
+	return &job, nil
+}
+
+// jobs selects the jobs with the IDs from the hash in the same order, skipping those gone meanwhile
+// and those of other salons
+func (q *JobQueue) jobs(ctx context.Context, hash string, ids []string) ([]domain.Job, error) {
+	if len(ids) == 0 {
+		return []domain.Job{}, nil
+	}
+
+	values, err := q.client.HMGet(ctx, hash, ids...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]domain.Job, 0, len(values))
+	for _, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		var job domain.Job
+		if err := json.Unmarshal([]byte(data), &job); err != nil {
+			return nil, err
+		}
+		if util.InSalon(ctx, &job) {
+			jobs = append(jobs, job)
+		}
+	}
+
+	return jobs, nil
+}
+
+// RemoveDeadLetter takes a job out of the dead-letter list
+func (q *JobQueue) RemoveDeadLetter(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	// The jobs of other salons are not found
+	if _, err := q.job(ctx, q.keys.deadJobs, id); err != nil {
+		return nil, err
+	}
+
+	removed, err := q.client.ZRem(ctx, q.keys.dead, id.String()).Result()
+	if err != nil {
+		return nil, err
+	}
+	if removed == 0 {
+		return nil, domain.ErrDataNotFound
+	}
+
+	data, err := q.client.HGet(ctx, q.keys.deadJobs, id.String()).Bytes()
+	if err == redis.Nil {
+		return nil, domain.ErrDataNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := q.client.HDel(ctx, q.keys.deadJobs, id.String()).Err(); err != nil {
+		return nil, err
+	}
+
+	var job domain.Job
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// Close closes the connection to the redis server holding the queue
+func (q *JobQueue) Close() error {
+	return q.client.Close()
+}
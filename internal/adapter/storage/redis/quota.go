@@ -0,0 +1,115 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// quotasKey is the hash holding the quotas set by admins, its fields are "role:action"
+const quotasKey = "quotas"
+
+// consumeQuota counts an action in KEYS[1] when the count is under the limit in ARGV[1], the
+// key expires at the unix time in ARGV[2]. It returns whether the action was counted and the count
+var consumeQuota = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local count = tonumber(redis.call('GET', KEYS[1]) or '0')
+if count >= limit then
+	return {0, count}
+end
+
+count = redis.call('INCR', KEYS[1])
+redis.call('EXPIREAT', KEYS[1], ARGV[2])
+return {1, count}
+`)
+
+// releaseQuota gives back an action counted in KEYS[1], never going below zero
+var releaseQuota = redis.NewScript(`
+local count = tonumber(redis.call('GET', KEYS[1]) or '0')
+if count > 0 then
+	redis.call('DECR', KEYS[1])
+end
+return 0
+`)
+
+/**
+ * QuotaRepository implements port.QuotaRepository interface
+ * and keeps the daily counts and the quotas set by admins in redis
+ */
+type QuotaRepository struct {
+	client    redis.UniversalClient
+	namespace string
+}
+
+// NewQuotaRepository creates a new instance of QuotaRepository, its keys are prefixed with namespace
+func NewQuotaRepository(ctx context.Context, config *config.Redis, namespace string) (port.QuotaRepository, error) {
+	client, err := newClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QuotaRepository{client, namespace}, nil
+}
+
+// Consume counts an action under key until resetAt, when it is still under limit
+func (r *QuotaRepository) Consume(ctx context.Context, key string, limit int, resetAt time.Time) (*domain.QuotaResult, error) {
+	values, err := consumeQuota.Run(ctx, r.client, []string{r.namespace + key}, limit, resetAt.Unix()).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.QuotaResult{
+		Allowed:   values[0] == 1,
+		Limit:     limit,
+		Remaining: max(0, limit-int(values[1])),
+		ResetAt:   resetAt,
+	}, nil
+}
+
+// Release gives back an action counted under key
+func (r *QuotaRepository) Release(ctx context.Context, key string) error {
+	return releaseQuota.Run(ctx, r.client, []string{r.namespace + key}).Err()
+}
+
+// ListQuotas selects the quotas set by admins
+func (r *QuotaRepository) ListQuotas(ctx context.Context) ([]domain.Quota, error) {
+	fields, err := r.client.HGetAll(ctx, r.namespace+quotasKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	quotas := make([]domain.Quota, 0, len(fields))
+	for field, value := range fields {
+		role, action, found := strings.Cut(field, ":")
+		limit, err := strconv.Atoi(value)
+		if !found || err != nil {
+			continue
+		}
+
+		quotas = append(quotas, domain.Quota{
+			Role:   domain.UserRole(role),
+			Action: domain.QuotaAction(action),
+			Limit:  limit,
+		})
+	}
+
+	return quotas, nil
+}
+
+// SetQuota stores a quota set by an admin
+func (r *QuotaRepository) SetQuota(ctx context.Context, quota *domain.Quota) error {
+	field := string(quota.Role) + ":" + string(quota.Action)
+	return r.client.HSet(ctx, r.namespace+quotasKey, field, quota.Limit).Err()
+}
+
+// Close closes the connection to the redis server holding the quotas
+func (r *QuotaRepository) Close() error {
+	return r.client.Close()
+}
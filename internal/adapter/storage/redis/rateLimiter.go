@@ -0,0 +1,82 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// takeToken refills the bucket in KEYS[1] for the time since it was last used and takes a token from it.
+// ARGV holds the capacity and the refill rate in tokens per millisecond, the clock is the one of redis
+// so servers with skewed clocks share the buckets fairly. It returns whether the token was taken,
+// the tokens left and the milliseconds until the next token
+var takeToken = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1]) or capacity
+local ts = tonumber(bucket[2]) or now
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens) / rate) + 1000)
+
+return {allowed, math.floor(tokens), retry}
+`)
+
+/**
+ * RateLimiter implements port.RateLimitRepository interface
+ * and keeps the token buckets in redis
+ */
+type RateLimiter struct {
+	client    redis.UniversalClient
+	namespace string
+}
+
+// NewRateLimiter creates a new instance of RateLimiter, the keys of the buckets are prefixed with namespace
+func NewRateLimiter(ctx context.Context, config *config.Redis, namespace string) (port.RateLimitRepository, error) {
+	client, err := newClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RateLimiter{client, namespace}, nil
+}
+
+// Take takes a token from the bucket under key
+func (l *RateLimiter) Take(ctx context.Context, key string, limit domain.RateLimit) (*domain.RateLimitResult, error) {
+	rate := float64(limit.Requests) / (float64(limit.Period) / float64(time.Millisecond))
+
+	values, err := takeToken.Run(ctx, l.client, []string{l.namespace + key}, limit.Requests, rate).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.RateLimitResult{
+		Allowed:    values[0] == 1,
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+	}, nil
+}
+
+// Close closes the connection to the redis server holding the buckets
+func (l *RateLimiter) Close() error {
+	return l.client.Close()
+}
@@ -14,18 +14,12 @@ import (
  * and provides an access to the redis library
  */
 type Redis struct {
-	client *redis.Client
+	client redis.UniversalClient
 }
 
 // New creates a new instance of Redis
 func New(ctx context.Context, config *config.Redis) (port.CacheRepository, error) {
-	client := redis.NewClient(&redis.Options{
-		Addr:     config.Addr,
-		Password: config.Password,
-		DB:       0,
-	})
-
-	_, err := client.Ping(ctx).Result()
+	client, err := newClient(ctx, config)
 	if err != nil {
 		return nil, err
 	}
@@ -33,9 +27,27 @@ func New(ctx context.Context, config *config.Redis) (port.CacheRepository, error
 	return &Redis{client}, nil
 }
 
-// Set stores the value in the redis database
-func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
-	return r.client.Set(ctx, key, value, ttl).Err()
+// Set stores the value in the redis database, adding the key to the set of every tag.
+// Tag sets expire with the longest lived of their keys
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, key, value, ttl)
+
+		for _, tag := range tags {
+			tagKey := tagKey(tag)
+			pipe.SAdd(ctx, tagKey, key)
+
+			if ttl > 0 {
+				pipe.ExpireNX(ctx, tagKey, ttl)
+				pipe.ExpireGT(ctx, tagKey, ttl)
+			} else {
+				pipe.Persist(ctx, tagKey)
+			}
+		}
+
+		return nil
+	})
+	return err
 }
 
 // Get retrieves the value from the redis database
@@ -50,34 +62,92 @@ func (r *Redis) Delete(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
 
-// DeleteByPrefix removes the value from the redis database with the given prefix
+// scanCount is how many keys are asked for on every SCAN and unlinked per pipeline
+const scanCount = 500
+
+// DeleteByPrefix removes the value from the redis database with the given prefix.
+// The keyspace is walked with SCAN so redis is never blocked, and every batch of keys
+// is unlinked in a single round trip, leaving the memory to be freed in the background.
+// In a cluster every master holds part of the keyspace, so each one is scanned
 func (r *Redis) DeleteByPrefix(ctx context.Context, prefix string) error {
-	var cursor uint64
-	var keys []string
-
-	for {
-		var err error
-		keys, cursor, err = r.client.Scan(ctx, cursor, prefix, 100).Result()
-		if err != nil {
-			return err
-		}
+	if cluster, ok := r.client.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return r.deleteByPrefix(ctx, node, prefix)
+		})
+	}
+	return r.deleteByPrefix(ctx, r.client, prefix)
+}
 
-		for _, key := range keys {
-			err := r.client.Del(ctx, key).Err()
-			if err != nil {
+// deleteByPrefix scans a single node for the keys with the given prefix
+func (r *Redis) deleteByPrefix(ctx context.Context, node redis.Cmdable, prefix string) error {
+	iter := node.Scan(ctx, 0, prefix, scanCount).Iterator()
+	keys := make([]string, 0, scanCount)
+
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == scanCount {
+			if err := r.unlink(ctx, keys); err != nil {
 				return err
 			}
+			keys = keys[:0]
 		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	return r.unlink(ctx, keys)
+}
+
+// unlink removes the keys in a single pipeline
+func (r *Redis) unlink(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
 
-		if cursor == 0 {
-			break
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Unlink(ctx, key)
 		}
+		return nil
+	})
+	return err
+}
+
+// DeleteByTag removes every value stored with the given tag. Only the keys that were
+// read are removed from the tag set, so a key tagged meanwhile is kept for the next invalidation
+func (r *Redis) DeleteByTag(ctx context.Context, tag string) error {
+	tagKey := tagKey(tag)
+
+	keys, err := r.client.SMembers(ctx, tagKey).Result()
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	members := make([]any, len(keys))
+	for i, key := range keys {
+		members[i] = key
 	}
 
-	return nil
+	_, err = r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Del(ctx, key)
+		}
+		pipe.SRem(ctx, tagKey, members...)
+		return nil
+	})
+	return err
 }
 
 // Close closes the connection to the redis database
 func (r *Redis) Close() error {
 	return r.client.Close()
 }
+
+// tagKey returns the key of the set holding the keys stored with tag
+func tagKey(tag string) string {
+	return "tag:" + tag
+}
@@ -0,0 +1,45 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// schedulerLockPrefix prefixes the keys of the scheduler locks, which are followed by the task and the unix time of the run
+const schedulerLockPrefix = "scheduler:"
+
+/**
+ * SchedulerLockRepository implements port.SchedulerLockRepository interface
+ * and takes a key per run of a task with SET NX, so only the first server to ask runs it
+ */
+type SchedulerLockRepository struct {
+	client    redis.UniversalClient
+	namespace string
+}
+
+// NewSchedulerLockRepository creates a new instance of SchedulerLockRepository, its keys are prefixed with namespace
+func NewSchedulerLockRepository(ctx context.Context, config *config.Redis, namespace string) (port.SchedulerLockRepository, error) {
+	client, err := newClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SchedulerLockRepository{client, namespace}, nil
+}
+
+// TryLock takes the lock of the run of task due at runAt, reporting false when another server took it
+func (r *SchedulerLockRepository) TryLock(ctx context.Context, task string, runAt time.Time, ttl time.Duration) (bool, error) {
+	key := r.namespace + schedulerLockPrefix + task + ":" + strconv.FormatInt(runAt.Unix(), 10)
+	return r.client.SetNX(ctx, key, 1, ttl).Result()
+}
+
+// Close closes the connection to the redis server
+func (r *SchedulerLockRepository) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,83 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"harajuku/backend/internal/core/util"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracingHook implements redis.Hook and wraps every command and pipeline in a client span
+type tracingHook struct {
+	tracer trace.Tracer
+}
+
+// newTracingHook creates a new tracingHook instance
+func newTracingHook() *tracingHook {
+	return &tracingHook{otel.Tracer("harajuku/backend/redis")}
+}
+
+// DialHook leaves dialing untraced
+func (h *tracingHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+// ProcessHook traces a command, named after it, e.g. "GET"
+func (h *tracingHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		ctx, span := h.start(ctx, cmd.FullName(), 1)
+		defer span.End()
+		defer recordTiming(ctx, time.Now())
+
+		err := next(ctx, cmd)
+		end(span, err)
+		return err
+	}
+}
+
+// ProcessPipelineHook traces a pipeline or transaction as a whole
+func (h *tracingHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		ctx, span := h.start(ctx, "pipeline", len(cmds))
+		defer span.End()
+		defer recordTiming(ctx, time.Now())
+
+		err := next(ctx, cmds)
+		end(span, err)
+		return err
+	}
+}
+
+// start starts the client span of name, the keys and values are left out as they may hold personal data
+func (h *tracingHook) start(ctx context.Context, name string, commands int) (context.Context, trace.Span) {
+	return h.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "redis"),
+			attribute.Int("db.operation.batch.size", commands),
+		),
+	)
+}
+
+// end records err on the span, a missing key isn't an error
+func end(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, redis.Nil) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// recordTiming adds the command or pipeline started at started to the cache timings of the request
+func recordTiming(ctx context.Context, started time.Time) {
+	util.RecordTiming(ctx, "cache", time.Since(started))
+}
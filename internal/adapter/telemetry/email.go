@@ -0,0 +1,48 @@
+package telemetry
+
+import (
+	"context"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+/**
+ * TracedEmailRepository implements port.EmailRepository interface
+ * and wraps every email sent through the provider in a span
+ */
+type TracedEmailRepository struct {
+	repo     port.EmailRepository
+	provider string
+	tracer   trace.Tracer
+}
+
+// NewTracedEmailRepository creates a new TracedEmailRepository instance, provider names the email provider in the spans
+func NewTracedEmailRepository(repo port.EmailRepository, provider string) *TracedEmailRepository {
+	return &TracedEmailRepository{
+		repo,
+		provider,
+		otel.Tracer("harajuku/backend/email"),
+	}
+}
+
+// SendEmail traces the delivery of an email, the recipients are left out of the span as they are personal data
+func (r *TracedEmailRepository) SendEmail(ctx context.Context, to []string, subject string, textContent string, htmlContent string, attachments ...domain.EmailAttachment) (string, error) {
+	ctx, span := r.tracer.Start(ctx, "email.Send",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("email.provider", r.provider),
+			attribute.Int("email.recipients", len(to)),
+			attribute.Int("email.attachments", len(attachments)),
+		),
+	)
+	defer span.End()
+
+	id, err := r.repo.SendEmail(ctx, to, subject, textContent, htmlContent, attachments...)
+	end(span, err)
+	return id, err
+}
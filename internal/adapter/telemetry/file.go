@@ -0,0 +1,78 @@
+package telemetry
+
+import (
+	"context"
+	"io"
+
+	"harajuku/backend/internal/core/port"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+/**
+ * TracedFileRepository implements port.FileRepository interface
+ * and wraps every call to the file storage in a span
+ */
+type TracedFileRepository struct {
+	repo     port.FileRepository
+	provider string
+	tracer   trace.Tracer
+}
+
+// NewTracedFileRepository creates a new TracedFileRepository instance, provider names the storage in the spans
+func NewTracedFileRepository(repo port.FileRepository, provider string) *TracedFileRepository {
+	return &TracedFileRepository{
+		repo,
+		provider,
+		otel.Tracer("harajuku/backend/storage"),
+	}
+}
+
+// Save traces the upload of a file
+func (r *TracedFileRepository) Save(ctx context.Context, reader io.Reader, size int64, name string) (string, error) {
+	ctx, span := r.start(ctx, "storage.Save", attribute.String("file.name", name), attribute.Int64("file.size", size))
+	defer span.End()
+
+	path, err := r.repo.Save(ctx, reader, size, name)
+	end(span, err)
+	return path, err
+}
+
+// Get traces the download of a file
+func (r *TracedFileRepository) Get(ctx context.Context, path string) ([]byte, error) {
+	ctx, span := r.start(ctx, "storage.Get", attribute.String("file.path", path))
+	defer span.End()
+
+	data, err := r.repo.Get(ctx, path)
+	end(span, err)
+	return data, err
+}
+
+// Delete traces the deletion of a file
+func (r *TracedFileRepository) Delete(ctx context.Context, path string) error {
+	ctx, span := r.start(ctx, "storage.Delete", attribute.String("file.path", path))
+	defer span.End()
+
+	err := r.repo.Delete(ctx, path)
+	end(span, err)
+	return err
+}
+
+// start starts a client span tagged with the storage provider
+func (r *TracedFileRepository) start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return r.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(append(attrs, attribute.String("storage.provider", r.provider))...),
+	)
+}
+
+// end records err on the span, if any
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
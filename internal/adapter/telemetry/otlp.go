@@ -0,0 +1,254 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+/**
+ * OTLPExporter implements sdktrace.SpanExporter interface
+ * and sends the spans to an OTLP/HTTP collector encoded as JSON
+ */
+type OTLPExporter struct {
+	client  *http.Client
+	url     string
+	headers map[string]string
+}
+
+// NewOTLPExporter creates a new OTLPExporter sending to the /v1/traces path of endpoint,
+// headers are written as "key=value,key2=value2"
+func NewOTLPExporter(endpoint, headers string) (*OTLPExporter, error) {
+	parsed := make(map[string]string)
+	for _, pair := range strings.Split(headers, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		key, value, found := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid OTLP header %q", pair)
+		}
+		parsed[key] = strings.TrimSpace(value)
+	}
+
+	return &OTLPExporter{
+		client:  &http.Client{Timeout: 10 * time.Second},
+		url:     strings.TrimSuffix(endpoint, "/") + "/v1/traces",
+		headers: parsed,
+	}, nil
+}
+
+// ExportSpans sends a batch of spans to the collector
+func (e *OTLPExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(newExportRequest(spans))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("OTLP export failed with status %d: %s", res.StatusCode, msg)
+	}
+
+	return nil
+}
+
+// Shutdown releases the idle connections to the collector
+func (e *OTLPExporter) Shutdown(ctx context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// The types below are the JSON encoding of an OTLP ExportTraceServiceRequest, IDs are hex
+// and 64 bit integers are strings as the OTLP JSON mapping requires
+
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   otlpResource `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string      `json:"traceId"`
+	SpanID            string      `json:"spanId"`
+	ParentSpanID      string      `json:"parentSpanId,omitempty"`
+	Name              string      `json:"name"`
+	Kind              int         `json:"kind"`
+	StartTimeUnixNano string      `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string      `json:"endTimeUnixNano"`
+	Attributes        []keyValue  `json:"attributes,omitempty"`
+	Events            []otlpEvent `json:"events,omitempty"`
+	Status            otlpStatus  `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string     `json:"timeUnixNano"`
+	Name         string     `json:"name"`
+	Attributes   []keyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// newExportRequest groups the spans by resource and instrumentation scope
+func newExportRequest(spans []sdktrace.ReadOnlySpan) exportRequest {
+	var req exportRequest
+	resources := make(map[attribute.Distinct]int)
+	scopes := make(map[attribute.Distinct]map[string]int)
+
+	for _, span := range spans {
+		res := span.Resource()
+		if res == nil {
+			res = resource.Empty()
+		}
+
+		key := res.Equivalent()
+		r, ok := resources[key]
+		if !ok {
+			r = len(req.ResourceSpans)
+			resources[key] = r
+			scopes[key] = make(map[string]int)
+			req.ResourceSpans = append(req.ResourceSpans, resourceSpans{
+				Resource: otlpResource{Attributes: newKeyValues(res.Attributes())},
+			})
+		}
+
+		scope := span.InstrumentationScope()
+		s, ok := scopes[key][scope.Name]
+		if !ok {
+			s = len(req.ResourceSpans[r].ScopeSpans)
+			scopes[key][scope.Name] = s
+			req.ResourceSpans[r].ScopeSpans = append(req.ResourceSpans[r].ScopeSpans, scopeSpans{
+				Scope: otlpScope{Name: scope.Name, Version: scope.Version},
+			})
+		}
+
+		req.ResourceSpans[r].ScopeSpans[s].Spans = append(req.ResourceSpans[r].ScopeSpans[s].Spans, newSpan(span))
+	}
+
+	return req
+}
+
+// newSpan encodes a span, the kinds of the SDK match the OTLP ones but the status codes don't
+func newSpan(span sdktrace.ReadOnlySpan) otlpSpan {
+	encoded := otlpSpan{
+		TraceID:           span.SpanContext().TraceID().String(),
+		SpanID:            span.SpanContext().SpanID().String(),
+		Name:              span.Name(),
+		Kind:              int(span.SpanKind()),
+		StartTimeUnixNano: strconv.FormatInt(span.StartTime().UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.EndTime().UnixNano(), 10),
+		Attributes:        newKeyValues(span.Attributes()),
+	}
+	if span.Parent().IsValid() {
+		encoded.ParentSpanID = span.Parent().SpanID().String()
+	}
+
+	for _, event := range span.Events() {
+		encoded.Events = append(encoded.Events, otlpEvent{
+			TimeUnixNano: strconv.FormatInt(event.Time.UnixNano(), 10),
+			Name:         event.Name,
+			Attributes:   newKeyValues(event.Attributes),
+		})
+	}
+
+	switch span.Status().Code {
+	case codes.Ok:
+		encoded.Status = otlpStatus{Code: 1}
+	case codes.Error:
+		encoded.Status = otlpStatus{Code: 2, Message: span.Status().Description}
+	}
+
+	return encoded
+}
+
+// newKeyValues encodes attributes, slices are sent as their string form
+func newKeyValues(attrs []attribute.KeyValue) []keyValue {
+	encoded := make([]keyValue, 0, len(attrs))
+
+	for _, attr := range attrs {
+		var value anyValue
+		switch attr.Value.Type() {
+		case attribute.BOOL:
+			b := attr.Value.AsBool()
+			value.BoolValue = &b
+		case attribute.INT64:
+			i := strconv.FormatInt(attr.Value.AsInt64(), 10)
+			value.IntValue = &i
+		case attribute.FLOAT64:
+			f := attr.Value.AsFloat64()
+			value.DoubleValue = &f
+		default:
+			s := attr.Value.Emit()
+			value.StringValue = &s
+		}
+
+		encoded = append(encoded, keyValue{Key: string(attr.Key), Value: value})
+	}
+
+	return encoded
+}
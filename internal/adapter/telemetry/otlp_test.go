@@ -0,0 +1,93 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestOTLPExporterSendsSpans(t *testing.T) {
+	var received exportRequest
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		headers = r.Header
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	exporter, err := NewOTLPExporter(server.URL+"/", "x-api-key=secret")
+	require.NoError(t, err)
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	tracer := provider.Tracer("harajuku/backend/test")
+
+	ctx, parent := tracer.Start(context.Background(), "POST /v1/quotes", trace.WithSpanKind(trace.SpanKindServer))
+	_, child := tracer.Start(ctx, "INSERT", trace.WithAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.Int64("db.response.rows_affected", 1),
+	))
+	child.SetStatus(codes.Error, "duplicate key")
+	child.End()
+	require.NoError(t, provider.ForceFlush(ctx))
+
+	assert.Equal(t, "application/json", headers.Get("Content-Type"))
+	assert.Equal(t, "secret", headers.Get("X-Api-Key"))
+
+	require.Len(t, received.ResourceSpans, 1)
+	require.Len(t, received.ResourceSpans[0].ScopeSpans, 1)
+	assert.Equal(t, "harajuku/backend/test", received.ResourceSpans[0].ScopeSpans[0].Scope.Name)
+
+	spans := received.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "INSERT", span.Name)
+	assert.Equal(t, parent.SpanContext().TraceID().String(), span.TraceID)
+	assert.Equal(t, parent.SpanContext().SpanID().String(), span.ParentSpanID)
+	assert.Equal(t, 1, span.Kind)
+	assert.Equal(t, otlpStatus{Code: 2, Message: "duplicate key"}, span.Status)
+
+	require.Len(t, span.Attributes, 2)
+	assert.Equal(t, "postgresql", *span.Attributes[0].Value.StringValue)
+	assert.Equal(t, "1", *span.Attributes[1].Value.IntValue)
+
+	parent.End()
+}
+
+func TestOTLPExporterReportsRejectedBatches(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "quota exceeded", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	exporter, err := NewOTLPExporter(server.URL, "")
+	require.NoError(t, err)
+
+	// The provider only hands out the span, the exporter is called directly to see its error
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(discard{}))
+	_, span := provider.Tracer("test").Start(context.Background(), "GET")
+	span.End()
+
+	err = exporter.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{span.(sdktrace.ReadOnlySpan)})
+	assert.ErrorContains(t, err, "status 429")
+}
+
+func TestNewOTLPExporterInvalidHeaders(t *testing.T) {
+	_, err := NewOTLPExporter("http://localhost:4318", "x-api-key")
+	assert.Error(t, err)
+}
+
+// discard is an exporter that drops the spans
+type discard struct{}
+
+func (discard) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error { return nil }
+func (discard) Shutdown(ctx context.Context) error                                   { return nil }
@@ -1,6 +1,8 @@
 package domain
 
 import (
+  "time"
+
   "github.com/google/uuid"
 )
 
@@ -22,4 +24,9 @@ type Appointment struct {
 	SlotID     		uuid.UUID
 	QuoteID       uuid.UUID
   Status      	AppointmentStatus
+	// DeletedAt se asigna cuando la cita se elimina lógicamente
+	DeletedAt     *time.Time
+	// Version se incrementa en cada actualización para detectar ediciones concurrentes
+	Version       int
+	SalonID       uuid.UUID
 }
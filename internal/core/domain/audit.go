@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEntity is an enum for the entities whose sensitive changes are audited
+type AuditEntity string
+
+// AuditEntity enum values
+const (
+	AuditEntityUser          AuditEntity = "user"
+	AuditEntityTypeOfService AuditEntity = "typeOfService"
+	AuditEntityPaymentProof  AuditEntity = "paymentProof"
+)
+
+// AuditAction is an enum for the sensitive changes services audit
+type AuditAction string
+
+// AuditAction enum values
+const (
+	AuditRoleChanged           AuditAction = "role.changed"
+	AuditPriceChanged          AuditAction = "price.changed"
+	AuditPaymentApproved       AuditAction = "payment.approved"
+	AuditPaymentApprovalUndone AuditAction = "payment.approvalUndone"
+)
+
+// IsValid reports whether the action is one services audit
+func (a AuditAction) IsValid() bool {
+	switch a {
+	case AuditRoleChanged, AuditPriceChanged, AuditPaymentApproved, AuditPaymentApprovalUndone:
+		return true
+	default:
+		return false
+	}
+}
+
+// AuditEntry is an entity that records a sensitive change a service made, with the values the entity had
+// before and after it. Unlike the audit log of the requests it says what changed, not what was asked for
+type AuditEntry struct {
+	ID       uuid.UUID
+	Entity   AuditEntity
+	EntityID uuid.UUID
+	Action   AuditAction
+	// ActorID and ActorRole are the user who made the change, nil for the changes the system made on its own
+	ActorID   *uuid.UUID
+	ActorRole UserRole
+	Before    map[string]any
+	After     map[string]any
+	CreatedAt time.Time
+	SalonID   uuid.UUID
+}
+
+// NewAuditEntry creates an entry of action on the entity, made by actor, which is nil for the system
+func NewAuditEntry(actor *TokenPayload, entity AuditEntity, entityID uuid.UUID, action AuditAction, before, after map[string]any) *AuditEntry {
+	entry := &AuditEntry{
+		ID:        uuid.New(),
+		Entity:    entity,
+		EntityID:  entityID,
+		Action:    action,
+		Before:    before,
+		After:     after,
+		CreatedAt: time.Now(),
+	}
+	if actor != nil {
+		entry.ActorID = &actor.UserID
+		entry.ActorRole = actor.Role
+	}
+
+	return entry
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditLog is an entity that records a request an authenticated user made to change data, kept so
+// admins can tell who changed what and when
+type AuditLog struct {
+	ID        uuid.UUID
+	ActorID   uuid.UUID
+	ActorRole UserRole
+	Method    string
+	// Route is the route template, e.g. /v1/quotes/:id, and Path the path that was requested
+	Route string
+	Path  string
+	// TargetID is the ID of the entity the request changed, nil when the route doesn't name one
+	TargetID *uuid.UUID
+	Status   int
+	// Changes holds the fields the request sent, with the secrets redacted, nil for bodies that aren't JSON
+	Changes   map[string]any
+	RequestID string
+	CreatedAt time.Time
+	SalonID   uuid.UUID
+}
@@ -12,4 +12,9 @@ type AvailabilitySlot struct {
 	StartTime     time.Time
 	EndTime       time.Time
   IsBooked      bool
+	// DeletedAt se asigna cuando el slot se elimina lógicamente
+	DeletedAt     *time.Time
+	// Version se incrementa en cada actualización para detectar ediciones concurrentes
+	Version       int
+	SalonID       uuid.UUID
 }
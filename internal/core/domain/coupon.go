@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Coupon is an entity that represents a discount code clients apply to their quotes, on top of the
+// promotion the quote was priced with
+type Coupon struct {
+	ID uuid.UUID
+	// Code is what clients type to apply the coupon, unique within its salon and kept in upper case
+	Code         string
+	DiscountType DiscountType
+	// Value is a percentage between 0 and 100 or a fixed amount in Currency
+	Value    float64
+	Currency Currency
+	StartsAt time.Time
+	EndsAt   time.Time
+	// MaxRedemptions caps the quotes the coupon is applied to, it is unlimited when zero
+	MaxRedemptions int
+	// MaxRedemptionsPerClient caps the quotes of a single client the coupon is applied to, it is unlimited when zero
+	MaxRedemptionsPerClient int
+	// TypeOfServiceIDs restricts the coupon to these types of service, it applies to every service when empty
+	TypeOfServiceIDs []uuid.UUID
+	// Redemptions is the number of quotes the coupon is applied to
+	Redemptions int
+	SalonID     uuid.UUID
+}
+
+// CouponRedemption records that a coupon was applied to a quote, a quote has at most one
+type CouponRedemption struct {
+	ID         uuid.UUID
+	CouponID   uuid.UUID
+	QuoteID    uuid.UUID
+	ClientID   uuid.UUID
+	RedeemedAt time.Time
+}
+
+// NormalizeCouponCode returns the form a coupon code is stored and looked up in, so clients can type it in any case
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// IsValid checks the code, the discount value, the validity window and the usage limits of a coupon
+func (c *Coupon) IsValid() bool {
+	return c.Code != "" &&
+		validDiscount(c.DiscountType, c.Value, c.Currency) &&
+		c.EndsAt.After(c.StartsAt) &&
+		c.MaxRedemptions >= 0 &&
+		c.MaxRedemptionsPerClient >= 0
+}
+
+// IsActive reports whether the coupon can be applied at the given time
+func (c *Coupon) IsActive(at time.Time) bool {
+	return !at.Before(c.StartsAt) && at.Before(c.EndsAt)
+}
+
+// AppliesTo reports whether the coupon covers a type of service priced in a currency,
+// fixed discounts only cover prices in their own currency
+func (c *Coupon) AppliesTo(typeOfServiceID uuid.UUID, currency Currency) bool {
+	if c.DiscountType == DiscountFixed && c.Currency != currency {
+		return false
+	}
+
+	if len(c.TypeOfServiceIDs) == 0 {
+		return true
+	}
+
+	for _, id := range c.TypeOfServiceIDs {
+		if id == typeOfServiceID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Discount returns the amount the coupon takes off a price, never more than the price itself
+func (c *Coupon) Discount(price float64, currency Currency) float64 {
+	return discountOf(c.DiscountType, c.Value, c.Currency, price, currency)
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCouponWindowLimitsAndServices(t *testing.T) {
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	serviceID := uuid.New()
+	coupon := Coupon{
+		Code:                    NormalizeCouponCode(" verano25 "),
+		DiscountType:            DiscountPercentage,
+		Value:                   25,
+		StartsAt:                start,
+		EndsAt:                  start.Add(90 * 24 * time.Hour),
+		MaxRedemptionsPerClient: 1,
+		TypeOfServiceIDs:        []uuid.UUID{serviceID},
+	}
+
+	assert.Equal(t, "VERANO25", coupon.Code)
+	assert.True(t, coupon.IsValid())
+	assert.True(t, coupon.IsActive(start))
+	assert.False(t, coupon.IsActive(coupon.EndsAt))
+	assert.True(t, coupon.AppliesTo(serviceID, CurrencyMXN))
+	assert.False(t, coupon.AppliesTo(uuid.New(), CurrencyMXN))
+	assert.Equal(t, 250.0, coupon.Discount(1000, CurrencyMXN))
+
+	coupon.MaxRedemptions = -1
+	assert.False(t, coupon.IsValid())
+}
+
+func TestFixedCouponOnlyAppliesToItsCurrency(t *testing.T) {
+	coupon := Coupon{
+		Code:         "BIENVENIDA",
+		DiscountType: DiscountFixed,
+		Value:        150,
+		Currency:     CurrencyMXN,
+		StartsAt:     time.Now(),
+		EndsAt:       time.Now().Add(time.Hour),
+	}
+
+	assert.True(t, coupon.IsValid())
+	assert.True(t, coupon.AppliesTo(uuid.New(), CurrencyMXN))
+	assert.False(t, coupon.AppliesTo(uuid.New(), CurrencyUSD))
+	assert.Equal(t, 100.0, coupon.Discount(100, CurrencyMXN))
+
+	coupon.Currency = ""
+	assert.False(t, coupon.IsValid())
+}
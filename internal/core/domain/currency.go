@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Currency is an ISO 4217 currency code
+type Currency string
+
+// Currency enum values
+const (
+	CurrencyMXN Currency = "MXN"
+	CurrencyUSD Currency = "USD"
+	CurrencyEUR Currency = "EUR"
+)
+
+// currencyFormats holds how each supported currency is displayed
+var currencyFormats = map[Currency]struct {
+	symbol   string
+	decimals int
+}{
+	CurrencyMXN: {"$", 2},
+	CurrencyUSD: {"US$", 2},
+	CurrencyEUR: {"€", 2},
+}
+
+// IsValid checks if a Currency is supported
+func (c Currency) IsValid() bool {
+	_, ok := currencyFormats[c]
+	return ok
+}
+
+// Symbol returns the symbol used to display amounts in this currency
+func (c Currency) Symbol() string {
+	return currencyFormats[c].symbol
+}
+
+// Decimals returns the number of minor unit digits of this currency
+func (c Currency) Decimals() int {
+	return currencyFormats[c].decimals
+}
+
+// ExchangeRates maps a currency to how many units of the default currency one unit of it is worth
+type ExchangeRates struct {
+	Base  Currency
+	Rates map[Currency]float64
+}
+
+// ParseExchangeRates parses rates written as "USD:17.05,EUR:18.40" against the base currency
+func ParseExchangeRates(base Currency, s string) (*ExchangeRates, error) {
+	if !base.IsValid() {
+		return nil, ErrUnsupportedCurrency
+	}
+
+	rates := &ExchangeRates{
+		Base:  base,
+		Rates: map[Currency]float64{base: 1},
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		code, value, found := strings.Cut(pair, ":")
+		currency := Currency(strings.ToUpper(strings.TrimSpace(code)))
+		if !found || !currency.IsValid() {
+			return nil, ErrUnsupportedCurrency
+		}
+
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || rate <= 0 {
+			return nil, errors.New("invalid exchange rate for " + string(currency))
+		}
+
+		rates.Rates[currency] = rate
+	}
+
+	return rates, nil
+}
+
+// Convert converts an amount between two currencies through the base currency
+func (er *ExchangeRates) Convert(amount float64, from, to Currency) (float64, error) {
+	fromRate, ok := er.Rates[from]
+	if !ok {
+		return 0, ErrUnsupportedCurrency
+	}
+
+	toRate, ok := er.Rates[to]
+	if !ok {
+		return 0, ErrUnsupportedCurrency
+	}
+
+	return amount * fromRate / toRate, nil
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cursor is the position of a row in a listing ordered by a timestamp, with the id breaking ties.
+// A page that starts after a cursor stays stable while rows are inserted before it
+type Cursor struct {
+	Time time.Time
+	ID   uuid.UUID
+}
+
+// Encode returns the cursor as an opaque token for clients
+func (c Cursor) Encode() string {
+	raw := c.Time.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a token returned by Encode
+func DecodeCursor(token string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	value, id, found := strings.Cut(string(raw), "|")
+	if !found {
+		return nil, ErrInvalidCursor
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{t, parsedID}, nil
+}
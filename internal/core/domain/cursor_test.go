@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := Cursor{time.Date(2025, 3, 14, 9, 30, 0, 123456789, time.FixedZone("CST", -6*3600)), uuid.New()}
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	require.NoError(t, err)
+
+	assert.True(t, cursor.Time.Equal(decoded.Time))
+	assert.Equal(t, cursor.ID, decoded.ID)
+}
+
+func TestDecodeCursorRejectsGarbage(t *testing.T) {
+	for _, token := range []string{"", "not base64!", "bm8tc2VwYXJhdG9y", "eHx5"} {
+		_, err := DecodeCursor(token)
+		assert.ErrorIs(t, err, ErrInvalidCursor, token)
+	}
+}
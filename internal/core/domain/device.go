@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DevicePlatform is an enum for the platforms the mobile app runs on
+type DevicePlatform string
+
+// DevicePlatform enum values
+const (
+	PlatformAndroid DevicePlatform = "android"
+	PlatformIOS     DevicePlatform = "ios"
+	PlatformWeb     DevicePlatform = "web"
+)
+
+// Device is an entity that represents a device a user signed in to the app from,
+// its token is the address push notifications are sent to
+type Device struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Token     string
+	Platform  DevicePlatform
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	SalonID   uuid.UUID
+}
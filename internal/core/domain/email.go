@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailTemplate is an enum for the event an email and an in-app notification are sent for
+type EmailTemplate string
+
+// EmailTemplate enum values
+const (
+	EmailQuoteCreated         EmailTemplate = "quote-created"
+	EmailQuoteRequiresProof   EmailTemplate = "quote-requires-proof"
+	EmailQuoteStateChanged    EmailTemplate = "quote-state-changed"
+	EmailAppointmentConfirmed EmailTemplate = "appointment-confirmed"
+	EmailAppointmentReminder  EmailTemplate = "appointment-reminder"
+	EmailPaymentProofUploaded EmailTemplate = "payment-proof-uploaded"
+	EmailReportReady          EmailTemplate = "report-ready"
+)
+
+// NewData returns a pointer to a zero value of the data of the template, for decoding the data of a queued
+// notification back into the type its templates were written for
+func (t EmailTemplate) NewData() (any, bool) {
+	switch t {
+	case EmailQuoteCreated:
+		return &QuoteCreatedEmail{}, true
+	case EmailQuoteRequiresProof, EmailQuoteStateChanged:
+		return &QuoteStateEmail{}, true
+	case EmailAppointmentConfirmed:
+		return &AppointmentConfirmedEmail{}, true
+	case EmailAppointmentReminder:
+		return &AppointmentReminderEmail{}, true
+	case EmailPaymentProofUploaded:
+		return &PaymentProofUploadedEmail{}, true
+	}
+	return nil, false
+}
+
+// EmailMessage is an email rendered from a template, with the HTML and plain text parts of the same content
+type EmailMessage struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// EmailAttachment is a file sent along with an email, such as a receipt or a quote PDF
+type EmailAttachment struct {
+	FileName    string
+	Content     []byte
+	ContentType string
+}
+
+// The data of every template is also the payload of its in-app notification
+
+// QuoteCreatedEmail is the data of the email admins receive when a client creates a quote
+type QuoteCreatedEmail struct {
+	QuoteID     uuid.UUID `json:"quoteId"`
+	Description string    `json:"description"`
+	ClientName  string    `json:"clientName"`
+}
+
+// QuoteStateEmail is the data of the emails a client receives when their quote changes state
+type QuoteStateEmail struct {
+	QuoteID    uuid.UUID  `json:"quoteId"`
+	ClientName string     `json:"clientName"`
+	State      QuoteState `json:"state"`
+}
+
+// AppointmentConfirmedEmail is the data of the email a client receives when their appointment is booked
+type AppointmentConfirmedEmail struct {
+	AppointmentID uuid.UUID `json:"appointmentId"`
+	QuoteID       uuid.UUID `json:"quoteId"`
+	ClientName    string    `json:"clientName"`
+	StartTime     time.Time `json:"startTime"`
+	EndTime       time.Time `json:"endTime"`
+}
+
+// AppointmentReminderEmail is the data of the email a client receives shortly before their appointment
+type AppointmentReminderEmail struct {
+	AppointmentID uuid.UUID `json:"appointmentId"`
+	QuoteID       uuid.UUID `json:"quoteId"`
+	ClientName    string    `json:"clientName"`
+	StartTime     time.Time `json:"startTime"`
+	EndTime       time.Time `json:"endTime"`
+}
+
+// PaymentProofUploadedEmail is the data of the email admins receive when a client uploads a proof of payment
+type PaymentProofUploadedEmail struct {
+	PaymentProofID uuid.UUID `json:"paymentProofId"`
+	QuoteID        uuid.UUID `json:"quoteId"`
+	ClientName     string    `json:"clientName"`
+}
+
+// ReportReadyEmail is the data of the email an admin receives with the report they asked for attached
+type ReportReadyEmail struct {
+	AdminName string     `json:"adminName"`
+	Kind      ReportKind `json:"kind"`
+	Rows      int        `json:"rows"`
+}
+
+// QueuedEmail is an email waiting in the delivery queue, or in the dead-letter list once it ran out of attempts
+type QueuedEmail struct {
+	ID            uuid.UUID
+	To            []string
+	Subject       string
+	Text          string
+	HTML          string
+	Attachments   []EmailAttachment
+	Template      EmailTemplate
+	Attempts      int
+	LastError     string
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+	// SalonID is the salon the email was sent from, its delivery attempts are logged in it
+	SalonID uuid.UUID
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailStatus is an enum for the outcome of an attempt to deliver an email
+type EmailStatus string
+
+// EmailStatus enum values
+const (
+	EmailSent   EmailStatus = "sent"
+	EmailFailed EmailStatus = "failed"
+)
+
+// EmailLog is an entity that records one attempt to deliver an email, kept so admins can tell
+// whether and when an email reached the provider
+type EmailLog struct {
+	ID uuid.UUID
+	// EmailID is the ID of the queued email, shared by every attempt to deliver it
+	EmailID           uuid.UUID
+	Recipients        []string
+	Template          EmailTemplate
+	Subject           string
+	Status            EmailStatus
+	ProviderMessageID string
+	Error             string
+	Attempt           int
+	CreatedAt         time.Time
+	SalonID           uuid.UUID
+}
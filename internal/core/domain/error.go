@@ -39,4 +39,72 @@ var (
 	ErrForbidden = errors.New("user is forbidden to access the resource")
 	// ErrForbiden cuando no se puede crear una cita porque la cita no se encuentra en pendiente de pago o requiere prueba de mechon
 	ErrForbidenAppointment = errors.New("you cannot create an appointment because the quote is not in pending payment or requires a payment proof")
+	// ErrInvalidSignature is an error for when a webhook signature cannot be verified
+	ErrInvalidSignature = errors.New("webhook signature is invalid")
+	// ErrInvalidPaymentEvent is an error for when a payment provider callback cannot be decoded
+	ErrInvalidPaymentEvent = errors.New("payment event payload is invalid")
+	// ErrTypeOfServiceArchived is an error for when an archived type of service is used for a new quote
+	ErrTypeOfServiceArchived = errors.New("type of service is archived")
+	// ErrServiceNotOffered is an error for when a slot's admin does not perform the quoted type of service
+	ErrServiceNotOffered = errors.New("the admin of this slot does not offer the quoted type of service")
+	// ErrUnsupportedCurrency is an error for when a currency is not supported
+	ErrUnsupportedCurrency = errors.New("currency is not supported")
+	// ErrInvalidDurationRange is an error for when the estimated minimum duration exceeds the maximum
+	ErrInvalidDurationRange = errors.New("minimum duration cannot be greater than maximum duration")
+	// ErrInvalidPromotion is an error for when a promotion has an invalid discount or validity window
+	ErrInvalidPromotion = errors.New("promotion discount or validity window is invalid")
+	// ErrInvalidWebhook is an error for when a webhook subscribes to an unknown event type
+	ErrInvalidWebhook = errors.New("webhook event types are invalid")
+	// ErrDuplicateFile is an error for when an identical file has already been uploaded
+	ErrDuplicateFile = errors.New("an identical file has already been uploaded")
+	// ErrFileTooLarge is an error for when an upload exceeds the size allowed for its route
+	ErrFileTooLarge = errors.New("uploaded file is too large")
+	// ErrRequestTooLarge is an error for when a request body exceeds the size allowed
+	ErrRequestTooLarge = errors.New("request body is too large")
+	// ErrTooManyFiles is an error for when a request carries more files than its route allows
+	ErrTooManyFiles = errors.New("too many files uploaded")
+	// ErrUnsupportedFileType is an error for when an uploaded file's content type is not allowed
+	ErrUnsupportedFileType = errors.New("uploaded file type is not supported")
+	// ErrInfectedFile is an error for when the antivirus finds a threat in an upload
+	ErrInfectedFile = errors.New("uploaded file was rejected by the antivirus scan")
+	// ErrInvalidImage is an error for when an uploaded image cannot be decoded
+	ErrInvalidImage = errors.New("uploaded image is corrupt or cannot be decoded")
+	// ErrImageTooLarge is an error for when an uploaded image is wider, taller or holds more pixels than allowed
+	ErrImageTooLarge = errors.New("uploaded image dimensions are too large")
+	// ErrEmailRejected is an error for when the email provider refuses an email for good, so retrying it is pointless
+	ErrEmailRejected = errors.New("email was rejected by the provider")
+	// ErrMessageRejected is an error for when the messaging provider refuses a message for good, e.g. for an invalid number
+	ErrMessageRejected = errors.New("message was rejected by the provider")
+	// ErrJobRejected is an error for when a job can never run, e.g. for an unknown type or a malformed payload, so retrying it is pointless
+	ErrJobRejected = errors.New("job was rejected by its handler")
+	// ErrDeviceUnregistered is an error for when a push notification is sent to a device token that is no longer valid
+	ErrDeviceUnregistered = errors.New("device token is no longer registered")
+	// ErrPhoneRequired is an error for when SMS or WhatsApp notifications are enabled without a phone number
+	ErrPhoneRequired = errors.New("a phone number is required to receive SMS or WhatsApp messages")
+	// ErrInvalidCursor is an error for when a pagination cursor is malformed
+	ErrInvalidCursor = errors.New("pagination cursor is invalid")
+	// ErrDatabaseUnavailable is an error for when the database can't be reached or its schema is broken
+	ErrDatabaseUnavailable = errors.New("database is unavailable")
+	// ErrNotReady is an error for when a service the API depends on can't be reached, so it shouldn't receive traffic
+	ErrNotReady = errors.New("service is not ready")
+	// ErrTooManyRequests is an error for when a client runs out of requests in a rate limit
+	ErrTooManyRequests = errors.New("too many requests, try again later")
+	// ErrQuotaExceeded is an error for when a user used up the daily quota of an action
+	ErrQuotaExceeded = errors.New("daily quota exceeded, try again tomorrow")
+	// ErrInvalidQuota is an error for when a quota is set for an unknown role or action, or with a negative limit
+	ErrInvalidQuota = errors.New("quota role, action or limit is invalid")
+	// ErrInvalidReport is an error for when a report is requested for an unknown kind or a date range ending before it starts
+	ErrInvalidReport = errors.New("report kind or date range is invalid")
+	// ErrInvalidRateLimit is an error for when a rate limit isn't written as "requests/period"
+	ErrInvalidRateLimit = errors.New("rate limit must be written as requests/period, e.g. 10/1m")
+	// ErrInvalidSchedule is an error for when a schedule is neither a cron expression, a macro like @daily nor "@every <duration>"
+	ErrInvalidSchedule = errors.New("schedule must be a cron expression like */5 * * * *, @daily or @every 1h")
+	// ErrStaffInactive is an error for when an inactive stylist is given new availability slots or types of service
+	ErrStaffInactive = errors.New("stylist is not active")
+	// ErrInvalidCoupon is an error for when a coupon has no code, an invalid discount, validity window or usage limits
+	ErrInvalidCoupon = errors.New("coupon code, discount, validity window or usage limits are invalid")
+	// ErrCouponUnavailable is an error for when a coupon is applied outside of its validity window or has no uses left
+	ErrCouponUnavailable = errors.New("coupon is expired or has no uses left")
+	// ErrCouponNotApplicable is an error for when a coupon does not cover the type of service, currency or state of a quote
+	ErrCouponNotApplicable = errors.New("coupon does not apply to this quote")
 )
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType is an enum for the domain events services publish
+type EventType string
+
+// EventType enum values
+const (
+	EventQuoteCreated         EventType = "quote.created"
+	EventPaymentProofUploaded EventType = "paymentProof.uploaded"
+	EventAppointmentBooked    EventType = "appointment.booked"
+	EventPaymentReviewed      EventType = "payment.reviewed"
+)
+
+// IsValid reports whether the event type is one services publish
+func (t EventType) IsValid() bool {
+	switch t {
+	case EventQuoteCreated, EventPaymentProofUploaded, EventAppointmentBooked, EventPaymentReviewed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Event is something that happened in the domain, published for whoever needs to react to it
+type Event struct {
+	ID         uuid.UUID
+	Type       EventType
+	Payload    any
+	OccurredAt time.Time
+	// SalonID is the salon the event happened in, only its webhooks and admins hear of it
+	SalonID uuid.UUID
+}
+
+// NewEvent creates an event of the type that just happened
+func NewEvent(eventType EventType, payload any) Event {
+	return Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		Payload:    payload,
+		OccurredAt: time.Now(),
+	}
+}
+
+// StoredEvent is an event as recorded in the event store, with its payload in JSON
+type StoredEvent struct {
+	ID         uuid.UUID
+	Type       EventType
+	Payload    json.RawMessage
+	OccurredAt time.Time
+	RecordedAt time.Time
+	SalonID    uuid.UUID
+}
+
+// NewStoredEvent encodes the event to be appended to the event store
+func NewStoredEvent(event Event) (*StoredEvent, error) {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StoredEvent{
+		ID:         event.ID,
+		Type:       event.Type,
+		Payload:    payload,
+		OccurredAt: event.OccurredAt,
+		SalonID:    event.SalonID,
+	}, nil
+}
+
+// QuoteCreatedEvent is the payload of EventQuoteCreated
+type QuoteCreatedEvent struct {
+	QuoteID     uuid.UUID `json:"quoteId"`
+	ClientID    uuid.UUID `json:"clientId"`
+	Description string    `json:"description"`
+}
+
+// PaymentProofUploadedEvent is the payload of EventPaymentProofUploaded
+type PaymentProofUploadedEvent struct {
+	PaymentProofID uuid.UUID `json:"paymentProofId"`
+	QuoteID        uuid.UUID `json:"quoteId"`
+}
+
+// AppointmentBookedEvent is the payload of EventAppointmentBooked
+type AppointmentBookedEvent struct {
+	AppointmentID uuid.UUID `json:"appointmentId"`
+	QuoteID       uuid.UUID `json:"quoteId"`
+	ClientID      uuid.UUID `json:"clientId"`
+	StartTime     time.Time `json:"startTime"`
+	EndTime       time.Time `json:"endTime"`
+}
+
+// PaymentReviewedEvent is the payload of EventPaymentReviewed
+type PaymentReviewedEvent struct {
+	PaymentProofID uuid.UUID `json:"paymentProofId"`
+	QuoteID        uuid.UUID `json:"quoteId"`
+}
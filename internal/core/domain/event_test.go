@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStoredEvent(t *testing.T) {
+	quoteID := uuid.New()
+	event := NewEvent(EventPaymentReviewed, PaymentReviewedEvent{QuoteID: quoteID})
+
+	stored, err := NewStoredEvent(event)
+	require.NoError(t, err)
+
+	// The stored event keeps the ID the webhooks deliver, so both can be matched
+	assert.Equal(t, event.ID, stored.ID)
+	assert.Equal(t, EventPaymentReviewed, stored.Type)
+	assert.Equal(t, event.OccurredAt, stored.OccurredAt)
+	assert.JSONEq(t, `{"paymentProofId":"00000000-0000-0000-0000-000000000000","quoteId":"`+quoteID.String()+`"}`, string(stored.Payload))
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// PoolStats are the statistics of a database connection pool
+type PoolStats struct {
+	MaxConns      int32
+	TotalConns    int32
+	AcquiredConns int32
+	IdleConns     int32
+	AcquireCount  int64
+	// AcquireDuration is the total time spent waiting for a connection since the pool was opened
+	AcquireDuration time.Duration
+	// EmptyAcquires counts the acquires that had to wait because every connection was in use
+	EmptyAcquires int64
+}
+
+// DatabaseHealth is a snapshot of the state of the database, used by load balancers and on-call debugging
+type DatabaseHealth struct {
+	// Latency is the round trip of a ping
+	Latency time.Duration
+	Pool    *PoolStats
+	// MigrationVersion is the schema version, 0 when no migration has been applied
+	MigrationVersion uint
+	// MigrationDirty tells whether the last migration failed halfway
+	MigrationDirty bool
+}
+
+// ComponentHealth is the outcome of checking one of the services the API depends on
+type ComponentHealth struct {
+	Name    string
+	Healthy bool
+	// Latency is how long the check took, up to the timeout when the service didn't answer
+	Latency time.Duration
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobType is an enum for the work a background job does, the worker runs every type with the handler registered for it
+type JobType string
+
+// JobType enum values
+const (
+	JobNotify            JobType = "notify"
+	JobGenerateThumbnail JobType = "generate-thumbnail"
+	JobBuildReport       JobType = "build-report"
+)
+
+// JobStatus is an enum for where a job is: waiting in the queue, possibly deferred after a failed attempt,
+// or in the dead-letter list
+type JobStatus string
+
+// JobStatus enum values
+const (
+	JobQueued JobStatus = "queued"
+	JobDead   JobStatus = "dead"
+)
+
+// Job is a unit of work waiting in the job queue, or in the dead-letter list once it ran out of attempts
+type Job struct {
+	ID   uuid.UUID
+	Type JobType
+	// Payload is the JSON of the payload struct of Type
+	Payload       json.RawMessage
+	Attempts      int
+	LastError     string
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+	// SalonID is the salon the job was enqueued from, its admins are the ones who see it in the queue
+	SalonID uuid.UUID
+}
+
+// NotifyJob is the payload of the jobs delivering a notification, the recipients are loaded when it runs
+// so their latest preferences apply
+type NotifyJob struct {
+	RecipientIDs []uuid.UUID     `json:"recipientIds"`
+	Template     EmailTemplate   `json:"template"`
+	Data         json.RawMessage `json:"data"`
+}
+
+// ThumbnailJob is the payload of the jobs generating the thumbnail of an uploaded image
+type ThumbnailJob struct {
+	Key string `json:"key"`
+}
+
+// ThumbnailKey returns the key the thumbnail of the file at key is stored at
+func ThumbnailKey(key string) string {
+	return "thumbnails/" + strings.TrimSuffix(key, path.Ext(key)) + ".jpg"
+}
+
+// ReportKind is an enum for the data a report lists
+type ReportKind string
+
+// ReportKind enum values
+const (
+	ReportQuotes       ReportKind = "quotes"
+	ReportAppointments ReportKind = "appointments"
+)
+
+// IsValid reports whether the report kind is one of the known kinds
+func (k ReportKind) IsValid() bool {
+	return k == ReportQuotes || k == ReportAppointments
+}
+
+// ReportJob is the payload of the jobs building a report, which is emailed as a CSV file to the admin who asked for it
+type ReportJob struct {
+	Kind        ReportKind `json:"kind"`
+	StartDate   *time.Time `json:"startDate,omitempty"`
+	EndDate     *time.Time `json:"endDate,omitempty"`
+	RequestedBy uuid.UUID  `json:"requestedBy"`
+}
@@ -0,0 +1,30 @@
+package domain
+
+// Language is an enum for the languages notifications are sent in
+type Language string
+
+// Language enum values
+const (
+	LanguageSpanish Language = "es"
+	LanguageEnglish Language = "en"
+)
+
+// DefaultLanguage is used for users without a preferred language and for admin notifications
+const DefaultLanguage = LanguageSpanish
+
+// IsValid checks if a Language is supported
+func (l Language) IsValid() bool {
+	switch l {
+	case LanguageSpanish, LanguageEnglish:
+		return true
+	}
+	return false
+}
+
+// OrDefault returns the language, or DefaultLanguage when it is empty or unsupported
+func (l Language) OrDefault() Language {
+	if l.IsValid() {
+		return l
+	}
+	return DefaultLanguage
+}
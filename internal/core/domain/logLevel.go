@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"log/slog"
+	"time"
+)
+
+// LogLevel is the level the application logs at, admins change it while diagnosing an incident
+type LogLevel struct {
+	Level slog.Level
+	// ResetAt is when the level goes back to the one set on startup, nil when the change lasts until a restart
+	ResetAt *time.Time
+}
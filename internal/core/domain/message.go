@@ -0,0 +1,19 @@
+package domain
+
+// Message is a short text message sent to a phone
+type Message struct {
+	// To is the recipient's number in E.164 format, or the device token for push notifications
+	To   string
+	Body string
+	// Template is the pre-approved template channels such as WhatsApp send instead of the body
+	Template *MessageTemplate
+	// Data is handed to the app by push notifications so it can open what the message is about
+	Data map[string]string
+}
+
+// MessageTemplate is a message template registered with the messaging provider and the values of its placeholders
+type MessageTemplate struct {
+	Name       string
+	Language   Language
+	Parameters []string
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification is an entity that represents an in-app notification shown to a user,
+// its type names the event it is about and matches the email sent for it
+type Notification struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Type      EmailTemplate
+	Payload   json.RawMessage
+	Read      bool
+	CreatedAt time.Time
+	SalonID   uuid.UUID
+}
+
+// NotificationChannel is an enum for the channels a user can be notified through
+type NotificationChannel string
+
+// NotificationChannel enum values
+const (
+	ChannelInApp    NotificationChannel = "inApp"
+	ChannelEmail    NotificationChannel = "email"
+	ChannelSMS      NotificationChannel = "sms"
+	ChannelWhatsApp NotificationChannel = "whatsapp"
+	ChannelPush     NotificationChannel = "push"
+)
+
+// NotificationPreferences holds the channels a user chose to be notified through,
+// new users receive emails and in-app notifications only
+type NotificationPreferences struct {
+	Email    bool
+	SMS      bool
+	WhatsApp bool
+}
+
+// DefaultNotificationPreferences returns the preferences of a user who has not chosen any
+func DefaultNotificationPreferences() NotificationPreferences {
+	return NotificationPreferences{Email: true}
+}
+
+// Allows reports whether the user accepts notifications through channel, in-app notifications cannot be turned off
+// and push notifications are opted into by registering a device
+func (p NotificationPreferences) Allows(channel NotificationChannel) bool {
+	switch channel {
+	case ChannelInApp, ChannelPush:
+		return true
+	case ChannelEmail:
+		return p.Email
+	case ChannelSMS:
+		return p.SMS
+	case ChannelWhatsApp:
+		return p.WhatsApp
+	default:
+		return false
+	}
+}
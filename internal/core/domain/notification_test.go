@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationPreferencesAllows(t *testing.T) {
+	tests := []struct {
+		name        string
+		preferences NotificationPreferences
+		channel     NotificationChannel
+		want        bool
+	}{
+		{"defaults send emails", DefaultNotificationPreferences(), ChannelEmail, true},
+		{"defaults do not text", DefaultNotificationPreferences(), ChannelSMS, false},
+		{"defaults do not use whatsapp", DefaultNotificationPreferences(), ChannelWhatsApp, false},
+		{"email opt-out", NotificationPreferences{SMS: true}, ChannelEmail, false},
+		{"sms opt-in", NotificationPreferences{SMS: true}, ChannelSMS, true},
+		{"whatsapp opt-in", NotificationPreferences{WhatsApp: true}, ChannelWhatsApp, true},
+		{"in-app cannot be turned off", NotificationPreferences{}, ChannelInApp, true},
+		{"push follows registered devices", NotificationPreferences{}, ChannelPush, true},
+		{"unknown channel", DefaultNotificationPreferences(), "fax", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.preferences.Allows(tt.channel))
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxKind is an enum for what an outbox message hands over once the change it belongs to is committed
+type OutboxKind string
+
+// OutboxKind enum values
+const (
+	// OutboxNotification carries a NotifyJob, queued for the workers to deliver
+	OutboxNotification OutboxKind = "notification"
+	// OutboxEvent carries an OutboxEventPayload, published to the event subscribers and the webhooks
+	OutboxEvent OutboxKind = "event"
+)
+
+// OutboxMessage is a notification or event written in the same transaction as the change it is about,
+// so it is relayed exactly when the change is committed, and retried until it is
+type OutboxMessage struct {
+	ID        uuid.UUID
+	Kind      OutboxKind
+	Payload   json.RawMessage
+	Attempts  int
+	LastError string
+	// AvailableAt is when the message is due to be relayed, pushed back while a relay holds it or after a failure
+	AvailableAt time.Time
+	CreatedAt   time.Time
+	SalonID     uuid.UUID
+}
+
+// OutboxEventPayload is the payload of an OutboxEvent, the event as it was published
+type OutboxEventPayload struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       EventType       `json:"type"`
+	Payload    json.RawMessage `json:"payload"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	SalonID    uuid.UUID       `json:"salonId"`
+}
+
+// NewOutboxNotification creates the outbox message notifying recipients of the event template is about
+func NewOutboxNotification(recipients []User, template EmailTemplate, data any) (*OutboxMessage, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(recipients))
+	for i, recipient := range recipients {
+		ids[i] = recipient.ID
+	}
+
+	return newOutboxMessage(OutboxNotification, NotifyJob{
+		RecipientIDs: ids,
+		Template:     template,
+		Data:         payload,
+	})
+}
+
+// NewOutboxEvent creates the outbox message publishing event
+func NewOutboxEvent(event Event) (*OutboxMessage, error) {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := newOutboxMessage(OutboxEvent, OutboxEventPayload{
+		ID:         event.ID,
+		Type:       event.Type,
+		Payload:    payload,
+		OccurredAt: event.OccurredAt,
+		SalonID:    event.SalonID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	message.SalonID = event.SalonID
+	return message, nil
+}
+
+// newOutboxMessage creates an outbox message of kind due right away
+func newOutboxMessage(kind OutboxKind, payload any) (*OutboxMessage, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &OutboxMessage{
+		ID:          uuid.New(),
+		Kind:        kind,
+		Payload:     data,
+		AvailableAt: now,
+		CreatedAt:   now,
+	}, nil
+}
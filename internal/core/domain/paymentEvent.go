@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PaymentProvider is an enum for the provider that notified a payment
+type PaymentProvider string
+
+// PaymentProvider enum values
+const (
+	PaymentProviderStripe      PaymentProvider = "stripe"
+	PaymentProviderMercadoPago PaymentProvider = "mercadopago"
+	PaymentProviderBank        PaymentProvider = "bank"
+)
+
+// IsValid checks if a PaymentProvider is supported
+func (p PaymentProvider) IsValid() bool {
+	switch p {
+	case PaymentProviderStripe, PaymentProviderMercadoPago, PaymentProviderBank:
+		return true
+	}
+	return false
+}
+
+// PaymentEventStatus is an enum for the outcome reported by the provider
+type PaymentEventStatus string
+
+// PaymentEventStatus enum values
+const (
+	PaymentSucceeded PaymentEventStatus = "succeeded"
+	PaymentFailed    PaymentEventStatus = "failed"
+	PaymentPending   PaymentEventStatus = "pending"
+)
+
+// PaymentEvent is an entity that represents a payment callback received from a provider
+type PaymentEvent struct {
+	ID         uuid.UUID
+	Provider   PaymentProvider
+	ExternalID string
+	QuoteID    uuid.UUID
+	Amount     float64
+	Status     PaymentEventStatus
+	ReceivedAt time.Time
+	SalonID    uuid.UUID
+}
@@ -4,8 +4,12 @@ import "github.com/google/uuid"
 
 // PaymentProof represents the proof of payment associated with a quote
 type PaymentProof struct {
-	ID         uuid.UUID
-	QuoteID    uuid.UUID
-	URL        string
-	IsReviewed bool
+	ID          uuid.UUID
+	QuoteID     uuid.UUID
+	URL         string
+	IsReviewed  bool
+	FileName    string
+	Size        int64
+	ContentType string
+	Checksum    string
 }
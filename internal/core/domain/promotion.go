@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DiscountType is an enum for how a promotion reduces the price of a quote
+type DiscountType string
+
+// DiscountType enum values
+const (
+	DiscountPercentage DiscountType = "percentage"
+	DiscountFixed      DiscountType = "fixed"
+)
+
+// IsValid checks if a DiscountType is supported
+func (d DiscountType) IsValid() bool {
+	switch d {
+	case DiscountPercentage, DiscountFixed:
+		return true
+	}
+	return false
+}
+
+// Promotion is an entity that represents a discount offered on types of service during a validity window
+type Promotion struct {
+	ID           uuid.UUID
+	Name         string
+	DiscountType DiscountType
+	// Value is a percentage between 0 and 100 or a fixed amount in Currency
+	Value    float64
+	Currency Currency
+	StartsAt time.Time
+	EndsAt   time.Time
+	// TypeOfServiceIDs restricts the promotion to these types of service, it applies to every service when empty
+	TypeOfServiceIDs []uuid.UUID
+	SalonID          uuid.UUID
+}
+
+// IsValid checks the discount value and the validity window of a promotion
+func (p *Promotion) IsValid() bool {
+	return validDiscount(p.DiscountType, p.Value, p.Currency) && p.EndsAt.After(p.StartsAt)
+}
+
+// validDiscount checks a percentage between 0 and 100, or a fixed amount in a supported currency
+func validDiscount(discountType DiscountType, value float64, currency Currency) bool {
+	if !discountType.IsValid() || value <= 0 {
+		return false
+	}
+
+	if discountType == DiscountPercentage {
+		return value <= 100
+	}
+
+	return currency.IsValid()
+}
+
+// IsActive reports whether the promotion can be applied at the given time
+func (p *Promotion) IsActive(at time.Time) bool {
+	return !at.Before(p.StartsAt) && at.Before(p.EndsAt)
+}
+
+// AppliesTo reports whether the promotion covers a type of service
+func (p *Promotion) AppliesTo(typeOfServiceID uuid.UUID) bool {
+	if len(p.TypeOfServiceIDs) == 0 {
+		return true
+	}
+
+	for _, id := range p.TypeOfServiceIDs {
+		if id == typeOfServiceID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Discount returns the amount the promotion takes off a price, never more than the price itself.
+// Fixed discounts only apply to prices in the same currency
+func (p *Promotion) Discount(price float64, currency Currency) float64 {
+	return discountOf(p.DiscountType, p.Value, p.Currency, price, currency)
+}
+
+// discountOf returns the amount a discount of value takes off a price in currency, rounded to the
+// decimals of the currency and never more than the price itself
+func discountOf(discountType DiscountType, value float64, discountCurrency Currency, price float64, currency Currency) float64 {
+	var discount float64
+
+	switch discountType {
+	case DiscountPercentage:
+		discount = price * value / 100
+	case DiscountFixed:
+		if discountCurrency != currency {
+			return 0
+		}
+		discount = value
+	}
+
+	discount = math.Min(discount, price)
+	scale := math.Pow(10, float64(currency.Decimals()))
+	return math.Round(discount*scale) / scale
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromotionDiscount(t *testing.T) {
+	tests := []struct {
+		name      string
+		promotion Promotion
+		price     float64
+		currency  Currency
+		want      float64
+	}{
+		{"percentage", Promotion{DiscountType: DiscountPercentage, Value: 15}, 1200, CurrencyMXN, 180},
+		{"percentage rounds to the currency", Promotion{DiscountType: DiscountPercentage, Value: 33}, 99.99, CurrencyUSD, 33},
+		{"fixed", Promotion{DiscountType: DiscountFixed, Value: 200, Currency: CurrencyMXN}, 1200, CurrencyMXN, 200},
+		{"fixed never exceeds the price", Promotion{DiscountType: DiscountFixed, Value: 500, Currency: CurrencyMXN}, 350, CurrencyMXN, 350},
+		{"fixed in another currency", Promotion{DiscountType: DiscountFixed, Value: 20, Currency: CurrencyUSD}, 1200, CurrencyMXN, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.promotion.Discount(tt.price, tt.currency))
+		})
+	}
+}
+
+func TestPromotionWindowAndServices(t *testing.T) {
+	start := time.Date(2025, 11, 14, 0, 0, 0, 0, time.UTC)
+	serviceID := uuid.New()
+	promotion := Promotion{
+		DiscountType:     DiscountPercentage,
+		Value:            10,
+		StartsAt:         start,
+		EndsAt:           start.Add(96 * time.Hour),
+		TypeOfServiceIDs: []uuid.UUID{serviceID},
+	}
+
+	assert.True(t, promotion.IsValid())
+	assert.True(t, promotion.IsActive(start))
+	assert.False(t, promotion.IsActive(promotion.EndsAt))
+	assert.True(t, promotion.AppliesTo(serviceID))
+	assert.False(t, promotion.AppliesTo(uuid.New()))
+
+	promotion.EndsAt = start
+	assert.False(t, promotion.IsValid())
+}
@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+// QuotaAction is an enum for the actions counted against the daily quotas of the users
+type QuotaAction string
+
+// QuotaAction enum values
+const (
+	QuotaQuoteCreation QuotaAction = "quote_creation"
+	QuotaFileUpload    QuotaAction = "file_upload"
+)
+
+// QuotaActions are the actions a quota can be set for
+var QuotaActions = []QuotaAction{QuotaQuoteCreation, QuotaFileUpload}
+
+// IsValid checks if a QuotaAction is supported
+func (a QuotaAction) IsValid() bool {
+	switch a {
+	case QuotaQuoteCreation, QuotaFileUpload:
+		return true
+	}
+	return false
+}
+
+// Quota is how many times a day every user of a role may perform an action, a limit of 0 is no limit
+type Quota struct {
+	Role   UserRole
+	Action QuotaAction
+	Limit  int
+}
+
+// QuotaResult is the outcome of counting a request against a quota
+type QuotaResult struct {
+	Allowed bool
+	Limit   int
+	// Remaining is how many requests the user has left today
+	Remaining int
+	// ResetAt is when the count starts over, at midnight UTC
+	ResetAt time.Time
+}
+
+// QuotaResetAt returns when the daily quotas counted at now start over
+func QuotaResetAt(now time.Time) time.Time {
+	year, month, day := now.UTC().Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC)
+}
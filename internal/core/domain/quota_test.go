@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaResetAt(t *testing.T) {
+	// The day is counted in UTC, 20:30 in Mexico City is already the next day
+	mexico := time.FixedZone("CST", -6*60*60)
+	now := time.Date(2025, time.March, 14, 20, 30, 0, 0, mexico)
+
+	assert.Equal(t, time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC), QuotaResetAt(now))
+	assert.Equal(t, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), QuotaResetAt(time.Date(2024, time.December, 31, 23, 59, 59, 0, time.UTC)))
+}
@@ -21,12 +21,14 @@ const (
 	QuoteRejected       QuoteState = "rejected"
 	QuoteRequiresProof  QuoteState = "requires_proof"
 	QuotePendingPayment QuoteState = "pending_payment"
+	// QuoteExpired is set by the scheduler on quotes left waiting on the client or an admin for too long
+	QuoteExpired QuoteState = "expired"
 )
 
 // IsValidState checks if a QuoteState is valid
 func (q QuoteState) IsValidState() bool {
 	switch q {
-	case QuotePending, QuoteApproved, QuoteRejected, QuoteRequiresProof, QuotePendingPayment:
+	case QuotePending, QuoteApproved, QuoteRejected, QuoteRequiresProof, QuotePendingPayment, QuoteExpired:
 		return true
 	}
 	return false
@@ -50,4 +52,26 @@ type Quote struct {
 	Description     string
 	State           QuoteState
 	Price           float64
+	Currency        Currency
+	// PromotionID is the promotion applied when the quote was priced, Discount is the amount it took off Price
+	PromotionID *uuid.UUID
+	Discount    float64
+	// CouponID is the coupon the client applied, CouponDiscount is the amount it took off the price left by the promotion
+	CouponID       *uuid.UUID
+	CouponDiscount float64
+	// DeletedAt is set once the quote is soft deleted
+	DeletedAt *time.Time
+	// Version is bumped on every update to detect concurrent edits
+	Version int
+	SalonID uuid.UUID
+}
+
+// TotalDiscount returns the amount the promotion and the coupon took off the price
+func (q *Quote) TotalDiscount() float64 {
+	return q.Discount + q.CouponDiscount
+}
+
+// Total returns the price of the quote after its discounts
+func (q *Quote) Total() float64 {
+	return q.Price - q.TotalDiscount()
 }
@@ -4,12 +4,17 @@ import "github.com/google/uuid"
 
 // QuoteImage represents the image associated with a quote
 type QuoteImage struct {
-	ID      uuid.UUID
-	QuoteID uuid.UUID
-	URL     string
+	ID          uuid.UUID
+	QuoteID     uuid.UUID
+	URL         string
+	FileName    string
+	Size        int64
+	ContentType string
+	Checksum    string
 }
 
 // QuoteImageFilters represents the filters to use when fetching quote images
 type QuoteImageFilters struct {
-	QuoteID *uuid.UUID
+	QuoteID  *uuid.UUID
+	Checksum *string
 }
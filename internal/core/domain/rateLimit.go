@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit is a token bucket holding Requests tokens, which refills completely over Period
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// ParseRateLimit parses a rate limit written as "requests/period", e.g. "10/1m", an empty string is no limit
+func ParseRateLimit(s string) (*RateLimit, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	requests, period, found := strings.Cut(s, "/")
+	if !found {
+		return nil, ErrInvalidRateLimit
+	}
+
+	limit := &RateLimit{}
+	var err error
+	limit.Requests, err = strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil || limit.Requests <= 0 {
+		return nil, ErrInvalidRateLimit
+	}
+	limit.Period, err = time.ParseDuration(strings.TrimSpace(period))
+	if err != nil || limit.Period <= 0 {
+		return nil, ErrInvalidRateLimit
+	}
+
+	return limit, nil
+}
+
+// RateLimitResult is the outcome of taking a token from a bucket
+type RateLimitResult struct {
+	Allowed bool
+	// Remaining is how many tokens are left in the bucket
+	Remaining int
+	// RetryAfter is how long until the next token when the request wasn't allowed
+	RetryAfter time.Duration
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	limit, err := ParseRateLimit(" 10 / 1m ")
+	require.NoError(t, err)
+	assert.Equal(t, &RateLimit{Requests: 10, Period: time.Minute}, limit)
+
+	// An empty limit leaves the routes unlimited
+	limit, err = ParseRateLimit("")
+	require.NoError(t, err)
+	assert.Nil(t, limit)
+}
+
+func TestParseRateLimitInvalid(t *testing.T) {
+	for _, s := range []string{"10", "10/", "/1m", "ten/1m", "0/1m", "10/0s", "10/-1m", "10/minute"} {
+		_, err := ParseRateLimit(s)
+		assert.ErrorIs(t, err, ErrInvalidRateLimit, s)
+	}
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StaffUtilization compares the hours an admin offered in availability slots over a period with the hours
+// clients booked and the ones that ended in a completed appointment
+type StaffUtilization struct {
+	AdminID   uuid.UUID
+	AdminName string
+	// Slots counts the slots offered, BookedSlots those with a booked or completed appointment and
+	// CompletedSlots those whose appointment was completed
+	Slots          int
+	BookedSlots    int
+	CompletedSlots int
+	Offered        time.Duration
+	Booked         time.Duration
+	Completed      time.Duration
+}
+
+// BookedRate is the share of the offered time that was booked, between 0 and 1
+func (u *StaffUtilization) BookedRate() float64 {
+	if u.Offered <= 0 {
+		return 0
+	}
+	return float64(u.Booked) / float64(u.Offered)
+}
+
+// CompletedRate is the share of the offered time spent in completed appointments, between 0 and 1
+func (u *StaffUtilization) CompletedRate() float64 {
+	if u.Offered <= 0 {
+		return 0
+	}
+	return float64(u.Completed) / float64(u.Offered)
+}
+
+// ClientRetention tells how many clients come back: the new and returning clients of every month of a period,
+// the usual time between two visits of a client and the clients who spent the most. A visit is a completed
+// appointment, dated by the start of its slot
+type ClientRetention struct {
+	Months []ClientRetentionMonth
+	// AverageVisitGap is the mean time between a visit in the period and the previous visit of the same
+	// client, zero when no client came back
+	AverageVisitGap time.Duration
+	TopClients      []ClientSpend
+}
+
+// ClientRetentionMonth counts the clients who visited in a month, new ones on their first visit ever
+type ClientRetentionMonth struct {
+	// Month is the first day of the month, in UTC
+	Month            time.Time
+	NewClients       int
+	ReturningClients int
+	Visits           int
+}
+
+// ClientSpend is what a client spent on the quotes of their visits in a currency
+type ClientSpend struct {
+	ClientID   uuid.UUID
+	ClientName string
+	Visits     int
+	Spent      float64
+	Currency   Currency
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaffUtilizationRates(t *testing.T) {
+	u := StaffUtilization{Offered: 40 * time.Hour, Booked: 30 * time.Hour, Completed: 10 * time.Hour}
+	assert.Equal(t, 0.75, u.BookedRate())
+	assert.Equal(t, 0.25, u.CompletedRate())
+
+	// An admin without offered time is at zero rather than dividing by it
+	empty := StaffUtilization{}
+	assert.Equal(t, 0.0, empty.BookedRate())
+	assert.Equal(t, 0.0, empty.CompletedRate())
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultSalonID is the salon the rows created before there were salons belong to, and the one
+// users register in when they don't pick one
+var DefaultSalonID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// Salon is an entity that represents a studio hosted by the platform, its users, types of service,
+// availability slots, quotes, appointments and the rest of its data are only seen from within it
+type Salon struct {
+	ID   uuid.UUID
+	Name string
+	// Slug identifies the salon in URLs, unique among the salons
+	Slug      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SalonScoped is implemented by the entities that belong to a salon
+type SalonScoped interface {
+	// Salon returns the ID of the salon of the entity
+	Salon() uuid.UUID
+}
+
+// salonOrDefault returns the salon, or the default one when it is not set
+func salonOrDefault(id uuid.UUID) uuid.UUID {
+	if id == uuid.Nil {
+		return DefaultSalonID
+	}
+	return id
+}
+
+// Salon returns the salon of the user, the values cached before there were salons belong to the default one
+func (u *User) Salon() uuid.UUID { return salonOrDefault(u.SalonID) }
+
+// Salon returns the salon of the type of service
+func (s *TypeOfService) Salon() uuid.UUID { return salonOrDefault(s.SalonID) }
+
+// Salon returns the salon of the availability slot
+func (s *AvailabilitySlot) Salon() uuid.UUID { return salonOrDefault(s.SalonID) }
+
+// Salon returns the salon of the quote
+func (q *Quote) Salon() uuid.UUID { return salonOrDefault(q.SalonID) }
+
+// Salon returns the salon of the appointment
+func (a *Appointment) Salon() uuid.UUID { return salonOrDefault(a.SalonID) }
+
+// Salon returns the salon of the staff member
+func (s *Staff) Salon() uuid.UUID { return salonOrDefault(s.SalonID) }
+
+// Salon returns the salon of the coupon
+func (c *Coupon) Salon() uuid.UUID { return salonOrDefault(c.SalonID) }
+
+// Salon returns the salon of the webhook
+func (w *Webhook) Salon() uuid.UUID { return salonOrDefault(w.SalonID) }
+
+// Salon returns the salon of the audit log
+func (l *AuditLog) Salon() uuid.UUID { return salonOrDefault(l.SalonID) }
+
+// Salon returns the salon of the audit entry
+func (e *AuditEntry) Salon() uuid.UUID { return salonOrDefault(e.SalonID) }
+
+// Salon returns the salon of the email log
+func (l *EmailLog) Salon() uuid.UUID { return salonOrDefault(l.SalonID) }
+
+// Salon returns the salon of the promotion
+func (p *Promotion) Salon() uuid.UUID { return salonOrDefault(p.SalonID) }
+
+// Salon returns the salon of the service category
+func (c *ServiceCategory) Salon() uuid.UUID { return salonOrDefault(c.SalonID) }
+
+// Salon returns the salon the event happened in, the events published before there were salons belong to the default one
+func (e *Event) Salon() uuid.UUID { return salonOrDefault(e.SalonID) }
+
+// Salon returns the salon of the stored event
+func (e *StoredEvent) Salon() uuid.UUID { return salonOrDefault(e.SalonID) }
+
+// Salon returns the salon of the notification
+func (n *Notification) Salon() uuid.UUID { return salonOrDefault(n.SalonID) }
+
+// Salon returns the salon of the device
+func (d *Device) Salon() uuid.UUID { return salonOrDefault(d.SalonID) }
+
+// Salon returns the salon of the payment event
+func (e *PaymentEvent) Salon() uuid.UUID { return salonOrDefault(e.SalonID) }
+
+// Salon returns the salon of the type of service image
+func (i *TypeOfServiceImage) Salon() uuid.UUID { return salonOrDefault(i.SalonID) }
+
+// Salon returns the salon of the service offering
+func (o *ServiceOffering) Salon() uuid.UUID { return salonOrDefault(o.SalonID) }
+
+// Salon returns the salon the email was sent from, the emails queued before there were salons belong to the default one
+func (e *QueuedEmail) Salon() uuid.UUID { return salonOrDefault(e.SalonID) }
+
+// Salon returns the salon the job was enqueued from, the jobs queued before there were salons belong to the default one
+func (j *Job) Salon() uuid.UUID { return salonOrDefault(j.SalonID) }
@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// scheduleMacros are the shorthands accepted for the most common schedules
+var scheduleMacros = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+// Schedule is when a periodic task runs, either every fixed interval or on the minutes matching a cron
+// expression. Times are matched in UTC
+type Schedule struct {
+	// Every is the interval of "@every" schedules, zero for cron expressions
+	Every time.Duration
+
+	minutes, hours, days, months, weekdays uint64
+	// anyDay and anyWeekday are set when the day of month or the day of week field is "*", a day
+	// matches when both fields do or, as in cron, when either does if neither is "*"
+	anyDay, anyWeekday bool
+}
+
+// ParseSchedule parses a schedule written as a five field cron expression ("*/15 * * * *"), one of the
+// macros @hourly, @daily, @weekly and @monthly, or "@every <duration>". An empty string is no schedule
+func ParseSchedule(s string) (*Schedule, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	if every, found := strings.CutPrefix(s, "@every "); found {
+		d, err := time.ParseDuration(strings.TrimSpace(every))
+		if err != nil || d < time.Second {
+			return nil, ErrInvalidSchedule
+		}
+		return &Schedule{Every: d}, nil
+	}
+	if macro, ok := scheduleMacros[s]; ok {
+		s = macro
+	}
+
+	fields := strings.Fields(s)
+	if len(fields) != 5 {
+		return nil, ErrInvalidSchedule
+	}
+
+	schedule := &Schedule{
+		anyDay:     fields[2] == "*",
+		anyWeekday: fields[4] == "*",
+	}
+	var err error
+	if schedule.minutes, err = parseScheduleField(fields[0], 0, 59); err != nil {
+		return nil, err
+	}
+	if schedule.hours, err = parseScheduleField(fields[1], 0, 23); err != nil {
+		return nil, err
+	}
+	if schedule.days, err = parseScheduleField(fields[2], 1, 31); err != nil {
+		return nil, err
+	}
+	if schedule.months, err = parseScheduleField(fields[3], 1, 12); err != nil {
+		return nil, err
+	}
+	// Sunday is both 0 and 7
+	if schedule.weekdays, err = parseScheduleField(fields[4], 0, 7); err != nil {
+		return nil, err
+	}
+	if schedule.weekdays&(1<<7) != 0 {
+		schedule.weekdays |= 1
+	}
+
+	return schedule, nil
+}
+
+// parseScheduleField parses a comma separated list of "*", values and ranges, each with an optional
+// "/step", into a bit set of the values between min and max it matches
+func parseScheduleField(field string, min, max int) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, ErrInvalidSchedule
+			}
+		}
+
+		var low, high int
+		switch {
+		case rangePart == "*":
+			low, high = min, max
+		case strings.Contains(rangePart, "-"):
+			from, to, _ := strings.Cut(rangePart, "-")
+			var err1, err2 error
+			low, err1 = strconv.Atoi(from)
+			high, err2 = strconv.Atoi(to)
+			if err1 != nil || err2 != nil {
+				return 0, ErrInvalidSchedule
+			}
+		default:
+			value, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, ErrInvalidSchedule
+			}
+			low, high = value, value
+			// "5/15" runs from 5 to the end of the range
+			if hasStep {
+				high = max
+			}
+		}
+		if low < min || high > max || low > high {
+			return 0, ErrInvalidSchedule
+		}
+
+		for value := low; value <= high; value += step {
+			set |= 1 << value
+		}
+	}
+
+	return set, nil
+}
+
+// Next returns the first time the schedule runs strictly after t. Interval schedules run on the multiples
+// of their interval since the Unix epoch, so every server computes the same times
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.UTC()
+	if s.Every > 0 {
+		return t.Truncate(s.Every).Add(s.Every)
+	}
+
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	// Five years cover every valid expression, including the 29th of February
+	limit := next.AddDate(5, 0, 0)
+	for next.Before(limit) {
+		switch {
+		case s.months&(1<<uint(next.Month())) == 0:
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.matchesDay(next):
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, time.UTC)
+		case s.hours&(1<<uint(next.Hour())) == 0:
+			next = next.Truncate(time.Hour).Add(time.Hour)
+		case s.minutes&(1<<uint(next.Minute())) == 0:
+			next = next.Add(time.Minute)
+		default:
+			return next
+		}
+	}
+
+	return time.Time{}
+}
+
+// matchesDay reports whether the day of t matches the day of month and day of week fields
+func (s *Schedule) matchesDay(t time.Time) bool {
+	day := s.days&(1<<uint(t.Day())) != 0
+	weekday := s.weekdays&(1<<uint(t.Weekday())) != 0
+
+	if s.anyDay || s.anyWeekday {
+		return day && weekday
+	}
+	return day || weekday
+}
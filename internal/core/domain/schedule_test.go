@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleNext(t *testing.T) {
+	// Friday the 14th of March 2025
+	now := time.Date(2025, 3, 14, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		schedule string
+		want     time.Time
+	}{
+		{"*/5 * * * *", time.Date(2025, 3, 14, 10, 10, 0, 0, time.UTC)},
+		{"0 * * * *", time.Date(2025, 3, 14, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"30 3 * * *", time.Date(2025, 3, 15, 3, 30, 0, 0, time.UTC)},
+		{"0 9-17/4 * * 1-5", time.Date(2025, 3, 14, 13, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Either the day of month or the day of week matches when both are given
+		{"0 0 1 * 6", time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"@every 1h", time.Date(2025, 3, 14, 11, 0, 0, 0, time.UTC)},
+		{"@every 15m", time.Date(2025, 3, 14, 10, 15, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		schedule, err := ParseSchedule(tt.schedule)
+		require.NoError(t, err, tt.schedule)
+		assert.Equal(t, tt.want, schedule.Next(now), tt.schedule)
+	}
+}
+
+func TestScheduleNextIsStrictlyAfter(t *testing.T) {
+	schedule, err := ParseSchedule("0 * * * *")
+	require.NoError(t, err)
+
+	at := time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, at.Add(time.Hour), schedule.Next(at))
+}
+
+func TestScheduleNever(t *testing.T) {
+	schedule, err := ParseSchedule("0 0 31 2 *")
+	require.NoError(t, err)
+	assert.True(t, schedule.Next(time.Now()).IsZero())
+}
+
+func TestParseSchedule(t *testing.T) {
+	// An empty schedule disables the task
+	schedule, err := ParseSchedule("")
+	require.NoError(t, err)
+	assert.Nil(t, schedule)
+
+	for _, s := range []string{"* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@yearly", "@every", "@every 10ms", "@every soon"} {
+		_, err := ParseSchedule(s)
+		assert.ErrorIs(t, err, ErrInvalidSchedule, s)
+	}
+}
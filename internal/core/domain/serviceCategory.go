@@ -0,0 +1,10 @@
+package domain
+
+import "github.com/google/uuid"
+
+// ServiceCategory is an entity that groups types of service, e.g. "Color", "Cut" or "Treatment"
+type ServiceCategory struct {
+	ID      uuid.UUID
+	Name    string
+	SalonID uuid.UUID
+}
@@ -0,0 +1,10 @@
+package domain
+
+import "github.com/google/uuid"
+
+// ServiceOffering links an admin (stylist) to a type of service they perform
+type ServiceOffering struct {
+	AdminID         uuid.UUID
+	TypeOfServiceID uuid.UUID
+	SalonID         uuid.UUID
+}
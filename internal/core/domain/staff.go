@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Staff is an entity that represents the profile of a stylist in the staff directory. The stylist
+// attends appointments with the admin account UserID, the availability slots and the service
+// offerings of that admin are the stylist's
+type Staff struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	SalonID     uuid.UUID
+	DisplayName string
+	Bio         string
+	// Specialties are free-form labels shown to clients, e.g. "balayage" or "cortes bob"
+	Specialties []string
+	PhotoURL    string
+	// Active is false for the stylists who stopped taking appointments, they keep their profile
+	Active bool
+	// TypeOfServiceIDs are the types of service the stylist offers, read from the service offerings
+	TypeOfServiceIDs []uuid.UUID
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
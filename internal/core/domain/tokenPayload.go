@@ -9,4 +9,8 @@ type TokenPayload struct {
 	ID     uuid.UUID
 	UserID uuid.UUID
   Role UserRole
+	// Language is the preferred language of the user when the token was issued
+	Language Language
+	// SalonID is the salon of the user, the requests made with the token only reach its rows
+	SalonID uuid.UUID
 }
@@ -1,10 +1,29 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // TypeOfService is an entity that represents a type of service
 type TypeOfService struct {
-	ID    uuid.UUID
-	Name  string
-	Price float64
+	ID                      uuid.UUID
+	Name                    string
+	Description             string
+	PreparationInstructions string
+	Price                   float64
+	Currency                Currency
+	DurationMinutes         int
+	// DurationMinMinutes and DurationMaxMinutes estimate how long the service can take, both are optional
+	DurationMinMinutes *int
+	DurationMaxMinutes *int
+	CategoryID         *uuid.UUID
+	Archived           bool
+	Images             []TypeOfServiceImage
+	// OfferedBy holds the IDs of the admins that perform this type of service
+	OfferedBy []uuid.UUID
+	// DeletedAt is set once the type of service is soft deleted
+	DeletedAt *time.Time
+	SalonID   uuid.UUID
 }
@@ -0,0 +1,11 @@
+package domain
+
+import "github.com/google/uuid"
+
+// TypeOfServiceImage represents a showcase image of a type of service
+type TypeOfServiceImage struct {
+	ID              uuid.UUID
+	TypeOfServiceID uuid.UUID
+	URL             string
+	SalonID         uuid.UUID
+}
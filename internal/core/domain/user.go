@@ -28,4 +28,9 @@ type User struct {
 	Role      UserRole
 	Email     string
 	Password  string
+	PreferredLanguage Language
+	// Phone is the number in E.164 format text messages are sent to
+	Phone string
+	NotificationPreferences NotificationPreferences
+	SalonID                 uuid.UUID
 }
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook is an entity that represents an external endpoint subscribed to domain events,
+// the events are posted to its URL signed with its secret
+type Webhook struct {
+	ID         uuid.UUID
+	URL        string
+	Secret     string
+	EventTypes []EventType
+	Active     bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	SalonID    uuid.UUID
+}
+
+// IsValid reports whether the webhook subscribes to at least one event and only to known ones
+func (w *Webhook) IsValid() bool {
+	if len(w.EventTypes) == 0 {
+		return false
+	}
+	for _, t := range w.EventTypes {
+		if !t.IsValid() {
+			return false
+		}
+	}
+	return true
+}
+
+// Subscribes reports whether the webhook is active and receives events of eventType
+func (w *Webhook) Subscribes(eventType EventType) bool {
+	return w.Active && slices.Contains(w.EventTypes, eventType)
+}
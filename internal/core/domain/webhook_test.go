@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookIsValid(t *testing.T) {
+	tests := []struct {
+		name       string
+		eventTypes []EventType
+		want       bool
+	}{
+		{"known events", []EventType{EventQuoteCreated, EventAppointmentBooked, EventPaymentReviewed}, true},
+		{"no events", nil, false},
+		{"unknown event", []EventType{EventQuoteCreated, "quote.deleted"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook := &Webhook{URL: "https://example.com/hooks", EventTypes: tt.eventTypes}
+			assert.Equal(t, tt.want, webhook.IsValid())
+		})
+	}
+}
+
+func TestWebhookSubscribes(t *testing.T) {
+	webhook := &Webhook{EventTypes: []EventType{EventAppointmentBooked}, Active: true}
+	assert.True(t, webhook.Subscribes(EventAppointmentBooked))
+	assert.False(t, webhook.Subscribes(EventQuoteCreated))
+
+	webhook.Active = false
+	assert.False(t, webhook.Subscribes(EventAppointmentBooked))
+}
@@ -12,27 +12,45 @@ import (
 type AppointmentFilter struct {
 	CustomerID  *uuid.UUID
 	QuoteID     *uuid.UUID
+	SlotID      *uuid.UUID
 	StartDate   *time.Time
 	EndDate   	*time.Time
 	ByState 		*domain.AppointmentStatus
 	Skip    		uint64
 	Limit   		uint64
+	// After continúa el listado después de un cursor en lugar de saltar páginas
+	After   		*domain.Cursor
+	// IncludeDeleted también lista las citas eliminadas lógicamente, para admins
+	IncludeDeleted	bool
 }
 
 // AppointmentRepository es la interfaz para interactuar con los datos de Appointment
 type AppointmentRepository interface {
 	CreateAppointment(ctx context.Context, slot *domain.Appointment) (*domain.Appointment, error)
 	GetAppointmentByID(ctx context.Context, id uuid.UUID) (*domain.Appointment, error)
-	ListAppointments(ctx context.Context, filter AppointmentFilter) ([]domain.Appointment, error)
+	ListAppointments(ctx context.Context, filter AppointmentFilter) ([]domain.Appointment, *domain.Cursor, error)
+	CountAppointments(ctx context.Context, filter AppointmentFilter) (uint64, error)
 	UpdateAppointment(ctx context.Context, slot *domain.Appointment) (*domain.Appointment, error)
 	DeleteAppointment(ctx context.Context, id uuid.UUID) error
+	// ListDueReminders obtiene las citas reservadas que empiezan antes de until y aún no se recuerdan
+	ListDueReminders(ctx context.Context, until time.Time) ([]domain.Appointment, error)
+	// MarkReminderSent registra que el recordatorio de la cita se envió
+	MarkReminderSent(ctx context.Context, id uuid.UUID, at time.Time) error
 }
 
 // AppointmentService es la interfaz para interactuar con la lógica de negocio de Appointment
 type AppointmentService interface {
 	CreateAppointment(ctx context.Context, slot *domain.Appointment) (*domain.Appointment, error)
 	GetAppointment(ctx context.Context, id uuid.UUID) (*domain.Appointment, error)
-	ListAppointments(ctx context.Context, filter AppointmentFilter) ([]domain.Appointment, error)
+	ListAppointments(ctx context.Context, filter AppointmentFilter) ([]domain.Appointment, uint64, *domain.Cursor, error)
 	UpdateAppointment(ctx context.Context, slot *domain.Appointment) (*domain.Appointment, error)
 	DeleteAppointment(ctx context.Context, id uuid.UUID) error
+	// CancelAppointment cancela la cita del cliente que la solicita, o cualquiera para admins, y libera su slot
+	CancelAppointment(ctx context.Context, id uuid.UUID, requester *domain.TokenPayload) (*domain.Appointment, error)
+}
+
+// AppointmentReminderService es la interfaz para recordar a los clientes sus próximas citas
+type AppointmentReminderService interface {
+	// RemindDue envía los recordatorios de las citas que empiezan pronto
+	RemindDue(ctx context.Context) error
 }
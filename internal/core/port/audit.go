@@ -0,0 +1,41 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=audit.go -destination=mock/audit.go -package=mock
+
+// AuditEntryFilter narrows down a listing of the audit trail
+type AuditEntryFilter struct {
+	Entity   *domain.AuditEntity
+	EntityID *uuid.UUID
+	Action   *domain.AuditAction
+	ActorID  *uuid.UUID
+	// StartDate and EndDate bound when the changes were made
+	StartDate *time.Time
+	EndDate   *time.Time
+	Skip      uint64
+	Limit     uint64
+}
+
+// AuditRepository is an interface for interacting with the audit trail of the sensitive changes
+type AuditRepository interface {
+	// CreateAuditEntry inserts an audited change into the database
+	CreateAuditEntry(ctx context.Context, entry *domain.AuditEntry) error
+	// ListAuditEntries selects the audited changes, newest first
+	ListAuditEntries(ctx context.Context, filter AuditEntryFilter) ([]domain.AuditEntry, error)
+	// CountAuditEntries counts the audited changes matching the filter, ignoring pagination
+	CountAuditEntries(ctx context.Context, filter AuditEntryFilter) (uint64, error)
+}
+
+// AuditService is an interface for inspecting the audit trail
+type AuditService interface {
+	// ListAuditEntries lists the audited changes, newest first, and how many match the filter
+	ListAuditEntries(ctx context.Context, filter AuditEntryFilter) ([]domain.AuditEntry, uint64, error)
+}
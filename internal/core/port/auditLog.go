@@ -0,0 +1,38 @@
+package port
+
+import (
+	"context"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=auditLog.go -destination=mock/auditLog.go -package=mock
+
+// AuditLogFilter narrows down a listing of the audit log
+type AuditLogFilter struct {
+	ActorID  *uuid.UUID
+	TargetID *uuid.UUID
+	Method   string
+	// Route keeps only the requests to this route template, e.g. /v1/quotes/:id
+	Route string
+	Skip  uint64
+	Limit uint64
+}
+
+// AuditLogRepository is an interface for interacting with the audit log
+type AuditLogRepository interface {
+	// CreateAuditLog inserts an audited request into the database
+	CreateAuditLog(ctx context.Context, log *domain.AuditLog) error
+	// ListAuditLogs selects the audited requests, newest first
+	ListAuditLogs(ctx context.Context, filter AuditLogFilter) ([]domain.AuditLog, error)
+}
+
+// AuditLogService is an interface for recording and inspecting the changes users make
+type AuditLogService interface {
+	// RecordAuditLog records a request that changed data
+	RecordAuditLog(ctx context.Context, log *domain.AuditLog) error
+	// ListAuditLogs lists the audited requests, newest first
+	ListAuditLogs(ctx context.Context, filter AuditLogFilter) ([]domain.AuditLog, error)
+}
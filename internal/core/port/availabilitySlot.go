@@ -25,13 +25,18 @@ type AvailabilitySlotFilter struct {
 	ByState *SlotState
 	Skip    uint64
 	Limit   uint64
+	// After continúa el listado después de un cursor en lugar de saltar páginas
+	After   *domain.Cursor
+	// IncludeDeleted también lista los slots eliminados lógicamente, para admins
+	IncludeDeleted bool
 }
 
 // AvailabilitySlotRepository es la interfaz para interactuar con los datos de AvailabilitySlot
 type AvailabilitySlotRepository interface {
 	CreateAvailabilitySlot(ctx context.Context, slot *domain.AvailabilitySlot) (*domain.AvailabilitySlot, error)
 	GetAvailabilitySlotByID(ctx context.Context, id uuid.UUID) (*domain.AvailabilitySlot, error)
-	ListAvailabilitySlots(ctx context.Context, filter AvailabilitySlotFilter) ([]domain.AvailabilitySlot, error)
+	ListAvailabilitySlots(ctx context.Context, filter AvailabilitySlotFilter) ([]domain.AvailabilitySlot, *domain.Cursor, error)
+	CountAvailabilitySlots(ctx context.Context, filter AvailabilitySlotFilter) (uint64, error)
 	UpdateAvailabilitySlot(ctx context.Context, slot *domain.AvailabilitySlot) (*domain.AvailabilitySlot, error)
 	DeleteAvailabilitySlot(ctx context.Context, id uuid.UUID) error
 }
@@ -40,7 +45,7 @@ type AvailabilitySlotRepository interface {
 type AvailabilitySlotService interface {
 	CreateAvailabilitySlot(ctx context.Context, slot *domain.AvailabilitySlot) (*domain.AvailabilitySlot, error)
 	GetAvailabilitySlot(ctx context.Context, id uuid.UUID) (*domain.AvailabilitySlot, error)
-	ListAvailabilitySlots(ctx context.Context, filter AvailabilitySlotFilter) ([]domain.AvailabilitySlot, error)
+	ListAvailabilitySlots(ctx context.Context, filter AvailabilitySlotFilter) ([]domain.AvailabilitySlot, uint64, *domain.Cursor, error)
 	UpdateAvailabilitySlot(ctx context.Context, slot *domain.AvailabilitySlot) (*domain.AvailabilitySlot, error)
 	DeleteAvailabilitySlot(ctx context.Context, id uuid.UUID) error
 }
@@ -0,0 +1,50 @@
+package port
+
+import (
+	"context"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=coupon.go -destination=mock/coupon.go -package=mock
+
+// CouponRepository is an interface for interacting with coupon-related data
+type CouponRepository interface {
+	// CreateCoupon inserts a new coupon and its types of service into the database
+	CreateCoupon(ctx context.Context, coupon *domain.Coupon) (*domain.Coupon, error)
+	// GetCouponByID selects a coupon by id
+	GetCouponByID(ctx context.Context, id uuid.UUID) (*domain.Coupon, error)
+	// GetCouponByCode selects a coupon by its normalized code
+	GetCouponByCode(ctx context.Context, code string) (*domain.Coupon, error)
+	// ListCoupons selects a list of coupons
+	ListCoupons(ctx context.Context, skip, limit uint64) ([]domain.Coupon, error)
+	// UpdateCoupon updates a coupon and replaces its types of service
+	UpdateCoupon(ctx context.Context, coupon *domain.Coupon) (*domain.Coupon, error)
+	// DeleteCoupon deletes a coupon
+	DeleteCoupon(ctx context.Context, id uuid.UUID) error
+	// RedeemCoupon records the redemption, replacing the one of its quote, or fails with
+	// domain.ErrCouponUnavailable when the coupon or its client reached their limit
+	RedeemCoupon(ctx context.Context, redemption *domain.CouponRedemption) (*domain.CouponRedemption, error)
+	// ReleaseCoupon deletes the redemption of a quote, giving the use back to its coupon
+	ReleaseCoupon(ctx context.Context, quoteID uuid.UUID) error
+	// ListCouponRedemptions selects the redemptions of a coupon
+	ListCouponRedemptions(ctx context.Context, couponID uuid.UUID, skip, limit uint64) ([]domain.CouponRedemption, error)
+}
+
+// CouponService is an interface for interacting with coupon-related business logic
+type CouponService interface {
+	// CreateCoupon creates a new coupon
+	CreateCoupon(ctx context.Context, coupon *domain.Coupon) (*domain.Coupon, error)
+	// GetCoupon returns a coupon by id
+	GetCoupon(ctx context.Context, id uuid.UUID) (*domain.Coupon, error)
+	// ListCoupons returns a list of coupons with pagination
+	ListCoupons(ctx context.Context, skip, limit uint64) ([]domain.Coupon, error)
+	// UpdateCoupon updates a coupon
+	UpdateCoupon(ctx context.Context, coupon *domain.Coupon) (*domain.Coupon, error)
+	// DeleteCoupon deletes a coupon
+	DeleteCoupon(ctx context.Context, id uuid.UUID) error
+	// ListCouponRedemptions returns the redemptions of a coupon with pagination
+	ListCouponRedemptions(ctx context.Context, couponID uuid.UUID, skip, limit uint64) ([]domain.CouponRedemption, error)
+}
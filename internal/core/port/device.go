@@ -0,0 +1,31 @@
+package port
+
+import (
+	"context"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=device.go -destination=mock/device.go -package=mock
+
+// DeviceRepository is an interface for interacting with the devices push notifications are sent to
+type DeviceRepository interface {
+	// UpsertDevice inserts a device, or moves its token to the user and platform given when it is already registered
+	UpsertDevice(ctx context.Context, device *domain.Device) (*domain.Device, error)
+	// ListDevicesByUsers selects the devices of the users
+	ListDevicesByUsers(ctx context.Context, userIDs []uuid.UUID) ([]domain.Device, error)
+	// DeleteDevice deletes one of a user's devices by its token
+	DeleteDevice(ctx context.Context, userID uuid.UUID, token string) error
+	// DeleteDeviceByToken deletes a device whatever its user, for tokens the push provider no longer knows
+	DeleteDeviceByToken(ctx context.Context, token string) error
+}
+
+// DeviceService is an interface for registering the devices of the signed in user
+type DeviceService interface {
+	// RegisterDevice registers a device of a user for push notifications
+	RegisterDevice(ctx context.Context, device *domain.Device) (*domain.Device, error)
+	// UnregisterDevice stops sending push notifications to one of a user's devices
+	UnregisterDevice(ctx context.Context, userID uuid.UUID, token string) error
+}
@@ -2,8 +2,23 @@ package port
 
 import (
 	"context"
+
+	"harajuku/backend/internal/core/domain"
 )
 
+//go:generate mockgen -source=email.go -destination=mock/email.go -package=mock
+
 type EmailRepository interface {
-	SendEmail(ctx context.Context, to[] string, subjects string, textContent string, htmlContent string) error
+	// SendEmail sends the email to every recipient, with the attachments added as files,
+	// returning the ID the provider assigned to the message
+	SendEmail(ctx context.Context, to[] string, subjects string, textContent string, htmlContent string, attachments ...domain.EmailAttachment) (string, error)
+}
+
+// EmailTemplateRenderer is an interface for rendering the email of an event from its typed data
+type EmailTemplateRenderer interface {
+	// Render renders the subject, HTML and text parts of template with data in lang
+	Render(template domain.EmailTemplate, lang domain.Language, data any) (*domain.EmailMessage, error)
+	// RenderMessage renders the short text message of template for phone channels, along with the provider
+	// template it matches, returning nil when the event is not sent to phones
+	RenderMessage(template domain.EmailTemplate, lang domain.Language, data any) (*domain.Message, error)
 }
@@ -0,0 +1,30 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+)
+
+//go:generate mockgen -source=emailLog.go -destination=mock/emailLog.go -package=mock
+
+// EmailLogFilter narrows down a listing of the email delivery log
+type EmailLogFilter struct {
+	// Recipient keeps only the emails sent to this address
+	Recipient string
+	Status    *domain.EmailStatus
+	Template  *domain.EmailTemplate
+	Skip      uint64
+	Limit     uint64
+}
+
+// EmailLogRepository is an interface for interacting with the email delivery log
+type EmailLogRepository interface {
+	// CreateEmailLog inserts a delivery attempt into the database
+	CreateEmailLog(ctx context.Context, log *domain.EmailLog) error
+	// ListEmailLogs selects the delivery attempts, newest first
+	ListEmailLogs(ctx context.Context, filter EmailLogFilter) ([]domain.EmailLog, error)
+	// DeleteEmailLogsBefore deletes up to limit delivery attempts made before before and returns how many it deleted
+	DeleteEmailLogsBefore(ctx context.Context, before time.Time, limit uint64) (uint64, error)
+}
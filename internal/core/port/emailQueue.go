@@ -0,0 +1,42 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=emailQueue.go -destination=mock/emailQueue.go -package=mock
+
+// EmailQueueRepository is an interface for interacting with the email delivery queue
+type EmailQueueRepository interface {
+	// Enqueue schedules the email to be sent at its NextAttemptAt
+	Enqueue(ctx context.Context, email *domain.QueuedEmail) error
+	// Dequeue claims the next email due at now, returning ErrDataNotFound when none is due
+	Dequeue(ctx context.Context, now time.Time) (*domain.QueuedEmail, error)
+	// DeadLetter moves an email that ran out of attempts to the dead-letter list
+	DeadLetter(ctx context.Context, email *domain.QueuedEmail) error
+	// ListDeadLetters lists the dead-lettered emails, most recent failure first
+	ListDeadLetters(ctx context.Context, skip, limit uint64) ([]domain.QueuedEmail, error)
+	// RemoveDeadLetter takes an email out of the dead-letter list
+	RemoveDeadLetter(ctx context.Context, id uuid.UUID) (*domain.QueuedEmail, error)
+	// Close closes the connection to the queue
+	Close() error
+}
+
+// EmailQueueService is an interface for delivering queued emails and inspecting the ones that failed
+type EmailQueueService interface {
+	// Run sends due emails until ctx is done
+	Run(ctx context.Context)
+	// ListDeadLetters lists the emails that could not be delivered
+	ListDeadLetters(ctx context.Context, skip, limit uint64) ([]domain.QueuedEmail, error)
+	// RetryDeadLetter puts a dead-lettered email back in the queue with fresh attempts
+	RetryDeadLetter(ctx context.Context, id uuid.UUID) (*domain.QueuedEmail, error)
+	// DiscardDeadLetter deletes a dead-lettered email for good
+	DiscardDeadLetter(ctx context.Context, id uuid.UUID) error
+	// ListEmailLogs lists the attempts to deliver emails, newest first
+	ListEmailLogs(ctx context.Context, filter EmailLogFilter) ([]domain.EmailLog, error)
+}
@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+)
+
+//go:generate mockgen -source=errorReport.go -destination=mock/errorReport.go -package=mock
+
+// ErrorReporter is an interface for sending the unexpected errors and panics to an error tracker,
+// reports are tagged with the log attributes of the context, so they point to the logs of the request
+type ErrorReporter interface {
+	// Report sends err without waiting for it to be delivered
+	Report(ctx context.Context, err error)
+	// ReportPanic sends the value a panic was recovered with, it is called from the deferred function
+	// that recovered it so the stack of the panic is reported
+	ReportPanic(ctx context.Context, recovered any)
+	// Flush waits until the reports sent so far are delivered or ctx is done
+	Flush(ctx context.Context) error
+}
@@ -0,0 +1,23 @@
+package port
+
+import (
+	"context"
+
+	"harajuku/backend/internal/core/domain"
+)
+
+//go:generate mockgen -source=event.go -destination=mock/event.go -package=mock
+
+// EventPublisher is an interface for publishing domain events
+type EventPublisher interface {
+	// Publish delivers the event to the current subscribers of its type without waiting on them
+	Publish(ctx context.Context, event domain.Event)
+}
+
+// EventDispatcher is an interface for publishing domain events and subscribing to them
+type EventDispatcher interface {
+	EventPublisher
+	// Subscribe returns a channel receiving the events of the given types, or of every type when none is given,
+	// and a function that unsubscribes and closes the channel
+	Subscribe(types ...domain.EventType) (<-chan domain.Event, func())
+}
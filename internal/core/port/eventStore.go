@@ -0,0 +1,34 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+)
+
+//go:generate mockgen -source=eventStore.go -destination=mock/eventStore.go -package=mock
+
+// EventFilter narrows down a listing of the event store
+type EventFilter struct {
+	Type *domain.EventType
+	// StartDate and EndDate bound when the events occurred
+	StartDate *time.Time
+	EndDate   *time.Time
+	Skip      uint64
+	Limit     uint64
+}
+
+// EventStoreRepository is an interface for interacting with the append-only store of domain events
+type EventStoreRepository interface {
+	// AppendEvents inserts the events, an event already stored is left as it is
+	AppendEvents(ctx context.Context, events ...*domain.StoredEvent) error
+	// ListEvents selects the stored events in the order they occurred
+	ListEvents(ctx context.Context, filter EventFilter) ([]domain.StoredEvent, error)
+}
+
+// EventStoreService is an interface for reading back the domain events that were published
+type EventStoreService interface {
+	// ListEvents lists the stored events in the order they occurred
+	ListEvents(ctx context.Context, filter EventFilter) ([]domain.StoredEvent, error)
+}
@@ -2,10 +2,27 @@ package port
 
 import (
   "context"
+  "io"
+  "time"
 )
 
+//go:generate mockgen -source=file.go -destination=mock/file.go -package=mock
+
 type FileRepository interface {
-  Save(ctx context.Context, data []byte, name string) (string, error)
+  // Save streams size bytes from r into the file called name, size is -1 when unknown
+  Save(ctx context.Context, r io.Reader, size int64, name string) (string, error)
   Get(ctx context.Context, path string) ([]byte, error) 
   Delete(ctx context.Context, path string) error
 }
+
+// FileURLSigner is an interface for handing out temporary URLs to stored files
+type FileURLSigner interface {
+  // SignURL returns a URL granting read access to the file at path for a limited time
+  SignURL(path string) (string, error)
+}
+
+// FileLister is implemented by file repositories that can list what they store
+type FileLister interface {
+  // ListFiles calls fn with the key and last modification time of every stored file, stopping at the first error
+  ListFiles(ctx context.Context, fn func(key string, modifiedAt time.Time) error) error
+}
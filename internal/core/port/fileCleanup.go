@@ -0,0 +1,20 @@
+package port
+
+import (
+	"context"
+)
+
+//go:generate mockgen -source=fileCleanup.go -destination=mock/fileCleanup.go -package=mock
+
+// FileReferenceRepository is an interface for finding which stored files the database still points to
+type FileReferenceRepository interface {
+	// GetReferencedFiles returns the keys among keys referenced by a quote image, a payment proof, a
+	// type of service image or the photo of a staff member
+	GetReferencedFiles(ctx context.Context, keys []string) (map[string]bool, error)
+}
+
+// FileCleanupService is an interface for removing stored files nothing refers to
+type FileCleanupService interface {
+	// CleanOrphans deletes the stored files no row references anymore and returns how many it deleted
+	CleanOrphans(ctx context.Context) (int, error)
+}
@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+	"io"
+)
+
+//go:generate mockgen -source=fileScanner.go -destination=mock/fileScanner.go -package=mock
+
+// FileScanner is an interface for scanning uploads for malware before they are stored
+type FileScanner interface {
+	// Scan reads r to its end and returns the name of the threat found, or an empty string for a clean file
+	Scan(ctx context.Context, r io.Reader) (string, error)
+}
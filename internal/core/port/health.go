@@ -0,0 +1,29 @@
+package port
+
+import (
+	"context"
+
+	"harajuku/backend/internal/core/domain"
+)
+
+//go:generate mockgen -source=health.go -destination=mock/health.go -package=mock
+
+// HealthRepository is an interface for checking the state of the database
+type HealthRepository interface {
+	// DatabaseHealth pings the database and collects its pool statistics and schema version
+	DatabaseHealth(ctx context.Context) (*domain.DatabaseHealth, error)
+}
+
+// HealthChecker is an interface for the services the API depends on that can tell whether they are reachable
+type HealthChecker interface {
+	// Ping returns an error when the service can't be reached
+	Ping(ctx context.Context) error
+}
+
+// HealthService is an interface for checking the state of the services the API depends on
+type HealthService interface {
+	// DatabaseHealth returns the state of the database, ErrDatabaseUnavailable when it can't be reached
+	DatabaseHealth(ctx context.Context) (*domain.DatabaseHealth, error)
+	// Readiness checks every dependency, ErrNotReady when one of them can't be reached
+	Readiness(ctx context.Context) ([]domain.ComponentHealth, error)
+}
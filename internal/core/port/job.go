@@ -0,0 +1,62 @@
+package port
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=job.go -destination=mock/job.go -package=mock
+
+// JobQueueRepository is an interface for interacting with the background job queue
+type JobQueueRepository interface {
+	// Enqueue schedules the job to run at its NextAttemptAt
+	Enqueue(ctx context.Context, job *domain.Job) error
+	// Dequeue claims the next job due at now, returning ErrDataNotFound when none is due
+	Dequeue(ctx context.Context, now time.Time) (*domain.Job, error)
+	// ListQueued lists the jobs waiting in the queue, the next one due first
+	ListQueued(ctx context.Context, skip, limit uint64) ([]domain.Job, error)
+	// GetQueued selects a job waiting in the queue by its ID
+	GetQueued(ctx context.Context, id uuid.UUID) (*domain.Job, error)
+	// RemoveQueued takes a job out of the queue, as a worker claiming it would
+	RemoveQueued(ctx context.Context, id uuid.UUID) (*domain.Job, error)
+	// DeadLetter moves a job that ran out of attempts to the dead-letter list
+	DeadLetter(ctx context.Context, job *domain.Job) error
+	// ListDeadLetters lists the dead-lettered jobs, most recent failure first
+	ListDeadLetters(ctx context.Context, skip, limit uint64) ([]domain.Job, error)
+	// GetDeadLetter selects a dead-lettered job by its ID
+	GetDeadLetter(ctx context.Context, id uuid.UUID) (*domain.Job, error)
+	// RemoveDeadLetter takes a job out of the dead-letter list
+	RemoveDeadLetter(ctx context.Context, id uuid.UUID) (*domain.Job, error)
+	// Close closes the connection to the queue
+	Close() error
+}
+
+// JobHandler runs a job with its payload, errors wrapping ErrJobRejected aren't retried
+type JobHandler func(ctx context.Context, payload json.RawMessage) error
+
+// JobQueueService is an interface for running work in the background, off the request path
+type JobQueueService interface {
+	// Enqueue schedules a job of jobType, payload is the payload struct of the type
+	Enqueue(ctx context.Context, jobType domain.JobType, payload any) (*domain.Job, error)
+	// Handle registers the handler running the jobs of jobType
+	Handle(jobType domain.JobType, handler JobHandler)
+	// Run runs due jobs until ctx is done
+	Run(ctx context.Context)
+	// ListDeadLetters lists the jobs that failed every attempt
+	ListDeadLetters(ctx context.Context, skip, limit uint64) ([]domain.Job, error)
+	// RetryDeadLetter puts a dead-lettered job back in the queue with fresh attempts
+	RetryDeadLetter(ctx context.Context, id uuid.UUID) (*domain.Job, error)
+	// ListQueued lists the jobs waiting to run, including those deferred after failing, the next one due first
+	ListQueued(ctx context.Context, skip, limit uint64) ([]domain.Job, error)
+	// GetJob returns a queued or dead-lettered job with its payload and where it is
+	GetJob(ctx context.Context, id uuid.UUID) (*domain.Job, domain.JobStatus, error)
+	// RetryJob runs a queued job right away, or puts a dead-lettered one back in the queue with fresh attempts
+	RetryJob(ctx context.Context, id uuid.UUID) (*domain.Job, error)
+	// DiscardJob deletes a queued or dead-lettered job for good
+	DiscardJob(ctx context.Context, id uuid.UUID) error
+}
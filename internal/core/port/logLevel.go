@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+)
+
+//go:generate mockgen -source=logLevel.go -destination=mock/logLevel.go -package=mock
+
+// LogLevelService is an interface for changing the level of the logs of the running server
+type LogLevelService interface {
+	// GetLogLevel returns the current level of the logs
+	GetLogLevel(ctx context.Context) *domain.LogLevel
+	// SetLogLevel changes the level of the logs, back to the startup level after duration unless it is 0
+	SetLogLevel(ctx context.Context, level slog.Level, duration time.Duration) *domain.LogLevel
+}
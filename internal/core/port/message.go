@@ -0,0 +1,15 @@
+package port
+
+import (
+	"context"
+
+	"harajuku/backend/internal/core/domain"
+)
+
+//go:generate mockgen -source=message.go -destination=mock/message.go -package=mock
+
+// MessageSender is an interface for sending text messages to a phone, whatever the channel
+type MessageSender interface {
+	// SendMessage sends the message, returning domain.ErrMessageRejected when retrying it cannot succeed
+	SendMessage(ctx context.Context, message *domain.Message) error
+}
@@ -0,0 +1,49 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=notification.go -destination=mock/notification.go -package=mock
+
+// NotificationFilter narrows down a listing of a user's notifications
+type NotificationFilter struct {
+	UserID uuid.UUID
+	// Unread keeps only the notifications not marked as read
+	Unread bool
+	Skip   uint64
+	Limit  uint64
+}
+
+// NotificationRepository is an interface for interacting with notification-related data
+type NotificationRepository interface {
+	// CreateNotifications inserts the notifications into the database
+	CreateNotifications(ctx context.Context, notifications []domain.Notification) error
+	// ListNotifications selects a user's notifications, newest first
+	ListNotifications(ctx context.Context, filter NotificationFilter) ([]domain.Notification, error)
+	// CountUnread counts the notifications a user has not read
+	CountUnread(ctx context.Context, userID uuid.UUID) (uint64, error)
+	// MarkAsRead marks one of a user's notifications as read
+	MarkAsRead(ctx context.Context, userID, id uuid.UUID) error
+	// MarkAllAsRead marks every notification of a user as read
+	MarkAllAsRead(ctx context.Context, userID uuid.UUID) error
+	// DeleteNotificationsBefore deletes up to limit notifications created before before and returns how many it deleted
+	DeleteNotificationsBefore(ctx context.Context, before time.Time, limit uint64) (uint64, error)
+}
+
+// NotificationService is an interface for notifying users of events and reading their notifications
+type NotificationService interface {
+	// Notify records an in-app notification for every recipient and emails them in their language
+	Notify(ctx context.Context, recipients []domain.User, template domain.EmailTemplate, data any) error
+	// ListNotifications returns a user's notifications and how many of them are unread
+	ListNotifications(ctx context.Context, filter NotificationFilter) ([]domain.Notification, uint64, error)
+	// MarkAsRead marks one of a user's notifications as read
+	MarkAsRead(ctx context.Context, userID, id uuid.UUID) error
+	// MarkAllAsRead marks every notification of a user as read
+	MarkAllAsRead(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,31 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=outbox.go -destination=mock/outbox.go -package=mock
+
+// OutboxRepository is an interface for interacting with the transactional outbox
+type OutboxRepository interface {
+	// CreateOutboxMessages inserts the messages, in the transaction of the change they belong to
+	CreateOutboxMessages(ctx context.Context, messages ...*domain.OutboxMessage) error
+	// ClaimOutboxMessages takes up to limit messages due at now, holding them for lease so no other
+	// relay takes them meanwhile. Their attempts are counted
+	ClaimOutboxMessages(ctx context.Context, now time.Time, lease time.Duration, limit uint64) ([]domain.OutboxMessage, error)
+	// DeleteOutboxMessage deletes a relayed message
+	DeleteOutboxMessage(ctx context.Context, id uuid.UUID) error
+	// RetryOutboxMessage records why a message failed and makes it due again at availableAt
+	RetryOutboxMessage(ctx context.Context, id uuid.UUID, availableAt time.Time, lastError string) error
+}
+
+// OutboxService is an interface for relaying the outbox
+type OutboxService interface {
+	// Run relays the due messages until ctx is done
+	Run(ctx context.Context)
+}
@@ -0,0 +1,29 @@
+package port
+
+import (
+	"context"
+
+	"harajuku/backend/internal/core/domain"
+)
+
+//go:generate mockgen -source=paymentEvent.go -destination=mock/paymentEvent.go -package=mock
+
+// PaymentEventRepository is an interface for interacting with payment-event-related data
+type PaymentEventRepository interface {
+	// CreatePaymentEvent inserts a new payment event into the database
+	CreatePaymentEvent(ctx context.Context, event *domain.PaymentEvent) (*domain.PaymentEvent, error)
+	// GetPaymentEventByExternalID selects a payment event by the provider's own identifier
+	GetPaymentEventByExternalID(ctx context.Context, provider domain.PaymentProvider, externalID string) (*domain.PaymentEvent, error)
+}
+
+// PaymentWebhookVerifier is an interface for verifying and decoding payment provider callbacks
+type PaymentWebhookVerifier interface {
+	// Verify checks the callback signature found in headers and decodes the payload into a payment event
+	Verify(ctx context.Context, provider domain.PaymentProvider, headers map[string]string, payload []byte) (*domain.PaymentEvent, error)
+}
+
+// PaymentWebhookService is an interface for interacting with payment-webhook-related business logic
+type PaymentWebhookService interface {
+	// HandlePaymentEvent verifies a provider callback, records it and advances the related quote
+	HandlePaymentEvent(ctx context.Context, provider domain.PaymentProvider, headers map[string]string, payload []byte) (*domain.PaymentEvent, error)
+}
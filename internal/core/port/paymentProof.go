@@ -3,6 +3,7 @@ package port
 import (
 	"context"
 	"harajuku/backend/internal/core/domain"
+	"io"
 
 	"github.com/google/uuid"
 )
@@ -12,6 +13,7 @@ import (
 type PaymentProofFilter struct {
 	QuoteID    *uuid.UUID // Optional filter by QuoteID
 	IsReviewed *bool      // Optional filter by IsReviewed status
+	Checksum   *string    // Optional filter by the SHA-256 of the uploaded file
 	Skip       uint64     // Number of records to skip for pagination
 	Limit      uint64     // Maximum number of records to return
 
@@ -30,6 +32,8 @@ type PaymentProofRepository interface {
 	GetPaymentProofByQuoteID(ctx context.Context, quoteID uuid.UUID) (*domain.PaymentProof, error)
 	// GetPaymentProofs selects all payment proofs with optional filtering by QuoteID
 	GetPaymentProofs(ctx context.Context, filter PaymentProofFilter) ([]domain.PaymentProof, error)
+	// CountPaymentProofs counts the payment proofs matching the filter, ignoring pagination
+	CountPaymentProofs(ctx context.Context, filter PaymentProofFilter) (uint64, error)
 	// Wrap a function in a DB transaction; if fn returns an error, rollback
 	WithTx(ctx context.Context, fn func(repo PaymentProofRepository) error) error
 }
@@ -37,13 +41,13 @@ type PaymentProofRepository interface {
 // PaymentProofService is an interface for interacting with payment-proof-related business logic
 type PaymentProofService interface {
 	// CreatePaymentProof creates a new payment proof
-	CreatePaymentProof(ctx context.Context, proof *domain.PaymentProof, file []byte, fileName string) (*domain.PaymentProof, error)
+	CreatePaymentProof(ctx context.Context, proof *domain.PaymentProof, file io.Reader, size int64, fileName string) (*domain.PaymentProof, error)
 	// UpdatePaymentProof updates an existing payment proof
 	UpdatePaymentProof(ctx context.Context, proof *domain.PaymentProof) (*domain.PaymentProof, error)
 	// DeletePaymentProof deletes a payment proof by its ID
 	DeletePaymentProof(ctx context.Context, id uuid.UUID) error
 	// GetPaymentProofByID returns a payment proof and its file content by its ID
 	GetPaymentProofByID(ctx context.Context, id uuid.UUID) (*domain.PaymentProof, []byte, error)
-	// GetPaymentProofs returns a list of payment proofs, with optional filtering by quoteId
-	GetPaymentProofs(ctx context.Context, filter PaymentProofFilter) ([]domain.PaymentProof, error)
+	// GetPaymentProofs returns a page of payment proofs, with optional filtering by quoteId, and the total number of matches
+	GetPaymentProofs(ctx context.Context, filter PaymentProofFilter) ([]domain.PaymentProof, uint64, error)
 }
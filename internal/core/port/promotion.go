@@ -0,0 +1,51 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=promotion.go -destination=mock/promotion.go -package=mock
+
+// PromotionFilter narrows down a listing of promotions
+type PromotionFilter struct {
+	// ActiveAt keeps the promotions whose validity window contains it
+	ActiveAt *time.Time
+	// TypeOfServiceID keeps the promotions that apply to it, including the ones for every service
+	TypeOfServiceID *uuid.UUID
+	Skip            uint64
+	// Limit disables pagination when zero
+	Limit uint64
+}
+
+// PromotionRepository is an interface for interacting with promotion-related data
+type PromotionRepository interface {
+	// CreatePromotion inserts a new promotion and its types of service into the database
+	CreatePromotion(ctx context.Context, promotion *domain.Promotion) (*domain.Promotion, error)
+	// GetPromotionByID selects a promotion by id
+	GetPromotionByID(ctx context.Context, id uuid.UUID) (*domain.Promotion, error)
+	// ListPromotions selects a list of promotions
+	ListPromotions(ctx context.Context, filter PromotionFilter) ([]domain.Promotion, error)
+	// UpdatePromotion updates a promotion and replaces its types of service
+	UpdatePromotion(ctx context.Context, promotion *domain.Promotion) (*domain.Promotion, error)
+	// DeletePromotion deletes a promotion
+	DeletePromotion(ctx context.Context, id uuid.UUID) error
+}
+
+// PromotionService is an interface for interacting with promotion-related business logic
+type PromotionService interface {
+	// CreatePromotion creates a new promotion
+	CreatePromotion(ctx context.Context, promotion *domain.Promotion) (*domain.Promotion, error)
+	// GetPromotion returns a promotion by id
+	GetPromotion(ctx context.Context, id uuid.UUID) (*domain.Promotion, error)
+	// ListPromotions returns a list of promotions with pagination
+	ListPromotions(ctx context.Context, filter PromotionFilter) ([]domain.Promotion, error)
+	// UpdatePromotion updates a promotion
+	UpdatePromotion(ctx context.Context, promotion *domain.Promotion) (*domain.Promotion, error)
+	// DeletePromotion deletes a promotion
+	DeletePromotion(ctx context.Context, id uuid.UUID) error
+}
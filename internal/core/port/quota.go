@@ -0,0 +1,38 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=quota.go -destination=mock/quota.go -package=mock
+
+// QuotaRepository is an interface for counting the actions of the users against their daily quotas
+type QuotaRepository interface {
+	// Consume counts an action under key until resetAt, when it is still under limit
+	Consume(ctx context.Context, key string, limit int, resetAt time.Time) (*domain.QuotaResult, error)
+	// Release gives back an action counted under key, for requests that failed
+	Release(ctx context.Context, key string) error
+	// ListQuotas selects the quotas set by admins, which replace the configured ones
+	ListQuotas(ctx context.Context) ([]domain.Quota, error)
+	// SetQuota stores a quota set by an admin
+	SetQuota(ctx context.Context, quota *domain.Quota) error
+	// Close closes the connection to the store of the quotas
+	Close() error
+}
+
+// QuotaService is an interface for enforcing and managing the daily quotas of the users
+type QuotaService interface {
+	// Consume counts an action of a user against the quota of their role, the result is nil when the role has no quota
+	Consume(ctx context.Context, userID uuid.UUID, role domain.UserRole, action domain.QuotaAction) (*domain.QuotaResult, error)
+	// Release gives back an action counted today, for requests that failed
+	Release(ctx context.Context, userID uuid.UUID, action domain.QuotaAction) error
+	// ListQuotas lists the quota of every role and action
+	ListQuotas(ctx context.Context) ([]domain.Quota, error)
+	// SetQuota changes the quota of a role for an action
+	SetQuota(ctx context.Context, quota *domain.Quota) error
+}
@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"harajuku/backend/internal/core/domain"
@@ -19,6 +20,10 @@ type QuoteFilter struct {
 	ByState 		*domain.QuoteState
 	Skip    		uint64
 	Limit   		uint64
+	// After resumes the listing after a cursor instead of skipping pages
+	After   		*domain.Cursor
+	// IncludeDeleted lists soft-deleted quotes too, for admins
+	IncludeDeleted	bool
 }
 
 // QuoteRepository is an interface for interacting with quote-related data
@@ -27,8 +32,10 @@ type QuoteRepository interface {
 	CreateQuote(ctx context.Context, user *domain.Quote) (*domain.Quote, error)
 	// GetQuoteByID selects a quote by id
 	GetQuoteByID(ctx context.Context, id uuid.UUID) (*domain.Quote, error)
-	// ListQuotes selects a list of quotes with pagination
-	ListQuotes(ctx context.Context, filter QuoteFilter) ([]domain.Quote, error)
+	// ListQuotes selects a page of quotes ordered by time and the cursor of the next page
+	ListQuotes(ctx context.Context, filter QuoteFilter) ([]domain.Quote, *domain.Cursor, error)
+	// CountQuotes counts the quotes matching the filter, ignoring pagination
+	CountQuotes(ctx context.Context, filter QuoteFilter) (uint64, error)
 	// UpdateQuote updates a quote
 	UpdateQuote(ctx context.Context, user *domain.Quote) (*domain.Quote, error)
 	// DeleteQuote deletes a quote
@@ -40,15 +47,22 @@ type QuoteRepository interface {
 // QuoteService is an interface for interacting with quote-related business logic
 type QuoteService interface {
 	// Creates a new quote
-	CreateQuote(ctx context.Context, quote *domain.Quote, file []byte, fileName string) (*domain.Quote, error)
+	CreateQuote(ctx context.Context, quote *domain.Quote, file io.Reader, size int64, fileName string) (*domain.Quote, error)
 	// GetQuote returns a quote by id
 	GetQuote(ctx context.Context, id uuid.UUID) (*domain.Quote, []domain.QuoteImage, error)
-	// ListQuotes returns a list of quotes with pagination
-	ListQuotes(ctx context.Context, filter QuoteFilter) ([]domain.Quote, error)
+	// ListQuotes returns a page of quotes, the total number of matches and the cursor of the next page
+	ListQuotes(ctx context.Context, filter QuoteFilter) ([]domain.Quote, uint64, *domain.Cursor, error)
 	// UpdateQuote updates a quote
 	UpdateQuote(ctx context.Context, quote *domain.Quote) (*domain.Quote, error)
 	// DeleteQuote deletes a quote
 	DeleteQuote(ctx context.Context, id uuid.UUID) error
 	// ChangeQuoteState updates the quote's but it has more logic invovled after
 	ChangeQuoteState(ctx context.Context, id uuid.UUID, state domain.QuoteState) (*domain.Quote, error)
+	// ExpireQuotes expires the quotes still waiting on the client or an admin that were created before,
+	// returning how many it expired
+	ExpireQuotes(ctx context.Context, before time.Time) (int, error)
+	// ApplyCoupon applies the coupon with the code to a quote still waiting to be paid, replacing the one it had
+	ApplyCoupon(ctx context.Context, quoteID uuid.UUID, code string) (*domain.Quote, error)
+	// RemoveCoupon removes the coupon applied to a quote still waiting to be paid
+	RemoveCoupon(ctx context.Context, quoteID uuid.UUID) (*domain.Quote, error)
 }
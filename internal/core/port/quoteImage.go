@@ -3,6 +3,7 @@ package port
 import (
 	"context"
 	"harajuku/backend/internal/core/domain"
+	"io"
 
 	"github.com/google/uuid"
 )
@@ -27,8 +28,8 @@ type QuoteImageRepository interface {
 
 // QuoteImageService is an interface for interacting with quote-image-related business logic
 type QuoteImageService interface {
-	// CreateQuoteImage creates a new quote image
-	//CreateQuoteImage(ctx context.Context, quoteImage *domain.QuoteImage) (*domain.QuoteImage, error)
+	// CreateQuoteImage uploads a new image for a quote owned by the requester
+	CreateQuoteImage(ctx context.Context, quoteID uuid.UUID, requester *domain.TokenPayload, file io.Reader, size int64, fileName string) (*domain.QuoteImage, error)
 	// UpdateQuoteImage updates an existing quote image
 	//UpdateQuoteImage(ctx context.Context, quoteImage *domain.QuoteImage) (*domain.QuoteImage, error)
 	// DeleteQuoteImage deletes a quote image by its ID
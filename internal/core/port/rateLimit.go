@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+
+	"harajuku/backend/internal/core/domain"
+)
+
+//go:generate mockgen -source=rateLimit.go -destination=mock/rateLimit.go -package=mock
+
+// RateLimitRepository is an interface for counting requests against rate limits
+type RateLimitRepository interface {
+	// Take takes a token from the bucket under key, which is shared by every server
+	Take(ctx context.Context, key string, limit domain.RateLimit) (*domain.RateLimitResult, error)
+	// Close closes the connection to the store of the buckets
+	Close() error
+}
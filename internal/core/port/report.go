@@ -0,0 +1,34 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+)
+
+//go:generate mockgen -source=report.go -destination=mock/report.go -package=mock
+
+// ReportRepository is an interface for the aggregates reports are built from
+type ReportRepository interface {
+	// GetStaffUtilization sums the offered, booked and completed slot time of every admin with slots starting
+	// between start and end
+	GetStaffUtilization(ctx context.Context, start, end time.Time) ([]domain.StaffUtilization, error)
+	// ListClientRetentionMonths counts the new and returning clients of every month with visits between start and end
+	ListClientRetentionMonths(ctx context.Context, start, end time.Time) ([]domain.ClientRetentionMonth, error)
+	// GetAverageVisitGap averages the time between every visit between start and end and the previous visit of its client
+	GetAverageVisitGap(ctx context.Context, start, end time.Time) (time.Duration, error)
+	// ListTopClients selects the limit clients who spent the most on their visits between start and end
+	ListTopClients(ctx context.Context, start, end time.Time, limit uint64) ([]domain.ClientSpend, error)
+}
+
+// ReportService is an interface for building reports
+type ReportService interface {
+	// RequestReport queues the report, which is emailed to the admin who asked for it once it is built
+	RequestReport(ctx context.Context, report *domain.ReportJob) (*domain.Job, error)
+	// GetStaffUtilization compares the slot time every admin offered between start and end with the time booked
+	GetStaffUtilization(ctx context.Context, start, end time.Time) ([]domain.StaffUtilization, error)
+	// GetClientRetention counts new and returning clients per month between start and end, the average time between
+	// visits and the top clients by spend
+	GetClientRetention(ctx context.Context, start, end time.Time, top uint64) (*domain.ClientRetention, error)
+}
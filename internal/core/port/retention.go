@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+)
+
+//go:generate mockgen -source=retention.go -destination=mock/retention.go -package=mock
+
+// RetentionService is an interface for purging the records kept only for a while
+type RetentionService interface {
+	// Purge deletes the email logs and notifications older than their retention window
+	Purge(ctx context.Context) error
+}
@@ -0,0 +1,33 @@
+package port
+
+import (
+	"context"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// SalonRepository is an interface for interacting with salon-related data
+type SalonRepository interface {
+	// CreateSalon inserts a new salon into the database
+	CreateSalon(ctx context.Context, salon *domain.Salon) (*domain.Salon, error)
+	// GetSalonByID selects a salon by id
+	GetSalonByID(ctx context.Context, id uuid.UUID) (*domain.Salon, error)
+	// ListSalons selects a list of salons ordered by name with pagination
+	ListSalons(ctx context.Context, skip, limit uint64) ([]domain.Salon, error)
+	// UpdateSalon updates the name and slug of a salon
+	UpdateSalon(ctx context.Context, salon *domain.Salon) (*domain.Salon, error)
+}
+
+// SalonService is an interface for interacting with salon-related business logic
+type SalonService interface {
+	// CreateSalon creates a new salon
+	CreateSalon(ctx context.Context, salon *domain.Salon) (*domain.Salon, error)
+	// GetSalon returns a salon by id
+	GetSalon(ctx context.Context, id uuid.UUID) (*domain.Salon, error)
+	// ListSalons returns a list of salons with pagination
+	ListSalons(ctx context.Context, skip, limit uint64) ([]domain.Salon, error)
+	// UpdateSalon updates a salon
+	UpdateSalon(ctx context.Context, salon *domain.Salon) (*domain.Salon, error)
+}
@@ -0,0 +1,31 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+)
+
+//go:generate mockgen -source=scheduler.go -destination=mock/scheduler.go -package=mock
+
+// SchedulerLockRepository is an interface for the locks that keep several servers from running the same
+// run of a periodic task
+type SchedulerLockRepository interface {
+	// TryLock takes the lock of the run of task due at runAt, reporting false when another server took it.
+	// The lock is never released, it expires after ttl
+	TryLock(ctx context.Context, task string, runAt time.Time, ttl time.Duration) (bool, error)
+	// Close closes the connection to the lock store
+	Close() error
+}
+
+// ScheduledTask is the work of a periodic task, the error is logged
+type ScheduledTask func(ctx context.Context) error
+
+// SchedulerService is an interface for running periodic tasks once per run across the servers
+type SchedulerService interface {
+	// Schedule registers task under name to run on schedule, a nil schedule disables it
+	Schedule(name string, schedule *domain.Schedule, task ScheduledTask)
+	// Run runs the registered tasks on their schedules until ctx is done
+	Run(ctx context.Context)
+}
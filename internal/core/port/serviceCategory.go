@@ -0,0 +1,39 @@
+package port
+
+import (
+	"context"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=serviceCategory.go -destination=mock/serviceCategory.go -package=mock
+
+// ServiceCategoryRepository is an interface for interacting with service-category-related data
+type ServiceCategoryRepository interface {
+	// CreateServiceCategory inserts a new service category into the database
+	CreateServiceCategory(ctx context.Context, category *domain.ServiceCategory) (*domain.ServiceCategory, error)
+	// GetServiceCategoryByID selects a service category by id
+	GetServiceCategoryByID(ctx context.Context, id uuid.UUID) (*domain.ServiceCategory, error)
+	// ListServiceCategories selects a list of service categories with pagination
+	ListServiceCategories(ctx context.Context, skip, limit uint64) ([]domain.ServiceCategory, error)
+	// UpdateServiceCategory updates a service category
+	UpdateServiceCategory(ctx context.Context, category *domain.ServiceCategory) (*domain.ServiceCategory, error)
+	// DeleteServiceCategory deletes a service category
+	DeleteServiceCategory(ctx context.Context, id uuid.UUID) error
+}
+
+// ServiceCategoryService is an interface for interacting with service-category-related business logic
+type ServiceCategoryService interface {
+	// CreateServiceCategory creates a new service category
+	CreateServiceCategory(ctx context.Context, category *domain.ServiceCategory) (*domain.ServiceCategory, error)
+	// GetServiceCategory returns a service category by id
+	GetServiceCategory(ctx context.Context, id uuid.UUID) (*domain.ServiceCategory, error)
+	// ListServiceCategories returns a list of service categories with pagination
+	ListServiceCategories(ctx context.Context, skip, limit uint64) ([]domain.ServiceCategory, error)
+	// UpdateServiceCategory updates a service category
+	UpdateServiceCategory(ctx context.Context, category *domain.ServiceCategory) (*domain.ServiceCategory, error)
+	// DeleteServiceCategory deletes a service category
+	DeleteServiceCategory(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=serviceOffering.go -destination=mock/serviceOffering.go -package=mock
+
+// ServiceOfferingRepository is an interface for interacting with which admins offer which types of service
+type ServiceOfferingRepository interface {
+	// CreateServiceOffering records that an admin offers a type of service
+	CreateServiceOffering(ctx context.Context, offering *domain.ServiceOffering) (*domain.ServiceOffering, error)
+	// DeleteServiceOffering removes an admin from a type of service
+	DeleteServiceOffering(ctx context.Context, offering *domain.ServiceOffering) error
+	// ListServiceOfferings selects the offerings of the given types of service
+	ListServiceOfferings(ctx context.Context, typeOfServiceIDs []uuid.UUID) ([]domain.ServiceOffering, error)
+	// IsServiceOffered reports whether an admin offers a type of service
+	IsServiceOffered(ctx context.Context, adminID, typeOfServiceID uuid.UUID) (bool, error)
+}
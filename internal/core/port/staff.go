@@ -0,0 +1,58 @@
+package port
+
+import (
+	"context"
+	"io"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=staff.go -destination=mock/staff.go -package=mock
+
+// StaffFilter narrows down a listing of the staff directory
+type StaffFilter struct {
+	// Active keeps the stylists taking appointments, or the inactive ones when false
+	Active *bool
+	// Specialty keeps the stylists with this specialty
+	Specialty string
+	// TypeOfServiceID keeps the stylists who offer this type of service
+	TypeOfServiceID *uuid.UUID
+	Skip            uint64
+	Limit           uint64
+}
+
+// StaffRepository is an interface for interacting with staff-related data
+type StaffRepository interface {
+	// CreateStaff inserts a new staff member into the database, an admin has a single profile
+	CreateStaff(ctx context.Context, staff *domain.Staff) (*domain.Staff, error)
+	// GetStaffByID selects a staff member by id
+	GetStaffByID(ctx context.Context, id uuid.UUID) (*domain.Staff, error)
+	// GetStaffByUserID selects the staff member an admin account belongs to
+	GetStaffByUserID(ctx context.Context, userID uuid.UUID) (*domain.Staff, error)
+	// ListStaff selects a list of staff members ordered by name with pagination
+	ListStaff(ctx context.Context, filter StaffFilter) ([]domain.Staff, error)
+	// UpdateStaff updates the profile of a staff member, including its photo
+	UpdateStaff(ctx context.Context, staff *domain.Staff) (*domain.Staff, error)
+	// DeleteStaff deletes a staff member
+	DeleteStaff(ctx context.Context, id uuid.UUID) error
+}
+
+// StaffService is an interface for interacting with staff-related business logic
+type StaffService interface {
+	// CreateStaff creates the profile of an admin in the staff directory
+	CreateStaff(ctx context.Context, staff *domain.Staff) (*domain.Staff, error)
+	// GetStaff returns a staff member by id
+	GetStaff(ctx context.Context, id uuid.UUID) (*domain.Staff, error)
+	// ListStaff returns a list of staff members with pagination
+	ListStaff(ctx context.Context, filter StaffFilter) ([]domain.Staff, error)
+	// UpdateStaff updates the profile of a staff member
+	UpdateStaff(ctx context.Context, staff *domain.Staff) (*domain.Staff, error)
+	// DeleteStaff deletes a staff member and its photo
+	DeleteStaff(ctx context.Context, id uuid.UUID) error
+	// SetStaffPhoto uploads the photo of a staff member, replacing the previous one
+	SetStaffPhoto(ctx context.Context, id uuid.UUID, file io.Reader, size int64, fileName string) (*domain.Staff, error)
+	// GetStaffPhoto returns a staff member along with the contents of its photo
+	GetStaffPhoto(ctx context.Context, id uuid.UUID) (*domain.Staff, []byte, error)
+}
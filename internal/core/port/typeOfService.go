@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"io"
 
 	"harajuku/backend/internal/core/domain"
 
@@ -10,6 +11,20 @@ import (
 
 //go:generate mockgen -source=type_of_service.go -destination=mock/type_of_service.go -package=mock
 
+// TypeOfServiceFilter narrows down a listing of types of service
+type TypeOfServiceFilter struct {
+	CategoryID      *uuid.UUID
+	IncludeArchived bool
+	// IncludeDeleted lists soft-deleted types of service too, for admins
+	IncludeDeleted bool
+	// Name matches types of service whose name contains it, case insensitive
+	Name string
+	// PriceOrder sorts by price, "asc" or "desc"; results are sorted by name otherwise
+	PriceOrder string
+	Skip       uint64
+	Limit      uint64
+}
+
 // TypeOfServiceRepository is an interface for interacting with type-of-service-related data
 type TypeOfServiceRepository interface {
 	// CreateTypeOfService inserts a new type of service into the database
@@ -17,9 +32,13 @@ type TypeOfServiceRepository interface {
 	// GetTypeOfServiceByID selects a type of service by id
 	GetTypeOfServiceByID(ctx context.Context, id uuid.UUID) (*domain.TypeOfService, error)
 	// ListTypeOfServices selects a list of types of service with pagination
-	ListTypeOfServices(ctx context.Context, skip, limit uint64) ([]domain.TypeOfService, error)
+	ListTypeOfServices(ctx context.Context, filter TypeOfServiceFilter) ([]domain.TypeOfService, error)
+	// CountTypeOfServices counts the types of service matching the filter, ignoring pagination
+	CountTypeOfServices(ctx context.Context, filter TypeOfServiceFilter) (uint64, error)
 	// UpdateTypeOfService updates a type of service
 	UpdateTypeOfService(ctx context.Context, service *domain.TypeOfService) (*domain.TypeOfService, error)
+	// SetTypeOfServiceArchived sets the archived flag of a type of service
+	SetTypeOfServiceArchived(ctx context.Context, id uuid.UUID, archived bool) (*domain.TypeOfService, error)
 	// DeleteTypeOfService deletes a type of service
 	DeleteTypeOfService(ctx context.Context, id uuid.UUID) error
 }
@@ -30,10 +49,22 @@ type TypeOfServiceService interface {
 	CreateTypeOfService(ctx context.Context, service *domain.TypeOfService) (*domain.TypeOfService, error)
 	// GetTypeOfService returns a type of service by id
 	GetTypeOfService(ctx context.Context, id uuid.UUID) (*domain.TypeOfService, error)
-	// ListTypeOfServices returns a list of types of service with pagination
-	ListTypeOfServices(ctx context.Context, skip, limit uint64) ([]domain.TypeOfService, error)
+	// ListTypeOfServices returns a page of types of service and the total number of matches
+	ListTypeOfServices(ctx context.Context, filter TypeOfServiceFilter) ([]domain.TypeOfService, uint64, error)
 	// UpdateTypeOfService updates a type of service
 	UpdateTypeOfService(ctx context.Context, service *domain.TypeOfService) (*domain.TypeOfService, error)
 	// DeleteTypeOfService deletes a type of service
 	DeleteTypeOfService(ctx context.Context, id uuid.UUID) error
+	// ArchiveTypeOfService hides or restores a type of service for new quotes
+	ArchiveTypeOfService(ctx context.Context, id uuid.UUID, archived bool) (*domain.TypeOfService, error)
+	// AddServiceOffering marks an admin as performing a type of service
+	AddServiceOffering(ctx context.Context, offering *domain.ServiceOffering) (*domain.ServiceOffering, error)
+	// RemoveServiceOffering stops an admin from performing a type of service
+	RemoveServiceOffering(ctx context.Context, offering *domain.ServiceOffering) error
+	// AddTypeOfServiceImage uploads a showcase image for a type of service
+	AddTypeOfServiceImage(ctx context.Context, typeOfServiceID uuid.UUID, file io.Reader, size int64, fileName string) (*domain.TypeOfServiceImage, error)
+	// GetTypeOfServiceImage returns a showcase image and its file contents
+	GetTypeOfServiceImage(ctx context.Context, id uuid.UUID) (*domain.TypeOfServiceImage, []byte, error)
+	// DeleteTypeOfServiceImage deletes a showcase image
+	DeleteTypeOfServiceImage(ctx context.Context, id uuid.UUID) error
 }
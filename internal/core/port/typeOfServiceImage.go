@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=typeOfServiceImage.go -destination=mock/typeOfServiceImage.go -package=mock
+
+// TypeOfServiceImageRepository is an interface for interacting with type-of-service-image-related data
+type TypeOfServiceImageRepository interface {
+	// CreateTypeOfServiceImage inserts a new type of service image into the database
+	CreateTypeOfServiceImage(ctx context.Context, image *domain.TypeOfServiceImage) (*domain.TypeOfServiceImage, error)
+	// GetTypeOfServiceImageByID selects a type of service image by its ID
+	GetTypeOfServiceImageByID(ctx context.Context, id uuid.UUID) (*domain.TypeOfServiceImage, error)
+	// ListTypeOfServiceImages selects the images of the given types of service
+	ListTypeOfServiceImages(ctx context.Context, typeOfServiceIDs []uuid.UUID) ([]domain.TypeOfServiceImage, error)
+	// DeleteTypeOfServiceImage deletes a type of service image by ID
+	DeleteTypeOfServiceImage(ctx context.Context, id uuid.UUID) error
+}
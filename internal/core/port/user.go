@@ -20,10 +20,16 @@ type UserRepository interface {
 	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
 	// GetAdminsEmail returns an array containing all the emails of amdmin users
 	GetAdminsEmails(ctx context.Context) ([]string, error)
+	// GetAdmins returns every admin user
+	GetAdmins(ctx context.Context) ([]domain.User, error)
 	// ListUsers selects a list of users with pagination
 	ListUsers(ctx context.Context, skip, limit uint64, filters domain.UserFilters) ([]domain.User, error)
+	// CountUsers counts the users matching the filters, ignoring pagination
+	CountUsers(ctx context.Context, filters domain.UserFilters) (uint64, error)
 	// UpdateUser updates a user
 	UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error)
+	// UpdateNotificationPreferences replaces the phone and notification preferences of a user
+	UpdateNotificationPreferences(ctx context.Context, user *domain.User) (*domain.User, error)
 	// DeleteUser deletes a user
 	DeleteUser(ctx context.Context, id uuid.UUID) error
 }
@@ -34,10 +40,12 @@ type UserService interface {
 	Register(ctx context.Context, user *domain.User) (*domain.User, error)
 	// GetUser returns a user by id
 	GetUser(ctx context.Context, id uuid.UUID) (*domain.User, error)
-	// ListUsers returns a list of users with pagination
-	ListUsers(ctx context.Context, skip, limit uint64, filters domain.UserFilters) ([]domain.User, error)
+	// ListUsers returns a page of users and the total number of matches
+	ListUsers(ctx context.Context, skip, limit uint64, filters domain.UserFilters) ([]domain.User, uint64, error)
 	// UpdateUser updates a user
 	UpdateUser(ctx context.Context, user *domain.User) (*domain.User, error)
+	// UpdateNotificationPreferences sets the phone of a user and the channels they are notified through
+	UpdateNotificationPreferences(ctx context.Context, id uuid.UUID, phone string, preferences domain.NotificationPreferences) (*domain.User, error)
 	// DeleteUser deletes a user
 	DeleteUser(ctx context.Context, id uuid.UUID) error
 }
@@ -0,0 +1,49 @@
+package port
+
+import (
+	"context"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=webhook.go -destination=mock/webhook.go -package=mock
+
+// WebhookRepository is an interface for interacting with webhook subscription data
+type WebhookRepository interface {
+	// CreateWebhook inserts a new webhook into the database
+	CreateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error)
+	// GetWebhookByID selects a webhook by id
+	GetWebhookByID(ctx context.Context, id uuid.UUID) (*domain.Webhook, error)
+	// ListWebhooks selects a list of webhooks with pagination
+	ListWebhooks(ctx context.Context, skip, limit uint64) ([]domain.Webhook, error)
+	// ListActiveWebhooks selects the active webhooks subscribed to eventType
+	ListActiveWebhooks(ctx context.Context, eventType domain.EventType) ([]domain.Webhook, error)
+	// UpdateWebhook updates a webhook
+	UpdateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error)
+	// DeleteWebhook deletes a webhook
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+}
+
+// WebhookSender is an interface for posting an event to a webhook
+type WebhookSender interface {
+	// Send posts the event to the webhook URL signed with its secret, failing when it is not accepted
+	Send(ctx context.Context, webhook *domain.Webhook, event domain.Event) error
+}
+
+// WebhookService is an interface for managing webhooks and delivering events to them
+type WebhookService interface {
+	// CreateWebhook creates a new webhook, generating its secret when none is given
+	CreateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error)
+	// GetWebhook returns a webhook by id
+	GetWebhook(ctx context.Context, id uuid.UUID) (*domain.Webhook, error)
+	// ListWebhooks returns a list of webhooks with pagination
+	ListWebhooks(ctx context.Context, skip, limit uint64) ([]domain.Webhook, error)
+	// UpdateWebhook updates a webhook
+	UpdateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error)
+	// DeleteWebhook deletes a webhook
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	// Run delivers published events to the webhooks subscribed to them until ctx is done
+	Run(ctx context.Context)
+}
@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"log"
 	"log/slog"
 
 	"harajuku/backend/internal/core/domain"
@@ -15,19 +14,29 @@ import (
 // AvailabilitySlotService implementa la interfaz port.AvailabilitySlotService
 // y proporciona acceso al repositorio de availability slot y al servicio de caché
 type AppointmentService struct {
-	repo  port.AppointmentRepository
-	quote port.QuoteRepository
-	slot  port.AvailabilitySlotRepository
-	cache port.CacheRepository
+	repo         port.AppointmentRepository
+	quote        port.QuoteRepository
+	slot         port.AvailabilitySlotRepository
+	offering     port.ServiceOfferingRepository
+	user         port.UserRepository
+	notification port.NotificationService
+	events       port.EventPublisher
+	eventStore   port.EventStoreRepository
+	cache        *CachedRepository[domain.Appointment]
 }
 
 // NewAppointmentService crea una nueva instancia del servicio Appointment
-func NewAppointmentService(repo port.AppointmentRepository, quote port.QuoteRepository, slot port.AvailabilitySlotRepository, cache port.CacheRepository) *AppointmentService {
+func NewAppointmentService(repo port.AppointmentRepository, quote port.QuoteRepository, slot port.AvailabilitySlotRepository, offering port.ServiceOfferingRepository, user port.UserRepository, notification port.NotificationService, events port.EventPublisher, eventStore port.EventStoreRepository, cache port.CacheRepository) *AppointmentService {
 	return &AppointmentService{
 		repo,
 		quote,
 		slot,
-		cache,
+		offering,
+		user,
+		notification,
+		events,
+		eventStore,
+		NewCachedRepository[domain.Appointment](cache, "appointment", "appointments"),
 	}
 }
 
@@ -70,13 +79,24 @@ func (as *AppointmentService) CreateAppointment(ctx context.Context, appointment
 		return nil, domain.ErrForbidenAppointment
 	}
 
+	// El admin del slot debe ofrecer el tipo de servicio cotizado
+	offered, err := as.offering.IsServiceOffered(ctx, slot.AdminID, quote.TypeOfServiceID)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to check service offering", "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	if !offered {
+		return nil, domain.ErrServiceNotOffered
+	}
+
 	if quote.State == domain.QuoteRequiresProof {
 		appointment.Status = domain.Booked
 		// Marcar el slot availability como booked
 		slot.IsBooked = true
 		_, err = as.slot.UpdateAvailabilitySlot(ctx, slot)
 		if err != nil {
-			slog.Error("Failed to update slot availability", "error", err)
+			slog.ErrorContext(ctx, "Failed to update slot availability", "error", err)
 			return nil, domain.ErrInternal
 		}
 
@@ -86,7 +106,7 @@ func (as *AppointmentService) CreateAppointment(ctx context.Context, appointment
 
 	createdAppointment, err := as.repo.CreateAppointment(ctx, appointment)
 	if err != nil {
-		slog.Error("Appointment creation failed", "error", err)
+		slog.ErrorContext(ctx, "Appointment creation failed", "error", err)
 		if err == domain.ErrConflictingData {
 			return nil, err
 		}
@@ -94,20 +114,13 @@ func (as *AppointmentService) CreateAppointment(ctx context.Context, appointment
 	}
 
 	// Cache del appointment creado
-	cacheKey := util.GenerateCacheKey("appointment", createdAppointment.ID)
-	appointmentSerialized, err := util.Serialize(createdAppointment)
+	err = as.cache.Store(ctx, createdAppointment.ID, createdAppointment)
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, err
 	}
 
-	err = as.cache.Set(ctx, cacheKey, appointmentSerialized, 0)
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
-
-	err = as.cache.DeleteByPrefix(ctx, "appointments:*")
-	if err != nil {
-		return nil, domain.ErrInternal
+	if createdAppointment.Status == domain.Booked {
+		as.notifyConfirmed(ctx, createdAppointment, slot)
 	}
 
 	return createdAppointment, nil
@@ -115,21 +128,9 @@ func (as *AppointmentService) CreateAppointment(ctx context.Context, appointment
 
 // GetAppointment obtiene un availability appointment por ID
 func (as *AppointmentService) GetAppointment(ctx context.Context, id uuid.UUID) (*domain.Appointment, error) {
-	var appointment *domain.Appointment
-
-	// Revisar la caché primero
-	cacheKey := util.GenerateCacheKey("appointment", id)
-	cachedappointment, err := as.cache.Get(ctx, cacheKey)
-	if err == nil {
-		err := util.Deserialize(cachedappointment, &appointment)
-		if err != nil {
-			return nil, domain.ErrInternal
-		}
-		return appointment, nil
-	}
-
-	// Obtener del repositorio si no está en caché
-	appointment, err = as.repo.GetAppointmentByID(ctx, id)
+	appointment, err := as.cache.Get(ctx, id, func() (*domain.Appointment, error) {
+		return as.repo.GetAppointmentByID(ctx, id)
+	})
 	if err != nil {
 		if err == domain.ErrDataNotFound {
 			return nil, err
@@ -137,24 +138,11 @@ func (as *AppointmentService) GetAppointment(ctx context.Context, id uuid.UUID)
 		return nil, domain.ErrInternal
 	}
 
-	// Cache del appointment
-	appointmentSerialized, err := util.Serialize(appointment)
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
-
-	err = as.cache.Set(ctx, cacheKey, appointmentSerialized, 0)
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
-
 	return appointment, nil
 }
 
 // ListAppointments lista todos los availability appointments con opciones de filtrado
-func (as *AppointmentService) ListAppointments(ctx context.Context, filter port.AppointmentFilter) ([]domain.Appointment, error) {
-	var appointments []domain.Appointment
-
+func (as *AppointmentService) ListAppointments(ctx context.Context, filter port.AppointmentFilter) ([]domain.Appointment, uint64, *domain.Cursor, error) {
 	params := util.GenerateCacheKeyParams(
 		filter.CustomerID,
 		filter.StartDate,
@@ -162,37 +150,28 @@ func (as *AppointmentService) ListAppointments(ctx context.Context, filter port.
 		filter.ByState,
 		filter.Skip,
 		filter.Limit,
+		filter.After,
+		filter.IncludeDeleted,
 	)
-	cacheKey := util.GenerateCacheKey("appointments", params)
 
-	// Revisar la caché primero
-	cachedappointments, err := as.cache.Get(ctx, cacheKey)
-	if err == nil {
-		err := util.Deserialize(cachedappointments, &appointments)
+	appointments, total, next, err := as.cache.ListCursorPage(ctx, params, func() ([]domain.Appointment, uint64, *domain.Cursor, error) {
+		appointments, next, err := as.repo.ListAppointments(ctx, filter)
 		if err != nil {
-			return nil, domain.ErrInternal
+			return nil, 0, nil, err
 		}
-		return appointments, nil
-	}
 
-	// Obtener del repositorio si no está en caché
-	appointments, err = as.repo.ListAppointments(ctx, filter)
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
-
-	// Cache de los appointments
-	appointmentsSerialized, err := util.Serialize(appointments)
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
+		total, err := as.repo.CountAppointments(ctx, filter)
+		if err != nil {
+			return nil, 0, nil, err
+		}
 
-	err = as.cache.Set(ctx, cacheKey, appointmentsSerialized, 0)
+		return appointments, total, next, nil
+	})
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, 0, nil, domain.ErrInternal
 	}
 
-	return appointments, nil
+	return appointments, total, next, nil
 }
 
 // UpdateAppointment actualiza los datos de un availability appointment
@@ -205,6 +184,13 @@ func (as *AppointmentService) UpdateAppointment(ctx context.Context, appointment
 		return nil, domain.ErrInternal
 	}
 
+	// Sin versión se actualiza sobre la última leída; con versión, debe coincidir con la actual
+	if appointment.Version == 0 {
+		appointment.Version = existingAppointment.Version
+	} else if appointment.Version != existingAppointment.Version {
+		return nil, domain.ErrConflictingData
+	}
+
 	//slot validation
 
 	slot, err := as.slot.GetAvailabilitySlotByID(ctx, appointment.SlotID)
@@ -212,7 +198,7 @@ func (as *AppointmentService) UpdateAppointment(ctx context.Context, appointment
 		if err == domain.ErrDataNotFound {
 			return nil, err
 		}
-		log.Printf("error al buscar slot")
+		slog.ErrorContext(ctx, "Failed to get appointment slot", "slot_id", appointment.SlotID, "error", err)
 		return nil, domain.ErrInternal
 	}
 
@@ -236,35 +222,140 @@ func (as *AppointmentService) UpdateAppointment(ctx context.Context, appointment
 		return nil, domain.ErrNoUpdatedData
 	}
 
+	// Al cambiar de slot o de cotización, el admin del slot debe seguir ofreciendo el servicio
+	quoteID := appointment.QuoteID
+	if quoteID == zeroUUID {
+		quoteID = existingAppointment.QuoteID
+	}
+
+	if existingAppointment.SlotID != appointment.SlotID || existingAppointment.QuoteID != quoteID {
+		quote, err := as.quote.GetQuoteByID(ctx, quoteID)
+		if err != nil {
+			if err == domain.ErrDataNotFound {
+				return nil, err
+			}
+			return nil, domain.ErrInternal
+		}
+
+		offered, err := as.offering.IsServiceOffered(ctx, slot.AdminID, quote.TypeOfServiceID)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to check service offering", "error", err)
+			return nil, domain.ErrInternal
+		}
+
+		if !offered {
+			return nil, domain.ErrServiceNotOffered
+		}
+	}
+
 	// Actualizar el appointment
 	_, err = as.repo.UpdateAppointment(ctx, appointment)
 	if err != nil {
+		if err == domain.ErrConflictingData {
+			return nil, err
+		}
 		return nil, domain.ErrInternal
 	}
 
 	// Cache del appointment actualizado
-	cacheKey := util.GenerateCacheKey("appointment", appointment.ID)
+	err = as.cache.Store(ctx, appointment.ID, appointment)
+	if err != nil {
+		return nil, err
+	}
+
+	if appointment.Status == domain.Booked && existingAppointment.Status != domain.Booked {
+		as.notifyConfirmed(ctx, appointment, slot)
+	}
 
-	err = as.cache.Delete(ctx, cacheKey)
+	return appointment, nil
+}
+
+// notifyConfirmed guarda y publica la reserva de la cita y envía al cliente su confirmación (best-effort)
+func (as *AppointmentService) notifyConfirmed(ctx context.Context, appointment *domain.Appointment, slot *domain.AvailabilitySlot) {
+	event := domain.NewEvent(domain.EventAppointmentBooked, domain.AppointmentBookedEvent{
+		AppointmentID: appointment.ID,
+		QuoteID:       appointment.QuoteID,
+		ClientID:      appointment.UserID,
+		StartTime:     slot.StartTime,
+		EndTime:       slot.EndTime,
+	})
+	event.SalonID = appointment.Salon()
+
+	// La cita ya está guardada, si el evento no se puede registrar solo queda en el log
+	stored, err := domain.NewStoredEvent(event)
+	if err == nil {
+		err = as.eventStore.AppendEvents(ctx, stored)
+	}
 	if err != nil {
-		return nil, domain.ErrInternal
+		slog.ErrorContext(ctx, "could not store appointment event", "appointment_id", appointment.ID, "event_id", event.ID, "error", err)
 	}
+	as.events.Publish(ctx, event)
 
-	appointmentSerialized, err := util.Serialize(appointment)
+	client, err := as.user.GetUserByID(ctx, appointment.UserID)
 	if err != nil {
+		slog.WarnContext(ctx, "could not fetch appointment client", "appointment_id", appointment.ID, "error", err)
+		return
+	}
+
+	notify(ctx, as.notification, []domain.User{*client}, domain.EmailAppointmentConfirmed, domain.AppointmentConfirmedEmail{
+		AppointmentID: appointment.ID,
+		QuoteID:       appointment.QuoteID,
+		ClientName:    fullName(client),
+		StartTime:     slot.StartTime,
+		EndTime:       slot.EndTime,
+	}, "appointment_id", appointment.ID)
+}
+
+// CancelAppointment cancela una cita pendiente o reservada y libera su slot para otra reserva.
+// Solo el cliente de la cita o un admin pueden cancelarla
+func (as *AppointmentService) CancelAppointment(ctx context.Context, id uuid.UUID, requester *domain.TokenPayload) (*domain.Appointment, error) {
+	appointment, err := as.repo.GetAppointmentByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
 		return nil, domain.ErrInternal
 	}
 
-	err = as.cache.Set(ctx, cacheKey, appointmentSerialized, 0)
+	if requester.Role != domain.Admin && appointment.UserID != requester.UserID {
+		return nil, domain.ErrForbidden
+	}
+
+	if appointment.Status == domain.Cancelled || appointment.Status == domain.Completed {
+		return nil, domain.ErrConflictingData
+	}
+
+	appointment.Status = domain.Cancelled
+	_, err = as.repo.UpdateAppointment(ctx, appointment)
 	if err != nil {
+		if err == domain.ErrConflictingData {
+			return nil, err
+		}
 		return nil, domain.ErrInternal
 	}
 
-	err = as.cache.DeleteByPrefix(ctx, "appointments:*")
+	// Liberar el slot availability
+	slot, err := as.slot.GetAvailabilitySlotByID(ctx, appointment.SlotID)
 	if err != nil {
+		slog.ErrorContext(ctx, "Failed to get slot of cancelled appointment", "appointment_id", appointment.ID, "error", err)
 		return nil, domain.ErrInternal
 	}
 
+	if slot.IsBooked {
+		slot.IsBooked = false
+		_, err = as.slot.UpdateAvailabilitySlot(ctx, slot)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to release slot availability", "appointment_id", appointment.ID, "error", err)
+			return nil, domain.ErrInternal
+		}
+	}
+
+	// Cache del appointment cancelado
+	err = as.cache.Store(ctx, appointment.ID, appointment)
+	if err != nil {
+		return nil, err
+	}
+
 	return appointment, nil
 }
 
@@ -279,16 +370,9 @@ func (as *AppointmentService) DeleteAppointment(ctx context.Context, id uuid.UUI
 	}
 
 	// Eliminar de la caché
-	cacheKey := util.GenerateCacheKey("appointment", id)
-
-	err = as.cache.Delete(ctx, cacheKey)
-	if err != nil {
-		return domain.ErrInternal
-	}
-
-	err = as.cache.DeleteByPrefix(ctx, "appointments:*")
+	err = as.cache.Invalidate(ctx, id)
 	if err != nil {
-		return domain.ErrInternal
+		return err
 	}
 
 	// Eliminar del repositorio
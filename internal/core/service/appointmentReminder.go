@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+)
+
+// AppointmentReminderService implementa la interfaz port.AppointmentReminderService
+// y notifica a cada cliente una vez, lead antes de que empiece su cita
+type AppointmentReminderService struct {
+	repo         port.AppointmentRepository
+	slot         port.AvailabilitySlotRepository
+	user         port.UserRepository
+	notification port.NotificationService
+	lead         time.Duration
+}
+
+// NewAppointmentReminderService crea una nueva instancia del servicio de recordatorios
+func NewAppointmentReminderService(repo port.AppointmentRepository, slot port.AvailabilitySlotRepository, user port.UserRepository, notification port.NotificationService, lead time.Duration) *AppointmentReminderService {
+	return &AppointmentReminderService{
+		repo,
+		slot,
+		user,
+		notification,
+		lead,
+	}
+}
+
+// RemindDue notifica las citas reservadas que empiezan dentro de lead, el scheduler lo ejecuta periódicamente
+func (rs *AppointmentReminderService) RemindDue(ctx context.Context) error {
+	appointments, err := rs.repo.ListDueReminders(ctx, time.Now().Add(rs.lead))
+	if err != nil {
+		return fmt.Errorf("listing due reminders: %w", err)
+	}
+
+	for _, appointment := range appointments {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		rs.remind(ctx, &appointment)
+	}
+
+	return nil
+}
+
+// remind notifica al cliente y marca la cita como recordada aunque algún canal falle,
+// un recordatorio repetido molesta más que uno perdido
+func (rs *AppointmentReminderService) remind(ctx context.Context, appointment *domain.Appointment) {
+	client, err := rs.user.GetUserByID(ctx, appointment.UserID)
+	if err != nil {
+		slog.WarnContext(ctx, "could not fetch appointment client", "appointment_id", appointment.ID, "error", err)
+		return
+	}
+
+	slot, err := rs.slot.GetAvailabilitySlotByID(ctx, appointment.SlotID)
+	if err != nil {
+		slog.WarnContext(ctx, "could not fetch appointment slot", "appointment_id", appointment.ID, "error", err)
+		return
+	}
+
+	notify(ctx, rs.notification, []domain.User{*client}, domain.EmailAppointmentReminder, domain.AppointmentReminderEmail{
+		AppointmentID: appointment.ID,
+		QuoteID:       appointment.QuoteID,
+		ClientName:    fullName(client),
+		StartTime:     slot.StartTime,
+		EndTime:       slot.EndTime,
+	}, "appointment_id", appointment.ID)
+
+	if err := rs.repo.MarkReminderSent(ctx, appointment.ID, time.Now()); err != nil {
+		slog.ErrorContext(ctx, "Marking reminder as sent failed", "appointment_id", appointment.ID, "error", err)
+	}
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/adapter/storage/cache"
+	"harajuku/backend/internal/adapter/storage/memory"
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAppointmentServiceNotOffered(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	offerings := memoryOfferings{}
+	quotes := memory.NewQuoteRepository(db)
+	slots := memory.NewAvailabilitySlotRepository(db)
+	svc := NewAppointmentService(memory.NewAppointmentRepository(db), quotes, slots, offerings, memory.NewUserRepository(db), nil, nil, nil, cache.NewMemory(100))
+
+	typeOfServiceID := uuid.New()
+	quote, err := quotes.CreateQuote(ctx, &domain.Quote{TypeOfServiceID: typeOfServiceID, ClientID: uuid.New(), Price: 500, State: domain.QuoteApproved})
+	require.NoError(t, err)
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	slot, err := slots.CreateAvailabilitySlot(ctx, &domain.AvailabilitySlot{AdminID: uuid.New(), StartTime: start, EndTime: start.Add(time.Hour)})
+	require.NoError(t, err)
+
+	// The admin of the slot has to perform the quoted type of service
+	_, err = svc.CreateAppointment(ctx, &domain.Appointment{UserID: quote.ClientID, SlotID: slot.ID, QuoteID: quote.ID})
+	assert.Equal(t, domain.ErrServiceNotOffered, err)
+
+	offerings[domain.ServiceOffering{AdminID: slot.AdminID, TypeOfServiceID: typeOfServiceID}] = true
+
+	appointment, err := svc.CreateAppointment(ctx, &domain.Appointment{UserID: quote.ClientID, SlotID: slot.ID, QuoteID: quote.ID})
+	require.NoError(t, err)
+	assert.Equal(t, domain.Pending, appointment.Status)
+}
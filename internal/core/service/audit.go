@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+)
+
+/**
+ * AuditService implements port.AuditService interface
+ * and lists the sensitive changes services recorded in the audit trail
+ */
+type AuditService struct {
+	repo port.AuditRepository
+}
+
+// NewAuditService creates a new audit service instance
+func NewAuditService(repo port.AuditRepository) *AuditService {
+	return &AuditService{
+		repo,
+	}
+}
+
+// ListAuditEntries lists the audited changes, newest first, and how many match the filter
+func (s *AuditService) ListAuditEntries(ctx context.Context, filter port.AuditEntryFilter) ([]domain.AuditEntry, uint64, error) {
+	entries, err := s.repo.ListAuditEntries(ctx, filter)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error listing audit entries", "error", err)
+		return nil, 0, domain.ErrInternal
+	}
+
+	total, err := s.repo.CountAuditEntries(ctx, filter)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error counting audit entries", "error", err)
+		return nil, 0, domain.ErrInternal
+	}
+
+	return entries, total, nil
+}
+
+// recordAudit stores the entry of a change that was already made, outside of a transaction. A failure is
+// logged with the entry, so the change can still be traced, and doesn't undo the change
+func recordAudit(ctx context.Context, repo port.AuditRepository, entry *domain.AuditEntry) {
+	if err := repo.CreateAuditEntry(ctx, entry); err != nil {
+		slog.ErrorContext(ctx, "Audit entry insertion failed", "entity", entry.Entity, "entity_id", entry.EntityID,
+			"action", entry.Action, "before", entry.Before, "after", entry.After, "error", err)
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+/**
+ * AuditLogService implements port.AuditLogService interface
+ * and records the requests users make to change data
+ */
+type AuditLogService struct {
+	repo port.AuditLogRepository
+}
+
+// NewAuditLogService creates a new audit log service instance
+func NewAuditLogService(repo port.AuditLogRepository) *AuditLogService {
+	return &AuditLogService{
+		repo,
+	}
+}
+
+// RecordAuditLog records a request that changed data
+func (s *AuditLogService) RecordAuditLog(ctx context.Context, log *domain.AuditLog) error {
+	log.ID = uuid.New()
+	log.CreatedAt = time.Now()
+
+	if err := s.repo.CreateAuditLog(ctx, log); err != nil {
+		slog.ErrorContext(ctx, "Audit log insertion failed", "actor_id", log.ActorID, "route", log.Route, "error", err)
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+// ListAuditLogs lists the audited requests, newest first
+func (s *AuditLogService) ListAuditLogs(ctx context.Context, filter port.AuditLogFilter) ([]domain.AuditLog, error) {
+	logs, err := s.repo.ListAuditLogs(ctx, filter)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryAudit is a port.AuditRepository keeping the entries in memory
+type memoryAudit struct {
+	entries []domain.AuditEntry
+	err     error
+}
+
+func (a *memoryAudit) CreateAuditEntry(ctx context.Context, entry *domain.AuditEntry) error {
+	if a.err != nil {
+		return a.err
+	}
+	a.entries = append(a.entries, *entry)
+	return nil
+}
+
+func (a *memoryAudit) ListAuditEntries(ctx context.Context, filter port.AuditEntryFilter) ([]domain.AuditEntry, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+
+	var entries []domain.AuditEntry
+	for _, entry := range a.entries {
+		if filter.EntityID == nil || entry.EntityID == *filter.EntityID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func (a *memoryAudit) CountAuditEntries(ctx context.Context, filter port.AuditEntryFilter) (uint64, error) {
+	entries, err := a.ListAuditEntries(ctx, filter)
+	return uint64(len(entries)), err
+}
+
+func TestRecordAudit(t *testing.T) {
+	admin := &domain.TokenPayload{UserID: uuid.New(), Role: domain.Admin}
+	serviceID := uuid.New()
+	audit := &memoryAudit{}
+
+	ctx := util.WithActor(context.Background(), admin)
+	recordAudit(ctx, audit, domain.NewAuditEntry(util.Actor(ctx), domain.AuditEntityTypeOfService, serviceID, domain.AuditPriceChanged,
+		map[string]any{"price": 450.0},
+		map[string]any{"price": 520.0},
+	))
+
+	// The changes the system makes on its own have no actor
+	recordAudit(context.Background(), audit, domain.NewAuditEntry(util.Actor(context.Background()), domain.AuditEntityUser, uuid.New(), domain.AuditRoleChanged, nil, nil))
+
+	svc := NewAuditService(audit)
+	entries, total, err := svc.ListAuditEntries(context.Background(), port.AuditEntryFilter{EntityID: &serviceID})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), total)
+	require.Len(t, entries, 1)
+	assert.Equal(t, domain.AuditPriceChanged, entries[0].Action)
+	assert.Equal(t, &admin.UserID, entries[0].ActorID)
+	assert.Equal(t, domain.Admin, entries[0].ActorRole)
+	assert.Equal(t, 520.0, entries[0].After["price"])
+
+	assert.Nil(t, audit.entries[1].ActorID)
+
+	// A failure doesn't fail the change it records, listing fails as any other read
+	audit.err = errors.New("connection refused")
+	recordAudit(ctx, audit, domain.NewAuditEntry(admin, domain.AuditEntityUser, uuid.New(), domain.AuditRoleChanged, nil, nil))
+	_, _, err = svc.ListAuditEntries(context.Background(), port.AuditEntryFilter{})
+	assert.Equal(t, domain.ErrInternal, err)
+}
@@ -12,45 +12,43 @@ import (
 )
 
 // AvailabilitySlotService implementa la interfaz port.AvailabilitySlotService
-// y proporciona acceso al repositorio de availability slot y al servicio de caché
+// y proporciona acceso al repositorio de availability slot, a sus citas, a los perfiles del personal y al servicio de caché
 type AvailabilitySlotService struct {
-	repo  port.AvailabilitySlotRepository
-	cache port.CacheRepository
+	repo        port.AvailabilitySlotRepository
+	appointment port.AppointmentRepository
+	staff       port.StaffRepository
+	cache       *CachedRepository[domain.AvailabilitySlot]
 }
 
 // NewAvailabilitySlotService crea una nueva instancia del servicio AvailabilitySlot
-func NewAvailabilitySlotService(repo port.AvailabilitySlotRepository, cache port.CacheRepository) *AvailabilitySlotService {
+func NewAvailabilitySlotService(repo port.AvailabilitySlotRepository, appointment port.AppointmentRepository, staff port.StaffRepository, cache port.CacheRepository) *AvailabilitySlotService {
 	return &AvailabilitySlotService{
 		repo,
-		cache,
+		appointment,
+		staff,
+		NewCachedRepository[domain.AvailabilitySlot](cache, "availabilitySlot", "availabilitySlots"),
 	}
 }
 
 func (as *AvailabilitySlotService) CreateAvailabilitySlot(ctx context.Context, slot *domain.AvailabilitySlot) (*domain.AvailabilitySlot, error) {
+	// Un estilista inactivo no recibe horarios nuevos, los admins sin perfil no se restringen
+	if _, err := activeStaff(ctx, as.staff, slot.AdminID); err != nil {
+		return nil, err
+	}
+
 	slot.ID = uuid.New()
 	slot.IsBooked = false
 
 	createdSlot, err := as.repo.CreateAvailabilitySlot(ctx, slot)
 	if err != nil {
-		slog.Error("AvailabilitySlot creation failed", "error", err)
+		slog.ErrorContext(ctx, "AvailabilitySlot creation failed", "error", err)
 		return nil, domain.ErrInternal
 	}
 
 	// Cache del slot creado
-	cacheKey := util.GenerateCacheKey("availabilitySlot", createdSlot.ID)
-	slotSerialized, err := util.Serialize(createdSlot)
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
-
-	err = as.cache.Set(ctx, cacheKey, slotSerialized, 0)
+	err = as.cache.Store(ctx, createdSlot.ID, createdSlot)
 	if err != nil {
-		return nil, domain.ErrInternal
-	}
-
-	err = as.cache.DeleteByPrefix(ctx, "availabilitySlots:*")
-	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, err
 	}
 
 	return createdSlot, nil
@@ -58,21 +56,9 @@ func (as *AvailabilitySlotService) CreateAvailabilitySlot(ctx context.Context, s
 
 // GetAvailabilitySlot obtiene un availability slot por ID
 func (as *AvailabilitySlotService) GetAvailabilitySlot(ctx context.Context, id uuid.UUID) (*domain.AvailabilitySlot, error) {
-	var slot *domain.AvailabilitySlot
-
-	// Revisar la caché primero
-	cacheKey := util.GenerateCacheKey("availabilitySlot", id)
-	cachedSlot, err := as.cache.Get(ctx, cacheKey)
-	if err == nil {
-		err := util.Deserialize(cachedSlot, &slot)
-		if err != nil {
-			return nil, domain.ErrInternal
-		}
-		return slot, nil
-	}
-
-	// Obtener del repositorio si no está en caché
-	slot, err = as.repo.GetAvailabilitySlotByID(ctx, id)
+	slot, err := as.cache.Get(ctx, id, func() (*domain.AvailabilitySlot, error) {
+		return as.repo.GetAvailabilitySlotByID(ctx, id)
+	})
 	if err != nil {
 		if err == domain.ErrDataNotFound {
 			return nil, err
@@ -80,24 +66,11 @@ func (as *AvailabilitySlotService) GetAvailabilitySlot(ctx context.Context, id u
 		return nil, domain.ErrInternal
 	}
 
-	// Cache del slot
-	slotSerialized, err := util.Serialize(slot)
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
-
-	err = as.cache.Set(ctx, cacheKey, slotSerialized, 0)
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
-
 	return slot, nil
 }
 
 // ListAvailabilitySlots lista todos los availability slots con opciones de filtrado
-func (as *AvailabilitySlotService) ListAvailabilitySlots(ctx context.Context, filter port.AvailabilitySlotFilter) ([]domain.AvailabilitySlot, error) {
-	var slots []domain.AvailabilitySlot
-
+func (as *AvailabilitySlotService) ListAvailabilitySlots(ctx context.Context, filter port.AvailabilitySlotFilter) ([]domain.AvailabilitySlot, uint64, *domain.Cursor, error) {
 	params := util.GenerateCacheKeyParams(
 		filter.UserID,
 		filter.StartDate,
@@ -105,37 +78,28 @@ func (as *AvailabilitySlotService) ListAvailabilitySlots(ctx context.Context, fi
 		filter.ByState,
 		filter.Skip,
 		filter.Limit,
+		filter.After,
+		filter.IncludeDeleted,
 	)
-	cacheKey := util.GenerateCacheKey("availabilitySlots", params)
 
-	// Revisar la caché primero
-	cachedSlots, err := as.cache.Get(ctx, cacheKey)
-	if err == nil {
-		err := util.Deserialize(cachedSlots, &slots)
+	slots, total, next, err := as.cache.ListCursorPage(ctx, params, func() ([]domain.AvailabilitySlot, uint64, *domain.Cursor, error) {
+		slots, next, err := as.repo.ListAvailabilitySlots(ctx, filter)
 		if err != nil {
-			return nil, domain.ErrInternal
+			return nil, 0, nil, err
 		}
-		return slots, nil
-	}
-
-	// Obtener del repositorio si no está en caché
-	slots, err = as.repo.ListAvailabilitySlots(ctx, filter)
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
 
-	// Cache de los slots
-	slotsSerialized, err := util.Serialize(slots)
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
+		total, err := as.repo.CountAvailabilitySlots(ctx, filter)
+		if err != nil {
+			return nil, 0, nil, err
+		}
 
-	err = as.cache.Set(ctx, cacheKey, slotsSerialized, 0)
+		return slots, total, next, nil
+	})
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, 0, nil, domain.ErrInternal
 	}
 
-	return slots, nil
+	return slots, total, next, nil
 }
 
 // UpdateAvailabilitySlot actualiza los datos de un availability slot
@@ -148,6 +112,13 @@ func (as *AvailabilitySlotService) UpdateAvailabilitySlot(ctx context.Context, s
 		return nil, domain.ErrInternal
 	}
 
+	// Sin versión se actualiza sobre la última leída; con versión, debe coincidir con la actual
+	if slot.Version == 0 {
+		slot.Version = existingSlot.Version
+	} else if slot.Version != existingSlot.Version {
+		return nil, domain.ErrConflictingData
+	}
+
 	// Revisar si los datos son los mismos (sin actualización)
 	if existingSlot.StartTime == slot.StartTime && existingSlot.EndTime == slot.EndTime && existingSlot.IsBooked == slot.IsBooked {
 		return nil, domain.ErrNoUpdatedData
@@ -156,30 +127,16 @@ func (as *AvailabilitySlotService) UpdateAvailabilitySlot(ctx context.Context, s
 	// Actualizar el slot
 	_, err = as.repo.UpdateAvailabilitySlot(ctx, slot)
 	if err != nil {
+		if err == domain.ErrConflictingData {
+			return nil, err
+		}
 		return nil, domain.ErrInternal
 	}
 
 	// Cache del slot actualizado
-	cacheKey := util.GenerateCacheKey("availabilitySlot", slot.ID)
-
-	err = as.cache.Delete(ctx, cacheKey)
+	err = as.cache.Store(ctx, slot.ID, slot)
 	if err != nil {
-		return nil, domain.ErrInternal
-	}
-
-	slotSerialized, err := util.Serialize(slot)
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
-
-	err = as.cache.Set(ctx, cacheKey, slotSerialized, 0)
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
-
-	err = as.cache.DeleteByPrefix(ctx, "availabilitySlots:*")
-	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, err
 	}
 
 	return slot, nil
@@ -195,17 +152,19 @@ func (as *AvailabilitySlotService) DeleteAvailabilitySlot(ctx context.Context, i
 		return domain.ErrInternal
 	}
 
-	// Eliminar de la caché
-	cacheKey := util.GenerateCacheKey("availabilitySlot", id)
-
-	err = as.cache.Delete(ctx, cacheKey)
+	// Un slot con una cita vigente no se elimina
+	booked, err := as.appointment.CountAppointments(ctx, port.AppointmentFilter{SlotID: &id})
 	if err != nil {
 		return domain.ErrInternal
 	}
+	if booked > 0 {
+		return domain.ErrConflictingData
+	}
 
-	err = as.cache.DeleteByPrefix(ctx, "availabilitySlots:*")
+	// Eliminar de la caché
+	err = as.cache.Invalidate(ctx, id)
 	if err != nil {
-		return domain.ErrInternal
+		return err
 	}
 
 	// Eliminar del repositorio
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/adapter/storage/cache"
+	"harajuku/backend/internal/adapter/storage/memory"
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteAvailabilitySlotWithAppointment(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	appointments := memory.NewAppointmentRepository(db)
+	svc := NewAvailabilitySlotService(memory.NewAvailabilitySlotRepository(db), appointments, memoryStaff{}, cache.NewMemory(100))
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	slot, err := svc.CreateAvailabilitySlot(ctx, &domain.AvailabilitySlot{AdminID: uuid.New(), StartTime: start, EndTime: start.Add(time.Hour)})
+	require.NoError(t, err)
+
+	appointment, err := appointments.CreateAppointment(ctx, &domain.Appointment{UserID: uuid.New(), SlotID: slot.ID, QuoteID: uuid.New(), Status: domain.Booked})
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.ErrConflictingData, svc.DeleteAvailabilitySlot(ctx, slot.ID))
+
+	// Once its appointment is deleted the slot can go
+	require.NoError(t, appointments.DeleteAppointment(ctx, appointment.ID))
+	require.NoError(t, svc.DeleteAvailabilitySlot(ctx, slot.ID))
+
+	_, err = svc.GetAvailabilitySlot(ctx, slot.ID)
+	assert.Equal(t, domain.ErrDataNotFound, err)
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+)
+
+/**
+ * CachedRepository is a read-through cache in front of the repository of T,
+ * single values are cached under "<entity>:<id>" and listings under "<list>:<params>",
+ * tagged with list so every listing is dropped together when a value changes.
+ * Listings made in a salon are cached apart from those of the others, and a cached
+ * value of another salon is not found, like the repositories do
+ */
+type CachedRepository[T any] struct {
+	cache  port.CacheRepository
+	entity string
+	list   string
+}
+
+// NewCachedRepository creates a new read-through cache of T
+func NewCachedRepository[T any](cache port.CacheRepository, entity, list string) *CachedRepository[T] {
+	return &CachedRepository[T]{
+		cache,
+		entity,
+		list,
+	}
+}
+
+// Get returns the value cached under id, loading and caching it on a miss.
+// Errors of load are returned as they are so callers keep mapping them
+func (c *CachedRepository[T]) Get(ctx context.Context, id any, load func() (*T, error)) (*T, error) {
+	cacheKey := util.GenerateCacheKey(c.entity, id)
+
+	var value *T
+	if c.read(ctx, cacheKey, &value) && value != nil {
+		if scoped, ok := any(value).(domain.SalonScoped); ok && !util.InSalon(ctx, scoped) {
+			return nil, domain.ErrDataNotFound
+		}
+		return value, nil
+	}
+
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.write(ctx, cacheKey, value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// List returns the listing cached under params, loading and caching it on a miss
+func (c *CachedRepository[T]) List(ctx context.Context, params any, load func() ([]T, error)) ([]T, error) {
+	cacheKey := c.listKey(ctx, params)
+
+	var values []T
+	if c.read(ctx, cacheKey, &values) {
+		return values, nil
+	}
+
+	values, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.write(ctx, cacheKey, values, c.list); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// cachedPage is the cached form of a listing page, the total of its listing and the cursor of the next page
+type cachedPage[T any] struct {
+	Items []T
+	Total uint64
+	Next  *domain.Cursor `json:",omitempty"`
+}
+
+// ListPage returns the listing page and total cached under params, loading and caching them on a miss
+func (c *CachedRepository[T]) ListPage(ctx context.Context, params any, load func() ([]T, uint64, error)) ([]T, uint64, error) {
+	cacheKey := c.listKey(ctx, params)
+
+	var page cachedPage[T]
+	if c.read(ctx, cacheKey, &page) {
+		return page.Items, page.Total, nil
+	}
+
+	values, total, err := load()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := c.write(ctx, cacheKey, cachedPage[T]{values, total, nil}, c.list); err != nil {
+		return nil, 0, err
+	}
+
+	return values, total, nil
+}
+
+// ListCursorPage is ListPage for listings paginated with a cursor, caching the cursor of the next page too
+func (c *CachedRepository[T]) ListCursorPage(ctx context.Context, params any, load func() ([]T, uint64, *domain.Cursor, error)) ([]T, uint64, *domain.Cursor, error) {
+	cacheKey := c.listKey(ctx, params)
+
+	var page cachedPage[T]
+	if c.read(ctx, cacheKey, &page) {
+		return page.Items, page.Total, page.Next, nil
+	}
+
+	values, total, next, err := load()
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	if err := c.write(ctx, cacheKey, cachedPage[T]{values, total, next}, c.list); err != nil {
+		return nil, 0, nil, err
+	}
+
+	return values, total, next, nil
+}
+
+// Store caches the value under id and drops the listings, which may include it
+func (c *CachedRepository[T]) Store(ctx context.Context, id any, value *T) error {
+	if err := c.write(ctx, util.GenerateCacheKey(c.entity, id), value); err != nil {
+		return err
+	}
+	return c.InvalidateLists(ctx)
+}
+
+// Invalidate drops the value cached under id and the listings
+func (c *CachedRepository[T]) Invalidate(ctx context.Context, id any) error {
+	if err := c.cache.Delete(ctx, util.GenerateCacheKey(c.entity, id)); err != nil {
+		return domain.ErrInternal
+	}
+	return c.InvalidateLists(ctx)
+}
+
+// InvalidateLists drops every cached listing
+func (c *CachedRepository[T]) InvalidateLists(ctx context.Context) error {
+	if err := c.cache.DeleteByTag(ctx, c.list); err != nil {
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// listKey is the key of the listing under params, made in the salon of ctx
+func (c *CachedRepository[T]) listKey(ctx context.Context, params any) string {
+	if salon, ok := util.Salon(ctx); ok {
+		return util.GenerateCacheKey(c.list, util.GenerateCacheKeyParams(salon, params))
+	}
+	return util.GenerateCacheKey(c.list, params)
+}
+
+// read deserializes the cached value into out, a value that can't be deserialized is treated as a miss
+func (c *CachedRepository[T]) read(ctx context.Context, cacheKey string, out any) bool {
+	cached, err := c.cache.Get(ctx, cacheKey)
+	if err != nil {
+		return false
+	}
+
+	if err := util.Deserialize(cached, out); err != nil {
+		slog.WarnContext(ctx, "cache deserialization failed", "key", cacheKey, "error", err)
+		return false
+	}
+
+	return true
+}
+
+// write serializes and caches the value
+func (c *CachedRepository[T]) write(ctx context.Context, cacheKey string, value any, tags ...string) error {
+	serialized, err := util.Serialize(value)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	if err := c.cache.Set(ctx, cacheKey, serialized, 0, tags...); err != nil {
+		return domain.ErrInternal
+	}
+
+	return nil
+}
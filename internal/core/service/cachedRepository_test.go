@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mapCache is a minimal port.CacheRepository for testing
+type mapCache struct {
+	values map[string][]byte
+	tags   map[string][]string
+}
+
+func newMapCache() *mapCache {
+	return &mapCache{values: map[string][]byte{}, tags: map[string][]string{}}
+}
+
+func (c *mapCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	c.values[key] = value
+	for _, tag := range tags {
+		c.tags[tag] = append(c.tags[tag], key)
+	}
+	return nil
+}
+
+func (c *mapCache) Get(ctx context.Context, key string) ([]byte, error) {
+	value, ok := c.values[key]
+	if !ok {
+		return nil, errors.New("miss")
+	}
+	return value, nil
+}
+
+func (c *mapCache) Delete(ctx context.Context, key string) error {
+	delete(c.values, key)
+	return nil
+}
+
+func (c *mapCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	for key := range c.values {
+		if strings.HasPrefix(key, strings.TrimSuffix(prefix, "*")) {
+			delete(c.values, key)
+		}
+	}
+	return nil
+}
+
+func (c *mapCache) DeleteByTag(ctx context.Context, tag string) error {
+	for _, key := range c.tags[tag] {
+		delete(c.values, key)
+	}
+	delete(c.tags, tag)
+	return nil
+}
+
+func (c *mapCache) Close() error { return nil }
+
+func TestCachedRepositoryGet(t *testing.T) {
+	ctx := context.Background()
+	cached := NewCachedRepository[domain.Promotion](newMapCache(), "promotion", "promotions")
+
+	loads := 0
+	load := func() (*domain.Promotion, error) {
+		loads++
+		return &domain.Promotion{Name: "Verano"}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		p, err := cached.Get(ctx, "7b0c", load)
+		require.NoError(t, err)
+		assert.Equal(t, "Verano", p.Name)
+	}
+	assert.Equal(t, 1, loads)
+
+	require.NoError(t, cached.Invalidate(ctx, "7b0c"))
+	_, err := cached.Get(ctx, "7b0c", load)
+	require.NoError(t, err)
+	assert.Equal(t, 2, loads)
+}
+
+func TestCachedRepositoryGetKeepsLoadErrors(t *testing.T) {
+	ctx := context.Background()
+	cached := NewCachedRepository[domain.Promotion](newMapCache(), "promotion", "promotions")
+
+	_, err := cached.Get(ctx, "7b0c", func() (*domain.Promotion, error) {
+		return nil, domain.ErrDataNotFound
+	})
+	assert.ErrorIs(t, err, domain.ErrDataNotFound)
+}
+
+func TestCachedRepositoryStoreDropsLists(t *testing.T) {
+	ctx := context.Background()
+	cache := newMapCache()
+	cached := NewCachedRepository[domain.Promotion](cache, "promotion", "promotions")
+
+	loads := 0
+	load := func() ([]domain.Promotion, error) {
+		loads++
+		return []domain.Promotion{{Name: "Verano"}}, nil
+	}
+
+	_, err := cached.List(ctx, "1-10", load)
+	require.NoError(t, err)
+	_, err = cached.List(ctx, "1-10", load)
+	require.NoError(t, err)
+	assert.Equal(t, 1, loads)
+
+	require.NoError(t, cached.Store(ctx, "7b0c", &domain.Promotion{Name: "Invierno"}))
+	assert.Contains(t, cache.values, "promotion:7b0c")
+
+	_, err = cached.List(ctx, "1-10", load)
+	require.NoError(t, err)
+	assert.Equal(t, 2, loads)
+}
+
+func TestCachedRepositoryListPage(t *testing.T) {
+	ctx := context.Background()
+	cached := NewCachedRepository[domain.Promotion](newMapCache(), "promotion", "promotions")
+
+	load := func() ([]domain.Promotion, uint64, error) {
+		return []domain.Promotion{{Name: "Verano"}}, 12, nil
+	}
+	_, _, err := cached.ListPage(ctx, "1-1", load)
+	require.NoError(t, err)
+
+	values, total, err := cached.ListPage(ctx, "1-1", func() ([]domain.Promotion, uint64, error) {
+		t.Fatal("expected the cached page")
+		return nil, 0, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(12), total)
+	assert.Equal(t, "Verano", values[0].Name)
+}
+
+func TestCachedRepositorySalons(t *testing.T) {
+	cached := NewCachedRepository[domain.Quote](newMapCache(), "quote", "quotes")
+	roma := util.WithSalon(context.Background(), uuid.New())
+	condesa := util.WithSalon(context.Background(), uuid.New())
+
+	// A quote cached from one salon is not found from another
+	quote := &domain.Quote{ID: uuid.New(), SalonID: salonOf(roma)}
+	require.NoError(t, cached.Store(roma, quote.ID, quote))
+
+	_, err := cached.Get(condesa, quote.ID, func() (*domain.Quote, error) {
+		t.Fatal("expected the cached quote")
+		return nil, nil
+	})
+	assert.ErrorIs(t, err, domain.ErrDataNotFound)
+
+	got, err := cached.Get(context.Background(), quote.ID, func() (*domain.Quote, error) {
+		t.Fatal("expected the cached quote")
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, quote.ID, got.ID)
+
+	// Listings with the same params are cached apart for every salon
+	for _, ctx := range []context.Context{roma, condesa} {
+		values, err := cached.List(ctx, "1-10", func() ([]domain.Quote, error) {
+			return []domain.Quote{{SalonID: salonOf(ctx)}}, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, salonOf(ctx), values[0].SalonID)
+	}
+}
+
+// salonOf returns the salon ctx is made in
+func salonOf(ctx context.Context) uuid.UUID {
+	salon, _ := util.Salon(ctx)
+	return salon
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+)
+
+/**
+ * CouponService implements port.CouponService interface
+ * and provides access to the coupon repository and cache service.
+ * Coupons are applied to quotes by the quote service, which
+ * tracks their redemptions
+ */
+type CouponService struct {
+	repo  port.CouponRepository
+	cache *CachedRepository[domain.Coupon]
+}
+
+// NewCouponService creates a new coupon service instance
+func NewCouponService(repo port.CouponRepository, cache port.CacheRepository) *CouponService {
+	return &CouponService{
+		repo,
+		newCouponCache(cache),
+	}
+}
+
+// newCouponCache creates the cache of the coupons, shared with the quote service that redeems them
+func newCouponCache(cache port.CacheRepository) *CachedRepository[domain.Coupon] {
+	return NewCachedRepository[domain.Coupon](cache, "coupon", "coupons")
+}
+
+// CreateCoupon creates a new coupon, its code is unique whatever its case
+func (s *CouponService) CreateCoupon(ctx context.Context, c *domain.Coupon) (*domain.Coupon, error) {
+	c.Code = domain.NormalizeCouponCode(c.Code)
+	if !c.IsValid() {
+		return nil, domain.ErrInvalidCoupon
+	}
+
+	c.TypeOfServiceIDs = uniqueIDs(c.TypeOfServiceIDs)
+
+	created, err := s.repo.CreateCoupon(ctx, c)
+	if err != nil {
+		if err == domain.ErrConflictingData || err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		slog.ErrorContext(ctx, "Coupon creation failed", "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	err = s.cache.Store(ctx, created.ID, created)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// GetCoupon retrieves a coupon by ID
+func (s *CouponService) GetCoupon(ctx context.Context, id uuid.UUID) (*domain.Coupon, error) {
+	c, err := s.cache.Get(ctx, id, func() (*domain.Coupon, error) {
+		return s.repo.GetCouponByID(ctx, id)
+	})
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	return c, nil
+}
+
+// ListCoupons lists coupons with pagination
+func (s *CouponService) ListCoupons(ctx context.Context, skip, limit uint64) ([]domain.Coupon, error) {
+	params := util.GenerateCacheKeyParams(skip, limit)
+
+	coupons, err := s.cache.List(ctx, params, func() ([]domain.Coupon, error) {
+		return s.repo.ListCoupons(ctx, skip, limit)
+	})
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return coupons, nil
+}
+
+// UpdateCoupon updates an existing coupon, quotes it was already applied to keep their discount
+func (s *CouponService) UpdateCoupon(ctx context.Context, c *domain.Coupon) (*domain.Coupon, error) {
+	_, err := s.repo.GetCouponByID(ctx, c.ID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	c.Code = domain.NormalizeCouponCode(c.Code)
+	if !c.IsValid() {
+		return nil, domain.ErrInvalidCoupon
+	}
+
+	c.TypeOfServiceIDs = uniqueIDs(c.TypeOfServiceIDs)
+
+	updated, err := s.repo.UpdateCoupon(ctx, c)
+	if err != nil {
+		if err == domain.ErrConflictingData || err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		slog.ErrorContext(ctx, "Coupon update failed", "coupon_id", c.ID, "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	err = s.cache.Store(ctx, updated.ID, updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteCoupon deletes a coupon by ID
+func (s *CouponService) DeleteCoupon(ctx context.Context, id uuid.UUID) error {
+	_, err := s.repo.GetCouponByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return err
+		}
+		return domain.ErrInternal
+	}
+
+	err = s.repo.DeleteCoupon(ctx, id)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	return s.cache.Invalidate(ctx, id)
+}
+
+// ListCouponRedemptions lists the redemptions of a coupon, they change with every quote so they are not cached
+func (s *CouponService) ListCouponRedemptions(ctx context.Context, couponID uuid.UUID, skip, limit uint64) ([]domain.CouponRedemption, error) {
+	_, err := s.GetCoupon(ctx, couponID)
+	if err != nil {
+		return nil, err
+	}
+
+	redemptions, err := s.repo.ListCouponRedemptions(ctx, couponID, skip, limit)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error listing coupon redemptions", "coupon_id", couponID, "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	return redemptions, nil
+}
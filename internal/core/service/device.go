@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+/**
+ * DeviceService implements port.DeviceService interface
+ * and registers the devices push notifications are sent to
+ */
+type DeviceService struct {
+	repo port.DeviceRepository
+}
+
+// NewDeviceService creates a new device service instance
+func NewDeviceService(repo port.DeviceRepository) *DeviceService {
+	return &DeviceService{
+		repo,
+	}
+}
+
+// RegisterDevice registers a device of a user for push notifications, registering a token again
+// refreshes it and moves it to the user now signed in on the device
+func (s *DeviceService) RegisterDevice(ctx context.Context, device *domain.Device) (*domain.Device, error) {
+	now := time.Now()
+	device.ID = uuid.New()
+	device.CreatedAt = now
+	device.UpdatedAt = now
+
+	registered, err := s.repo.UpsertDevice(ctx, device)
+	if err != nil {
+		slog.ErrorContext(ctx, "Device registration failed", "user_id", device.UserID, "error", err)
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	return registered, nil
+}
+
+// UnregisterDevice stops sending push notifications to one of a user's devices
+func (s *DeviceService) UnregisterDevice(ctx context.Context, userID uuid.UUID, token string) error {
+	err := s.repo.DeleteDevice(ctx, userID, token)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return err
+		}
+		return domain.ErrInternal
+	}
+
+	return nil
+}
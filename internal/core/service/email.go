@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+)
+
+// emailTemplateKey is the context key the template of an email is passed to the email queue with,
+// so the delivery log can tell which event an email was sent for
+type emailTemplateKey struct{}
+
+// withEmailTemplate returns a copy of ctx carrying the template of the email being sent
+func withEmailTemplate(ctx context.Context, template domain.EmailTemplate) context.Context {
+	return context.WithValue(ctx, emailTemplateKey{}, template)
+}
+
+// emailTemplateFrom returns the template carried by ctx, empty when the email was not rendered from one
+func emailTemplateFrom(ctx context.Context) domain.EmailTemplate {
+	template, _ := ctx.Value(emailTemplateKey{}).(domain.EmailTemplate)
+	return template
+}
+
+// sendTemplatedEmail renders the template of an event in lang and sends its HTML and text parts to the recipients
+func sendTemplatedEmail(
+	ctx context.Context,
+	email port.EmailRepository,
+	templates port.EmailTemplateRenderer,
+	to []string,
+	lang domain.Language,
+	template domain.EmailTemplate,
+	data any,
+	attachments ...domain.EmailAttachment,
+) error {
+	message, err := templates.Render(template, lang, data)
+	if err != nil {
+		return err
+	}
+
+	_, err = email.SendEmail(withEmailTemplate(ctx, template), to, message.Subject, message.Text, message.HTML, attachments...)
+	return err
+}
+
+// fullName joins the name and last name a user is addressed by in notifications, empty when the user is unknown
+func fullName(user *domain.User) string {
+	if user == nil {
+		return ""
+	}
+	if user.LastName == "" {
+		return user.Name
+	}
+	return user.Name + " " + user.LastName
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+)
+
+// maxEmailRetryDelay caps the exponential backoff between attempts
+const maxEmailRetryDelay = time.Hour
+
+/**
+ * EmailQueueService implements port.EmailRepository and port.EmailQueueService interfaces,
+ * services send through it so their emails are queued and delivered by the worker with retries
+ */
+type EmailQueueService struct {
+	queue        port.EmailQueueRepository
+	email        port.EmailRepository
+	logs         port.EmailLogRepository
+	maxAttempts  int
+	retryDelay   time.Duration
+	pollInterval time.Duration
+}
+
+// NewEmailQueueService creates a new email queue service instance delivering through email
+// and recording every attempt in the delivery log
+func NewEmailQueueService(
+	queue port.EmailQueueRepository,
+	email port.EmailRepository,
+	logs port.EmailLogRepository,
+	maxAttempts int,
+	retryDelay time.Duration,
+	pollInterval time.Duration,
+) *EmailQueueService {
+	return &EmailQueueService{
+		queue,
+		email,
+		logs,
+		maxAttempts,
+		retryDelay,
+		pollInterval,
+	}
+}
+
+// SendEmail queues the email for delivery, returning the ID of the queued email, it fails only when the email cannot be queued
+func (s *EmailQueueService) SendEmail(ctx context.Context, to []string, subject string, textContent string, htmlContent string, attachments ...domain.EmailAttachment) (string, error) {
+	now := time.Now()
+
+	email := &domain.QueuedEmail{
+		ID:            uuid.New(),
+		To:            to,
+		Subject:       subject,
+		Text:          textContent,
+		HTML:          htmlContent,
+		Attachments:   attachments,
+		Template:      emailTemplateFrom(ctx),
+		SalonID:       util.SalonOr(ctx, uuid.Nil),
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}
+
+	if err := s.queue.Enqueue(ctx, email); err != nil {
+		return "", err
+	}
+
+	return email.ID.String(), nil
+}
+
+// Run sends due emails until ctx is done
+func (s *EmailQueueService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.deliverDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliverDue sends every email due now, one at a time
+func (s *EmailQueueService) deliverDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		email, err := s.queue.Dequeue(ctx, time.Now())
+		if err != nil {
+			if err != domain.ErrDataNotFound {
+				slog.ErrorContext(ctx, "Email dequeue failed", "error", err)
+			}
+			return
+		}
+
+		s.deliver(ctx, email)
+	}
+}
+
+// deliver sends the email, rescheduling it with backoff or dead-lettering it when the attempt fails
+func (s *EmailQueueService) deliver(ctx context.Context, email *domain.QueuedEmail) {
+	messageID, err := s.email.SendEmail(ctx, email.To, email.Subject, email.Text, email.HTML, email.Attachments...)
+	s.log(ctx, email, messageID, err)
+	if err == nil {
+		return
+	}
+
+	email.Attempts++
+	email.LastError = err.Error()
+
+	// Rejected emails would fail the same way on every retry
+	if email.Attempts >= s.maxAttempts || errors.Is(err, domain.ErrEmailRejected) {
+		slog.ErrorContext(ctx, "Email delivery failed, moving it to the dead-letter list", "email_id", email.ID, "attempts", email.Attempts, "error", err)
+		if err := s.queue.DeadLetter(ctx, email); err != nil {
+			slog.ErrorContext(ctx, "Email dead-lettering failed", "email_id", email.ID, "error", err)
+		}
+		return
+	}
+
+	email.NextAttemptAt = time.Now().Add(s.backoff(email.Attempts))
+	slog.WarnContext(ctx, "Email delivery failed, retrying", "email_id", email.ID, "attempts", email.Attempts, "next_attempt_at", email.NextAttemptAt, "error", err)
+
+	if err := s.queue.Enqueue(ctx, email); err != nil {
+		slog.ErrorContext(ctx, "Email requeue failed", "email_id", email.ID, "error", err)
+	}
+}
+
+// log records the attempt to deliver email in the delivery log, a failure to record it does not affect delivery
+func (s *EmailQueueService) log(ctx context.Context, email *domain.QueuedEmail, messageID string, err error) {
+	entry := &domain.EmailLog{
+		ID:                uuid.New(),
+		EmailID:           email.ID,
+		Recipients:        email.To,
+		Template:          email.Template,
+		Subject:           email.Subject,
+		Status:            domain.EmailSent,
+		ProviderMessageID: messageID,
+		Attempt:           email.Attempts + 1,
+		CreatedAt:         time.Now(),
+		SalonID:           email.SalonID,
+	}
+	if err != nil {
+		entry.Status = domain.EmailFailed
+		entry.Error = err.Error()
+	}
+
+	if err := s.logs.CreateEmailLog(ctx, entry); err != nil {
+		slog.ErrorContext(ctx, "Email delivery logging failed", "email_id", email.ID, "error", err)
+	}
+}
+
+// backoff returns the wait before the next attempt, doubling the retry delay after every failed one
+func (s *EmailQueueService) backoff(attempts int) time.Duration {
+	delay := s.retryDelay
+	for i := 1; i < attempts && delay < maxEmailRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxEmailRetryDelay)
+}
+
+// ListDeadLetters lists the emails that could not be delivered
+func (s *EmailQueueService) ListDeadLetters(ctx context.Context, skip, limit uint64) ([]domain.QueuedEmail, error) {
+	emails, err := s.queue.ListDeadLetters(ctx, skip, limit)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return emails, nil
+}
+
+// RetryDeadLetter puts a dead-lettered email back in the queue with fresh attempts
+func (s *EmailQueueService) RetryDeadLetter(ctx context.Context, id uuid.UUID) (*domain.QueuedEmail, error) {
+	email, err := s.queue.RemoveDeadLetter(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	email.Attempts = 0
+	email.NextAttemptAt = time.Now()
+
+	err = s.queue.Enqueue(ctx, email)
+	if err != nil {
+		slog.ErrorContext(ctx, "Email requeue failed", "email_id", email.ID, "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	return email, nil
+}
+
+// DiscardDeadLetter deletes a dead-lettered email for good
+func (s *EmailQueueService) DiscardDeadLetter(ctx context.Context, id uuid.UUID) error {
+	email, err := s.queue.RemoveDeadLetter(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return err
+		}
+		return domain.ErrInternal
+	}
+
+	slog.InfoContext(ctx, "Email discarded", "email_id", email.ID, "attempts", email.Attempts)
+	return nil
+}
+
+// ListEmailLogs lists the attempts to deliver emails, newest first
+func (s *EmailQueueService) ListEmailLogs(ctx context.Context, filter port.EmailLogFilter) ([]domain.EmailLog, error) {
+	logs, err := s.logs.ListEmailLogs(ctx, filter)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return logs, nil
+}
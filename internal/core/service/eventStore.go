@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/adapter/storage/postgres/repository"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+)
+
+/**
+ * EventStoreService implements port.EventStoreService interface
+ * and reads back the domain events recorded when they were published
+ */
+type EventStoreService struct {
+	repo port.EventStoreRepository
+}
+
+// NewEventStoreService creates a new event store service instance
+func NewEventStoreService(repo port.EventStoreRepository) *EventStoreService {
+	return &EventStoreService{
+		repo,
+	}
+}
+
+// ListEvents lists the stored events in the order they occurred
+func (s *EventStoreService) ListEvents(ctx context.Context, filter port.EventFilter) ([]domain.StoredEvent, error) {
+	events, err := s.repo.ListEvents(ctx, filter)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error listing stored events", "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	return events, nil
+}
+
+// recordEvent appends the event to the event store within the transaction of txDB and returns the outbox message
+// publishing it once the transaction commits, so the event is stored and published only if the change is.
+// The event happens in the salon of ctx unless it already names one
+func recordEvent(ctx context.Context, txDB *postgres.DB, event domain.Event) (*domain.OutboxMessage, error) {
+	event.SalonID = util.SalonOr(ctx, event.SalonID)
+
+	stored, err := domain.NewStoredEvent(event)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repository.NewEventStoreRepository(txDB).AppendEvents(ctx, stored); err != nil {
+		return nil, err
+	}
+
+	return domain.NewOutboxEvent(event)
+}
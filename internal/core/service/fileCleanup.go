@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+)
+
+// fileCleanupBatchSize is how many listed files are looked up in the database at once
+const fileCleanupBatchSize = 500
+
+/**
+ * FileCleanupService implements port.FileCleanupService interface
+ * and deletes the stored files left behind by uploads whose row was never written or was removed
+ */
+type FileCleanupService struct {
+	lister     port.FileLister
+	file       port.FileRepository
+	references port.FileReferenceRepository
+	grace      time.Duration
+}
+
+// NewFileCleanupService creates a new file cleanup service instance. Files are listed with lister and deleted
+// through file, those modified less than grace ago are kept as their row may not be written yet
+func NewFileCleanupService(lister port.FileLister, file port.FileRepository, references port.FileReferenceRepository, grace time.Duration) *FileCleanupService {
+	return &FileCleanupService{
+		lister,
+		file,
+		references,
+		grace,
+	}
+}
+
+// CleanOrphans deletes every stored file older than the grace period that no row references, with its thumbnail
+func (s *FileCleanupService) CleanOrphans(ctx context.Context) (int, error) {
+	cutoff := time.Now().Add(-s.grace)
+	deleted := 0
+	batch := make([]string, 0, fileCleanupBatchSize)
+
+	flush := func() error {
+		n, err := s.deleteOrphans(ctx, batch)
+		deleted += n
+		batch = batch[:0]
+		return err
+	}
+
+	err := s.lister.ListFiles(ctx, func(key string, modifiedAt time.Time) error {
+		// Thumbnails go with the file they were generated from
+		if strings.HasPrefix(key, "thumbnails/") || modifiedAt.After(cutoff) {
+			return nil
+		}
+
+		batch = append(batch, key)
+		if len(batch) < fileCleanupBatchSize {
+			return nil
+		}
+		return flush()
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Error cleaning orphan files", "deleted", deleted, "error", err)
+		return deleted, domain.ErrInternal
+	}
+
+	if deleted > 0 {
+		slog.InfoContext(ctx, "Orphan files deleted", "count", deleted)
+	}
+
+	return deleted, nil
+}
+
+// deleteOrphans deletes the keys of the batch no row references and returns how many it deleted
+func (s *FileCleanupService) deleteOrphans(ctx context.Context, keys []string) (int, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	referenced, err := s.references.GetReferencedFiles(ctx, keys)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, key := range keys {
+		if referenced[key] {
+			continue
+		}
+
+		err := s.file.Delete(ctx, key)
+		if err != nil {
+			return deleted, err
+		}
+		err = s.file.Delete(ctx, domain.ThumbnailKey(key))
+		if err != nil {
+			return deleted, err
+		}
+
+		slog.DebugContext(ctx, "Orphan file deleted", "key", key)
+		deleted++
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryFiles is a port.FileRepository and port.FileLister keeping the files with their modification time
+type memoryFiles map[string]time.Time
+
+func (f memoryFiles) Save(ctx context.Context, r io.Reader, size int64, name string) (string, error) {
+	f[name] = time.Now()
+	return name, nil
+}
+
+func (f memoryFiles) Get(ctx context.Context, path string) ([]byte, error) {
+	if _, ok := f[path]; !ok {
+		return nil, domain.ErrDataNotFound
+	}
+	return nil, nil
+}
+
+func (f memoryFiles) Delete(ctx context.Context, path string) error {
+	delete(f, path)
+	return nil
+}
+
+func (f memoryFiles) ListFiles(ctx context.Context, fn func(key string, modifiedAt time.Time) error) error {
+	for key, modifiedAt := range f {
+		if err := fn(key, modifiedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// memoryFileReferences is a port.FileReferenceRepository over a set of referenced keys
+type memoryFileReferences map[string]bool
+
+func (r memoryFileReferences) GetReferencedFiles(ctx context.Context, keys []string) (map[string]bool, error) {
+	referenced := map[string]bool{}
+	for _, key := range keys {
+		if r[key] {
+			referenced[key] = true
+		}
+	}
+	return referenced, nil
+}
+
+func TestCleanOrphans(t *testing.T) {
+	old := time.Now().Add(-48 * time.Hour)
+	files := memoryFiles{
+		"quotes/1/photo.jpg":            old,
+		"thumbnails/quotes/1/photo.jpg": old,
+		"quotes/2/photo.jpg":            old,
+		"thumbnails/quotes/2/photo.jpg": old,
+		"proofs/3/receipt.pdf":          old,
+	}
+	_, err := files.Save(context.Background(), bytes.NewReader(nil), 0, "quotes/4/uploading.jpg")
+	require.NoError(t, err)
+
+	references := memoryFileReferences{"quotes/1/photo.jpg": true}
+	svc := NewFileCleanupService(files, files, references, 24*time.Hour)
+
+	deleted, err := svc.CleanOrphans(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	// Referenced files, their thumbnails and files still within the grace period are kept
+	assert.ElementsMatch(t, []string{"quotes/1/photo.jpg", "thumbnails/quotes/1/photo.jpg", "quotes/4/uploading.jpg"}, fileKeys(files))
+}
+
+func fileKeys(files memoryFiles) []string {
+	var keys []string
+	for key := range files {
+		keys = append(keys, key)
+	}
+	return keys
+}
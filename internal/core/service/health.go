@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+)
+
+/**
+ * HealthService implements port.HealthService interface
+ * and reports the state of the database and the other dependencies
+ */
+type HealthService struct {
+	repo    port.HealthRepository
+	checks  map[string]port.HealthChecker
+	timeout time.Duration
+}
+
+// NewHealthService creates a new health service instance, checks are the dependencies readiness
+// depends on by name and each of them gets timeout to answer
+func NewHealthService(repo port.HealthRepository, checks map[string]port.HealthChecker, timeout time.Duration) *HealthService {
+	return &HealthService{
+		repo,
+		checks,
+		timeout,
+	}
+}
+
+// DatabaseHealth returns the state of the database, a database that can't be pinged or whose last
+// migration failed halfway is unavailable
+func (hs *HealthService) DatabaseHealth(ctx context.Context) (*domain.DatabaseHealth, error) {
+	health, err := hs.repo.DatabaseHealth(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Database health check failed", "error", err)
+		return nil, domain.ErrDatabaseUnavailable
+	}
+
+	if health.MigrationDirty {
+		slog.ErrorContext(ctx, "Database schema is dirty", "version", health.MigrationVersion)
+		return health, domain.ErrDatabaseUnavailable
+	}
+
+	return health, nil
+}
+
+// Readiness pings every dependency at the same time, so a slow one costs the timeout once.
+// The components are sorted by name
+func (hs *HealthService) Readiness(ctx context.Context) ([]domain.ComponentHealth, error) {
+	components := make([]domain.ComponentHealth, 0, len(hs.checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for name, check := range hs.checks {
+		wg.Add(1)
+		go func(name string, check port.HealthChecker) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, hs.timeout)
+			defer cancel()
+
+			started := time.Now()
+			err := check.Ping(checkCtx)
+			component := domain.ComponentHealth{Name: name, Healthy: err == nil, Latency: time.Since(started)}
+			if err != nil {
+				slog.ErrorContext(ctx, "Readiness check failed", "component", name, "error", err)
+			}
+
+			mu.Lock()
+			components = append(components, component)
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].Name < components[j].Name
+	})
+
+	for _, component := range components {
+		if !component.Healthy {
+			return components, domain.ErrNotReady
+		}
+	}
+
+	return components, nil
+}
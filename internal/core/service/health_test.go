@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pingFunc is a port.HealthChecker answering with a function
+type pingFunc func(ctx context.Context) error
+
+func (f pingFunc) Ping(ctx context.Context) error {
+	return f(ctx)
+}
+
+// hangingPing never answers, until its context is done
+func hangingPing(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestReadiness(t *testing.T) {
+	svc := NewHealthService(nil, map[string]port.HealthChecker{
+		"redis":    pingFunc(func(ctx context.Context) error { return nil }),
+		"postgres": pingFunc(func(ctx context.Context) error { return nil }),
+	}, time.Second)
+
+	components, err := svc.Readiness(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, components, 2)
+	assert.Equal(t, "postgres", components[0].Name)
+	assert.Equal(t, "redis", components[1].Name)
+	assert.True(t, components[0].Healthy)
+	assert.True(t, components[1].Healthy)
+}
+
+func TestReadinessTimesOutHangingDependencies(t *testing.T) {
+	svc := NewHealthService(nil, map[string]port.HealthChecker{
+		"postgres": pingFunc(func(ctx context.Context) error { return nil }),
+		"storage":  pingFunc(hangingPing),
+		"redis":    pingFunc(hangingPing),
+	}, 20*time.Millisecond)
+
+	started := time.Now()
+	components, err := svc.Readiness(context.Background())
+	assert.ErrorIs(t, err, domain.ErrNotReady)
+
+	// The checks run at the same time, so two hanging dependencies cost a single timeout
+	assert.Less(t, time.Since(started), 200*time.Millisecond)
+
+	require.Len(t, components, 3)
+	assert.True(t, components[0].Healthy)
+	assert.False(t, components[1].Healthy)
+	assert.False(t, components[2].Healthy)
+}
+
+func TestReadinessReportsUnreachableDependencies(t *testing.T) {
+	svc := NewHealthService(nil, map[string]port.HealthChecker{
+		"redis": pingFunc(func(ctx context.Context) error { return errors.New("connection refused") }),
+	}, time.Second)
+
+	components, err := svc.Readiness(context.Background())
+	assert.ErrorIs(t, err, domain.ErrNotReady)
+	require.Len(t, components, 1)
+	assert.False(t, components[0].Healthy)
+}
@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+)
+
+// maxJobRetryDelay caps the exponential backoff between attempts
+const maxJobRetryDelay = time.Hour
+
+/**
+ * JobQueueService implements port.JobQueueService interface
+ * and runs the queued jobs with the handlers registered for their types, with retries
+ */
+type JobQueueService struct {
+	queue        port.JobQueueRepository
+	reporter     port.ErrorReporter
+	maxAttempts  int
+	retryDelay   time.Duration
+	pollInterval time.Duration
+	concurrency  int
+
+	mu       sync.RWMutex
+	handlers map[domain.JobType]port.JobHandler
+}
+
+// NewJobQueueService creates a new job queue service instance, Run takes concurrency jobs at a time.
+// The panics of the jobs and the jobs given up on are sent to reporter
+func NewJobQueueService(
+	queue port.JobQueueRepository,
+	reporter port.ErrorReporter,
+	maxAttempts int,
+	retryDelay time.Duration,
+	pollInterval time.Duration,
+	concurrency int,
+) *JobQueueService {
+	return &JobQueueService{
+		queue:        queue,
+		reporter:     reporter,
+		maxAttempts:  maxAttempts,
+		retryDelay:   retryDelay,
+		pollInterval: pollInterval,
+		concurrency:  max(concurrency, 1),
+		handlers:     map[domain.JobType]port.JobHandler{},
+	}
+}
+
+// Enqueue schedules a job of jobType to run now, payload is the payload struct of the type
+func (s *JobQueueService) Enqueue(ctx context.Context, jobType domain.JobType, payload any) (*domain.Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	job := &domain.Job{
+		ID:            uuid.New(),
+		Type:          jobType,
+		Payload:       data,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+		SalonID:       util.SalonOr(ctx, uuid.Nil),
+	}
+
+	if err := s.queue.Enqueue(ctx, job); err != nil {
+		slog.ErrorContext(ctx, "Job enqueue failed", "type", jobType, "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	return job, nil
+}
+
+// Handle registers the handler running the jobs of jobType, it replaces the previous one
+func (s *JobQueueService) Handle(jobType domain.JobType, handler port.JobHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[jobType] = handler
+}
+
+// Run runs due jobs until ctx is done, a job that is running when ctx is done is finished first
+func (s *JobQueueService) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < s.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.poll(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+// poll runs the due jobs one at a time, looking for more every poll interval
+func (s *JobQueueService) poll(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.runDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runDue runs every job due now
+func (s *JobQueueService) runDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, err := s.queue.Dequeue(ctx, time.Now())
+		if err != nil {
+			if err != domain.ErrDataNotFound {
+				slog.ErrorContext(ctx, "Job dequeue failed", "error", err)
+			}
+			return
+		}
+
+		// The job was claimed, so it runs to its end even when the worker is stopping
+		s.run(context.WithoutCancel(ctx), job)
+	}
+}
+
+// run runs the job, rescheduling it with backoff or dead-lettering it when the attempt fails
+func (s *JobQueueService) run(ctx context.Context, job *domain.Job) {
+	s.mu.RLock()
+	handler, ok := s.handlers[job.Type]
+	s.mu.RUnlock()
+
+	// Whatever the handler logs can be traced back to the job
+	ctx = util.WithLogAttrs(ctx, slog.String("job_id", job.ID.String()), slog.String("job_type", string(job.Type)))
+	start := time.Now()
+	var err error
+	if ok {
+		err = s.safeRun(ctx, handler, job.Payload)
+	} else {
+		err = fmt.Errorf("%w: no handler for job type %s", domain.ErrJobRejected, job.Type)
+	}
+	if err == nil {
+		slog.DebugContext(ctx, "Job done", "job_id", job.ID, "type", job.Type, "duration", time.Since(start))
+		return
+	}
+
+	job.Attempts++
+	job.LastError = err.Error()
+
+	// Rejected jobs would fail the same way on every retry
+	if job.Attempts >= s.maxAttempts || errors.Is(err, domain.ErrJobRejected) {
+		slog.ErrorContext(ctx, "Job failed, moving it to the dead-letter list", "job_id", job.ID, "type", job.Type, "attempts", job.Attempts, "error", err)
+		s.reporter.Report(ctx, fmt.Errorf("job %s dead-lettered after %d attempts: %w", job.Type, job.Attempts, err))
+		if err := s.queue.DeadLetter(ctx, job); err != nil {
+			slog.ErrorContext(ctx, "Job dead-lettering failed", "job_id", job.ID, "error", err)
+		}
+		return
+	}
+
+	job.NextAttemptAt = time.Now().Add(s.backoff(job.Attempts))
+	slog.WarnContext(ctx, "Job failed, retrying", "job_id", job.ID, "type", job.Type, "attempts", job.Attempts, "next_attempt_at", job.NextAttemptAt, "error", err)
+
+	if err := s.queue.Enqueue(ctx, job); err != nil {
+		slog.ErrorContext(ctx, "Job requeue failed", "job_id", job.ID, "error", err)
+	}
+}
+
+// safeRun runs handler, turning a panic into an error so one broken job doesn't stop the worker
+func (s *JobQueueService) safeRun(ctx context.Context, handler port.JobHandler, payload json.RawMessage) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.reporter.ReportPanic(ctx, r)
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return handler(ctx, payload)
+}
+
+// backoff returns the wait before the next attempt, doubling the retry delay after every failed one
+func (s *JobQueueService) backoff(attempts int) time.Duration {
+	delay := s.retryDelay
+	for i := 1; i < attempts && delay < maxJobRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxJobRetryDelay)
+}
+
+// ListDeadLetters lists the jobs that failed every attempt
+func (s *JobQueueService) ListDeadLetters(ctx context.Context, skip, limit uint64) ([]domain.Job, error) {
+	jobs, err := s.queue.ListDeadLetters(ctx, skip, limit)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return jobs, nil
+}
+
+// RetryDeadLetter puts a dead-lettered job back in the queue with fresh attempts
+func (s *JobQueueService) RetryDeadLetter(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	job, err := s.queue.RemoveDeadLetter(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	job.Attempts = 0
+	job.NextAttemptAt = time.Now()
+
+	err = s.queue.Enqueue(ctx, job)
+	if err != nil {
+		slog.ErrorContext(ctx, "Job requeue failed", "job_id", job.ID, "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	return job, nil
+}
+
+// ListQueued lists the jobs waiting to run, including those deferred after failing, the next one due first
+func (s *JobQueueService) ListQueued(ctx context.Context, skip, limit uint64) ([]domain.Job, error) {
+	jobs, err := s.queue.ListQueued(ctx, skip, limit)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error listing queued jobs", "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	return jobs, nil
+}
+
+// GetJob returns a queued or dead-lettered job with its payload and where it is
+func (s *JobQueueService) GetJob(ctx context.Context, id uuid.UUID) (*domain.Job, domain.JobStatus, error) {
+	job, err := s.queue.GetQueued(ctx, id)
+	if err == nil {
+		return job, domain.JobQueued, nil
+	}
+	if err != domain.ErrDataNotFound {
+		slog.ErrorContext(ctx, "Error getting queued job", "job_id", id, "error", err)
+		return nil, "", domain.ErrInternal
+	}
+
+	job, err = s.queue.GetDeadLetter(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, "", err
+		}
+		slog.ErrorContext(ctx, "Error getting dead-lettered job", "job_id", id, "error", err)
+		return nil, "", domain.ErrInternal
+	}
+
+	return job, domain.JobDead, nil
+}
+
+// RetryJob runs a queued job right away, or puts a dead-lettered one back in the queue with fresh attempts
+func (s *JobQueueService) RetryJob(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	job, err := s.queue.RemoveQueued(ctx, id)
+	if err == domain.ErrDataNotFound {
+		return s.RetryDeadLetter(ctx, id)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Error removing queued job", "job_id", id, "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	// Attempts are kept, the job only skips the wait before its next one
+	job.NextAttemptAt = time.Now()
+
+	err = s.queue.Enqueue(ctx, job)
+	if err != nil {
+		slog.ErrorContext(ctx, "Job requeue failed", "job_id", job.ID, "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	return job, nil
+}
+
+// DiscardJob deletes a queued or dead-lettered job for good
+func (s *JobQueueService) DiscardJob(ctx context.Context, id uuid.UUID) error {
+	job, err := s.queue.RemoveQueued(ctx, id)
+	if err == domain.ErrDataNotFound {
+		job, err = s.queue.RemoveDeadLetter(ctx, id)
+	}
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return err
+		}
+		slog.ErrorContext(ctx, "Error discarding job", "job_id", id, "error", err)
+		return domain.ErrInternal
+	}
+
+	slog.InfoContext(ctx, "Job discarded", "job_id", job.ID, "type", job.Type, "attempts", job.Attempts)
+	return nil
+}
+
+// decodeJob decodes the payload of a job into v, a payload that doesn't decode is rejected
+func decodeJob(payload json.RawMessage, v any) error {
+	if err := json.Unmarshal(payload, v); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrJobRejected, err)
+	}
+	return nil
+}
@@ -0,0 +1,357 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryErrorReporter is a port.ErrorReporter keeping the reports in memory
+type memoryErrorReporter struct {
+	mu     sync.Mutex
+	errors []error
+	panics []any
+}
+
+func (r *memoryErrorReporter) Report(ctx context.Context, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, err)
+}
+
+func (r *memoryErrorReporter) ReportPanic(ctx context.Context, recovered any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.panics = append(r.panics, recovered)
+}
+
+func (r *memoryErrorReporter) Flush(ctx context.Context) error {
+	return nil
+}
+
+// memoryJobQueue is a port.JobQueueRepository keeping the jobs in memory
+type memoryJobQueue struct {
+	mu     sync.Mutex
+	queued []*domain.Job
+	dead   []*domain.Job
+}
+
+func (q *memoryJobQueue) Enqueue(ctx context.Context, job *domain.Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.queued = append(q.queued, job)
+	return nil
+}
+
+func (q *memoryJobQueue) Dequeue(ctx context.Context, now time.Time) (*domain.Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, job := range q.queued {
+		if !job.NextAttemptAt.After(now) {
+			q.queued = append(q.queued[:i], q.queued[i+1:]...)
+			return job, nil
+		}
+	}
+	return nil, domain.ErrDataNotFound
+}
+
+func (q *memoryJobQueue) ListQueued(ctx context.Context, skip, limit uint64) ([]domain.Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	jobs := make([]domain.Job, len(q.queued))
+	for i, job := range q.queued {
+		jobs[i] = *job
+	}
+	return jobs, nil
+}
+
+func (q *memoryJobQueue) GetQueued(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, job := range q.queued {
+		if job.ID == id {
+			return job, nil
+		}
+	}
+	return nil, domain.ErrDataNotFound
+}
+
+func (q *memoryJobQueue) RemoveQueued(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, job := range q.queued {
+		if job.ID == id {
+			q.queued = append(q.queued[:i], q.queued[i+1:]...)
+			return job, nil
+		}
+	}
+	return nil, domain.ErrDataNotFound
+}
+
+func (q *memoryJobQueue) GetDeadLetter(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, job := range q.dead {
+		if job.ID == id {
+			return job, nil
+		}
+	}
+	return nil, domain.ErrDataNotFound
+}
+
+func (q *memoryJobQueue) DeadLetter(ctx context.Context, job *domain.Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.dead = append(q.dead, job)
+	return nil
+}
+
+func (q *memoryJobQueue) ListDeadLetters(ctx context.Context, skip, limit uint64) ([]domain.Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	jobs := make([]domain.Job, len(q.dead))
+	for i, job := range q.dead {
+		jobs[i] = *job
+	}
+	return jobs, nil
+}
+
+func (q *memoryJobQueue) RemoveDeadLetter(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, job := range q.dead {
+		if job.ID == id {
+			q.dead = append(q.dead[:i], q.dead[i+1:]...)
+			return job, nil
+		}
+	}
+	return nil, domain.ErrDataNotFound
+}
+
+func (q *memoryJobQueue) Close() error {
+	return nil
+}
+
+func TestJobQueueRunsJobs(t *testing.T) {
+	queue := &memoryJobQueue{}
+	svc := NewJobQueueService(queue, &memoryErrorReporter{}, 3, time.Minute, time.Hour, 1)
+
+	var got domain.ThumbnailJob
+	svc.Handle(domain.JobGenerateThumbnail, func(ctx context.Context, payload json.RawMessage) error {
+		return json.Unmarshal(payload, &got)
+	})
+
+	_, err := svc.Enqueue(context.Background(), domain.JobGenerateThumbnail, domain.ThumbnailJob{Key: "quotes/1/2.png"})
+	require.NoError(t, err)
+
+	svc.runDue(context.Background())
+
+	assert.Equal(t, "quotes/1/2.png", got.Key)
+	assert.Empty(t, queue.queued)
+	assert.Empty(t, queue.dead)
+}
+
+func TestJobQueueEnqueueKeepsTheSalon(t *testing.T) {
+	svc := NewJobQueueService(&memoryJobQueue{}, &memoryErrorReporter{}, 3, time.Minute, time.Hour, 1)
+	salon := uuid.New()
+
+	job, err := svc.Enqueue(util.WithSalon(context.Background(), salon), domain.JobGenerateThumbnail, domain.ThumbnailJob{})
+	require.NoError(t, err)
+	assert.Equal(t, salon, job.SalonID)
+
+	job, err = svc.Enqueue(context.Background(), domain.JobGenerateThumbnail, domain.ThumbnailJob{})
+	require.NoError(t, err)
+	assert.Equal(t, domain.DefaultSalonID, job.SalonID)
+}
+
+func TestJobQueueRetriesWithBackoff(t *testing.T) {
+	queue := &memoryJobQueue{}
+	svc := NewJobQueueService(queue, &memoryErrorReporter{}, 3, time.Minute, time.Hour, 1)
+	svc.Handle(domain.JobNotify, func(ctx context.Context, payload json.RawMessage) error {
+		return errors.New("connection refused")
+	})
+
+	_, err := svc.Enqueue(context.Background(), domain.JobNotify, domain.NotifyJob{})
+	require.NoError(t, err)
+
+	svc.runDue(context.Background())
+
+	require.Len(t, queue.queued, 1)
+	job := queue.queued[0]
+	assert.Equal(t, 1, job.Attempts)
+	assert.Equal(t, "connection refused", job.LastError)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), job.NextAttemptAt, 5*time.Second)
+
+	// The retry isn't due yet
+	svc.runDue(context.Background())
+	assert.Equal(t, 1, job.Attempts)
+
+	assert.Equal(t, 2*time.Minute, svc.backoff(2))
+	assert.Equal(t, maxJobRetryDelay, svc.backoff(20))
+}
+
+func TestJobQueueDeadLetters(t *testing.T) {
+	failing := func(ctx context.Context, payload json.RawMessage) error {
+		return errors.New("connection refused")
+	}
+
+	t.Run("after the last attempt", func(t *testing.T) {
+		queue := &memoryJobQueue{}
+		reporter := &memoryErrorReporter{}
+		svc := NewJobQueueService(queue, reporter, 1, time.Minute, time.Hour, 1)
+		svc.Handle(domain.JobBuildReport, failing)
+
+		_, err := svc.Enqueue(context.Background(), domain.JobBuildReport, domain.ReportJob{Kind: domain.ReportQuotes})
+		require.NoError(t, err)
+		svc.runDue(context.Background())
+
+		assert.Empty(t, queue.queued)
+		require.Len(t, queue.dead, 1)
+		assert.Equal(t, 1, queue.dead[0].Attempts)
+		require.Len(t, reporter.errors, 1)
+		assert.EqualError(t, reporter.errors[0], "job build-report dead-lettered after 1 attempts: connection refused")
+	})
+
+	t.Run("rejected jobs right away", func(t *testing.T) {
+		queue := &memoryJobQueue{}
+		svc := NewJobQueueService(queue, &memoryErrorReporter{}, 5, time.Minute, time.Hour, 1)
+		svc.Handle(domain.JobBuildReport, func(ctx context.Context, payload json.RawMessage) error {
+			return fmt.Errorf("%w: unknown report kind", domain.ErrJobRejected)
+		})
+
+		_, err := svc.Enqueue(context.Background(), domain.JobBuildReport, domain.ReportJob{})
+		require.NoError(t, err)
+		svc.runDue(context.Background())
+
+		assert.Empty(t, queue.queued)
+		assert.Len(t, queue.dead, 1)
+	})
+
+	t.Run("jobs without handler", func(t *testing.T) {
+		queue := &memoryJobQueue{}
+		svc := NewJobQueueService(queue, &memoryErrorReporter{}, 5, time.Minute, time.Hour, 1)
+
+		_, err := svc.Enqueue(context.Background(), "unknown", struct{}{})
+		require.NoError(t, err)
+		svc.runDue(context.Background())
+
+		assert.Len(t, queue.dead, 1)
+	})
+
+	t.Run("panicking handlers", func(t *testing.T) {
+		queue := &memoryJobQueue{}
+		reporter := &memoryErrorReporter{}
+		svc := NewJobQueueService(queue, reporter, 1, time.Minute, time.Hour, 1)
+		svc.Handle(domain.JobNotify, func(ctx context.Context, payload json.RawMessage) error {
+			panic("nil map")
+		})
+
+		_, err := svc.Enqueue(context.Background(), domain.JobNotify, domain.NotifyJob{})
+		require.NoError(t, err)
+		svc.runDue(context.Background())
+
+		require.Len(t, queue.dead, 1)
+		assert.Equal(t, "job panicked: nil map", queue.dead[0].LastError)
+		assert.Equal(t, []any{"nil map"}, reporter.panics)
+	})
+
+	t.Run("retried with fresh attempts", func(t *testing.T) {
+		queue := &memoryJobQueue{}
+		svc := NewJobQueueService(queue, &memoryErrorReporter{}, 1, time.Minute, time.Hour, 1)
+		svc.Handle(domain.JobNotify, failing)
+
+		job, err := svc.Enqueue(context.Background(), domain.JobNotify, domain.NotifyJob{})
+		require.NoError(t, err)
+		svc.runDue(context.Background())
+
+		retried, err := svc.RetryDeadLetter(context.Background(), job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, retried.Attempts)
+		assert.Empty(t, queue.dead)
+		assert.Len(t, queue.queued, 1)
+
+		_, err = svc.RetryDeadLetter(context.Background(), uuid.New())
+		assert.Equal(t, domain.ErrDataNotFound, err)
+	})
+}
+
+func TestJobQueueManagement(t *testing.T) {
+	queue := &memoryJobQueue{}
+	svc := NewJobQueueService(queue, &memoryErrorReporter{}, 1, time.Minute, time.Hour, 1)
+	svc.Handle(domain.JobNotify, func(ctx context.Context, payload json.RawMessage) error {
+		return errors.New("connection refused")
+	})
+
+	dead, err := svc.Enqueue(context.Background(), domain.JobNotify, domain.NotifyJob{})
+	require.NoError(t, err)
+	svc.runDue(context.Background())
+
+	deferred, err := svc.Enqueue(context.Background(), domain.JobNotify, domain.NotifyJob{Template: "quote_created"})
+	require.NoError(t, err)
+	deferred.Attempts = 2
+	deferred.NextAttemptAt = time.Now().Add(time.Hour)
+
+	t.Run("get finds queued and dead-lettered jobs", func(t *testing.T) {
+		job, status, err := svc.GetJob(context.Background(), deferred.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.JobQueued, status)
+		assert.JSONEq(t, `{"recipientIds":null,"template":"quote_created","data":null}`, string(job.Payload))
+
+		job, status, err = svc.GetJob(context.Background(), dead.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.JobDead, status)
+		assert.Equal(t, "connection refused", job.LastError)
+
+		_, _, err = svc.GetJob(context.Background(), uuid.New())
+		assert.Equal(t, domain.ErrDataNotFound, err)
+	})
+
+	t.Run("retry runs a deferred job now keeping its attempts", func(t *testing.T) {
+		job, err := svc.RetryJob(context.Background(), deferred.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, job.Attempts)
+		assert.WithinDuration(t, time.Now(), job.NextAttemptAt, 5*time.Second)
+
+		queued, err := svc.ListQueued(context.Background(), 1, 10)
+		require.NoError(t, err)
+		assert.Len(t, queued, 1)
+	})
+
+	t.Run("discard deletes queued and dead-lettered jobs", func(t *testing.T) {
+		require.NoError(t, svc.DiscardJob(context.Background(), deferred.ID))
+		require.NoError(t, svc.DiscardJob(context.Background(), dead.ID))
+		assert.Empty(t, queue.queued)
+		assert.Empty(t, queue.dead)
+
+		assert.Equal(t, domain.ErrDataNotFound, svc.DiscardJob(context.Background(), dead.ID))
+	})
+}
+
+func TestJobQueueRunStopsWithContext(t *testing.T) {
+	svc := NewJobQueueService(&memoryJobQueue{}, &memoryErrorReporter{}, 3, time.Minute, 10*time.Millisecond, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Run(ctx)
+		close(done)
+	}()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run didn't return after the context was cancelled")
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+)
+
+/**
+ * LogLevelService implements port.LogLevelService interface
+ * and changes the level of the logs of this server, each server keeps its own
+ */
+type LogLevelService struct {
+	level *slog.LevelVar
+	// initial is the level on startup, temporary changes go back to it
+	initial slog.Level
+
+	mu      sync.Mutex
+	reset   *time.Timer
+	resetAt *time.Time
+}
+
+// NewLogLevelService creates a new log level service instance changing level
+func NewLogLevelService(level *slog.LevelVar) *LogLevelService {
+	return &LogLevelService{
+		level:   level,
+		initial: level.Level(),
+	}
+}
+
+// GetLogLevel returns the current level of the logs
+func (ls *LogLevelService) GetLogLevel(ctx context.Context) *domain.LogLevel {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	return &domain.LogLevel{
+		Level:   ls.level.Level(),
+		ResetAt: ls.resetAt,
+	}
+}
+
+// SetLogLevel changes the level of the logs, back to the startup level after duration unless it is 0.
+// A change replaces the pending reset of the previous one
+func (ls *LogLevelService) SetLogLevel(ctx context.Context, level slog.Level, duration time.Duration) *domain.LogLevel {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if ls.reset != nil {
+		ls.reset.Stop()
+		ls.reset, ls.resetAt = nil, nil
+	}
+
+	previous := ls.level.Level()
+	ls.level.Set(level)
+	// Logged at warn so the change shows up whatever the new level is
+	slog.WarnContext(ctx, "Log level changed", "from", previous, "to", level, "duration", duration)
+
+	if duration > 0 {
+		resetAt := time.Now().Add(duration)
+		ls.resetAt = &resetAt
+
+		var timer *time.Timer
+		timer = time.AfterFunc(duration, func() {
+			ls.mu.Lock()
+			defer ls.mu.Unlock()
+
+			// A later change replaced this one
+			if ls.reset != timer {
+				return
+			}
+			ls.level.Set(ls.initial)
+			ls.reset, ls.resetAt = nil, nil
+			slog.WarnContext(ctx, "Log level reset", "to", ls.initial)
+		})
+		ls.reset = timer
+	}
+
+	return &domain.LogLevel{
+		Level:   level,
+		ResetAt: ls.resetAt,
+	}
+}
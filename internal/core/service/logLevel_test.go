@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetLogLevel(t *testing.T) {
+	level := new(slog.LevelVar)
+	level.Set(slog.LevelInfo)
+	svc := NewLogLevelService(level)
+	ctx := context.Background()
+
+	changed := svc.SetLogLevel(ctx, slog.LevelWarn, 0)
+	assert.Equal(t, slog.LevelWarn, changed.Level)
+	assert.Nil(t, changed.ResetAt)
+	assert.Equal(t, slog.LevelWarn, level.Level())
+}
+
+func TestSetLogLevelResets(t *testing.T) {
+	level := new(slog.LevelVar)
+	level.Set(slog.LevelInfo)
+	svc := NewLogLevelService(level)
+	ctx := context.Background()
+
+	changed := svc.SetLogLevel(ctx, slog.LevelDebug, 20*time.Millisecond)
+	require.NotNil(t, changed.ResetAt)
+	assert.Equal(t, slog.LevelDebug, level.Level())
+
+	// The level goes back to the startup one, not to the one before the change
+	assert.Eventually(t, func() bool {
+		return level.Level() == slog.LevelInfo && svc.GetLogLevel(ctx).ResetAt == nil
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestSetLogLevelReplacesPendingReset(t *testing.T) {
+	level := new(slog.LevelVar)
+	level.Set(slog.LevelInfo)
+	svc := NewLogLevelService(level)
+	ctx := context.Background()
+
+	svc.SetLogLevel(ctx, slog.LevelDebug, 20*time.Millisecond)
+	svc.SetLogLevel(ctx, slog.LevelError, 0)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, slog.LevelError, level.Level())
+	assert.Nil(t, svc.GetLogLevel(ctx).ResetAt)
+}
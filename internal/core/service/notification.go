@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+)
+
+/**
+ * NotificationService implements port.NotificationService interface
+ * and delivers every event as an in-app notification, an email, a push notification to the devices
+ * the users registered and, to the users who opted in, an SMS or WhatsApp message.
+ * Notifications change on every event and read, so they are not cached.
+ * With a job queue the events are delivered by the workers, off the request path
+ */
+type NotificationService struct {
+	repo      port.NotificationRepository
+	devices   port.DeviceRepository
+	users     port.UserRepository
+	email     port.EmailRepository
+	sms       port.MessageSender
+	whatsApp  port.MessageSender
+	push      port.MessageSender
+	templates port.EmailTemplateRenderer
+	jobs      port.JobQueueService
+}
+
+// NewNotificationService creates a new notification service instance, sms, whatsApp and push are nil when their channel is disabled
+// and jobs is nil to deliver the events while notifying
+func NewNotificationService(
+	repo port.NotificationRepository,
+	devices port.DeviceRepository,
+	users port.UserRepository,
+	email port.EmailRepository,
+	sms port.MessageSender,
+	whatsApp port.MessageSender,
+	push port.MessageSender,
+	templates port.EmailTemplateRenderer,
+	jobs port.JobQueueService,
+) *NotificationService {
+	s := &NotificationService{
+		repo,
+		devices,
+		users,
+		email,
+		sms,
+		whatsApp,
+		push,
+		templates,
+		jobs,
+	}
+	if jobs != nil {
+		jobs.Handle(domain.JobNotify, s.runNotifyJob)
+	}
+
+	return s
+}
+
+// Notify queues the event for the workers to deliver it, or delivers it right away without a job queue
+func (s *NotificationService) Notify(ctx context.Context, recipients []domain.User, template domain.EmailTemplate, data any) error {
+	if len(recipients) == 0 {
+		return nil
+	}
+	if s.jobs == nil {
+		return s.deliver(ctx, recipients, template, data)
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]uuid.UUID, len(recipients))
+	for i, recipient := range recipients {
+		ids[i] = recipient.ID
+	}
+
+	_, err = s.jobs.Enqueue(ctx, domain.JobNotify, domain.NotifyJob{
+		RecipientIDs: ids,
+		Template:     template,
+		Data:         payload,
+	})
+	return err
+}
+
+// runNotifyJob delivers a queued event to its recipients that still exist, with their current preferences
+func (s *NotificationService) runNotifyJob(ctx context.Context, payload json.RawMessage) error {
+	var job domain.NotifyJob
+	if err := decodeJob(payload, &job); err != nil {
+		return err
+	}
+
+	data, ok := job.Template.NewData()
+	if !ok {
+		return fmt.Errorf("%w: unknown notification template %q", domain.ErrJobRejected, job.Template)
+	}
+	if err := decodeJob(job.Data, data); err != nil {
+		return err
+	}
+
+	recipients := make([]domain.User, 0, len(job.RecipientIDs))
+	for _, id := range job.RecipientIDs {
+		user, err := s.users.GetUserByID(ctx, id)
+		if err == domain.ErrDataNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		recipients = append(recipients, *user)
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	// The templates take the data by value
+	return s.deliver(ctx, recipients, job.Template, reflect.ValueOf(data).Elem().Interface())
+}
+
+// emailAudience is the salon and language of the recipients sent the same email
+type emailAudience struct {
+	salon uuid.UUID
+	lang  domain.Language
+}
+
+// deliver records an in-app notification for every recipient, emails those who accept emails in their language and,
+// when the event has a short message, pushes it to their devices and messages the phones of those with SMS or
+// WhatsApp enabled, every channel is attempted even when another fails
+func (s *NotificationService) deliver(ctx context.Context, recipients []domain.User, template domain.EmailTemplate, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	notifications := make([]domain.Notification, len(recipients))
+	for i, recipient := range recipients {
+		notifications[i] = domain.Notification{
+			ID:        uuid.New(),
+			UserID:    recipient.ID,
+			Type:      template,
+			Payload:   payload,
+			CreatedAt: now,
+			SalonID:   recipient.Salon(),
+		}
+	}
+
+	notifyErr := s.repo.CreateNotifications(ctx, notifications)
+	if notifyErr != nil {
+		slog.ErrorContext(ctx, "Notification creation failed", "type", template, "error", notifyErr)
+	}
+
+	// One email per salon and language so every recipient reads it in theirs, and its delivery is logged in their salon
+	byLanguage := make(map[emailAudience][]string)
+	for _, recipient := range recipientsFor(recipients, domain.ChannelEmail) {
+		audience := emailAudience{recipient.Salon(), recipient.PreferredLanguage.OrDefault()}
+		byLanguage[audience] = append(byLanguage[audience], recipient.Email)
+	}
+
+	emailErr := error(nil)
+	for audience, emails := range byLanguage {
+		if err := sendTemplatedEmail(util.WithSalon(ctx, audience.salon), s.email, s.templates, emails, audience.lang, template, data); err != nil {
+			slog.ErrorContext(ctx, "Notification email failed", "type", template, "language", audience.lang, "error", err)
+			emailErr = err
+		}
+	}
+
+	messageErr := s.sendMessages(ctx, recipients, domain.ChannelSMS, s.sms, template, data)
+	if err := s.sendMessages(ctx, recipients, domain.ChannelWhatsApp, s.whatsApp, template, data); err != nil {
+		messageErr = err
+	}
+	if err := s.sendPush(ctx, recipients, notifications, template, data); err != nil {
+		messageErr = err
+	}
+
+	if notifyErr != nil {
+		return notifyErr
+	}
+	if emailErr != nil {
+		return emailErr
+	}
+	return messageErr
+}
+
+// sendMessages sends the short message of the event through a phone channel to the recipients who enabled it
+func (s *NotificationService) sendMessages(ctx context.Context, recipients []domain.User, channel domain.NotificationChannel, sender port.MessageSender, template domain.EmailTemplate, data any) error {
+	if sender == nil {
+		return nil
+	}
+
+	var messageErr error
+	for _, recipient := range recipientsFor(recipients, channel) {
+		message, err := s.templates.RenderMessage(template, recipient.PreferredLanguage, data)
+		if err != nil {
+			return err
+		}
+		// The event has no short message, so no recipient is messaged
+		if message == nil {
+			return nil
+		}
+		message.To = recipient.Phone
+
+		if err := sender.SendMessage(ctx, message); err != nil {
+			slog.ErrorContext(ctx, "Notification message failed", "type", template, "channel", channel, "user_id", recipient.ID, "error", err)
+			messageErr = err
+		}
+	}
+
+	return messageErr
+}
+
+// sendPush pushes the short message of the event, or the subject of its email when it has none, to every device
+// of the recipients along with the in-app notification it matches so the app can open it.
+// Tokens the provider no longer knows are forgotten
+func (s *NotificationService) sendPush(ctx context.Context, recipients []domain.User, notifications []domain.Notification, template domain.EmailTemplate, data any) error {
+	if s.push == nil {
+		return nil
+	}
+
+	users := make(map[uuid.UUID]domain.User)
+	var userIDs []uuid.UUID
+	for _, recipient := range recipientsFor(recipients, domain.ChannelPush) {
+		users[recipient.ID] = recipient
+		userIDs = append(userIDs, recipient.ID)
+	}
+
+	devices, err := s.devices.ListDevicesByUsers(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+
+	notificationIDs := make(map[uuid.UUID]uuid.UUID, len(notifications))
+	for _, n := range notifications {
+		notificationIDs[n.UserID] = n.ID
+	}
+
+	var pushErr error
+	for _, device := range devices {
+		body, err := s.pushBody(template, users[device.UserID].PreferredLanguage, data)
+		if err != nil {
+			return err
+		}
+
+		err = s.push.SendMessage(ctx, &domain.Message{
+			To:   device.Token,
+			Body: body,
+			Data: map[string]string{
+				"type":           string(template),
+				"notificationId": notificationIDs[device.UserID].String(),
+			},
+		})
+		if errors.Is(err, domain.ErrDeviceUnregistered) {
+			slog.InfoContext(ctx, "Forgetting unregistered device", "user_id", device.UserID, "platform", device.Platform)
+			if err := s.devices.DeleteDeviceByToken(ctx, device.Token); err != nil {
+				slog.ErrorContext(ctx, "Device deletion failed", "user_id", device.UserID, "error", err)
+			}
+			continue
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Notification push failed", "type", template, "user_id", device.UserID, "platform", device.Platform, "error", err)
+			pushErr = err
+		}
+	}
+
+	return pushErr
+}
+
+// pushBody renders the text pushed for the event in lang
+func (s *NotificationService) pushBody(template domain.EmailTemplate, lang domain.Language, data any) (string, error) {
+	message, err := s.templates.RenderMessage(template, lang, data)
+	if err != nil {
+		return "", err
+	}
+	if message != nil {
+		return message.Body, nil
+	}
+
+	email, err := s.templates.Render(template, lang, data)
+	if err != nil {
+		return "", err
+	}
+	return email.Subject, nil
+}
+
+// recipientsFor keeps the recipients whose preferences accept notifications through channel,
+// phone channels also need a phone to send to
+func recipientsFor(recipients []domain.User, channel domain.NotificationChannel) []domain.User {
+	var accepted []domain.User
+	for _, recipient := range recipients {
+		if !recipient.NotificationPreferences.Allows(channel) {
+			continue
+		}
+		if (channel == domain.ChannelSMS || channel == domain.ChannelWhatsApp) && recipient.Phone == "" {
+			continue
+		}
+		accepted = append(accepted, recipient)
+	}
+	return accepted
+}
+
+// notify hands an event to the notification service on behalf of another service, which honors every
+// recipient's preferences channel by channel. Notifications are best-effort, so a failure is only logged
+func notify(ctx context.Context, notification port.NotificationService, recipients []domain.User, template domain.EmailTemplate, data any, attrs ...any) {
+	if err := notification.Notify(ctx, recipients, template, data); err != nil {
+		slog.WarnContext(ctx, "notification failed", append(attrs, "type", template, "error", err)...)
+	}
+}
+
+// ListNotifications returns a user's notifications and how many of them are unread
+func (s *NotificationService) ListNotifications(ctx context.Context, filter port.NotificationFilter) ([]domain.Notification, uint64, error) {
+	notifications, err := s.repo.ListNotifications(ctx, filter)
+	if err != nil {
+		return nil, 0, domain.ErrInternal
+	}
+
+	unread, err := s.repo.CountUnread(ctx, filter.UserID)
+	if err != nil {
+		return nil, 0, domain.ErrInternal
+	}
+
+	return notifications, unread, nil
+}
+
+// MarkAsRead marks one of a user's notifications as read
+func (s *NotificationService) MarkAsRead(ctx context.Context, userID, id uuid.UUID) error {
+	err := s.repo.MarkAsRead(ctx, userID, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return err
+		}
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+// MarkAllAsRead marks every notification of a user as read
+func (s *NotificationService) MarkAllAsRead(ctx context.Context, userID uuid.UUID) error {
+	err := s.repo.MarkAllAsRead(ctx, userID)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	return nil
+}
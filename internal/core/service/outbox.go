@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+)
+
+const (
+	// outboxBatchSize is how many messages a relay claims at once
+	outboxBatchSize = 100
+	// outboxLease is how long a claimed message is held, a relay that stops meanwhile leaves it to the next one
+	outboxLease = time.Minute
+	// maxOutboxRetryDelay caps the exponential backoff between attempts
+	maxOutboxRetryDelay = time.Hour
+)
+
+/**
+ * OutboxService implements port.OutboxService interface
+ * and relays the messages services write to the outbox along with their changes: notifications are queued
+ * for the workers and events are published. A message is retried until it is relayed, it is never given up on
+ */
+type OutboxService struct {
+	repo         port.OutboxRepository
+	jobs         port.JobQueueService
+	events       port.EventPublisher
+	retryDelay   time.Duration
+	pollInterval time.Duration
+}
+
+// NewOutboxService creates a new outbox service instance
+func NewOutboxService(
+	repo port.OutboxRepository,
+	jobs port.JobQueueService,
+	events port.EventPublisher,
+	retryDelay time.Duration,
+	pollInterval time.Duration,
+) *OutboxService {
+	return &OutboxService{
+		repo,
+		jobs,
+		events,
+		retryDelay,
+		pollInterval,
+	}
+}
+
+// Run relays the due messages every poll interval until ctx is done
+func (s *OutboxService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.relayDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relayDue relays batches of due messages until none is left
+func (s *OutboxService) relayDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		messages, err := s.repo.ClaimOutboxMessages(ctx, time.Now(), outboxLease, outboxBatchSize)
+		if err != nil {
+			slog.ErrorContext(ctx, "Outbox claim failed", "error", err)
+			return
+		}
+
+		// Claimed messages are relayed even when the relay is stopping
+		for _, message := range messages {
+			s.relay(context.WithoutCancel(ctx), &message)
+		}
+
+		if len(messages) < outboxBatchSize {
+			return
+		}
+	}
+}
+
+// relay hands the message over and deletes it, or makes it due again with backoff when that fails
+func (s *OutboxService) relay(ctx context.Context, message *domain.OutboxMessage) {
+	err := s.handOver(ctx, message)
+	if err == nil {
+		err = s.repo.DeleteOutboxMessage(ctx, message.ID)
+		if err != nil {
+			// The lease runs out and the message is relayed again, the job and event IDs let receivers spot it
+			slog.ErrorContext(ctx, "Outbox message deletion failed", "id", message.ID, "kind", message.Kind, "error", err)
+		}
+		return
+	}
+
+	delay := s.backoff(message.Attempts)
+	slog.WarnContext(ctx, "Outbox relay failed, retrying", "id", message.ID, "kind", message.Kind, "attempts", message.Attempts, "retry_in", delay, "error", err)
+
+	err = s.repo.RetryOutboxMessage(ctx, message.ID, time.Now().Add(delay), err.Error())
+	if err != nil {
+		slog.ErrorContext(ctx, "Outbox message retry failed", "id", message.ID, "kind", message.Kind, "error", err)
+	}
+}
+
+// handOver queues a notification for the workers or publishes an event
+func (s *OutboxService) handOver(ctx context.Context, message *domain.OutboxMessage) error {
+	switch message.Kind {
+	case domain.OutboxNotification:
+		// The payload is the NotifyJob as is
+		_, err := s.jobs.Enqueue(ctx, domain.JobNotify, message.Payload)
+		return err
+	case domain.OutboxEvent:
+		var payload domain.OutboxEventPayload
+		if err := json.Unmarshal(message.Payload, &payload); err != nil {
+			return err
+		}
+
+		s.events.Publish(ctx, domain.Event{
+			ID:         payload.ID,
+			Type:       payload.Type,
+			Payload:    payload.Payload,
+			OccurredAt: payload.OccurredAt,
+			SalonID:    payload.SalonID,
+		})
+		return nil
+	default:
+		return fmt.Errorf("unknown outbox message kind %q", message.Kind)
+	}
+}
+
+// backoff returns the wait before the next attempt, doubling the retry delay after every failed one
+func (s *OutboxService) backoff(attempts int) time.Duration {
+	delay := s.retryDelay
+	for i := 1; i < attempts && delay < maxOutboxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxOutboxRetryDelay)
+}
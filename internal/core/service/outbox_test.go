@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryOutbox is a port.OutboxRepository keeping the messages in memory
+type memoryOutbox struct {
+	messages map[uuid.UUID]*domain.OutboxMessage
+}
+
+func (o *memoryOutbox) CreateOutboxMessages(ctx context.Context, messages ...*domain.OutboxMessage) error {
+	for _, m := range messages {
+		o.messages[m.ID] = m
+	}
+	return nil
+}
+
+func (o *memoryOutbox) ClaimOutboxMessages(ctx context.Context, now time.Time, lease time.Duration, limit uint64) ([]domain.OutboxMessage, error) {
+	var claimed []domain.OutboxMessage
+	for _, m := range o.messages {
+		if m.AvailableAt.After(now) || uint64(len(claimed)) == limit {
+			continue
+		}
+		m.AvailableAt = now.Add(lease)
+		m.Attempts++
+		claimed = append(claimed, *m)
+	}
+	return claimed, nil
+}
+
+func (o *memoryOutbox) DeleteOutboxMessage(ctx context.Context, id uuid.UUID) error {
+	delete(o.messages, id)
+	return nil
+}
+
+func (o *memoryOutbox) RetryOutboxMessage(ctx context.Context, id uuid.UUID, availableAt time.Time, lastError string) error {
+	o.messages[id].AvailableAt = availableAt
+	o.messages[id].LastError = lastError
+	return nil
+}
+
+// recordingPublisher is a port.EventPublisher keeping the published events
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []domain.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event domain.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+}
+
+func TestOutboxRelaysMessages(t *testing.T) {
+	ctx := context.Background()
+	outbox := &memoryOutbox{messages: map[uuid.UUID]*domain.OutboxMessage{}}
+	queue := &memoryJobQueue{}
+	publisher := &recordingPublisher{}
+	svc := NewOutboxService(outbox, NewJobQueueService(queue, &memoryErrorReporter{}, 3, time.Minute, time.Hour, 1), publisher, time.Minute, time.Hour)
+
+	quoteID := uuid.New()
+	notification, err := domain.NewOutboxNotification([]domain.User{{ID: uuid.New()}}, domain.EmailQuoteStateChanged, domain.QuoteStateEmail{QuoteID: quoteID, State: domain.QuoteExpired})
+	require.NoError(t, err)
+	published := domain.NewEvent(domain.EventQuoteCreated, domain.QuoteCreatedEvent{QuoteID: quoteID})
+	published.SalonID = uuid.New()
+	event, err := domain.NewOutboxEvent(published)
+	require.NoError(t, err)
+	require.NoError(t, outbox.CreateOutboxMessages(ctx, notification, event))
+
+	svc.relayDue(ctx)
+
+	assert.Empty(t, outbox.messages)
+
+	require.Len(t, queue.queued, 1)
+	assert.Equal(t, domain.JobNotify, queue.queued[0].Type)
+	var job domain.NotifyJob
+	require.NoError(t, json.Unmarshal(queue.queued[0].Payload, &job))
+	assert.Equal(t, domain.EmailQuoteStateChanged, job.Template)
+
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, domain.EventQuoteCreated, publisher.events[0].Type)
+	// The event is published in the salon it happened in, for its webhooks and admins only
+	assert.Equal(t, published.SalonID, publisher.events[0].SalonID)
+	payload, err := json.Marshal(publisher.events[0].Payload)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"quoteId":"`+quoteID.String()+`","clientId":"00000000-0000-0000-0000-000000000000","description":""}`, string(payload))
+}
+
+func TestOutboxRetriesFailedMessages(t *testing.T) {
+	ctx := context.Background()
+	outbox := &memoryOutbox{messages: map[uuid.UUID]*domain.OutboxMessage{}}
+	svc := NewOutboxService(outbox, nil, &recordingPublisher{}, time.Minute, time.Hour)
+
+	message := &domain.OutboxMessage{ID: uuid.New(), Kind: "unknown", AvailableAt: time.Now()}
+	require.NoError(t, outbox.CreateOutboxMessages(ctx, message))
+
+	svc.relayDue(ctx)
+
+	require.Contains(t, outbox.messages, message.ID)
+	assert.Equal(t, 1, message.Attempts)
+	assert.Contains(t, message.LastError, "unknown outbox message kind")
+	assert.WithinDuration(t, time.Now().Add(time.Minute), message.AvailableAt, 5*time.Second)
+}
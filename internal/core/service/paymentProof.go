@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"io"
 	"log/slog"
+	"path/filepath"
 
 	"harajuku/backend/internal/adapter/storage/postgres"
 	"harajuku/backend/internal/adapter/storage/postgres/repository"
@@ -17,16 +19,16 @@ type PaymentProofService struct {
 	repo      port.PaymentProofRepository
 	file      port.FileRepository
 	quoteRepo port.QuoteRepository
-	email     port.EmailRepository
+	user      port.UserRepository
 	db        postgres.DB
-	cache     port.CacheRepository
+	cache     *CachedRepository[domain.PaymentProof]
 }
 
 func NewPaymentProofService(
 	repo port.PaymentProofRepository,
 	file port.FileRepository,
 	quoteRepo port.QuoteRepository,
-	email port.EmailRepository,
+	user port.UserRepository,
 	db postgres.DB,
 	cache port.CacheRepository,
 ) *PaymentProofService {
@@ -34,16 +36,16 @@ func NewPaymentProofService(
 		repo:      repo,
 		file:      file,
 		quoteRepo: quoteRepo,
-		email:     email,
+		user:      user,
 		db:        db,
-		cache:     cache,
+		cache:     NewCachedRepository[domain.PaymentProof](cache, "paymentProof", "paymentProofs"),
 	}
 }
 
 // CreatePaymentProof carga la imagen y crea el registro asociado a una cotización
-func (ps *PaymentProofService) CreatePaymentProof(ctx context.Context, proof *domain.PaymentProof, file []byte, fileName string) (*domain.PaymentProof, error) {
+func (ps *PaymentProofService) CreatePaymentProof(ctx context.Context, proof *domain.PaymentProof, file io.Reader, size int64, fileName string) (*domain.PaymentProof, error) {
 	// Validar que la cotización exista
-	_, err := ps.quoteRepo.GetQuoteByID(ctx, proof.QuoteID)
+	quote, err := ps.quoteRepo.GetQuoteByID(ctx, proof.QuoteID)
 	if err != nil {
 		return nil, domain.ErrDataNotFound
 	}
@@ -51,22 +53,66 @@ func (ps *PaymentProofService) CreatePaymentProof(ctx context.Context, proof *do
 	// Verificar que no exista ya un comprobante para la cotización
 	existing, err := ps.repo.GetPaymentProofByQuoteID(ctx, proof.QuoteID)
 	if err != nil && err != domain.ErrDataNotFound {
-		slog.Error("failed to get existing payment proof", "error", err)
+		slog.ErrorContext(ctx, "failed to get existing payment proof", "error", err)
 		return nil, domain.ErrInternal
 	}
 	if existing != nil {
-		slog.Warn("payment proof already exists for quote", "quoteID", proof.QuoteID)
+		slog.WarnContext(ctx, "payment proof already exists for quote", "quoteID", proof.QuoteID)
 		return nil, domain.ErrConflictingData
 	}
 
+	// Strip EXIF/GPS metadata and apply the orientation before anything is stored
+	file, size, err = util.NormalizeImage(file, size)
+	if err != nil {
+		slog.ErrorContext(ctx, "normalizing image failed", "error", err)
+		if err == domain.ErrInvalidImage || err == domain.ErrImageTooLarge || err == domain.ErrFileTooLarge {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	inspector, err := util.NewFileInspector(file)
+	if err != nil {
+		slog.ErrorContext(ctx, "reading file failed", "error", err)
+		return nil, domain.ErrInternal
+	}
+
 	// Upload the image first. Fail fast if this errors.
-	path, err := ps.file.Save(ctx, file, fileName)
+	path, err := ps.file.Save(ctx, inspector, size, fileName)
 	if err != nil {
-		slog.Error("file save failed", "error", err)
+		slog.ErrorContext(ctx, "file save failed", "error", err)
+		if err == domain.ErrInfectedFile {
+			return nil, err
+		}
 		return nil, domain.ErrInternal
 	}
 
-	// Atomic DB transaction: create quote + image row
+	proof.FileName = filepath.Base(fileName)
+	proof.Size = inspector.Size()
+	proof.ContentType = inspector.ContentType()
+	proof.Checksum = inspector.Checksum()
+
+	// The same receipt cannot back the payment of another quote
+	duplicates, err := ps.repo.GetPaymentProofs(ctx, port.PaymentProofFilter{Checksum: &proof.Checksum, Limit: 1})
+	if err != nil {
+		slog.ErrorContext(ctx, "checking duplicate payment proof failed", "error", err)
+		_ = ps.file.Delete(ctx, path) // limpiar archivo
+		return nil, domain.ErrInternal
+	}
+	if len(duplicates) > 0 {
+		slog.WarnContext(ctx, "payment proof already uploaded", "quoteID", duplicates[0].QuoteID)
+		_ = ps.file.Delete(ctx, path) // limpiar archivo
+		return nil, domain.ErrDuplicateFile
+	}
+
+	// Los administradores se avisan por el outbox, solo cuando el comprobante queda guardado
+	admins, err := ps.user.GetAdmins(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "could not fetch admins", "error", err)
+	}
+	client, _ := ps.user.GetUserByID(ctx, quote.ClientID)
+
+	// Atomic DB transaction: create quote + image row + outbox messages
 	var created *domain.PaymentProof
 
 	err = ps.db.WithTx(ctx, func(txDB *postgres.DB) error {
@@ -83,45 +129,51 @@ func (ps *PaymentProofService) CreatePaymentProof(ctx context.Context, proof *do
 			return err
 		}
 		created = p
-		return nil
+
+		// el evento se guarda ya, él y el aviso se entregan cuando la transacción se confirma
+		event, err := recordEvent(ctx, txDB, domain.NewEvent(domain.EventPaymentProofUploaded, domain.PaymentProofUploadedEvent{
+			PaymentProofID: created.ID,
+			QuoteID:        created.QuoteID,
+		}))
+		if err != nil {
+			return err
+		}
+		messages := []*domain.OutboxMessage{event}
+
+		if len(admins) > 0 {
+			notification, err := domain.NewOutboxNotification(admins, domain.EmailPaymentProofUploaded, domain.PaymentProofUploadedEmail{
+				PaymentProofID: created.ID,
+				QuoteID:        created.QuoteID,
+				ClientName:     fullName(client),
+			})
+			if err != nil {
+				return err
+			}
+			messages = append(messages, notification)
+		}
+
+		return repository.NewOutboxRepository(txDB).CreateOutboxMessages(ctx, messages...)
 	})
 
 	if err != nil {
-		slog.Error("transaction failed", "error", err)
+		slog.ErrorContext(ctx, "transaction failed", "error", err)
 		_ = ps.file.Delete(ctx, path) // limpiar archivo
 		return nil, domain.ErrInternal
 	}
 
 	// Cachear
-	cacheKey := util.GenerateCacheKey("paymentProof", created.ID)
-	data, _ := util.Serialize(created)
-	if err := ps.cache.Set(ctx, cacheKey, data, 0); err != nil {
-		slog.Warn("cache set failed", "error", err)
+	if err := ps.cache.Store(ctx, created.ID, created); err != nil {
+		slog.WarnContext(ctx, "cache set failed", "error", err)
 	}
-	_ = ps.cache.DeleteByPrefix(ctx, "paymentProofs:*")
 
 	return created, nil
 }
 
 // GetPaymentProofByID obtiene comprobante por ID con cache y archivo desde S3
 func (ps *PaymentProofService) GetPaymentProofByID(ctx context.Context, id uuid.UUID) (*domain.PaymentProof, []byte, error) {
-	var proof *domain.PaymentProof
-	cacheKey := util.GenerateCacheKey("paymentProof", id)
-
-	cached, err := ps.cache.Get(ctx, cacheKey)
-	if err == nil {
-		err = util.Deserialize(cached, &proof)
-		if err == nil {
-			// obtener archivo desde S3
-			file, err := ps.file.Get(ctx, proof.URL)
-			if err != nil {
-				return nil, nil, domain.ErrInternal
-			}
-			return proof, file, nil
-		}
-	}
-
-	proof, err = ps.repo.GetPaymentProofByID(ctx, id)
+	proof, err := ps.cache.Get(ctx, id, func() (*domain.PaymentProof, error) {
+		return ps.repo.GetPaymentProofByID(ctx, id)
+	})
 	if err != nil {
 		if err == domain.ErrDataNotFound {
 			return nil, nil, err
@@ -129,11 +181,6 @@ func (ps *PaymentProofService) GetPaymentProofByID(ctx context.Context, id uuid.
 		return nil, nil, domain.ErrInternal
 	}
 
-	data, err := util.Serialize(proof)
-	if err == nil {
-		_ = ps.cache.Set(ctx, cacheKey, data, 0)
-	}
-
 	// obtener archivo desde S3
 	file, err := ps.file.Get(ctx, proof.URL)
 	if err != nil {
@@ -144,31 +191,27 @@ func (ps *PaymentProofService) GetPaymentProofByID(ctx context.Context, id uuid.
 }
 
 // GetPaymentProofs lista comprobantes con filtro y cache
-func (ps *PaymentProofService) GetPaymentProofs(ctx context.Context, filter port.PaymentProofFilter) ([]domain.PaymentProof, error) {
-	var proofs []domain.PaymentProof
-
+func (ps *PaymentProofService) GetPaymentProofs(ctx context.Context, filter port.PaymentProofFilter) ([]domain.PaymentProof, uint64, error) {
 	params := util.GenerateCacheKeyParams(filter)
-	cacheKey := util.GenerateCacheKey("paymentProofs", params)
 
-	cached, err := ps.cache.Get(ctx, cacheKey)
-	if err == nil {
-		err = util.Deserialize(cached, &proofs)
-		if err == nil {
-			return proofs, nil
+	proofs, total, err := ps.cache.ListPage(ctx, params, func() ([]domain.PaymentProof, uint64, error) {
+		proofs, err := ps.repo.GetPaymentProofs(ctx, filter)
+		if err != nil {
+			return nil, 0, err
 		}
-	}
 
-	proofs, err = ps.repo.GetPaymentProofs(ctx, filter)
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
+		total, err := ps.repo.CountPaymentProofs(ctx, filter)
+		if err != nil {
+			return nil, 0, err
+		}
 
-	data, err := util.Serialize(proofs)
-	if err == nil {
-		_ = ps.cache.Set(ctx, cacheKey, data, 0)
+		return proofs, total, nil
+	})
+	if err != nil {
+		return nil, 0, domain.ErrInternal
 	}
 
-	return proofs, nil
+	return proofs, total, nil
 }
 
 // UpdatePaymentProof permite actualizar el campo IsReviewed (por ejemplo)
@@ -185,7 +228,37 @@ func (ps *PaymentProofService) UpdatePaymentProof(ctx context.Context, proof *do
 		return nil, domain.ErrNoUpdatedData
 	}
 
-	updated, err := ps.repo.UpdatePaymentProof(ctx, proof)
+	// La revisión se guarda y se publica en la misma transacción que la actualización
+	var updated *domain.PaymentProof
+	err = ps.db.WithTx(ctx, func(txDB *postgres.DB) error {
+		updated, err = repository.NewPaymentProofRepository(txDB).UpdatePaymentProof(ctx, proof)
+		if err != nil {
+			return err
+		}
+
+		// La aprobación del pago queda en la auditoría junto con el cambio, o ninguno de los dos
+		action := domain.AuditPaymentApprovalUndone
+		if updated.IsReviewed {
+			action = domain.AuditPaymentApproved
+		}
+		err = repository.NewAuditRepository(txDB).CreateAuditEntry(ctx, domain.NewAuditEntry(util.Actor(ctx), domain.AuditEntityPaymentProof, updated.ID, action,
+			map[string]any{"isReviewed": existing.IsReviewed, "quoteId": existing.QuoteID},
+			map[string]any{"isReviewed": updated.IsReviewed, "quoteId": updated.QuoteID},
+		))
+		if err != nil || !updated.IsReviewed {
+			return err
+		}
+
+		event, err := recordEvent(ctx, txDB, domain.NewEvent(domain.EventPaymentReviewed, domain.PaymentReviewedEvent{
+			PaymentProofID: updated.ID,
+			QuoteID:        updated.QuoteID,
+		}))
+		if err != nil {
+			return err
+		}
+
+		return repository.NewOutboxRepository(txDB).CreateOutboxMessages(ctx, event)
+	})
 	if err != nil {
 		if err == domain.ErrConflictingData {
 			return nil, err
@@ -193,15 +266,7 @@ func (ps *PaymentProofService) UpdatePaymentProof(ctx context.Context, proof *do
 		return nil, domain.ErrInternal
 	}
 
-	cacheKey := util.GenerateCacheKey("paymentProof", updated.ID)
-	_ = ps.cache.Delete(ctx, cacheKey)
-
-	data, err := util.Serialize(updated)
-	if err == nil {
-		_ = ps.cache.Set(ctx, cacheKey, data, 0)
-	}
-
-	_ = ps.cache.DeleteByPrefix(ctx, "paymentProofs:*")
+	_ = ps.cache.Store(ctx, updated.ID, updated)
 
 	return updated, nil
 }
@@ -227,13 +292,11 @@ func (ps *PaymentProofService) DeletePaymentProof(ctx context.Context, id uuid.U
 	})
 
 	if err != nil {
-		slog.Error("transaction failed", "error", err)
+		slog.ErrorContext(ctx, "transaction failed", "error", err)
 		return domain.ErrInternal
 	}
 
-	cacheKey := util.GenerateCacheKey("paymentProof", id)
-	_ = ps.cache.Delete(ctx, cacheKey)
-	_ = ps.cache.DeleteByPrefix(ctx, "paymentProofs:*")
+	_ = ps.cache.Invalidate(ctx, id)
 
 	return nil
 }
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+)
+
+/**
+ * PaymentWebhookService implements port.PaymentWebhookService interface
+ * and turns verified provider callbacks into quote state changes
+ */
+type PaymentWebhookService struct {
+	repo      port.PaymentEventRepository
+	verifier  port.PaymentWebhookVerifier
+	quoteRepo port.QuoteRepository
+	quote     port.QuoteService
+}
+
+// NewPaymentWebhookService creates a new payment webhook service instance
+func NewPaymentWebhookService(
+	repo port.PaymentEventRepository,
+	verifier port.PaymentWebhookVerifier,
+	quoteRepo port.QuoteRepository,
+	quote port.QuoteService,
+) *PaymentWebhookService {
+	return &PaymentWebhookService{
+		repo:      repo,
+		verifier:  verifier,
+		quoteRepo: quoteRepo,
+		quote:     quote,
+	}
+}
+
+// HandlePaymentEvent verifies a provider callback, approves the quote when fully paid and records the event once
+func (ps *PaymentWebhookService) HandlePaymentEvent(ctx context.Context, provider domain.PaymentProvider, headers map[string]string, payload []byte) (*domain.PaymentEvent, error) {
+	event, err := ps.verifier.Verify(ctx, provider, headers, payload)
+	if err != nil {
+		if err != domain.ErrInvalidSignature && err != domain.ErrInvalidPaymentEvent {
+			// The provider couldn't be asked about the payment, failing lets it retry the delivery
+			slog.ErrorContext(ctx, "payment webhook verification failed", "provider", provider, "error", err)
+			return nil, domain.ErrInternal
+		}
+		slog.WarnContext(ctx, "payment webhook rejected", "provider", provider, "error", err)
+		return nil, err
+	}
+
+	// Providers retry deliveries, an already recorded event is acknowledged without side effects
+	existing, err := ps.repo.GetPaymentEventByExternalID(ctx, event.Provider, event.ExternalID)
+	if err == nil {
+		return existing, nil
+	}
+	if err != domain.ErrDataNotFound {
+		slog.ErrorContext(ctx, "failed to look up payment event", "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	quote, err := ps.quoteRepo.GetQuoteByID(ctx, event.QuoteID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	if event.Status != domain.PaymentSucceeded || quote.State != domain.QuotePendingPayment {
+		slog.InfoContext(ctx, "payment event recorded without state change",
+			"quote_id", quote.ID, "status", event.Status, "quote_state", quote.State)
+	} else if event.Amount < quote.Total() {
+		// The client pays the price after its discounts
+		slog.WarnContext(ctx, "payment event amount is below the quote total",
+			"quote_id", quote.ID, "amount", event.Amount, "total", quote.Total(), "error", domain.ErrInsufficientPayment)
+	} else {
+		// The event is only recorded once the quote is approved,
+		// a failed approval leaves the provider retrying the delivery
+		_, err = ps.quote.ChangeQuoteState(ctx, quote.ID, domain.QuoteApproved)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to approve paid quote", "quote_id", quote.ID, "error", err)
+			return nil, domain.ErrInternal
+		}
+	}
+
+	// Providers call back from outside of any salon, the event is recorded in the one of its quote
+	event.SalonID = quote.Salon()
+	created, err := ps.repo.CreatePaymentEvent(ctx, event)
+	if err != nil {
+		// A concurrent delivery of the same event recorded it first
+		if err == domain.ErrConflictingData {
+			return ps.repo.GetPaymentEventByExternalID(ctx, event.Provider, event.ExternalID)
+		}
+
+		slog.ErrorContext(ctx, "payment event creation failed", "error", err)
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	return created, nil
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"harajuku/backend/internal/adapter/storage/memory"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryPaymentEvents keeps the payment events by provider and external ID, which are unique
+type memoryPaymentEvents map[string]domain.PaymentEvent
+
+func (r memoryPaymentEvents) CreatePaymentEvent(ctx context.Context, event *domain.PaymentEvent) (*domain.PaymentEvent, error) {
+	key := string(event.Provider) + "/" + event.ExternalID
+	if _, ok := r[key]; ok {
+		return nil, domain.ErrConflictingData
+	}
+	r[key] = *event
+	return event, nil
+}
+
+func (r memoryPaymentEvents) GetPaymentEventByExternalID(ctx context.Context, provider domain.PaymentProvider, externalID string) (*domain.PaymentEvent, error) {
+	event, ok := r[string(provider)+"/"+externalID]
+	if !ok {
+		return nil, domain.ErrDataNotFound
+	}
+	return &event, nil
+}
+
+// eventVerifier is a port.PaymentWebhookVerifier accepting every callback as its event, or failing with err
+type eventVerifier struct {
+	event domain.PaymentEvent
+	err   error
+}
+
+func (v *eventVerifier) Verify(ctx context.Context, provider domain.PaymentProvider, headers map[string]string, payload []byte) (*domain.PaymentEvent, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	event := v.event
+	return &event, nil
+}
+
+// approvingQuotes is a port.QuoteService whose quote state changes fail until told otherwise
+type approvingQuotes struct {
+	port.QuoteService
+	quotes port.QuoteRepository
+	err    error
+}
+
+func (s *approvingQuotes) ChangeQuoteState(ctx context.Context, id uuid.UUID, state domain.QuoteState) (*domain.Quote, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	quote, err := s.quotes.GetQuoteByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	quote.State = state
+	return s.quotes.UpdateQuote(ctx, quote)
+}
+
+func TestHandlePaymentEventRecordsOnlyAfterApproval(t *testing.T) {
+	ctx := context.Background()
+	quotes := memory.NewQuoteRepository(memory.New())
+	quote, err := quotes.CreateQuote(ctx, &domain.Quote{TypeOfServiceID: uuid.New(), ClientID: uuid.New(), State: domain.QuotePendingPayment, Price: 500, Discount: 100})
+	require.NoError(t, err)
+
+	events := memoryPaymentEvents{}
+	quoteService := &approvingQuotes{quotes: quotes, err: errors.New("connection reset")}
+	verifier := &eventVerifier{event: domain.PaymentEvent{ID: uuid.New(), Provider: domain.PaymentProviderBank, ExternalID: "tx-1", QuoteID: quote.ID, Amount: 400, Status: domain.PaymentSucceeded}}
+	svc := NewPaymentWebhookService(events, verifier, quotes, quoteService)
+
+	// A failed approval records nothing, so the retried delivery is not taken for a duplicate
+	_, err = svc.HandlePaymentEvent(ctx, domain.PaymentProviderBank, nil, nil)
+	assert.Equal(t, domain.ErrInternal, err)
+	assert.Empty(t, events)
+
+	quoteService.err = nil
+	event, err := svc.HandlePaymentEvent(ctx, domain.PaymentProviderBank, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "tx-1", event.ExternalID)
+
+	approved, err := quotes.GetQuoteByID(ctx, quote.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.QuoteApproved, approved.State)
+
+	// The recorded event acknowledges later deliveries
+	again, err := svc.HandlePaymentEvent(ctx, domain.PaymentProviderBank, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, event.ID, again.ID)
+	assert.Len(t, events, 1)
+}
+
+func TestHandlePaymentEventVerificationFailures(t *testing.T) {
+	ctx := context.Background()
+	quotes := memory.NewQuoteRepository(memory.New())
+
+	tests := map[string]struct {
+		err  error
+		want error
+	}{
+		"invalid signature":           {domain.ErrInvalidSignature, domain.ErrInvalidSignature},
+		"unknown payment":             {domain.ErrInvalidPaymentEvent, domain.ErrInvalidPaymentEvent},
+		"provider API is unreachable": {errors.New("connection refused"), domain.ErrInternal},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			events := memoryPaymentEvents{}
+			svc := NewPaymentWebhookService(events, &eventVerifier{err: tt.err}, quotes, &approvingQuotes{quotes: quotes})
+
+			_, err := svc.HandlePaymentEvent(ctx, domain.PaymentProviderMercadoPago, nil, nil)
+			assert.Equal(t, tt.want, err)
+			assert.Empty(t, events)
+		})
+	}
+}
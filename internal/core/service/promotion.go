@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+)
+
+/**
+ * PromotionService implements port.PromotionService interface
+ * and provides access to the promotion repository and cache service
+ */
+type PromotionService struct {
+	repo  port.PromotionRepository
+	cache *CachedRepository[domain.Promotion]
+}
+
+// NewPromotionService creates a new promotion service instance
+func NewPromotionService(repo port.PromotionRepository, cache port.CacheRepository) *PromotionService {
+	return &PromotionService{
+		repo,
+		NewCachedRepository[domain.Promotion](cache, "promotion", "promotions"),
+	}
+}
+
+// CreatePromotion creates a new promotion
+func (s *PromotionService) CreatePromotion(ctx context.Context, p *domain.Promotion) (*domain.Promotion, error) {
+	if !p.IsValid() {
+		return nil, domain.ErrInvalidPromotion
+	}
+
+	p.TypeOfServiceIDs = uniqueIDs(p.TypeOfServiceIDs)
+
+	created, err := s.repo.CreatePromotion(ctx, p)
+	if err != nil {
+		slog.ErrorContext(ctx, "Promotion creation failed", "error", err)
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	err = s.cache.Store(ctx, created.ID, created)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// GetPromotion retrieves a promotion by ID
+func (s *PromotionService) GetPromotion(ctx context.Context, id uuid.UUID) (*domain.Promotion, error) {
+	p, err := s.cache.Get(ctx, id, func() (*domain.Promotion, error) {
+		return s.repo.GetPromotionByID(ctx, id)
+	})
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	return p, nil
+}
+
+// ListPromotions lists promotions, listings narrowed to a point in time skip the cache since their key would never repeat
+func (s *PromotionService) ListPromotions(ctx context.Context, filter port.PromotionFilter) ([]domain.Promotion, error) {
+	if filter.ActiveAt != nil {
+		promotions, err := s.repo.ListPromotions(ctx, filter)
+		if err != nil {
+			return nil, domain.ErrInternal
+		}
+		return promotions, nil
+	}
+
+	var typeOfServiceID string
+	if filter.TypeOfServiceID != nil {
+		typeOfServiceID = filter.TypeOfServiceID.String()
+	}
+	params := util.GenerateCacheKeyParams(filter.Skip, filter.Limit, typeOfServiceID)
+
+	promotions, err := s.cache.List(ctx, params, func() ([]domain.Promotion, error) {
+		return s.repo.ListPromotions(ctx, filter)
+	})
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return promotions, nil
+}
+
+// UpdatePromotion updates an existing promotion, quotes already priced keep their discount
+func (s *PromotionService) UpdatePromotion(ctx context.Context, p *domain.Promotion) (*domain.Promotion, error) {
+	_, err := s.repo.GetPromotionByID(ctx, p.ID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	if !p.IsValid() {
+		return nil, domain.ErrInvalidPromotion
+	}
+
+	p.TypeOfServiceIDs = uniqueIDs(p.TypeOfServiceIDs)
+
+	updated, err := s.repo.UpdatePromotion(ctx, p)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	err = s.cache.Store(ctx, p.ID, updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeletePromotion deletes a promotion by ID
+func (s *PromotionService) DeletePromotion(ctx context.Context, id uuid.UUID) error {
+	_, err := s.repo.GetPromotionByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return err
+		}
+		return domain.ErrInternal
+	}
+
+	err = s.repo.DeletePromotion(ctx, id)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	return s.cache.Invalidate(ctx, id)
+}
+
+// uniqueIDs drops repeated IDs while keeping their order
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
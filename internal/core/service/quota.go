@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+// quotaRoles are the roles a quota can be set for
+var quotaRoles = []domain.UserRole{domain.Client, domain.Admin}
+
+/**
+ * QuotaService implements port.QuotaService interface
+ * and counts the actions of the users against the daily quotas of their roles
+ */
+type QuotaService struct {
+	repo port.QuotaRepository
+	// defaults are the configured quotas, used until an admin sets one
+	defaults []domain.Quota
+}
+
+// NewQuotaService creates a new quota service instance
+func NewQuotaService(repo port.QuotaRepository, defaults []domain.Quota) *QuotaService {
+	return &QuotaService{
+		repo,
+		defaults,
+	}
+}
+
+// Consume counts an action of a user against the quota of their role, the result is nil when the role has no quota
+func (s *QuotaService) Consume(ctx context.Context, userID uuid.UUID, role domain.UserRole, action domain.QuotaAction) (*domain.QuotaResult, error) {
+	quotas, err := s.ListQuotas(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := 0
+	for _, quota := range quotas {
+		if quota.Role == role && quota.Action == action {
+			limit = quota.Limit
+		}
+	}
+	if limit == 0 {
+		return nil, nil
+	}
+
+	result, err := s.repo.Consume(ctx, quotaKey(userID, action, time.Now()), limit, domain.QuotaResetAt(time.Now()))
+	if err != nil {
+		slog.ErrorContext(ctx, "Quota count failed", "user_id", userID, "action", action, "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	return result, nil
+}
+
+// Release gives back an action counted today, for requests that failed
+func (s *QuotaService) Release(ctx context.Context, userID uuid.UUID, action domain.QuotaAction) error {
+	if err := s.repo.Release(ctx, quotaKey(userID, action, time.Now())); err != nil {
+		slog.ErrorContext(ctx, "Quota release failed", "user_id", userID, "action", action, "error", err)
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+// ListQuotas lists the quota of every role and action, the ones set by admins replace the configured ones
+func (s *QuotaService) ListQuotas(ctx context.Context) ([]domain.Quota, error) {
+	stored, err := s.repo.ListQuotas(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "Quota listing failed", "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	var quotas []domain.Quota
+	for _, role := range quotaRoles {
+		for _, action := range domain.QuotaActions {
+			quota := domain.Quota{Role: role, Action: action}
+			for _, q := range s.defaults {
+				if q.Role == role && q.Action == action {
+					quota.Limit = q.Limit
+				}
+			}
+			for _, q := range stored {
+				if q.Role == role && q.Action == action {
+					quota.Limit = q.Limit
+				}
+			}
+			quotas = append(quotas, quota)
+		}
+	}
+
+	return quotas, nil
+}
+
+// SetQuota changes the quota of a role for an action, a limit of 0 removes it
+func (s *QuotaService) SetQuota(ctx context.Context, quota *domain.Quota) error {
+	if (quota.Role != domain.Client && quota.Role != domain.Admin) || !quota.Action.IsValid() || quota.Limit < 0 {
+		return domain.ErrInvalidQuota
+	}
+
+	if err := s.repo.SetQuota(ctx, quota); err != nil {
+		slog.ErrorContext(ctx, "Quota update failed", "role", quota.Role, "action", quota.Action, "error", err)
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+// quotaKey is the key the actions of a user are counted under on the day of now, in UTC
+func quotaKey(userID uuid.UUID, action domain.QuotaAction, now time.Time) string {
+	return fmt.Sprintf("quota:%s:%s:%s", action, userID, now.UTC().Format(time.DateOnly))
+}
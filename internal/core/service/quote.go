@@ -2,8 +2,9 @@ package service
 
 import (
 	"context"
-	"fmt"
+	"io"
 	"log/slog"
+	"path/filepath"
 	"time"
 
 	"harajuku/backend/internal/adapter/storage/postgres"
@@ -24,11 +25,15 @@ type QuoteService struct {
 	repo          port.QuoteRepository
 	file          port.FileRepository
 	user          port.UserRepository
-	email         port.EmailRepository
 	quoteImage    port.QuoteImageRepository
 	typeOfService port.TypeOfServiceRepository
+	promotion     port.PromotionRepository
+	coupon        port.CouponRepository
 	db            postgres.DB
-	cache         port.CacheRepository
+	cache         *CachedRepository[domain.Quote]
+	images        *CachedRepository[domain.QuoteImage]
+	appointments  *CachedRepository[domain.Appointment]
+	coupons       *CachedRepository[domain.Coupon]
 }
 
 // NewQuoteService creates a new quote service instance
@@ -36,9 +41,10 @@ func NewQuoteService(
 	repo port.QuoteRepository,
 	file port.FileRepository,
 	user port.UserRepository,
-	email port.EmailRepository,
 	quoteImage port.QuoteImageRepository,
 	typeOfService port.TypeOfServiceRepository,
+	promotion port.PromotionRepository,
+	coupon port.CouponRepository,
 	db postgres.DB,
 	cache port.CacheRepository,
 ) *QuoteService {
@@ -46,37 +52,74 @@ func NewQuoteService(
 		repo,
 		file,
 		user,
-		email,
 		quoteImage,
 		typeOfService,
+		promotion,
+		coupon,
 		db,
-		cache,
+		NewCachedRepository[domain.Quote](cache, "quote", "quotes"),
+		NewCachedRepository[domain.QuoteImage](cache, "quoteImage", "quoteImages"),
+		NewCachedRepository[domain.Appointment](cache, "appointment", "appointments"),
+		newCouponCache(cache),
 	}
 }
 
 // Register creates a new quote
-func (us *QuoteService) CreateQuote(ctx context.Context, quote *domain.Quote, file []byte, fileName string) (*domain.Quote, error) {
+func (us *QuoteService) CreateQuote(ctx context.Context, quote *domain.Quote, file io.Reader, size int64, fileName string) (*domain.Quote, error) {
 	// 1) Validate IDs
-	_, err := us.typeOfService.GetTypeOfServiceByID(ctx, quote.TypeOfServiceID)
+	typeOfService, err := us.typeOfService.GetTypeOfServiceByID(ctx, quote.TypeOfServiceID)
 
-	if err != nil {
+	// A deleted type of service is only kept for the quotes made before
+	if err != nil || typeOfService.DeletedAt != nil {
 		return nil, domain.ErrDataNotFound
 	}
 
-	_, err = us.user.GetUserByID(ctx, quote.ClientID)
+	if typeOfService.Archived {
+		return nil, domain.ErrTypeOfServiceArchived
+	}
+
+	// Quotes are priced in the currency of their type of service
+	quote.Currency = typeOfService.Currency
+
+	client, err := us.user.GetUserByID(ctx, quote.ClientID)
 
 	if err != nil {
 		return nil, domain.ErrDataNotFound
 	}
 
+	// The admins are notified through the outbox, so only once the quote is committed
+	admins, err := us.user.GetAdmins(ctx)
+	if err != nil {
+		slog.WarnContext(ctx, "could not fetch admins", "error", err)
+	}
+
+	// Strip EXIF/GPS metadata and apply the orientation before anything is stored
+	file, size, err = util.NormalizeImage(file, size)
+	if err != nil {
+		slog.ErrorContext(ctx, "normalizing image failed", "error", err)
+		if err == domain.ErrInvalidImage || err == domain.ErrImageTooLarge || err == domain.ErrFileTooLarge {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	inspector, err := util.NewFileInspector(file)
+	if err != nil {
+		slog.ErrorContext(ctx, "reading file failed", "error", err)
+		return nil, domain.ErrInternal
+	}
+
 	// 2) Upload the image first. Fail fast if this errors.
-	path, err := us.file.Save(ctx, file, fileName)
+	path, err := us.file.Save(ctx, inspector, size, fileName)
 	if err != nil {
-		slog.Error("file save failed", "error", err)
+		slog.ErrorContext(ctx, "file save failed", "error", err)
+		if err == domain.ErrInfectedFile {
+			return nil, err
+		}
 		return nil, domain.ErrInternal
 	}
 
-	// 3) Atomic DB transaction: create quote + image row
+	// 3) Atomic DB transaction: create quote + image row + outbox messages
 	var created *domain.Quote
 
 	err = us.db.WithTx(ctx, func(txDB *postgres.DB) error {
@@ -93,19 +136,49 @@ func (us *QuoteService) CreateQuote(ctx context.Context, quote *domain.Quote, fi
 
 		// insert image in *same* tx
 		_, err = txImageRepo.CreateQuoteImage(ctx, &domain.QuoteImage{
-			ID:      uuid.New(),
-			QuoteID: created.ID,
-			URL:     path,
+			ID:          uuid.New(),
+			QuoteID:     created.ID,
+			URL:         path,
+			FileName:    filepath.Base(fileName),
+			Size:        inspector.Size(),
+			ContentType: inspector.ContentType(),
+			Checksum:    inspector.Checksum(),
 		})
+		if err != nil {
+			return err
+		}
 
-		return err
+		// the event is stored now, it and the notification are relayed once the transaction commits
+		event, err := recordEvent(ctx, txDB, domain.NewEvent(domain.EventQuoteCreated, domain.QuoteCreatedEvent{
+			QuoteID:     created.ID,
+			ClientID:    created.ClientID,
+			Description: created.Description,
+		}))
+		if err != nil {
+			return err
+		}
+		messages := []*domain.OutboxMessage{event}
+
+		if len(admins) > 0 {
+			notification, err := domain.NewOutboxNotification(admins, domain.EmailQuoteCreated, domain.QuoteCreatedEmail{
+				QuoteID:     created.ID,
+				Description: created.Description,
+				ClientName:  fullName(client),
+			})
+			if err != nil {
+				return err
+			}
+			messages = append(messages, notification)
+		}
+
+		return repository.NewOutboxRepository(txDB).CreateOutboxMessages(ctx, messages...)
 	})
 
 	if err != nil {
-		slog.Error("transaction failed", "error", err)
+		slog.ErrorContext(ctx, "transaction failed", "error", err)
 		err := us.file.Delete(ctx, path)
 		if err != nil {
-			slog.Error("deleting file failed", "error", err)
+			slog.ErrorContext(ctx, "deleting file failed", "error", err)
 			return nil, domain.ErrInternal
 		}
 
@@ -113,39 +186,12 @@ func (us *QuoteService) CreateQuote(ctx context.Context, quote *domain.Quote, fi
 	}
 
 	// 4) Cache the new quote (best-effort)
-	cacheKey := util.GenerateCacheKey("quote", created.ID)
-	data, _ := util.Serialize(created)
-	if err := us.cache.Set(ctx, cacheKey, data, 0); err != nil {
-		slog.Warn("cache set failed", "error", err)
-	}
-	err = us.cache.DeleteByPrefix(ctx, "quotes:*")
-	if err != nil {
-		return nil, domain.ErrInternal
+	if err := us.cache.Store(ctx, created.ID, created); err != nil {
+		slog.WarnContext(ctx, "cache set failed", "error", err)
 	}
-	err = us.cache.DeleteByPrefix(ctx, "quoteImages:*")
+	err = us.images.InvalidateLists(ctx)
 	if err != nil {
-		return nil, domain.ErrInternal
-	}
-
-	// 5) Notify admins (best-effort)
-	emails, err := us.user.GetAdminsEmails(ctx)
-
-	if err == nil {
-		client, _ := us.user.GetUserByID(ctx, created.ClientID)
-		if err := us.email.SendEmail(
-			ctx,
-			emails,
-			"Se ha creado una nueva cotización",
-			fmt.Sprintf(
-				"Una nueva cotización se ha creado\n\tid: %s\n\tDescripción: %s\n\tCliente: %s %s",
-				created.ID, created.Description, client.Name, client.LastName,
-			),
-			"",
-		); err != nil {
-			slog.Warn("email send failed", "quote_id", created.ID, "error", err)
-		}
-	} else {
-		slog.Warn("could not fetch admin emails", "error", err)
+		return nil, err
 	}
 
 	return created, nil
@@ -153,33 +199,9 @@ func (us *QuoteService) CreateQuote(ctx context.Context, quote *domain.Quote, fi
 
 // GetQuote gets a quote by ID
 func (us *QuoteService) GetQuote(ctx context.Context, id uuid.UUID) (*domain.Quote, []domain.QuoteImage, error) {
-	var quote *domain.Quote
-	cacheKey := util.GenerateCacheKey("quote", id)
-	cachedQuote, err := us.cache.Get(ctx, cacheKey)
-	if err == nil {
-		err := util.Deserialize(cachedQuote, &quote)
-		if err != nil {
-			return nil, nil, domain.ErrInternal
-		}
-	}
-
-	var images []domain.QuoteImage
-	cacheKeyQuoteImage := util.GenerateCacheKey("quoteImages", id)
-	cachedImages, err := us.cache.Get(ctx, cacheKeyQuoteImage)
-	if err == nil {
-		err := util.Deserialize(cachedImages, &images)
-		if err != nil {
-			return nil, nil, domain.ErrInternal
-		}
-	}
-
-	if quote != nil && images != nil {
-		return quote, images, nil
-	}
-
-	//
-
-	quote, err = us.repo.GetQuoteByID(ctx, id)
+	quote, err := us.cache.Get(ctx, id, func() (*domain.Quote, error) {
+		return us.repo.GetQuoteByID(ctx, id)
+	})
 	if err != nil {
 		if err == domain.ErrDataNotFound {
 			return nil, nil, err
@@ -187,29 +209,10 @@ func (us *QuoteService) GetQuote(ctx context.Context, id uuid.UUID) (*domain.Quo
 		return nil, nil, domain.ErrInternal
 	}
 
-	quoteSerialized, err := util.Serialize(quote)
-	if err != nil {
-		return nil, nil, domain.ErrInternal
-	}
-
-	err = us.cache.Set(ctx, cacheKey, quoteSerialized, 0)
-	if err != nil {
-		return nil, nil, domain.ErrInternal
-	}
-
-	//
-
-	images, err = us.quoteImage.GetQuoteImages(ctx, 1, 10, domain.QuoteImageFilters{QuoteID: &quote.ID})
-	if err != nil {
-		return nil, nil, domain.ErrInternal
-	}
-
-	quoteImageSerialized, err := util.Serialize(images)
-	if err != nil {
-		return nil, nil, domain.ErrInternal
-	}
-
-	err = us.cache.Set(ctx, cacheKeyQuoteImage, quoteImageSerialized, 0)
+	// The images of a quote are cached as a listing keyed by the quote
+	images, err := us.images.List(ctx, id, func() ([]domain.QuoteImage, error) {
+		return us.quoteImage.GetQuoteImages(ctx, 1, 10, domain.QuoteImageFilters{QuoteID: &quote.ID})
+	})
 	if err != nil {
 		return nil, nil, domain.ErrInternal
 	}
@@ -218,37 +221,27 @@ func (us *QuoteService) GetQuote(ctx context.Context, id uuid.UUID) (*domain.Quo
 }
 
 // ListQuotes lists all quotes
-func (us *QuoteService) ListQuotes(ctx context.Context, filter port.QuoteFilter) ([]domain.Quote, error) {
-	var quotes []domain.Quote
-
+func (us *QuoteService) ListQuotes(ctx context.Context, filter port.QuoteFilter) ([]domain.Quote, uint64, *domain.Cursor, error) {
 	params := util.GenerateCacheKeyParams(filter)
-	cacheKey := util.GenerateCacheKey("quotes", params)
 
-	cachedQuotes, err := us.cache.Get(ctx, cacheKey)
-	if err == nil {
-		err := util.Deserialize(cachedQuotes, &quotes)
+	quotes, total, next, err := us.cache.ListCursorPage(ctx, params, func() ([]domain.Quote, uint64, *domain.Cursor, error) {
+		quotes, next, err := us.repo.ListQuotes(ctx, filter)
 		if err != nil {
-			return nil, domain.ErrInternal
+			return nil, 0, nil, err
 		}
-		return quotes, nil
-	}
 
-	quotes, err = us.repo.ListQuotes(ctx, filter)
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
-
-	quotesSerialized, err := util.Serialize(quotes)
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
+		total, err := us.repo.CountQuotes(ctx, filter)
+		if err != nil {
+			return nil, 0, nil, err
+		}
 
-	err = us.cache.Set(ctx, cacheKey, quotesSerialized, 0)
+		return quotes, total, next, nil
+	})
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, 0, nil, domain.ErrInternal
 	}
 
-	return quotes, nil
+	return quotes, total, next, nil
 }
 
 // UpdateQuote updates a quote's content, author, and associated metadata.
@@ -261,6 +254,13 @@ func (us *QuoteService) UpdateQuote(ctx context.Context, quote *domain.Quote) (*
 		return nil, domain.ErrInternal
 	}
 
+	// Without a version the update applies on top of the latest read; with one, it must still be current
+	if quote.Version == 0 {
+		quote.Version = existingQuote.Version
+	} else if quote.Version != existingQuote.Version {
+		return nil, domain.ErrConflictingData
+	}
+
 	// Create zero values for comparison
 	zeroUUID := uuid.UUID{}
 	zeroTime := time.Time{}
@@ -283,17 +283,9 @@ func (us *QuoteService) UpdateQuote(ctx context.Context, quote *domain.Quote) (*
 		return nil, domain.ErrNoUpdatedData
 	}
 
-	quote, err = us.repo.UpdateQuote(ctx, quote)
-	if err != nil {
-		if err == domain.ErrConflictingData {
-			return nil, err
-		}
-		return nil, domain.ErrInternal
-	}
-
-	if quote.State == domain.QuoteRequiresProof {
-		client, err := us.user.GetUserByID(ctx, quote.ClientID)
-
+	// Moving a quote to another type of service follows the same rules as creating it
+	if quote.TypeOfServiceID != zeroUUID && quote.TypeOfServiceID != existingQuote.TypeOfServiceID {
+		typeOfService, err := us.typeOfService.GetTypeOfServiceByID(ctx, quote.TypeOfServiceID)
 		if err != nil {
 			if err == domain.ErrDataNotFound {
 				return nil, err
@@ -301,53 +293,91 @@ func (us *QuoteService) UpdateQuote(ctx context.Context, quote *domain.Quote) (*
 			return nil, domain.ErrInternal
 		}
 
-		emails := []string{client.Email}
+		if typeOfService.DeletedAt != nil {
+			return nil, domain.ErrDataNotFound
+		}
 
-		if err := us.email.SendEmail(
-			ctx,
-			emails,
-			"Resupesta a su cotización",
-			fmt.Sprintf(
-				"Estimado cliente su Cotización requiere una prueba de mechón, para esto necesitamos que realice una cita en nuestro sistema.",
-			),
-			"",
-		); err != nil {
-			slog.Warn("email send failed", "quote_id", quote.ID, "error", err)
+		if typeOfService.Archived {
+			return nil, domain.ErrTypeOfServiceArchived
 		}
+
+		quote.Currency = typeOfService.Currency
 	}
 
-	cacheKey := util.GenerateCacheKey("quote", quote.ID)
+	// Pricing the quote, or moving it to another type of service, applies the best promotion active right now
+	// and reprices the coupon on what is left
+	typeChanged := quote.TypeOfServiceID != zeroUUID && quote.TypeOfServiceID != existingQuote.TypeOfServiceID
+	if typeChanged || quote.Price != existingQuote.Price {
+		err = us.applyPromotion(ctx, quote, existingQuote, time.Now())
+		if err != nil {
+			return nil, domain.ErrInternal
+		}
 
-	err = us.cache.Delete(ctx, cacheKey)
-	if err != nil {
-		return nil, domain.ErrInternal
+		err = us.repriceCoupon(ctx, quote, existingQuote)
+		if err != nil {
+			return nil, domain.ErrInternal
+		}
+	} else {
+		quote.PromotionID = existingQuote.PromotionID
+		quote.Discount = existingQuote.Discount
+		quote.CouponID = existingQuote.CouponID
+		quote.CouponDiscount = existingQuote.CouponDiscount
 	}
+	couponDropped := existingQuote.CouponID != nil && quote.CouponID == nil
 
-	quoteSerialized, err := util.Serialize(quote)
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
+	// The client is told the quote requires a proof in the same transaction as the update
+	err = us.db.WithTx(ctx, func(txDB *postgres.DB) error {
+		quote, err = repository.NewQuoteRepository(txDB).UpdateQuote(ctx, quote)
+		if err != nil {
+			return err
+		}
+		if couponDropped {
+			err = repository.NewCouponRepository(txDB).ReleaseCoupon(ctx, quote.ID)
+			if err != nil {
+				return err
+			}
+		}
+		if quote.State != domain.QuoteRequiresProof {
+			return nil
+		}
 
-	err = us.cache.Set(ctx, cacheKey, quoteSerialized, 0)
+		client, err := us.user.GetUserByID(ctx, quote.ClientID)
+		if err != nil {
+			return err
+		}
+
+		return writeQuoteStateNotification(ctx, txDB, quote, client, domain.EmailQuoteRequiresProof)
+	})
 	if err != nil {
+		if err == domain.ErrConflictingData || err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		slog.ErrorContext(ctx, "quote update failed", "quote_id", existingQuote.ID, "error", err)
 		return nil, domain.ErrInternal
 	}
 
-	err = us.cache.DeleteByPrefix(ctx, "quotes:*")
+	err = us.cache.Store(ctx, quote.ID, quote)
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, err
 	}
-	err = us.cache.DeleteByPrefix(ctx, "quoteImages:*")
+	err = us.images.InvalidateLists(ctx)
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, err
+	}
+	if couponDropped {
+		err = us.coupons.Invalidate(ctx, *existingQuote.CouponID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return quote, nil
 }
 
-// DeleteQuote deletes a quote by ID
+// DeleteQuote soft deletes a quote by ID, its images are kept so admins can still look at it.
+// A quote with an appointment that is not deleted is kept
 func (us *QuoteService) DeleteQuote(ctx context.Context, id uuid.UUID) error {
-	quote, err := us.repo.GetQuoteByID(ctx, id)
+	_, err := us.repo.GetQuoteByID(ctx, id)
 	if err != nil {
 		if err == domain.ErrDataNotFound {
 			return err
@@ -355,52 +385,23 @@ func (us *QuoteService) DeleteQuote(ctx context.Context, id uuid.UUID) error {
 		return domain.ErrInternal
 	}
 
-	images, err := us.quoteImage.GetQuoteImages(ctx, 1, 10, domain.QuoteImageFilters{QuoteID: &quote.ID})
+	appointments, err := repository.NewAppointmentRepository(&us.db).CountAppointments(ctx, port.AppointmentFilter{QuoteID: &id})
 	if err != nil {
 		return domain.ErrInternal
 	}
-
-	err = us.db.WithTx(ctx, func(txDB *postgres.DB) error {
-		// build both repos on txDB
-		txQuoteRepo := repository.NewQuoteRepository(txDB)
-		txImageRepo := repository.NewQuoteImageRepository(txDB)
-		for _, image := range images {
-			err = us.file.Delete(ctx, image.URL)
-			if err != nil {
-				return err
-			}
-			err := txImageRepo.DeleteQuoteImage(ctx, image.ID)
-			if err != nil {
-				return err
-			}
-		}
-
-		err = txQuoteRepo.DeleteQuote(ctx, quote.ID)
-		return err
-	})
-
-	if err != nil {
-		slog.Error("transaction failed", "error", err)
-		return domain.ErrInternal
+	if appointments > 0 {
+		return domain.ErrConflictingData
 	}
 
-	cacheKey := util.GenerateCacheKey("quote", id)
-
-	err = us.cache.Delete(ctx, cacheKey)
-	if err != nil {
-		return domain.ErrInternal
-	}
-
-	err = us.cache.DeleteByPrefix(ctx, "quotes:*")
-	if err != nil {
-		return domain.ErrInternal
-	}
-	err = us.cache.DeleteByPrefix(ctx, "quoteImages:*")
+	err = us.repo.DeleteQuote(ctx, id)
 	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return err
+		}
 		return domain.ErrInternal
 	}
 
-	return us.repo.DeleteQuote(ctx, id)
+	return us.cache.Invalidate(ctx, id)
 }
 
 func (us *QuoteService) ChangeQuoteState(ctx context.Context, id uuid.UUID, state domain.QuoteState) (*domain.Quote, error) {
@@ -435,80 +436,359 @@ func (us *QuoteService) ChangeQuoteState(ctx context.Context, id uuid.UUID, stat
 		return nil, domain.ErrDataNotFound
 	}
 
+	client, err := us.user.GetUserByID(ctx, existingQuote.ClientID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	template := domain.EmailQuoteStateChanged
+	if state == domain.QuoteRequiresProof {
+		template = domain.EmailQuoteRequiresProof
+	}
+
 	quote := existingQuote
 	quote.State = state
 
-	quote, err = us.repo.UpdateQuote(ctx, quote)
+	// The state, what approving it books and the notification of the client are committed together
+	err = us.db.WithTx(ctx, func(txDB *postgres.DB) error {
+		quote, err = repository.NewQuoteRepository(txDB).UpdateQuote(ctx, quote)
+		if err != nil {
+			return err
+		}
+
+		// A rejected quote gives the use of its coupon back
+		if state == domain.QuoteRejected && quote.CouponID != nil {
+			err = repository.NewCouponRepository(txDB).ReleaseCoupon(ctx, id)
+			if err != nil {
+				return err
+			}
+		}
+
+		if state == domain.QuoteApproved {
+			err = repository.NewAvailabilitySlotRepository(txDB).MarkSlotsAsBookedByQuoteID(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			err = repository.NewPaymentProofRepository(txDB).MarkAsReviewedByQuoteID(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			err = repository.NewAppointmentRepository(txDB).MarkAsBookedByQuoteID(ctx, id)
+			if err != nil {
+				return err
+			}
+		}
+
+		return writeQuoteStateNotification(ctx, txDB, quote, client, template)
+	})
 	if err != nil {
 		if err == domain.ErrConflictingData {
 			return nil, err
 		}
+		slog.ErrorContext(ctx, "quote state change failed", "quote_id", id, "error", err)
 		return nil, domain.ErrInternal
 	}
 
-	cacheKey := util.GenerateCacheKey("quote", quote.ID)
+	err = us.cache.Store(ctx, quote.ID, quote)
+	if err != nil {
+		return nil, err
+	}
+	err = us.appointments.InvalidateLists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = us.images.InvalidateLists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if state == domain.QuoteRejected && quote.CouponID != nil {
+		err = us.coupons.Invalidate(ctx, *quote.CouponID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return quote, nil
+}
 
-	err = us.cache.Delete(ctx, cacheKey)
+// writeQuoteStateNotification writes to the outbox of the transaction the notification telling the client
+// of the quote its new state
+func writeQuoteStateNotification(ctx context.Context, txDB *postgres.DB, quote *domain.Quote, client *domain.User, template domain.EmailTemplate) error {
+	message, err := domain.NewOutboxNotification([]domain.User{*client}, template, domain.QuoteStateEmail{
+		QuoteID:    quote.ID,
+		ClientName: fullName(client),
+		State:      quote.State,
+	})
 	if err != nil {
-		return nil, domain.ErrInternal
+		return err
 	}
 
-	quoteSerialized, err := util.Serialize(quote)
+	return repository.NewOutboxRepository(txDB).CreateOutboxMessages(ctx, message)
+}
+
+// expiringStates are the states of the quotes waiting on the client or an admin, which expire when left too long
+var expiringStates = []domain.QuoteState{domain.QuotePending, domain.QuoteRequiresProof, domain.QuotePendingPayment}
+
+// ExpireQuotes expires the quotes still waiting on the client or an admin that were created before, notifying
+// their clients. A quote that changes while it is expired is left as it is
+func (us *QuoteService) ExpireQuotes(ctx context.Context, before time.Time) (int, error) {
+	expired := 0
+	for _, state := range expiringStates {
+		filter := port.QuoteFilter{
+			EndDate: &before,
+			ByState: &state,
+			Limit:   100,
+		}
+
+		for {
+			quotes, next, err := us.repo.ListQuotes(ctx, filter)
+			if err != nil {
+				return expired, err
+			}
+
+			for _, quote := range quotes {
+				if ctx.Err() != nil {
+					return expired, ctx.Err()
+				}
+				if us.expireQuote(ctx, &quote) {
+					expired++
+				}
+			}
+
+			if next == nil {
+				break
+			}
+			filter.After = next
+		}
+	}
+
+	return expired, nil
+}
+
+// expireQuote moves a quote to the expired state and notifies its client, reporting whether it was expired
+func (us *QuoteService) expireQuote(ctx context.Context, quote *domain.Quote) bool {
+	// A quote whose client is gone still expires, nobody is notified
+	client, err := us.user.GetUserByID(ctx, quote.ClientID)
 	if err != nil {
-		return nil, domain.ErrInternal
+		slog.WarnContext(ctx, "could not fetch quote client", "quote_id", quote.ID, "error", err)
+		client = nil
 	}
 
-	err = us.cache.Set(ctx, cacheKey, quoteSerialized, 0)
+	quote.State = domain.QuoteExpired
+
+	var updated *domain.Quote
+	err = us.db.WithTx(ctx, func(txDB *postgres.DB) error {
+		updated, err = repository.NewQuoteRepository(txDB).UpdateQuote(ctx, quote)
+		if err != nil {
+			return err
+		}
+
+		// An expired quote gives the use of its coupon back
+		if updated.CouponID != nil {
+			err = repository.NewCouponRepository(txDB).ReleaseCoupon(ctx, updated.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		if client == nil {
+			return nil
+		}
+
+		return writeQuoteStateNotification(ctx, txDB, updated, client, domain.EmailQuoteStateChanged)
+	})
 	if err != nil {
-		return nil, domain.ErrInternal
+		// The version check fails when the quote changed since it was listed
+		if err != domain.ErrConflictingData {
+			slog.ErrorContext(ctx, "Quote expiration failed", "quote_id", quote.ID, "error", err)
+		}
+		return false
 	}
 
-	err = us.cache.DeleteByPrefix(ctx, "quotes:*")
+	if err := us.cache.Store(ctx, updated.ID, updated); err != nil {
+		slog.WarnContext(ctx, "cache set failed", "error", err)
+	}
+	if updated.CouponID != nil {
+		if err := us.coupons.Invalidate(ctx, *updated.CouponID); err != nil {
+			slog.WarnContext(ctx, "cache invalidation failed", "error", err)
+		}
+	}
+
+	return true
+}
+
+// applyPromotion sets the discount of the promotion that takes the most off the quote's price, or clears it when none applies
+func (us *QuoteService) applyPromotion(ctx context.Context, quote, existingQuote *domain.Quote, at time.Time) error {
+	typeOfServiceID := quote.TypeOfServiceID
+	if typeOfServiceID == (uuid.UUID{}) {
+		typeOfServiceID = existingQuote.TypeOfServiceID
+	}
+
+	currency := quote.Currency
+	if currency == "" {
+		currency = existingQuote.Currency
+	}
+
+	promotions, err := us.promotion.ListPromotions(ctx, port.PromotionFilter{
+		ActiveAt:        &at,
+		TypeOfServiceID: &typeOfServiceID,
+	})
 	if err != nil {
-		return nil, domain.ErrInternal
+		slog.ErrorContext(ctx, "Failed to list active promotions", "error", err)
+		return err
 	}
-	err = us.cache.DeleteByPrefix(ctx, "appointments:*")
+
+	quote.PromotionID = nil
+	quote.Discount = 0
+
+	for _, promotion := range promotions {
+		discount := promotion.Discount(quote.Price, currency)
+		if discount > quote.Discount {
+			quote.PromotionID = &promotion.ID
+			quote.Discount = discount
+		}
+	}
+
+	return nil
+}
+
+// repriceCoupon recomputes the discount of the coupon applied to the quote on the price left by its promotion,
+// dropping the coupon when it no longer applies to the quote's type of service or currency
+func (us *QuoteService) repriceCoupon(ctx context.Context, quote, existingQuote *domain.Quote) error {
+	quote.CouponID = nil
+	quote.CouponDiscount = 0
+
+	if existingQuote.CouponID == nil {
+		return nil
+	}
+
+	coupon, err := us.coupon.GetCouponByID(ctx, *existingQuote.CouponID)
 	if err != nil {
+		// A deleted coupon leaves its quotes without one
+		if err == domain.ErrDataNotFound {
+			return nil
+		}
+		slog.ErrorContext(ctx, "Failed to get quote coupon", "coupon_id", *existingQuote.CouponID, "error", err)
+		return err
+	}
+
+	typeOfServiceID := quote.TypeOfServiceID
+	if typeOfServiceID == (uuid.UUID{}) {
+		typeOfServiceID = existingQuote.TypeOfServiceID
+	}
+
+	currency := quote.Currency
+	if currency == "" {
+		currency = existingQuote.Currency
+	}
+
+	if !coupon.AppliesTo(typeOfServiceID, currency) {
+		return nil
+	}
+
+	quote.CouponID = &coupon.ID
+	quote.CouponDiscount = coupon.Discount(quote.Price-quote.Discount, currency)
+
+	return nil
+}
+
+// couponStates are the states of the quotes still waiting to be paid, the only ones a coupon is applied to or removed from
+var couponStates = map[domain.QuoteState]bool{
+	domain.QuotePending:        true,
+	domain.QuoteRequiresProof:  true,
+	domain.QuotePendingPayment: true,
+}
+
+// ApplyCoupon applies the coupon with the code to a quote, replacing the one it had. The coupon must be active,
+// cover the quote's type of service and have uses left for the quote's client
+func (us *QuoteService) ApplyCoupon(ctx context.Context, quoteID uuid.UUID, code string) (*domain.Quote, error) {
+	quote, err := us.repo.GetQuoteByID(ctx, quoteID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
 		return nil, domain.ErrInternal
 	}
-	err = us.cache.DeleteByPrefix(ctx, "quoteImages:*")
+
+	if !couponStates[quote.State] {
+		return nil, domain.ErrCouponNotApplicable
+	}
+
+	coupon, err := us.coupon.GetCouponByCode(ctx, domain.NormalizeCouponCode(code))
 	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		slog.ErrorContext(ctx, "Error getting coupon", "error", err)
 		return nil, domain.ErrInternal
 	}
 
-	if state == domain.QuoteApproved {
-		slotRepo := repository.NewAvailabilitySlotRepository(&us.db)
-		err = slotRepo.MarkSlotsAsBookedByQuoteID(ctx, id)
+	if quote.CouponID != nil && *quote.CouponID == coupon.ID {
+		return nil, domain.ErrNoUpdatedData
+	}
+
+	if !coupon.IsActive(time.Now()) {
+		return nil, domain.ErrCouponUnavailable
+	}
+
+	if !coupon.AppliesTo(quote.TypeOfServiceID, quote.Currency) {
+		return nil, domain.ErrCouponNotApplicable
+	}
+
+	previous := quote.CouponID
+	quote.CouponID = &coupon.ID
+	quote.CouponDiscount = coupon.Discount(quote.Price-quote.Discount, quote.Currency)
+
+	// The redemption replaces the one of the previous coupon, both are committed with the quote
+	err = us.db.WithTx(ctx, func(txDB *postgres.DB) error {
+		_, err := repository.NewCouponRepository(txDB).RedeemCoupon(ctx, &domain.CouponRedemption{
+			ID:       uuid.New(),
+			CouponID: coupon.ID,
+			QuoteID:  quote.ID,
+			ClientID: quote.ClientID,
+		})
 		if err != nil {
-			return nil, domain.ErrInternal
+			return err
 		}
 
-		paymentProofRepo := repository.NewPaymentProofRepository(&us.db)
-		err = paymentProofRepo.MarkAsReviewedByQuoteID(ctx, id)
-		if err != nil {
-			return nil, domain.ErrInternal
+		quote, err = repository.NewQuoteRepository(txDB).UpdateQuote(ctx, quote)
+		return err
+	})
+	if err != nil {
+		if err == domain.ErrCouponUnavailable || err == domain.ErrConflictingData || err == domain.ErrDataNotFound {
+			return nil, err
 		}
+		slog.ErrorContext(ctx, "Coupon redemption failed", "quote_id", quoteID, "coupon_id", coupon.ID, "error", err)
+		return nil, domain.ErrInternal
+	}
 
-		appointmentRepo := repository.NewAppointmentRepository(&us.db)
-		err = appointmentRepo.MarkAsBookedByQuoteID(ctx, id)
+	err = us.cache.Store(ctx, quote.ID, quote)
+	if err != nil {
+		return nil, err
+	}
+	err = us.coupons.Invalidate(ctx, coupon.ID)
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil {
+		err = us.coupons.Invalidate(ctx, *previous)
 		if err != nil {
-			return nil, domain.ErrInternal
+			return nil, err
 		}
 	}
 
-	var text string
-
-	if state == domain.QuoteApproved {
-		text = "Estimado cliente su Cotización ha sido aprobada."
-	} else if state == domain.QuoteRejected {
-		text = "Estimado cliente su Cotización ha sido rechazada le recomendamos actualizar los datos de su cotización para una nueva revisión."
-	} else if state == domain.QuoteRequiresProof {
-		text = "Estimado cliente su Cotización para seguir el proceso necesitamos realizar una prueba de mechón para esto es importante que genere una cita en nuestro sistema."
-	} else if state == domain.QuotePendingPayment {
-		text = "Estimado cliente su Cotización ha sido aprobada, para seguir con el proceso necesitamos que suba el comprobante de pago al sistema para poder proceder."
-	}
+	slog.InfoContext(ctx, "Coupon applied", "quote_id", quote.ID, "coupon_id", coupon.ID)
+	return quote, nil
+}
 
-	client, err := us.user.GetUserByID(ctx, quote.ClientID)
+// RemoveCoupon removes the coupon applied to a quote, giving its use back
+func (us *QuoteService) RemoveCoupon(ctx context.Context, quoteID uuid.UUID) (*domain.Quote, error) {
+	quote, err := us.repo.GetQuoteByID(ctx, quoteID)
 	if err != nil {
 		if err == domain.ErrDataNotFound {
 			return nil, err
@@ -516,16 +796,42 @@ func (us *QuoteService) ChangeQuoteState(ctx context.Context, id uuid.UUID, stat
 		return nil, domain.ErrInternal
 	}
 
-	emails := []string{client.Email}
+	if quote.CouponID == nil {
+		return nil, domain.ErrNoUpdatedData
+	}
+
+	if !couponStates[quote.State] {
+		return nil, domain.ErrCouponNotApplicable
+	}
 
-	if err := us.email.SendEmail(
-		ctx,
-		emails,
-		"Resupesta a su cotización",
-		text,
-		"",
-	); err != nil {
-		slog.Warn("email send failed", "quote_id", quote.ID, "error", err)
+	couponID := *quote.CouponID
+	quote.CouponID = nil
+	quote.CouponDiscount = 0
+
+	err = us.db.WithTx(ctx, func(txDB *postgres.DB) error {
+		err := repository.NewCouponRepository(txDB).ReleaseCoupon(ctx, quote.ID)
+		if err != nil {
+			return err
+		}
+
+		quote, err = repository.NewQuoteRepository(txDB).UpdateQuote(ctx, quote)
+		return err
+	})
+	if err != nil {
+		if err == domain.ErrConflictingData {
+			return nil, err
+		}
+		slog.ErrorContext(ctx, "Coupon removal failed", "quote_id", quoteID, "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	err = us.cache.Store(ctx, quote.ID, quote)
+	if err != nil {
+		return nil, err
+	}
+	err = us.coupons.Invalidate(ctx, couponID)
+	if err != nil {
+		return nil, err
 	}
 
 	return quote, nil
@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"log/slog"
+	"path/filepath"
 
 	"harajuku/backend/internal/adapter/storage/postgres"
 	"harajuku/backend/internal/adapter/storage/postgres/repository"
@@ -18,7 +21,7 @@ type QuoteImageService struct {
 	file      port.FileRepository
 	quoteRepo port.QuoteRepository
 	db        postgres.DB
-	cache     port.CacheRepository
+	cache     *CachedRepository[domain.QuoteImage]
 }
 
 func NewQuoteImageService(
@@ -33,34 +36,106 @@ func NewQuoteImageService(
 		file:      file,
 		quoteRepo: quoteRepo,
 		db:        db,
-		cache:     cache,
+		cache:     NewCachedRepository[domain.QuoteImage](cache, "quoteImage", "quoteImages"),
 	}
 }
 
-// CreateQuoteImage creates a new quote image and stores it in the database and file storage
-func (qs *QuoteImageService) GetQuoteImageByID(ctx context.Context, id uuid.UUID) (*domain.QuoteImage, []byte, error) {
-	var image *domain.QuoteImage
-	cacheKey := util.GenerateCacheKey("quoteImage", id)
-
-	cached, err := qs.cache.Get(ctx, cacheKey)
-	if err == nil {
-		err = util.Deserialize(cached, &image)
-		if err == nil {
-			file, err := qs.file.Get(ctx, image.URL)
-			if err != nil {
-				return nil, nil, domain.ErrInternal
-			}
-			return image, file, nil
+// CreateQuoteImage appends an image to an existing quote, storing the file and its row together.
+// Only the quote's client or an admin can add images
+func (qs *QuoteImageService) CreateQuoteImage(ctx context.Context, quoteID uuid.UUID, requester *domain.TokenPayload, file io.Reader, size int64, fileName string) (*domain.QuoteImage, error) {
+	quote, err := qs.quoteRepo.GetQuoteByID(ctx, quoteID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
 		}
+		return nil, domain.ErrInternal
 	}
 
-	image, err = qs.repo.GetQuoteImageByID(ctx, id)
+	if requester.Role != domain.Admin && quote.ClientID != requester.UserID {
+		return nil, domain.ErrForbidden
+	}
+
+	// Strip EXIF/GPS metadata and apply the orientation before anything is stored
+	file, size, err = util.NormalizeImage(file, size)
 	if err != nil {
-		return nil, nil, domain.ErrInternal
+		slog.ErrorContext(ctx, "normalizing image failed", "error", err)
+		if err == domain.ErrInvalidImage || err == domain.ErrImageTooLarge || err == domain.ErrFileTooLarge {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	inspector, err := util.NewFileInspector(file)
+	if err != nil {
+		slog.ErrorContext(ctx, "reading file failed", "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	image := &domain.QuoteImage{
+		ID:       uuid.New(),
+		QuoteID:  quoteID,
+		FileName: filepath.Base(fileName),
+	}
+
+	// Keys are scoped by quote and image so uploads with the same name never collide
+	key := fmt.Sprintf("quotes/%s/%s%s", quoteID, image.ID, filepath.Ext(fileName))
+	image.URL, err = qs.file.Save(ctx, inspector, size, key)
+	if err != nil {
+		slog.ErrorContext(ctx, "file save failed", "error", err)
+		if err == domain.ErrInfectedFile {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	image.Size = inspector.Size()
+	image.ContentType = inspector.ContentType()
+	image.Checksum = inspector.Checksum()
+
+	var created *domain.QuoteImage
+
+	err = qs.db.WithTx(ctx, func(txDB *postgres.DB) error {
+		txRepo := repository.NewQuoteImageRepository(txDB)
+
+		// The same picture is only kept once per quote
+		duplicates, err := txRepo.GetQuoteImages(ctx, 1, 1, domain.QuoteImageFilters{QuoteID: &quoteID, Checksum: &image.Checksum})
+		if err != nil {
+			return err
+		}
+		if len(duplicates) > 0 {
+			return domain.ErrDuplicateFile
+		}
+
+		created, err = txRepo.CreateQuoteImage(ctx, image)
+		return err
+	})
+
+	if err != nil {
+		if delErr := qs.file.Delete(ctx, image.URL); delErr != nil {
+			slog.ErrorContext(ctx, "deleting file failed", "error", delErr)
+		}
+		if err == domain.ErrDuplicateFile {
+			return nil, err
+		}
+		slog.ErrorContext(ctx, "transaction failed", "error", err)
+		return nil, domain.ErrInternal
 	}
 
-	data, _ := util.Serialize(image)
-	_ = qs.cache.Set(ctx, cacheKey, data, 0)
+	if err := qs.cache.Store(ctx, created.ID, created); err != nil {
+		slog.WarnContext(ctx, "cache set failed", "error", err)
+	}
+
+	return created, nil
+}
+
+// GetQuoteImageByID retrieves a quote image and its file content by ID
+func (qs *QuoteImageService) GetQuoteImageByID(ctx context.Context, id uuid.UUID) (*domain.QuoteImage, []byte, error) {
+	image, err := qs.cache.Get(ctx, id, func() (*domain.QuoteImage, error) {
+		return qs.repo.GetQuoteImageByID(ctx, id)
+	})
+	if err != nil {
+		return nil, nil, domain.ErrInternal
+	}
 
 	file, err := qs.file.Get(ctx, image.URL)
 	if err != nil {
@@ -73,29 +148,19 @@ func (qs *QuoteImageService) GetQuoteImageByID(ctx context.Context, id uuid.UUID
 // GetQuoteImages retrieves a list of quote images, optionally filtered by quoteID, and supports pagination
 func (qs *QuoteImageService) GetQuoteImages(ctx context.Context, quoteID *uuid.UUID, skip, limit uint64) ([]domain.QuoteImage, error) {
 	filters := domain.QuoteImageFilters{QuoteID: quoteID}
-	cacheKey := util.GenerateCacheKey("quoteImages", struct {
+	params := struct {
 		Filters domain.QuoteImageFilters
 		Skip    uint64
 		Limit   uint64
-	}{filters, skip, limit})
-
-	var images []domain.QuoteImage
-	cached, err := qs.cache.Get(ctx, cacheKey)
-	if err == nil {
-		err = util.Deserialize(cached, &images)
-		if err == nil {
-			return images, nil
-		}
-	}
+	}{filters, skip, limit}
 
-	images, err = qs.repo.GetQuoteImages(ctx, skip, limit, filters)
+	images, err := qs.cache.List(ctx, params, func() ([]domain.QuoteImage, error) {
+		return qs.repo.GetQuoteImages(ctx, skip, limit, filters)
+	})
 	if err != nil {
 		return nil, domain.ErrInternal
 	}
 
-	data, _ := util.Serialize(images)
-	_ = qs.cache.Set(ctx, cacheKey, data, 0)
-
 	return images, nil
 }
 
@@ -117,13 +182,11 @@ func (qs *QuoteImageService) DeleteQuoteImage(ctx context.Context, id uuid.UUID)
 	})
 
 	if err != nil {
-		slog.Error("transaction failed", "error", err)
+		slog.ErrorContext(ctx, "transaction failed", "error", err)
 		return domain.ErrInternal
 	}
 
-	cacheKey := util.GenerateCacheKey("quoteImage", id)
-	_ = qs.cache.Delete(ctx, cacheKey)
-	_ = qs.cache.DeleteByPrefix(ctx, "quoteImages:*")
+	_ = qs.cache.Invalidate(ctx, id)
 
 	return nil
 }
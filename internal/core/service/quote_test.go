@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"harajuku/backend/internal/adapter/storage/cache"
+	"harajuku/backend/internal/adapter/storage/memory"
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateQuoteRejectsRetiredTypesOfService(t *testing.T) {
+	ctx := context.Background()
+	db := memory.New()
+	types := memoryTypesOfService{}
+	typesOfService := newTestTypeOfServiceService(types)
+	svc := NewQuoteService(
+		memory.NewQuoteRepository(db),
+		memory.NewFileRepository(),
+		memory.NewUserRepository(db),
+		memory.NewQuoteImageRepository(db),
+		types,
+		nil,
+		nil,
+		postgres.DB{},
+		cache.NewMemory(100),
+	)
+
+	archived, err := typesOfService.CreateTypeOfService(ctx, &domain.TypeOfService{Name: "Permanente", Price: 700})
+	require.NoError(t, err)
+	_, err = typesOfService.ArchiveTypeOfService(ctx, archived.ID, true)
+	require.NoError(t, err)
+
+	deleted, err := typesOfService.CreateTypeOfService(ctx, &domain.TypeOfService{Name: "Alaciado", Price: 800})
+	require.NoError(t, err)
+	require.NoError(t, typesOfService.DeleteTypeOfService(ctx, deleted.ID))
+
+	_, err = svc.CreateQuote(ctx, &domain.Quote{TypeOfServiceID: archived.ID, ClientID: uuid.New()}, nil, 0, "")
+	assert.Equal(t, domain.ErrTypeOfServiceArchived, err)
+
+	_, err = svc.CreateQuote(ctx, &domain.Quote{TypeOfServiceID: deleted.ID, ClientID: uuid.New()}, nil, 0, "")
+	assert.Equal(t, domain.ErrDataNotFound, err)
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+)
+
+// reportPageSize is the number of rows read from the database at a time while building a report
+const reportPageSize = 500
+
+/**
+ * ReportService implements port.ReportService interface
+ * and builds the CSV reports of quotes and appointments in the workers, emailing them to the admin who asked,
+ * and the staff utilization report on request
+ */
+type ReportService struct {
+	quotes       port.QuoteRepository
+	appointments port.AppointmentRepository
+	reports      port.ReportRepository
+	users        port.UserRepository
+	email        port.EmailRepository
+	templates    port.EmailTemplateRenderer
+	jobs         port.JobQueueService
+}
+
+// NewReportService creates a new report service instance and registers the handler of its jobs
+func NewReportService(
+	quotes port.QuoteRepository,
+	appointments port.AppointmentRepository,
+	reports port.ReportRepository,
+	users port.UserRepository,
+	email port.EmailRepository,
+	templates port.EmailTemplateRenderer,
+	jobs port.JobQueueService,
+) *ReportService {
+	s := &ReportService{
+		quotes,
+		appointments,
+		reports,
+		users,
+		email,
+		templates,
+		jobs,
+	}
+	jobs.Handle(domain.JobBuildReport, s.runReportJob)
+
+	return s
+}
+
+// RequestReport queues the report, which is emailed to the admin who asked for it once it is built
+func (s *ReportService) RequestReport(ctx context.Context, report *domain.ReportJob) (*domain.Job, error) {
+	if !report.Kind.IsValid() {
+		return nil, domain.ErrInvalidReport
+	}
+	if report.StartDate != nil && report.EndDate != nil && report.EndDate.Before(*report.StartDate) {
+		return nil, domain.ErrInvalidReport
+	}
+
+	return s.jobs.Enqueue(ctx, domain.JobBuildReport, report)
+}
+
+// GetStaffUtilization compares the slot time every admin offered between start and end with the time booked
+func (s *ReportService) GetStaffUtilization(ctx context.Context, start, end time.Time) ([]domain.StaffUtilization, error) {
+	if !end.After(start) {
+		return nil, domain.ErrInvalidReport
+	}
+
+	utilization, err := s.reports.GetStaffUtilization(ctx, start, end)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error getting staff utilization", "start", start, "end", end, "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	return utilization, nil
+}
+
+// GetClientRetention counts new and returning clients per month between start and end, the average time between
+// visits and the top clients by spend
+func (s *ReportService) GetClientRetention(ctx context.Context, start, end time.Time, top uint64) (*domain.ClientRetention, error) {
+	if !end.After(start) {
+		return nil, domain.ErrInvalidReport
+	}
+
+	months, err := s.reports.ListClientRetentionMonths(ctx, start, end)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error counting returning clients", "start", start, "end", end, "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	gap, err := s.reports.GetAverageVisitGap(ctx, start, end)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error averaging the time between visits", "start", start, "end", end, "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	topClients, err := s.reports.ListTopClients(ctx, start, end, top)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error listing the top clients", "start", start, "end", end, "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	return &domain.ClientRetention{
+		Months:          months,
+		AverageVisitGap: gap,
+		TopClients:      topClients,
+	}, nil
+}
+
+// runReportJob builds a queued report and emails it, reports for admins that no longer exist are dropped
+func (s *ReportService) runReportJob(ctx context.Context, payload json.RawMessage) error {
+	var job domain.ReportJob
+	if err := decodeJob(payload, &job); err != nil {
+		return err
+	}
+
+	admin, err := s.users.GetUserByID(ctx, job.RequestedBy)
+	if err == domain.ErrDataNotFound {
+		return fmt.Errorf("%w: admin %s no longer exists", domain.ErrJobRejected, job.RequestedBy)
+	}
+	if err != nil {
+		return err
+	}
+
+	// The report only holds the rows of the salon of the admin who asked for it
+	ctx = util.WithSalon(ctx, admin.Salon())
+
+	var rows [][]string
+	switch job.Kind {
+	case domain.ReportQuotes:
+		rows, err = s.quoteRows(ctx, &job)
+	case domain.ReportAppointments:
+		rows, err = s.appointmentRows(ctx, &job)
+	default:
+		return fmt.Errorf("%w: unknown report kind %q", domain.ErrJobRejected, job.Kind)
+	}
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+
+	attachment := domain.EmailAttachment{
+		FileName:    fmt.Sprintf("%s-%s.csv", job.Kind, time.Now().UTC().Format("2006-01-02")),
+		Content:     buf.Bytes(),
+		ContentType: "text/csv",
+	}
+	data := domain.ReportReadyEmail{
+		AdminName: fullName(admin),
+		Kind:      job.Kind,
+		Rows:      len(rows) - 1,
+	}
+
+	err = sendTemplatedEmail(ctx, s.email, s.templates, []string{admin.Email}, admin.PreferredLanguage.OrDefault(), domain.EmailReportReady, data, attachment)
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Report sent", "kind", job.Kind, "rows", data.Rows, "user_id", admin.ID)
+	return nil
+}
+
+// quoteRows reads every quote in the dates of the report, page by page, with a header row first
+func (s *ReportService) quoteRows(ctx context.Context, job *domain.ReportJob) ([][]string, error) {
+	rows := [][]string{{"id", "typeOfServiceId", "clientId", "time", "description", "state", "price", "discount", "total", "currency"}}
+
+	filter := port.QuoteFilter{
+		StartDate: job.StartDate,
+		EndDate:   job.EndDate,
+		Limit:     reportPageSize,
+	}
+	for {
+		quotes, next, err := s.quotes.ListQuotes(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, q := range quotes {
+			rows = append(rows, []string{
+				q.ID.String(),
+				q.TypeOfServiceID.String(),
+				q.ClientID.String(),
+				q.Time.UTC().Format(time.RFC3339),
+				q.Description,
+				string(q.State),
+				strconv.FormatFloat(q.Price, 'f', 2, 64),
+				strconv.FormatFloat(q.TotalDiscount(), 'f', 2, 64),
+				strconv.FormatFloat(q.Total(), 'f', 2, 64),
+				string(q.Currency),
+			})
+		}
+
+		if next == nil {
+			return rows, nil
+		}
+		filter.After = next
+	}
+}
+
+// appointmentRows reads every appointment in the dates of the report, page by page, with a header row first
+func (s *ReportService) appointmentRows(ctx context.Context, job *domain.ReportJob) ([][]string, error) {
+	rows := [][]string{{"id", "userId", "slotId", "quoteId", "status"}}
+
+	filter := port.AppointmentFilter{
+		StartDate: job.StartDate,
+		EndDate:   job.EndDate,
+		Limit:     reportPageSize,
+	}
+	for {
+		appointments, next, err := s.appointments.ListAppointments(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range appointments {
+			rows = append(rows, []string{
+				a.ID.String(),
+				a.UserID.String(),
+				a.SlotID.String(),
+				a.QuoteID.String(),
+				string(a.Status),
+			})
+		}
+
+		if next == nil {
+			return rows, nil
+		}
+		filter.After = next
+	}
+}
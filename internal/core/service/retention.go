@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+)
+
+// retentionBatchSize is how many rows are deleted at once, so a large backlog doesn't lock a table for long
+const retentionBatchSize = 1000
+
+/**
+ * RetentionService implements port.RetentionService interface
+ * and deletes the email logs and notifications once they are older than their retention window
+ */
+type RetentionService struct {
+	emailLogs             port.EmailLogRepository
+	notifications         port.NotificationRepository
+	emailLogRetention     time.Duration
+	notificationRetention time.Duration
+}
+
+// NewRetentionService creates a new retention service instance, a zero retention keeps the records forever
+func NewRetentionService(
+	emailLogs port.EmailLogRepository,
+	notifications port.NotificationRepository,
+	emailLogRetention time.Duration,
+	notificationRetention time.Duration,
+) *RetentionService {
+	return &RetentionService{
+		emailLogs,
+		notifications,
+		emailLogRetention,
+		notificationRetention,
+	}
+}
+
+// Purge deletes the email logs and notifications older than their retention window. A table that fails
+// doesn't stop the others from being purged
+func (s *RetentionService) Purge(ctx context.Context) error {
+	now := time.Now()
+	emailLogsErr := s.purge(ctx, "email_logs", s.emailLogRetention, now, s.emailLogs.DeleteEmailLogsBefore)
+	notificationsErr := s.purge(ctx, "notifications", s.notificationRetention, now, s.notifications.DeleteNotificationsBefore)
+
+	if emailLogsErr != nil || notificationsErr != nil {
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// purge deletes the rows older than retention in batches with deleteBefore, until a batch comes back short
+func (s *RetentionService) purge(
+	ctx context.Context,
+	table string,
+	retention time.Duration,
+	now time.Time,
+	deleteBefore func(ctx context.Context, before time.Time, limit uint64) (uint64, error),
+) error {
+	if retention <= 0 {
+		return nil
+	}
+
+	cutoff := now.Add(-retention)
+	var deleted uint64
+	for {
+		n, err := deleteBefore(ctx, cutoff, retentionBatchSize)
+		deleted += n
+		if err != nil {
+			slog.ErrorContext(ctx, "Error purging expired records", "table", table, "deleted", deleted, "error", err)
+			return err
+		}
+		if n < retentionBatchSize {
+			break
+		}
+	}
+
+	if deleted > 0 {
+		slog.InfoContext(ctx, "Expired records purged", "table", table, "count", deleted, "before", cutoff)
+	}
+
+	return nil
+}
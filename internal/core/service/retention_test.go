@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRetained is the part of port.EmailLogRepository and port.NotificationRepository the retention
+// service uses, keeping the creation time of every row
+type memoryRetained struct {
+	port.EmailLogRepository
+	port.NotificationRepository
+	emailLogs     []time.Time
+	notifications []time.Time
+	failEmailLogs bool
+}
+
+// deleteBefore removes up to limit of the rows created before before
+func deleteBefore(rows *[]time.Time, before time.Time, limit uint64) uint64 {
+	var kept []time.Time
+	var deleted uint64
+	for _, createdAt := range *rows {
+		if createdAt.Before(before) && deleted < limit {
+			deleted++
+			continue
+		}
+		kept = append(kept, createdAt)
+	}
+	*rows = kept
+	return deleted
+}
+
+func (m *memoryRetained) DeleteEmailLogsBefore(ctx context.Context, before time.Time, limit uint64) (uint64, error) {
+	if m.failEmailLogs {
+		return 0, errors.New("connection refused")
+	}
+	return deleteBefore(&m.emailLogs, before, limit), nil
+}
+
+func (m *memoryRetained) DeleteNotificationsBefore(ctx context.Context, before time.Time, limit uint64) (uint64, error) {
+	return deleteBefore(&m.notifications, before, limit), nil
+}
+
+// rowsCreated returns count creation times, every one at age
+func rowsCreated(count int, age time.Duration) []time.Time {
+	rows := make([]time.Time, count)
+	for i := range rows {
+		rows[i] = time.Now().Add(-age)
+	}
+	return rows
+}
+
+func TestRetentionPurge(t *testing.T) {
+	t.Run("deletes the rows past their window in batches", func(t *testing.T) {
+		repo := &memoryRetained{
+			emailLogs:     append(rowsCreated(retentionBatchSize+10, 100*24*time.Hour), rowsCreated(3, time.Hour)...),
+			notifications: append(rowsCreated(5, 40*24*time.Hour), rowsCreated(2, time.Hour)...),
+		}
+		svc := NewRetentionService(repo, repo, 90*24*time.Hour, 30*24*time.Hour)
+
+		require.NoError(t, svc.Purge(context.Background()))
+		assert.Len(t, repo.emailLogs, 3)
+		assert.Len(t, repo.notifications, 2)
+	})
+
+	t.Run("keeps the rows without a window", func(t *testing.T) {
+		repo := &memoryRetained{
+			emailLogs:     rowsCreated(5, 365*24*time.Hour),
+			notifications: rowsCreated(5, 365*24*time.Hour),
+		}
+		svc := NewRetentionService(repo, repo, 0, 30*24*time.Hour)
+
+		require.NoError(t, svc.Purge(context.Background()))
+		assert.Len(t, repo.emailLogs, 5)
+		assert.Empty(t, repo.notifications)
+	})
+
+	t.Run("a failing table doesn't stop the others", func(t *testing.T) {
+		repo := &memoryRetained{
+			emailLogs:     rowsCreated(5, 365*24*time.Hour),
+			notifications: rowsCreated(5, 365*24*time.Hour),
+			failEmailLogs: true,
+		}
+		svc := NewRetentionService(repo, repo, 90*24*time.Hour, 30*24*time.Hour)
+
+		assert.Equal(t, domain.ErrInternal, svc.Purge(context.Background()))
+		assert.Len(t, repo.emailLogs, 5)
+		assert.Empty(t, repo.notifications)
+	})
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+)
+
+/**
+ * SalonService implements port.SalonService interface
+ * and provides access to the salon repository and cache service.
+ * The salons are managed by the admins of the default salon, the
+ * admins of the others only see their own
+ */
+type SalonService struct {
+	repo  port.SalonRepository
+	cache *CachedRepository[domain.Salon]
+}
+
+// NewSalonService creates a new salon service instance
+func NewSalonService(repo port.SalonRepository, cache port.CacheRepository) *SalonService {
+	return &SalonService{
+		repo,
+		NewCachedRepository[domain.Salon](cache, "salon", "salons"),
+	}
+}
+
+// CreateSalon creates a new salon
+func (s *SalonService) CreateSalon(ctx context.Context, salon *domain.Salon) (*domain.Salon, error) {
+	if !util.ManagesSalons(ctx) {
+		return nil, domain.ErrForbidden
+	}
+
+	created, err := s.repo.CreateSalon(ctx, salon)
+	if err != nil {
+		if err == domain.ErrConflictingData {
+			return nil, err
+		}
+		slog.ErrorContext(ctx, "Salon creation failed", "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	err = s.cache.Store(ctx, created.ID, created)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "Salon created", "salon_id", created.ID, "slug", created.Slug)
+	return created, nil
+}
+
+// GetSalon retrieves a salon by ID, the salons other than its own are not found from within a salon
+func (s *SalonService) GetSalon(ctx context.Context, id uuid.UUID) (*domain.Salon, error) {
+	if salon, ok := util.Salon(ctx); ok && salon != id && !util.ManagesSalons(ctx) {
+		return nil, domain.ErrDataNotFound
+	}
+
+	salon, err := s.cache.Get(ctx, id, func() (*domain.Salon, error) {
+		return s.repo.GetSalonByID(ctx, id)
+	})
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		slog.ErrorContext(ctx, "Error getting salon", "salon_id", id, "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	return salon, nil
+}
+
+// ListSalons lists the salons
+func (s *SalonService) ListSalons(ctx context.Context, skip, limit uint64) ([]domain.Salon, error) {
+	if !util.ManagesSalons(ctx) {
+		return nil, domain.ErrForbidden
+	}
+
+	params := util.GenerateCacheKeyParams(skip, limit)
+
+	salons, err := s.cache.List(ctx, params, func() ([]domain.Salon, error) {
+		return s.repo.ListSalons(ctx, skip, limit)
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Error listing salons", "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	return salons, nil
+}
+
+// UpdateSalon renames a salon or changes its slug
+func (s *SalonService) UpdateSalon(ctx context.Context, salon *domain.Salon) (*domain.Salon, error) {
+	if !util.ManagesSalons(ctx) {
+		return nil, domain.ErrForbidden
+	}
+
+	existing, err := s.repo.GetSalonByID(ctx, salon.ID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		slog.ErrorContext(ctx, "Error getting salon", "salon_id", salon.ID, "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	if existing.Name == salon.Name && existing.Slug == salon.Slug {
+		return nil, domain.ErrNoUpdatedData
+	}
+
+	updated, err := s.repo.UpdateSalon(ctx, salon)
+	if err != nil {
+		if err == domain.ErrConflictingData || err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		slog.ErrorContext(ctx, "Salon update failed", "salon_id", salon.ID, "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	err = s.cache.Store(ctx, updated.ID, updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
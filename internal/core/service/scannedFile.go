@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+)
+
+/**
+ * ScannedFileService implements port.FileRepository interface
+ * and scans every upload for malware before handing it to the file storage
+ */
+type ScannedFileService struct {
+	file    port.FileRepository
+	scanner port.FileScanner
+}
+
+// NewScannedFileService wraps a file storage so infected uploads are never persisted
+func NewScannedFileService(file port.FileRepository, scanner port.FileScanner) *ScannedFileService {
+	return &ScannedFileService{
+		file,
+		scanner,
+	}
+}
+
+// Save scans the upload while spooling it to a temporary file, then stores it only if it is clean
+func (s *ScannedFileService) Save(ctx context.Context, r io.Reader, size int64, name string) (string, error) {
+	tmp, err := os.CreateTemp("", "upload-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	threat, err := s.scanner.Scan(ctx, io.TeeReader(r, tmp))
+	if err != nil {
+		slog.ErrorContext(ctx, "Antivirus scan failed", "file", name, "error", err)
+		return "", err
+	}
+
+	if threat != "" {
+		slog.WarnContext(ctx, "Infected upload rejected", "file", name, "threat", threat)
+		return "", domain.ErrInfectedFile
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	return s.file.Save(ctx, tmp, size, name)
+}
+
+// Get retrieves a file from the wrapped storage
+func (s *ScannedFileService) Get(ctx context.Context, path string) ([]byte, error) {
+	return s.file.Get(ctx, path)
+}
+
+// Delete removes a file from the wrapped storage
+func (s *ScannedFileService) Delete(ctx context.Context, path string) error {
+	return s.file.Delete(ctx, path)
+}
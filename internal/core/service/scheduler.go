@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+)
+
+// minSchedulerLockTTL is the shortest a run stays locked, so servers whose clocks are a little behind
+// still find the lock of a run that was due at the same time
+const minSchedulerLockTTL = time.Minute
+
+// scheduledTask is a task registered in the scheduler
+type scheduledTask struct {
+	name     string
+	schedule *domain.Schedule
+	run      port.ScheduledTask
+}
+
+/**
+ * SchedulerService implements port.SchedulerService interface
+ * and runs periodic tasks on their schedules, every run is locked in redis so it happens on one server only
+ */
+type SchedulerService struct {
+	locks    port.SchedulerLockRepository
+	reporter port.ErrorReporter
+	tasks    []scheduledTask
+}
+
+// NewSchedulerService creates a new scheduler service instance, the runs that fail are sent to reporter
+func NewSchedulerService(locks port.SchedulerLockRepository, reporter port.ErrorReporter) *SchedulerService {
+	return &SchedulerService{
+		locks:    locks,
+		reporter: reporter,
+	}
+}
+
+// Schedule registers task under name to run on schedule, a nil schedule disables it. Tasks are registered before Run
+func (s *SchedulerService) Schedule(name string, schedule *domain.Schedule, task port.ScheduledTask) {
+	if schedule == nil {
+		slog.Info("Scheduled task disabled", "task", name)
+		return
+	}
+
+	s.tasks = append(s.tasks, scheduledTask{name, schedule, task})
+}
+
+// Run runs the registered tasks on their schedules until ctx is done, a run in progress is finished first
+func (s *SchedulerService) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, task := range s.tasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.loop(ctx, task)
+		}()
+	}
+	wg.Wait()
+}
+
+// loop waits for every run of task and runs it when this server takes its lock
+func (s *SchedulerService) loop(ctx context.Context, task scheduledTask) {
+	for {
+		runAt := task.schedule.Next(time.Now())
+		if runAt.IsZero() {
+			slog.WarnContext(ctx, "Scheduled task never runs", "task", task.name)
+			return
+		}
+
+		timer := time.NewTimer(time.Until(runAt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// The run is locked until the next one is due, the following run takes a lock of its own
+		ttl := max(task.schedule.Next(runAt).Sub(runAt), minSchedulerLockTTL)
+		locked, err := s.locks.TryLock(ctx, task.name, runAt, ttl)
+		if err != nil {
+			slog.ErrorContext(ctx, "Scheduled task lock failed, skipping the run", "task", task.name, "run_at", runAt, "error", err)
+			continue
+		}
+		if !locked {
+			slog.DebugContext(ctx, "Scheduled task run by another server", "task", task.name, "run_at", runAt)
+			continue
+		}
+
+		s.run(context.WithoutCancel(ctx), task, runAt)
+	}
+}
+
+// run runs task once, logging how it went. A panic is logged like an error so the task runs again next time
+func (s *SchedulerService) run(ctx context.Context, task scheduledTask, runAt time.Time) {
+	ctx = util.WithLogAttrs(ctx, slog.String("task", task.name))
+	start := time.Now()
+	panicked := false
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				panicked = true
+				s.reporter.ReportPanic(ctx, r)
+				err = fmt.Errorf("task panicked: %v", r)
+			}
+		}()
+		return task.run(ctx)
+	}()
+
+	if err != nil {
+		slog.ErrorContext(ctx, "Scheduled task failed", "task", task.name, "run_at", runAt, "duration", time.Since(start), "error", err)
+		if !panicked {
+			s.reporter.Report(ctx, fmt.Errorf("scheduled task %s failed: %w", task.name, err))
+		}
+		return
+	}
+	slog.DebugContext(ctx, "Scheduled task done", "task", task.name, "run_at", runAt, "duration", time.Since(start))
+}
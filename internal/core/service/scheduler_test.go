@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySchedulerLock is a port.SchedulerLockRepository shared by the schedulers of a test
+type memorySchedulerLock struct {
+	mu     sync.Mutex
+	locked map[string]bool
+}
+
+func (l *memorySchedulerLock) TryLock(ctx context.Context, task string, runAt time.Time, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	key := fmt.Sprintf("%s:%d", task, runAt.Unix())
+	if l.locked[key] {
+		return false, nil
+	}
+	l.locked[key] = true
+	return true, nil
+}
+
+func (l *memorySchedulerLock) Close() error {
+	return nil
+}
+
+func TestSchedulerRunsEachRunOnce(t *testing.T) {
+	schedule, err := domain.ParseSchedule("@every 1s")
+	require.NoError(t, err)
+
+	locks := &memorySchedulerLock{locked: map[string]bool{}}
+	var runs atomic.Int32
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Three servers run the same task, every run happens on one of them
+	var wg sync.WaitGroup
+	for range 3 {
+		scheduler := NewSchedulerService(locks, &memoryErrorReporter{})
+		scheduler.Schedule("count", schedule, func(ctx context.Context) error {
+			runs.Add(1)
+			return nil
+		})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			scheduler.Run(ctx)
+		}()
+	}
+
+	time.Sleep(2500 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	assert.Equal(t, int32(len(locks.locked)), runs.Load())
+	assert.GreaterOrEqual(t, runs.Load(), int32(2))
+}
+
+func TestSchedulerKeepsRunningAfterFailures(t *testing.T) {
+	schedule, err := domain.ParseSchedule("@every 1s")
+	require.NoError(t, err)
+
+	var runs atomic.Int32
+	ctx, cancel := context.WithCancel(context.Background())
+	reporter := &memoryErrorReporter{}
+	scheduler := NewSchedulerService(&memorySchedulerLock{locked: map[string]bool{}}, reporter)
+	scheduler.Schedule("failing", schedule, func(ctx context.Context) error {
+		if runs.Add(1) == 1 {
+			panic("boom")
+		}
+		return errors.New("still failing")
+	})
+	scheduler.Schedule("disabled", nil, func(ctx context.Context) error {
+		t.Error("a disabled task ran")
+		return nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+
+	time.Sleep(2500 * time.Millisecond)
+	cancel()
+	<-done
+
+	assert.GreaterOrEqual(t, runs.Load(), int32(2))
+	assert.Equal(t, []any{"boom"}, reporter.panics)
+	require.NotEmpty(t, reporter.errors)
+	assert.EqualError(t, reporter.errors[0], "scheduled task failing failed: still failing")
+}
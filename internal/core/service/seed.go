@@ -0,0 +1,379 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"log/slog"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+)
+
+// SeedPassword is the password of every seeded user
+const SeedPassword = "harajuku123"
+
+/**
+ * SeedService fills an empty database with a realistic dataset
+ * for local development, seeded users log in with SeedPassword
+ */
+type SeedService struct {
+	user            port.UserRepository
+	category        port.ServiceCategoryRepository
+	typeOfService   port.TypeOfServiceRepository
+	serviceOffering port.ServiceOfferingRepository
+	slot            port.AvailabilitySlotRepository
+	quote           port.QuoteRepository
+	appointment     port.AppointmentRepository
+	paymentProof    port.PaymentProofRepository
+	file            port.FileRepository
+	currency        domain.Currency
+}
+
+// NewSeedService creates a new seed service instance
+func NewSeedService(
+	user port.UserRepository,
+	category port.ServiceCategoryRepository,
+	typeOfService port.TypeOfServiceRepository,
+	serviceOffering port.ServiceOfferingRepository,
+	slot port.AvailabilitySlotRepository,
+	quote port.QuoteRepository,
+	appointment port.AppointmentRepository,
+	paymentProof port.PaymentProofRepository,
+	file port.FileRepository,
+	currency domain.Currency,
+) *SeedService {
+	return &SeedService{
+		user:            user,
+		category:        category,
+		typeOfService:   typeOfService,
+		serviceOffering: serviceOffering,
+		slot:            slot,
+		quote:           quote,
+		appointment:     appointment,
+		paymentProof:    paymentProof,
+		file:            file,
+		currency:        currency,
+	}
+}
+
+// seedAdmins and seedClients are the seeded users, the first admin marks the database as seeded
+var (
+	seedAdmins = []domain.User{
+		{Name: "Aiko", LastName: "Tanaka", SecondLastName: "Ruiz", Email: "admin@harajuku.dev", Phone: "+525511111111"},
+		{Name: "Mariana", LastName: "López", SecondLastName: "Vega", Email: "stylist@harajuku.dev", Phone: "+525522222222"},
+	}
+	seedClients = []domain.User{
+		{Name: "Sofía", LastName: "Hernández", SecondLastName: "García", Email: "sofia@harajuku.dev", Phone: "+525533333333"},
+		{Name: "Valeria", LastName: "Martínez", SecondLastName: "Cruz", Email: "valeria@harajuku.dev", Phone: "+525544444444"},
+		{Name: "Camila", LastName: "Ramírez", SecondLastName: "Flores", Email: "camila@harajuku.dev", Phone: "+525555555555", PreferredLanguage: domain.LanguageEnglish},
+		{Name: "Daniela", LastName: "Torres", SecondLastName: "Morales", Email: "daniela@harajuku.dev", Phone: "+525566666666"},
+	}
+)
+
+// seedTypeOfService is a seeded type of service and the name of its category
+type seedTypeOfService struct {
+	category        string
+	name            string
+	description     string
+	price           float64
+	durationMinutes int
+}
+
+var (
+	seedCategories     = []string{"Color", "Corte", "Tratamiento"}
+	seedTypesOfService = []seedTypeOfService{
+		{"Color", "Balayage", "Aclarado a mano alzada con tono personalizado", 2800, 240},
+		{"Color", "Retoque de raíz", "Aplicación de color en la raíz", 950, 90},
+		{"Corte", "Corte y peinado", "Corte con lavado y secado", 450, 60},
+		{"Tratamiento", "Tratamiento de keratina", "Alisado y reparación con keratina", 1800, 180},
+	}
+)
+
+// Seed inserts the dataset, nothing is inserted when the database was already seeded
+func (ss *SeedService) Seed(ctx context.Context) error {
+	_, err := ss.user.GetUserByEmail(ctx, seedAdmins[0].Email)
+	if err == nil {
+		slog.InfoContext(ctx, "The database is already seeded", "email", seedAdmins[0].Email)
+		return nil
+	}
+	if err != domain.ErrDataNotFound {
+		return err
+	}
+
+	password, err := util.HashPassword(SeedPassword)
+	if err != nil {
+		return err
+	}
+
+	admins, err := ss.seedUsers(ctx, seedAdmins, domain.Admin, password)
+	if err != nil {
+		return fmt.Errorf("seed admins: %w", err)
+	}
+
+	clients, err := ss.seedUsers(ctx, seedClients, domain.Client, password)
+	if err != nil {
+		return fmt.Errorf("seed clients: %w", err)
+	}
+
+	services, err := ss.seedTypesOfService(ctx, admins)
+	if err != nil {
+		return fmt.Errorf("seed types of service: %w", err)
+	}
+
+	err = ss.seedSchedule(ctx, admins, clients, services)
+	if err != nil {
+		return fmt.Errorf("seed schedule: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Successfully seeded the database", "admins", len(admins), "clients", len(clients), "types_of_service", len(services))
+	return nil
+}
+
+// seedUsers creates the users with the given role
+func (ss *SeedService) seedUsers(ctx context.Context, users []domain.User, role domain.UserRole, password string) ([]domain.User, error) {
+	var created []domain.User
+
+	for _, u := range users {
+		user := u
+		user.ID = uuid.New()
+		user.Password = password
+		user.NotificationPreferences = domain.DefaultNotificationPreferences()
+
+		_, err := ss.user.CreateUser(ctx, &user)
+		if err != nil {
+			return nil, err
+		}
+
+		// Users are always created as clients
+		if role != user.Role {
+			user.Role = role
+			_, err = ss.user.UpdateUser(ctx, &user)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		created = append(created, user)
+	}
+
+	return created, nil
+}
+
+// seedTypesOfService creates the categories and the types of service, every admin offers every service
+func (ss *SeedService) seedTypesOfService(ctx context.Context, admins []domain.User) ([]domain.TypeOfService, error) {
+	categories := make(map[string]uuid.UUID)
+	for _, name := range seedCategories {
+		category, err := ss.category.CreateServiceCategory(ctx, &domain.ServiceCategory{ID: uuid.New(), Name: name})
+		if err != nil {
+			return nil, err
+		}
+		categories[name] = category.ID
+	}
+
+	var services []domain.TypeOfService
+	for _, s := range seedTypesOfService {
+		categoryID := categories[s.category]
+		service, err := ss.typeOfService.CreateTypeOfService(ctx, &domain.TypeOfService{
+			ID:              uuid.New(),
+			Name:            s.name,
+			Description:     s.description,
+			Price:           s.price,
+			Currency:        ss.currency,
+			DurationMinutes: s.durationMinutes,
+			CategoryID:      &categoryID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, admin := range admins {
+			_, err = ss.serviceOffering.CreateServiceOffering(ctx, &domain.ServiceOffering{AdminID: admin.ID, TypeOfServiceID: service.ID})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		services = append(services, *service)
+	}
+
+	return services, nil
+}
+
+// seedQuoteStates are the quote states the seeded quotes go through, every client gets one quote in each
+var seedQuoteStates = []domain.QuoteState{
+	domain.QuotePending,
+	domain.QuoteRequiresProof,
+	domain.QuotePendingPayment,
+	domain.QuoteApproved,
+	domain.QuoteRejected,
+}
+
+// seedSchedule creates the slots of the past and next week, the quotes of every client,
+// the payment proofs of the quotes that were paid and the appointments of the approved quotes
+func (ss *SeedService) seedSchedule(ctx context.Context, admins, clients []domain.User, services []domain.TypeOfService) error {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	var past, upcoming []domain.AvailabilitySlot
+	for day := -7; day <= 7; day++ {
+		if day == 0 {
+			continue
+		}
+		for i, admin := range admins {
+			for _, hour := range []int{10, 13, 16} {
+				start := today.AddDate(0, 0, day).Add(time.Duration(hour+i) * time.Hour)
+				slot, err := ss.slot.CreateAvailabilitySlot(ctx, &domain.AvailabilitySlot{
+					ID:        uuid.New(),
+					AdminID:   admin.ID,
+					StartTime: start,
+					EndTime:   start.Add(2 * time.Hour),
+				})
+				if err != nil {
+					return err
+				}
+
+				if day < 0 {
+					past = append(past, *slot)
+				} else {
+					upcoming = append(upcoming, *slot)
+				}
+			}
+		}
+	}
+
+	for i, client := range clients {
+		for j, state := range seedQuoteStates {
+			service := services[(i+j)%len(services)]
+			quote, err := ss.quote.CreateQuote(ctx, &domain.Quote{
+				ID:              uuid.New(),
+				TypeOfServiceID: service.ID,
+				ClientID:        client.ID,
+				Time:            today.AddDate(0, 0, -j),
+				Description:     fmt.Sprintf("%s para %s", service.Name, client.Name),
+				State:           state,
+				Price:           service.Price,
+				Currency:        service.Currency,
+			})
+			if err != nil {
+				return err
+			}
+
+			// A proof is uploaded once the payment is requested and reviewed when the quote is approved
+			if state == domain.QuotePendingPayment || state == domain.QuoteApproved {
+				err = ss.seedPaymentProof(ctx, quote, state == domain.QuoteApproved)
+				if err != nil {
+					return err
+				}
+			}
+
+			if state != domain.QuoteApproved {
+				continue
+			}
+
+			// The approved quote of every client has an appointment in a different status
+			slots, status := upcoming, domain.Booked
+			switch i % 4 {
+			case 0:
+				slots, status = past, domain.Completed
+			case 1:
+				status = domain.Pending
+			case 2:
+				slots, status = past, domain.Cancelled
+			}
+
+			slot := slots[i]
+			_, err = ss.appointment.CreateAppointment(ctx, &domain.Appointment{
+				ID:      uuid.New(),
+				UserID:  client.ID,
+				SlotID:  slot.ID,
+				QuoteID: quote.ID,
+				Status:  status,
+			})
+			if err != nil {
+				return err
+			}
+
+			if status == domain.Cancelled {
+				continue
+			}
+
+			slot.IsBooked = true
+			_, err = ss.slot.UpdateAvailabilitySlot(ctx, &slot)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// seedPaymentProof stores a generated receipt image for the quote and creates its payment proof
+func (ss *SeedService) seedPaymentProof(ctx context.Context, quote *domain.Quote, reviewed bool) error {
+	receipt, err := seedReceipt(quote.ID)
+	if err != nil {
+		return err
+	}
+
+	inspector, err := util.NewFileInspector(bytes.NewReader(receipt))
+	if err != nil {
+		return err
+	}
+
+	fileName := fmt.Sprintf("seed/payment-proofs/%s.png", quote.ID)
+	path, err := ss.file.Save(ctx, inspector, int64(len(receipt)), fileName)
+	if err != nil {
+		return err
+	}
+
+	proof, err := ss.paymentProof.CreatePaymentProof(ctx, &domain.PaymentProof{
+		ID:          uuid.New(),
+		QuoteID:     quote.ID,
+		URL:         path,
+		FileName:    "comprobante.png",
+		Size:        inspector.Size(),
+		ContentType: inspector.ContentType(),
+		Checksum:    inspector.Checksum(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if !reviewed {
+		return nil
+	}
+
+	proof.IsReviewed = true
+	_, err = ss.paymentProof.UpdatePaymentProof(ctx, proof)
+	return err
+}
+
+// seedReceipt draws a small PNG tinted after the quote ID, so every receipt has a different checksum
+func seedReceipt(id uuid.UUID) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, 64, 96))
+	tint := color.RGBA{R: id[0], G: id[1], B: id[2], A: 255}
+	for y := 0; y < 96; y++ {
+		for x := 0; x < 64; x++ {
+			c := tint
+			if y%12 == 0 {
+				c = color.RGBA{A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, img)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+)
+
+/**
+ * ServiceCategoryService implements port.ServiceCategoryService interface
+ * and provides access to the service category repository and cache service
+ */
+type ServiceCategoryService struct {
+	repo  port.ServiceCategoryRepository
+	cache *CachedRepository[domain.ServiceCategory]
+}
+
+// NewServiceCategoryService creates a new service category service instance
+func NewServiceCategoryService(repo port.ServiceCategoryRepository, cache port.CacheRepository) *ServiceCategoryService {
+	return &ServiceCategoryService{
+		repo,
+		NewCachedRepository[domain.ServiceCategory](cache, "servicecategory", "servicecategories"),
+	}
+}
+
+// CreateServiceCategory creates a new service category
+func (s *ServiceCategoryService) CreateServiceCategory(ctx context.Context, c *domain.ServiceCategory) (*domain.ServiceCategory, error) {
+	created, err := s.repo.CreateServiceCategory(ctx, c)
+	if err != nil {
+		slog.ErrorContext(ctx, "ServiceCategory creation failed", "error", err)
+		if err == domain.ErrConflictingData {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	err = s.cache.Store(ctx, created.ID, created)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// GetServiceCategory retrieves a service category by ID
+func (s *ServiceCategoryService) GetServiceCategory(ctx context.Context, id uuid.UUID) (*domain.ServiceCategory, error) {
+	c, err := s.cache.Get(ctx, id, func() (*domain.ServiceCategory, error) {
+		return s.repo.GetServiceCategoryByID(ctx, id)
+	})
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	return c, nil
+}
+
+// ListServiceCategories lists service categories
+func (s *ServiceCategoryService) ListServiceCategories(ctx context.Context, skip, limit uint64) ([]domain.ServiceCategory, error) {
+	params := util.GenerateCacheKeyParams(skip, limit)
+
+	categories, err := s.cache.List(ctx, params, func() ([]domain.ServiceCategory, error) {
+		return s.repo.ListServiceCategories(ctx, skip, limit)
+	})
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return categories, nil
+}
+
+// UpdateServiceCategory updates an existing service category
+func (s *ServiceCategoryService) UpdateServiceCategory(ctx context.Context, c *domain.ServiceCategory) (*domain.ServiceCategory, error) {
+	existing, err := s.repo.GetServiceCategoryByID(ctx, c.ID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	if existing.Name == c.Name {
+		return nil, domain.ErrNoUpdatedData
+	}
+
+	updated, err := s.repo.UpdateServiceCategory(ctx, c)
+	if err != nil {
+		if err == domain.ErrConflictingData || err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	err = s.cache.Store(ctx, c.ID, updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteServiceCategory deletes a service category by ID
+func (s *ServiceCategoryService) DeleteServiceCategory(ctx context.Context, id uuid.UUID) error {
+	_, err := s.repo.GetServiceCategoryByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return err
+		}
+		return domain.ErrInternal
+	}
+
+	// A category that still groups types of service is rejected by the repository
+	err = s.repo.DeleteServiceCategory(ctx, id)
+	if err != nil {
+		if err == domain.ErrConflictingData {
+			return err
+		}
+		return domain.ErrInternal
+	}
+
+	err = s.cache.Invalidate(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
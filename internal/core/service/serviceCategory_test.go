@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"harajuku/backend/internal/adapter/storage/cache"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryServiceCategories keeps the service categories in a map, rejecting the deletion of the ones
+// still grouping types of service like the foreign key does
+type memoryServiceCategories struct {
+	categories map[uuid.UUID]domain.ServiceCategory
+	types      memoryTypesOfService
+}
+
+func (r *memoryServiceCategories) CreateServiceCategory(ctx context.Context, category *domain.ServiceCategory) (*domain.ServiceCategory, error) {
+	for _, c := range r.categories {
+		if c.Name == category.Name {
+			return nil, domain.ErrConflictingData
+		}
+	}
+	category.ID = uuid.New()
+	r.categories[category.ID] = *category
+	return category, nil
+}
+
+func (r *memoryServiceCategories) GetServiceCategoryByID(ctx context.Context, id uuid.UUID) (*domain.ServiceCategory, error) {
+	category, ok := r.categories[id]
+	if !ok {
+		return nil, domain.ErrDataNotFound
+	}
+	return &category, nil
+}
+
+func (r *memoryServiceCategories) ListServiceCategories(ctx context.Context, skip, limit uint64) ([]domain.ServiceCategory, error) {
+	var categories []domain.ServiceCategory
+	for _, c := range r.categories {
+		categories = append(categories, c)
+	}
+	return categories, nil
+}
+
+func (r *memoryServiceCategories) UpdateServiceCategory(ctx context.Context, category *domain.ServiceCategory) (*domain.ServiceCategory, error) {
+	if _, ok := r.categories[category.ID]; !ok {
+		return nil, domain.ErrDataNotFound
+	}
+	r.categories[category.ID] = *category
+	return category, nil
+}
+
+func (r *memoryServiceCategories) DeleteServiceCategory(ctx context.Context, id uuid.UUID) error {
+	for _, t := range r.types {
+		if t.CategoryID != nil && *t.CategoryID == id {
+			return domain.ErrConflictingData
+		}
+	}
+	delete(r.categories, id)
+	return nil
+}
+
+func TestUpdateServiceCategoryUnchanged(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryServiceCategories{categories: map[uuid.UUID]domain.ServiceCategory{}}
+	svc := NewServiceCategoryService(repo, cache.NewMemory(100))
+
+	created, err := svc.CreateServiceCategory(ctx, &domain.ServiceCategory{Name: "Color"})
+	require.NoError(t, err)
+
+	_, err = svc.UpdateServiceCategory(ctx, &domain.ServiceCategory{ID: created.ID, Name: "Color"})
+	assert.Equal(t, domain.ErrNoUpdatedData, err)
+
+	updated, err := svc.UpdateServiceCategory(ctx, &domain.ServiceCategory{ID: created.ID, Name: "Coloración"})
+	require.NoError(t, err)
+	assert.Equal(t, "Coloración", updated.Name)
+
+	got, err := svc.GetServiceCategory(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Coloración", got.Name)
+}
+
+func TestDeleteServiceCategoryInUse(t *testing.T) {
+	ctx := context.Background()
+	types := memoryTypesOfService{}
+	repo := &memoryServiceCategories{categories: map[uuid.UUID]domain.ServiceCategory{}, types: types}
+	svc := NewServiceCategoryService(repo, cache.NewMemory(100))
+	typesOfService := newTestTypeOfServiceService(types)
+
+	color, err := svc.CreateServiceCategory(ctx, &domain.ServiceCategory{Name: "Color"})
+	require.NoError(t, err)
+	cut, err := svc.CreateServiceCategory(ctx, &domain.ServiceCategory{Name: "Corte"})
+	require.NoError(t, err)
+
+	balayage, err := typesOfService.CreateTypeOfService(ctx, &domain.TypeOfService{Name: "Balayage", Price: 1200, CategoryID: &color.ID})
+	require.NoError(t, err)
+	_, err = typesOfService.CreateTypeOfService(ctx, &domain.TypeOfService{Name: "Corte", Price: 250})
+	require.NoError(t, err)
+
+	// The category filter reaches the repository
+	services, total, err := typesOfService.ListTypeOfServices(ctx, port.TypeOfServiceFilter{CategoryID: &color.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	assert.Equal(t, balayage.ID, services[0].ID)
+	assert.Equal(t, uint64(1), total)
+
+	assert.Equal(t, domain.ErrConflictingData, svc.DeleteServiceCategory(ctx, color.ID))
+	require.NoError(t, svc.DeleteServiceCategory(ctx, cut.ID))
+
+	_, err = svc.GetServiceCategory(ctx, cut.ID)
+	assert.Equal(t, domain.ErrDataNotFound, err)
+}
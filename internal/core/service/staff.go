@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+)
+
+/**
+ * StaffService implements port.StaffService interface
+ * and provides access to the staff repository, the admins the
+ * profiles belong to, the file storage of their photos and cache service
+ */
+type StaffService struct {
+	repo  port.StaffRepository
+	user  port.UserRepository
+	file  port.FileRepository
+	cache *CachedRepository[domain.Staff]
+}
+
+// NewStaffService creates a new staff service instance
+func NewStaffService(repo port.StaffRepository, user port.UserRepository, file port.FileRepository, cache port.CacheRepository) *StaffService {
+	return &StaffService{
+		repo,
+		user,
+		file,
+		newStaffCache(cache),
+	}
+}
+
+// newStaffCache creates the cache of the staff directory, shared with the services changing what
+// a staff member offers
+func newStaffCache(cache port.CacheRepository) *CachedRepository[domain.Staff] {
+	return NewCachedRepository[domain.Staff](cache, "staff", "staffList")
+}
+
+// activeStaff returns the staff profile of an admin, nil for the admins without one. It fails with
+// ErrStaffInactive when the profile is not active, so the stylist is given no new work
+func activeStaff(ctx context.Context, repo port.StaffRepository, adminID uuid.UUID) (*domain.Staff, error) {
+	staff, err := repo.GetStaffByUserID(ctx, adminID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, nil
+		}
+		slog.ErrorContext(ctx, "Error getting staff member", "user_id", adminID, "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	if !staff.Active {
+		return nil, domain.ErrStaffInactive
+	}
+
+	return staff, nil
+}
+
+// CreateStaff creates the profile of an admin in the staff directory
+func (s *StaffService) CreateStaff(ctx context.Context, staff *domain.Staff) (*domain.Staff, error) {
+	user, err := s.user.GetUserByID(ctx, staff.UserID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	// Only admins attend appointments, so only they are stylists
+	if user.Role != domain.Admin {
+		return nil, domain.ErrForbidden
+	}
+
+	staff.SalonID = user.SalonID
+	staff.Specialties = uniqueSpecialties(staff.Specialties)
+
+	created, err := s.repo.CreateStaff(ctx, staff)
+	if err != nil {
+		if err == domain.ErrConflictingData || err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		slog.ErrorContext(ctx, "Staff creation failed", "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	err = s.cache.Store(ctx, created.ID, created)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// GetStaff retrieves a staff member by ID
+func (s *StaffService) GetStaff(ctx context.Context, id uuid.UUID) (*domain.Staff, error) {
+	staff, err := s.cache.Get(ctx, id, func() (*domain.Staff, error) {
+		return s.repo.GetStaffByID(ctx, id)
+	})
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		slog.ErrorContext(ctx, "Error getting staff member", "staff_id", id, "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	return staff, nil
+}
+
+// ListStaff lists the staff directory
+func (s *StaffService) ListStaff(ctx context.Context, filter port.StaffFilter) ([]domain.Staff, error) {
+	var typeOfServiceID string
+	if filter.TypeOfServiceID != nil {
+		typeOfServiceID = filter.TypeOfServiceID.String()
+	}
+	params := util.GenerateCacheKeyParams(filter.Active, filter.Specialty, typeOfServiceID, filter.Skip, filter.Limit)
+
+	staff, err := s.cache.List(ctx, params, func() ([]domain.Staff, error) {
+		return s.repo.ListStaff(ctx, filter)
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "Error listing staff", "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	return staff, nil
+}
+
+// UpdateStaff updates the profile of a staff member, its photo is changed with SetStaffPhoto
+func (s *StaffService) UpdateStaff(ctx context.Context, staff *domain.Staff) (*domain.Staff, error) {
+	existing, err := s.repo.GetStaffByID(ctx, staff.ID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	staff.UserID = existing.UserID
+	staff.PhotoURL = existing.PhotoURL
+	staff.Specialties = uniqueSpecialties(staff.Specialties)
+
+	updated, err := s.repo.UpdateStaff(ctx, staff)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		slog.ErrorContext(ctx, "Staff update failed", "staff_id", staff.ID, "error", err)
+		return nil, domain.ErrInternal
+	}
+
+	if existing.Active != updated.Active {
+		slog.InfoContext(ctx, "Staff member activity changed", "staff_id", updated.ID, "active", updated.Active)
+	}
+
+	err = s.cache.Store(ctx, updated.ID, updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteStaff deletes a staff member and its photo, the admin account keeps its slots and offerings
+func (s *StaffService) DeleteStaff(ctx context.Context, id uuid.UUID) error {
+	staff, err := s.repo.GetStaffByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return err
+		}
+		return domain.ErrInternal
+	}
+
+	err = s.repo.DeleteStaff(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return err
+		}
+		return domain.ErrInternal
+	}
+
+	if staff.PhotoURL != "" {
+		if err := s.file.Delete(ctx, staff.PhotoURL); err != nil {
+			slog.WarnContext(ctx, "deleting staff photo failed", "path", staff.PhotoURL, "error", err)
+		}
+	}
+
+	return s.cache.Invalidate(ctx, id)
+}
+
+// SetStaffPhoto uploads the photo of a staff member and deletes the one it replaces
+func (s *StaffService) SetStaffPhoto(ctx context.Context, id uuid.UUID, file io.Reader, size int64, fileName string) (*domain.Staff, error) {
+	staff, err := s.repo.GetStaffByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	// Strip EXIF/GPS metadata and apply the orientation before anything is stored
+	file, size, err = util.NormalizeImage(file, size)
+	if err != nil {
+		slog.ErrorContext(ctx, "normalizing image failed", "error", err)
+		if err == domain.ErrInvalidImage || err == domain.ErrImageTooLarge || err == domain.ErrFileTooLarge {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	// Every upload gets its own key so the signed URLs of the previous photo stop showing the new one
+	previous := staff.PhotoURL
+	key := fmt.Sprintf("staff/%s/%s%s", id, uuid.New(), filepath.Ext(fileName))
+	staff.PhotoURL, err = s.file.Save(ctx, file, size, key)
+	if err != nil {
+		slog.ErrorContext(ctx, "file save failed", "error", err)
+		if err == domain.ErrInfectedFile {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	updated, err := s.repo.UpdateStaff(ctx, staff)
+	if err != nil {
+		slog.ErrorContext(ctx, "Staff photo update failed", "staff_id", id, "error", err)
+		if err := s.file.Delete(ctx, staff.PhotoURL); err != nil {
+			slog.ErrorContext(ctx, "deleting file failed", "error", err)
+		}
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	if previous != "" {
+		if err := s.file.Delete(ctx, previous); err != nil {
+			slog.WarnContext(ctx, "deleting previous staff photo failed", "path", previous, "error", err)
+		}
+	}
+
+	err = s.cache.Store(ctx, updated.ID, updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// GetStaffPhoto returns a staff member along with the contents of its photo
+func (s *StaffService) GetStaffPhoto(ctx context.Context, id uuid.UUID) (*domain.Staff, []byte, error) {
+	staff, err := s.GetStaff(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if staff.PhotoURL == "" {
+		return nil, nil, domain.ErrDataNotFound
+	}
+
+	data, err := s.file.Get(ctx, staff.PhotoURL)
+	if err != nil {
+		slog.ErrorContext(ctx, "file get failed", "path", staff.PhotoURL, "error", err)
+		return nil, nil, domain.ErrInternal
+	}
+
+	return staff, data, nil
+}
+
+// uniqueSpecialties drops the repeated and empty specialties while keeping their order, never nil
+// since the column is not nullable
+func uniqueSpecialties(specialties []string) []string {
+	seen := make(map[string]bool, len(specialties))
+	unique := make([]string, 0, len(specialties))
+	for _, specialty := range specialties {
+		if specialty != "" && !seen[specialty] {
+			seen[specialty] = true
+			unique = append(unique, specialty)
+		}
+	}
+	return unique
+}
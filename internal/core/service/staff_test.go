@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/adapter/storage/cache"
+	"harajuku/backend/internal/adapter/storage/memory"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStaff keeps the staff profiles in a map, the profiles of an admin are unique
+type memoryStaff map[uuid.UUID]domain.Staff
+
+func (r memoryStaff) CreateStaff(ctx context.Context, staff *domain.Staff) (*domain.Staff, error) {
+	if _, err := r.GetStaffByUserID(ctx, staff.UserID); err == nil {
+		return nil, domain.ErrConflictingData
+	}
+	r[staff.ID] = *staff
+	return staff, nil
+}
+
+func (r memoryStaff) GetStaffByID(ctx context.Context, id uuid.UUID) (*domain.Staff, error) {
+	staff, ok := r[id]
+	if !ok {
+		return nil, domain.ErrDataNotFound
+	}
+	return &staff, nil
+}
+
+func (r memoryStaff) GetStaffByUserID(ctx context.Context, userID uuid.UUID) (*domain.Staff, error) {
+	for _, staff := range r {
+		if staff.UserID == userID {
+			return &staff, nil
+		}
+	}
+	return nil, domain.ErrDataNotFound
+}
+
+func (r memoryStaff) ListStaff(ctx context.Context, filter port.StaffFilter) ([]domain.Staff, error) {
+	var staff []domain.Staff
+	for _, s := range r {
+		staff = append(staff, s)
+	}
+	return staff, nil
+}
+
+func (r memoryStaff) UpdateStaff(ctx context.Context, staff *domain.Staff) (*domain.Staff, error) {
+	if _, ok := r[staff.ID]; !ok {
+		return nil, domain.ErrDataNotFound
+	}
+	r[staff.ID] = *staff
+	return staff, nil
+}
+
+func (r memoryStaff) DeleteStaff(ctx context.Context, id uuid.UUID) error {
+	delete(r, id)
+	return nil
+}
+
+func TestCreateStaffOnlyForAdmins(t *testing.T) {
+	ctx := context.Background()
+	users := memory.NewUserRepository(memory.New())
+	svc := NewStaffService(memoryStaff{}, users, memory.NewFileRepository(), cache.NewMemory(100))
+
+	client, err := users.CreateUser(ctx, &domain.User{Name: "Ana", Email: "ana@harajuku.mx", Role: domain.Client})
+	require.NoError(t, err)
+	admin, err := users.CreateUser(ctx, &domain.User{Name: "Mariana", Email: "mariana@harajuku.mx", Role: domain.Admin})
+	require.NoError(t, err)
+
+	_, err = svc.CreateStaff(ctx, &domain.Staff{ID: uuid.New(), UserID: client.ID, DisplayName: "Ana"})
+	assert.Equal(t, domain.ErrForbidden, err)
+
+	staff, err := svc.CreateStaff(ctx, &domain.Staff{ID: uuid.New(), UserID: admin.ID, DisplayName: "Mariana", Specialties: []string{"balayage", "", "balayage"}, Active: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"balayage"}, staff.Specialties)
+	assert.Equal(t, admin.Salon(), staff.SalonID)
+
+	// An admin has a single profile
+	_, err = svc.CreateStaff(ctx, &domain.Staff{ID: uuid.New(), UserID: admin.ID, DisplayName: "Mariana"})
+	assert.Equal(t, domain.ErrConflictingData, err)
+}
+
+func TestInactiveStaffGetsNoNewSlots(t *testing.T) {
+	ctx := context.Background()
+	staff := memoryStaff{}
+	db := memory.New()
+	svc := NewAvailabilitySlotService(memory.NewAvailabilitySlotRepository(db), memory.NewAppointmentRepository(db), staff, cache.NewMemory(100))
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	slotOf := func(adminID uuid.UUID) *domain.AvailabilitySlot {
+		return &domain.AvailabilitySlot{AdminID: adminID, StartTime: start, EndTime: start.Add(time.Hour)}
+	}
+
+	// The admins without a profile are not restricted
+	_, err := svc.CreateAvailabilitySlot(ctx, slotOf(uuid.New()))
+	require.NoError(t, err)
+
+	stylist := domain.Staff{ID: uuid.New(), UserID: uuid.New(), DisplayName: "Mariana", Active: true}
+	staff[stylist.ID] = stylist
+
+	_, err = svc.CreateAvailabilitySlot(ctx, slotOf(stylist.UserID))
+	require.NoError(t, err)
+
+	stylist.Active = false
+	staff[stylist.ID] = stylist
+
+	_, err = svc.CreateAvailabilitySlot(ctx, slotOf(stylist.UserID))
+	assert.Equal(t, domain.ErrStaffInactive, err)
+}
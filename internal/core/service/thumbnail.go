@@ -0,0 +1,114 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+)
+
+// thumbnailSize is the largest width and height of the thumbnails, in pixels
+const thumbnailSize = 320
+
+// thumbnailExtensions are the extensions of the uploads a thumbnail is generated for
+var thumbnailExtensions = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+	".gif":  true,
+	".webp": true,
+}
+
+/**
+ * ThumbnailService implements port.FileRepository interface
+ * and queues the generation of a thumbnail for every image saved, the workers store it at domain.ThumbnailKey
+ */
+type ThumbnailService struct {
+	file port.FileRepository
+	jobs port.JobQueueService
+}
+
+// NewThumbnailService wraps a file storage so saved images get a thumbnail, the thumbnails are written
+// to storage, which is the storage before any wrapping that would scan or thumbnail them again
+func NewThumbnailService(file port.FileRepository, storage port.FileRepository, jobs port.JobQueueService) *ThumbnailService {
+	s := &ThumbnailService{
+		file,
+		jobs,
+	}
+	jobs.Handle(domain.JobGenerateThumbnail, func(ctx context.Context, payload json.RawMessage) error {
+		return generateThumbnail(ctx, storage, payload)
+	})
+
+	return s
+}
+
+// Save stores the file and, when it is an image, queues its thumbnail. A thumbnail that can't be queued is only logged
+func (s *ThumbnailService) Save(ctx context.Context, r io.Reader, size int64, name string) (string, error) {
+	key, err := s.file.Save(ctx, r, size, name)
+	if err != nil {
+		return "", err
+	}
+
+	if thumbnailExtensions[strings.ToLower(path.Ext(key))] {
+		if _, err := s.jobs.Enqueue(ctx, domain.JobGenerateThumbnail, domain.ThumbnailJob{Key: key}); err != nil {
+			slog.ErrorContext(ctx, "Thumbnail job enqueue failed", "file", key, "error", err)
+		}
+	}
+
+	return key, nil
+}
+
+// Get retrieves a file from the wrapped storage
+func (s *ThumbnailService) Get(ctx context.Context, path string) ([]byte, error) {
+	return s.file.Get(ctx, path)
+}
+
+// Delete removes a file from the wrapped storage along with its thumbnail
+func (s *ThumbnailService) Delete(ctx context.Context, key string) error {
+	if err := s.file.Delete(ctx, key); err != nil {
+		return err
+	}
+
+	if thumbnailExtensions[strings.ToLower(path.Ext(key))] {
+		if err := s.file.Delete(ctx, domain.ThumbnailKey(key)); err != nil {
+			slog.WarnContext(ctx, "Thumbnail deletion failed", "file", key, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// generateThumbnail runs a thumbnail job, images that can't be decoded are rejected
+func generateThumbnail(ctx context.Context, storage port.FileRepository, payload json.RawMessage) error {
+	var job domain.ThumbnailJob
+	if err := decodeJob(payload, &job); err != nil {
+		return err
+	}
+
+	data, err := storage.Get(ctx, job.Key)
+	if err != nil {
+		return err
+	}
+
+	thumbnail, err := util.Thumbnail(data, thumbnailSize)
+	if err == domain.ErrInvalidImage {
+		return fmt.Errorf("%w: %s is not an image", domain.ErrJobRejected, job.Key)
+	}
+	if err == domain.ErrImageTooLarge {
+		return fmt.Errorf("%w: %s is too large to decode", domain.ErrJobRejected, job.Key)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = storage.Save(ctx, bytes.NewReader(thumbnail), int64(len(thumbnail)), domain.ThumbnailKey(job.Key))
+	return err
+}
@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"log/slog"
+	"path/filepath"
 
 	"harajuku/backend/internal/core/domain"
 	"harajuku/backend/internal/core/port"
@@ -13,70 +16,147 @@ import (
 
 /**
  * TypeOfServiceService implements port.TypeOfServiceService interface
- * and provides access to the type of service repository and cache service
+ * and provides access to the type of service repository, its showcase images,
+ * the admins offering it, their staff profiles and cache service
  */
 type TypeOfServiceService struct {
-	repo  port.TypeOfServiceRepository
-	cache port.CacheRepository
+	repo     port.TypeOfServiceRepository
+	image    port.TypeOfServiceImageRepository
+	offering port.ServiceOfferingRepository
+	staff    port.StaffRepository
+	user     port.UserRepository
+	file     port.FileRepository
+	cache    *CachedRepository[domain.TypeOfService]
+	// staffCache drops the staff members whose offered types of service change
+	staffCache *CachedRepository[domain.Staff]
+	currency   domain.Currency
+	audit      port.AuditRepository
 }
 
 // NewTypeOfServiceService creates a new TypeOfService service instance
-func NewTypeOfServiceService(repo port.TypeOfServiceRepository, cache port.CacheRepository) *TypeOfServiceService {
+func NewTypeOfServiceService(
+	repo port.TypeOfServiceRepository,
+	image port.TypeOfServiceImageRepository,
+	offering port.ServiceOfferingRepository,
+	staff port.StaffRepository,
+	user port.UserRepository,
+	file port.FileRepository,
+	cache port.CacheRepository,
+	currency domain.Currency,
+	audit port.AuditRepository,
+) *TypeOfServiceService {
 	return &TypeOfServiceService{
 		repo,
-		cache,
+		image,
+		offering,
+		staff,
+		user,
+		file,
+		NewCachedRepository[domain.TypeOfService](cache, "typeofservice", "typeofservices"),
+		newStaffCache(cache),
+		currency,
+		audit,
 	}
 }
 
 // CreateTypeOfService creates a new type of service
 func (s *TypeOfServiceService) CreateTypeOfService(ctx context.Context, t *domain.TypeOfService) (*domain.TypeOfService, error) {
+	err := s.resolveCurrency(t)
+	if err != nil {
+		return nil, err
+	}
+
+	if !validDurationRange(t) {
+		return nil, domain.ErrInvalidDurationRange
+	}
+
 	// Save the TypeOfService using the repository
 	created, err := s.repo.CreateTypeOfService(ctx, t)
 	if err != nil {
-		slog.Error("TypeOfService creation failed", "error", err)
-		if err == domain.ErrConflictingData {
+		slog.ErrorContext(ctx, "TypeOfService creation failed", "error", err)
+		if err == domain.ErrConflictingData || err == domain.ErrDataNotFound {
 			return nil, err
 		}
 		return nil, domain.ErrInternal
 	}
 
 	// Cache the newly created TypeOfService
-	cacheKey := util.GenerateCacheKey("typeofservice", created.ID)
-	serialized, err := util.Serialize(created)
+	err = s.cache.Store(ctx, created.ID, created)
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, err
 	}
 
-	err = s.cache.Set(ctx, cacheKey, serialized, 0)
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
+	return created, nil
+}
+
+// GetTypeOfService retrieves a type of service by ID
+func (s *TypeOfServiceService) GetTypeOfService(ctx context.Context, id uuid.UUID) (*domain.TypeOfService, error) {
+	t, err := s.cache.Get(ctx, id, func() (*domain.TypeOfService, error) {
+		t, err := s.repo.GetTypeOfServiceByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.attachDetails(ctx, []*domain.TypeOfService{t})
+		if err != nil {
+			return nil, err
+		}
 
-	err = s.cache.DeleteByPrefix(ctx, "typeofservices:*")
+		return t, nil
+	})
 	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
 		return nil, domain.ErrInternal
 	}
 
-	return created, nil
+	return t, nil
 }
 
-// GetTypeOfService retrieves a type of service by ID
-func (s *TypeOfServiceService) GetTypeOfService(ctx context.Context, id uuid.UUID) (*domain.TypeOfService, error) {
-	var t *domain.TypeOfService
+// ListTypeOfServices lists all types of service
+func (s *TypeOfServiceService) ListTypeOfServices(ctx context.Context, filter port.TypeOfServiceFilter) ([]domain.TypeOfService, uint64, error) {
+	// Generate cache key for paginated list
+	var categoryID string
+	if filter.CategoryID != nil {
+		categoryID = filter.CategoryID.String()
+	}
+	params := util.GenerateCacheKeyParams(filter.Skip, filter.Limit, categoryID, filter.IncludeArchived, filter.IncludeDeleted, filter.Name, filter.PriceOrder)
 
-	// Check cache for TypeOfService
-	cacheKey := util.GenerateCacheKey("typeofservice", id)
-	cachedData, err := s.cache.Get(ctx, cacheKey)
-	if err == nil {
-		err := util.Deserialize(cachedData, &t)
+	services, total, err := s.cache.ListPage(ctx, params, func() ([]domain.TypeOfService, uint64, error) {
+		services, err := s.repo.ListTypeOfServices(ctx, filter)
 		if err != nil {
-			return nil, domain.ErrInternal
+			return nil, 0, err
 		}
-		return t, nil
+
+		total, err := s.repo.CountTypeOfServices(ctx, filter)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		refs := make([]*domain.TypeOfService, len(services))
+		for i := range services {
+			refs[i] = &services[i]
+		}
+
+		err = s.attachDetails(ctx, refs)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return services, total, nil
+	})
+	if err != nil {
+		return nil, 0, domain.ErrInternal
 	}
 
-	// If not found in cache, fetch from repository
-	t, err = s.repo.GetTypeOfServiceByID(ctx, id)
+	return services, total, nil
+}
+
+// UpdateTypeOfService updates an existing type of service
+func (s *TypeOfServiceService) UpdateTypeOfService(ctx context.Context, t *domain.TypeOfService) (*domain.TypeOfService, error) {
+	// Check if the type of service exists
+	existingService, err := s.getLiveTypeOfService(ctx, t.ID)
 	if err != nil {
 		if err == domain.ErrDataNotFound {
 			return nil, err
@@ -84,113 +164,279 @@ func (s *TypeOfServiceService) GetTypeOfService(ctx context.Context, id uuid.UUI
 		return nil, domain.ErrInternal
 	}
 
-	// Cache the retrieved TypeOfService
-	serialized, err := util.Serialize(t)
+	// Updates without a currency keep the current one
+	if t.Currency == "" {
+		t.Currency = existingService.Currency
+	}
+
+	err = s.resolveCurrency(t)
 	if err != nil {
+		return nil, err
+	}
+
+	if !validDurationRange(t) {
+		return nil, domain.ErrInvalidDurationRange
+	}
+
+	// If no data was changed, return early
+	if existingService.Name == t.Name && existingService.Price == t.Price && existingService.Currency == t.Currency &&
+		existingService.Description == t.Description && existingService.PreparationInstructions == t.PreparationInstructions &&
+		existingService.DurationMinutes == t.DurationMinutes && sameDuration(existingService.DurationMinMinutes, t.DurationMinMinutes) &&
+		sameDuration(existingService.DurationMaxMinutes, t.DurationMaxMinutes) && sameCategory(existingService.CategoryID, t.CategoryID) {
+		return nil, domain.ErrNoUpdatedData
+	}
+
+	// Update the type of service in the repository
+	updated, err := s.repo.UpdateTypeOfService(ctx, t)
+	if err != nil {
+		if err == domain.ErrConflictingData || err == domain.ErrDataNotFound {
+			return nil, err
+		}
 		return nil, domain.ErrInternal
 	}
 
-	err = s.cache.Set(ctx, cacheKey, serialized, 0)
+	if existingService.Price != updated.Price || existingService.Currency != updated.Currency {
+		recordAudit(ctx, s.audit, domain.NewAuditEntry(util.Actor(ctx), domain.AuditEntityTypeOfService, updated.ID, domain.AuditPriceChanged,
+			map[string]any{"price": existingService.Price, "currency": existingService.Currency},
+			map[string]any{"price": updated.Price, "currency": updated.Currency},
+		))
+	}
+
+	err = s.attachDetails(ctx, []*domain.TypeOfService{updated})
 	if err != nil {
 		return nil, domain.ErrInternal
 	}
 
-	return t, nil
+	// Cache the updated TypeOfService, dropping the cached lists
+	err = s.cache.Store(ctx, t.ID, updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
 }
 
-// ListTypeOfServices lists all types of service
-func (s *TypeOfServiceService) ListTypeOfServices(ctx context.Context, skip, limit uint64) ([]domain.TypeOfService, error) {
-	var services []domain.TypeOfService
+// DeleteTypeOfService soft deletes a type of service by ID, its images are kept for the quotes that use it
+func (s *TypeOfServiceService) DeleteTypeOfService(ctx context.Context, id uuid.UUID) error {
+	// Check if the type of service exists
+	_, err := s.getLiveTypeOfService(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return err
+		}
+		return domain.ErrInternal
+	}
 
-	// Generate cache key for paginated list
-	params := util.GenerateCacheKeyParams(skip, limit)
-	cacheKey := util.GenerateCacheKey("typeofservices", params)
+	err = s.repo.DeleteTypeOfService(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return err
+		}
+		return domain.ErrInternal
+	}
 
-	cachedData, err := s.cache.Get(ctx, cacheKey)
-	if err == nil {
-		err := util.Deserialize(cachedData, &services)
-		if err != nil {
-			return nil, domain.ErrInternal
+	// Invalidate the cache for this type of service and the lists of types of service
+	return s.cache.Invalidate(ctx, id)
+}
+
+// ArchiveTypeOfService hides a type of service from new quotes while keeping it resolvable for old ones
+func (s *TypeOfServiceService) ArchiveTypeOfService(ctx context.Context, id uuid.UUID, archived bool) (*domain.TypeOfService, error) {
+	existingService, err := s.getLiveTypeOfService(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
 		}
-		return services, nil
+		return nil, domain.ErrInternal
+	}
+
+	if existingService.Archived == archived {
+		return nil, domain.ErrNoUpdatedData
 	}
 
-	// Fetch list from repository if not found in cache
-	services, err = s.repo.ListTypeOfServices(ctx, skip, limit)
+	updated, err := s.repo.SetTypeOfServiceArchived(ctx, id, archived)
 	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
 		return nil, domain.ErrInternal
 	}
 
-	// Cache the list of TypeOfServices
-	serialized, err := util.Serialize(services)
+	err = s.attachDetails(ctx, []*domain.TypeOfService{updated})
 	if err != nil {
 		return nil, domain.ErrInternal
 	}
 
-	err = s.cache.Set(ctx, cacheKey, serialized, 0)
+	err = s.cache.Invalidate(ctx, id)
 	if err != nil {
 		return nil, domain.ErrInternal
 	}
 
-	return services, nil
+	return updated, nil
 }
 
-// UpdateTypeOfService updates an existing type of service
-func (s *TypeOfServiceService) UpdateTypeOfService(ctx context.Context, t *domain.TypeOfService) (*domain.TypeOfService, error) {
-	// Check if the type of service exists
-	existingService, err := s.repo.GetTypeOfServiceByID(ctx, t.ID)
+// AddServiceOffering marks an admin as performing a type of service
+func (s *TypeOfServiceService) AddServiceOffering(ctx context.Context, offering *domain.ServiceOffering) (*domain.ServiceOffering, error) {
+	typeOfService, err := s.getLiveTypeOfService(ctx, offering.TypeOfServiceID)
 	if err != nil {
 		if err == domain.ErrDataNotFound {
 			return nil, err
 		}
 		return nil, domain.ErrInternal
 	}
+	offering.SalonID = typeOfService.Salon()
 
-	// If no data was changed, return early
-	if existingService.Name == t.Name && existingService.Price == t.Price {
-		return nil, domain.ErrNoUpdatedData
+	admin, err := s.user.GetUserByID(ctx, offering.AdminID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
 	}
 
-	// Update the type of service in the repository
-	updated, err := s.repo.UpdateTypeOfService(ctx, t)
+	// Only admins attend appointments, so only they can offer a service
+	if admin.Role != domain.Admin {
+		return nil, domain.ErrForbidden
+	}
+
+	// An inactive stylist takes no new services
+	staff, err := activeStaff(ctx, s.staff, offering.AdminID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.offering.CreateServiceOffering(ctx, offering)
 	if err != nil {
-		if err == domain.ErrConflictingData {
+		slog.ErrorContext(ctx, "ServiceOffering creation failed", "error", err)
+		if err == domain.ErrConflictingData || err == domain.ErrDataNotFound {
 			return nil, err
 		}
 		return nil, domain.ErrInternal
 	}
 
-	// Invalidate the cache
-	cacheKey := util.GenerateCacheKey("typeofservice", t.ID)
-	err = s.cache.Delete(ctx, cacheKey)
+	err = s.cache.Invalidate(ctx, offering.TypeOfServiceID)
 	if err != nil {
 		return nil, domain.ErrInternal
 	}
 
-	// Cache the updated TypeOfService
-	serialized, err := util.Serialize(updated)
+	err = s.invalidateStaff(ctx, staff)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// RemoveServiceOffering stops an admin from performing a type of service
+func (s *TypeOfServiceService) RemoveServiceOffering(ctx context.Context, offering *domain.ServiceOffering) error {
+	err := s.offering.DeleteServiceOffering(ctx, offering)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return err
+		}
+		return domain.ErrInternal
+	}
+
+	err = s.cache.Invalidate(ctx, offering.TypeOfServiceID)
 	if err != nil {
+		return domain.ErrInternal
+	}
+
+	staff, err := s.staff.GetStaffByUserID(ctx, offering.AdminID)
+	if err != nil && err != domain.ErrDataNotFound {
+		return domain.ErrInternal
+	}
+
+	return s.invalidateStaff(ctx, staff)
+}
+
+// invalidateStaff drops the cached staff member, whose offered types of service changed, and the
+// staff listings filtered by them. Nothing is cached for the admins without a staff profile
+func (s *TypeOfServiceService) invalidateStaff(ctx context.Context, staff *domain.Staff) error {
+	if staff == nil {
+		return s.staffCache.InvalidateLists(ctx)
+	}
+	return s.staffCache.Invalidate(ctx, staff.ID)
+}
+
+// AddTypeOfServiceImage uploads a showcase image and links it to a type of service
+func (s *TypeOfServiceService) AddTypeOfServiceImage(ctx context.Context, typeOfServiceID uuid.UUID, file io.Reader, size int64, fileName string) (*domain.TypeOfServiceImage, error) {
+	typeOfService, err := s.getLiveTypeOfService(ctx, typeOfServiceID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
 		return nil, domain.ErrInternal
 	}
 
-	err = s.cache.Set(ctx, cacheKey, serialized, 0)
+	image := &domain.TypeOfServiceImage{
+		ID:              uuid.New(),
+		TypeOfServiceID: typeOfServiceID,
+		SalonID:         typeOfService.Salon(),
+	}
+
+	// Strip EXIF/GPS metadata and apply the orientation before anything is stored
+	file, size, err = util.NormalizeImage(file, size)
 	if err != nil {
+		slog.ErrorContext(ctx, "normalizing image failed", "error", err)
+		if err == domain.ErrInvalidImage || err == domain.ErrImageTooLarge || err == domain.ErrFileTooLarge {
+			return nil, err
+		}
 		return nil, domain.ErrInternal
 	}
 
-	// Invalidate general cache for lists
-	err = s.cache.DeleteByPrefix(ctx, "typeofservices:*")
+	// Keys are scoped by service and image so uploads with the same name never collide
+	key := fmt.Sprintf("typesofservice/%s/%s%s", typeOfServiceID, image.ID, filepath.Ext(fileName))
+	image.URL, err = s.file.Save(ctx, file, size, key)
 	if err != nil {
+		slog.ErrorContext(ctx, "file save failed", "error", err)
+		if err == domain.ErrInfectedFile {
+			return nil, err
+		}
 		return nil, domain.ErrInternal
 	}
 
-	return updated, nil
+	created, err := s.image.CreateTypeOfServiceImage(ctx, image)
+	if err != nil {
+		slog.ErrorContext(ctx, "TypeOfServiceImage creation failed", "error", err)
+		if err := s.file.Delete(ctx, image.URL); err != nil {
+			slog.ErrorContext(ctx, "deleting file failed", "error", err)
+		}
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	err = s.cache.Invalidate(ctx, typeOfServiceID)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return created, nil
 }
 
-// DeleteTypeOfService deletes a type of service by ID
-func (s *TypeOfServiceService) DeleteTypeOfService(ctx context.Context, id uuid.UUID) error {
-	// Check if the type of service exists
-	_, err := s.repo.GetTypeOfServiceByID(ctx, id)
+// GetTypeOfServiceImage returns a showcase image along with its file contents
+func (s *TypeOfServiceService) GetTypeOfServiceImage(ctx context.Context, id uuid.UUID) (*domain.TypeOfServiceImage, []byte, error) {
+	image, err := s.image.GetTypeOfServiceImageByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, nil, err
+		}
+		return nil, nil, domain.ErrInternal
+	}
+
+	data, err := s.file.Get(ctx, image.URL)
+	if err != nil {
+		slog.ErrorContext(ctx, "file get failed", "path", image.URL, "error", err)
+		return nil, nil, domain.ErrInternal
+	}
+
+	return image, data, nil
+}
+
+// DeleteTypeOfServiceImage removes a showcase image and its file
+func (s *TypeOfServiceService) DeleteTypeOfServiceImage(ctx context.Context, id uuid.UUID) error {
+	image, err := s.image.GetTypeOfServiceImageByID(ctx, id)
 	if err != nil {
 		if err == domain.ErrDataNotFound {
 			return err
@@ -198,19 +444,108 @@ func (s *TypeOfServiceService) DeleteTypeOfService(ctx context.Context, id uuid.
 		return domain.ErrInternal
 	}
 
-	// Invalidate the cache for this type of service
-	cacheKey := util.GenerateCacheKey("typeofservice", id)
-	err = s.cache.Delete(ctx, cacheKey)
+	err = s.image.DeleteTypeOfServiceImage(ctx, id)
 	if err != nil {
 		return domain.ErrInternal
 	}
 
-	// Invalidate the general cache for lists of types of service
-	err = s.cache.DeleteByPrefix(ctx, "typeofservices:*")
+	if err := s.file.Delete(ctx, image.URL); err != nil {
+		slog.WarnContext(ctx, "deleting type of service image file failed", "path", image.URL, "error", err)
+	}
+
+	err = s.cache.Invalidate(ctx, image.TypeOfServiceID)
 	if err != nil {
 		return domain.ErrInternal
 	}
 
-	// Delete the type of service from the repository
-	return s.repo.DeleteTypeOfService(ctx, id)
+	return nil
+}
+
+// attachDetails loads the showcase images and offering admins of the given types of service
+func (s *TypeOfServiceService) attachDetails(ctx context.Context, services []*domain.TypeOfService) error {
+	ids := make([]uuid.UUID, len(services))
+	for i, t := range services {
+		ids[i] = t.ID
+	}
+
+	images, err := s.image.ListTypeOfServiceImages(ctx, ids)
+	if err != nil {
+		slog.ErrorContext(ctx, "listing type of service images failed", "error", err)
+		return err
+	}
+
+	offerings, err := s.offering.ListServiceOfferings(ctx, ids)
+	if err != nil {
+		slog.ErrorContext(ctx, "listing service offerings failed", "error", err)
+		return err
+	}
+
+	imagesByService := make(map[uuid.UUID][]domain.TypeOfServiceImage, len(services))
+	for _, image := range images {
+		imagesByService[image.TypeOfServiceID] = append(imagesByService[image.TypeOfServiceID], image)
+	}
+
+	adminsByService := make(map[uuid.UUID][]uuid.UUID, len(services))
+	for _, offering := range offerings {
+		adminsByService[offering.TypeOfServiceID] = append(adminsByService[offering.TypeOfServiceID], offering.AdminID)
+	}
+
+	for _, t := range services {
+		t.Images = imagesByService[t.ID]
+		t.OfferedBy = adminsByService[t.ID]
+	}
+
+	return nil
+}
+
+// resolveCurrency applies the default currency and rejects unsupported ones
+func (s *TypeOfServiceService) resolveCurrency(t *domain.TypeOfService) error {
+	if t.Currency == "" {
+		t.Currency = s.currency
+	}
+
+	if !t.Currency.IsValid() {
+		return domain.ErrUnsupportedCurrency
+	}
+
+	return nil
+}
+
+// validDurationRange reports whether the estimated duration range is ordered, an open end is always valid
+func validDurationRange(t *domain.TypeOfService) bool {
+	if t.DurationMinMinutes == nil || t.DurationMaxMinutes == nil {
+		return true
+	}
+	return *t.DurationMinMinutes <= *t.DurationMaxMinutes
+}
+
+// sameDuration compares two optional durations
+func sameDuration(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// sameCategory reports whether two optional category references point to the same category
+func sameCategory(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// getLiveTypeOfService retrieves a type of service that can still be changed, a soft deleted one is only
+// kept for the quotes that point at it and is not found
+func (s *TypeOfServiceService) getLiveTypeOfService(ctx context.Context, id uuid.UUID) (*domain.TypeOfService, error) {
+	t, err := s.repo.GetTypeOfServiceByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.DeletedAt != nil {
+		return nil, domain.ErrDataNotFound
+	}
+
+	return t, nil
 }
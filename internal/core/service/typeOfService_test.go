@@ -0,0 +1,450 @@
+package service
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/adapter/storage/cache"
+	"harajuku/backend/internal/adapter/storage/memory"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryTypesOfService keeps the types of service in a map, deleting one only marks it as deleted
+type memoryTypesOfService map[uuid.UUID]domain.TypeOfService
+
+func (r memoryTypesOfService) CreateTypeOfService(ctx context.Context, t *domain.TypeOfService) (*domain.TypeOfService, error) {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	r[t.ID] = *t
+	return t, nil
+}
+
+func (r memoryTypesOfService) GetTypeOfServiceByID(ctx context.Context, id uuid.UUID) (*domain.TypeOfService, error) {
+	t, ok := r[id]
+	if !ok {
+		return nil, domain.ErrDataNotFound
+	}
+	return &t, nil
+}
+
+func (r memoryTypesOfService) ListTypeOfServices(ctx context.Context, filter port.TypeOfServiceFilter) ([]domain.TypeOfService, error) {
+	var services []domain.TypeOfService
+	for _, t := range r {
+		if (t.Archived && !filter.IncludeArchived) || (t.DeletedAt != nil && !filter.IncludeDeleted) {
+			continue
+		}
+		if filter.CategoryID != nil && (t.CategoryID == nil || *t.CategoryID != *filter.CategoryID) {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(t.Name), strings.ToLower(filter.Name)) {
+			continue
+		}
+		services = append(services, t)
+	}
+
+	slices.SortFunc(services, func(a, b domain.TypeOfService) int {
+		switch filter.PriceOrder {
+		case "asc":
+			return cmp.Compare(a.Price, b.Price)
+		case "desc":
+			return cmp.Compare(b.Price, a.Price)
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	// Pages are numbered from 1 as in the postgres repository
+	var offset uint64
+	if filter.Skip > 1 {
+		offset = (filter.Skip - 1) * filter.Limit
+	}
+	start := min(offset, uint64(len(services)))
+	end := min(start+filter.Limit, uint64(len(services)))
+	return services[start:end], nil
+}
+
+func (r memoryTypesOfService) CountTypeOfServices(ctx context.Context, filter port.TypeOfServiceFilter) (uint64, error) {
+	filter.Skip, filter.Limit = 0, uint64(len(r))
+	services, err := r.ListTypeOfServices(ctx, filter)
+	return uint64(len(services)), err
+}
+
+func (r memoryTypesOfService) UpdateTypeOfService(ctx context.Context, t *domain.TypeOfService) (*domain.TypeOfService, error) {
+	existing, ok := r[t.ID]
+	if !ok {
+		return nil, domain.ErrDataNotFound
+	}
+	t.Archived = existing.Archived
+	r[t.ID] = *t
+	return t, nil
+}
+
+func (r memoryTypesOfService) SetTypeOfServiceArchived(ctx context.Context, id uuid.UUID, archived bool) (*domain.TypeOfService, error) {
+	t, ok := r[id]
+	if !ok {
+		return nil, domain.ErrDataNotFound
+	}
+	t.Archived = archived
+	r[id] = t
+	return &t, nil
+}
+
+func (r memoryTypesOfService) DeleteTypeOfService(ctx context.Context, id uuid.UUID) error {
+	t, ok := r[id]
+	if !ok {
+		return domain.ErrDataNotFound
+	}
+	now := time.Now()
+	t.DeletedAt = &now
+	r[id] = t
+	return nil
+}
+
+// memoryTypeOfServiceImages keeps the showcase images in a map
+type memoryTypeOfServiceImages map[uuid.UUID]domain.TypeOfServiceImage
+
+func (r memoryTypeOfServiceImages) CreateTypeOfServiceImage(ctx context.Context, image *domain.TypeOfServiceImage) (*domain.TypeOfServiceImage, error) {
+	r[image.ID] = *image
+	return image, nil
+}
+
+func (r memoryTypeOfServiceImages) GetTypeOfServiceImageByID(ctx context.Context, id uuid.UUID) (*domain.TypeOfServiceImage, error) {
+	image, ok := r[id]
+	if !ok {
+		return nil, domain.ErrDataNotFound
+	}
+	return &image, nil
+}
+
+func (r memoryTypeOfServiceImages) ListTypeOfServiceImages(ctx context.Context, typeOfServiceIDs []uuid.UUID) ([]domain.TypeOfServiceImage, error) {
+	var images []domain.TypeOfServiceImage
+	for _, image := range r {
+		if slices.Contains(typeOfServiceIDs, image.TypeOfServiceID) {
+			images = append(images, image)
+		}
+	}
+	return images, nil
+}
+
+func (r memoryTypeOfServiceImages) DeleteTypeOfServiceImage(ctx context.Context, id uuid.UUID) error {
+	if _, ok := r[id]; !ok {
+		return domain.ErrDataNotFound
+	}
+	delete(r, id)
+	return nil
+}
+
+// memoryOfferings keeps the service offerings in a set, an admin offers a type of service once
+type memoryOfferings map[domain.ServiceOffering]bool
+
+func (r memoryOfferings) CreateServiceOffering(ctx context.Context, offering *domain.ServiceOffering) (*domain.ServiceOffering, error) {
+	key := domain.ServiceOffering{AdminID: offering.AdminID, TypeOfServiceID: offering.TypeOfServiceID}
+	if r[key] {
+		return nil, domain.ErrConflictingData
+	}
+	r[key] = true
+	return offering, nil
+}
+
+func (r memoryOfferings) DeleteServiceOffering(ctx context.Context, offering *domain.ServiceOffering) error {
+	key := domain.ServiceOffering{AdminID: offering.AdminID, TypeOfServiceID: offering.TypeOfServiceID}
+	if !r[key] {
+		return domain.ErrDataNotFound
+	}
+	delete(r, key)
+	return nil
+}
+
+func (r memoryOfferings) ListServiceOfferings(ctx context.Context, typeOfServiceIDs []uuid.UUID) ([]domain.ServiceOffering, error) {
+	var offerings []domain.ServiceOffering
+	for offering := range r {
+		if slices.Contains(typeOfServiceIDs, offering.TypeOfServiceID) {
+			offerings = append(offerings, offering)
+		}
+	}
+	return offerings, nil
+}
+
+func (r memoryOfferings) IsServiceOffered(ctx context.Context, adminID, typeOfServiceID uuid.UUID) (bool, error) {
+	return r[domain.ServiceOffering{AdminID: adminID, TypeOfServiceID: typeOfServiceID}], nil
+}
+
+// newTestTypeOfServiceService creates a TypeOfServiceService over in-memory repositories charging in MXN by default
+func newTestTypeOfServiceService(types memoryTypesOfService) *TypeOfServiceService {
+	return NewTypeOfServiceService(
+		types,
+		memoryTypeOfServiceImages{},
+		memoryOfferings{},
+		memoryStaff{},
+		memory.NewUserRepository(memory.New()),
+		memory.NewFileRepository(),
+		cache.NewMemory(100),
+		domain.CurrencyMXN,
+		&memoryAudit{},
+	)
+}
+
+func TestUpdateTypeOfServiceDuration(t *testing.T) {
+	ctx := context.Background()
+	types := memoryTypesOfService{}
+	svc := newTestTypeOfServiceService(types)
+
+	created, err := svc.CreateTypeOfService(ctx, &domain.TypeOfService{Name: "Corte", Price: 250, DurationMinutes: 45})
+	require.NoError(t, err)
+
+	_, err = svc.UpdateTypeOfService(ctx, &domain.TypeOfService{ID: created.ID, Name: "Corte", Price: 250, DurationMinutes: 45})
+	assert.Equal(t, domain.ErrNoUpdatedData, err)
+
+	updated, err := svc.UpdateTypeOfService(ctx, &domain.TypeOfService{ID: created.ID, Name: "Corte", Price: 250, DurationMinutes: 60})
+	require.NoError(t, err)
+	assert.Equal(t, 60, updated.DurationMinutes)
+	assert.Equal(t, 60, types[created.ID].DurationMinutes)
+
+	got, err := svc.GetTypeOfService(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 60, got.DurationMinutes)
+}
+
+// testPNG encodes a small opaque PNG, a valid upload for the image normalization
+func testPNG(t *testing.T) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for x := range 8 {
+		for y := range 8 {
+			img.Set(x, y, color.RGBA{R: 200, G: 40, B: 120, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestTypeOfServiceImages(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestTypeOfServiceService(memoryTypesOfService{})
+
+	created, err := svc.CreateTypeOfService(ctx, &domain.TypeOfService{Name: "Uñas acrílicas", Price: 400})
+	require.NoError(t, err)
+
+	data := testPNG(t)
+	img, err := svc.AddTypeOfServiceImage(ctx, created.ID, bytes.NewReader(data), int64(len(data)), "portada.png")
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("typesofservice/%s/%s.png", created.ID, img.ID), img.URL)
+
+	// The cached type of service is dropped, so the new image shows up
+	got, err := svc.GetTypeOfService(ctx, created.ID)
+	require.NoError(t, err)
+	require.Len(t, got.Images, 1)
+	assert.Equal(t, img.ID, got.Images[0].ID)
+
+	stored, contents, err := svc.GetTypeOfServiceImage(ctx, img.ID)
+	require.NoError(t, err)
+	assert.Equal(t, img.URL, stored.URL)
+	_, err = png.Decode(bytes.NewReader(contents))
+	assert.NoError(t, err)
+
+	// A truncated PNG is rejected before anything is stored
+	_, err = svc.AddTypeOfServiceImage(ctx, created.ID, bytes.NewReader(data[:40]), 40, "portada.png")
+	assert.Equal(t, domain.ErrInvalidImage, err)
+
+	_, err = svc.AddTypeOfServiceImage(ctx, uuid.New(), bytes.NewReader(data), int64(len(data)), "portada.png")
+	assert.Equal(t, domain.ErrDataNotFound, err)
+
+	require.NoError(t, svc.DeleteTypeOfServiceImage(ctx, img.ID))
+	_, err = svc.file.Get(ctx, img.URL)
+	assert.Equal(t, domain.ErrDataNotFound, err)
+
+	got, err = svc.GetTypeOfService(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got.Images)
+
+	assert.Equal(t, domain.ErrDataNotFound, svc.DeleteTypeOfServiceImage(ctx, img.ID))
+}
+
+func TestArchiveAndDeleteTypeOfService(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestTypeOfServiceService(memoryTypesOfService{})
+
+	created, err := svc.CreateTypeOfService(ctx, &domain.TypeOfService{Name: "Keratina", Price: 900})
+	require.NoError(t, err)
+
+	_, err = svc.ArchiveTypeOfService(ctx, created.ID, false)
+	assert.Equal(t, domain.ErrNoUpdatedData, err)
+
+	archived, err := svc.ArchiveTypeOfService(ctx, created.ID, true)
+	require.NoError(t, err)
+	assert.True(t, archived.Archived)
+
+	// Archived types of service are left out of the listing unless asked for
+	services, _, err := svc.ListTypeOfServices(ctx, port.TypeOfServiceFilter{Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, services)
+	services, _, err = svc.ListTypeOfServices(ctx, port.TypeOfServiceFilter{IncludeArchived: true, Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, services, 1)
+
+	require.NoError(t, svc.DeleteTypeOfService(ctx, created.ID))
+
+	// A deleted type of service still resolves for the quotes pointing at it, but can no longer change
+	got, err := svc.GetTypeOfService(ctx, created.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, got.DeletedAt)
+
+	_, err = svc.ArchiveTypeOfService(ctx, created.ID, false)
+	assert.Equal(t, domain.ErrDataNotFound, err)
+	_, err = svc.UpdateTypeOfService(ctx, &domain.TypeOfService{ID: created.ID, Name: "Keratina", Price: 950})
+	assert.Equal(t, domain.ErrDataNotFound, err)
+	assert.Equal(t, domain.ErrDataNotFound, svc.DeleteTypeOfService(ctx, created.ID))
+}
+
+func TestListTypeOfServicesSearchAndSort(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestTypeOfServiceService(memoryTypesOfService{})
+
+	for _, typeOfService := range []domain.TypeOfService{
+		{Name: "Corte de dama", Price: 300},
+		{Name: "Corte de caballero", Price: 200},
+		{Name: "Corte infantil", Price: 150},
+		{Name: "Tinte", Price: 800},
+	} {
+		_, err := svc.CreateTypeOfService(ctx, &typeOfService)
+		require.NoError(t, err)
+	}
+
+	// The total counts every match, not just the page
+	services, total, err := svc.ListTypeOfServices(ctx, port.TypeOfServiceFilter{Name: "corte", PriceOrder: "asc", Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), total)
+	require.Len(t, services, 2)
+	assert.Equal(t, "Corte infantil", services[0].Name)
+	assert.Equal(t, "Corte de caballero", services[1].Name)
+
+	services, total, err = svc.ListTypeOfServices(ctx, port.TypeOfServiceFilter{Name: "corte", PriceOrder: "asc", Skip: 2, Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), total)
+	require.Len(t, services, 1)
+	assert.Equal(t, "Corte de dama", services[0].Name)
+
+	// Each search and order is cached on its own
+	services, total, err = svc.ListTypeOfServices(ctx, port.TypeOfServiceFilter{Name: "corte", PriceOrder: "desc", Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), total)
+	require.Len(t, services, 2)
+	assert.Equal(t, "Corte de dama", services[0].Name)
+
+	services, total, err = svc.ListTypeOfServices(ctx, port.TypeOfServiceFilter{Name: "tinte", Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), total)
+	require.Len(t, services, 1)
+	assert.Equal(t, "Tinte", services[0].Name)
+}
+
+func TestAddServiceOfferingOnlyForAdmins(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestTypeOfServiceService(memoryTypesOfService{})
+
+	created, err := svc.CreateTypeOfService(ctx, &domain.TypeOfService{Name: "Balayage", Price: 1500})
+	require.NoError(t, err)
+	client, err := svc.user.CreateUser(ctx, &domain.User{Name: "Ana", Email: "ana@harajuku.mx", Role: domain.Client})
+	require.NoError(t, err)
+	admin, err := svc.user.CreateUser(ctx, &domain.User{Name: "Mariana", Email: "mariana@harajuku.mx", Role: domain.Admin})
+	require.NoError(t, err)
+
+	_, err = svc.AddServiceOffering(ctx, &domain.ServiceOffering{AdminID: client.ID, TypeOfServiceID: created.ID})
+	assert.Equal(t, domain.ErrForbidden, err)
+
+	_, err = svc.AddServiceOffering(ctx, &domain.ServiceOffering{AdminID: admin.ID, TypeOfServiceID: created.ID})
+	require.NoError(t, err)
+
+	got, err := svc.GetTypeOfService(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{admin.ID}, got.OfferedBy)
+
+	require.NoError(t, svc.RemoveServiceOffering(ctx, &domain.ServiceOffering{AdminID: admin.ID, TypeOfServiceID: created.ID}))
+	got, err = svc.GetTypeOfService(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got.OfferedBy)
+}
+
+func TestTypeOfServiceCurrency(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestTypeOfServiceService(memoryTypesOfService{})
+	audit := svc.audit.(*memoryAudit)
+
+	_, err := svc.CreateTypeOfService(ctx, &domain.TypeOfService{Name: "Peinado", Price: 350, Currency: "ARS"})
+	assert.Equal(t, domain.ErrUnsupportedCurrency, err)
+
+	// Without a currency the configured default applies
+	created, err := svc.CreateTypeOfService(ctx, &domain.TypeOfService{Name: "Peinado", Price: 350})
+	require.NoError(t, err)
+	assert.Equal(t, domain.CurrencyMXN, created.Currency)
+
+	// An update without a currency keeps the current one, a price change is audited
+	updated, err := svc.UpdateTypeOfService(ctx, &domain.TypeOfService{ID: created.ID, Name: "Peinado", Price: 400})
+	require.NoError(t, err)
+	assert.Equal(t, domain.CurrencyMXN, updated.Currency)
+	require.Len(t, audit.entries, 1)
+	assert.Equal(t, domain.AuditPriceChanged, audit.entries[0].Action)
+	assert.Equal(t, 350.0, audit.entries[0].Before["price"])
+	assert.Equal(t, 400.0, audit.entries[0].After["price"])
+
+	updated, err = svc.UpdateTypeOfService(ctx, &domain.TypeOfService{ID: created.ID, Name: "Peinado", Price: 400, Currency: domain.CurrencyUSD})
+	require.NoError(t, err)
+	assert.Equal(t, domain.CurrencyUSD, updated.Currency)
+	require.Len(t, audit.entries, 2)
+	assert.Equal(t, domain.CurrencyUSD, audit.entries[1].After["currency"])
+
+	_, err = svc.UpdateTypeOfService(ctx, &domain.TypeOfService{ID: created.ID, Name: "Peinado", Price: 400, Currency: "ARS"})
+	assert.Equal(t, domain.ErrUnsupportedCurrency, err)
+}
+
+func TestTypeOfServiceDetails(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestTypeOfServiceService(memoryTypesOfService{})
+	minutes := func(m int) *int { return &m }
+
+	_, err := svc.CreateTypeOfService(ctx, &domain.TypeOfService{Name: "Mechas", Price: 1100, DurationMinMinutes: minutes(180), DurationMaxMinutes: minutes(120)})
+	assert.Equal(t, domain.ErrInvalidDurationRange, err)
+
+	// An open ended range is valid
+	created, err := svc.CreateTypeOfService(ctx, &domain.TypeOfService{Name: "Mechas", Price: 1100, DurationMinMinutes: minutes(120)})
+	require.NoError(t, err)
+
+	_, err = svc.UpdateTypeOfService(ctx, &domain.TypeOfService{ID: created.ID, Name: "Mechas", Price: 1100, DurationMinMinutes: minutes(120), DurationMaxMinutes: minutes(90)})
+	assert.Equal(t, domain.ErrInvalidDurationRange, err)
+
+	_, err = svc.UpdateTypeOfService(ctx, &domain.TypeOfService{ID: created.ID, Name: "Mechas", Price: 1100, DurationMinMinutes: minutes(120)})
+	assert.Equal(t, domain.ErrNoUpdatedData, err)
+
+	// Changing only the description or the instructions is an update
+	updated, err := svc.UpdateTypeOfService(ctx, &domain.TypeOfService{ID: created.ID, Name: "Mechas", Price: 1100, DurationMinMinutes: minutes(120), Description: "Mechas con papel aluminio"})
+	require.NoError(t, err)
+	assert.Equal(t, "Mechas con papel aluminio", updated.Description)
+
+	updated, err = svc.UpdateTypeOfService(ctx, &domain.TypeOfService{
+		ID:                      created.ID,
+		Name:                    "Mechas",
+		Price:                   1100,
+		DurationMinMinutes:      minutes(120),
+		DurationMaxMinutes:      minutes(180),
+		Description:             "Mechas con papel aluminio",
+		PreparationInstructions: "Llegar con el cabello seco",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Llegar con el cabello seco", updated.PreparationInstructions)
+	assert.Equal(t, 180, *updated.DurationMaxMinutes)
+}
@@ -3,7 +3,6 @@ package service
 import (
 	"context"
 	"log/slog"
-	"time"
 
 	"harajuku/backend/internal/core/domain"
 	"harajuku/backend/internal/core/port"
@@ -19,14 +18,16 @@ import (
  */
 type UserService struct {
 	repo  port.UserRepository
-	cache port.CacheRepository
+	cache *CachedRepository[domain.User]
+	audit port.AuditRepository
 }
 
 // NewUserService creates a new user service instance
-func NewUserService(repo port.UserRepository, cache port.CacheRepository) *UserService {
+func NewUserService(repo port.UserRepository, cache port.CacheRepository, audit port.AuditRepository) *UserService {
 	return &UserService{
 		repo,
-		cache,
+		NewCachedRepository[domain.User](cache, "user", "users"),
+		audit,
 	}
 }
 
@@ -38,30 +39,20 @@ func (us *UserService) Register(ctx context.Context, user *domain.User) (*domain
       }
 
 	user.Password = hashedPassword
+	user.NotificationPreferences = domain.DefaultNotificationPreferences()
 
 	user, err = us.repo.CreateUser(ctx, user)
 	if err != nil {
-    slog.Error("User registration failed", "error", err)
+    slog.ErrorContext(ctx, "User registration failed", "error", err)
 		if err == domain.ErrConflictingData {
 			return nil, err
 		}
 		return nil, domain.ErrInternal
 	}
 
-	cacheKey := util.GenerateCacheKey("user", user.ID)
-	userSerialized, err := util.Serialize(user)
+	err = us.cache.Store(ctx, user.ID, user)
 	if err != nil {
-		return nil, domain.ErrInternal
-	}
-
-	err = us.cache.Set(ctx, cacheKey, userSerialized, 0)
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
-
-	err = us.cache.DeleteByPrefix(ctx, "users:*")
-	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, err
 	}
 
 	return user, nil
@@ -69,19 +60,9 @@ func (us *UserService) Register(ctx context.Context, user *domain.User) (*domain
 
 // GetUser gets a user by ID
 func (us *UserService) GetUser(ctx context.Context, id uuid.UUID) (*domain.User, error) {
-	var user *domain.User
-
-	cacheKey := util.GenerateCacheKey("user", id)
-	cachedUser, err := us.cache.Get(ctx, cacheKey)
-	if err == nil {
-		err := util.Deserialize(cachedUser, &user)
-		if err != nil {
-			return nil, domain.ErrInternal
-		}
-		return user, nil
-	}
-
-	user, err = us.repo.GetUserByID(ctx, id)
+	user, err := us.cache.Get(ctx, id, func() (*domain.User, error) {
+		return us.repo.GetUserByID(ctx, id)
+	})
 	if err != nil {
 		if err == domain.ErrDataNotFound {
 			return nil, err
@@ -89,57 +70,32 @@ func (us *UserService) GetUser(ctx context.Context, id uuid.UUID) (*domain.User,
 		return nil, domain.ErrInternal
 	}
 
-	userSerialized, err := util.Serialize(user)
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
-
-	err = us.cache.Set(ctx, cacheKey, userSerialized, 0)
-	if err != nil {
-		return nil, domain.ErrInternal
-	}
-
 	return user, nil
 }
 
 // ListUsers lists all users
-func (us *UserService) ListUsers(ctx context.Context, skip, limit uint64, filters domain.UserFilters) ([]domain.User, error) {
-    var users []domain.User
-
+func (us *UserService) ListUsers(ctx context.Context, skip, limit uint64, filters domain.UserFilters) ([]domain.User, uint64, error) {
     // Include filters in cache key
     params := util.GenerateCacheKeyParams(skip, limit, filters)
-    cacheKey := util.GenerateCacheKey("users", params)
 
-    // Try cache first
-    cachedUsers, err := us.cache.Get(ctx, cacheKey)
-    if err == nil {
-        err := util.Deserialize(cachedUsers, &users)
+    users, total, err := us.cache.ListPage(ctx, params, func() ([]domain.User, uint64, error) {
+        users, err := us.repo.ListUsers(ctx, skip, limit, filters)
         if err != nil {
-            slog.Error("cache deserialization failed", "error", err)
-            // Fall through to database query
-        } else {
-            return users, nil
+            return nil, 0, err
         }
-    }
 
-    // Cache miss - query database
-    users, err = us.repo.ListUsers(ctx, skip, limit, filters)
-    if err != nil {
-        return nil, domain.ErrInternal
-    }
+        total, err := us.repo.CountUsers(ctx, filters)
+        if err != nil {
+            return nil, 0, err
+        }
 
-    // Cache results
-    usersSerialized, err := util.Serialize(users)
+        return users, total, nil
+    })
     if err != nil {
-        slog.Error("serialization failed", "error", err)
-        return users, nil // Return results without caching
-    }
-
-    if err := us.cache.Set(ctx, cacheKey, usersSerialized, 10*time.Minute); err != nil {
-        slog.Error("cache set failed", "error", err)
+        return nil, 0, domain.ErrInternal
     }
 
-    return users, nil
+    return users, total, nil
 }
 
 // UpdateUser updates a user's name, email, and password
@@ -157,13 +113,15 @@ func (us *UserService) UpdateUser(ctx context.Context, user *domain.User) (*doma
 		user.SecondLastName == "" &&
 		user.Email == "" &&
 		user.Password == "" &&
-    user.Role == ""
+    user.Role == "" &&
+		user.PreferredLanguage == ""
 
 	sameData := existingUser.Name == user.Name &&
 		existingUser.LastName == user.LastName &&
 		existingUser.SecondLastName == user.SecondLastName &&
 		existingUser.Email == user.Email &&
-    existingUser.Role == user.Role
+    existingUser.Role == user.Role &&
+		existingUser.PreferredLanguage == user.PreferredLanguage
 	if emptyData || sameData {
 		return nil, domain.ErrNoUpdatedData
 	}
@@ -187,26 +145,42 @@ func (us *UserService) UpdateUser(ctx context.Context, user *domain.User) (*doma
 		return nil, domain.ErrInternal
 	}
 
-	cacheKey := util.GenerateCacheKey("user", user.ID)
+	if user.Role != "" && user.Role != existingUser.Role {
+		recordAudit(ctx, us.audit, domain.NewAuditEntry(util.Actor(ctx), domain.AuditEntityUser, user.ID, domain.AuditRoleChanged,
+			map[string]any{"role": existingUser.Role},
+			map[string]any{"role": user.Role},
+		))
+	}
 
-	err = us.cache.Delete(ctx, cacheKey)
+	err = us.cache.Store(ctx, user.ID, user)
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, err
 	}
 
-	userSerialized, err := util.Serialize(user)
-	if err != nil {
-		return nil, domain.ErrInternal
+	return user, nil
+}
+
+// UpdateNotificationPreferences sets the phone of a user and the channels they are notified through
+func (us *UserService) UpdateNotificationPreferences(ctx context.Context, id uuid.UUID, phone string, preferences domain.NotificationPreferences) (*domain.User, error) {
+	if (preferences.SMS || preferences.WhatsApp) && phone == "" {
+		return nil, domain.ErrPhoneRequired
 	}
 
-	err = us.cache.Set(ctx, cacheKey, userSerialized, 0)
+	user, err := us.repo.UpdateNotificationPreferences(ctx, &domain.User{
+		ID:                      id,
+		Phone:                   phone,
+		NotificationPreferences: preferences,
+	})
 	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
 		return nil, domain.ErrInternal
 	}
 
-	err = us.cache.DeleteByPrefix(ctx, "users:*")
+	err = us.cache.Invalidate(ctx, user.ID)
 	if err != nil {
-		return nil, domain.ErrInternal
+		return nil, err
 	}
 
 	return user, nil
@@ -222,16 +196,9 @@ func (us *UserService) DeleteUser(ctx context.Context, id uuid.UUID) error {
 		return domain.ErrInternal
 	}
 
-	cacheKey := util.GenerateCacheKey("user", id)
-
-	err = us.cache.Delete(ctx, cacheKey)
-	if err != nil {
-		return domain.ErrInternal
-	}
-
-	err = us.cache.DeleteByPrefix(ctx, "users:*")
+	err = us.cache.Invalidate(ctx, id)
 	if err != nil {
-		return domain.ErrInternal
+		return err
 	}
 
 	return us.repo.DeleteUser(ctx, id)
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"harajuku/backend/internal/adapter/storage/cache"
+	"harajuku/backend/internal/adapter/storage/memory"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterAndLogin(t *testing.T) {
+	ctx := context.Background()
+	users := memory.NewUserRepository(memory.New())
+	svc := NewUserService(users, cache.NewMemory(100), &memoryAudit{})
+
+	user, err := svc.Register(ctx, &domain.User{Name: "Ana", LastName: "López", Email: "ana@harajuku.mx", Password: "secreto123"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.Client, user.Role)
+
+	_, err = svc.Register(ctx, &domain.User{Name: "Ana", Email: "ana@harajuku.mx", Password: "secreto123"})
+	assert.Equal(t, domain.ErrConflictingData, err)
+
+	stored, err := users.GetUserByEmail(ctx, "ana@harajuku.mx")
+	require.NoError(t, err)
+	assert.NoError(t, util.ComparePassword("secreto123", stored.Password))
+}
+
+func TestUpdateUserAuditsRoleChanges(t *testing.T) {
+	ctx := context.Background()
+	audit := &memoryAudit{}
+	svc := NewUserService(memory.NewUserRepository(memory.New()), cache.NewMemory(100), audit)
+
+	user, err := svc.Register(ctx, &domain.User{Name: "Ana", Email: "ana@harajuku.mx", Password: "secreto123"})
+	require.NoError(t, err)
+
+	admin := &domain.TokenPayload{UserID: uuid.New(), Role: domain.Admin}
+	updated, err := svc.UpdateUser(util.WithActor(ctx, admin), &domain.User{ID: user.ID, Role: domain.Admin})
+	require.NoError(t, err)
+	assert.Equal(t, domain.Admin, updated.Role)
+
+	require.Len(t, audit.entries, 1)
+	assert.Equal(t, domain.AuditRoleChanged, audit.entries[0].Action)
+	assert.Equal(t, &admin.UserID, audit.entries[0].ActorID)
+	assert.Equal(t, map[string]any{"role": domain.Client}, audit.entries[0].Before)
+
+	// Renaming a user is not audited
+	_, err = svc.UpdateUser(ctx, &domain.User{ID: user.ID, Name: "Ana María"})
+	require.NoError(t, err)
+	assert.Len(t, audit.entries, 1)
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+)
+
+// maxWebhookRetryDelay caps the exponential backoff between attempts
+const maxWebhookRetryDelay = time.Hour
+
+/**
+ * WebhookService implements port.WebhookService interface,
+ * it manages the webhook subscriptions and posts the published events to them with retries
+ */
+type WebhookService struct {
+	repo        port.WebhookRepository
+	sender      port.WebhookSender
+	events      port.EventDispatcher
+	maxAttempts int
+	retryDelay  time.Duration
+}
+
+// NewWebhookService creates a new webhook service instance delivering the events published through events
+func NewWebhookService(
+	repo port.WebhookRepository,
+	sender port.WebhookSender,
+	events port.EventDispatcher,
+	maxAttempts int,
+	retryDelay time.Duration,
+) *WebhookService {
+	return &WebhookService{
+		repo,
+		sender,
+		events,
+		maxAttempts,
+		retryDelay,
+	}
+}
+
+// CreateWebhook creates a new webhook, generating its secret when none is given
+func (s *WebhookService) CreateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	if !webhook.IsValid() {
+		return nil, domain.ErrInvalidWebhook
+	}
+
+	if webhook.Secret == "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return nil, domain.ErrInternal
+		}
+		webhook.Secret = secret
+	}
+
+	now := time.Now()
+	webhook.ID = uuid.New()
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+
+	created, err := s.repo.CreateWebhook(ctx, webhook)
+	if err != nil {
+		slog.ErrorContext(ctx, "Webhook creation failed", "error", err)
+		if err == domain.ErrConflictingData {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	return created, nil
+}
+
+// GetWebhook returns a webhook by id
+func (s *WebhookService) GetWebhook(ctx context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	webhook, err := s.repo.GetWebhookByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	return webhook, nil
+}
+
+// ListWebhooks returns a list of webhooks with pagination
+func (s *WebhookService) ListWebhooks(ctx context.Context, skip, limit uint64) ([]domain.Webhook, error) {
+	webhooks, err := s.repo.ListWebhooks(ctx, skip, limit)
+	if err != nil {
+		return nil, domain.ErrInternal
+	}
+
+	return webhooks, nil
+}
+
+// UpdateWebhook updates a webhook, keeping its secret when none is given
+func (s *WebhookService) UpdateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	existing, err := s.repo.GetWebhookByID(ctx, webhook.ID)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	if !webhook.IsValid() {
+		return nil, domain.ErrInvalidWebhook
+	}
+
+	if webhook.Secret == "" {
+		webhook.Secret = existing.Secret
+	}
+	webhook.UpdatedAt = time.Now()
+
+	updated, err := s.repo.UpdateWebhook(ctx, webhook)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return nil, err
+		}
+		return nil, domain.ErrInternal
+	}
+
+	return updated, nil
+}
+
+// DeleteWebhook deletes a webhook
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	_, err := s.repo.GetWebhookByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrDataNotFound {
+			return err
+		}
+		return domain.ErrInternal
+	}
+
+	err = s.repo.DeleteWebhook(ctx, id)
+	if err != nil {
+		return domain.ErrInternal
+	}
+
+	return nil
+}
+
+// Run delivers published events to the webhooks subscribed to them until ctx is done,
+// every delivery is retried on its own so a failing endpoint does not hold back the others
+func (s *WebhookService) Run(ctx context.Context) {
+	events, unsubscribe := s.events.Subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			// Only the webhooks of the salon the event happened in hear of it
+			webhooks, err := s.repo.ListActiveWebhooks(util.WithSalon(ctx, event.Salon()), event.Type)
+			if err != nil {
+				slog.ErrorContext(ctx, "Webhook lookup failed", "event_id", event.ID, "type", event.Type, "error", err)
+				continue
+			}
+
+			for _, webhook := range webhooks {
+				go s.deliver(ctx, webhook, event)
+			}
+		}
+	}
+}
+
+// deliver posts the event to the webhook, waiting with backoff between failed attempts
+func (s *WebhookService) deliver(ctx context.Context, webhook domain.Webhook, event domain.Event) {
+	delay := s.retryDelay
+
+	for attempt := 1; ; attempt++ {
+		err := s.sender.Send(ctx, &webhook, event)
+		if err == nil {
+			return
+		}
+
+		if attempt >= s.maxAttempts {
+			slog.ErrorContext(ctx, "Webhook delivery failed, giving up", "webhook_id", webhook.ID, "event_id", event.ID, "attempts", attempt, "error", err)
+			return
+		}
+		slog.WarnContext(ctx, "Webhook delivery failed, retrying", "webhook_id", webhook.ID, "event_id", event.ID, "attempts", attempt, "retry_in", delay, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxWebhookRetryDelay)
+	}
+}
+
+// newWebhookSecret generates a random secret for signing the deliveries of a webhook
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(secret), nil
+}
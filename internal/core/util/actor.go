@@ -0,0 +1,22 @@
+package util
+
+import (
+	"context"
+
+	"harajuku/backend/internal/core/domain"
+)
+
+// actorKey is the context key of the user a request is made by
+type actorKey struct{}
+
+// WithActor returns a copy of ctx made by the user of payload, the handlers set it once the token is verified so
+// services can tell who made a change without taking the user as an argument
+func WithActor(ctx context.Context, payload *domain.TokenPayload) context.Context {
+	return context.WithValue(ctx, actorKey{}, payload)
+}
+
+// Actor returns the user ctx is made by, nil for the work the system does on its own
+func Actor(ctx context.Context) *domain.TokenPayload {
+	payload, _ := ctx.Value(actorKey{}).(*domain.TokenPayload)
+	return payload
+}
@@ -0,0 +1,62 @@
+package util
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+)
+
+// sniffLen is the number of bytes http.DetectContentType looks at
+const sniffLen = 512
+
+// FileInspector wraps an upload and collects its size, content type and SHA-256 checksum
+// while it is streamed to the file storage, so the file never has to be held in memory
+type FileInspector struct {
+	r           io.Reader
+	hash        hash.Hash
+	size        int64
+	contentType string
+}
+
+// NewFileInspector reads the head of r to detect its content type and returns a reader
+// that yields the whole file, head included
+func NewFileInspector(r io.Reader) (*FileInspector, error) {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	head = head[:n]
+
+	return &FileInspector{
+		r:           io.MultiReader(bytes.NewReader(head), r),
+		hash:        sha256.New(),
+		contentType: http.DetectContentType(head),
+	}, nil
+}
+
+// Read implements io.Reader, hashing and counting every byte read
+func (f *FileInspector) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	f.hash.Write(p[:n])
+	f.size += int64(n)
+	return n, err
+}
+
+// Size returns the number of bytes read so far
+func (f *FileInspector) Size() int64 {
+	return f.size
+}
+
+// ContentType returns the content type detected from the head of the file
+func (f *FileInspector) ContentType() string {
+	return f.contentType
+}
+
+// Checksum returns the hex encoded SHA-256 of the bytes read so far
+func (f *FileInspector) Checksum() string {
+	return hex.EncodeToString(f.hash.Sum(nil))
+}
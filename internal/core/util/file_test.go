@@ -0,0 +1,40 @@
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileInspector(t *testing.T) {
+	content := "\x89PNG\r\n\x1a\n" + strings.Repeat("x", 2048)
+
+	inspector, err := NewFileInspector(strings.NewReader(content))
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(inspector)
+	require.NoError(t, err)
+
+	sum := sha256.Sum256([]byte(content))
+	assert.Equal(t, content, string(data))
+	assert.Equal(t, int64(len(content)), inspector.Size())
+	assert.Equal(t, "image/png", inspector.ContentType())
+	assert.Equal(t, hex.EncodeToString(sum[:]), inspector.Checksum())
+}
+
+func TestFileInspectorSmallFile(t *testing.T) {
+	inspector, err := NewFileInspector(strings.NewReader("hello"))
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(inspector)
+	require.NoError(t, err)
+
+	assert.Equal(t, "hello", string(data))
+	assert.Equal(t, int64(5), inspector.Size())
+	assert.Equal(t, "text/plain; charset=utf-8", inspector.ContentType())
+}
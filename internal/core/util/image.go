@@ -0,0 +1,160 @@
+package util
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"io"
+	"net/http"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/disintegration/imaging"
+	// Registers the WebP decoder for the thumbnails
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// jpegQuality is the quality normalized JPEG images are re-encoded with
+	jpegQuality = 92
+	// maxImageSide is the largest width or height in pixels of an image that is decoded
+	maxImageSide = 10_000
+	// maxImagePixels is the largest number of pixels of an image that is decoded
+	maxImagePixels = 40_000_000
+)
+
+// MaxImageSize is the size in bytes of the largest image read into memory, set to the upload limit
+var MaxImageSize int64 = 10 << 20
+
+// NormalizeImage strips the EXIF, GPS and XMP metadata of an uploaded image and applies its
+// orientation to the pixels. JPEG and PNG images are re-encoded, WebP images have their metadata
+// chunks removed and any other file is returned untouched along with its original size
+func NormalizeImage(r io.Reader, size int64) (io.Reader, int64, error) {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, 0, err
+	}
+	head = head[:n]
+	r = io.MultiReader(bytes.NewReader(head), r)
+
+	var format imaging.Format
+	switch http.DetectContentType(head) {
+	case "image/jpeg":
+		format = imaging.JPEG
+	case "image/png":
+		format = imaging.PNG
+	case "image/webp":
+		return stripWebPMetadata(r)
+	default:
+		return r, size, nil
+	}
+
+	r, err = checkImageBounds(r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	img, err := imaging.Decode(r, imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, 0, domain.ErrInvalidImage
+	}
+
+	var buf bytes.Buffer
+	if err := imaging.Encode(&buf, img, format, imaging.JPEGQuality(jpegQuality)); err != nil {
+		return nil, 0, err
+	}
+
+	return &buf, int64(buf.Len()), nil
+}
+
+// stripWebPMetadata drops the EXIF and XMP chunks of a WebP file and clears their flags in the VP8X header
+func stripWebPMetadata(r io.Reader) (io.Reader, int64, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxImageSize+1))
+	if err != nil {
+		return nil, 0, err
+	}
+	if int64(len(data)) > MaxImageSize {
+		return nil, 0, domain.ErrFileTooLarge
+	}
+
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, 0, domain.ErrInvalidImage
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:12])
+
+	for offset := 12; offset < len(data); {
+		if offset+8 > len(data) {
+			return nil, 0, domain.ErrInvalidImage
+		}
+
+		chunkLen := int(binary.LittleEndian.Uint32(data[offset+4 : offset+8]))
+		end := offset + 8 + chunkLen + chunkLen%2
+		if end > len(data) {
+			return nil, 0, domain.ErrInvalidImage
+		}
+
+		chunk := data[offset:end]
+		switch string(chunk[0:4]) {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			if chunkLen < 1 {
+				return nil, 0, domain.ErrInvalidImage
+			}
+			chunk = bytes.Clone(chunk)
+			// Bit 3 flags EXIF metadata and bit 2 flags XMP metadata
+			chunk[8] &^= 0x08 | 0x04
+			out.Write(chunk)
+		default:
+			out.Write(chunk)
+		}
+
+		offset = end
+	}
+
+	stripped := out.Bytes()
+	binary.LittleEndian.PutUint32(stripped[4:8], uint32(len(stripped)-8))
+
+	return bytes.NewReader(stripped), int64(len(stripped)), nil
+}
+
+// Thumbnail decodes a JPEG, PNG, GIF or WebP image and returns it as a JPEG scaled down to fit in a
+// square of maxSize pixels, keeping its aspect ratio. Images already smaller are only re-encoded
+func Thumbnail(data []byte, maxSize int) ([]byte, error) {
+	r, err := checkImageBounds(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := imaging.Decode(r, imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, domain.ErrInvalidImage
+	}
+
+	img = imaging.Fit(img, maxSize, maxSize, imaging.Lanczos)
+
+	var buf bytes.Buffer
+	if err := imaging.Encode(&buf, img, imaging.JPEG, imaging.JPEGQuality(jpegQuality)); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// checkImageBounds reads the header of an image and rejects it before it is decoded when it is wider,
+// taller or holds more pixels than allowed, the returned reader still starts at the header
+func checkImageBounds(r io.Reader) (io.Reader, error) {
+	var head bytes.Buffer
+	config, _, err := image.DecodeConfig(io.TeeReader(r, &head))
+	if err != nil {
+		return nil, domain.ErrInvalidImage
+	}
+
+	if config.Width > maxImageSide || config.Height > maxImageSide || config.Width*config.Height > maxImagePixels {
+		return nil, domain.ErrImageTooLarge
+	}
+
+	return io.MultiReader(&head, r), nil
+}
@@ -0,0 +1,191 @@
+package util
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exifSegment builds an APP1 segment holding only an orientation tag
+func exifSegment(orientation uint16) []byte {
+	var tiff bytes.Buffer
+	tiff.WriteString("II*\x00")
+	binary.Write(&tiff, binary.LittleEndian, uint32(8))
+	binary.Write(&tiff, binary.LittleEndian, uint16(1))
+	binary.Write(&tiff, binary.LittleEndian, []uint16{0x0112, 3})
+	binary.Write(&tiff, binary.LittleEndian, uint32(1))
+	binary.Write(&tiff, binary.LittleEndian, []uint16{orientation, 0})
+	binary.Write(&tiff, binary.LittleEndian, uint32(0))
+
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+func TestNormalizeImageJPEG(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for x := 0; x < 40; x++ {
+		for y := 0; y < 20; y++ {
+			img.Set(x, y, color.White)
+		}
+	}
+
+	var encoded bytes.Buffer
+	require.NoError(t, jpeg.Encode(&encoded, img, nil))
+
+	// Insert the EXIF segment right after the SOI marker, rotated 90° clockwise
+	raw := encoded.Bytes()
+	withExif := append(append(append([]byte{}, raw[:2]...), exifSegment(6)...), raw[2:]...)
+
+	r, size, err := NormalizeImage(bytes.NewReader(withExif), int64(len(withExif)))
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)), size)
+	assert.NotContains(t, string(data), "Exif")
+
+	normalized, err := jpeg.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 20, 40), normalized.Bounds())
+}
+
+func TestNormalizeImageWebP(t *testing.T) {
+	chunk := func(fourCC string, payload []byte) []byte {
+		c := append([]byte(fourCC), 0, 0, 0, 0)
+		binary.LittleEndian.PutUint32(c[4:], uint32(len(payload)))
+		c = append(c, payload...)
+		if len(payload)%2 == 1 {
+			c = append(c, 0)
+		}
+		return c
+	}
+
+	var body []byte
+	body = append(body, chunk("VP8X", []byte{0x0C, 0, 0, 0, 0, 0, 0, 0, 0, 0})...)
+	body = append(body, chunk("VP8L", []byte("pixels"))...)
+	body = append(body, chunk("EXIF", []byte("gps"))...)
+	body = append(body, chunk("XMP ", []byte("<x/>"))...)
+
+	webp := append([]byte("RIFF\x00\x00\x00\x00WEBP"), body...)
+	binary.LittleEndian.PutUint32(webp[4:], uint32(len(webp)-8))
+
+	r, size, err := NormalizeImage(bytes.NewReader(webp), int64(len(webp)))
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)), size)
+	assert.NotContains(t, string(data), "EXIF")
+	assert.NotContains(t, string(data), "XMP ")
+	assert.Contains(t, string(data), "VP8L")
+	assert.Equal(t, byte(0), data[20])
+	assert.Equal(t, uint32(len(data)-8), binary.LittleEndian.Uint32(data[4:8]))
+}
+
+func TestNormalizeImageLeavesOtherFiles(t *testing.T) {
+	pdf := "%PDF-1.7 comprobante"
+
+	r, size, err := NormalizeImage(strings.NewReader(pdf), 42)
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, pdf, string(data))
+	assert.Equal(t, int64(42), size)
+}
+
+func TestNormalizeImageCorrupt(t *testing.T) {
+	_, _, err := NormalizeImage(strings.NewReader("\xFF\xD8\xFF\xE0garbage"), -1)
+	assert.Equal(t, domain.ErrInvalidImage, err)
+}
+
+// hugePNG encodes a small PNG and rewrites its header to claim width x height pixels
+func hugePNG(t *testing.T, width, height uint32) []byte {
+	var encoded bytes.Buffer
+	require.NoError(t, png.Encode(&encoded, image.NewGray(image.Rect(0, 0, 1, 1))))
+
+	data := encoded.Bytes()
+	binary.BigEndian.PutUint32(data[16:20], width)
+	binary.BigEndian.PutUint32(data[20:24], height)
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+func TestNormalizeImageTooLarge(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height uint32
+	}{
+		{"too wide", maxImageSide + 1, 10},
+		{"too tall", 10, maxImageSide + 1},
+		{"too many pixels", 9_000, 9_000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := hugePNG(t, tt.width, tt.height)
+			_, _, err := NormalizeImage(bytes.NewReader(data), int64(len(data)))
+			assert.Equal(t, domain.ErrImageTooLarge, err)
+		})
+	}
+}
+
+func TestNormalizeImageWebPOverLimit(t *testing.T) {
+	limit := MaxImageSize
+	MaxImageSize = 64
+	t.Cleanup(func() { MaxImageSize = limit })
+
+	webp := append([]byte("RIFF\x00\x00\x00\x00WEBPVP8L"), bytes.Repeat([]byte{0}, 100)...)
+	_, _, err := NormalizeImage(bytes.NewReader(webp), -1)
+	assert.Equal(t, domain.ErrFileTooLarge, err)
+}
+
+func TestThumbnail(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 800, 400))
+	var encoded bytes.Buffer
+	require.NoError(t, png.Encode(&encoded, img))
+
+	data, err := Thumbnail(encoded.Bytes(), 320)
+	require.NoError(t, err)
+
+	thumbnail, err := jpeg.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 320, 160), thumbnail.Bounds())
+}
+
+func TestThumbnailKeepsSmallImages(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 100, 50))
+	var encoded bytes.Buffer
+	require.NoError(t, jpeg.Encode(&encoded, img, nil))
+
+	data, err := Thumbnail(encoded.Bytes(), 320)
+	require.NoError(t, err)
+
+	thumbnail, err := jpeg.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 100, 50), thumbnail.Bounds())
+}
+
+func TestThumbnailNotAnImage(t *testing.T) {
+	_, err := Thumbnail([]byte("%PDF-1.7 comprobante"), 320)
+	assert.Equal(t, domain.ErrInvalidImage, err)
+}
+
+func TestThumbnailTooLarge(t *testing.T) {
+	_, err := Thumbnail(hugePNG(t, maxImageSide, maxImageSide), 320)
+	assert.Equal(t, domain.ErrImageTooLarge, err)
+}
@@ -0,0 +1,33 @@
+package util
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// logAttrsKey is the context key of the attributes the logs written with the context carry
+type logAttrsKey struct{}
+
+// WithLogAttrs returns a copy of ctx whose logs carry attrs besides the ones of ctx, so a request or a job can
+// be followed through every log it causes. The attributes are added by the logger to the records logged with
+// the context, through slog.ErrorContext and its siblings
+func WithLogAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	if len(attrs) == 0 {
+		return ctx
+	}
+
+	// The attributes of ctx are copied, contexts derived from the same parent don't share them
+	current := LogAttrs(ctx)
+	return context.WithValue(ctx, logAttrsKey{}, append(slices.Clip(current), attrs...))
+}
+
+// LogAttrs returns the attributes the logs written with ctx carry
+func LogAttrs(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+
+	attrs, _ := ctx.Value(logAttrsKey{}).([]slog.Attr)
+	return attrs
+}
@@ -0,0 +1,48 @@
+package util
+
+import (
+	"context"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// salonKey is the context key of the salon a request is made in
+type salonKey struct{}
+
+// WithSalon returns a copy of ctx made in the salon with id, the repositories then only reach its rows
+func WithSalon(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, salonKey{}, id)
+}
+
+// Salon returns the salon ctx is made in, false for the work the system does on its own across every salon
+func Salon(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(salonKey{}).(uuid.UUID)
+	return id, ok
+}
+
+// SalonOr returns the salon new rows are created in: id when it is set, otherwise the salon ctx is made in,
+// and the default salon outside of one
+func SalonOr(ctx context.Context, id uuid.UUID) uuid.UUID {
+	if id != uuid.Nil {
+		return id
+	}
+	if salon, ok := Salon(ctx); ok {
+		return salon
+	}
+	return domain.DefaultSalonID
+}
+
+// InSalon reports whether an entity can be seen from ctx, every entity can outside of a salon
+func InSalon(ctx context.Context, entity domain.SalonScoped) bool {
+	salon, ok := Salon(ctx)
+	return !ok || entity.Salon() == salon
+}
+
+// ManagesSalons reports whether the salons and the settings shared by every salon can be managed from ctx,
+// outside of a salon or from the default one
+func ManagesSalons(ctx context.Context) bool {
+	salon, ok := Salon(ctx)
+	return !ok || salon == domain.DefaultSalonID
+}
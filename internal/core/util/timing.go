@@ -0,0 +1,60 @@
+package util
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Timing is the time a request spent waiting on a dependency, such as the database or the cache,
+// and how many calls it made to it
+type Timing struct {
+	Duration time.Duration
+	Calls    int
+}
+
+// Timings adds up the time a request spends waiting on each dependency, the adapters record their
+// calls in it from the goroutines handling the request
+type Timings struct {
+	mu         sync.Mutex
+	dependency map[string]Timing
+}
+
+// timingsKey is the context key of the timings of a request
+type timingsKey struct{}
+
+// WithTimings returns a copy of ctx recording the calls of the adapters in the returned timings
+func WithTimings(ctx context.Context) (context.Context, *Timings) {
+	timings := &Timings{dependency: make(map[string]Timing)}
+	return context.WithValue(ctx, timingsKey{}, timings), timings
+}
+
+// RecordTiming adds a call to dependency taking d to the timings of ctx, nothing is recorded without them
+func RecordTiming(ctx context.Context, dependency string, d time.Duration) {
+	timings, ok := ctx.Value(timingsKey{}).(*Timings)
+	if !ok {
+		return
+	}
+
+	timings.mu.Lock()
+	defer timings.mu.Unlock()
+
+	timing := timings.dependency[dependency]
+	timing.Duration += d
+	timing.Calls++
+	timings.dependency[dependency] = timing
+}
+
+// RecordsTimings reports whether ctx records the calls of the adapters, so they skip measuring them otherwise
+func RecordsTimings(ctx context.Context) bool {
+	_, ok := ctx.Value(timingsKey{}).(*Timings)
+	return ok
+}
+
+// Get returns the time spent waiting on dependency
+func (t *Timings) Get(dependency string) Timing {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.dependency[dependency]
+}
@@ -30,7 +30,7 @@ func TestSendEmailIntegrationTest(t *testing.T) {
 
     emails := []string{"gizehmata@gmail.com", "shely0210@hotmail.com"}
 
-    err = emailManager.SendEmail(ctx, emails, "Saludo", "Hola, cómo estás?", "")
+    _, err = emailManager.SendEmail(ctx, emails, "Saludo", "Hola, cómo estás?", "")
     if err != nil {
         t.Fatalf("failed to send email: %v", err) // Updated error message
     }
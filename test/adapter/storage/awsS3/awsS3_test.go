@@ -6,21 +6,22 @@ import (
 	"os"
 	"testing"
 
+	"harajuku/backend/internal/adapter/config"
 	"harajuku/backend/internal/adapter/storage/awsS3"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
 func TestSaveFile(t *testing.T) {
-	// Initialize AWS session
-	sess := session.Must(session.NewSession(&aws.Config{
-		Region: aws.String("us-east-1"),
-	}))
-	
+	// Initialize AWS client
+	client, err := awsS3.NewClient(context.Background(), &config.AwsS3{Region: "us-east-1", MaxAttempts: 3})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
 	// Create S3 adapter
-	s3Adapter := awsS3.NewAwsS3(sess, "aws-harajuku-bucket-001")
+	s3Adapter := awsS3.NewAwsS3(client, "aws-harajuku-bucket-001", awsS3.MinPartSize)
 
 	// 1. Read test file
 	testFilePath := "/Users/ram/Downloads/minutas.md" // Create this file in your project
@@ -31,7 +32,7 @@ func TestSaveFile(t *testing.T) {
 
 	// 2. Upload file
 	objectKey := "minutas.md"
-	uploadedPath, err := s3Adapter.Save(context.Background(), fileData, objectKey)
+	uploadedPath, err := s3Adapter.Save(context.Background(), bytes.NewReader(fileData), int64(len(fileData)), objectKey)
 	if err != nil {
 		t.Fatalf("Save failed: %v", err)
 	}
@@ -47,27 +48,27 @@ func TestSaveFile(t *testing.T) {
 
 func TestSaveAndVerifyFile(t *testing.T) {
 	ctx := context.Background()
-	sess := session.Must(session.NewSession(&aws.Config{
-		Region: aws.String("us-east-1"),
-	}))
-	
+	s3Client, err := awsS3.NewClient(ctx, &config.AwsS3{Region: "us-east-1", MaxAttempts: 3})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
 	bucket := "ews-bucket-test-001"
-	s3Adapter := awsS3.NewAwsS3(sess, bucket)
-	s3Client := s3.New(sess)
+	s3Adapter := awsS3.NewAwsS3(s3Client, bucket, awsS3.MinPartSize)
 
 	// Test data
 	testContent := []byte("This is a test file content")
 	objectKey := "test-upload.txt"
 
 	// Cleanup before test (in case previous test failed)
-	_, _ = s3Client.DeleteObject(&s3.DeleteObjectInput{
+	_, _ = s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
 		Bucket: aws.String(bucket),
 		Key:    aws.String(objectKey),
 	})
 
 	t.Run("Save file successfully", func(t *testing.T) {
 		// Upload file
-		uploadedPath, err := s3Adapter.Save(ctx, testContent, objectKey)
+		uploadedPath, err := s3Adapter.Save(ctx, bytes.NewReader(testContent), int64(len(testContent)), objectKey)
 		if err != nil {
 			t.Fatalf("Save failed: %v", err)
 		}
@@ -77,7 +78,7 @@ func TestSaveAndVerifyFile(t *testing.T) {
 		}
 
 		// Verify file exists in S3
-		_, err = s3Client.GetObject(&s3.GetObjectInput{
+		_, err = s3Client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket: aws.String(bucket),
 			Key:    aws.String(objectKey),
 		})
@@ -100,7 +101,7 @@ func TestSaveAndVerifyFile(t *testing.T) {
 
 	// Cleanup after test
 	t.Cleanup(func() {
-		_, err := s3Client.DeleteObject(&s3.DeleteObjectInput{
+		_, err := s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket: aws.String(bucket),
 			Key:    aws.String(objectKey),
 		})
@@ -0,0 +1,108 @@
+package minio
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/adapter/storage/awsS3"
+	"harajuku/backend/internal/adapter/storage/minio"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func setupMinio(t *testing.T) *config.Minio {
+	t.Helper()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	ctx := context.Background()
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "minio/minio:latest",
+			ExposedPorts: []string{"9000/tcp"},
+			Cmd:          []string{"server", "/data"},
+			Env: map[string]string{
+				"MINIO_ROOT_USER":     "minioadmin",
+				"MINIO_ROOT_PASSWORD": "minioadmin",
+			},
+			WaitingFor: wait.ForHTTP("/minio/health/live").WithPort("9000/tcp").WithStartupTimeout(60 * time.Second),
+		},
+		Started: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to start container: %v", err)
+	}
+	t.Cleanup(func() { _ = container.Terminate(ctx) })
+
+	endpoint, err := container.PortEndpoint(ctx, "9000/tcp", "")
+	if err != nil {
+		t.Fatalf("failed to get endpoint: %v", err)
+	}
+
+	cfg := &config.Minio{
+		Endpoint:  endpoint,
+		Region:    "us-east-1",
+		Bucket:    "harajuku-test",
+		AccessKey: "minioadmin",
+		SecretKey: "minioadmin",
+	}
+
+	client, err := awsS3.NewClient(ctx, &config.AwsS3{
+		Region:       cfg.Region,
+		Endpoint:     "http://" + cfg.Endpoint,
+		AccessKey:    cfg.AccessKey,
+		SecretKey:    cfg.SecretKey,
+		UsePathStyle: true,
+		MaxAttempts:  3,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.CreateBucket(ctx, &s3.CreateBucketInput{Bucket: aws.String(cfg.Bucket)})
+	if err != nil {
+		t.Fatalf("failed to create bucket: %v", err)
+	}
+
+	return cfg
+}
+
+func TestSaveGetDeleteIntegration(t *testing.T) {
+	cfg := setupMinio(t)
+	ctx := context.Background()
+
+	storage, err := minio.New(ctx, cfg)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+
+	key, err := storage.Save(ctx, strings.NewReader("quote attachment"), int64(len("quote attachment")), "quotes/test/photo.jpg")
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	data, err := storage.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	if string(data) != "quote attachment" {
+		t.Errorf("content mismatch, got %q", data)
+	}
+
+	err = storage.Delete(ctx, key)
+	if err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	_, err = storage.Get(ctx, key)
+	if err == nil {
+		t.Error("expected an error after deleting the file")
+	}
+}
@@ -0,0 +1,111 @@
+package helpers
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/adapter/storage/postgres"
+)
+
+// Fixture is a migrated database shared by the tests of a package,
+// every test runs inside its own transaction that is rolled back when it ends
+type Fixture struct {
+	container *TestDBContainer
+	db        *postgres.DB
+}
+
+// NewFixture starts one container for the package, connects to it and applies the migrations
+func NewFixture(ctx context.Context) (*Fixture, error) {
+	testContainer, err := startTestDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &config.DB{
+		Connection: "postgres",
+		User:       "user",
+		Password:   "secret",
+		Host:       "localhost",
+		Port:       testContainer.PORT,
+		Name:       "testdb",
+	}
+
+	db, err := postgres.New(ctx, cfg)
+	if err != nil {
+		testContainer.Teardown()
+		return nil, fmt.Errorf("failed to connect to test db: %w", err)
+	}
+
+	err = db.Migrate()
+	if err != nil {
+		db.Close()
+		testContainer.Teardown()
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	return &Fixture{container: testContainer, db: db}, nil
+}
+
+// Run is meant to be called from TestMain, it runs the tests of the package against a fixture
+// stored in fixture and returns the exit code. With -short the container isn't started and
+// the tests using the fixture are skipped
+func Run(m *testing.M, fixture **Fixture) int {
+	flag.Parse()
+
+	if !testing.Short() {
+		f, err := NewFixture(context.Background())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to set up the test database: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+
+		*fixture = f
+	}
+
+	return m.Run()
+}
+
+// Tx begins a transaction that is rolled back when the test ends and returns a DB wrapping it,
+// repositories built on it only see the data of the test
+func (f *Fixture) Tx(t *testing.T) *postgres.DB {
+	t.Helper()
+
+	if f == nil {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	txDB, err := f.db.BeginTx(ctx)
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = txDB.Rollback(ctx)
+	})
+
+	return txDB
+}
+
+// DB returns the shared database, outside of a transaction. What the tests write through it is
+// committed, so they must not depend on the rows of the other tests
+func (f *Fixture) DB(t *testing.T) *postgres.DB {
+	t.Helper()
+
+	if f == nil {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	return f.db
+}
+
+// Close closes the connection and terminates the container
+func (f *Fixture) Close() {
+	f.db.Close()
+	f.container.Teardown()
+}
@@ -2,109 +2,35 @@ package repository
 
 import (
 	"context"
-	"harajuku/backend/internal/adapter/config"
-	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/adapter/storage/postgres/repository"
 	"harajuku/backend/internal/core/domain"
-	"harajuku/backend/test/adapter/storage/postgres/helpers"
+	"harajuku/backend/test/factory"
 	"testing"
-	"time"
-
-	"github.com/google/uuid"
 )
 
 func TestCreateAppointmentIntegration(t *testing.T) {
-	testContainer := helpers.SetupTestDB(t)
-	defer testContainer.Teardown()
-
+	db := fixture.Tx(t)
 	ctx := context.Background()
 
-	cfg := &config.DB{
-		Connection: "postgres",
-		User:       "user",
-		Password:   "secret",
-		Host:       "localhost",
-		Port:       testContainer.PORT,
-		Name:       "testdb",
-	}
-
-	db, err := postgres.New(ctx, cfg)
-	if err != nil {
-		t.Fatalf("failed to connect to test db: %v", err)
-	}
+	repo := repository.NewAppointmentRepository(db)
 
-	err = db.Migrate()
-	if err != nil {
-		t.Fatalf("failed to run migrations: %v", err)
-	}
+	quote := factory.NewQuote(t, db)
+	slot := factory.NewAvailabilitySlot(t, db)
 
-	repo := postgres.NewAppointmentRepository(db)
+	appointment := factory.Appointment(factory.For(quote, slot), factory.WithStatus(domain.Booked))
 
-	// Insert test admin user first
-	adminID := uuid.New()
-	_, err = db.Exec(ctx, `INSERT INTO "users" ("id", "name", "lastName", "secondLastName", "email", "password", "role")
-	VALUES (
-		$1,
-		'Juan',
-		'Pérez',
-		'González',
-		'juan.perez@example.com',
-		'hashed_password_aqui',
-		'admin' 
-	);
-	`, adminID)
+	createdAppointment, err := repo.CreateAppointment(ctx, appointment)
 	if err != nil {
-		t.Fatalf("failed to insert test admin: %v", err)
+		t.Fatalf("failed to create appointment: %v", err)
 	}
 
-	// Insert customer user first
-	clientID := uuid.New()
-	_, err = db.Exec(ctx, `INSERT INTO "users" ("id", "name", "lastName", "email", "password", "role")
-	VALUES (
-		$1,
-		'Kevin',
-		'Rodríguez',
-		'kevin.rdz@example.com',
-		'hashed_password_aqui',
-		'client' 
-	);
-	`, clientID)
-	if err != nil {
-		t.Fatalf("failed to insert test admin: %v", err)
+	if createdAppointment.ID != appointment.ID {
+		t.Errorf("expected appointment ID %v, got %v", appointment.ID, createdAppointment.ID)
 	}
 
-	quote := domain.Quote{
-		ID:              uuid.New(),
-		TypeOfServiceID: uuid.MustParse("11111111-1111-1111-1111-111111111111"),
-		ClientID:        uuid.MustParse("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"),
-		Time:            time.Now(),
-		Description:     "Test Create Quote",
-		State:           "pending",
-		Price:           100.50,
-	}
-
-	slot := domain.AvailabilitySlot{
-		ID:        uuid.New(),
-		AdminID:   adminID,
-		StartTime: time.Now().UTC(),
-		EndTime:   time.Now().UTC().Add(1 * time.Hour),
-		IsBooked:  false,
-	}
-
-	appointment := domain.Appointment{
-		ID:        uuid.New(),
-		UserID:   clientID,
-		SlotId: slot.ID,
-		QuoteId:   quote.ID,
-		Status:  domain.Booked,
-	}
-
-	createdSlot, err := repo.CreateAppointment(ctx, &appointment)
-	if err != nil {
-		t.Fatalf("failed to create slot: %v", err)
-	}
-
-	if createdSlot.ID != slot.ID {
-		t.Errorf("expected slot ID %v, got %v", slot.ID, createdSlot.ID)
+	// A quote has a single appointment
+	_, err = repo.CreateAppointment(ctx, factory.Appointment(factory.For(quote, factory.NewAvailabilitySlot(t, db)), factory.WithStatus(domain.Booked)))
+	if err != domain.ErrConflictingData {
+		t.Errorf("expected conflicting data for a second appointment, got %v", err)
 	}
 }
-
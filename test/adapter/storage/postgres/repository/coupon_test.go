@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"harajuku/backend/internal/adapter/storage/postgres/repository"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/test/factory"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCreateCouponIntegration(t *testing.T) {
+	db := fixture.Tx(t)
+	ctx := context.Background()
+
+	repo := repository.NewCouponRepository(db)
+	service := factory.NewTypeOfService(t, db)
+	coupon := factory.NewCoupon(t, db, func(c *domain.Coupon) {
+		c.TypeOfServiceIDs = []uuid.UUID{service.ID}
+	})
+
+	retrieved, err := repo.GetCouponByCode(ctx, coupon.Code)
+	if err != nil {
+		t.Fatalf("failed to get coupon by code: %v", err)
+	}
+	if retrieved.ID != coupon.ID {
+		t.Errorf("expected coupon %v, got %v", coupon.ID, retrieved.ID)
+	}
+	if len(retrieved.TypeOfServiceIDs) != 1 || retrieved.TypeOfServiceIDs[0] != service.ID {
+		t.Errorf("expected type of service %v, got %v", service.ID, retrieved.TypeOfServiceIDs)
+	}
+
+	// Codes are unique
+	_, err = repo.CreateCoupon(ctx, factory.Coupon(func(c *domain.Coupon) { c.Code = coupon.Code }))
+	if err != domain.ErrConflictingData {
+		t.Errorf("expected ErrConflictingData for a repeated code, got %v", err)
+	}
+}
+
+func TestRedeemCouponLimitsIntegration(t *testing.T) {
+	db := fixture.Tx(t)
+	ctx := context.Background()
+
+	repo := repository.NewCouponRepository(db)
+	coupon := factory.NewCoupon(t, db, func(c *domain.Coupon) {
+		c.MaxRedemptions = 2
+		c.MaxRedemptionsPerClient = 1
+	})
+	client := factory.NewUser(t, db)
+	first := factory.NewQuote(t, db, func(q *domain.Quote) { q.ClientID = client.ID })
+	second := factory.NewQuote(t, db, func(q *domain.Quote) { q.ClientID = client.ID })
+	other := factory.NewQuote(t, db)
+	last := factory.NewQuote(t, db)
+
+	redeem := func(quote *domain.Quote) error {
+		_, err := repo.RedeemCoupon(ctx, &domain.CouponRedemption{
+			ID:       uuid.New(),
+			CouponID: coupon.ID,
+			QuoteID:  quote.ID,
+			ClientID: quote.ClientID,
+		})
+		return err
+	}
+
+	if err := redeem(first); err != nil {
+		t.Fatalf("failed to redeem coupon: %v", err)
+	}
+
+	// Applying the coupon again to the same quote replaces its redemption
+	if err := redeem(first); err != nil {
+		t.Fatalf("failed to redeem coupon again on the same quote: %v", err)
+	}
+
+	// The client used its only redemption
+	if err := redeem(second); err != domain.ErrCouponUnavailable {
+		t.Errorf("expected ErrCouponUnavailable for a second quote of the client, got %v", err)
+	}
+
+	if err := redeem(other); err != nil {
+		t.Fatalf("failed to redeem coupon for another client: %v", err)
+	}
+
+	// The coupon used its two redemptions
+	if err := redeem(last); err != domain.ErrCouponUnavailable {
+		t.Errorf("expected ErrCouponUnavailable past the limit, got %v", err)
+	}
+
+	retrieved, err := repo.GetCouponByID(ctx, coupon.ID)
+	if err != nil {
+		t.Fatalf("failed to get coupon: %v", err)
+	}
+	if retrieved.Redemptions != 2 {
+		t.Errorf("expected 2 redemptions, got %d", retrieved.Redemptions)
+	}
+
+	// Releasing a quote gives its use back
+	if err := repo.ReleaseCoupon(ctx, other.ID); err != nil {
+		t.Fatalf("failed to release coupon: %v", err)
+	}
+	if err := redeem(last); err != nil {
+		t.Errorf("expected the released use to be redeemable, got %v", err)
+	}
+
+	redemptions, err := repo.ListCouponRedemptions(ctx, coupon.ID, 1, 10)
+	if err != nil {
+		t.Fatalf("failed to list redemptions: %v", err)
+	}
+	if len(redemptions) != 2 {
+		t.Errorf("expected 2 redemptions, got %d", len(redemptions))
+	}
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"os"
+	"testing"
+
+	"harajuku/backend/test/adapter/storage/postgres/helpers"
+)
+
+// fixture is the database shared by the tests of the package, each test gets its own transaction with fixture.Tx
+var fixture *helpers.Fixture
+
+func TestMain(m *testing.M) {
+	os.Exit(helpers.Run(m, &fixture))
+}
@@ -2,180 +2,69 @@ package repository
 
 import (
 	"context"
-	"harajuku/backend/internal/adapter/config"
-	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/adapter/storage/postgres/repository"
 	"harajuku/backend/internal/core/domain"
-	"harajuku/backend/test/adapter/storage/postgres/helpers"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/test/factory"
 	"testing"
-	"time"
 
 	"github.com/google/uuid"
 )
 
-const QUOTE_PORT = "5435"
-
 func TestCreateQuoteIntegration(t *testing.T) {
-	testContainer := helpers.SetupTestDB(t)
-	defer testContainer.Teardown()
-
+	db := fixture.Tx(t)
 	ctx := context.Background()
 
-	cfg := &config.DB{
-		Connection: "postgres",
-		User:       "user",
-		Password:   "secret",
-		Host:       "localhost",
-		Port:       QUOTE_PORT,
-		Name:       "testdb",
-	}
+	repo := repository.NewQuoteRepository(db)
 
-	// overwrite the db URL to point to container
-	db, err := postgres.New(ctx, cfg)
-	if err != nil {
-		t.Fatalf("failed to connect to test db: %v", err)
-	}
+	quote := factory.Quote(factory.ForClient(factory.NewUser(t, db).ID), func(q *domain.Quote) {
+		q.TypeOfServiceID = factory.NewTypeOfService(t, db).ID
+	})
 
-	// apply migrations
-	err = db.Migrate()
-	if err != nil {
-		t.Fatalf("failed to run migrations: %v", err)
-	}
-
-	repo := postgres.NewQuoteRepository(db)
-
-	// Insert test data
-	quote := domain.Quote{
-		ID:              uuid.New(),
-		TypeOfServiceID: uuid.MustParse("11111111-1111-1111-1111-111111111111"),
-		ClientID:        uuid.MustParse("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"),
-		Time:            time.Now(),
-		Description:     "Test Create Quote",
-		State:           "pending",
-		Price:           100.50,
-	}
-
-	createdQuote, err := repo.CreateQuote(ctx, &quote)
+	createdQuote, err := repo.CreateQuote(ctx, quote)
 	if err != nil {
 		t.Fatalf("failed to create quote: %v", err)
 	}
 
-	t.Logf("Created quote with ID: %v", createdQuote.ID)
+	if createdQuote.Version != 1 {
+		t.Errorf("expected version 1, got %d", createdQuote.Version)
+	}
 }
 
 func TestGetQuoteByIDIntegration(t *testing.T) {
-	testContainer := helpers.SetupTestDB(t)
-	defer testContainer.Teardown()
-
+	db := fixture.Tx(t)
 	ctx := context.Background()
 
-	cfg := &config.DB{
-		Connection: "postgres",
-		User:       "user",
-		Password:   "secret",
-		Host:       "localhost",
-		Port:       PORT,
-		Name:       "testdb",
-	}
-
-	// overwrite the db URL to point to container
-	db, err := postgres.New(ctx, cfg)
-	if err != nil {
-		t.Fatalf("failed to connect to test db: %v", err)
-	}
+	repo := repository.NewQuoteRepository(db)
+	quote := factory.NewQuote(t, db, func(q *domain.Quote) { q.Price = 200.75 })
 
-	// apply migrations
-	err = db.Migrate()
+	retrievedQuote, err := repo.GetQuoteByID(ctx, quote.ID)
 	if err != nil {
-		t.Fatalf("failed to run migrations: %v", err)
+		t.Fatalf("failed to get quote by ID: %v", err)
 	}
 
-	repo := postgres.NewQuoteRepository(db)
-
-	// Insert test data
-	quote := domain.Quote{
-		ID:              uuid.New(),
-		TypeOfServiceID: uuid.MustParse("11111111-1111-1111-1111-111111111111"),
-		ClientID:        uuid.MustParse("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"),
-		Time:            time.Now(),
-		Description:     "Test Get Quote",
-		State:           "pending",
-		Price:           200.75,
+	if retrievedQuote.Price != 200.75 {
+		t.Errorf("expected price 200.75, got %v", retrievedQuote.Price)
 	}
 
-	_, err = repo.CreateQuote(ctx, &quote)
-	if err != nil {
-		t.Fatalf("failed to create quote: %v", err)
+	_, err = repo.GetQuoteByID(ctx, uuid.New())
+	if err != domain.ErrDataNotFound {
+		t.Errorf("expected data not found for a missing quote, got %v", err)
 	}
-
-	// Retrieve quote by ID
-	retrievedQuote, err := repo.GetQuoteByID(ctx, quote.ID)
-	if err != nil {
-		t.Fatalf("failed to get quote by ID: %v", err)
-	}
-
-	t.Logf("Retrieved quote: %+v", retrievedQuote)
 }
 
 func TestListQuotesIntegration(t *testing.T) {
-	testContainer := helpers.SetupTestDB(t)
-	defer testContainer.Teardown()
-
+	db := fixture.Tx(t)
 	ctx := context.Background()
 
-	cfg := &config.DB{
-		Connection: "postgres",
-		User:       "user",
-		Password:   "secret",
-		Host:       "localhost",
-		Port:       PORT,
-		Name:       "testdb",
-	}
-
-	// overwrite the db URL to point to container
-	db, err := postgres.New(ctx, cfg)
-	if err != nil {
-		t.Fatalf("failed to connect to test db: %v", err)
-	}
-
-	// apply migrations
-	err = db.Migrate()
-	if err != nil {
-		t.Fatalf("failed to run migrations: %v", err)
-	}
-
-	repo := postgres.NewQuoteRepository(db)
-
-	// Insert multiple test data
-	quotes := []domain.Quote{
-		{
-			ID:              uuid.New(),
-			TypeOfServiceID: uuid.MustParse("11111111-1111-1111-1111-111111111111"),
-			ClientID:        uuid.MustParse("aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"),
-			Time:            time.Now(),
-			Description:     "Quote 1",
-			State:           "pending",
-			Price:           100.00,
-		},
-		{
-			ID:              uuid.New(),
-			TypeOfServiceID: uuid.MustParse("22222222-2222-2222-2222-222222222222"),
-			ClientID:        uuid.MustParse("bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb"),
-			Time:            time.Now(),
-			Description:     "Quote 2",
-			State:           "approved",
-			Price:           200.00,
-		},
-	}
+	repo := repository.NewQuoteRepository(db)
 
-	for _, q := range quotes {
-		_, err := repo.CreateQuote(ctx, &q)
-		if err != nil {
-			t.Fatalf("failed to create quote: %v", err)
-		}
-	}
+	client := factory.NewUser(t, db)
+	factory.NewQuote(t, db, func(q *domain.Quote) { q.ClientID = client.ID })
+	factory.NewQuote(t, db, func(q *domain.Quote) { q.ClientID = client.ID; q.State = domain.QuoteApproved })
+	factory.NewQuote(t, db)
 
-	// List quotes
-	listedQuotes, err := repo.ListQuotes(ctx, 1, 10)
+	listedQuotes, _, err := repo.ListQuotes(ctx, port.QuoteFilter{ClientID: &client.ID, Skip: 1, Limit: 10})
 	if err != nil {
 		t.Fatalf("failed to list quotes: %v", err)
 	}
@@ -184,53 +73,20 @@ func TestListQuotesIntegration(t *testing.T) {
 		t.Fatalf("expected 2 quotes, got %d", len(listedQuotes))
 	}
 
-	t.Logf("Listed quotes: %+v", listedQuotes)
+	approved := domain.QuoteApproved
+	total, err := repo.CountQuotes(ctx, port.QuoteFilter{ClientID: &client.ID, ByState: &approved})
+	if err != nil || total != 1 {
+		t.Fatalf("expected 1 approved quote, got %d, error: %v", total, err)
+	}
 }
 
 func TestUpdateQuoteIntegration(t *testing.T) {
-	testContainer := helpers.SetupTestDB(t)
-	defer testContainer.Teardown()
-
+	db := fixture.Tx(t)
 	ctx := context.Background()
 
-	cfg := &config.DB{
-		Connection: "postgres",
-		User:       "user",
-		Password:   "secret",
-		Host:       "localhost",
-		Port:       PORT,
-		Name:       "testdb",
-	}
-
-	// overwrite the db URL to point to container
-	db, err := postgres.New(ctx, cfg)
-	if err != nil {
-		t.Fatalf("failed to connect to test db: %v", err)
-	}
-
-	// apply migrations
-	err = db.Migrate()
-	if err != nil {
-		t.Fatalf("failed to run migrations: %v", err)
-	}
-
-	repo := postgres.NewQuoteRepository(db)
-
-	// Insert test data
-	quote := domain.Quote{
-		ID:              uuid.New(),
-		TypeOfServiceID: uuid.MustParse("33333333-3333-3333-3333-333333333333"),
-		ClientID:        uuid.MustParse("cccccccc-cccc-cccc-cccc-cccccccccccc"),
-		Time:            time.Now(),
-		Description:     "Test Update Quote",
-		State:           "pending",
-		Price:           50.25,
-	}
-
-	createdQuote, err := repo.CreateQuote(ctx, &quote)
-	if err != nil {
-		t.Fatalf("failed to create quote: %v", err)
-	}
+	repo := repository.NewQuoteRepository(db)
+	createdQuote := factory.NewQuote(t, db)
+	version := createdQuote.Version
 
 	// Update quote
 	createdQuote.Description = "Updated description"
@@ -241,59 +97,51 @@ func TestUpdateQuoteIntegration(t *testing.T) {
 		t.Fatalf("failed to update quote: %v", err)
 	}
 
-	t.Logf("Updated quote: %+v", updatedQuote)
+	if updatedQuote.Version != version+1 {
+		t.Errorf("expected version %d, got %d", version+1, updatedQuote.Version)
+	}
+
+	// A stale version is rejected
+	updatedQuote.Version = version
+	_, err = repo.UpdateQuote(ctx, updatedQuote)
+	if err != domain.ErrConflictingData {
+		t.Errorf("expected conflicting data for a stale version, got %v", err)
+	}
 }
 
 func TestDeleteQuoteIntegration(t *testing.T) {
-	testContainer := helpers.SetupTestDB(t)
-	defer testContainer.Teardown()
-
+	db := fixture.Tx(t)
 	ctx := context.Background()
 
-	cfg := &config.DB{
-		Connection: "postgres",
-		User:       "user",
-		Password:   "secret",
-		Host:       "localhost",
-		Port:       PORT,
-		Name:       "testdb",
-	}
+	repo := repository.NewQuoteRepository(db)
+	createdQuote := factory.NewQuote(t, db)
 
-	// overwrite the db URL to point to container
-	db, err := postgres.New(ctx, cfg)
+	err := repo.DeleteQuote(ctx, createdQuote.ID)
 	if err != nil {
-		t.Fatalf("failed to connect to test db: %v", err)
+		t.Fatalf("failed to delete quote: %v", err)
 	}
 
-	// apply migrations
-	err = db.Migrate()
-	if err != nil {
-		t.Fatalf("failed to run migrations: %v", err)
+	_, err = repo.GetQuoteByID(ctx, createdQuote.ID)
+	if err != domain.ErrDataNotFound {
+		t.Errorf("expected data not found for a deleted quote, got %v", err)
 	}
+}
 
-	repo := postgres.NewQuoteRepository(db)
-
-	// Insert test data
-	quote := domain.Quote{
-		ID:              uuid.New(),
-		TypeOfServiceID: uuid.MustParse("44444444-4444-4444-4444-444444444444"),
-		ClientID:        uuid.MustParse("dddddddd-dddd-dddd-dddd-dddddddddddd"),
-		Time:            time.Now(),
-		Description:     "Test Delete Quote",
-		State:           "pending",
-		Price:           75.50,
-	}
+func TestUpdateQuoteIntegrationKeepsEmptyFields(t *testing.T) {
+	db := fixture.Tx(t)
+	ctx := context.Background()
 
-	createdQuote, err := repo.CreateQuote(ctx, &quote)
-	if err != nil {
-		t.Fatalf("failed to create quote: %v", err)
-	}
+	repo := repository.NewQuoteRepository(db)
+	createdQuote := factory.NewQuote(t, db, func(q *domain.Quote) { q.Price = 300 })
 
-	// Delete quote
-	err = repo.DeleteQuote(ctx, createdQuote.ID)
+	// Only the description changes, the rest keeps its value
+	updatedQuote, err := repo.UpdateQuote(ctx, &domain.Quote{ID: createdQuote.ID, Description: "Updated description", Version: createdQuote.Version})
 	if err != nil {
-		t.Fatalf("failed to delete quote: %v", err)
+		t.Fatalf("failed to update quote: %v", err)
 	}
 
-	t.Logf("Deleted quote with ID: %v", createdQuote.ID)
+	if updatedQuote.Description != "Updated description" || updatedQuote.Price != 300 ||
+		updatedQuote.ClientID != createdQuote.ClientID || updatedQuote.State != createdQuote.State {
+		t.Errorf("expected only the description to change, got %+v", updatedQuote)
+	}
 }
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"harajuku/backend/internal/adapter/storage/postgres/repository"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/util"
+	"harajuku/backend/test/factory"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCreateSalonIntegration(t *testing.T) {
+	db := fixture.Tx(t)
+	ctx := context.Background()
+
+	repo := repository.NewSalonRepository(db)
+	salon := factory.NewSalon(t, db)
+
+	retrieved, err := repo.GetSalonByID(ctx, salon.ID)
+	if err != nil {
+		t.Fatalf("failed to get salon by ID: %v", err)
+	}
+	if retrieved.Slug != salon.Slug {
+		t.Errorf("expected slug %q, got %q", salon.Slug, retrieved.Slug)
+	}
+
+	// The slug is unique
+	_, err = repo.CreateSalon(ctx, factory.Salon(func(s *domain.Salon) { s.Slug = salon.Slug }))
+	if err != domain.ErrConflictingData {
+		t.Errorf("expected ErrConflictingData for a taken slug, got %v", err)
+	}
+}
+
+func TestRowsAreScopedToTheirSalonIntegration(t *testing.T) {
+	db := fixture.Tx(t)
+
+	salon := factory.NewSalon(t, db)
+	roma := util.WithSalon(context.Background(), salon.ID)
+	harajuku := util.WithSalon(context.Background(), domain.DefaultSalonID)
+
+	services := repository.NewTypeOfServiceRepository(db)
+
+	// Created in the salon of the context, the rows created outside of one are of the default salon
+	own, err := services.CreateTypeOfService(roma, factory.TypeOfService())
+	if err != nil {
+		t.Fatalf("failed to create type of service: %v", err)
+	}
+	if own.SalonID != salon.ID {
+		t.Errorf("expected salon %v, got %v", salon.ID, own.SalonID)
+	}
+	other := factory.NewTypeOfService(t, db)
+	if other.SalonID != domain.DefaultSalonID {
+		t.Errorf("expected the default salon, got %v", other.SalonID)
+	}
+
+	if _, err := services.GetTypeOfServiceByID(roma, other.ID); err != domain.ErrDataNotFound {
+		t.Errorf("expected ErrDataNotFound for a type of service of another salon, got %v", err)
+	}
+	if err := services.DeleteTypeOfService(harajuku, own.ID); err != domain.ErrDataNotFound {
+		t.Errorf("expected ErrDataNotFound deleting from another salon, got %v", err)
+	}
+
+	listed, err := services.ListTypeOfServices(roma, port.TypeOfServiceFilter{Limit: 100})
+	if err != nil {
+		t.Fatalf("failed to list types of service: %v", err)
+	}
+	if len(listed) != 1 || listed[0].ID != own.ID {
+		t.Errorf("expected only the type of service of the salon, got %v", listed)
+	}
+
+	// Users are scoped too, while the emails stay unique across salons
+	users := repository.NewUserRepository(db)
+	client := factory.NewUser(t, db, func(u *domain.User) { u.SalonID = salon.ID })
+
+	if _, err := users.GetUserByID(harajuku, client.ID); err != domain.ErrDataNotFound {
+		t.Errorf("expected ErrDataNotFound for a user of another salon, got %v", err)
+	}
+	if _, err := users.GetUserByEmail(context.Background(), client.Email); err != nil {
+		t.Errorf("expected the user to be found outside of a salon, got %v", err)
+	}
+	if _, err := users.CreateUser(harajuku, factory.User(func(u *domain.User) { u.Email = client.Email })); err != domain.ErrConflictingData {
+		t.Errorf("expected ErrConflictingData for an email taken in another salon, got %v", err)
+	}
+
+	// Coupon codes are only unique within a salon, and a code finds the coupon of the salon
+	coupons := repository.NewCouponRepository(db)
+	shared := factory.NewCoupon(t, db)
+
+	romaCoupon, err := coupons.CreateCoupon(roma, factory.Coupon(func(c *domain.Coupon) { c.Code = shared.Code }))
+	if err != nil {
+		t.Fatalf("failed to create a coupon with a code of another salon: %v", err)
+	}
+	if _, err := coupons.CreateCoupon(roma, factory.Coupon(func(c *domain.Coupon) { c.Code = shared.Code })); err != domain.ErrConflictingData {
+		t.Errorf("expected ErrConflictingData for a code taken in the salon, got %v", err)
+	}
+
+	found, err := coupons.GetCouponByCode(roma, shared.Code)
+	if err != nil {
+		t.Fatalf("failed to get coupon by code: %v", err)
+	}
+	if found.ID != romaCoupon.ID {
+		t.Errorf("expected the coupon of the salon, got %v", found.ID)
+	}
+	if _, err := coupons.GetCouponByID(roma, shared.ID); err != domain.ErrDataNotFound {
+		t.Errorf("expected ErrDataNotFound for a coupon of another salon, got %v", err)
+	}
+
+	// Category names are only unique within a salon
+	categories := repository.NewServiceCategoryRepository(db)
+	if _, err := categories.CreateServiceCategory(harajuku, &domain.ServiceCategory{ID: uuid.New(), Name: "Color"}); err != nil {
+		t.Fatalf("failed to create service category: %v", err)
+	}
+	if _, err := categories.CreateServiceCategory(roma, &domain.ServiceCategory{ID: uuid.New(), Name: "Color"}); err != nil {
+		t.Fatalf("failed to create a service category with a name of another salon: %v", err)
+	}
+	if _, err := categories.CreateServiceCategory(roma, &domain.ServiceCategory{ID: uuid.New(), Name: "Color"}); err != domain.ErrConflictingData {
+		t.Errorf("expected ErrConflictingData for a name taken in the salon, got %v", err)
+	}
+
+	// Events are only posted to the webhooks of the salon they happened in
+	webhooks := repository.NewWebhookRepository(db)
+	now := time.Now()
+	romaWebhook, err := webhooks.CreateWebhook(roma, &domain.Webhook{ID: uuid.New(), URL: "https://roma.example.com/hooks", Secret: "whsec_roma", EventTypes: []domain.EventType{domain.EventQuoteCreated}, Active: true, CreatedAt: now, UpdatedAt: now})
+	if err != nil {
+		t.Fatalf("failed to create webhook: %v", err)
+	}
+	if _, err := webhooks.CreateWebhook(harajuku, &domain.Webhook{ID: uuid.New(), URL: "https://harajuku.example.com/hooks", Secret: "whsec_harajuku", EventTypes: []domain.EventType{domain.EventQuoteCreated}, Active: true, CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("failed to create webhook: %v", err)
+	}
+
+	active, err := webhooks.ListActiveWebhooks(roma, domain.EventQuoteCreated)
+	if err != nil {
+		t.Fatalf("failed to list active webhooks: %v", err)
+	}
+	if len(active) != 1 || active[0].ID != romaWebhook.ID {
+		t.Errorf("expected only the webhook of the salon, got %v", active)
+	}
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"harajuku/backend/internal/adapter/storage/postgres/repository"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/test/factory"
+	"testing"
+)
+
+func TestCreateStaffIntegration(t *testing.T) {
+	db := fixture.Tx(t)
+	ctx := context.Background()
+
+	repo := repository.NewStaffRepository(db)
+	staff := factory.NewStaff(t, db)
+
+	retrieved, err := repo.GetStaffByUserID(ctx, staff.UserID)
+	if err != nil {
+		t.Fatalf("failed to get staff member by user ID: %v", err)
+	}
+	if retrieved.ID != staff.ID {
+		t.Errorf("expected staff member %v, got %v", staff.ID, retrieved.ID)
+	}
+	if len(retrieved.Specialties) != 2 {
+		t.Errorf("expected 2 specialties, got %v", retrieved.Specialties)
+	}
+
+	// An admin has a single profile
+	_, err = repo.CreateStaff(ctx, factory.Staff(func(s *domain.Staff) { s.UserID = staff.UserID }))
+	if err != domain.ErrConflictingData {
+		t.Errorf("expected ErrConflictingData for a second profile, got %v", err)
+	}
+}
+
+func TestStaffOfferingsIntegration(t *testing.T) {
+	db := fixture.Tx(t)
+	ctx := context.Background()
+
+	repo := repository.NewStaffRepository(db)
+	staff := factory.NewStaff(t, db)
+	factory.NewStaff(t, db, func(s *domain.Staff) { s.Specialties = []string{"uñas"} })
+	service := factory.NewTypeOfService(t, db)
+
+	_, err := repository.NewServiceOfferingRepository(db).CreateServiceOffering(ctx, &domain.ServiceOffering{
+		AdminID:         staff.UserID,
+		TypeOfServiceID: service.ID,
+	})
+	if err != nil {
+		t.Fatalf("failed to create service offering: %v", err)
+	}
+
+	// The types of service of a staff member are the ones its admin offers
+	retrieved, err := repo.GetStaffByID(ctx, staff.ID)
+	if err != nil {
+		t.Fatalf("failed to get staff member: %v", err)
+	}
+	if len(retrieved.TypeOfServiceIDs) != 1 || retrieved.TypeOfServiceIDs[0] != service.ID {
+		t.Errorf("expected type of service %v, got %v", service.ID, retrieved.TypeOfServiceIDs)
+	}
+
+	offering, err := repo.ListStaff(ctx, port.StaffFilter{TypeOfServiceID: &service.ID, Limit: 10})
+	if err != nil {
+		t.Fatalf("failed to list staff: %v", err)
+	}
+	if len(offering) != 1 || offering[0].ID != staff.ID {
+		t.Errorf("expected only %v to offer the type of service, got %v", staff.ID, offering)
+	}
+
+	colorists, err := repo.ListStaff(ctx, port.StaffFilter{Specialty: "balayage", Limit: 10})
+	if err != nil {
+		t.Fatalf("failed to list staff: %v", err)
+	}
+	if len(colorists) != 1 || colorists[0].ID != staff.ID {
+		t.Errorf("expected only %v to have the specialty, got %v", staff.ID, colorists)
+	}
+}
@@ -2,154 +2,48 @@ package repository
 
 import (
 	"context"
-	"harajuku/backend/internal/adapter/config"
-	"harajuku/backend/internal/adapter/storage/postgres"
 	"harajuku/backend/internal/adapter/storage/postgres/repository"
 	"harajuku/backend/internal/core/domain"
-	"harajuku/backend/test/adapter/storage/postgres/helpers"
+	"harajuku/backend/test/factory"
 	"testing"
-
-	"github.com/google/uuid"
 )
 
-const PORT = "5435"
-
 func TestCreateUsersIntegration(t *testing.T) {
-	testContainer := helpers.SetupTestDB(t)
-	defer testContainer.Teardown()
-
+	db := fixture.Tx(t)
 	ctx := context.Background()
 
-	cfg := &config.DB{
-		Connection: "postgres",
-		User:       "user",
-		Password:   "secret",
-		Host:       "localhost",
-		Port:       PORT,
-		Name:       "testdb",
-	}
-
-	// overwrite the db URL to point to container
-	db, err := postgres.New(ctx, cfg)
-	if err != nil {
-		t.Fatalf("failed to connect to test db: %v", err)
-	}
-
-	// apply migrations
-	err = db.Migrate()
-	if err != nil {
-		t.Fatalf("failed to run migrations: %v", err)
-	}
-
 	repo := repository.NewUserRepository(db)
 
 	// insert test data
-	users_arr := []domain.User{
-		{
-			ID:       uuid.New(),
-			Name:     "Ramses",
-			Email:    "ramses.hdz30@gmail.com",
-			LastName: "Mata",
-		},
-		{
-			ID:       uuid.New(),
-			Name:     "Mariana",
-			Email:    "m.mata@gmail.com",
-			LastName: "Mata",
-		},
+	users_arr := []*domain.User{
+		factory.User(func(u *domain.User) { u.Name, u.LastName, u.Email = "Ramses", "Mata", "ramses.hdz30@gmail.com" }),
+		factory.User(func(u *domain.User) { u.Name, u.LastName, u.Email = "Mariana", "Mata", "m.mata@gmail.com" }),
 	}
 
 	for _, a := range users_arr {
-		_, err := repo.CreateUser(ctx, &a)
+		_, err := repo.CreateUser(ctx, a)
 		if err != nil {
 			t.Fatalf("failed to crate user: %v", err)
 		}
 	}
+
+	// The email is unique
+	_, err := repo.CreateUser(ctx, factory.User(func(u *domain.User) { u.Email = "ramses.hdz30@gmail.com" }))
+	if err != domain.ErrConflictingData {
+		t.Fatalf("expected conflicting data for a duplicated email, got %v", err)
+	}
 }
 
 func TestListUsersIntegration(t *testing.T) {
-	testContainer := helpers.SetupTestDB(t)
-	defer testContainer.Teardown()
-
+	db := fixture.Tx(t)
 	ctx := context.Background()
 
-	cfg := &config.DB{
-		Connection: "postgres",
-		User:       "user",
-		Password:   "secret",
-		Host:       "localhost",
-		Port:       PORT,
-		Name:       "testdb",
-	}
-
-	// overwrite the db URL to point to container
-	db, err := postgres.New(ctx, cfg)
-	if err != nil {
-		t.Fatalf("failed to connect to test db: %v", err)
-	}
-
-	// apply migrations
-	err = db.Migrate()
-	if err != nil {
-		t.Fatalf("failed to run migrations: %v", err)
-	}
-
-	// Verify migrations were applied
-	var exists bool
-	err = db.Pool.QueryRow(ctx,
-		`SELECT EXISTS(SELECT 1 FROM information_schema.tables WHERE table_name = 'users')`).Scan(&exists)
-	if err != nil || !exists {
-		t.Fatalf("migrations verification failed: %v (exists: %v)", err, exists)
-	}
-
 	repo := repository.NewUserRepository(db)
 
-	tx, err := db.Pool.Begin(ctx)
-
-	if err != nil {
-		t.Fatalf("failed to begin transaction: %v", err)
-	}
-
-	defer tx.Rollback(ctx) // Safe rollback if commit fails
-
-	// insert test data
-	users_arr := []domain.User{
-		{
-			ID:       uuid.New(),
-			Name:     "Ramses",
-			Email:    "ramses.hdz30@gmail.com",
-			LastName: "Mata",
-		},
-		{
-			ID:       uuid.New(),
-			Name:     "Mariana",
-			Email:    "m.mata@gmail.com",
-			LastName: "Mata",
-		},
-	}
-
-	for _, a := range users_arr {
-		_, err := repo.CreateUser(ctx, &a)
-		if err != nil {
-			t.Fatalf("failed to crate user: %v", err)
-		}
-	}
-
-	// Commit the test data
-	if err := tx.Commit(ctx); err != nil {
-		t.Fatalf("failed to commit test data: %v", err)
-	}
+	factory.NewUser(t, db, func(u *domain.User) { u.Name = "Ramses"; u.LastName = "Mata" })
+	factory.NewUser(t, db, func(u *domain.User) { u.Name = "Mariana"; u.LastName = "Mata" })
 
-	// Verify data exists
-	var count int
-	err = db.Pool.QueryRow(ctx, "SELECT COUNT(*) FROM users").Scan(&count)
-	if err != nil || count != 2 {
-		t.Fatalf("data verification failed: %v (count: %d)", err, count)
-	}
-
-	// Now test ListUsers
-	filters := domain.UserFilters{}
-	result, err := repo.ListUsers(ctx, 0, 10, filters) // Skip=0 to get first page
+	result, err := repo.ListUsers(ctx, 1, 10, domain.UserFilters{})
 	if err != nil {
 		t.Fatalf("failed to list users: %v", err)
 	}
@@ -161,90 +55,20 @@ func TestListUsersIntegration(t *testing.T) {
 }
 
 func TestListUsersIntegrationAndCheckFilters(t *testing.T) {
-	testContainer := helpers.SetupTestDB(t)
-	defer testContainer.Teardown()
-
+	db := fixture.Tx(t)
 	ctx := context.Background()
 
-	cfg := &config.DB{
-		Connection: "postgres",
-		User:       "user",
-		Password:   "secret",
-		Host:       "localhost",
-		Port:       PORT,
-		Name:       "testdb",
-	}
-
-	// overwrite the db URL to point to container
-	db, err := postgres.New(ctx, cfg)
-	if err != nil {
-		t.Fatalf("failed to connect to test db: %v", err)
-	}
-
-	// apply migrations
-	err = db.Migrate()
-	if err != nil {
-		t.Fatalf("failed to run migrations: %v", err)
-	}
-
-	// Verify migrations were applied
-	var exists bool
-	err = db.Pool.QueryRow(ctx,
-		`SELECT EXISTS(SELECT 1 FROM information_schema.tables WHERE table_name = 'users')`).Scan(&exists)
-	if err != nil || !exists {
-		t.Fatalf("migrations verification failed: %v (exists: %v)", err, exists)
-	}
-
 	repo := repository.NewUserRepository(db)
 
-	tx, err := db.Pool.Begin(ctx)
-
-	if err != nil {
-		t.Fatalf("failed to begin transaction: %v", err)
-	}
-
-	defer tx.Rollback(ctx) // Safe rollback if commit fails
-
-	// insert test data
-	users_arr := []domain.User{
-		{
-			ID:       uuid.New(),
-			Name:     "Ramses",
-			Email:    "ramses.hdz30@gmail.com",
-			LastName: "Mata",
-		},
-		{
-			ID:       uuid.New(),
-			Name:     "Mariana",
-			Email:    "m.mata@gmail.com",
-			LastName: "Mata",
-		},
-	}
-
-	for _, a := range users_arr {
-		_, err := repo.CreateUser(ctx, &a)
-		if err != nil {
-			t.Fatalf("failed to crate user: %v", err)
-		}
-	}
-
-	// Commit the test data
-	if err := tx.Commit(ctx); err != nil {
-		t.Fatalf("failed to commit test data: %v", err)
-	}
-
-	// Verify data exists
-	var count int
-	err = db.Pool.QueryRow(ctx, "SELECT COUNT(*) FROM users").Scan(&count)
-	if err != nil || count != 2 {
-		t.Fatalf("data verification failed: %v (count: %d)", err, count)
-	}
+	factory.NewUser(t, db, func(u *domain.User) { u.Name = "Ramses"; u.LastName = "Mata" })
+	factory.NewUser(t, db, func(u *domain.User) { u.Name = "Mariana"; u.LastName = "Mata" })
+	factory.NewAdmin(t, db, func(u *domain.User) { u.Name = "Juan"; u.LastName = "Pérez" })
 
-	// Test 1: Empty filters should return all users (expecting 2 users)
+	// Test 1: Empty filters should return all users
 	filters := domain.UserFilters{}
 	result, err := repo.ListUsers(ctx, 1, 10, filters)
-	if err != nil || len(result) != 2 {
-		t.Fatalf("Empty filter test failed: expected 2 users, got %d, error: %v", len(result), err)
+	if err != nil || len(result) != 3 {
+		t.Fatalf("Empty filter test failed: expected 3 users, got %d, error: %v", len(result), err)
 	}
 
 	filters = domain.UserFilters{Name: "Ram"}
@@ -253,23 +77,46 @@ func TestListUsersIntegrationAndCheckFilters(t *testing.T) {
 		t.Fatalf("Partial name filter test failed: expected Ramses, got %+v, error: %v", result, err)
 	}
 
-	filters = domain.UserFilters{Name: "Mar"}
+	filters = domain.UserFilters{Name: "mar"}
 	result, err = repo.ListUsers(ctx, 1, 10, filters)
 	if err != nil || len(result) != 1 || result[0].Name != "Mariana" {
 		t.Fatalf("Case-insensitive filter test failed: expected Mariana, got %+v, error: %v", result, err)
 	}
 
-	// Test 4: Filtering by role ("user" should return Bob and Charlie)
-	filters = domain.UserFilters{Role: "client"}
+	// Test 4: Filtering by role
+	filters = domain.UserFilters{Role: domain.Client}
 	result, err = repo.ListUsers(ctx, 1, 10, filters)
 	if err != nil || len(result) != 2 {
 		t.Fatalf("Role filter test failed: expected 2 users, got %d, error: %v", len(result), err)
 	}
 
-	// Test 5: Combination of filters (filter by last name and role; using "own" and "user" to match "Charlie Brown")
-	filters = domain.UserFilters{LastName: "Mata", Role: "client"}
+	// Test 5: Combination of filters
+	filters = domain.UserFilters{LastName: "Mata", Role: domain.Admin}
 	result, err = repo.ListUsers(ctx, 1, 10, filters)
-	if err != nil || len(result) != 2 {
-		t.Fatalf("Role filter test failed: expected 2 users, got %d, error: %v", len(result), err)
+	if err != nil || len(result) != 0 {
+		t.Fatalf("Combined filter test failed: expected no users, got %d, error: %v", len(result), err)
+	}
+
+	count, err := repo.CountUsers(ctx, domain.UserFilters{LastName: "Mata"})
+	if err != nil || count != 2 {
+		t.Fatalf("Count test failed: expected 2 users, got %d, error: %v", count, err)
+	}
+}
+
+func TestUpdateUserIntegrationKeepsEmptyFields(t *testing.T) {
+	db := fixture.Tx(t)
+	ctx := context.Background()
+
+	repo := repository.NewUserRepository(db)
+	user := factory.NewUser(t, db, func(u *domain.User) { u.Name = "Ramses"; u.LastName = "Mata"; u.SecondLastName = "Hernández" })
+
+	// Only the last name changes, the rest keeps its value
+	updated, err := repo.UpdateUser(ctx, &domain.User{ID: user.ID, LastName: "Ortiz"})
+	if err != nil {
+		t.Fatalf("failed to update user: %v", err)
+	}
+
+	if updated.Name != "Ramses" || updated.LastName != "Ortiz" || updated.SecondLastName != "Hernández" || updated.Email != user.Email {
+		t.Errorf("expected only the last name to change, got %+v", updated)
 	}
 }
@@ -0,0 +1,91 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/adapter/storage/redis"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/util"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+func TestJobQueueSalonScope(t *testing.T) {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	ctx := context.Background()
+
+	addr, err := startRedis(ctx, t)
+	require.NoError(t, err)
+	queue, err := redis.NewJobQueue(ctx, &config.Redis{Addr: addr})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = queue.Close() })
+
+	salon, other := uuid.New(), uuid.New()
+	ours := &domain.Job{ID: uuid.New(), Type: domain.JobNotify, NextAttemptAt: time.Now(), SalonID: salon}
+	theirs := &domain.Job{ID: uuid.New(), Type: domain.JobNotify, NextAttemptAt: time.Now(), SalonID: other}
+	for _, job := range []*domain.Job{ours, theirs} {
+		require.NoError(t, queue.Enqueue(ctx, job))
+		require.NoError(t, queue.DeadLetter(ctx, job))
+	}
+
+	inSalon := util.WithSalon(ctx, salon)
+
+	queued, err := queue.ListQueued(inSalon, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, queued, 1)
+	assert.Equal(t, ours.ID, queued[0].ID)
+
+	dead, err := queue.ListDeadLetters(inSalon, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, ours.ID, dead[0].ID)
+
+	_, err = queue.GetQueued(inSalon, theirs.ID)
+	assert.Equal(t, domain.ErrDataNotFound, err)
+	_, err = queue.RemoveQueued(inSalon, theirs.ID)
+	assert.Equal(t, domain.ErrDataNotFound, err)
+	_, err = queue.RemoveDeadLetter(inSalon, theirs.ID)
+	assert.Equal(t, domain.ErrDataNotFound, err)
+
+	// Outside of a salon every job is reached
+	all, err := queue.ListDeadLetters(ctx, 1, 10)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+}
+
+func TestEmailQueueSalonScope(t *testing.T) {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	ctx := context.Background()
+
+	addr, err := startRedis(ctx, t)
+	require.NoError(t, err)
+	queue, err := redis.NewEmailQueue(ctx, &config.Redis{Addr: addr})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = queue.Close() })
+
+	salon := uuid.New()
+	ours := &domain.QueuedEmail{ID: uuid.New(), SalonID: salon}
+	theirs := &domain.QueuedEmail{ID: uuid.New(), SalonID: uuid.New()}
+	require.NoError(t, queue.DeadLetter(ctx, ours))
+	require.NoError(t, queue.DeadLetter(ctx, theirs))
+
+	inSalon := util.WithSalon(ctx, salon)
+
+	dead, err := queue.ListDeadLetters(inSalon, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, ours.ID, dead[0].ID)
+
+	_, err = queue.RemoveDeadLetter(inSalon, theirs.ID)
+	assert.Equal(t, domain.ErrDataNotFound, err)
+
+	removed, err := queue.RemoveDeadLetter(inSalon, ours.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ours.ID, removed.ID)
+}
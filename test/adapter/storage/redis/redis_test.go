@@ -0,0 +1,151 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/adapter/storage/redis"
+	"harajuku/backend/internal/core/port"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// startRedis starts a redis container and returns its address, testcontainers panics
+// when there is no docker so that is reported as an error too
+func startRedis(ctx context.Context, tb testing.TB) (addr string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "redis:7-alpine",
+			ExposedPorts: []string{"6379/tcp"},
+			WaitingFor:   wait.ForLog("Ready to accept connections").WithStartupTimeout(60 * time.Second),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return "", err
+	}
+	tb.Cleanup(func() { _ = container.Terminate(ctx) })
+
+	return container.PortEndpoint(ctx, "6379/tcp", "")
+}
+
+// setupRedis returns the cache adapter and a raw client to the same container
+func setupRedis(ctx context.Context, tb testing.TB, addr string) (port.CacheRepository, *goredis.Client) {
+	cache, err := redis.New(ctx, &config.Redis{Addr: addr})
+	if err != nil {
+		tb.Fatalf("failed to connect to redis: %v", err)
+	}
+	tb.Cleanup(func() { _ = cache.Close() })
+
+	client := goredis.NewClient(&goredis.Options{Addr: addr})
+	tb.Cleanup(func() { _ = client.Close() })
+
+	return cache, client
+}
+
+// seed stores n keys with the prefix
+func seed(ctx context.Context, tb testing.TB, client *goredis.Client, prefix string, n int) {
+	pipe := client.Pipeline()
+	for i := 0; i < n; i++ {
+		pipe.Set(ctx, fmt.Sprintf("%s:%d-10", prefix, i), "[]", 0)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		tb.Fatalf("failed to seed redis: %v", err)
+	}
+}
+
+func TestDeleteByPrefix(t *testing.T) {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	ctx := context.Background()
+
+	addr, err := startRedis(ctx, t)
+	if err != nil {
+		t.Fatalf("failed to start container: %v", err)
+	}
+	cache, client := setupRedis(ctx, t, addr)
+
+	seed(ctx, t, client, "quotes", 1200)
+	seed(ctx, t, client, "quote", 10)
+
+	if err := cache.DeleteByPrefix(ctx, "quotes:*"); err != nil {
+		t.Fatalf("DeleteByPrefix failed: %v", err)
+	}
+
+	keys, err := client.Keys(ctx, "*").Result()
+	if err != nil {
+		t.Fatalf("failed to list keys: %v", err)
+	}
+	if len(keys) != 10 {
+		t.Fatalf("expected only the 10 quote keys to be left, got %d", len(keys))
+	}
+}
+
+// deleteByPrefixOneByOne is how DeleteByPrefix used to work, one DEL round trip per key
+func deleteByPrefixOneByOne(ctx context.Context, client *goredis.Client, prefix string) error {
+	var cursor uint64
+	for {
+		keys, next, err := client.Scan(ctx, cursor, prefix, 100).Result()
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := client.Del(ctx, key).Err(); err != nil {
+				return err
+			}
+		}
+		if cursor = next; cursor == 0 {
+			return nil
+		}
+	}
+}
+
+// BenchmarkDeleteByPrefix compares the pipelined UNLINK of DeleteByPrefix with deleting key by key:
+//
+//	go test ./test/adapter/storage/redis -run '^$' -bench DeleteByPrefix
+func BenchmarkDeleteByPrefix(b *testing.B) {
+	ctx := context.Background()
+
+	addr, err := startRedis(ctx, b)
+	if err != nil {
+		b.Skipf("redis container not available: %v", err)
+	}
+	cache, client := setupRedis(ctx, b, addr)
+
+	// Keys of other entities that the scan has to walk past
+	seed(ctx, b, client, "quote", 10000)
+
+	b.Run("OneByOne", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			seed(ctx, b, client, "quotes", 2000)
+			b.StartTimer()
+
+			if err := deleteByPrefixOneByOne(ctx, client, "quotes:*"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("PipelinedUnlink", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			seed(ctx, b, client, "quotes", 2000)
+			b.StartTimer()
+
+			if err := cache.DeleteByPrefix(ctx, "quotes:*"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/test/e2e/helpers"
+	"harajuku/backend/test/factory"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type coupon struct {
+	ID          uuid.UUID `json:"id"`
+	Code        string    `json:"code"`
+	Redemptions int       `json:"redemptions"`
+}
+
+type quoteWithCoupon struct {
+	ID     uuid.UUID `json:"id"`
+	Total  float64   `json:"total"`
+	Coupon *struct {
+		CouponID uuid.UUID `json:"couponId"`
+		Amount   float64   `json:"amount"`
+	} `json:"coupon"`
+}
+
+func TestClientsApplyCouponsToTheirQuotes(t *testing.T) {
+	h := env.New(t)
+	admin := h.AsAdmin(t)
+	client := h.AsClient(t)
+
+	code := "VERANO" + uuid.NewString()[:8]
+	rec := admin.JSON(t, http.MethodPost, "/v1/coupons", map[string]any{
+		"code":                    code,
+		"discountType":            "percentage",
+		"value":                   20,
+		"startsAt":                "2020-01-01T00:00:00Z",
+		"endsAt":                  "2099-01-01T00:00:00Z",
+		"maxRedemptionsPerClient": 1,
+	})
+	created := helpers.Decode[coupon](t, rec, http.StatusOK)
+
+	// Coupon codes are only seen by admins
+	rec = client.Get(t, "/v1/coupons/all?limit=10")
+	assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	first := factory.NewQuote(t, h.DB, func(q *domain.Quote) {
+		q.ClientID = client.User.ID
+		q.Price = 1000
+	})
+	second := factory.NewQuote(t, h.DB, func(q *domain.Quote) { q.ClientID = client.User.ID })
+
+	// The code is typed in any case
+	rec = client.JSON(t, http.MethodPut, "/v1/quotes/"+first.ID.String()+"/coupon", map[string]string{"code": "verano" + code[6:]})
+	applied := helpers.Decode[quoteWithCoupon](t, rec, http.StatusOK)
+	require.NotNil(t, applied.Coupon)
+	assert.Equal(t, created.ID, applied.Coupon.CouponID)
+	assert.Equal(t, 200.0, applied.Coupon.Amount)
+	assert.Equal(t, 800.0, applied.Total)
+
+	// The client used its only redemption
+	rec = client.JSON(t, http.MethodPut, "/v1/quotes/"+second.ID.String()+"/coupon", map[string]string{"code": code})
+	assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+
+	// Repricing the quote reprices its coupon
+	rec = admin.JSON(t, http.MethodPut, "/v1/quotes/"+first.ID.String(), map[string]any{"price": 1500})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	rec = client.Get(t, "/v1/quotes/"+first.ID.String())
+	repriced := helpers.Decode[quoteWithCoupon](t, rec, http.StatusOK)
+	require.NotNil(t, repriced.Coupon)
+	assert.Equal(t, 300.0, repriced.Coupon.Amount)
+
+	rec = admin.Get(t, "/v1/coupons?id="+created.ID.String())
+	assert.Equal(t, 1, helpers.Decode[coupon](t, rec, http.StatusOK).Redemptions)
+
+	// Removing it gives the use back
+	rec = client.JSON(t, http.MethodDelete, "/v1/quotes/"+first.ID.String()+"/coupon", nil)
+	removed := helpers.Decode[quoteWithCoupon](t, rec, http.StatusOK)
+	assert.Nil(t, removed.Coupon)
+
+	rec = client.JSON(t, http.MethodPut, "/v1/quotes/"+second.ID.String()+"/coupon", map[string]string{"code": code})
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// Unknown codes are not found
+	rec = client.JSON(t, http.MethodPut, "/v1/quotes/"+first.ID.String()+"/coupon", map[string]string{"code": "NOEXISTE"})
+	assert.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+}
@@ -0,0 +1,197 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/util"
+	"harajuku/backend/test/factory"
+)
+
+// Client sends requests to the router of a harness, authenticated as its user when it has one
+type Client struct {
+	// User is nil for anonymous clients
+	User *domain.User
+
+	h       *Harness
+	token   string
+	headers map[string]string
+}
+
+// As returns a client authenticated as user, who must be stored
+func (h *Harness) As(t *testing.T, user *domain.User) *Client {
+	t.Helper()
+
+	token, err := h.token.CreateToken(user)
+	if err != nil {
+		t.Fatalf("failed to create a token for %s: %v", user.Email, err)
+	}
+
+	return &Client{User: user, h: h, token: token}
+}
+
+// AsRole stores a new user with role and returns a client authenticated as them, the overrides are
+// applied to the user built by the factory package before it is stored
+func (h *Harness) AsRole(t *testing.T, role domain.UserRole, overrides ...func(*domain.User)) *Client {
+	t.Helper()
+
+	// The password is hashed like on registration, so the user can log in with factory.Password too
+	hashed, err := util.HashPassword(factory.Password)
+	if err != nil {
+		t.Fatalf("failed to hash the password: %v", err)
+	}
+
+	user := factory.NewUser(t, h.DB, append([]func(*domain.User){factory.WithRole(role), func(u *domain.User) {
+		u.Password = hashed
+	}}, overrides...)...)
+
+	return h.As(t, user)
+}
+
+// AsClient stores a new client and returns a client authenticated as them
+func (h *Harness) AsClient(t *testing.T, overrides ...func(*domain.User)) *Client {
+	t.Helper()
+
+	return h.AsRole(t, domain.Client, overrides...)
+}
+
+// AsAdmin stores a new admin and returns a client authenticated as them
+func (h *Harness) AsAdmin(t *testing.T, overrides ...func(*domain.User)) *Client {
+	t.Helper()
+
+	return h.AsRole(t, domain.Admin, overrides...)
+}
+
+// Anonymous returns a client sending requests without a token
+func (h *Harness) Anonymous() *Client {
+	return &Client{h: h}
+}
+
+// WithHeader returns a copy of the client sending the header with every request
+func (c *Client) WithHeader(key, value string) *Client {
+	headers := map[string]string{key: value}
+	for k, v := range c.headers {
+		if k != key {
+			headers[k] = v
+		}
+	}
+
+	client := *c
+	client.headers = headers
+	return &client
+}
+
+// Do sends a request with body to the router and returns the recorded response
+func (c *Client) Do(t *testing.T, method, path, contentType string, body io.Reader) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, body)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	for key, value := range c.headers {
+		req.Header.Set(key, value)
+	}
+
+	rec := httptest.NewRecorder()
+	c.h.Router.ServeHTTP(rec, req)
+	return rec
+}
+
+// Get sends a GET request
+func (c *Client) Get(t *testing.T, path string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	return c.Do(t, http.MethodGet, path, "", nil)
+}
+
+// JSON sends a request with body encoded as JSON, a nil body sends none
+func (c *Client) JSON(t *testing.T, method, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	if body == nil {
+		return c.Do(t, method, path, "", nil)
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("failed to encode the body of %s %s: %v", method, path, err)
+	}
+
+	return c.Do(t, method, path, "application/json", bytes.NewReader(data))
+}
+
+// Upload posts a multipart form with fields and file as its file field
+func (c *Client) Upload(t *testing.T, path string, fields map[string]string, fileName string, file []byte) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	for name, value := range fields {
+		if err := form.WriteField(name, value); err != nil {
+			t.Fatalf("failed to write the %s field: %v", name, err)
+		}
+	}
+
+	part, err := form.CreateFormFile("file", fileName)
+	if err == nil {
+		_, err = part.Write(file)
+	}
+	if err == nil {
+		err = form.Close()
+	}
+	if err != nil {
+		t.Fatalf("failed to write the file of %s: %v", path, err)
+	}
+
+	return c.Do(t, http.MethodPost, path, form.FormDataContentType(), &body)
+}
+
+// Decode checks the status of rec and returns the data of its body as a T
+func Decode[T any](t *testing.T, rec *httptest.ResponseRecorder, status int) T {
+	t.Helper()
+
+	if rec.Code != status {
+		t.Fatalf("expected status %d, got %d: %s", status, rec.Code, rec.Body.String())
+	}
+
+	var rsp struct {
+		Data T `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &rsp); err != nil {
+		t.Fatalf("failed to decode the response: %v: %s", err, rec.Body.String())
+	}
+
+	return rsp.Data
+}
+
+// PNG returns a small PNG image to upload
+func PNG(t *testing.T) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	for x := 0; x < 16; x++ {
+		for y := 0; y < 16; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 16), G: uint8(y * 16), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode the image: %v", err)
+	}
+
+	return buf.Bytes()
+}
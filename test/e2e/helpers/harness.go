@@ -0,0 +1,297 @@
+package helpers
+
+import (
+	"context"
+	"expvar"
+	"flag"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	paseto "harajuku/backend/internal/adapter/auth"
+	"harajuku/backend/internal/adapter/communication/email"
+	"harajuku/backend/internal/adapter/communication/inbox"
+	"harajuku/backend/internal/adapter/communication/webhook"
+	"harajuku/backend/internal/adapter/config"
+	"harajuku/backend/internal/adapter/errorreport/sentry"
+	"harajuku/backend/internal/adapter/event"
+	"harajuku/backend/internal/adapter/handler/http"
+	"harajuku/backend/internal/adapter/logger"
+	"harajuku/backend/internal/adapter/payment"
+	"harajuku/backend/internal/adapter/storage/cache"
+	"harajuku/backend/internal/adapter/storage/memory"
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/adapter/storage/postgres/repository"
+	"harajuku/backend/internal/adapter/storage/redis"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/internal/core/port"
+	"harajuku/backend/internal/core/service"
+	pghelpers "harajuku/backend/test/adapter/storage/postgres/helpers"
+
+	"github.com/google/uuid"
+)
+
+// Env is the postgres and redis shared by the end to end tests of a package. Unlike the repository
+// tests nothing is rolled back, the tests keep apart by working on the rows they create
+type Env struct {
+	db    *pghelpers.Fixture
+	redis *TestRedisContainer
+}
+
+// Run is meant to be called from TestMain, it starts the containers, runs the tests of the package and
+// returns the exit code. With -short the containers aren't started and the end to end tests are skipped
+func Run(m *testing.M, env **Env) int {
+	flag.Parse()
+
+	if !testing.Short() {
+		ctx := context.Background()
+
+		db, err := pghelpers.NewFixture(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to set up the test database: %v\n", err)
+			return 1
+		}
+		defer db.Close()
+
+		redis, err := startTestRedis(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to set up the test redis: %v\n", err)
+			return 1
+		}
+		defer redis.Teardown()
+
+		*env = &Env{db: db, redis: redis}
+	}
+
+	return m.Run()
+}
+
+// Harness is the full HTTP API of a test, wired like the server but keeping the files and the sent emails
+// in memory. The jobs, the email queue and the outbox run in the background until the test ends
+type Harness struct {
+	// DB is the shared database, to arrange what the API has no endpoint for and to assert what it stored
+	DB     *postgres.DB
+	Router *http.Router
+	// Files holds what the API stored, before thumbnailing and scanning
+	Files *memory.FileRepository
+	// Inbox holds the emails delivered by the email queue
+	Inbox *inbox.Inbox
+
+	token port.TokenService
+}
+
+// New builds the router of a test, with its own cache, rate limit and quota namespace
+func (e *Env) New(t *testing.T) *Harness {
+	t.Helper()
+
+	if e == nil {
+		t.Skip("skipping end to end test in short mode")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	db := e.db.DB(t)
+	namespace := fmt.Sprintf("harajuku:e2e-%s:", uuid.New())
+
+	httpConfig := &config.HTTP{
+		Env:                "test",
+		CORSDevMode:        true,
+		CompressionLevel:   6,
+		CompressionMinSize: 1024,
+		MaxBodySize:        1 << 20,
+		MaxUploadSize:      10 << 20,
+		MaxMediaUploadSize: 50 << 20,
+	}
+	redisConfig := &config.Redis{Provider: "redis", Mode: "standalone", Addr: e.redis.Addr}
+
+	token, err := paseto.New(&config.Token{Duration: "15m"})
+	must(t, err, "token service")
+
+	reporter, err := sentry.New(&config.Sentry{})
+	must(t, err, "error reporter")
+
+	redisCache, err := redis.New(ctx, redisConfig)
+	must(t, err, "cache")
+	cacheRepo := cache.NewResilient(cache.NewPolicy(cache.NewMetrics(cache.NewNamespace(redisCache, namespace), new(expvar.Map).Init()), time.Hour, nil))
+
+	var workers sync.WaitGroup
+	runWorker := func(run func(context.Context)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run(ctx)
+		}()
+	}
+	// The workers are stopped before the connections they use are closed
+	t.Cleanup(func() {
+		cancel()
+		workers.Wait()
+		cacheRepo.Close()
+	})
+
+	// Audit trail
+	auditRepo := repository.NewAuditRepository(db)
+	auditHandler := http.NewAuditHandler(service.NewAuditService(auditRepo))
+
+	// Salon
+	salonHandler := http.NewSalonHandler(service.NewSalonService(repository.NewSalonRepository(db), cacheRepo))
+
+	// User
+	userRepo := repository.NewUserRepository(db)
+	userService := service.NewUserService(userRepo, cacheRepo, auditRepo)
+	userHandler := http.NewUserHandler(userService)
+	authHandler := http.NewAuthHandler(service.NewAuthService(userRepo, token))
+
+	// Jobs
+	jobQueueRepo, err := redis.NewJobQueue(ctx, redisConfig)
+	must(t, err, "job queue")
+	t.Cleanup(func() { jobQueueRepo.Close() })
+
+	jobs := service.NewJobQueueService(jobQueueRepo, reporter, 3, 100*time.Millisecond, 50*time.Millisecond, 2)
+	jobQueueHandler := http.NewJobQueueHandler(jobs)
+
+	// File storage, images get their thumbnail from the jobs like on the server
+	files := memory.NewFileRepository()
+	fileStorage := service.NewThumbnailService(files, files, jobs)
+
+	// Email, delivered to the inbox through the queue
+	emailTemplates, err := email.NewTemplateRenderer()
+	must(t, err, "email templates")
+
+	emailInbox := inbox.New()
+	emailQueueRepo, err := redis.NewEmailQueue(ctx, redisConfig)
+	must(t, err, "email queue")
+	t.Cleanup(func() { emailQueueRepo.Close() })
+
+	emailQueue := service.NewEmailQueueService(emailQueueRepo, emailInbox, repository.NewEmailLogRepository(db), 3, 100*time.Millisecond, 50*time.Millisecond)
+	emailQueueHandler := http.NewEmailQueueHandler(emailQueue)
+	runWorker(emailQueue.Run)
+
+	// Catalog
+	serviceCategoryHandler := http.NewServiceCategoryHandler(service.NewServiceCategoryService(repository.NewServiceCategoryRepository(db), cacheRepo))
+
+	typeOfServiceRepo := repository.NewTypeOfServiceRepository(db)
+	serviceOfferingRepo := repository.NewServiceOfferingRepository(db)
+	staffRepo := repository.NewStaffRepository(db)
+	typeOfServiceService := service.NewTypeOfServiceService(typeOfServiceRepo, repository.NewTypeOfServiceImageRepository(db), serviceOfferingRepo, staffRepo, userRepo, fileStorage, cacheRepo, domain.CurrencyMXN, auditRepo)
+	typeOfServiceHandler := http.NewTypeOfServiceHandler(typeOfServiceService, nil)
+	staffHandler := http.NewStaffHandler(service.NewStaffService(staffRepo, userRepo, fileStorage, cacheRepo), nil)
+
+	promotionRepo := repository.NewPromotionRepository(db)
+	promotionHandler := http.NewPromotionHandler(service.NewPromotionService(promotionRepo, cacheRepo))
+	couponRepo := repository.NewCouponRepository(db)
+	couponHandler := http.NewCouponHandler(service.NewCouponService(couponRepo, cacheRepo))
+
+	// Events and notifications, without text messages, WhatsApp or push notifications
+	events := event.New()
+	eventStoreRepo := repository.NewEventStoreRepository(db)
+	eventStoreHandler := http.NewEventStoreHandler(service.NewEventStoreService(eventStoreRepo))
+
+	deviceRepo := repository.NewDeviceRepository(db)
+	deviceHandler := http.NewDeviceHandler(service.NewDeviceService(deviceRepo))
+
+	auditLogHandler := http.NewAuditLogHandler(service.NewAuditLogService(repository.NewAuditLogRepository(db)))
+
+	notificationService := service.NewNotificationService(repository.NewNotificationRepository(db), deviceRepo, userRepo, emailQueue, nil, nil, nil, emailTemplates, jobs)
+	notificationHandler := http.NewNotificationHandler(notificationService, events)
+
+	// Quotes, appointments and payments
+	quoteRepo := repository.NewQuoteRepository(db)
+	quoteImageRepo := repository.NewQuoteImageRepository(db)
+	quoteService := service.NewQuoteService(quoteRepo, fileStorage, userRepo, quoteImageRepo, typeOfServiceRepo, promotionRepo, couponRepo, *db, cacheRepo)
+	quoteHandler := http.NewQuoteHandler(quoteService, nil)
+
+	availabilitySlotRepo := repository.NewAvailabilitySlotRepository(db)
+	appointmentRepo := repository.NewAppointmentRepository(db)
+	availabilitySlotHandler := http.NewAvailabilitySlotHandler(service.NewAvailabilitySlotService(availabilitySlotRepo, appointmentRepo, staffRepo, cacheRepo), userService)
+
+	appointmentService := service.NewAppointmentService(appointmentRepo, quoteRepo, availabilitySlotRepo, serviceOfferingRepo, userRepo, notificationService, events, eventStoreRepo, cacheRepo)
+	appointmentHandler := http.NewAppointmentHandler(appointmentService, userService)
+
+	paymentProofHandler := http.NewPaymentProofHandler(service.NewPaymentProofService(repository.NewPaymentProofRepository(db), fileStorage, quoteRepo, userRepo, *db, cacheRepo), nil)
+	quoteImageHandler := http.NewQuoteImageHandler(service.NewQuoteImageService(quoteImageRepo, fileStorage, quoteRepo, *db, cacheRepo), nil)
+
+	paymentWebhookService := service.NewPaymentWebhookService(repository.NewPaymentEventRepository(db), payment.New(&config.PaymentWebhook{}), quoteRepo, quoteService)
+	paymentWebhookHandler := http.NewPaymentWebhookHandler(paymentWebhookService)
+
+	webhookService := service.NewWebhookService(repository.NewWebhookRepository(db), webhook.New(), events, 3, 100*time.Millisecond)
+	webhookHandler := http.NewWebhookHandler(webhookService)
+	runWorker(webhookService.Run)
+
+	outboxService := service.NewOutboxService(repository.NewOutboxRepository(db), jobs, events, 100*time.Millisecond, 50*time.Millisecond)
+	runWorker(outboxService.Run)
+
+	reportHandler := http.NewReportHandler(service.NewReportService(quoteRepo, appointmentRepo, repository.NewReportRepository(db), userRepo, emailQueue, emailTemplates, jobs))
+
+	// Registered last so every service added its job handlers before the first job is taken
+	runWorker(jobs.Run)
+
+	// Operations
+	healthHandler := http.NewHealthHandler(service.NewHealthService(db, map[string]port.HealthChecker{"postgres": db}, 2*time.Second))
+
+	rateLimiter, err := redis.NewRateLimiter(ctx, redisConfig, namespace)
+	must(t, err, "rate limiter")
+	t.Cleanup(func() { rateLimiter.Close() })
+
+	quotaRepo, err := redis.NewQuotaRepository(ctx, redisConfig, namespace)
+	must(t, err, "quotas")
+	t.Cleanup(func() { quotaRepo.Close() })
+
+	quotaHandler := http.NewQuotaHandler(service.NewQuotaService(quotaRepo, nil))
+	logLevelHandler := http.NewLogLevelHandler(service.NewLogLevelService(logger.Level()))
+
+	// Rate limits and quotas are left unlimited, the tests exercising them set their own
+	router, err := http.NewRouter(
+		httpConfig,
+		token,
+		rateLimiter,
+		http.RateLimits{},
+		reporter,
+		*userHandler,
+		*authHandler,
+		*quoteHandler,
+		*typeOfServiceHandler,
+		*availabilitySlotHandler,
+		*appointmentHandler,
+		*paymentProofHandler,
+		*quoteImageHandler,
+		*paymentWebhookHandler,
+		*serviceCategoryHandler,
+		*promotionHandler,
+		*emailQueueHandler,
+		*notificationHandler,
+		*webhookHandler,
+		*deviceHandler,
+		*healthHandler,
+		*auditLogHandler,
+		*quotaHandler,
+		*logLevelHandler,
+		*jobQueueHandler,
+		*reportHandler,
+		*eventStoreHandler,
+		*auditHandler,
+		*salonHandler,
+		*staffHandler,
+		*couponHandler,
+		*http.NewGraphQLHandler(nil),
+	)
+	must(t, err, "router")
+
+	return &Harness{
+		DB:     db,
+		Router: router,
+		Files:  files,
+		Inbox:  emailInbox,
+		token:  token,
+	}
+}
+
+// must fails the test when building a dependency of the harness failed
+func must(t *testing.T, err error, dependency string) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf("failed to build the %s: %v", dependency, err)
+	}
+}
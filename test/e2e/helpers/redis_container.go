@@ -0,0 +1,50 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+type TestRedisContainer struct {
+	Addr     string
+	Teardown func()
+}
+
+// startTestRedis starts a redis container, testcontainers panics when there is no docker
+// so that is reported as an error too
+func startTestRedis(ctx context.Context) (testContainer *TestRedisContainer, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to start redis container: %v", r)
+		}
+	}()
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "redis:7-alpine",
+			ExposedPorts: []string{"6379/tcp"},
+			WaitingFor:   wait.ForLog("Ready to accept connections").WithStartupTimeout(60 * time.Second),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start redis container: %w", err)
+	}
+
+	addr, err := container.PortEndpoint(ctx, "6379/tcp", "")
+	if err != nil {
+		_ = container.Terminate(ctx)
+		return nil, fmt.Errorf("failed to get the redis address: %w", err)
+	}
+
+	return &TestRedisContainer{
+		Addr: addr,
+		Teardown: func() {
+			_ = container.Terminate(ctx)
+		},
+	}, nil
+}
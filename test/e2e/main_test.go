@@ -0,0 +1,15 @@
+package e2e
+
+import (
+	"os"
+	"testing"
+
+	"harajuku/backend/test/e2e/helpers"
+)
+
+// env is the postgres and redis shared by the tests of the package, each test builds its API with env.New
+var env *helpers.Env
+
+func TestMain(m *testing.M) {
+	os.Exit(helpers.Run(m, &env))
+}
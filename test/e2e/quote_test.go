@@ -0,0 +1,132 @@
+package e2e
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"harajuku/backend/internal/adapter/storage/postgres/repository"
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/test/e2e/helpers"
+	"harajuku/backend/test/factory"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type quote struct {
+	ID       uuid.UUID `json:"id"`
+	ClientID uuid.UUID `json:"clientID"`
+	State    string    `json:"state"`
+}
+
+type slot struct {
+	ID uuid.UUID `json:"id"`
+}
+
+type appointment struct {
+	ID      uuid.UUID                `json:"id"`
+	UserID  uuid.UUID                `json:"userId"`
+	SlotID  uuid.UUID                `json:"slotId"`
+	QuoteID uuid.UUID                `json:"quoteId"`
+	Status  domain.AppointmentStatus `json:"status"`
+}
+
+func TestQuoteToAppointment(t *testing.T) {
+	h := env.New(t)
+	client := h.AsClient(t)
+	admin := h.AsAdmin(t)
+
+	// The type of service is arranged in the database, the offering and the slot go through the API
+	service := factory.NewTypeOfService(t, h.DB)
+	rec := admin.JSON(t, http.MethodPost, "/v1/typesofservice/offerings", map[string]string{
+		"typeOfServiceId": service.ID.String(),
+		"adminId":         admin.User.ID.String(),
+	})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	start := time.Now().UTC().Add(48 * time.Hour).Truncate(time.Hour)
+	rec = admin.JSON(t, http.MethodPost, "/v1/availabilityslots", map[string]string{
+		"startTime": start.Format(time.RFC3339),
+		"endTime":   start.Add(time.Hour).Format(time.RFC3339),
+	})
+	createdSlot := helpers.Decode[slot](t, rec, http.StatusOK)
+
+	// The client asks for a quote with a reference picture
+	rec = client.Upload(t, "/v1/quotes", map[string]string{
+		"typeOfServiceID": service.ID.String(),
+		"description":     "Corte bob con fleco",
+	}, "referencia.png", helpers.PNG(t))
+	createdQuote := helpers.Decode[quote](t, rec, http.StatusOK)
+	assert.Equal(t, client.User.ID, createdQuote.ClientID)
+	assert.Equal(t, string(domain.QuotePending), createdQuote.State)
+
+	// Only an admin approves it
+	rec = client.JSON(t, http.MethodPatch, "/v1/quotes/state?id="+createdQuote.ID.String(), map[string]string{"state": "approved"})
+	assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	rec = admin.JSON(t, http.MethodPatch, "/v1/quotes/state?id="+createdQuote.ID.String(), map[string]string{"state": "approved"})
+	approved := helpers.Decode[quote](t, rec, http.StatusOK)
+	assert.Equal(t, string(domain.QuoteApproved), approved.State)
+
+	// The client pays and uploads the proof
+	rec = client.Upload(t, "/v1/paymentproofs", map[string]string{"quoteId": createdQuote.ID.String()}, "comprobante.png", helpers.PNG(t))
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	// And books the slot
+	rec = client.JSON(t, http.MethodPost, "/v1/appointments", map[string]string{
+		"slotId":  createdSlot.ID.String(),
+		"quoteId": createdQuote.ID.String(),
+	})
+	booked := helpers.Decode[appointment](t, rec, http.StatusOK)
+	assert.Equal(t, client.User.ID, booked.UserID)
+	assert.Equal(t, createdSlot.ID, booked.SlotID)
+	assert.Equal(t, createdQuote.ID, booked.QuoteID)
+
+	// A quote has a single appointment
+	rec = client.JSON(t, http.MethodPost, "/v1/appointments", map[string]string{
+		"slotId":  createdSlot.ID.String(),
+		"quoteId": createdQuote.ID.String(),
+	})
+	assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+
+	// The proof was stored
+	proof, err := repository.NewPaymentProofRepository(h.DB).GetPaymentProofByQuoteID(context.Background(), createdQuote.ID)
+	require.NoError(t, err)
+	_, err = h.Files.Get(context.Background(), proof.URL)
+	assert.NoError(t, err)
+
+	// The approval reached the client by email through the outbox, the jobs and the email queue
+	require.Eventually(t, func() bool {
+		return len(h.Inbox.EmailsTo(client.User.Email)) > 0
+	}, 10*time.Second, 50*time.Millisecond)
+}
+
+func TestRolesAndAuthentication(t *testing.T) {
+	h := env.New(t)
+
+	rec := h.Anonymous().Get(t, "/v1/quotes/all?limit=10")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// Only admins create slots
+	start := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Hour)
+	body := map[string]string{
+		"startTime": start.Format(time.RFC3339),
+		"endTime":   start.Add(time.Hour).Format(time.RFC3339),
+	}
+	rec = h.AsClient(t).JSON(t, http.MethodPost, "/v1/availabilityslots", body)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = h.AsAdmin(t).JSON(t, http.MethodPost, "/v1/availabilityslots", body)
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// The users built by the harness log in with the factory password
+	client := h.AsClient(t)
+	rec = h.Anonymous().JSON(t, http.MethodPost, "/v1/users/login", map[string]string{
+		"email":    client.User.Email,
+		"password": factory.Password,
+	})
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
@@ -0,0 +1,74 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+
+	"harajuku/backend/internal/core/domain"
+	"harajuku/backend/test/e2e/helpers"
+	"harajuku/backend/test/factory"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type salon struct {
+	ID   uuid.UUID `json:"id"`
+	Slug string    `json:"slug"`
+}
+
+type typeOfService struct {
+	ID uuid.UUID `json:"id"`
+}
+
+func TestSalonsKeepTheirRowsApart(t *testing.T) {
+	h := env.New(t)
+
+	// The admins of the default salon manage the salons
+	slug := "roma-" + uuid.NewString()[:8]
+	rec := h.AsAdmin(t).JSON(t, http.MethodPost, "/v1/salons", map[string]string{"name": "Harajuku Roma", "slug": slug})
+	roma := helpers.Decode[salon](t, rec, http.StatusOK)
+	assert.Equal(t, slug, roma.Slug)
+
+	romaAdmin := h.AsAdmin(t, func(u *domain.User) { u.SalonID = roma.ID })
+	rec = romaAdmin.Get(t, "/v1/salons/all?limit=10")
+	assert.Equal(t, http.StatusForbidden, rec.Code, rec.Body.String())
+
+	// The types of service of the default salon are out of reach from the new one
+	service := factory.NewTypeOfService(t, h.DB)
+	rec = romaAdmin.Get(t, "/v1/typesofservice?id="+service.ID.String())
+	assert.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+
+	rec = romaAdmin.JSON(t, http.MethodPost, "/v1/typesofservice", map[string]any{
+		"name":            "Tinte",
+		"description":     "Tinte completo",
+		"price":           800,
+		"durationMinutes": 90,
+	})
+	own := helpers.Decode[typeOfService](t, rec, http.StatusOK)
+
+	rec = h.AsClient(t).Get(t, "/v1/typesofservice?id="+own.ID.String())
+	assert.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+
+	// Users register in the salon named by the header and log in to it
+	email := uuid.NewString() + "@example.com"
+	rec = h.Anonymous().WithHeader("X-Salon-ID", roma.ID.String()).JSON(t, http.MethodPost, "/v1/users/", map[string]string{
+		"name":     "Ana",
+		"lastName": "López",
+		"email":    email,
+		"password": factory.Password,
+	})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = h.Anonymous().WithHeader("X-Salon-ID", uuid.NewString()).JSON(t, http.MethodPost, "/v1/users/", map[string]string{
+		"name":     "Ana",
+		"lastName": "López",
+		"email":    uuid.NewString() + "@example.com",
+		"password": factory.Password,
+	})
+	assert.Equal(t, http.StatusNotFound, rec.Code, rec.Body.String())
+
+	rec = h.Anonymous().JSON(t, http.MethodPost, "/v1/users/login", map[string]string{"email": email, "password": factory.Password})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+}
@@ -0,0 +1,60 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"harajuku/backend/test/e2e/helpers"
+	"harajuku/backend/test/factory"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staff struct {
+	ID               uuid.UUID   `json:"id"`
+	UserID           uuid.UUID   `json:"userId"`
+	Active           bool        `json:"active"`
+	TypeOfServiceIDs []uuid.UUID `json:"typeOfServiceIds"`
+}
+
+func TestInactiveStylistsGetNoNewWork(t *testing.T) {
+	h := env.New(t)
+	admin := h.AsAdmin(t)
+
+	rec := admin.JSON(t, http.MethodPost, "/v1/staff", map[string]any{
+		"userId":      admin.User.ID.String(),
+		"displayName": "Mariana",
+		"specialties": []string{"balayage"},
+	})
+	created := helpers.Decode[staff](t, rec, http.StatusOK)
+	assert.True(t, created.Active)
+
+	// The services the admin offers are the stylist's
+	service := factory.NewTypeOfService(t, h.DB)
+	rec = admin.JSON(t, http.MethodPost, "/v1/typesofservice/offerings", map[string]string{
+		"typeOfServiceId": service.ID.String(),
+		"adminId":         admin.User.ID.String(),
+	})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = h.AsClient(t).Get(t, "/v1/staff?id="+created.ID.String())
+	shown := helpers.Decode[staff](t, rec, http.StatusOK)
+	assert.Equal(t, []uuid.UUID{service.ID}, shown.TypeOfServiceIDs)
+
+	// Once inactive the stylist is given no new slots
+	rec = admin.JSON(t, http.MethodPut, "/v1/staff?id="+created.ID.String(), map[string]any{
+		"displayName": "Mariana",
+		"active":      false,
+	})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	start := time.Now().UTC().Add(48 * time.Hour).Truncate(time.Hour)
+	rec = admin.JSON(t, http.MethodPost, "/v1/availabilityslots", map[string]string{
+		"startTime": start.Format(time.RFC3339),
+		"endTime":   start.Add(time.Hour).Format(time.RFC3339),
+	})
+	assert.Equal(t, http.StatusConflict, rec.Code, rec.Body.String())
+}
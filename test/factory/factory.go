@@ -0,0 +1,197 @@
+// Package factory builds valid domain objects for tests. Every builder fills the fields with sensible
+// defaults and new IDs, then applies the overrides in order. The references to other entities are new
+// IDs unless overridden and storing the object is left to the caller, while the New factories insert it
+// into postgres along with the rows it references
+package factory
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// Password is the plain password of the users built by User
+const Password = "harajuku123"
+
+// build applies the overrides to v in order
+func build[T any](v *T, overrides []func(*T)) *T {
+	for _, override := range overrides {
+		override(v)
+	}
+	return v
+}
+
+// Salon builds a salon with a unique slug
+func Salon(overrides ...func(*domain.Salon)) *domain.Salon {
+	id := uuid.New()
+
+	return build(&domain.Salon{
+		ID:   id,
+		Name: "Harajuku Roma",
+		Slug: fmt.Sprintf("roma-%s", id),
+	}, overrides)
+}
+
+// User builds a client with a unique email, the password is Password in plain text
+func User(overrides ...func(*domain.User)) *domain.User {
+	id := uuid.New()
+
+	return build(&domain.User{
+		ID:                      id,
+		Name:                    "Kevin",
+		LastName:                "Rodríguez",
+		Email:                   fmt.Sprintf("%s@example.com", id),
+		Password:                Password,
+		Role:                    domain.Client,
+		PreferredLanguage:       domain.DefaultLanguage,
+		NotificationPreferences: domain.DefaultNotificationPreferences(),
+	}, overrides)
+}
+
+// Admin builds a user with the admin role
+func Admin(overrides ...func(*domain.User)) *domain.User {
+	return User(append([]func(*domain.User){WithRole(domain.Admin)}, overrides...)...)
+}
+
+// WithRole sets the role of a user
+func WithRole(role domain.UserRole) func(*domain.User) {
+	return func(u *domain.User) {
+		u.Role = role
+	}
+}
+
+// TypeOfService builds a one hour type of service priced in MXN
+func TypeOfService(overrides ...func(*domain.TypeOfService)) *domain.TypeOfService {
+	return build(&domain.TypeOfService{
+		ID:              uuid.New(),
+		Name:            "Corte y peinado",
+		Description:     "Corte con lavado y secado",
+		Price:           450,
+		Currency:        domain.CurrencyMXN,
+		DurationMinutes: 60,
+	}, overrides)
+}
+
+// Quote builds a pending quote for a new client and type of service
+func Quote(overrides ...func(*domain.Quote)) *domain.Quote {
+	return build(&domain.Quote{
+		ID:              uuid.New(),
+		TypeOfServiceID: uuid.New(),
+		ClientID:        uuid.New(),
+		Time:            time.Now().UTC(),
+		Description:     "Test quote",
+		State:           domain.QuotePending,
+		Price:           100.50,
+		Currency:        domain.CurrencyMXN,
+	}, overrides)
+}
+
+// QuoteIn builds a quote in state
+func QuoteIn(state domain.QuoteState, overrides ...func(*domain.Quote)) *domain.Quote {
+	return Quote(append([]func(*domain.Quote){WithState(state)}, overrides...)...)
+}
+
+// WithState sets the state of a quote
+func WithState(state domain.QuoteState) func(*domain.Quote) {
+	return func(q *domain.Quote) {
+		q.State = state
+	}
+}
+
+// ForClient sets the client of a quote
+func ForClient(clientID uuid.UUID) func(*domain.Quote) {
+	return func(q *domain.Quote) {
+		q.ClientID = clientID
+	}
+}
+
+// AvailabilitySlot builds a free one hour slot of a new admin starting in a day, on the hour
+func AvailabilitySlot(overrides ...func(*domain.AvailabilitySlot)) *domain.AvailabilitySlot {
+	start := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Hour)
+
+	return build(&domain.AvailabilitySlot{
+		ID:        uuid.New(),
+		AdminID:   uuid.New(),
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+	}, overrides)
+}
+
+// StartingAt sets the start of a slot, keeping its length
+func StartingAt(start time.Time) func(*domain.AvailabilitySlot) {
+	return func(s *domain.AvailabilitySlot) {
+		s.StartTime, s.EndTime = start, start.Add(s.EndTime.Sub(s.StartTime))
+	}
+}
+
+// Appointment builds a pending appointment, the client, slot and quote are new IDs unless overridden,
+// use For to take them from a quote and a slot
+func Appointment(overrides ...func(*domain.Appointment)) *domain.Appointment {
+	return build(&domain.Appointment{
+		ID:      uuid.New(),
+		UserID:  uuid.New(),
+		SlotID:  uuid.New(),
+		QuoteID: uuid.New(),
+		Status:  domain.Pending,
+	}, overrides)
+}
+
+// For books an appointment for the quote, and its client, in the slot
+func For(quote *domain.Quote, slot *domain.AvailabilitySlot) func(*domain.Appointment) {
+	return func(a *domain.Appointment) {
+		a.UserID, a.QuoteID, a.SlotID = quote.ClientID, quote.ID, slot.ID
+	}
+}
+
+// WithStatus sets the status of an appointment
+func WithStatus(status domain.AppointmentStatus) func(*domain.Appointment) {
+	return func(a *domain.Appointment) {
+		a.Status = status
+	}
+}
+
+// PaymentProof builds a payment proof waiting for review of a new quote
+func PaymentProof(overrides ...func(*domain.PaymentProof)) *domain.PaymentProof {
+	id := uuid.New()
+
+	return build(&domain.PaymentProof{
+		ID:          id,
+		QuoteID:     uuid.New(),
+		URL:         fmt.Sprintf("payment-proofs/%s.jpg", id),
+		FileName:    "comprobante.jpg",
+		Size:        1024,
+		ContentType: "image/jpeg",
+		Checksum:    fmt.Sprintf("%x", sha256.Sum256(id[:])),
+	}, overrides)
+}
+
+// Staff builds the active profile of a new admin in the staff directory
+func Staff(overrides ...func(*domain.Staff)) *domain.Staff {
+	return build(&domain.Staff{
+		ID:          uuid.New(),
+		UserID:      uuid.New(),
+		DisplayName: "Mariana",
+		Bio:         "Colorista con diez años de experiencia",
+		Specialties: []string{"balayage", "cortes bob"},
+		Active:      true,
+	}, overrides)
+}
+
+// Coupon builds a coupon with a unique code taking 10% off every service, active for the next month
+// and without usage limits
+func Coupon(overrides ...func(*domain.Coupon)) *domain.Coupon {
+	id := uuid.New()
+	now := time.Now().UTC().Truncate(time.Second)
+	return build(&domain.Coupon{
+		ID:           id,
+		Code:         domain.NormalizeCouponCode(fmt.Sprintf("cupon%x", id[:4])),
+		DiscountType: domain.DiscountPercentage,
+		Value:        10,
+		StartsAt:     now.Add(-time.Hour),
+		EndsAt:       now.Add(30 * 24 * time.Hour),
+	}, overrides)
+}
@@ -0,0 +1,186 @@
+package factory
+
+import (
+	"context"
+	"testing"
+
+	"harajuku/backend/internal/adapter/storage/postgres"
+	"harajuku/backend/internal/adapter/storage/postgres/repository"
+	"harajuku/backend/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// The New factories insert an entity built by its builder through its repository and fail the test on
+// error, the overrides are applied before the insert and the referenced rows are created when left empty
+
+// NewSalon inserts a salon with a unique slug
+func NewSalon(t *testing.T, db *postgres.DB, overrides ...func(*domain.Salon)) *domain.Salon {
+	t.Helper()
+
+	salon, err := repository.NewSalonRepository(db).CreateSalon(context.Background(), Salon(overrides...))
+	if err != nil {
+		t.Fatalf("failed to create salon: %v", err)
+	}
+
+	return salon
+}
+
+// NewUser inserts a user with a unique email, the role is set after the insert as users are created as clients
+func NewUser(t *testing.T, db *postgres.DB, overrides ...func(*domain.User)) *domain.User {
+	t.Helper()
+
+	ctx := context.Background()
+	user := User(overrides...)
+
+	repo := repository.NewUserRepository(db)
+	role := user.Role
+
+	user, err := repo.CreateUser(ctx, user)
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	if role != "" && role != user.Role {
+		user.Role = role
+		user, err = repo.UpdateUser(ctx, user)
+		if err != nil {
+			t.Fatalf("failed to set user role: %v", err)
+		}
+	}
+
+	return user
+}
+
+// NewAdmin inserts a user with the admin role
+func NewAdmin(t *testing.T, db *postgres.DB, overrides ...func(*domain.User)) *domain.User {
+	t.Helper()
+
+	return NewUser(t, db, append([]func(*domain.User){WithRole(domain.Admin)}, overrides...)...)
+}
+
+// NewTypeOfService inserts a type of service
+func NewTypeOfService(t *testing.T, db *postgres.DB, overrides ...func(*domain.TypeOfService)) *domain.TypeOfService {
+	t.Helper()
+
+	service, err := repository.NewTypeOfServiceRepository(db).CreateTypeOfService(context.Background(), TypeOfService(overrides...))
+	if err != nil {
+		t.Fatalf("failed to create type of service: %v", err)
+	}
+
+	return service
+}
+
+// NewQuote inserts a pending quote, creating its client and type of service when they aren't set
+func NewQuote(t *testing.T, db *postgres.DB, overrides ...func(*domain.Quote)) *domain.Quote {
+	t.Helper()
+
+	quote := Quote(append([]func(*domain.Quote){func(q *domain.Quote) {
+		q.ClientID, q.TypeOfServiceID = uuid.Nil, uuid.Nil
+	}}, overrides...)...)
+
+	if quote.ClientID == uuid.Nil {
+		quote.ClientID = NewUser(t, db).ID
+	}
+	if quote.TypeOfServiceID == uuid.Nil {
+		quote.TypeOfServiceID = NewTypeOfService(t, db).ID
+	}
+
+	quote, err := repository.NewQuoteRepository(db).CreateQuote(context.Background(), quote)
+	if err != nil {
+		t.Fatalf("failed to create quote: %v", err)
+	}
+
+	return quote
+}
+
+// NewAvailabilitySlot inserts a free one hour slot starting in a day, creating its admin when it isn't set
+func NewAvailabilitySlot(t *testing.T, db *postgres.DB, overrides ...func(*domain.AvailabilitySlot)) *domain.AvailabilitySlot {
+	t.Helper()
+
+	slot := AvailabilitySlot(append([]func(*domain.AvailabilitySlot){func(s *domain.AvailabilitySlot) {
+		s.AdminID = uuid.Nil
+	}}, overrides...)...)
+
+	if slot.AdminID == uuid.Nil {
+		slot.AdminID = NewAdmin(t, db).ID
+	}
+
+	slot, err := repository.NewAvailabilitySlotRepository(db).CreateAvailabilitySlot(context.Background(), slot)
+	if err != nil {
+		t.Fatalf("failed to create availability slot: %v", err)
+	}
+
+	return slot
+}
+
+// NewAppointment inserts a pending appointment, creating its quote and slot when they aren't set.
+// The client is the one of the quote unless it is set
+func NewAppointment(t *testing.T, db *postgres.DB, overrides ...func(*domain.Appointment)) *domain.Appointment {
+	t.Helper()
+
+	appointment := Appointment(append([]func(*domain.Appointment){func(a *domain.Appointment) {
+		a.UserID, a.SlotID, a.QuoteID = uuid.Nil, uuid.Nil, uuid.Nil
+	}}, overrides...)...)
+
+	if appointment.QuoteID == uuid.Nil {
+		quote := NewQuote(t, db, func(q *domain.Quote) {
+			if appointment.UserID != uuid.Nil {
+				q.ClientID = appointment.UserID
+			}
+		})
+		appointment.QuoteID = quote.ID
+		if appointment.UserID == uuid.Nil {
+			appointment.UserID = quote.ClientID
+		}
+	}
+	if appointment.UserID == uuid.Nil {
+		quote, err := repository.NewQuoteRepository(db).GetQuoteByID(context.Background(), appointment.QuoteID)
+		if err != nil {
+			t.Fatalf("failed to get the quote of the appointment: %v", err)
+		}
+		appointment.UserID = quote.ClientID
+	}
+	if appointment.SlotID == uuid.Nil {
+		appointment.SlotID = NewAvailabilitySlot(t, db).ID
+	}
+
+	appointment, err := repository.NewAppointmentRepository(db).CreateAppointment(context.Background(), appointment)
+	if err != nil {
+		t.Fatalf("failed to create appointment: %v", err)
+	}
+
+	return appointment
+}
+
+// NewStaff inserts an active staff member, creating its admin when it isn't set
+func NewStaff(t *testing.T, db *postgres.DB, overrides ...func(*domain.Staff)) *domain.Staff {
+	t.Helper()
+
+	staff := Staff(append([]func(*domain.Staff){func(s *domain.Staff) {
+		s.UserID = uuid.Nil
+	}}, overrides...)...)
+
+	if staff.UserID == uuid.Nil {
+		staff.UserID = NewAdmin(t, db).ID
+	}
+
+	staff, err := repository.NewStaffRepository(db).CreateStaff(context.Background(), staff)
+	if err != nil {
+		t.Fatalf("failed to create staff member: %v", err)
+	}
+
+	return staff
+}
+
+// NewCoupon inserts a coupon with a unique code
+func NewCoupon(t *testing.T, db *postgres.DB, overrides ...func(*domain.Coupon)) *domain.Coupon {
+	t.Helper()
+
+	coupon, err := repository.NewCouponRepository(db).CreateCoupon(context.Background(), Coupon(overrides...))
+	if err != nil {
+		t.Fatalf("failed to create coupon: %v", err)
+	}
+
+	return coupon
+}